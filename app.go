@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/config"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/events"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/health"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/prompts"
+	"spacetraders-mcp/pkg/resources"
+	"spacetraders-mcp/pkg/session"
+	"spacetraders-mcp/pkg/shiplock"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/shipwatch"
+	"spacetraders-mcp/pkg/stations"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/telemetry"
+	"spacetraders-mcp/pkg/timeouts"
+	"spacetraders-mcp/pkg/tools"
+	"spacetraders-mcp/pkg/version"
+	"spacetraders-mcp/pkg/webhook"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// app is the MCP server with every resource, tool and prompt registered, and the background
+// work it owns. Both the stdio server and the command line subcommands run on it.
+type app struct {
+	server    *server.MCPServer
+	client    *client.Client
+	logger    *logging.Logger
+	stations  *stations.Poller
+	shipWatch *shipwatch.Watcher
+	fleet     *fleetstate.Model
+	tasks     *tasks.Manager
+	prices    *prices.DB
+
+	// checkpointFile is where in-flight state is saved on Close once resumeCheckpoint has run
+	checkpointFile string
+	checkpointing  bool
+	// resumeTasks restarts the checkpoint's tasks; otherwise they are only logged
+	resumeTasks bool
+
+	stopTasks       context.CancelFunc
+	shutdownTracing func(context.Context) error
+	errorLogger     *log.Logger
+}
+
+// newApp wires the client, its observers and the registries into an MCP server. It fails when
+// tracing can't be set up or the API rejects the token.
+func newApp(cfg *config.Config, errorLogger *log.Logger) (*app, error) {
+	// Export traces of tool and resource requests when a collector is configured
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.TracingEndpoint, version.Get().Version)
+	if err != nil {
+		return nil, fmt.Errorf("tracing setup error: %w", err)
+	}
+
+	// Create SpaceTraders client
+	spacetradersClient := client.NewClient(cfg.SpaceTradersAPIToken)
+	if cfg.APIBaseURL != "" {
+		spacetradersClient = client.NewClientWithBaseURL(cfg.SpaceTradersAPIToken, cfg.APIBaseURL)
+	}
+	spacetradersClient.SetCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown)
+	spacetradersClient.SetSecondaryTokens(cfg.SecondaryTokens)
+
+	// Record every transaction the client observes into the ledger
+	transactionLedger := ledger.New()
+	spacetradersClient.AddObserver(transactionLedger.Observe)
+
+	// Keep a history of every market price the client sees
+	priceDB := prices.New()
+	spacetradersClient.AddObserver(priceDB.Observe)
+
+	// Sample the credit balance from every response that carries the agent
+	creditsHistory := credits.New()
+	spacetradersClient.AddObserver(creditsHistory.Observe)
+
+	// Record extraction yields; ships whose location was not observed are looked up once
+	miningRecorder := mining.NewRecorder().WithLocator(func(shipSymbol string) (string, error) {
+		nav, err := spacetradersClient.GetShipNav(shipSymbol)
+		if err != nil {
+			return "", err
+		}
+		return nav.WaypointSymbol, nil
+	})
+	spacetradersClient.AddObserver(miningRecorder.Observe)
+
+	// Remember the cooldown each action puts a ship on
+	cooldownTracker := cooldowns.NewTracker()
+	spacetradersClient.AddObserver(cooldownTracker.Observe)
+
+	// Keep ship condition events, which the API only reports once
+	eventLog := events.New()
+	spacetradersClient.AddObserver(eventLog.Observe)
+
+	// Remember what changes during this session for the session summary
+	sessionRecorder := session.NewRecorder()
+	spacetradersClient.AddObserver(sessionRecorder.Observe)
+
+	// Enforce the configured spending limits, counting what is spent while the server runs
+	spendingPolicy := policy.New(policy.Limits{
+		MaxPurchase:     cfg.MaxPurchase,
+		ReserveFloor:    cfg.ReserveCredits,
+		SessionSpendCap: cfg.SessionSpendCap,
+	})
+	spacetradersClient.AddObserver(spendingPolicy.Observe)
+
+	// Remember explored systems and waypoints across sessions
+	explorationTracker, err := explorer.Open(cfg.ExplorationFile)
+	if err != nil {
+		errorLogger.Printf("Exploration progress error, starting fresh: %v", err)
+		explorationTracker, _ = explorer.Open("")
+	}
+	spacetradersClient.AddObserver(explorationTracker.Observe)
+
+	// Remember the labels, notes and tags given to ships across sessions
+	shipMeta, err := shipmeta.Open(cfg.ShipMetadataFile)
+	if err != nil {
+		errorLogger.Printf("Ship metadata error, starting fresh: %v", err)
+		shipMeta, _ = shipmeta.Open("")
+	}
+
+	// Hooks are filled in once the logger exists
+	hooks := &server.Hooks{}
+
+	// Completions read the fleet model once it exists
+	resourceCompletions := resources.NewCompletionProvider(spacetradersClient)
+	promptCompletions := prompts.NewCompletionProvider(spacetradersClient)
+
+	// Give every tool call and resource read a deadline, longer for reads of the whole universe
+	requestTimeouts := timeouts.New(cfg.Timeout, cfg.TimeoutOverrides)
+
+	// Create MCP server with resource and logging capabilities
+	s := server.NewMCPServer(
+		"SpaceTraders MCP Server",
+		version.Get().String(),
+		server.WithResourceCapabilities(false, false), // subscribe=false, listChanged=false
+		server.WithLogging(),                          // Enable MCP logging support
+		server.WithElicitation(),                      // Ask the user to confirm irreversible or expensive actions
+		server.WithCompletions(),                      // Suggest values for resource template and prompt parameters
+		server.WithResourceCompletionProvider(resourceCompletions),
+		server.WithPromptCompletionProvider(promptCompletions),
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(telemetry.ToolMiddleware()),
+		server.WithToolHandlerMiddleware(requestTimeouts.ToolMiddleware()),
+		server.WithResourceHandlerMiddleware(telemetry.ResourceMiddleware()),
+		server.WithResourceHandlerMiddleware(requestTimeouts.ResourceMiddleware()),
+	)
+
+	// Create application logger
+	appLogger := logging.NewLogger(s)
+
+	// Only send the client log messages at or above the level it asks for
+	hooks.AddAfterSetLevel(func(ctx context.Context, id any, message *mcp.SetLevelRequest, result *mcp.EmptyResult) {
+		level := logging.LevelFromMCP(message.Params.Level)
+		appLogger.SetLevel(level)
+		errorLogger.Printf("Client set logging level to %s", level)
+	})
+
+	// Fail fast on a rejected token rather than on the first resource read. Other failures
+	// are only logged, since the API may come back while the server is running.
+	if !cfg.SkipTokenCheck {
+		agent, err := health.ValidateToken(context.Background(), spacetradersClient, health.DefaultTimeout)
+		switch {
+		case errors.Is(err, health.ErrTokenRejected):
+			_ = shutdownTracing(context.Background())
+			return nil, fmt.Errorf("token validation failed: %w", err)
+		case err != nil:
+			appLogger.Warn("Could not validate token, continuing anyway: %v", err)
+		default:
+			appLogger.Info("Authenticated as agent %s with %d credits", agent.Symbol, agent.Credits)
+			sessionRecorder.SetStartingCredits(agent.Credits)
+
+			// Forget exploration progress from another agent or an earlier universe
+			statusCtx, cancel := context.WithTimeout(context.Background(), health.DefaultTimeout)
+			resetDate := ""
+			if status, err := spacetradersClient.WithContext(statusCtx).GetServerStatus(); err == nil {
+				resetDate = status.ResetDate
+			}
+			cancel()
+			explorationTracker.Bind(agent.Symbol, resetDate)
+		}
+	}
+
+	// Background tasks run until the server shuts down
+	taskCtx, stopTasks := context.WithCancel(context.Background())
+	// Actions on one ship, from tool calls or task steps, take turns; different ships run in parallel
+	shipLocks := shiplock.New()
+	taskManager := tasks.NewManager(taskCtx, spacetradersClient, appLogger).WithLocks(shipLocks).WithPolicy(spendingPolicy)
+	// Refresh markets and shipyards where ships are stationed, telling clients the resources
+	// changed; polling starts once the server is serving
+	stationPoller := stations.NewPoller(taskCtx, spacetradersClient, appLogger).
+		WatchFleet(cfg.PollStationedShips).
+		OnUpdate(func(uri string) {
+			s.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		})
+
+	// Record ship prices at every shipyard seen and check watched shipyards; checks start once
+	// the server is serving
+	shipWatcher := shipwatch.New(taskCtx, spacetradersClient, appLogger)
+	spacetradersClient.AddObserver(shipWatcher.Observe)
+
+	// Apply the result of every action to a local model of the fleet, reconciled with the API
+	// once the server is serving, so ship and agent reads rarely need a round trip
+	fleetState := fleetstate.New(taskCtx, spacetradersClient, appLogger)
+	spacetradersClient.AddObserver(fleetState.Observe)
+	resourceCompletions.WithFleetState(fleetState)
+	promptCompletions.WithFleetState(fleetState)
+
+	// Post alerts to a webhook so the user hears about them even when no client is attached
+	if cfg.WebhookURL != "" {
+		notifier := webhook.New(taskCtx, cfg.WebhookURL, appLogger).WithLowCredits(int64(cfg.LowCreditsAlert))
+		spacetradersClient.AddObserver(notifier.Observe)
+		taskManager.OnFinish(notifier.TaskFinished)
+		shipWatcher.OnAlert(func(alert shipwatch.Alert) {
+			notifier.Send(alert.Message())
+		})
+		appLogger.Info("Posting alerts to the configured webhook")
+	}
+
+	// Register all resources
+	resourceRegistry := resources.NewRegistry(spacetradersClient, appLogger,
+		resources.WithLedger(transactionLedger),
+		resources.WithTasks(taskManager),
+		resources.WithExplorer(explorationTracker),
+		resources.WithMining(miningRecorder),
+		resources.WithSession(sessionRecorder),
+		resources.WithEvents(eventLog),
+		resources.WithShipMeta(shipMeta),
+		resources.WithCooldowns(cooldownTracker),
+		resources.WithFleetState(fleetState),
+		resources.WithCredits(creditsHistory),
+		resources.WithPrices(priceDB),
+	)
+	resourceRegistry.RegisterWithServer(s)
+
+	// Register all tools (when we have them)
+	toolRegistry := tools.NewRegistry(spacetradersClient, appLogger,
+		tools.WithLedger(transactionLedger),
+		tools.WithTasks(taskManager),
+		tools.WithShipLocks(shipLocks),
+		tools.WithExplorer(explorationTracker),
+		tools.WithStations(stationPoller),
+		tools.WithPrices(priceDB),
+		tools.WithShipWatch(shipWatcher),
+		tools.WithMining(miningRecorder),
+		tools.WithAutoRefuel(cfg.AutoRefuel),
+		tools.WithAutoCorrectState(cfg.AutoCorrectState),
+		tools.WithConfirmSpendAbove(cfg.ConfirmSpendAbove),
+		tools.WithPolicy(spendingPolicy),
+		tools.WithShipMeta(shipMeta),
+		tools.WithCooldowns(cooldownTracker),
+		tools.WithCredits(creditsHistory),
+		tools.WithExportDir(cfg.ExportDir),
+	)
+	toolRegistry.RegisterWithServer(s)
+
+	// Register prompts to help guide user interactions
+	promptRegistry := prompts.NewRegistry(spacetradersClient, appLogger)
+	promptRegistry.RegisterWithServer(s)
+
+	return &app{
+		server:          s,
+		client:          spacetradersClient,
+		logger:          appLogger,
+		stations:        stationPoller,
+		shipWatch:       shipWatcher,
+		fleet:           fleetState,
+		tasks:           taskManager,
+		prices:          priceDB,
+		checkpointFile:  cfg.CheckpointFile,
+		resumeTasks:     cfg.ResumeTasks,
+		stopTasks:       stopTasks,
+		shutdownTracing: shutdownTracing,
+		errorLogger:     errorLogger,
+	}, nil
+}
+
+// Close stops background tasks, checkpointing them when serving, and flushes traces
+func (a *app) Close() {
+	// Let task steps wind down first, so the checkpoint sees the tasks as they were left
+	a.tasks.Stop()
+	a.saveCheckpoint()
+	a.stopTasks()
+	if err := a.shutdownTracing(context.Background()); err != nil {
+		a.errorLogger.Printf("Tracing shutdown error: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"spacetraders-mcp/pkg/snapshot"
+	"spacetraders-mcp/pkg/version"
+)
+
+// maxResumeAge is how old a checkpoint's tasks may be and still be resumed. After longer the
+// user has likely moved on, so the tasks are only logged.
+const maxResumeAge = 24 * time.Hour
+
+// resumeCheckpoint loads the checkpoint saved when the server last stopped: the price database
+// straight away, and, when resuming is turned on, its running tasks once each ship has been
+// checked against the API. From then on Close saves a new checkpoint. One-off commands don't call it, so they neither resume
+// tasks nor replace the checkpoint.
+func (a *app) resumeCheckpoint() {
+	if a.checkpointFile == "" {
+		return
+	}
+	a.checkpointing = true
+
+	report, err := snapshot.RestoreFile(a.checkpointFile, snapshot.State{Prices: a.prices}, false)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		a.logger.Warn("Could not read the checkpoint %s, starting without it: %v", a.checkpointFile, err)
+		return
+	}
+	// The checkpoint is used up, so a crash that skips the next save doesn't resume the same tasks again
+	if err := os.Remove(a.checkpointFile); err != nil {
+		a.logger.Warn("Could not remove the checkpoint %s: %v", a.checkpointFile, err)
+	}
+
+	savedAt := report.Manifest.CreatedAt
+	a.logger.Info("Restored %d market price snapshots from the checkpoint saved at %s", report.Restored[snapshot.PartPrices], savedAt.Format(time.RFC3339))
+	if len(report.Tasks) == 0 {
+		return
+	}
+	// Restarting tasks spends credits and moves ships without anyone asking, so it is opt-in
+	if !a.resumeTasks {
+		for _, spec := range report.Tasks {
+			a.logger.Info("Not resuming %s on %s: set SPACETRADERS_RESUME_TASKS=true to resume checkpointed tasks", spec.Behavior, spec.ShipSymbol)
+		}
+		return
+	}
+	if age := time.Since(savedAt); age > maxResumeAge {
+		for _, spec := range report.Tasks {
+			a.logger.Warn("Not resuming %s on %s: the checkpoint is %s old", spec.Behavior, spec.ShipSymbol, age.Round(time.Minute))
+		}
+		return
+	}
+
+	// Checking ships takes API calls, so it happens while the server starts serving
+	go func() {
+		for _, spec := range report.Tasks {
+			if _, err := a.tasks.Resume(spec.ShipSymbol, spec.Behavior, spec.Params); err != nil {
+				a.logger.Warn("Not resuming %s on %s: %v", spec.Behavior, spec.ShipSymbol, err)
+			}
+		}
+	}()
+}
+
+// saveCheckpoint saves the price database and the tasks still running, for resumeCheckpoint
+// on the next start
+func (a *app) saveCheckpoint() {
+	if !a.checkpointing {
+		return
+	}
+	manifest, err := snapshot.WriteFile(a.checkpointFile, snapshot.State{Prices: a.prices, Tasks: a.tasks}, version.Get().Version, time.Now())
+	if err != nil {
+		a.errorLogger.Printf("Checkpoint error, running tasks won't be resumed: %v", err)
+		return
+	}
+	a.logger.Info("Checkpointed %d running tasks and %d market price snapshots to %s",
+		manifest.Counts[snapshot.PartTasks], manifest.Counts[snapshot.PartPrices], a.checkpointFile)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"spacetraders-mcp/pkg/config"
+	"spacetraders-mcp/pkg/version"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Exit codes for the command line subcommands
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+const usage = `Usage:
+  spacetraders-mcp                                  Serve MCP over stdio
+  spacetraders-mcp call <tool> [--args '{...}'] [--json]
+                                                    Call a tool and print its result
+  spacetraders-mcp read <uri>                       Read a resource and print its contents
+  spacetraders-mcp --version                        Show the version and build
+  spacetraders-mcp help                             Show this help
+
+The subcommands use the same configuration as the server. --args takes the tool's arguments as a
+JSON object, or - to read them from stdin. --json prints the whole tool result as JSON instead of
+its text. The exit status is 1 when the tool or resource reports an error.
+`
+
+// errUsage marks a mistake in the command line itself
+var errUsage = errors.New("usage")
+
+// runCommand runs a command line subcommand against an in-process server and returns the exit
+// status. Results are written to stdout; logs go to stderr as usual.
+func runCommand(args []string, stdout io.Writer, errorLogger *log.Logger) int {
+	var command func(ctx context.Context, c *mcpclient.Client) error
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	case "version", "-version", "--version":
+		build := version.Get()
+		fmt.Fprintf(stdout, "spacetraders-mcp %s\n", build)
+		if build.Date != "" {
+			fmt.Fprintf(stdout, "built %s with %s for %s\n", build.Date, build.GoVersion, build.Platform)
+		} else {
+			fmt.Fprintf(stdout, "built with %s for %s\n", build.GoVersion, build.Platform)
+		}
+		return exitOK
+	case "call":
+		tool, arguments, asJSON, err := parseCallArgs(args[1:], os.Stdin)
+		if err != nil {
+			errorLogger.Printf("%v", err)
+			fmt.Fprint(os.Stderr, usage)
+			return exitUsage
+		}
+		command = func(ctx context.Context, c *mcpclient.Client) error {
+			return callTool(ctx, c, tool, arguments, asJSON, stdout)
+		}
+	case "read":
+		if len(args) != 2 || args[1] == "" {
+			errorLogger.Printf("read takes exactly one resource URI")
+			fmt.Fprint(os.Stderr, usage)
+			return exitUsage
+		}
+		command = func(ctx context.Context, c *mcpclient.Client) error {
+			return readResource(ctx, c, args[1], stdout)
+		}
+	default:
+		errorLogger.Printf("Unknown command %q", args[0])
+		fmt.Fprint(os.Stderr, usage)
+		return exitUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		errorLogger.Printf("Configuration error: %v", err)
+		return exitError
+	}
+	a, err := newApp(cfg, errorLogger)
+	if err != nil {
+		errorLogger.Printf("Startup error: %v", err)
+		return exitError
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	c, err := mcpclient.NewInProcessClient(a.server)
+	if err != nil {
+		errorLogger.Printf("Failed to create in-process client: %v", err)
+		return exitError
+	}
+	defer c.Close()
+	if err := c.Start(ctx); err != nil {
+		errorLogger.Printf("Failed to start in-process client: %v", err)
+		return exitError
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "spacetraders-mcp-cli", Version: version.Get().Version}
+	if _, err := c.Initialize(ctx, initRequest); err != nil {
+		errorLogger.Printf("Failed to initialize in-process client: %v", err)
+		return exitError
+	}
+
+	if err := command(ctx, c); err != nil {
+		errorLogger.Printf("%v", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// parseCallArgs reads the tool name and its flags, which may come before or after the name.
+// Arguments given as - are read from stdin.
+func parseCallArgs(args []string, stdin io.Reader) (string, map[string]interface{}, bool, error) {
+	flags := flag.NewFlagSet("call", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	rawArgs := flags.String("args", "", "tool arguments as a JSON object")
+	asJSON := flags.Bool("json", false, "print the whole result as JSON")
+
+	if err := flags.Parse(args); err != nil {
+		return "", nil, false, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() == 0 {
+		return "", nil, false, fmt.Errorf("%w: call needs a tool name", errUsage)
+	}
+	tool := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return "", nil, false, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() > 0 {
+		return "", nil, false, fmt.Errorf("%w: unexpected arguments %s; pass tool arguments with --args", errUsage, strings.Join(flags.Args(), " "))
+	}
+
+	raw := *rawArgs
+	if raw == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", nil, false, fmt.Errorf("failed to read arguments from stdin: %w", err)
+		}
+		raw = string(data)
+	}
+	arguments := map[string]interface{}{}
+	if strings.TrimSpace(raw) != "" {
+		if err := json.Unmarshal([]byte(raw), &arguments); err != nil {
+			return "", nil, false, fmt.Errorf("%w: --args must be a JSON object: %v", errUsage, err)
+		}
+	}
+	return tool, arguments, *asJSON, nil
+}
+
+// callTool calls a tool and prints its text, or the whole result as JSON
+func callTool(ctx context.Context, c *mcpclient.Client, tool string, arguments map[string]interface{}, asJSON bool, stdout io.Writer) error {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool
+	request.Params.Arguments = arguments
+	result, err := c.CallTool(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", tool, err)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format the result of %s: %w", tool, err)
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else {
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				fmt.Fprintln(stdout, text.Text)
+			}
+		}
+	}
+
+	if result.IsError {
+		return fmt.Errorf("%s reported an error", tool)
+	}
+	return nil
+}
+
+// readResource reads a resource and prints its contents. Resources answer errors with plain
+// text, so plain text counts as a failure.
+func readResource(ctx context.Context, c *mcpclient.Client, uri string, stdout io.Writer) error {
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	result, err := c.ReadResource(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+
+	failed := false
+	for _, content := range result.Contents {
+		if text, ok := content.(mcp.TextResourceContents); ok {
+			fmt.Fprintln(stdout, text.Text)
+			failed = failed || text.MIMEType == "text/plain"
+		}
+	}
+	if failed {
+		return fmt.Errorf("reading %s failed", uri)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is how many API failures in a row open the circuit of an endpoint class
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerCooldown is how long an open circuit rejects calls before letting one through
+	// to probe whether the API has recovered
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// Circuit states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// ErrUpstreamDegraded is wrapped by every error returned for a call the circuit breaker rejected
+var ErrUpstreamDegraded = errors.New("upstream degraded")
+
+// CircuitOpenError is returned instead of calling the API while an endpoint class's circuit is open
+type CircuitOpenError struct {
+	Class     string
+	Failures  int
+	LastError string
+	RetryAt   time.Time
+}
+
+// Error explains why the call was not made and when calls resume
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("upstream degraded: the last %d %s requests to the SpaceTraders API failed (%s), so %s calls are paused until %s",
+		e.Failures, e.Class, e.LastError, e.Class, e.RetryAt.UTC().Format(time.RFC3339))
+}
+
+// Unwrap lets callers match the error with errors.Is(err, ErrUpstreamDegraded)
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrUpstreamDegraded
+}
+
+// CircuitStatus is the breaker state of one endpoint class
+type CircuitStatus struct {
+	Class               string     `json:"class"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	RetryAt             *time.Time `json:"retryAt,omitempty"`
+	// Rejected counts the calls short-circuited since the circuit last opened
+	Rejected int `json:"rejected"`
+}
+
+// circuit tracks the failures of one endpoint class
+type circuit struct {
+	failures  int
+	lastError string
+	openedAt  time.Time
+	probing   bool
+	rejected  int
+}
+
+// breaker keeps a circuit per endpoint class. A class's circuit opens after threshold failures
+// in a row, rejects calls for cooldown, then lets a single probe through: success closes it,
+// failure opens it again.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	circuits  map[string]*circuit
+	now       func() time.Time
+}
+
+// SetCircuitBreaker sets how many API failures in a row open an endpoint class's circuit and how
+// long it stays open before a probe. A threshold of 0 turns the breaker off.
+func (c *Client) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	c.breaker.threshold = threshold
+	c.breaker.cooldown = cooldown
+	c.breaker.circuits = nil
+}
+
+// Circuits returns the state of every endpoint class that has failed since it last succeeded,
+// sorted by class. Classes that are working normally are left out.
+func (c *Client) Circuits() []CircuitStatus {
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+
+	statuses := make([]CircuitStatus, 0, len(c.breaker.circuits))
+	for class, circuit := range c.breaker.circuits {
+		status := CircuitStatus{
+			Class:               class,
+			State:               c.breaker.state(circuit),
+			ConsecutiveFailures: circuit.failures,
+			LastError:           circuit.lastError,
+			Rejected:            circuit.rejected,
+		}
+		if !circuit.openedAt.IsZero() {
+			openedAt, retryAt := circuit.openedAt, circuit.openedAt.Add(c.breaker.cooldown)
+			status.OpenedAt, status.RetryAt = &openedAt, &retryAt
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Class < statuses[j].Class
+	})
+	return statuses
+}
+
+// state names the state of a circuit; the caller holds the lock
+func (b *breaker) state(c *circuit) string {
+	switch {
+	case c.openedAt.IsZero():
+		return CircuitClosed
+	case c.probing || !b.now().Before(c.openedAt.Add(b.cooldown)):
+		return CircuitHalfOpen
+	default:
+		return CircuitOpen
+	}
+}
+
+// allow reports whether a call to class may go ahead, returning the error to fail it with when
+// it may not. A call let through an expired open circuit becomes its probe.
+func (b *breaker) allow(class string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[class]
+	if b.threshold <= 0 || c == nil || c.openedAt.IsZero() {
+		return nil
+	}
+	retryAt := c.openedAt.Add(b.cooldown)
+	if !c.probing && !b.now().Before(retryAt) {
+		c.probing = true
+		return nil
+	}
+	c.rejected++
+	return &CircuitOpenError{Class: class, Failures: c.failures, LastError: c.lastError, RetryAt: retryAt}
+}
+
+// record updates the circuit of class with the outcome of a call: failure is nil for a success
+func (b *breaker) record(class string, failure error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 {
+		return
+	}
+	if failure == nil {
+		// The API answered, so the class is healthy again
+		delete(b.circuits, class)
+		return
+	}
+
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+	c := b.circuits[class]
+	if c == nil {
+		c = &circuit{}
+		b.circuits[class] = c
+	}
+	c.failures++
+	c.lastError = failure.Error()
+	// A failed probe restarts the cooldown, as does reaching the threshold
+	if c.probing || (c.openedAt.IsZero() && c.failures >= b.threshold) {
+		c.openedAt = b.now()
+		c.probing = false
+		c.rejected = 0
+	}
+}
+
+// release gives up a probe whose outcome says nothing about the API, such as a call the caller
+// cancelled, so the next call probes instead
+func (b *breaker) release(class string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c := b.circuits[class]; c != nil {
+		c.probing = false
+	}
+}
+
+// endpointClass groups API paths the way the API documentation does, so a failing part of the
+// API doesn't stop calls to the rest: fleet, agents, contracts, systems, factions, data or global
+func endpointClass(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		switch segment {
+		case "my":
+			if i+1 < len(segments) {
+				switch segments[i+1] {
+				case "ships":
+					return "fleet"
+				case "agent":
+					return "agents"
+				case "contracts":
+					return "contracts"
+				}
+			}
+			return "agents"
+		case "systems":
+			return "systems"
+		case "factions":
+			return "factions"
+		case "agents":
+			return "agents"
+		case "market":
+			return "data"
+		}
+	}
+	return "global"
+}
+
+// breakerTransport fails calls to endpoint classes whose circuit is open without sending them,
+// and records whether the calls it sends fail: a network error or a 5xx response
+type breakerTransport struct {
+	base  http.RoundTripper
+	state *clientState
+}
+
+// RoundTrip sends the request unless its endpoint class's circuit is open
+func (t breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	class := endpointClass(req.URL.Path)
+	if err := t.state.breaker.allow(class); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// The caller gave up; that says nothing about the API
+		t.state.breaker.release(class)
+	case err != nil:
+		t.state.breaker.record(class, err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.state.breaker.record(class, fmt.Errorf("%s", resp.Status))
+	default:
+		t.state.breaker.record(class, nil)
+	}
+	return resp, err
+}
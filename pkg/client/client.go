@@ -4,16 +4,53 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"spacetraders-mcp/pkg/telemetry"
+
 	spacetraders "github.com/grantmd/spacetraders-mcp/spacetraders"
 )
 
-// Client wraps the generated OpenAPI client to provide a compatible interface
-// with the existing manual client while fixing type issues like reactor integrity.
+// MaxPageLimit is the most items the API returns in one page of a list endpoint
+const MaxPageLimit = 20
+
+// Client wraps the generated OpenAPI client, converting its responses into the plain types
+// the rest of the server uses and fixing type issues like fractional component integrity.
 type Client struct {
 	apiClient *spacetraders.APIClient
 	ctx       context.Context
+
+	*clientState
+}
+
+// clientState is shared by a client and every copy made with WithContext
+type clientState struct {
+	observersMu sync.RWMutex
+	observers   []Observer
+
+	waypointCacheMu sync.Mutex
+	waypointCache   map[string]cachedWaypoints
+
+	jumpGateCacheMu sync.Mutex
+	jumpGateCache   map[string]cachedJumpGate
+
+	marketListingCacheMu sync.Mutex
+	marketListingCache   map[string]cachedMarketListing
+
+	shipyardCacheMu sync.Mutex
+	shipyardCache   map[string]ShipyardListing
+
+	supplyChainMu        sync.Mutex
+	supplyChain          map[string][]string
+	supplyChainFetchedAt time.Time
+
+	rateLimitMu sync.Mutex
+	rateLimit   RateLimitStatus
+
+	breaker breaker
+
+	tokens tokenPool
 }
 
 // NewClient creates a new SpaceTraders client using the generated OpenAPI client
@@ -28,13 +65,41 @@ func NewClientWithBaseURL(apiToken, baseURL string) *Client {
 	cfg.Servers = []spacetraders.ServerConfiguration{
 		{URL: baseURL},
 	}
+	state := &clientState{
+		breaker: breaker{threshold: DefaultBreakerThreshold, cooldown: DefaultBreakerCooldown, now: time.Now},
+	}
 	cfg.HTTPClient = &http.Client{
 		Timeout: 30 * time.Second,
+		Transport: breakerTransport{
+			base: tokenPoolTransport{
+				primary: rateLimitTransport{
+					base:  telemetry.Transport(http.DefaultTransport),
+					state: state,
+				},
+				secondary: telemetry.Transport(http.DefaultTransport),
+				state:     state,
+			},
+			state: state,
+		},
+	}
+
+	return &Client{
+		apiClient:   spacetraders.NewAPIClient(cfg),
+		ctx:         context.Background(),
+		clientState: state,
 	}
+}
 
+// WithContext returns a copy of the client whose API calls use ctx, so cancelling ctx
+// aborts in-flight requests and pagination. Observers and caches are shared with c.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return &Client{
-		apiClient: spacetraders.NewAPIClient(cfg),
-		ctx:       context.Background(),
+		apiClient:   c.apiClient,
+		ctx:         ctx,
+		clientState: c.clientState,
 	}
 }
 
@@ -45,19 +110,28 @@ func (c *Client) GetAgent() (*Agent, error) {
 		return nil, fmt.Errorf("failed to get agent: %w", err)
 	}
 
-	return &Agent{
-		AccountID:       resp.Data.AccountId,
-		Symbol:          resp.Data.Symbol,
-		Headquarters:    resp.Data.Headquarters,
-		Credits:         resp.Data.Credits,
-		StartingFaction: resp.Data.StartingFaction,
-		ShipCount:       int(resp.Data.ShipCount),
-	}, nil
+	agent := convertAgentFromGenerated(resp.Data)
+	c.notifyAgent(agent)
+
+	return &agent, nil
+}
+
+// GetMyAccount returns the account the API token belongs to
+func (c *Client) GetMyAccount() (*Account, error) {
+	var resp struct {
+		Data struct {
+			Account Account `json:"account"`
+		} `json:"data"`
+	}
+	if err := c.getJSON("/my/account", &resp); err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	return &resp.Data.Account, nil
 }
 
 // GetAllShips returns all ships for the agent
 func (c *Client) GetAllShips() ([]Ship, error) {
-	var allShips []Ship
+	allShips := make([]Ship, 0)
 	page := int32(1)
 	limit := int32(20)
 
@@ -68,21 +142,7 @@ func (c *Client) GetAllShips() ([]Ship, error) {
 		}
 
 		for _, ship := range resp.Data {
-			convertedShip := Ship{
-				Symbol:       ship.Symbol,
-				Registration: convertRegistration(ship.Registration),
-				Nav:          convertNavigation(ship.Nav),
-				Crew:         convertCrew(ship.Crew),
-				Frame:        convertFrame(ship.Frame),
-				Reactor:      convertReactor(ship.Reactor),
-				Engine:       convertEngine(ship.Engine),
-				Cooldown:     convertCooldown(ship.Cooldown),
-				Modules:      convertModules(ship.Modules),
-				Mounts:       convertMounts(ship.Mounts),
-				Cargo:        convertCargo(ship.Cargo),
-				Fuel:         convertFuel(ship.Fuel),
-			}
-			allShips = append(allShips, convertedShip)
+			allShips = append(allShips, convertShipFromGenerated(ship))
 		}
 
 		// Check if we have more pages
@@ -91,6 +151,10 @@ func (c *Client) GetAllShips() ([]Ship, error) {
 		}
 		page++
 	}
+	c.notify(Observation{
+		Kind:  ObservedFleet,
+		Ships: allShips,
+	})
 
 	return allShips, nil
 }
@@ -102,46 +166,61 @@ func (c *Client) GetShip(shipSymbol string) (*Ship, error) {
 		return nil, fmt.Errorf("failed to get ship: %w", err)
 	}
 
-	ship := Ship{
-		Symbol:       resp.Data.Symbol,
-		Registration: convertRegistration(resp.Data.Registration),
-		Nav:          convertNavigation(resp.Data.Nav),
-		Crew:         convertCrew(resp.Data.Crew),
-		Frame:        convertFrame(resp.Data.Frame),
-		Reactor:      convertReactor(resp.Data.Reactor),
-		Engine:       convertEngine(resp.Data.Engine),
-		Cooldown:     convertCooldown(resp.Data.Cooldown),
-		Modules:      convertModules(resp.Data.Modules),
-		Mounts:       convertMounts(resp.Data.Mounts),
-		Cargo:        convertCargo(resp.Data.Cargo),
-		Fuel:         convertFuel(resp.Data.Fuel),
-	}
+	ship := convertShipFromGenerated(resp.Data)
+	c.notifyShip(ship)
 
 	return &ship, nil
 }
 
+// GetShipNav returns the navigation state of a specific ship
+func (c *Client) GetShipNav(shipSymbol string) (*Navigation, error) {
+	resp, _, err := c.apiClient.FleetAPI.GetShipNav(c.ctx, shipSymbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ship nav: %w", err)
+	}
+
+	nav := convertNavigation(resp.Data)
+	return &nav, nil
+}
+
+// GetShipCargo returns the cargo hold of a specific ship
+func (c *Client) GetShipCargo(shipSymbol string) (*Cargo, error) {
+	resp, _, err := c.apiClient.FleetAPI.GetMyShipCargo(c.ctx, shipSymbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ship cargo: %w", err)
+	}
+
+	cargo := convertCargo(resp.Data)
+	c.notifyCargo(shipSymbol, cargo)
+
+	return &cargo, nil
+}
+
 // GetShipCooldown returns cooldown information for a specific ship
 func (c *Client) GetShipCooldown(shipSymbol string) (*Cooldown, error) {
 	resp, httpResp, err := c.apiClient.FleetAPI.GetShipCooldown(c.ctx, shipSymbol).Execute()
 	if err != nil {
 		// Check if it's a 204 (no content) response, which means no cooldown
 		if httpResp != nil && httpResp.StatusCode == 204 {
+			c.notifyCooldown(shipSymbol, "", Cooldown{ShipSymbol: shipSymbol})
 			return nil, nil // No cooldown active
 		}
 		return nil, fmt.Errorf("failed to get ship cooldown: %w", err)
 	}
 
 	if resp == nil {
+		c.notifyCooldown(shipSymbol, "", Cooldown{ShipSymbol: shipSymbol})
 		return nil, nil // No cooldown active
 	}
 
 	cooldown := convertCooldown(resp.Data)
+	c.notifyCooldown(shipSymbol, "", cooldown)
 	return &cooldown, nil
 }
 
 // GetAllContracts returns all contracts for the agent
 func (c *Client) GetAllContracts() ([]Contract, error) {
-	var allContracts []Contract
+	allContracts := make([]Contract, 0)
 	page := int32(1)
 	limit := int32(20)
 
@@ -181,6 +260,31 @@ func (c *Client) GetAllContracts() ([]Contract, error) {
 	return allContracts, nil
 }
 
+// GetContract returns a single contract by ID
+func (c *Client) GetContract(contractID string) (*Contract, error) {
+	resp, _, err := c.apiClient.ContractsAPI.GetContract(c.ctx, contractID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract: %w", err)
+	}
+
+	var expiration, deadlineToAccept string
+	expiration = resp.Data.Expiration.Format("2006-01-02T15:04:05.000Z")
+	if resp.Data.DeadlineToAccept != nil {
+		deadlineToAccept = resp.Data.DeadlineToAccept.Format("2006-01-02T15:04:05.000Z")
+	}
+
+	return &Contract{
+		ID:               resp.Data.Id,
+		FactionSymbol:    resp.Data.FactionSymbol,
+		Type:             resp.Data.Type,
+		Terms:            convertContractTerms(resp.Data.Terms),
+		Accepted:         resp.Data.Accepted,
+		Fulfilled:        resp.Data.Fulfilled,
+		Expiration:       expiration,
+		DeadlineToAccept: deadlineToAccept,
+	}, nil
+}
+
 // AcceptContract accepts a contract by ID
 func (c *Client) AcceptContract(contractID string) (*AcceptContractResponse, error) {
 	resp, _, err := c.apiClient.ContractsAPI.AcceptContract(c.ctx, contractID).Execute()
@@ -194,26 +298,28 @@ func (c *Client) AcceptContract(contractID string) (*AcceptContractResponse, err
 		deadlineToAccept = resp.Data.Contract.DeadlineToAccept.Format("2006-01-02T15:04:05.000Z")
 	}
 
+	contract := Contract{
+		ID:               resp.Data.Contract.Id,
+		FactionSymbol:    resp.Data.Contract.FactionSymbol,
+		Type:             resp.Data.Contract.Type,
+		Terms:            convertContractTerms(resp.Data.Contract.Terms),
+		Accepted:         resp.Data.Contract.Accepted,
+		Fulfilled:        resp.Data.Contract.Fulfilled,
+		Expiration:       expiration,
+		DeadlineToAccept: deadlineToAccept,
+	}
+	c.notify(Observation{
+		Kind:     ObservedContractAccepted,
+		Contract: &contract,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+
 	return &AcceptContractResponse{
 		Data: AcceptContractData{
-			Contract: Contract{
-				ID:               resp.Data.Contract.Id,
-				FactionSymbol:    resp.Data.Contract.FactionSymbol,
-				Type:             resp.Data.Contract.Type,
-				Terms:            convertContractTerms(resp.Data.Contract.Terms),
-				Accepted:         resp.Data.Contract.Accepted,
-				Fulfilled:        resp.Data.Contract.Fulfilled,
-				Expiration:       expiration,
-				DeadlineToAccept: deadlineToAccept,
-			},
-			Agent: Agent{
-				AccountID:       resp.Data.Agent.AccountId,
-				Symbol:          resp.Data.Agent.Symbol,
-				Headquarters:    resp.Data.Agent.Headquarters,
-				Credits:         resp.Data.Agent.Credits,
-				StartingFaction: resp.Data.Agent.StartingFaction,
-				ShipCount:       int(resp.Data.Agent.ShipCount),
-			},
+			Contract: contract,
+			Agent:    agent,
 		},
 	}, nil
 }
@@ -221,40 +327,54 @@ func (c *Client) AcceptContract(contractID string) (*AcceptContractResponse, err
 // GetAllSystemWaypoints returns all waypoints in a system
 func (c *Client) GetAllSystemWaypoints(systemSymbol string) ([]SystemWaypoint, error) {
 	var allWaypoints []SystemWaypoint
-	page := int32(1)
-	limit := int32(20)
+	page := 1
 
 	for {
-		resp, _, err := c.apiClient.SystemsAPI.GetSystemWaypoints(c.ctx, systemSymbol).Page(page).Limit(limit).Execute()
+		waypoints, total, err := c.GetSystemWaypointsPage(systemSymbol, page, MaxPageLimit)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get system waypoints: %w", err)
-		}
-
-		for _, waypoint := range resp.Data {
-			convertedWaypoint := SystemWaypoint{
-				Symbol:    waypoint.Symbol,
-				Type:      string(waypoint.Type),
-				X:         int(waypoint.X),
-				Y:         int(waypoint.Y),
-				Orbitals:  convertOrbitals(waypoint.Orbitals),
-				Traits:    convertWaypointTraits(waypoint.Traits),
-				Modifiers: convertWaypointModifiers(waypoint.Modifiers),
-				Chart:     convertChart(waypoint.Chart),
-				Faction:   convertWaypointFaction(waypoint.Faction),
-			}
-			allWaypoints = append(allWaypoints, convertedWaypoint)
+			return nil, err
 		}
+		allWaypoints = append(allWaypoints, waypoints...)
 
 		// Check if we have more pages
-		if len(resp.Data) < int(limit) || int32(len(allWaypoints)) >= resp.Meta.Total {
+		if len(waypoints) < MaxPageLimit || len(allWaypoints) >= total {
 			break
 		}
 		page++
 	}
 
+	c.notify(Observation{
+		Kind:      ObservedSystemWaypoints,
+		Waypoints: allWaypoints,
+	})
+
 	return allWaypoints, nil
 }
 
+// GetSystemWaypointsPage returns one page of the waypoints in a system and the total number
+// of waypoints across all pages. Pages start at 1 and hold at most MaxPageLimit waypoints.
+func (c *Client) GetSystemWaypointsPage(systemSymbol string, page, limit int) ([]SystemWaypoint, int, error) {
+	resp, _, err := c.apiClient.SystemsAPI.GetSystemWaypoints(c.ctx, systemSymbol).Page(int32(page)).Limit(int32(limit)).Execute()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get system waypoints: %w", err)
+	}
+
+	return convertWaypoints(resp.Data), int(resp.Meta.Total), nil
+}
+
+// GetJumpGate returns the jump gate at a waypoint and the waypoints it connects to
+func (c *Client) GetJumpGate(systemSymbol, waypointSymbol string) (*JumpGate, error) {
+	resp, _, err := c.apiClient.SystemsAPI.GetJumpGate(c.ctx, systemSymbol, waypointSymbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jump gate: %w", err)
+	}
+
+	return &JumpGate{
+		Symbol:      resp.Data.Symbol,
+		Connections: resp.Data.Connections,
+	}, nil
+}
+
 // GetShipyard returns shipyard information for a waypoint
 func (c *Client) GetShipyard(systemSymbol, waypointSymbol string) (*Shipyard, error) {
 	resp, _, err := c.apiClient.SystemsAPI.GetShipyard(c.ctx, systemSymbol, waypointSymbol).Execute()
@@ -262,13 +382,20 @@ func (c *Client) GetShipyard(systemSymbol, waypointSymbol string) (*Shipyard, er
 		return nil, fmt.Errorf("failed to get shipyard: %w", err)
 	}
 
-	return &Shipyard{
+	shipyard := &Shipyard{
 		Symbol:           resp.Data.Symbol,
 		ShipTypes:        convertShipyardShipTypes(resp.Data.ShipTypes),
 		Transactions:     convertShipyardTransactions(resp.Data.Transactions),
 		Ships:            convertShipyardShips(resp.Data.Ships),
 		ModificationsFee: int(resp.Data.ModificationsFee),
-	}, nil
+	}
+	c.cacheShipyard(shipyard)
+	c.notify(Observation{
+		Kind:     ObservedShipyard,
+		Shipyard: shipyard,
+	})
+
+	return shipyard, nil
 }
 
 // GetMarket returns market information for a waypoint
@@ -278,14 +405,21 @@ func (c *Client) GetMarket(systemSymbol, waypointSymbol string) (*Market, error)
 		return nil, fmt.Errorf("failed to get market: %w", err)
 	}
 
-	return &Market{
+	market := &Market{
 		Symbol:       resp.Data.Symbol,
 		Exports:      convertTradeGoods(resp.Data.Exports),
 		Imports:      convertTradeGoods(resp.Data.Imports),
 		Exchange:     convertTradeGoods(resp.Data.Exchange),
 		Transactions: convertMarketTransactions(resp.Data.Transactions),
 		TradeGoods:   convertMarketTradeGoods(resp.Data.TradeGoods),
-	}, nil
+	}
+	c.cacheMarketListing(market)
+	c.notify(Observation{
+		Kind:   ObservedMarket,
+		Market: market,
+	})
+
+	return market, nil
 }
 
 // PurchaseShip purchases a new ship
@@ -300,18 +434,24 @@ func (c *Client) PurchaseShip(request PurchaseShipRequest) (*PurchaseShipRespons
 		return nil, fmt.Errorf("failed to purchase ship: %w", err)
 	}
 
+	transaction := convertTransactionFromGenerated(resp.Data.Transaction)
+	c.notify(Observation{
+		Kind:                ObservedShipyardTransaction,
+		ShipSymbol:          transaction.ShipSymbol,
+		ObservedAt:          parseTime(transaction.Timestamp),
+		ShipyardTransaction: &transaction,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	ship := convertShipFromGenerated(resp.Data.Ship)
+	c.notifyShip(ship)
+
 	return &PurchaseShipResponse{
 		Data: PurchaseShipData{
-			Agent: Agent{
-				AccountID:       resp.Data.Agent.AccountId,
-				Symbol:          resp.Data.Agent.Symbol,
-				Headquarters:    resp.Data.Agent.Headquarters,
-				Credits:         resp.Data.Agent.Credits,
-				StartingFaction: resp.Data.Agent.StartingFaction,
-				ShipCount:       int(resp.Data.Agent.ShipCount),
-			},
-			Ship:        convertShipFromGenerated(resp.Data.Ship),
-			Transaction: convertTransactionFromGenerated(resp.Data.Transaction),
+			Agent:       agent,
+			Ship:        ship,
+			Transaction: transaction,
 		},
 	}, nil
 }
@@ -323,9 +463,12 @@ func (c *Client) OrbitShip(shipSymbol string) (*OrbitResponse, error) {
 		return nil, fmt.Errorf("failed to orbit ship: %w", err)
 	}
 
+	nav := convertNavigation(resp.Data.Nav)
+	c.notifyNavigation(shipSymbol, nav)
+
 	return &OrbitResponse{
 		Data: OrbitData{
-			Nav: convertNavigation(resp.Data.Nav),
+			Nav: nav,
 		},
 	}, nil
 }
@@ -337,9 +480,12 @@ func (c *Client) DockShip(shipSymbol string) (*DockResponse, error) {
 		return nil, fmt.Errorf("failed to dock ship: %w", err)
 	}
 
+	nav := convertNavigation(resp.Data.Nav)
+	c.notifyNavigation(shipSymbol, nav)
+
 	return &DockResponse{
 		Data: DockData{
-			Nav: convertNavigation(resp.Data.Nav),
+			Nav: nav,
 		},
 	}, nil
 }
@@ -355,10 +501,16 @@ func (c *Client) NavigateShip(shipSymbol, waypointSymbol string) (*NavigateRespo
 		return nil, fmt.Errorf("failed to navigate ship: %w", err)
 	}
 
+	nav := convertNavigation(resp.Data.Nav)
+	c.notifyNavigation(shipSymbol, nav)
+	fuel := convertFuel(resp.Data.Fuel)
+	c.notifyFuel(shipSymbol, fuel)
+	c.notifyShipEvents(shipSymbol, convertEvents(resp.Data.Events))
+
 	return &NavigateResponse{
 		Data: NavigateData{
-			Fuel:  convertFuel(resp.Data.Fuel),
-			Nav:   convertNavigation(resp.Data.Nav),
+			Fuel:  fuel,
+			Nav:   nav,
 			Event: convertEvent(resp.Data.Events),
 		},
 	}, nil
@@ -367,30 +519,17 @@ func (c *Client) NavigateShip(shipSymbol, waypointSymbol string) (*NavigateRespo
 // GetAllSystems returns all systems
 func (c *Client) GetAllSystems() ([]System, error) {
 	var allSystems []System
-	page := int32(1)
-	limit := int32(20)
+	page := 1
 
 	for {
-		resp, _, err := c.apiClient.SystemsAPI.GetSystems(c.ctx).Page(page).Limit(limit).Execute()
+		systems, total, err := c.GetSystemsPage(page, MaxPageLimit)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get systems: %w", err)
-		}
-
-		for _, system := range resp.Data {
-			convertedSystem := System{
-				Symbol:       system.Symbol,
-				SectorSymbol: system.SectorSymbol,
-				Type:         string(system.Type),
-				X:            int(system.X),
-				Y:            int(system.Y),
-				Waypoints:    convertSystemWaypoints(system.Waypoints),
-				Factions:     convertSystemFactions(system.Factions),
-			}
-			allSystems = append(allSystems, convertedSystem)
+			return nil, err
 		}
+		allSystems = append(allSystems, systems...)
 
 		// Check if we have more pages
-		if len(resp.Data) < int(limit) || int32(len(allSystems)) >= resp.Meta.Total {
+		if len(systems) < MaxPageLimit || len(allSystems) >= total {
 			break
 		}
 		page++
@@ -399,6 +538,34 @@ func (c *Client) GetAllSystems() ([]System, error) {
 	return allSystems, nil
 }
 
+// GetSystemsPage returns one page of the systems in the universe and the total number of
+// systems across all pages. Pages start at 1 and hold at most MaxPageLimit systems.
+func (c *Client) GetSystemsPage(page, limit int) ([]System, int, error) {
+	resp, _, err := c.apiClient.SystemsAPI.GetSystems(c.ctx).Page(int32(page)).Limit(int32(limit)).Execute()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get systems: %w", err)
+	}
+
+	systems := make([]System, 0, len(resp.Data))
+	for _, system := range resp.Data {
+		systems = append(systems, System{
+			Symbol:       system.Symbol,
+			SectorSymbol: system.SectorSymbol,
+			Type:         string(system.Type),
+			X:            int(system.X),
+			Y:            int(system.Y),
+			Waypoints:    convertSystemWaypoints(system.Waypoints),
+			Factions:     convertSystemFactions(system.Factions),
+		})
+	}
+	c.notify(Observation{
+		Kind:    ObservedSystems,
+		Systems: systems,
+	})
+
+	return systems, int(resp.Meta.Total), nil
+}
+
 // GetSystem returns a specific system
 func (c *Client) GetSystem(systemSymbol string) (*System, error) {
 	resp, _, err := c.apiClient.SystemsAPI.GetSystem(c.ctx, systemSymbol).Execute()
@@ -406,7 +573,7 @@ func (c *Client) GetSystem(systemSymbol string) (*System, error) {
 		return nil, fmt.Errorf("failed to get system: %w", err)
 	}
 
-	return &System{
+	system := &System{
 		Symbol:       resp.Data.Symbol,
 		SectorSymbol: resp.Data.SectorSymbol,
 		Type:         string(resp.Data.Type),
@@ -414,7 +581,13 @@ func (c *Client) GetSystem(systemSymbol string) (*System, error) {
 		Y:            int(resp.Data.Y),
 		Waypoints:    convertSystemWaypoints(resp.Data.Waypoints),
 		Factions:     convertSystemFactions(resp.Data.Factions),
-	}, nil
+	}
+	c.notify(Observation{
+		Kind:    ObservedSystems,
+		Systems: []System{*system},
+	})
+
+	return system, nil
 }
 
 // GetAllFactions returns all factions
@@ -456,6 +629,35 @@ func (c *Client) GetAllFactions() ([]Faction, error) {
 	return allFactions, nil
 }
 
+// GetMyFactions returns the agent's reputation with each faction
+func (c *Client) GetMyFactions() ([]FactionReputation, error) {
+	var allReputations []FactionReputation
+	page := 1
+	limit := 20
+
+	for {
+		var resp struct {
+			Data []FactionReputation `json:"data"`
+			Meta struct {
+				Total int `json:"total"`
+			} `json:"meta"`
+		}
+		if err := c.getJSON(fmt.Sprintf("/my/factions?page=%d&limit=%d", page, limit), &resp); err != nil {
+			return nil, fmt.Errorf("failed to get faction reputations: %w", err)
+		}
+
+		allReputations = append(allReputations, resp.Data...)
+
+		// Check if we have more pages
+		if len(resp.Data) < limit || len(allReputations) >= resp.Meta.Total {
+			break
+		}
+		page++
+	}
+
+	return allReputations, nil
+}
+
 // GetFaction returns a specific faction
 func (c *Client) GetFaction(factionSymbol string) (*Faction, error) {
 	resp, _, err := c.apiClient.FactionsAPI.GetFaction(c.ctx, factionSymbol).Execute()
@@ -490,11 +692,19 @@ func (c *Client) SellCargo(shipSymbol, symbol string, units int) (*SellCargoResp
 		return nil, fmt.Errorf("failed to sell cargo: %w", err)
 	}
 
+	transaction := convertMarketTransactionFromGenerated(resp.Data.Transaction)
+	c.notifyMarketTransaction(shipSymbol, transaction)
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
+
 	return &SellCargoResponse{
 		Data: SellCargoData{
-			Agent:       convertAgentFromGenerated(resp.Data.Agent),
-			Cargo:       convertCargo(resp.Data.Cargo),
-			Transaction: convertMarketTransactionFromGenerated(resp.Data.Transaction),
+			Agent:       agent,
+			Cargo:       cargo,
+			Transaction: transaction,
 		},
 	}, nil
 }
@@ -511,11 +721,19 @@ func (c *Client) BuyCargo(shipSymbol, symbol string, units int) (*BuyCargoRespon
 		return nil, fmt.Errorf("failed to buy cargo: %w", err)
 	}
 
+	transaction := convertMarketTransactionFromGenerated(resp.Data.Transaction)
+	c.notifyMarketTransaction(shipSymbol, transaction)
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
+
 	return &BuyCargoResponse{
 		Data: BuyCargoData{
-			Agent:       convertAgentFromGenerated(resp.Data.Agent),
-			Cargo:       convertCargo(resp.Data.Cargo),
-			Transaction: convertMarketTransactionFromGenerated(resp.Data.Transaction),
+			Agent:       agent,
+			Cargo:       cargo,
+			Transaction: transaction,
 		},
 	}, nil
 }
@@ -538,6 +756,8 @@ func (c *Client) DeliverContract(contractID, shipSymbol, tradeSymbol string, uni
 	if resp.Data.Contract.DeadlineToAccept != nil {
 		deadlineToAccept = resp.Data.Contract.DeadlineToAccept.Format("2006-01-02T15:04:05.000Z")
 	}
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
 
 	return &DeliverContractResponse{
 		Data: DeliverContractData{
@@ -551,7 +771,7 @@ func (c *Client) DeliverContract(contractID, shipSymbol, tradeSymbol string, uni
 				Expiration:       expiration,
 				DeadlineToAccept: deadlineToAccept,
 			},
-			Cargo: convertCargo(resp.Data.Cargo),
+			Cargo: cargo,
 		},
 	}, nil
 }
@@ -569,19 +789,28 @@ func (c *Client) FulfillContract(contractID string) (*FulfillContractResponse, e
 		deadlineToAccept = resp.Data.Contract.DeadlineToAccept.Format("2006-01-02T15:04:05.000Z")
 	}
 
+	contract := Contract{
+		ID:               resp.Data.Contract.Id,
+		FactionSymbol:    resp.Data.Contract.FactionSymbol,
+		Type:             resp.Data.Contract.Type,
+		Terms:            convertContractTerms(resp.Data.Contract.Terms),
+		Accepted:         resp.Data.Contract.Accepted,
+		Fulfilled:        resp.Data.Contract.Fulfilled,
+		Expiration:       expiration,
+		DeadlineToAccept: deadlineToAccept,
+	}
+	c.notify(Observation{
+		Kind:     ObservedContractFulfilled,
+		Contract: &contract,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+
 	return &FulfillContractResponse{
 		Data: FulfillContractData{
-			Agent: convertAgentFromGenerated(resp.Data.Agent),
-			Contract: Contract{
-				ID:               resp.Data.Contract.Id,
-				FactionSymbol:    resp.Data.Contract.FactionSymbol,
-				Type:             resp.Data.Contract.Type,
-				Terms:            convertContractTerms(resp.Data.Contract.Terms),
-				Accepted:         resp.Data.Contract.Accepted,
-				Fulfilled:        resp.Data.Contract.Fulfilled,
-				Expiration:       expiration,
-				DeadlineToAccept: deadlineToAccept,
-			},
+			Agent:    agent,
+			Contract: contract,
 		},
 	}, nil
 }
@@ -604,12 +833,26 @@ func (c *Client) ExtractResources(shipSymbol string, survey *Survey) (*ExtractRe
 		return nil, fmt.Errorf("failed to extract resources: %w", err)
 	}
 
+	extraction := convertExtraction(resp.Data.Extraction)
+	c.notify(Observation{
+		Kind:       ObservedExtraction,
+		ShipSymbol: shipSymbol,
+		Extraction: &extraction,
+		Survey:     survey,
+	})
+	events := convertEvents(resp.Data.Events)
+	c.notifyShipEvents(shipSymbol, events)
+	cooldown := convertCooldown(resp.Data.Cooldown)
+	c.notifyCooldown(shipSymbol, string(ObservedExtraction), cooldown)
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
+
 	return &ExtractResponse{
 		Data: ExtractData{
-			Cooldown:   convertCooldown(resp.Data.Cooldown),
-			Extraction: convertExtraction(resp.Data.Extraction),
-			Cargo:      convertCargo(resp.Data.Cargo),
-			Events:     convertEvents(resp.Data.Events),
+			Cooldown:   cooldown,
+			Extraction: extraction,
+			Cargo:      cargo,
+			Events:     events,
 		},
 	}, nil
 }
@@ -626,9 +869,12 @@ func (c *Client) JettisonCargo(shipSymbol, symbol string, units int) (*JettisonR
 		return nil, fmt.Errorf("failed to jettison cargo: %w", err)
 	}
 
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
+
 	return &JettisonResponse{
 		Data: JettisonData{
-			Cargo: convertCargo(resp.Data.Cargo),
+			Cargo: cargo,
 		},
 	}, nil
 }
@@ -648,11 +894,19 @@ func (c *Client) RefuelShip(shipSymbol string, units *int, fromCargo bool) (*Ref
 		return nil, fmt.Errorf("failed to refuel ship: %w", err)
 	}
 
+	transaction := convertMarketTransactionFromGenerated(resp.Data.Transaction)
+	c.notifyMarketTransaction(shipSymbol, transaction)
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	fuel := convertFuel(resp.Data.Fuel)
+	c.notifyFuel(shipSymbol, fuel)
+
 	return &RefuelResponse{
 		Data: RefuelData{
-			Agent:       convertAgentFromGenerated(resp.Data.Agent),
-			Fuel:        convertFuel(resp.Data.Fuel),
-			Transaction: convertMarketTransactionFromGenerated(resp.Data.Transaction),
+			Agent:       agent,
+			Fuel:        fuel,
+			Transaction: transaction,
 		},
 	}, nil
 }
@@ -664,10 +918,19 @@ func (c *Client) ScanSystems(shipSymbol string) (*ScanSystemsResponse, error) {
 		return nil, fmt.Errorf("failed to scan systems: %w", err)
 	}
 
+	systems := convertScannedSystems(resp.Data.Systems)
+	c.notify(Observation{
+		Kind:           ObservedSystemScan,
+		ShipSymbol:     shipSymbol,
+		ScannedSystems: systems,
+	})
+	cooldown := convertCooldown(resp.Data.Cooldown)
+	c.notifyCooldown(shipSymbol, string(ObservedSystemScan), cooldown)
+
 	return &ScanSystemsResponse{
 		Data: ScanSystemsData{
-			Cooldown: convertCooldown(resp.Data.Cooldown),
-			Systems:  convertScannedSystems(resp.Data.Systems),
+			Cooldown: cooldown,
+			Systems:  systems,
 		},
 	}, nil
 }
@@ -679,10 +942,19 @@ func (c *Client) ScanWaypoints(shipSymbol string) (*ScanWaypointsResponse, error
 		return nil, fmt.Errorf("failed to scan waypoints: %w", err)
 	}
 
+	waypoints := convertScannedWaypoints(resp.Data.Waypoints)
+	c.notify(Observation{
+		Kind:             ObservedWaypointScan,
+		ShipSymbol:       shipSymbol,
+		ScannedWaypoints: waypoints,
+	})
+	cooldown := convertCooldown(resp.Data.Cooldown)
+	c.notifyCooldown(shipSymbol, string(ObservedWaypointScan), cooldown)
+
 	return &ScanWaypointsResponse{
 		Data: ScanWaypointsData{
-			Cooldown:  convertCooldown(resp.Data.Cooldown),
-			Waypoints: convertScannedWaypoints(resp.Data.Waypoints),
+			Cooldown:  cooldown,
+			Waypoints: waypoints,
 		},
 	}, nil
 }
@@ -694,14 +966,28 @@ func (c *Client) ScanShips(shipSymbol string) (*ScanShipsResponse, error) {
 		return nil, fmt.Errorf("failed to scan ships: %w", err)
 	}
 
+	cooldown := convertCooldown(resp.Data.Cooldown)
+	c.notifyCooldown(shipSymbol, "ship_scan", cooldown)
+
 	return &ScanShipsResponse{
 		Data: ScanShipsData{
-			Cooldown: convertCooldown(resp.Data.Cooldown),
+			Cooldown: cooldown,
 			Ships:    convertScannedShips(resp.Data.Ships),
 		},
 	}, nil
 }
 
+// GetRepairQuote returns what repairing a ship at its current waypoint would cost, without repairing it
+func (c *Client) GetRepairQuote(shipSymbol string) (*RepairTransaction, error) {
+	resp, _, err := c.apiClient.FleetAPI.GetRepairShip(c.ctx, shipSymbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repair quote: %w", err)
+	}
+
+	transaction := convertRepairTransactionFromGenerated(resp.Data.Transaction)
+	return &transaction, nil
+}
+
 // RepairShip repairs a ship
 func (c *Client) RepairShip(shipSymbol string) (*RepairShipResponse, error) {
 	resp, _, err := c.apiClient.FleetAPI.RepairShip(c.ctx, shipSymbol).Execute()
@@ -709,11 +995,135 @@ func (c *Client) RepairShip(shipSymbol string) (*RepairShipResponse, error) {
 		return nil, fmt.Errorf("failed to repair ship: %w", err)
 	}
 
+	transaction := convertRepairTransactionFromGenerated(resp.Data.Transaction)
+	c.notify(Observation{
+		Kind:              ObservedRepairTransaction,
+		ShipSymbol:        shipSymbol,
+		ObservedAt:        parseTime(transaction.Timestamp),
+		RepairTransaction: &transaction,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	ship := convertShipFromGenerated(resp.Data.Ship)
+	c.notifyShip(ship)
+
 	return &RepairShipResponse{
 		Data: RepairShipData{
-			Agent:       convertAgentFromGenerated(resp.Data.Agent),
-			Ship:        convertShipFromGenerated(resp.Data.Ship),
-			Transaction: convertRepairTransactionFromGenerated(resp.Data.Transaction),
+			Agent:       agent,
+			Ship:        ship,
+			Transaction: transaction,
+		},
+	}, nil
+}
+
+// GetScrapValue returns what scrapping a ship at its current waypoint would pay, without scrapping it
+func (c *Client) GetScrapValue(shipSymbol string) (*ScrapTransaction, error) {
+	resp, _, err := c.apiClient.FleetAPI.GetScrapShip(c.ctx, shipSymbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scrap value: %w", err)
+	}
+
+	transaction := convertScrapTransactionFromGenerated(resp.Data.Transaction)
+	return &transaction, nil
+}
+
+// ScrapShip scraps a ship at a shipyard, permanently removing it from the fleet
+func (c *Client) ScrapShip(shipSymbol string) (*ScrapShipResponse, error) {
+	resp, _, err := c.apiClient.FleetAPI.ScrapShip(c.ctx, shipSymbol).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrap ship: %w", err)
+	}
+
+	transaction := convertScrapTransactionFromGenerated(resp.Data.Transaction)
+	c.notify(Observation{
+		Kind:             ObservedScrapTransaction,
+		ShipSymbol:       shipSymbol,
+		ObservedAt:       parseTime(transaction.Timestamp),
+		ScrapTransaction: &transaction,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+
+	return &ScrapShipResponse{
+		Data: ScrapShipData{
+			Agent:       agent,
+			Transaction: transaction,
+		},
+	}, nil
+}
+
+// InstallMount installs a mount from the ship's cargo. The ship must be docked at a shipyard.
+func (c *Client) InstallMount(shipSymbol, mountSymbol string) (*ShipModificationResponse, error) {
+	req := spacetraders.InstallMountRequest{
+		Symbol: mountSymbol,
+	}
+
+	resp, _, err := c.apiClient.FleetAPI.InstallMount(c.ctx, shipSymbol).InstallMountRequest(req).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to install mount: %w", err)
+	}
+
+	transaction := convertModificationTransactionFromGenerated(resp.Data.Transaction)
+	c.notify(Observation{
+		Kind:                    ObservedModificationTransaction,
+		ShipSymbol:              shipSymbol,
+		ObservedAt:              parseTime(transaction.Timestamp),
+		ModificationTransaction: &transaction,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
+
+	return &ShipModificationResponse{
+		Data: ShipModificationData{
+			Agent:       agent,
+			Mounts:      convertMounts(resp.Data.Mounts),
+			Cargo:       cargo,
+			Transaction: transaction,
+		},
+	}, nil
+}
+
+// InstallShipModule installs a module from the ship's cargo. The ship must be docked at a shipyard.
+func (c *Client) InstallShipModule(shipSymbol, moduleSymbol string) (*ShipModificationResponse, error) {
+	req := spacetraders.InstallShipModuleRequest{
+		Symbol: moduleSymbol,
+	}
+
+	resp, _, err := c.apiClient.FleetAPI.InstallShipModule(c.ctx, shipSymbol).InstallShipModuleRequest(req).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to install module: %w", err)
+	}
+
+	transaction := ModificationTransaction{
+		WaypointSymbol: resp.Data.Transaction.WaypointSymbol,
+		ShipSymbol:     resp.Data.Transaction.ShipSymbol,
+		TradeSymbol:    resp.Data.Transaction.TradeSymbol,
+		TotalPrice:     int(resp.Data.Transaction.TotalPrice),
+		Timestamp:      resp.Data.Transaction.Timestamp,
+	}
+	c.notify(Observation{
+		Kind:                    ObservedModificationTransaction,
+		ShipSymbol:              shipSymbol,
+		ObservedAt:              parseTime(transaction.Timestamp),
+		ModificationTransaction: &transaction,
+	})
+
+	agent := convertAgentFromGenerated(resp.Data.Agent)
+	c.notifyAgent(agent)
+	cargo := convertCargo(resp.Data.Cargo)
+	c.notifyCargo(shipSymbol, cargo)
+
+	return &ShipModificationResponse{
+		Data: ShipModificationData{
+			Agent:       agent,
+			Modules:     convertModules(resp.Data.Modules),
+			Cargo:       cargo,
+			Transaction: transaction,
 		},
 	}, nil
 }
@@ -729,10 +1139,15 @@ func (c *Client) JumpShip(shipSymbol, systemSymbol string) (*JumpResponse, error
 		return nil, fmt.Errorf("failed to jump ship: %w", err)
 	}
 
+	nav := convertNavigation(resp.Data.Nav)
+	c.notifyNavigation(shipSymbol, nav)
+	cooldown := convertCooldown(resp.Data.Cooldown)
+	c.notifyCooldown(shipSymbol, "jump", cooldown)
+
 	return &JumpResponse{
 		Data: JumpData{
-			Cooldown: convertCooldown(resp.Data.Cooldown),
-			Nav:      convertNavigation(resp.Data.Nav),
+			Cooldown: cooldown,
+			Nav:      nav,
 			Event:    convertEventFromTransaction(resp.Data.Transaction),
 		},
 	}, nil
@@ -749,10 +1164,15 @@ func (c *Client) WarpShip(shipSymbol, waypointSymbol string) (*WarpResponse, err
 		return nil, fmt.Errorf("failed to warp ship: %w", err)
 	}
 
+	nav := convertNavigation(resp.Data.Nav)
+	c.notifyNavigation(shipSymbol, nav)
+	fuel := convertFuel(resp.Data.Fuel)
+	c.notifyFuel(shipSymbol, fuel)
+
 	return &WarpResponse{
 		Data: WarpData{
-			Fuel: convertFuel(resp.Data.Fuel),
-			Nav:  convertNavigation(resp.Data.Nav),
+			Fuel: fuel,
+			Nav:  nav,
 		},
 	}, nil
 }
@@ -767,8 +1187,11 @@ func (c *Client) PatchShipNav(shipSymbol, flightMode string) (*PatchNavResponse,
 	if err != nil {
 		return nil, fmt.Errorf("failed to patch ship nav: %w", err)
 	}
+	nav := convertNavigation(resp.Data.Nav)
+	c.notifyNavigation(shipSymbol, nav)
+	c.notifyShipEvents(shipSymbol, convertEvents(resp.Data.Events))
 
 	return &PatchNavResponse{
-		Data: convertNavigation(resp.Data.Nav),
+		Data: nav,
 	}, nil
 }
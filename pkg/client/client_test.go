@@ -0,0 +1,1058 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Fixtures shared by the endpoint responses below
+const (
+	agentJSON = `{"accountId": "account-1", "symbol": "TEST_AGENT", "headquarters": "X1-TEST-A1", "credits": 175000, "startingFaction": "COSMIC", "shipCount": 2}`
+
+	navJSON = `{"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT", "flightMode": "CRUISE",
+		"route": {"origin": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+			"destination": {"symbol": "X1-TEST-B2", "type": "ASTEROID", "systemSymbol": "X1-TEST", "x": 30, "y": 40},
+			"departureTime": "2025-01-01T00:00:00.000Z", "arrival": "2025-01-01T00:05:00.000Z"}}`
+
+	fuelJSON = `{"current": 350, "capacity": 400, "consumed": {"amount": 50, "timestamp": "2025-01-01T00:00:00.000Z"}}`
+
+	cargoJSON = `{"capacity": 40, "units": 10, "inventory": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "Ore", "units": 10}]}`
+
+	cooldownJSON = `{"shipSymbol": "TEST-1", "totalSeconds": 70, "remainingSeconds": 69, "expiration": "2025-01-01T00:01:10.000Z"}`
+
+	eventJSON = `{"symbol": "ENGINE_ACTUATOR_FAILURE", "component": "ENGINE", "name": "Engine Actuator Failure", "description": "Strained"}`
+
+	contractJSON = `{"id": "contract-1", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "accepted": true, "fulfilled": false,
+		"expiration": "2025-01-08T00:00:00.000Z", "deadlineToAccept": "2025-01-02T00:00:00.000Z",
+		"terms": {"deadline": "2025-01-07T00:00:00.000Z", "payment": {"onAccepted": 10000, "onFulfilled": 40000},
+			"deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-A1", "unitsRequired": 30, "unitsFulfilled": 10}]}}`
+
+	marketTransactionJSON = `{"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "tradeSymbol": "IRON_ORE", "type": "PURCHASE",
+		"units": 10, "pricePerUnit": 12, "totalPrice": 120, "timestamp": "2025-01-01T00:00:00.000Z"}`
+
+	systemJSON = `{"symbol": "X1-TEST", "sectorSymbol": "X1", "type": "RED_STAR", "x": 10, "y": 20, "waypoints": [], "factions": []}`
+
+	factionJSON = `{"symbol": "COSMIC", "name": "Cosmic Engineers", "description": "Engineers", "headquarters": "X1-TEST-A1", "traits": [], "isRecruiting": true}`
+
+	waypointJSON = `{"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0, "orbitals": []}`
+)
+
+// shipJSON has fractional component condition and integrity, which the legacy client read as integers
+var shipJSON = `{"symbol": "TEST-1", "registration": {"name": "TEST-1", "factionSymbol": "COSMIC", "role": "COMMAND"},
+	"nav": ` + navJSON + `, "fuel": ` + fuelJSON + `, "cargo": ` + cargoJSON + `, "cooldown": ` + cooldownJSON + `,
+	"crew": {"current": 57, "required": 57, "capacity": 80, "rotation": "STRICT", "morale": 100, "wages": 0},
+	"frame": {"symbol": "FRAME_FRIGATE", "name": "Frigate", "condition": 0.87, "integrity": 0.95, "moduleSlots": 8, "mountingPoints": 5, "fuelCapacity": 400, "quality": 4},
+	"reactor": {"symbol": "REACTOR_FISSION_I", "name": "Fission Reactor I", "condition": 0.5, "integrity": 0.75, "powerOutput": 31},
+	"engine": {"symbol": "ENGINE_ION_DRIVE_II", "name": "Ion Drive II", "condition": 1, "integrity": 1, "speed": 30},
+	"modules": [], "mounts": []}`
+
+// endpointServer answers each "METHOD /path" with its response body wrapped in {"data": ...}, and
+// records the requests it received. Unknown endpoints get a 404 in the API's error format.
+func endpointServer(t *testing.T, responses map[string]string) (*Client, map[string]string) {
+	t.Helper()
+
+	requests := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-token" {
+			t.Errorf("Expected the bearer token on %s %s, got %q", r.Method, r.URL.Path, auth)
+		}
+		key := r.Method + " " + r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		requests[key] = string(body)
+
+		w.Header().Set("Content-Type", "application/json")
+		data, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"message": "Not found", "code": 404}}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data": %s, "meta": {"total": 1, "page": 1, "limit": 20}}`, data)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClientWithBaseURL("test-token", server.URL), requests
+}
+
+// TestClient_Endpoints calls every endpoint against a canned response and checks the conversion,
+// so a regenerated client or converter change that drops a field fails here
+func TestClient_Endpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		response string
+		call     func(c *Client) (interface{}, error)
+		check    func(t *testing.T, result interface{})
+		// request, when set, must appear in the request body
+		request string
+	}{
+		{
+			name:     "GetAgent",
+			endpoint: "GET /my/agent",
+			response: agentJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetAgent() },
+			check: func(t *testing.T, result interface{}) {
+				agent := result.(*Agent)
+				if agent.AccountID == nil || *agent.AccountID != "account-1" || agent.Symbol != "TEST_AGENT" || agent.Credits != 175000 || agent.ShipCount != 2 {
+					t.Errorf("Unexpected agent %+v", agent)
+				}
+			},
+		},
+		{
+			name:     "GetMyAccount",
+			endpoint: "GET /my/account",
+			response: `{"account": {"id": "account-1", "email": "pilot@example.com", "token": null, "createdAt": "2025-01-01T00:00:00.000Z"}}`,
+			call:     func(c *Client) (interface{}, error) { return c.GetMyAccount() },
+			check: func(t *testing.T, result interface{}) {
+				account := result.(*Account)
+				if account.ID != "account-1" || account.Email == nil || *account.Email != "pilot@example.com" || account.CreatedAt == "" {
+					t.Errorf("Unexpected account %+v", account)
+				}
+			},
+		},
+		{
+			name:     "GetAllShips",
+			endpoint: "GET /my/ships",
+			response: "[" + shipJSON + "]",
+			call:     func(c *Client) (interface{}, error) { return c.GetAllShips() },
+			check: func(t *testing.T, result interface{}) {
+				ships := result.([]Ship)
+				if len(ships) != 1 || ships[0].Symbol != "TEST-1" {
+					t.Fatalf("Unexpected ships %+v", ships)
+				}
+				checkShip(t, ships[0])
+			},
+		},
+		{
+			name:     "GetShip",
+			endpoint: "GET /my/ships/TEST-1",
+			response: shipJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetShip("TEST-1") },
+			check:    func(t *testing.T, result interface{}) { checkShip(t, *result.(*Ship)) },
+		},
+		{
+			name:     "GetShipNav",
+			endpoint: "GET /my/ships/TEST-1/nav",
+			response: navJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetShipNav("TEST-1") },
+			check:    func(t *testing.T, result interface{}) { checkNav(t, *result.(*Navigation)) },
+		},
+		{
+			name:     "GetShipCargo",
+			endpoint: "GET /my/ships/TEST-1/cargo",
+			response: cargoJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetShipCargo("TEST-1") },
+			check:    func(t *testing.T, result interface{}) { checkCargo(t, *result.(*Cargo)) },
+		},
+		{
+			name:     "GetShipCooldown",
+			endpoint: "GET /my/ships/TEST-1/cooldown",
+			response: cooldownJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetShipCooldown("TEST-1") },
+			check:    func(t *testing.T, result interface{}) { checkCooldown(t, *result.(*Cooldown)) },
+		},
+		{
+			name:     "GetAllContracts",
+			endpoint: "GET /my/contracts",
+			response: "[" + contractJSON + "]",
+			call:     func(c *Client) (interface{}, error) { return c.GetAllContracts() },
+			check: func(t *testing.T, result interface{}) {
+				contracts := result.([]Contract)
+				if len(contracts) != 1 {
+					t.Fatalf("Expected 1 contract, got %d", len(contracts))
+				}
+				checkContract(t, contracts[0])
+			},
+		},
+		{
+			name:     "GetContract",
+			endpoint: "GET /my/contracts/contract-1",
+			response: contractJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetContract("contract-1") },
+			check:    func(t *testing.T, result interface{}) { checkContract(t, *result.(*Contract)) },
+		},
+		{
+			name:     "AcceptContract",
+			endpoint: "POST /my/contracts/contract-1/accept",
+			response: `{"agent": ` + agentJSON + `, "contract": ` + contractJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.AcceptContract("contract-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*AcceptContractResponse).Data
+				checkContract(t, data.Contract)
+				checkAgent(t, data.Agent)
+			},
+		},
+		{
+			name:     "DeliverContract",
+			endpoint: "POST /my/contracts/contract-1/deliver",
+			response: `{"contract": ` + contractJSON + `, "cargo": ` + cargoJSON + `}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.DeliverContract("contract-1", "TEST-1", "IRON_ORE", 10)
+			},
+			request: `"tradeSymbol":"IRON_ORE"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*DeliverContractResponse).Data
+				checkContract(t, data.Contract)
+				checkCargo(t, data.Cargo)
+			},
+		},
+		{
+			name:     "FulfillContract",
+			endpoint: "POST /my/contracts/contract-1/fulfill",
+			response: `{"agent": ` + agentJSON + `, "contract": ` + contractJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.FulfillContract("contract-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*FulfillContractResponse).Data
+				checkContract(t, data.Contract)
+				checkAgent(t, data.Agent)
+			},
+		},
+		{
+			name:     "GetAllSystemWaypoints",
+			endpoint: "GET /systems/X1-TEST/waypoints",
+			response: "[" + waypointJSON + "]",
+			call:     func(c *Client) (interface{}, error) { return c.GetAllSystemWaypoints("X1-TEST") },
+			check: func(t *testing.T, result interface{}) {
+				waypoints := result.([]SystemWaypoint)
+				if len(waypoints) != 1 || waypoints[0].Symbol != "X1-TEST-A1" || waypoints[0].Type != "PLANET" {
+					t.Errorf("Unexpected waypoints %+v", waypoints)
+				}
+			},
+		},
+		{
+			name:     "GetSystemWaypointsPage",
+			endpoint: "GET /systems/X1-TEST/waypoints",
+			response: "[" + waypointJSON + "]",
+			call: func(c *Client) (interface{}, error) {
+				waypoints, total, err := c.GetSystemWaypointsPage("X1-TEST", 1, 20)
+				if err == nil && total != 1 {
+					err = fmt.Errorf("expected a total of 1, got %d", total)
+				}
+				return waypoints, err
+			},
+			check: func(t *testing.T, result interface{}) {
+				if waypoints := result.([]SystemWaypoint); len(waypoints) != 1 {
+					t.Errorf("Expected 1 waypoint, got %d", len(waypoints))
+				}
+			},
+		},
+		{
+			name:     "GetJumpGate",
+			endpoint: "GET /systems/X1-TEST/waypoints/X1-TEST-J1/jump-gate",
+			response: `{"symbol": "X1-TEST-J1", "connections": ["X1-OTHER-J1"]}`,
+			call:     func(c *Client) (interface{}, error) { return c.GetJumpGate("X1-TEST", "X1-TEST-J1") },
+			check: func(t *testing.T, result interface{}) {
+				gate := result.(*JumpGate)
+				if gate.Symbol != "X1-TEST-J1" || len(gate.Connections) != 1 || gate.Connections[0] != "X1-OTHER-J1" {
+					t.Errorf("Unexpected jump gate %+v", gate)
+				}
+			},
+		},
+		{
+			name:     "GetShipyard",
+			endpoint: "GET /systems/X1-TEST/waypoints/X1-TEST-A1/shipyard",
+			response: `{"symbol": "X1-TEST-A1", "shipTypes": [{"type": "SHIP_MINING_DRONE"}], "modificationsFee": 100,
+				"ships": [{"type": "SHIP_MINING_DRONE", "name": "Mining Drone", "purchasePrice": 45000, "supply": "MODERATE"}]}`,
+			call: func(c *Client) (interface{}, error) { return c.GetShipyard("X1-TEST", "X1-TEST-A1") },
+			check: func(t *testing.T, result interface{}) {
+				shipyard := result.(*Shipyard)
+				if shipyard.Symbol != "X1-TEST-A1" || len(shipyard.ShipTypes) != 1 || shipyard.ShipTypes[0].Type != "SHIP_MINING_DRONE" {
+					t.Errorf("Unexpected shipyard %+v", shipyard)
+				}
+				if len(shipyard.Ships) != 1 || shipyard.Ships[0].PurchasePrice != 45000 {
+					t.Errorf("Expected the ship price, got %+v", shipyard.Ships)
+				}
+			},
+		},
+		{
+			name:     "GetMarket",
+			endpoint: "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market",
+			response: `{"symbol": "X1-TEST-A1", "exports": [{"symbol": "IRON", "name": "Iron", "description": "Iron"}],
+				"imports": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "Ore"}], "exchange": [],
+				"tradeGoods": [{"symbol": "IRON_ORE", "type": "IMPORT", "tradeVolume": 60, "supply": "SCARCE", "purchasePrice": 40, "sellPrice": 36}]}`,
+			call: func(c *Client) (interface{}, error) { return c.GetMarket("X1-TEST", "X1-TEST-A1") },
+			check: func(t *testing.T, result interface{}) {
+				market := result.(*Market)
+				if market.Symbol != "X1-TEST-A1" || len(market.Exports) != 1 || len(market.Imports) != 1 {
+					t.Errorf("Unexpected market %+v", market)
+				}
+				if len(market.TradeGoods) != 1 || market.TradeGoods[0].SellPrice != 36 || market.TradeGoods[0].TradeVolume != 60 {
+					t.Errorf("Expected the trade good prices, got %+v", market.TradeGoods)
+				}
+			},
+		},
+		{
+			name:     "PurchaseShip",
+			endpoint: "POST /my/ships",
+			response: `{"agent": ` + agentJSON + `, "ship": ` + shipJSON + `, "transaction": {"waypointSymbol": "X1-TEST-A1",
+				"shipSymbol": "TEST-1", "shipType": "SHIP_MINING_DRONE", "price": 45000, "agentSymbol": "TEST_AGENT", "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.PurchaseShip(PurchaseShipRequest{ShipType: "SHIP_MINING_DRONE", WaypointSymbol: "X1-TEST-A1"})
+			},
+			request: `"shipType":"SHIP_MINING_DRONE"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*PurchaseShipResponse).Data
+				checkAgent(t, data.Agent)
+				checkShip(t, data.Ship)
+				if data.Transaction.Price != 45000 || data.Transaction.ShipType != "SHIP_MINING_DRONE" {
+					t.Errorf("Unexpected transaction %+v", data.Transaction)
+				}
+			},
+		},
+		{
+			name:     "OrbitShip",
+			endpoint: "POST /my/ships/TEST-1/orbit",
+			response: `{"nav": ` + navJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.OrbitShip("TEST-1") },
+			check:    func(t *testing.T, result interface{}) { checkNav(t, result.(*OrbitResponse).Data.Nav) },
+		},
+		{
+			name:     "DockShip",
+			endpoint: "POST /my/ships/TEST-1/dock",
+			response: `{"nav": ` + navJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.DockShip("TEST-1") },
+			check:    func(t *testing.T, result interface{}) { checkNav(t, result.(*DockResponse).Data.Nav) },
+		},
+		{
+			name:     "NavigateShip",
+			endpoint: "POST /my/ships/TEST-1/navigate",
+			response: `{"nav": ` + navJSON + `, "fuel": ` + fuelJSON + `, "events": [` + eventJSON + `]}`,
+			call:     func(c *Client) (interface{}, error) { return c.NavigateShip("TEST-1", "X1-TEST-B2") },
+			request:  `"waypointSymbol":"X1-TEST-B2"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*NavigateResponse).Data
+				checkNav(t, data.Nav)
+				checkFuel(t, data.Fuel)
+				checkEvent(t, data.Event)
+			},
+		},
+		{
+			name:     "PatchShipNav",
+			endpoint: "PATCH /my/ships/TEST-1/nav",
+			response: `{"nav": ` + navJSON + `, "fuel": ` + fuelJSON + `, "events": []}`,
+			call:     func(c *Client) (interface{}, error) { return c.PatchShipNav("TEST-1", "CRUISE") },
+			request:  `"flightMode":"CRUISE"`,
+			check:    func(t *testing.T, result interface{}) { checkNav(t, result.(*PatchNavResponse).Data) },
+		},
+		{
+			name:     "WarpShip",
+			endpoint: "POST /my/ships/TEST-1/warp",
+			response: `{"nav": ` + navJSON + `, "fuel": ` + fuelJSON + `, "events": [` + eventJSON + `]}`,
+			call:     func(c *Client) (interface{}, error) { return c.WarpShip("TEST-1", "X1-OTHER-A1") },
+			request:  `"waypointSymbol":"X1-OTHER-A1"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*WarpResponse).Data
+				checkNav(t, data.Nav)
+				checkFuel(t, data.Fuel)
+			},
+		},
+		{
+			name:     "JumpShip",
+			endpoint: "POST /my/ships/TEST-1/jump",
+			response: `{"nav": ` + navJSON + `, "cooldown": ` + cooldownJSON + `, "agent": ` + agentJSON + `,
+				"transaction": ` + marketTransactionJSON + `}`,
+			call:    func(c *Client) (interface{}, error) { return c.JumpShip("TEST-1", "X1-OTHER-J1") },
+			request: `"waypointSymbol":"X1-OTHER-J1"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*JumpResponse).Data
+				checkNav(t, data.Nav)
+				checkCooldown(t, data.Cooldown)
+			},
+		},
+		{
+			name:     "GetAllSystems",
+			endpoint: "GET /systems",
+			response: "[" + systemJSON + "]",
+			call:     func(c *Client) (interface{}, error) { return c.GetAllSystems() },
+			check: func(t *testing.T, result interface{}) {
+				systems := result.([]System)
+				if len(systems) != 1 {
+					t.Fatalf("Expected 1 system, got %d", len(systems))
+				}
+				checkSystem(t, systems[0])
+			},
+		},
+		{
+			name:     "GetSystemsPage",
+			endpoint: "GET /systems",
+			response: "[" + systemJSON + "]",
+			call: func(c *Client) (interface{}, error) {
+				systems, total, err := c.GetSystemsPage(1, 20)
+				if err == nil && total != 1 {
+					err = fmt.Errorf("expected a total of 1, got %d", total)
+				}
+				return systems, err
+			},
+			check: func(t *testing.T, result interface{}) {
+				if systems := result.([]System); len(systems) != 1 {
+					t.Errorf("Expected 1 system, got %d", len(systems))
+				}
+			},
+		},
+		{
+			name:     "GetSystem",
+			endpoint: "GET /systems/X1-TEST",
+			response: systemJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetSystem("X1-TEST") },
+			check:    func(t *testing.T, result interface{}) { checkSystem(t, *result.(*System)) },
+		},
+		{
+			name:     "GetAllFactions",
+			endpoint: "GET /factions",
+			response: "[" + factionJSON + "]",
+			call:     func(c *Client) (interface{}, error) { return c.GetAllFactions() },
+			check: func(t *testing.T, result interface{}) {
+				factions := result.([]Faction)
+				if len(factions) != 1 {
+					t.Fatalf("Expected 1 faction, got %d", len(factions))
+				}
+				checkFaction(t, factions[0])
+			},
+		},
+		{
+			name:     "GetFaction",
+			endpoint: "GET /factions/COSMIC",
+			response: factionJSON,
+			call:     func(c *Client) (interface{}, error) { return c.GetFaction("COSMIC") },
+			check:    func(t *testing.T, result interface{}) { checkFaction(t, *result.(*Faction)) },
+		},
+		{
+			name:     "GetMyFactions",
+			endpoint: "GET /my/factions",
+			response: `[{"symbol": "COSMIC", "reputation": 120}]`,
+			call:     func(c *Client) (interface{}, error) { return c.GetMyFactions() },
+			check: func(t *testing.T, result interface{}) {
+				reputations := result.([]FactionReputation)
+				if len(reputations) != 1 || reputations[0].Symbol != "COSMIC" || reputations[0].Reputation != 120 {
+					t.Errorf("Unexpected reputations %+v", reputations)
+				}
+			},
+		},
+		{
+			name:     "SellCargo",
+			endpoint: "POST /my/ships/TEST-1/sell",
+			response: `{"agent": ` + agentJSON + `, "cargo": ` + cargoJSON + `, "transaction": ` + marketTransactionJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.SellCargo("TEST-1", "IRON_ORE", 10) },
+			request:  `"units":10`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*SellCargoResponse).Data
+				checkAgent(t, data.Agent)
+				checkCargo(t, data.Cargo)
+				checkMarketTransaction(t, data.Transaction)
+			},
+		},
+		{
+			name:     "BuyCargo",
+			endpoint: "POST /my/ships/TEST-1/purchase",
+			response: `{"agent": ` + agentJSON + `, "cargo": ` + cargoJSON + `, "transaction": ` + marketTransactionJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.BuyCargo("TEST-1", "IRON_ORE", 10) },
+			request:  `"symbol":"IRON_ORE"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*BuyCargoResponse).Data
+				checkAgent(t, data.Agent)
+				checkCargo(t, data.Cargo)
+				checkMarketTransaction(t, data.Transaction)
+			},
+		},
+		{
+			name:     "ExtractResources",
+			endpoint: "POST /my/ships/TEST-1/extract",
+			response: `{"cooldown": ` + cooldownJSON + `, "cargo": ` + cargoJSON + `, "events": [` + eventJSON + `],
+				"extraction": {"shipSymbol": "TEST-1", "yield": {"symbol": "IRON_ORE", "units": 7}}}`,
+			call: func(c *Client) (interface{}, error) { return c.ExtractResources("TEST-1", nil) },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ExtractResponse).Data
+				checkCooldown(t, data.Cooldown)
+				checkCargo(t, data.Cargo)
+				if data.Extraction.Yield.Symbol != "IRON_ORE" || data.Extraction.Yield.Units != 7 {
+					t.Errorf("Unexpected extraction %+v", data.Extraction)
+				}
+				if len(data.Events) != 1 {
+					t.Fatalf("Expected 1 event, got %d", len(data.Events))
+				}
+				checkEvent(t, data.Events[0])
+			},
+		},
+		{
+			name:     "JettisonCargo",
+			endpoint: "POST /my/ships/TEST-1/jettison",
+			response: `{"cargo": ` + cargoJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.JettisonCargo("TEST-1", "IRON_ORE", 5) },
+			request:  `"units":5`,
+			check:    func(t *testing.T, result interface{}) { checkCargo(t, result.(*JettisonResponse).Data.Cargo) },
+		},
+		{
+			name:     "RefuelShip",
+			endpoint: "POST /my/ships/TEST-1/refuel",
+			response: `{"agent": ` + agentJSON + `, "fuel": ` + fuelJSON + `, "transaction": ` + marketTransactionJSON + `}`,
+			call:     func(c *Client) (interface{}, error) { return c.RefuelShip("TEST-1", nil, true) },
+			request:  `"fromCargo":true`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*RefuelResponse).Data
+				checkAgent(t, data.Agent)
+				checkFuel(t, data.Fuel)
+				checkMarketTransaction(t, data.Transaction)
+			},
+		},
+		{
+			name:     "ScanSystems",
+			endpoint: "POST /my/ships/TEST-1/scan/systems",
+			response: `{"cooldown": ` + cooldownJSON + `, "systems": [{"symbol": "X1-NEAR", "sectorSymbol": "X1", "type": "BLUE_STAR", "x": 1, "y": 2, "distance": 5}]}`,
+			call:     func(c *Client) (interface{}, error) { return c.ScanSystems("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ScanSystemsResponse).Data
+				checkCooldown(t, data.Cooldown)
+				if len(data.Systems) != 1 || data.Systems[0].Symbol != "X1-NEAR" || data.Systems[0].Distance != 5 {
+					t.Errorf("Unexpected systems %+v", data.Systems)
+				}
+			},
+		},
+		{
+			name:     "ScanWaypoints",
+			endpoint: "POST /my/ships/TEST-1/scan/waypoints",
+			response: `{"cooldown": ` + cooldownJSON + `, "waypoints": [{"symbol": "X1-TEST-B2", "type": "ASTEROID", "systemSymbol": "X1-TEST", "x": 30, "y": 40,
+				"orbitals": [], "traits": [{"symbol": "COMMON_METAL_DEPOSITS", "name": "Common Metal Deposits", "description": "Metals"}]}]}`,
+			call: func(c *Client) (interface{}, error) { return c.ScanWaypoints("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ScanWaypointsResponse).Data
+				checkCooldown(t, data.Cooldown)
+				if len(data.Waypoints) != 1 || data.Waypoints[0].Symbol != "X1-TEST-B2" || len(data.Waypoints[0].Traits) != 1 {
+					t.Errorf("Unexpected waypoints %+v", data.Waypoints)
+				}
+			},
+		},
+		{
+			name:     "ScanShips",
+			endpoint: "POST /my/ships/TEST-1/scan/ships",
+			response: `{"cooldown": ` + cooldownJSON + `, "ships": [{"symbol": "OTHER-1", "registration": {"name": "OTHER-1", "factionSymbol": "VOID", "role": "HAULER"},
+				"nav": ` + navJSON + `, "engine": {"symbol": "ENGINE_ION_DRIVE_I"}}]}`,
+			call: func(c *Client) (interface{}, error) { return c.ScanShips("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ScanShipsResponse).Data
+				checkCooldown(t, data.Cooldown)
+				if len(data.Ships) != 1 || data.Ships[0].Symbol != "OTHER-1" || data.Ships[0].Registration.Role != "HAULER" {
+					t.Errorf("Unexpected ships %+v", data.Ships)
+				}
+			},
+		},
+		{
+			name:     "GetRepairQuote",
+			endpoint: "GET /my/ships/TEST-1/repair",
+			response: `{"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "totalPrice": 1500, "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call:     func(c *Client) (interface{}, error) { return c.GetRepairQuote("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				if quote := result.(*RepairTransaction); quote.TotalPrice != 1500 || quote.WaypointSymbol != "X1-TEST-A1" {
+					t.Errorf("Unexpected repair quote %+v", quote)
+				}
+			},
+		},
+		{
+			name:     "RepairShip",
+			endpoint: "POST /my/ships/TEST-1/repair",
+			response: `{"agent": ` + agentJSON + `, "ship": ` + shipJSON + `,
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "totalPrice": 1500, "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call: func(c *Client) (interface{}, error) { return c.RepairShip("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*RepairShipResponse).Data
+				checkAgent(t, data.Agent)
+				checkShip(t, data.Ship)
+				if data.Transaction.TotalPrice != 1500 {
+					t.Errorf("Unexpected transaction %+v", data.Transaction)
+				}
+			},
+		},
+		{
+			name:     "GetScrapValue",
+			endpoint: "GET /my/ships/TEST-1/scrap",
+			response: `{"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "totalPrice": 9000, "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call:     func(c *Client) (interface{}, error) { return c.GetScrapValue("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				if quote := result.(*ScrapTransaction); quote.TotalPrice != 9000 {
+					t.Errorf("Unexpected scrap quote %+v", quote)
+				}
+			},
+		},
+		{
+			name:     "ScrapShip",
+			endpoint: "POST /my/ships/TEST-1/scrap",
+			response: `{"agent": ` + agentJSON + `,
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "totalPrice": 9000, "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call: func(c *Client) (interface{}, error) { return c.ScrapShip("TEST-1") },
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ScrapShipResponse).Data
+				checkAgent(t, data.Agent)
+				if data.Transaction.TotalPrice != 9000 || data.Transaction.ShipSymbol != "TEST-1" {
+					t.Errorf("Unexpected transaction %+v", data.Transaction)
+				}
+			},
+		},
+		{
+			name:     "InstallMount",
+			endpoint: "POST /my/ships/TEST-1/mounts/install",
+			response: `{"agent": ` + agentJSON + `, "cargo": ` + cargoJSON + `,
+				"mounts": [{"symbol": "MOUNT_MINING_LASER_I", "name": "Mining Laser I", "strength": 10, "requirements": {"power": 1, "crew": 0}}],
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "tradeSymbol": "MOUNT_MINING_LASER_I", "totalPrice": 3000, "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call:    func(c *Client) (interface{}, error) { return c.InstallMount("TEST-1", "MOUNT_MINING_LASER_I") },
+			request: `"symbol":"MOUNT_MINING_LASER_I"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ShipModificationResponse).Data
+				checkAgent(t, data.Agent)
+				if len(data.Mounts) != 1 || data.Mounts[0].Strength != 10 || data.Transaction.TotalPrice != 3000 {
+					t.Errorf("Unexpected mounts %+v and transaction %+v", data.Mounts, data.Transaction)
+				}
+			},
+		},
+		{
+			name:     "InstallShipModule",
+			endpoint: "POST /my/ships/TEST-1/modules/install",
+			response: `{"agent": ` + agentJSON + `, "cargo": ` + cargoJSON + `,
+				"modules": [{"symbol": "MODULE_CARGO_HOLD_I", "name": "Cargo Hold", "capacity": 15, "requirements": {"power": 1, "crew": 0, "slots": 1}}],
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "TEST-1", "tradeSymbol": "MODULE_CARGO_HOLD_I", "totalPrice": 4000, "timestamp": "2025-01-01T00:00:00.000Z"}}`,
+			call:    func(c *Client) (interface{}, error) { return c.InstallShipModule("TEST-1", "MODULE_CARGO_HOLD_I") },
+			request: `"symbol":"MODULE_CARGO_HOLD_I"`,
+			check: func(t *testing.T, result interface{}) {
+				data := result.(*ShipModificationResponse).Data
+				checkAgent(t, data.Agent)
+				if len(data.Modules) != 1 || data.Modules[0].Capacity != 15 || data.Transaction.TotalPrice != 4000 {
+					t.Errorf("Unexpected modules %+v and transaction %+v", data.Modules, data.Transaction)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := endpointServer(t, map[string]string{tt.endpoint: tt.response})
+
+			result, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("%s returned error: %v", tt.name, err)
+			}
+			body, called := requests[tt.endpoint]
+			if !called {
+				t.Fatalf("Expected a request to %s, got %v", tt.endpoint, requests)
+			}
+			if tt.request != "" && !strings.Contains(body, tt.request) {
+				t.Errorf("Expected the request body to contain %s, got %s", tt.request, body)
+			}
+			tt.check(t, result)
+
+			// The same call fails cleanly when the API rejects it
+			failing, _ := endpointServer(t, nil)
+			if _, err := tt.call(failing); err == nil {
+				t.Errorf("Expected %s to return an error when the API responds 404", tt.name)
+			}
+		})
+	}
+}
+
+func TestClient_TokenInfo(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"identifier": "TEST_AGENT", "version": "v2.3.0", "reset_date": "2025-06-01", "iat": 1748736000, "sub": "agent-token"}`))
+	c := NewClient("eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9." + claims + ".signature")
+
+	info, err := c.TokenInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if info.Subject != "agent-token" || info.Identifier != "TEST_AGENT" || info.Version != "v2.3.0" || info.ResetDate != "2025-06-01" {
+		t.Errorf("Unexpected token info %+v", info)
+	}
+	if !info.IssuedAt.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the token issued on 2025-06-01, got %v", info.IssuedAt)
+	}
+
+	if _, err := NewClient("not-a-jwt").TokenInfo(); err == nil {
+		t.Error("Expected an error for a token that is not a JWT")
+	}
+}
+
+func TestClient_InvalidJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"contract": `))
+	}))
+	defer server.Close()
+
+	c := NewClientWithBaseURL("test-token", server.URL)
+	if _, err := c.AcceptContract("contract-1"); err == nil {
+		t.Error("Expected an error for a truncated response")
+	}
+}
+
+func TestClient_UnknownEnumValue(t *testing.T) {
+	c, _ := endpointServer(t, map[string]string{
+		"POST /my/ships/TEST-1/orbit": `{"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "HOVERING"}}`,
+	})
+	if _, err := c.OrbitShip("TEST-1"); err == nil {
+		t.Error("Expected an error for a nav status the spec doesn't allow")
+	}
+}
+
+func checkAgent(t *testing.T, agent Agent) {
+	t.Helper()
+	if agent.Symbol != "TEST_AGENT" || agent.Credits != 175000 || agent.Headquarters != "X1-TEST-A1" {
+		t.Errorf("Unexpected agent %+v", agent)
+	}
+}
+
+func checkShip(t *testing.T, ship Ship) {
+	t.Helper()
+	if ship.Symbol != "TEST-1" || ship.Registration.Role != "COMMAND" {
+		t.Errorf("Unexpected ship %s with role %s", ship.Symbol, ship.Registration.Role)
+	}
+	checkNav(t, ship.Nav)
+	checkFuel(t, ship.Fuel)
+	checkCargo(t, ship.Cargo)
+	if ship.Crew.Current != 57 || ship.Crew.Capacity != 80 {
+		t.Errorf("Unexpected crew %+v", ship.Crew)
+	}
+	// Condition and integrity are fractions between 0 and 1
+	if ship.Frame.Condition != 0.87 || ship.Frame.Integrity != 0.95 || ship.Frame.FuelCapacity != 400 {
+		t.Errorf("Unexpected frame %+v", ship.Frame)
+	}
+	if ship.Reactor.Condition != 0.5 || ship.Reactor.Integrity != 0.75 || ship.Reactor.PowerOutput != 31 {
+		t.Errorf("Unexpected reactor %+v", ship.Reactor)
+	}
+	if ship.Engine.Condition != 1 || ship.Engine.Speed != 30 {
+		t.Errorf("Unexpected engine %+v", ship.Engine)
+	}
+}
+
+func checkNav(t *testing.T, nav Navigation) {
+	t.Helper()
+	if nav.SystemSymbol != "X1-TEST" || nav.WaypointSymbol != "X1-TEST-A1" || nav.Status != "IN_ORBIT" || nav.FlightMode != "CRUISE" {
+		t.Errorf("Unexpected nav %+v", nav)
+	}
+	if nav.Route.Destination.Symbol != "X1-TEST-B2" || nav.Route.Destination.X != 30 || nav.Route.Arrival == "" {
+		t.Errorf("Unexpected route %+v", nav.Route)
+	}
+}
+
+func checkFuel(t *testing.T, fuel Fuel) {
+	t.Helper()
+	if fuel.Current != 350 || fuel.Capacity != 400 {
+		t.Errorf("Unexpected fuel %+v", fuel)
+	}
+	if fuel.Consumed == nil || fuel.Consumed.Amount != 50 {
+		t.Errorf("Expected 50 fuel consumed, got %+v", fuel.Consumed)
+	}
+}
+
+func checkCargo(t *testing.T, cargo Cargo) {
+	t.Helper()
+	if cargo.Capacity != 40 || cargo.Units != 10 || len(cargo.Inventory) != 1 || cargo.Inventory[0].Symbol != "IRON_ORE" {
+		t.Errorf("Unexpected cargo %+v", cargo)
+	}
+}
+
+func checkCooldown(t *testing.T, cooldown Cooldown) {
+	t.Helper()
+	if cooldown.ShipSymbol != "TEST-1" || cooldown.TotalSeconds != 70 || cooldown.RemainingSeconds != 69 || cooldown.Expiration == "" {
+		t.Errorf("Unexpected cooldown %+v", cooldown)
+	}
+}
+
+func checkEvent(t *testing.T, event Event) {
+	t.Helper()
+	if event.Symbol != "ENGINE_ACTUATOR_FAILURE" || event.Component != "ENGINE" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
+
+func checkContract(t *testing.T, contract Contract) {
+	t.Helper()
+	if contract.ID != "contract-1" || contract.FactionSymbol != "COSMIC" || contract.Type != "PROCUREMENT" || !contract.Accepted {
+		t.Errorf("Unexpected contract %+v", contract)
+	}
+	if contract.Terms.Payment.OnAccepted != 10000 || contract.Terms.Payment.OnFulfilled != 40000 {
+		t.Errorf("Unexpected payment %+v", contract.Terms.Payment)
+	}
+	if len(contract.Terms.Deliver) != 1 || contract.Terms.Deliver[0].UnitsRequired != 30 || contract.Terms.Deliver[0].UnitsFulfilled != 10 {
+		t.Errorf("Unexpected deliveries %+v", contract.Terms.Deliver)
+	}
+	if contract.Expiration == "" || contract.DeadlineToAccept == "" {
+		t.Errorf("Expected the contract deadlines, got %+v", contract)
+	}
+}
+
+func checkMarketTransaction(t *testing.T, transaction MarketTransaction) {
+	t.Helper()
+	if transaction.TradeSymbol != "IRON_ORE" || transaction.Units != 10 || transaction.PricePerUnit != 12 || transaction.TotalPrice != 120 {
+		t.Errorf("Unexpected transaction %+v", transaction)
+	}
+}
+
+func TestClient_RecordsRateLimit(t *testing.T) {
+	throttle := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Type", "IP_ADDRESS")
+		w.Header().Set("X-Ratelimit-Limit-Per-Second", "2")
+		w.Header().Set("X-Ratelimit-Limit-Burst", "30")
+		w.Header().Set("X-Ratelimit-Reset", "2025-01-01T00:00:01.000Z")
+		if throttle {
+			w.Header().Set("X-Ratelimit-Remaining", "0")
+			w.Header().Set("Retry-After", "1.5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": {"message": "You have reached your API limit.", "code": 429}}`))
+			return
+		}
+		w.Header().Set("X-Ratelimit-Remaining", "12")
+		_, _ = fmt.Fprintf(w, `{"data": %s}`, agentJSON)
+	}))
+	defer server.Close()
+	c := NewClientWithBaseURL("test-token", server.URL)
+
+	if _, observed := c.RateLimitStatus(); observed {
+		t.Error("Expected no rate limit before any request")
+	}
+
+	start := time.Now()
+	if _, err := c.GetAgent(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	status, observed := c.RateLimitStatus()
+	if !observed || status.Remaining != 12 || status.LimitPerSecond != 2 || status.Burst != 30 || status.Type != "IP_ADDRESS" {
+		t.Errorf("Unexpected rate limit %+v", status)
+	}
+	if _, throttled := c.ThrottledSince(start); throttled {
+		t.Error("Expected a successful request not to count as throttled")
+	}
+
+	throttle = true
+	if _, err := c.GetAgent(); err == nil {
+		t.Fatal("Expected an error for a 429 response")
+	}
+	status, throttled := c.ThrottledSince(start)
+	if !throttled || status.Remaining != 0 || status.RetryAfterSeconds != 1.5 {
+		t.Errorf("Expected the 429 to be recorded, got %+v", status)
+	}
+	if _, throttled := c.ThrottledSince(time.Now().Add(time.Second)); throttled {
+		t.Error("Expected no throttling after the 429")
+	}
+	for _, want := range []string{"0 requests remaining", "2 per second with bursts of 30", "Retry after 1.5s", "spacetraders://api/rate-limit"} {
+		if !strings.Contains(status.Describe(), want) {
+			t.Errorf("Expected %q in %q", want, status.Describe())
+		}
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	failing := true
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": {"message": "Service unavailable", "code": 503}}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data": %s}`, agentJSON)
+	}))
+	defer server.Close()
+	c := NewClientWithBaseURL("test-token", server.URL)
+	c.SetCircuitBreaker(2, time.Minute)
+	now := time.Now()
+	c.breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetAgent(); err == nil || errors.Is(err, ErrUpstreamDegraded) {
+			t.Fatalf("Expected call %d to reach the API and fail, got %v", i+1, err)
+		}
+	}
+	_, err := c.GetAgent()
+	var open *CircuitOpenError
+	if !errors.Is(err, ErrUpstreamDegraded) || !errors.As(err, &open) || open.Class != "agents" || !open.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Expected the open circuit to reject the call, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected the rejected call not to reach the API, got %d requests", requests)
+	}
+	if circuits := c.Circuits(); len(circuits) != 1 || circuits[0].State != CircuitOpen || circuits[0].Rejected != 1 || circuits[0].ConsecutiveFailures != 2 {
+		t.Errorf("Expected the agents circuit to be open with one rejection, got %+v", circuits)
+	}
+
+	// Other endpoint classes are still called
+	if _, err := c.GetFaction("COSMIC"); errors.Is(err, ErrUpstreamDegraded) || requests != 3 {
+		t.Errorf("Expected the factions call to reach the API, got %v after %d requests", err, requests)
+	}
+
+	// After the cooldown one probe is let through, and its failure opens the circuit again
+	now = now.Add(time.Minute)
+	if _, err := c.GetAgent(); errors.Is(err, ErrUpstreamDegraded) || requests != 4 {
+		t.Fatalf("Expected the probe to reach the API, got %v after %d requests", err, requests)
+	}
+	if _, err := c.GetAgent(); !errors.Is(err, ErrUpstreamDegraded) {
+		t.Fatalf("Expected the failed probe to open the circuit again, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	failing = false
+	if _, err := c.GetAgent(); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+	for _, circuit := range c.Circuits() {
+		if circuit.Class == "agents" {
+			t.Errorf("Expected the agents circuit to close after a successful probe, got %+v", circuit)
+		}
+	}
+}
+
+func TestEndpointClass(t *testing.T) {
+	for path, want := range map[string]string{
+		"/v2/my/ships/SHIP-1/navigate":          "fleet",
+		"/my/agent":                             "agents",
+		"/v2/agents/OTHER":                      "agents",
+		"/v2/my/contracts/abc/accept":           "contracts",
+		"/v2/systems/X1/waypoints/X1-A1/market": "systems",
+		"/v2/factions/COSMIC":                   "factions",
+		"/v2/market/supply-chain":               "data",
+		"/v2/":                                  "global",
+		"/v2/register":                          "global",
+	} {
+		if got := endpointClass(path); got != want {
+			t.Errorf("endpointClass(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestPublicEndpoint(t *testing.T) {
+	for request, want := range map[string]bool{
+		"GET /v2/systems":                                         true,
+		"GET /v2/systems/X1":                                      true,
+		"GET /v2/systems/X1/waypoints":                            true,
+		"GET /v2/systems/X1/waypoints/X1-A1":                      true,
+		"GET /v2/systems/X1/waypoints/X1-A1/jump-gate":            true,
+		"GET /v2/systems/X1/waypoints/X1-A1/construction":         true,
+		"GET /v2/factions":                                        true,
+		"GET /v2/factions/COSMIC":                                 true,
+		"GET /v2/market/supply-chain":                             true,
+		"GET /v2/systems/X1/waypoints/X1-A1/market":               false,
+		"GET /v2/systems/X1/waypoints/X1-A1/shipyard":             false,
+		"POST /v2/systems/X1/waypoints/X1-A1/construction/supply": false,
+		"GET /v2/my/agent":                                        false,
+		"GET /v2/my/ships":                                        false,
+		"GET /v2/agents/OTHER":                                    false,
+		"GET /v2/":                                                false,
+	} {
+		method, path, _ := strings.Cut(request, " ")
+		if got := publicEndpoint(method, path); got != want {
+			t.Errorf("publicEndpoint(%q) = %v, want %v", request, got, want)
+		}
+	}
+}
+
+func TestClient_SecondaryTokens(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string][]string)
+	refuse := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		mu.Lock()
+		seen[r.URL.Path] = append(seen[r.URL.Path], auth)
+		status := refuse[auth]
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if status != 0 {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error": {"message": "refused"}}`))
+			return
+		}
+		w.Header().Set("X-Ratelimit-Remaining", strings.TrimPrefix(auth, "Bearer "))
+		switch r.URL.Path {
+		case "/my/agent":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, agentJSON)
+		case "/systems/X1-TEST":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, systemJSON)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := NewClientWithBaseURL("1", server.URL)
+	c.SetSecondaryTokens([]string{"2", " ", "3"})
+
+	for range 3 {
+		if _, err := c.GetSystem("X1-TEST"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if _, err := c.GetAgent(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := strings.Join(seen["/systems/X1-TEST"], ","); got != "Bearer 2,Bearer 3,Bearer 2" {
+		t.Errorf("Expected the secondary tokens in turn, got %s", got)
+	}
+	if got := strings.Join(seen["/my/agent"], ","); got != "Bearer 1" {
+		t.Errorf("Expected the primary token for the agent, got %s", got)
+	}
+	// Secondary responses carry another quota, so only the primary's is recorded
+	if status, _ := c.RateLimitStatus(); status.Remaining != 1 {
+		t.Errorf("Expected the primary token's rate limit, got %+v", status)
+	}
+
+	// A throttled token is skipped until Retry-After, a rejected one for good, and the primary
+	// token sends what they were refused
+	mu.Lock()
+	refuse["Bearer 3"] = http.StatusTooManyRequests
+	refuse["Bearer 2"] = http.StatusUnauthorized
+	seen = make(map[string][]string)
+	mu.Unlock()
+	for range 3 {
+		if _, err := c.GetSystem("X1-TEST"); err != nil {
+			t.Fatalf("Expected the primary token to recover the request: %v", err)
+		}
+	}
+	if got := strings.Join(seen["/systems/X1-TEST"], ","); got != "Bearer 3,Bearer 1,Bearer 2,Bearer 1,Bearer 1" {
+		t.Errorf("Unexpected tokens after refusals: %s", got)
+	}
+
+	statuses := c.SecondaryTokens()
+	if len(statuses) != 2 {
+		t.Fatalf("Expected 2 secondary tokens, got %+v", statuses)
+	}
+	if statuses[0].Index != 1 || !statuses[0].Revoked || statuses[0].Fallbacks != 1 || statuses[0].Requests != 3 {
+		t.Errorf("Expected the first token to be revoked, got %+v", statuses[0])
+	}
+	if statuses[1].ThrottledUntil == nil || statuses[1].Revoked || statuses[1].Fallbacks != 1 {
+		t.Errorf("Expected the second token to be throttled, got %+v", statuses[1])
+	}
+}
+
+func checkSystem(t *testing.T, system System) {
+	t.Helper()
+	if system.Symbol != "X1-TEST" || system.SectorSymbol != "X1" || system.Type != "RED_STAR" || system.X != 10 || system.Y != 20 {
+		t.Errorf("Unexpected system %+v", system)
+	}
+}
+
+func checkFaction(t *testing.T, faction Faction) {
+	t.Helper()
+	if faction.Symbol != "COSMIC" || faction.Name != "Cosmic Engineers" || faction.Headquarters != "X1-TEST-A1" || !faction.IsRecruiting {
+		t.Errorf("Unexpected faction %+v", faction)
+	}
+}
+
+// Keep the fixtures valid JSON, so a typo fails here rather than as a confusing conversion error
+func TestClient_FixturesAreValidJSON(t *testing.T) {
+	for name, fixture := range map[string]string{
+		"agent": agentJSON, "nav": navJSON, "fuel": fuelJSON, "cargo": cargoJSON, "cooldown": cooldownJSON, "event": eventJSON,
+		"contract": contractJSON, "marketTransaction": marketTransactionJSON, "system": systemJSON, "faction": factionJSON,
+		"waypoint": waypointJSON, "ship": shipJSON,
+	} {
+		if !json.Valid([]byte(fixture)) {
+			t.Errorf("Fixture %s is not valid JSON", name)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package client
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	spacetraders "github.com/grantmd/spacetraders-mcp/spacetraders"
+)
+
+// These tests check the converters against the generated client, so regenerating it from a newer
+// spec can't silently lose data. Every generated field must either have a wrapper field with the
+// same JSON name or be listed as unmapped, so a field the spec adds fails here until it is
+// converted or deliberately skipped. And converting a fully populated generated value must leave
+// no wrapper field at its zero value, so a field that exists on both sides but isn't copied fails
+// too. Run them after `go generate ./pkg/client`.
+
+// converterCase is one converter, from a generated model to a wrapper type
+type converterCase struct {
+	name string
+	// gen points at the generated value; it is populated before convert is called
+	gen     interface{}
+	convert func() interface{}
+	// unmapped are generated JSON fields the wrapper deliberately leaves out
+	unmapped []string
+	// unset are wrapper field paths the converter deliberately leaves zero
+	unset []string
+}
+
+func converterCases() []converterCase {
+	var (
+		agent               spacetraders.Agent
+		ship                spacetraders.Ship
+		registration        spacetraders.ShipRegistration
+		nav                 spacetraders.ShipNav
+		route               spacetraders.ShipNavRoute
+		routeWaypoint       spacetraders.ShipNavRouteWaypoint
+		crew                spacetraders.ShipCrew
+		frame               spacetraders.ShipFrame
+		reactor             spacetraders.ShipReactor
+		engine              spacetraders.ShipEngine
+		requirements        spacetraders.ShipRequirements
+		cooldown            spacetraders.Cooldown
+		module              spacetraders.ShipModule
+		mount               spacetraders.ShipMount
+		cargo               spacetraders.ShipCargo
+		fuel                spacetraders.ShipFuel
+		terms               spacetraders.ContractTerms
+		trait               spacetraders.WaypointTrait
+		modifier            spacetraders.WaypointModifier
+		chart               spacetraders.Chart
+		waypoint            spacetraders.Waypoint
+		systemWaypoint      spacetraders.SystemWaypoint
+		marketTransaction   spacetraders.MarketTransaction
+		shipyardTransaction spacetraders.ShipyardTransaction
+		tradeGood           spacetraders.TradeGood
+		marketTradeGood     spacetraders.MarketTradeGood
+		extraction          spacetraders.Extraction
+		event               spacetraders.ShipConditionEvent
+		scannedSystem       spacetraders.ScannedSystem
+		scannedWaypoint     spacetraders.ScannedWaypoint
+		repair              spacetraders.RepairTransaction
+		scrap               spacetraders.ScrapTransaction
+		modification        spacetraders.ShipModificationTransaction
+	)
+
+	return []converterCase{
+		{name: "Agent", gen: &agent, convert: func() interface{} { return convertAgentFromGenerated(agent) }},
+		{name: "Ship", gen: &ship, convert: func() interface{} { return convertShipFromGenerated(ship) }},
+		{name: "ShipRegistration", gen: &registration, convert: func() interface{} { return convertRegistration(registration) }},
+		{name: "ShipNav", gen: &nav, convert: func() interface{} { return convertNavigation(nav) }},
+		{name: "ShipNavRoute", gen: &route, convert: func() interface{} { return convertRoute(route) }},
+		{
+			name: "ShipNavRouteWaypoint", gen: &routeWaypoint,
+			convert:  func() interface{} { return convertWaypointFromNavRoute(routeWaypoint) },
+			unmapped: []string{"systemSymbol"},
+		},
+		{name: "ShipCrew", gen: &crew, convert: func() interface{} { return convertCrew(crew) }},
+		{name: "ShipFrame", gen: &frame, convert: func() interface{} { return convertFrame(frame) }},
+		{name: "ShipReactor", gen: &reactor, convert: func() interface{} { return convertReactor(reactor) }},
+		{name: "ShipEngine", gen: &engine, convert: func() interface{} { return convertEngine(engine) }},
+		{name: "ShipRequirements", gen: &requirements, convert: func() interface{} { return convertShipRequirements(requirements) }},
+		{name: "Cooldown", gen: &cooldown, convert: func() interface{} { return convertCooldown(cooldown) }},
+		{
+			name: "ShipModule", gen: &module,
+			convert: func() interface{} { return convertModules([]spacetraders.ShipModule{module})[0] },
+		},
+		{
+			name: "ShipMount", gen: &mount,
+			convert: func() interface{} { return convertMounts([]spacetraders.ShipMount{mount})[0] },
+		},
+		{name: "ShipCargo", gen: &cargo, convert: func() interface{} { return convertCargo(cargo) }},
+		{name: "ShipFuel", gen: &fuel, convert: func() interface{} { return convertFuel(fuel) }},
+		{name: "ContractTerms", gen: &terms, convert: func() interface{} { return convertContractTerms(terms) }},
+		{
+			name: "WaypointTrait", gen: &trait,
+			convert: func() interface{} { return convertWaypointTraits([]spacetraders.WaypointTrait{trait})[0] },
+		},
+		{
+			name: "WaypointModifier", gen: &modifier,
+			convert: func() interface{} { return convertWaypointModifiers([]spacetraders.WaypointModifier{modifier})[0] },
+		},
+		{name: "Chart", gen: &chart, convert: func() interface{} { return *convertChart(&chart) }},
+		{
+			name: "Waypoint", gen: &waypoint,
+			convert:  func() interface{} { return convertWaypoints([]spacetraders.Waypoint{waypoint})[0] },
+			unmapped: []string{"systemSymbol"},
+		},
+		{
+			name: "SystemWaypoint", gen: &systemWaypoint,
+			convert: func() interface{} { return convertSystemWaypoints([]spacetraders.SystemWaypoint{systemWaypoint})[0] },
+			unset:   []string{"Traits", "Modifiers", "Chart", "Faction", "IsUnderConstruction"},
+		},
+		{name: "MarketTransaction", gen: &marketTransaction, convert: func() interface{} { return convertMarketTransactionFromGenerated(marketTransaction) }},
+		{name: "ShipyardTransaction", gen: &shipyardTransaction, convert: func() interface{} { return convertTransactionFromGenerated(shipyardTransaction) }},
+		{
+			name: "TradeGood", gen: &tradeGood,
+			convert: func() interface{} { return convertTradeGoods([]spacetraders.TradeGood{tradeGood})[0] },
+		},
+		{
+			name: "MarketTradeGood", gen: &marketTradeGood,
+			convert: func() interface{} { return convertMarketTradeGoods([]spacetraders.MarketTradeGood{marketTradeGood})[0] },
+		},
+		{name: "Extraction", gen: &extraction, convert: func() interface{} { return convertExtraction(extraction) }},
+		{
+			name: "ShipConditionEvent", gen: &event,
+			convert: func() interface{} { return convertEvents([]spacetraders.ShipConditionEvent{event})[0] },
+		},
+		{
+			name: "ScannedSystem", gen: &scannedSystem,
+			convert: func() interface{} { return convertScannedSystems([]spacetraders.ScannedSystem{scannedSystem})[0] },
+		},
+		{
+			name: "ScannedWaypoint", gen: &scannedWaypoint,
+			convert: func() interface{} { return convertScannedWaypoints([]spacetraders.ScannedWaypoint{scannedWaypoint})[0] },
+		},
+		{name: "RepairTransaction", gen: &repair, convert: func() interface{} { return convertRepairTransactionFromGenerated(repair) }},
+		{name: "ScrapTransaction", gen: &scrap, convert: func() interface{} { return convertScrapTransactionFromGenerated(scrap) }},
+		{name: "ShipModificationTransaction", gen: &modification, convert: func() interface{} { return convertModificationTransactionFromGenerated(modification) }},
+	}
+}
+
+func TestConverters_MapEveryGeneratedField(t *testing.T) {
+	for _, tc := range converterCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			populate(reflect.ValueOf(tc.gen).Elem(), 0)
+			converted := reflect.ValueOf(tc.convert())
+
+			wrapperFields := jsonFields(converted.Type())
+			unmapped := toSet(tc.unmapped)
+			for _, name := range sortedKeys(jsonFields(reflect.TypeOf(tc.gen).Elem())) {
+				if !wrapperFields[name] && !unmapped[name] {
+					t.Errorf("Generated field %q has no %s field; convert it, or list it as unmapped if it isn't needed",
+						name, converted.Type().Name())
+				}
+			}
+			for name := range unmapped {
+				if wrapperFields[name] {
+					t.Errorf("Field %q is listed as unmapped but %s has it; remove it from the list", name, converted.Type().Name())
+				}
+			}
+		})
+	}
+}
+
+func TestConverters_CopyEveryField(t *testing.T) {
+	for _, tc := range converterCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			populate(reflect.ValueOf(tc.gen).Elem(), 0)
+			converted := reflect.ValueOf(tc.convert())
+
+			unset := toSet(tc.unset)
+			for _, path := range zeroFields(converted, "") {
+				if !unset[path] {
+					t.Errorf("%s.%s is zero after converting a fully populated %s; the converter doesn't copy it",
+						converted.Type().Name(), path, tc.name)
+				}
+			}
+		})
+	}
+}
+
+// maxDepth bounds how deep populate follows nested structs
+const maxDepth = 6
+
+// populate sets every field of v to a non-zero value, allocating pointers and giving slices one element
+func populate(v reflect.Value, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("TEST")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(0.5)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(v.Elem(), depth+1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		populate(v.Index(0), depth+1)
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			v.Set(reflect.MakeMap(v.Type()))
+			value := reflect.New(v.Type().Elem()).Elem()
+			populate(value, depth+1)
+			v.SetMapIndex(reflect.ValueOf("TEST").Convert(v.Type().Key()), value)
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				populate(v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// zeroFields lists the paths of fields in v left at their zero value, looking inside nested
+// structs, pointers and the first element of slices
+func zeroFields(v reflect.Value, path string) []string {
+	var zero []string
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return []string{path}
+		}
+		return zeroFields(v.Elem(), path)
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return []string{path}
+		}
+		if v.Kind() == reflect.Slice {
+			return zeroFields(v.Index(0), path)
+		}
+		return nil
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			if v.Interface().(time.Time).IsZero() {
+				return []string{path}
+			}
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			zero = append(zero, zeroFields(v.Field(i), fieldPath)...)
+		}
+		return zero
+	default:
+		if v.IsZero() {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+// jsonFields returns the JSON names of a struct type's exported fields
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -333,7 +333,9 @@ func convertWaypointFaction(gen *spacetraders.WaypointFaction) *WaypointFaction
 	}
 }
 
-// convertSystemWaypoints converts generated SystemWaypoint slice to wrapper SystemWaypoint slice
+// convertSystemWaypoints converts generated SystemWaypoint slice to wrapper SystemWaypoint slice.
+// Systems only list where their waypoints are, so traits, modifiers, chart and faction stay
+// empty; use convertWaypoints for waypoints fetched with their details.
 func convertSystemWaypoints(gen []spacetraders.SystemWaypoint) []SystemWaypoint {
 	waypoints := make([]SystemWaypoint, len(gen))
 	for i, w := range gen {
@@ -343,6 +345,29 @@ func convertSystemWaypoints(gen []spacetraders.SystemWaypoint) []SystemWaypoint
 			X:        int(w.X),
 			Y:        int(w.Y),
 			Orbitals: convertOrbitals(w.Orbitals),
+			Orbits:   w.GetOrbits(),
+		}
+	}
+	return waypoints
+}
+
+// convertWaypoints converts generated Waypoint slice to wrapper SystemWaypoint slice, keeping
+// the traits, modifiers, chart and faction the waypoints endpoints return
+func convertWaypoints(gen []spacetraders.Waypoint) []SystemWaypoint {
+	waypoints := make([]SystemWaypoint, len(gen))
+	for i, w := range gen {
+		waypoints[i] = SystemWaypoint{
+			Symbol:              w.Symbol,
+			Type:                string(w.Type),
+			X:                   int(w.X),
+			Y:                   int(w.Y),
+			Orbitals:            convertOrbitals(w.Orbitals),
+			Orbits:              w.GetOrbits(),
+			Traits:              convertWaypointTraits(w.Traits),
+			Modifiers:           convertWaypointModifiers(w.Modifiers),
+			Chart:               convertChart(w.Chart),
+			Faction:             convertWaypointFaction(w.Faction),
+			IsUnderConstruction: w.IsUnderConstruction,
 		}
 	}
 	return waypoints
@@ -754,6 +779,27 @@ func convertRepairTransactionFromGenerated(gen spacetraders.RepairTransaction) R
 	}
 }
 
+// convertScrapTransactionFromGenerated converts a generated scrap transaction
+func convertScrapTransactionFromGenerated(gen spacetraders.ScrapTransaction) ScrapTransaction {
+	return ScrapTransaction{
+		WaypointSymbol: gen.WaypointSymbol,
+		ShipSymbol:     gen.ShipSymbol,
+		TotalPrice:     int(gen.TotalPrice),
+		Timestamp:      gen.Timestamp.Format("2006-01-02T15:04:05.000Z"),
+	}
+}
+
+// convertModificationTransactionFromGenerated converts a generated mount installation transaction
+func convertModificationTransactionFromGenerated(gen spacetraders.ShipModificationTransaction) ModificationTransaction {
+	return ModificationTransaction{
+		WaypointSymbol: gen.WaypointSymbol,
+		ShipSymbol:     gen.ShipSymbol,
+		TradeSymbol:    gen.TradeSymbol,
+		TotalPrice:     int(gen.TotalPrice),
+		Timestamp:      gen.Timestamp.Format("2006-01-02T15:04:05.000Z"),
+	}
+}
+
 // convertEventFromTransaction converts a transaction to an event
 func convertEventFromTransaction(gen spacetraders.MarketTransaction) Event {
 	return Event{
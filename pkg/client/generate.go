@@ -0,0 +1,7 @@
+package client
+
+// Regenerate the OpenAPI client in generated/spacetraders from the latest SpaceTraders spec, then
+// run this package's tests: the compatibility tests in compat_test.go fail when the new spec adds
+// fields the converters here don't copy. Set OPENAPI_SPEC to generate from a local file instead.
+//
+//go:generate make -C ../.. generate-client
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the rate-limit state the API reported in its response headers
+type RateLimit struct {
+	Type           string `json:"type,omitempty"`
+	Remaining      int    `json:"remaining"`
+	LimitPerSecond int    `json:"limitPerSecond,omitempty"`
+	Burst          int    `json:"burst,omitempty"`
+	ResetAt        string `json:"resetAt,omitempty"`
+}
+
+// PingResult is the outcome of a single authenticated round trip to the API
+type PingResult struct {
+	Agent      *Agent
+	StatusCode int
+	Latency    time.Duration
+	RateLimit  *RateLimit
+}
+
+// Ping fetches the agent to check that the API is reachable and the token is accepted.
+// The result is returned even on failure, with the status code set when the API answered.
+func (c *Client) Ping() (*PingResult, error) {
+	start := time.Now()
+	resp, httpResp, err := c.apiClient.AgentsAPI.GetMyAgent(c.ctx).Execute()
+
+	result := &PingResult{Latency: time.Since(start)}
+	if httpResp != nil {
+		result.StatusCode = httpResp.StatusCode
+		result.RateLimit = parseRateLimit(httpResp.Header)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	agent := convertAgentFromGenerated(resp.Data)
+	result.Agent = &agent
+	return result, nil
+}
+
+// parseRateLimit reads the x-ratelimit-* headers, returning nil when the API sent none
+func parseRateLimit(header http.Header) *RateLimit {
+	remaining := header.Get("X-Ratelimit-Remaining")
+	if remaining == "" {
+		return nil
+	}
+
+	limit := &RateLimit{
+		Type:    header.Get("X-Ratelimit-Type"),
+		ResetAt: header.Get("X-Ratelimit-Reset"),
+	}
+	limit.Remaining, _ = strconv.Atoi(remaining)
+	limit.LimitPerSecond, _ = strconv.Atoi(header.Get("X-Ratelimit-Limit-Per-Second"))
+	limit.Burst, _ = strconv.Atoi(header.Get("X-Ratelimit-Limit-Burst"))
+	return limit
+}
+
+// CacheStats describes what the client currently holds in its caches
+type CacheStats struct {
+	WaypointSystems      int    `json:"waypointSystems"`
+	JumpGates            int    `json:"jumpGates"`
+	SupplyChainCached    bool   `json:"supplyChainCached"`
+	SupplyChainFetchedAt string `json:"supplyChainFetchedAt,omitempty"`
+}
+
+// CacheStats reports the cache entries that are still fresh
+func (c *Client) CacheStats() CacheStats {
+	var stats CacheStats
+
+	c.waypointCacheMu.Lock()
+	for _, entry := range c.waypointCache {
+		if time.Since(entry.fetchedAt) < WaypointCacheTTL {
+			stats.WaypointSystems++
+		}
+	}
+	c.waypointCacheMu.Unlock()
+
+	c.jumpGateCacheMu.Lock()
+	for _, entry := range c.jumpGateCache {
+		if time.Since(entry.fetchedAt) < JumpGateCacheTTL {
+			stats.JumpGates++
+		}
+	}
+	c.jumpGateCacheMu.Unlock()
+
+	c.supplyChainMu.Lock()
+	if c.supplyChain != nil && time.Since(c.supplyChainFetchedAt) < SupplyChainCacheTTL {
+		stats.SupplyChainCached = true
+		stats.SupplyChainFetchedAt = c.supplyChainFetchedAt.UTC().Format(time.RFC3339)
+	}
+	c.supplyChainMu.Unlock()
+
+	return stats
+}
+
+// ServerStatus is the public status of the SpaceTraders server, including its reset schedule
+type ServerStatus struct {
+	Status         string `json:"status"`
+	Version        string `json:"version"`
+	ResetDate      string `json:"resetDate"`
+	NextReset      string `json:"nextReset"`
+	ResetFrequency string `json:"resetFrequency"`
+}
+
+// GetServerStatus returns the server status. It does not need a valid token.
+func (c *Client) GetServerStatus() (*ServerStatus, error) {
+	resp, _, err := c.apiClient.GlobalAPI.GetStatus(c.ctx).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server status: %w", err)
+	}
+
+	return &ServerStatus{
+		Status:         resp.Status,
+		Version:        resp.Version,
+		ResetDate:      resp.ResetDate,
+		NextReset:      resp.ServerResets.Next,
+		ResetFrequency: resp.ServerResets.Frequency,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"sort"
+	"time"
+)
+
+// JumpGateCacheTTL is how long a jump gate's connections are reused before they are fetched again.
+// Connections only change on a server reset or when a gate under construction is completed.
+const JumpGateCacheTTL = time.Hour
+
+// cachedJumpGate is a jump gate and when it was fetched
+type cachedJumpGate struct {
+	gate      JumpGate
+	fetchedAt time.Time
+}
+
+// GetCachedJumpGate returns the jump gate at a waypoint, reusing a previous fetch younger than
+// JumpGateCacheTTL
+func (c *Client) GetCachedJumpGate(systemSymbol, waypointSymbol string) (*JumpGate, error) {
+	c.jumpGateCacheMu.Lock()
+	entry, ok := c.jumpGateCache[waypointSymbol]
+	c.jumpGateCacheMu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < JumpGateCacheTTL {
+		gate := entry.gate
+		return &gate, nil
+	}
+
+	gate, err := c.GetJumpGate(systemSymbol, waypointSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	c.jumpGateCacheMu.Lock()
+	if c.jumpGateCache == nil {
+		c.jumpGateCache = make(map[string]cachedJumpGate)
+	}
+	c.jumpGateCache[waypointSymbol] = cachedJumpGate{gate: *gate, fetchedAt: time.Now()}
+	c.jumpGateCacheMu.Unlock()
+
+	return gate, nil
+}
+
+// KnownJumpGates returns the jump gate waypoints the client has already seen, either in a cached
+// system waypoint list or as a fetched gate or one of its connections. It makes no API calls.
+func (c *Client) KnownJumpGates() []string {
+	known := make(map[string]bool)
+
+	c.waypointCacheMu.Lock()
+	for _, entry := range c.waypointCache {
+		if time.Since(entry.fetchedAt) >= WaypointCacheTTL {
+			continue
+		}
+		for _, waypoint := range entry.waypoints {
+			if waypoint.Type == "JUMP_GATE" {
+				known[waypoint.Symbol] = true
+			}
+		}
+	}
+	c.waypointCacheMu.Unlock()
+
+	c.jumpGateCacheMu.Lock()
+	for symbol, entry := range c.jumpGateCache {
+		if time.Since(entry.fetchedAt) >= JumpGateCacheTTL {
+			continue
+		}
+		known[symbol] = true
+		for _, connection := range entry.gate.Connections {
+			known[connection] = true
+		}
+	}
+	c.jumpGateCacheMu.Unlock()
+
+	gates := make([]string, 0, len(known))
+	for symbol := range known {
+		gates = append(gates, symbol)
+	}
+	sort.Strings(gates)
+	return gates
+}
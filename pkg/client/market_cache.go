@@ -0,0 +1,74 @@
+package client
+
+import "time"
+
+// MarketListingCacheTTL is how long a market's import, export and exchange lists are reused before
+// they are fetched again. What a market trades only changes on a server reset; prices change
+// constantly and are never served from this cache.
+const MarketListingCacheTTL = time.Hour
+
+// cachedMarketListing is a market's trade lists and when they were fetched
+type cachedMarketListing struct {
+	market    Market
+	fetchedAt time.Time
+}
+
+// GetCachedMarketListing returns what a market imports, exports and exchanges, reusing a previous
+// fetch younger than MarketListingCacheTTL. Every GetMarket call refreshes the cache. Transactions
+// and trade good prices are left out, since they go stale long before the lists do. The returned
+// time is when the lists were fetched.
+func (c *Client) GetCachedMarketListing(systemSymbol, waypointSymbol string) (*Market, time.Time, error) {
+	c.marketListingCacheMu.Lock()
+	entry, ok := c.marketListingCache[waypointSymbol]
+	c.marketListingCacheMu.Unlock()
+	if !ok || time.Since(entry.fetchedAt) >= MarketListingCacheTTL {
+		market, err := c.GetMarket(systemSymbol, waypointSymbol)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		entry = cachedMarketListing{market: marketListing(market), fetchedAt: time.Now()}
+	}
+
+	market := entry.market
+	return &market, entry.fetchedAt, nil
+}
+
+// cacheMarketListing stores the trade lists of a fetched market
+func (c *Client) cacheMarketListing(market *Market) {
+	c.marketListingCacheMu.Lock()
+	defer c.marketListingCacheMu.Unlock()
+
+	if c.marketListingCache == nil {
+		c.marketListingCache = make(map[string]cachedMarketListing)
+	}
+	c.marketListingCache[market.Symbol] = cachedMarketListing{market: marketListing(market), fetchedAt: time.Now()}
+}
+
+// marketListing returns a copy of a market with only its trade lists
+func marketListing(market *Market) Market {
+	return Market{
+		Symbol:   market.Symbol,
+		Exports:  market.Exports,
+		Imports:  market.Imports,
+		Exchange: market.Exchange,
+	}
+}
+
+// KnownTradeGoods returns the name and description of every trade good listed by a market fetched
+// so far, by trade symbol. It makes no API calls.
+func (c *Client) KnownTradeGoods() map[string]TradeGood {
+	c.marketListingCacheMu.Lock()
+	defer c.marketListingCacheMu.Unlock()
+
+	goods := make(map[string]TradeGood)
+	for _, entry := range c.marketListingCache {
+		for _, list := range [][]TradeGood{entry.market.Exports, entry.market.Imports, entry.market.Exchange} {
+			for _, good := range list {
+				if good.Name != "" {
+					goods[good.Symbol] = good
+				}
+			}
+		}
+	}
+	return goods
+}
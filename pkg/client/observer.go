@@ -0,0 +1,190 @@
+package client
+
+import "time"
+
+// ObservationKind identifies the type of data carried by an Observation
+type ObservationKind string
+
+const (
+	// ObservedMarketTransaction is emitted for cargo purchases, cargo sales and fuel purchases
+	ObservedMarketTransaction ObservationKind = "market_transaction"
+	// ObservedShipyardTransaction is emitted when a ship is purchased
+	ObservedShipyardTransaction ObservationKind = "shipyard_transaction"
+	// ObservedRepairTransaction is emitted when a ship is repaired
+	ObservedRepairTransaction ObservationKind = "repair_transaction"
+	// ObservedScrapTransaction is emitted when a ship is scrapped
+	ObservedScrapTransaction ObservationKind = "scrap_transaction"
+	// ObservedModificationTransaction is emitted when a mount or module is installed
+	ObservedModificationTransaction ObservationKind = "modification_transaction"
+	// ObservedContractAccepted is emitted when a contract is accepted and its advance is paid
+	ObservedContractAccepted ObservationKind = "contract_accepted"
+	// ObservedContractFulfilled is emitted when a contract is fulfilled and its reward is paid
+	ObservedContractFulfilled ObservationKind = "contract_fulfilled"
+	// ObservedExtraction is emitted when a ship extracts resources
+	ObservedExtraction ObservationKind = "extraction"
+	// ObservedNavigation is emitted when a ship navigates, warps, jumps, orbits, docks or changes flight mode
+	ObservedNavigation ObservationKind = "navigation"
+	// ObservedSystemWaypoints is emitted when a system's waypoint list is fetched
+	ObservedSystemWaypoints ObservationKind = "system_waypoints"
+	// ObservedWaypointScan is emitted when a ship scans the waypoints around it
+	ObservedWaypointScan ObservationKind = "waypoint_scan"
+	// ObservedSystemScan is emitted when a ship scans the systems around it
+	ObservedSystemScan ObservationKind = "system_scan"
+	// ObservedSystems is emitted when systems are fetched, one at a time or a page at a time
+	ObservedSystems ObservationKind = "systems"
+	// ObservedShipyard is emitted when a shipyard is fetched; ships and prices are only included while a ship is present
+	ObservedShipyard ObservationKind = "shipyard"
+	// ObservedMarket is emitted when a market is fetched; prices are only included while a ship is present
+	ObservedMarket ObservationKind = "market"
+	// ObservedShipEvents is emitted when navigating, changing flight mode or extracting wears a ship's components
+	ObservedShipEvents ObservationKind = "ship_events"
+	// ObservedAgent is emitted whenever a response carries the agent, including its credits
+	ObservedAgent ObservationKind = "agent"
+	// ObservedCooldown is emitted when an action puts a ship on cooldown or its cooldown is fetched
+	ObservedCooldown ObservationKind = "cooldown"
+	// ObservedShip is emitted when a ship is fetched, purchased or repaired, carrying its whole state
+	ObservedShip ObservationKind = "ship"
+	// ObservedFleet is emitted when every ship of the agent is fetched at once
+	ObservedFleet ObservationKind = "fleet"
+	// ObservedCargo is emitted when an action changes a ship's cargo or its cargo is fetched
+	ObservedCargo ObservationKind = "cargo"
+	// ObservedFuel is emitted when navigating, warping or refueling changes a ship's fuel
+	ObservedFuel ObservationKind = "fuel"
+)
+
+// Observation describes something the client saw in an API response.
+// Exactly one of the payload fields is set, matching Kind, except that extraction
+// observations also carry the survey used, if any, and cooldown observations carry the
+// action that started the cooldown.
+type Observation struct {
+	Kind       ObservationKind
+	ShipSymbol string
+	ObservedAt time.Time
+
+	MarketTransaction       *MarketTransaction
+	ShipyardTransaction     *Transaction
+	RepairTransaction       *RepairTransaction
+	ScrapTransaction        *ScrapTransaction
+	ModificationTransaction *ModificationTransaction
+	Contract                *Contract
+	Extraction              *Extraction
+	Survey                  *Survey
+	Nav                     *Navigation
+	Waypoints               []SystemWaypoint
+	ScannedWaypoints        []ScannedWaypoint
+	ScannedSystems          []ScannedSystem
+	Systems                 []System
+	Shipyard                *Shipyard
+	Market                  *Market
+	Events                  []Event
+	Agent                   *Agent
+	Cooldown                *Cooldown
+	Ship                    *Ship
+	Ships                   []Ship
+	Cargo                   *Cargo
+	Fuel                    *Fuel
+	// Action is what started a cooldown, such as "extraction" or "waypoint_scan"; empty when
+	// the cooldown was fetched rather than started
+	Action string
+}
+
+// Observer is called synchronously for every observation the client makes
+type Observer func(Observation)
+
+// AddObserver registers an observer that is notified about API responses
+func (c *Client) AddObserver(observer Observer) {
+	c.observersMu.Lock()
+	defer c.observersMu.Unlock()
+	c.observers = append(c.observers, observer)
+}
+
+// notify delivers an observation to all registered observers
+func (c *Client) notify(observation Observation) {
+	if observation.ObservedAt.IsZero() {
+		observation.ObservedAt = time.Now()
+	}
+
+	c.observersMu.RLock()
+	observers := c.observers
+	c.observersMu.RUnlock()
+
+	for _, observer := range observers {
+		observer(observation)
+	}
+}
+
+// notifyMarketTransaction emits a market transaction observation
+func (c *Client) notifyMarketTransaction(shipSymbol string, transaction MarketTransaction) {
+	c.notify(Observation{
+		Kind:              ObservedMarketTransaction,
+		ShipSymbol:        shipSymbol,
+		ObservedAt:        parseTime(transaction.Timestamp),
+		MarketTransaction: &transaction,
+	})
+}
+
+// notifyAgent emits the agent's latest state
+func (c *Client) notifyAgent(agent Agent) {
+	c.notify(Observation{
+		Kind:  ObservedAgent,
+		Agent: &agent,
+	})
+}
+
+// notifyShipEvents emits the condition events of a response, if there were any
+func (c *Client) notifyShipEvents(shipSymbol string, events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	c.notify(Observation{
+		Kind:       ObservedShipEvents,
+		ShipSymbol: shipSymbol,
+		Events:     events,
+	})
+}
+
+// notifyCooldown emits the cooldown an action put a ship on
+func (c *Client) notifyCooldown(shipSymbol, action string, cooldown Cooldown) {
+	c.notify(Observation{
+		Kind:       ObservedCooldown,
+		ShipSymbol: shipSymbol,
+		Cooldown:   &cooldown,
+		Action:     action,
+	})
+}
+
+// notifyNavigation emits a navigation observation
+func (c *Client) notifyNavigation(shipSymbol string, nav Navigation) {
+	c.notify(Observation{
+		Kind:       ObservedNavigation,
+		ShipSymbol: shipSymbol,
+		Nav:        &nav,
+	})
+}
+
+// notifyShip emits a ship's whole state
+func (c *Client) notifyShip(ship Ship) {
+	c.notify(Observation{
+		Kind:       ObservedShip,
+		ShipSymbol: ship.Symbol,
+		Ship:       &ship,
+	})
+}
+
+// notifyCargo emits a ship's cargo after an action changed it
+func (c *Client) notifyCargo(shipSymbol string, cargo Cargo) {
+	c.notify(Observation{
+		Kind:       ObservedCargo,
+		ShipSymbol: shipSymbol,
+		Cargo:      &cargo,
+	})
+}
+
+// notifyFuel emits a ship's fuel after an action changed it
+func (c *Client) notifyFuel(shipSymbol string, fuel Fuel) {
+	c.notify(Observation{
+		Kind:       ObservedFuel,
+		ShipSymbol: shipSymbol,
+		Fuel:       &fuel,
+	})
+}
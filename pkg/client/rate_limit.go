@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitStatus is the rate-limit state from the latest API response that reported one
+type RateLimitStatus struct {
+	RateLimit
+	// ObservedAt is when that response arrived
+	ObservedAt time.Time `json:"observedAt"`
+	// ThrottledAt is when the API last refused a request with 429 Too Many Requests
+	ThrottledAt *time.Time `json:"throttledAt,omitempty"`
+	// RetryAfterSeconds is how long the API asked callers to wait after that refusal
+	RetryAfterSeconds float64 `json:"retryAfterSeconds,omitempty"`
+}
+
+// Describe explains the rate limit in a sentence meant for tool error messages
+func (s RateLimitStatus) Describe() string {
+	var b strings.Builder
+	b.WriteString("The SpaceTraders API is rate limiting requests (429 Too Many Requests)")
+	if s.ObservedAt.IsZero() {
+		b.WriteString(".")
+	} else {
+		fmt.Fprintf(&b, ": %d requests remaining", s.Remaining)
+		if s.LimitPerSecond > 0 {
+			fmt.Fprintf(&b, ", %d per second", s.LimitPerSecond)
+			if s.Burst > 0 {
+				fmt.Fprintf(&b, " with bursts of %d", s.Burst)
+			}
+		}
+		if s.ResetAt != "" {
+			fmt.Fprintf(&b, ", resetting at %s", s.ResetAt)
+		}
+		b.WriteString(".")
+	}
+	if s.RetryAfterSeconds > 0 {
+		fmt.Fprintf(&b, " Retry after %.1fs.", s.RetryAfterSeconds)
+	}
+	b.WriteString(" Wait before calling more tools; spacetraders://api/rate-limit shows the current quota.")
+	return b.String()
+}
+
+// RateLimitStatus returns the rate-limit state the API last reported, or false when no
+// response has carried rate-limit headers yet
+func (c *Client) RateLimitStatus() (RateLimitStatus, bool) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit, !c.rateLimit.ObservedAt.IsZero() || c.rateLimit.ThrottledAt != nil
+}
+
+// ThrottledSince returns the rate-limit state when the API refused a request with 429 at or
+// after since, so callers can tell whether their own requests were throttled
+func (c *Client) ThrottledSince(since time.Time) (RateLimitStatus, bool) {
+	status, _ := c.RateLimitStatus()
+	if status.ThrottledAt == nil || status.ThrottledAt.Before(since) {
+		return RateLimitStatus{}, false
+	}
+	return status, true
+}
+
+// recordRateLimit keeps the rate-limit headers of an API response
+func (s *clientState) recordRateLimit(resp *http.Response) {
+	now := time.Now()
+	limit := parseRateLimit(resp.Header)
+	throttled := resp.StatusCode == http.StatusTooManyRequests
+	if limit == nil && !throttled {
+		return
+	}
+
+	s.rateLimitMu.Lock()
+	defer s.rateLimitMu.Unlock()
+	if limit != nil {
+		s.rateLimit.RateLimit = *limit
+		s.rateLimit.ObservedAt = now
+	}
+	if throttled {
+		s.rateLimit.ThrottledAt = &now
+		s.rateLimit.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	}
+}
+
+// rateLimitTransport records the rate-limit headers of every API response
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	state *clientState
+}
+
+// RoundTrip sends the request with the base transport and records the response's rate limit
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.state.recordRateLimit(resp)
+	}
+	return resp, err
+}
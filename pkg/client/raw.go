@@ -0,0 +1,54 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// getJSON performs a GET against an endpoint the generated client does not cover yet,
+// reusing its base URL, headers and HTTP client, and decodes the response into out
+func (c *Client) getJSON(path string, out interface{}) error {
+	cfg := c.apiClient.GetConfig()
+	if len(cfg.Servers) == 0 {
+		return fmt.Errorf("no API server configured")
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, cfg.Servers[0].URL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range cfg.DefaultHeader {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import "time"
+
+// CooldownRemaining returns the time left on the ship's cooldown, preferring the expiration timestamp
+func (s Ship) CooldownRemaining(now time.Time) time.Duration {
+	if s.Cooldown.Expiration != "" {
+		if expiration := parseTime(s.Cooldown.Expiration); !expiration.IsZero() {
+			if remaining := expiration.Sub(now); remaining > 0 {
+				return remaining
+			}
+			return 0
+		}
+	}
+	return time.Duration(s.Cooldown.RemainingSeconds) * time.Second
+}
+
+// ArrivalIn returns the time until the ship reaches its destination, or zero if it is not in transit.
+// The second return value is false when the ship is in transit but the arrival time is unknown.
+func (s Ship) ArrivalIn(now time.Time) (time.Duration, bool) {
+	if s.Nav.Status != "IN_TRANSIT" {
+		return 0, true
+	}
+	arrival := parseTime(s.Nav.Route.Arrival)
+	if arrival.IsZero() {
+		return 0, false
+	}
+	if remaining := arrival.Sub(now); remaining > 0 {
+		return remaining, true
+	}
+	return 0, true
+}
+
+// FuelPercent returns the current fuel level as a percentage of capacity (100 for ships without fuel tanks)
+func (s Ship) FuelPercent() int {
+	if s.Fuel.Capacity == 0 {
+		return 100
+	}
+	return s.Fuel.Current * 100 / s.Fuel.Capacity
+}
+
+// CargoPercent returns how full the cargo hold is as a percentage of capacity
+func (s Ship) CargoPercent() int {
+	if s.Cargo.Capacity == 0 {
+		return 0
+	}
+	return s.Cargo.Units * 100 / s.Cargo.Capacity
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"sort"
+	"time"
+)
+
+// ShipyardListing is the last fetch of a shipyard that included its ships and prices
+type ShipyardListing struct {
+	Shipyard  Shipyard  `json:"shipyard"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// KnownShipyards returns the latest priced listing of every shipyard fetched so far, sorted by
+// waypoint. Shipyards only list their ships while one of ours is present, so a shipyard appears
+// here once it has been fetched with a ship there; later fetches without a ship keep the listing.
+// It makes no API calls.
+func (c *Client) KnownShipyards() []ShipyardListing {
+	c.shipyardCacheMu.Lock()
+	listings := make([]ShipyardListing, 0, len(c.shipyardCache))
+	for _, listing := range c.shipyardCache {
+		listings = append(listings, listing)
+	}
+	c.shipyardCacheMu.Unlock()
+
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].Shipyard.Symbol < listings[j].Shipyard.Symbol
+	})
+	return listings
+}
+
+// KnownShipyard returns the latest priced listing of one shipyard, if it has been fetched with
+// a ship there. It makes no API calls.
+func (c *Client) KnownShipyard(waypointSymbol string) (ShipyardListing, bool) {
+	c.shipyardCacheMu.Lock()
+	defer c.shipyardCacheMu.Unlock()
+
+	listing, ok := c.shipyardCache[waypointSymbol]
+	return listing, ok
+}
+
+// cacheShipyard stores a fetched shipyard if it lists ships
+func (c *Client) cacheShipyard(shipyard *Shipyard) {
+	if len(shipyard.Ships) == 0 {
+		return
+	}
+
+	c.shipyardCacheMu.Lock()
+	defer c.shipyardCacheMu.Unlock()
+
+	if c.shipyardCache == nil {
+		c.shipyardCache = make(map[string]ShipyardListing)
+	}
+	// Transactions are other agents' purchases and are not needed to compare ships
+	listing := *shipyard
+	listing.Transactions = nil
+	c.shipyardCache[shipyard.Symbol] = ShipyardListing{Shipyard: listing, FetchedAt: time.Now()}
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"fmt"
+	"time"
+)
+
+// SupplyChainCacheTTL is how long the supply chain is reused before it is fetched again.
+// The chain is static for the lifetime of a server reset.
+const SupplyChainCacheTTL = 24 * time.Hour
+
+// GetSupplyChain returns which import goods each export good is produced from
+func (c *Client) GetSupplyChain() (map[string][]string, error) {
+	resp, _, err := c.apiClient.DataAPI.GetSupplyChain(c.ctx).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supply chain: %w", err)
+	}
+
+	return resp.Data.ExportToImportMap, nil
+}
+
+// GetCachedSupplyChain returns the supply chain, reusing a previous fetch younger than
+// SupplyChainCacheTTL. The returned time is when the chain was fetched from the API.
+func (c *Client) GetCachedSupplyChain() (map[string][]string, time.Time, error) {
+	c.supplyChainMu.Lock()
+	chain, fetchedAt := c.supplyChain, c.supplyChainFetchedAt
+	c.supplyChainMu.Unlock()
+	if chain != nil && time.Since(fetchedAt) < SupplyChainCacheTTL {
+		return chain, fetchedAt, nil
+	}
+
+	return c.RefreshSupplyChain()
+}
+
+// RefreshSupplyChain fetches the supply chain from the API and caches it for
+// GetCachedSupplyChain, however fresh the cached chain is
+func (c *Client) RefreshSupplyChain() (map[string][]string, time.Time, error) {
+	chain, err := c.GetSupplyChain()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	fetchedAt := time.Now()
+	c.supplyChainMu.Lock()
+	c.supplyChain, c.supplyChainFetchedAt = chain, fetchedAt
+	c.supplyChainMu.Unlock()
+
+	return chain, fetchedAt, nil
+}
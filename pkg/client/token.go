@@ -0,0 +1,57 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenInfo is what the API token says about itself. Tokens are JWTs whose claims name the agent
+// and the server reset they were issued in; the token itself is never part of it.
+type TokenInfo struct {
+	// Subject is "agent-token" or "account-token"
+	Subject string `json:"subject"`
+	// Identifier is the agent symbol the token acts as
+	Identifier string    `json:"identifier"`
+	Version    string    `json:"version,omitempty"`
+	ResetDate  string    `json:"resetDate,omitempty"`
+	IssuedAt   time.Time `json:"issuedAt,omitzero"`
+}
+
+// TokenInfo decodes the claims of the client's API token. The signature is not checked; only the
+// API can tell whether the token is valid.
+func (c *Client) TokenInfo() (*TokenInfo, error) {
+	token := strings.TrimPrefix(c.apiClient.GetConfig().DefaultHeader["Authorization"], "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("API token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode API token claims: %w", err)
+	}
+	var claims struct {
+		Subject    string `json:"sub"`
+		Identifier string `json:"identifier"`
+		Version    string `json:"version"`
+		ResetDate  string `json:"reset_date"`
+		IssuedAt   int64  `json:"iat"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse API token claims: %w", err)
+	}
+
+	info := &TokenInfo{
+		Subject:    claims.Subject,
+		Identifier: claims.Identifier,
+		Version:    claims.Version,
+		ResetDate:  claims.ResetDate,
+	}
+	if claims.IssuedAt > 0 {
+		info.IssuedAt = time.Unix(claims.IssuedAt, 0).UTC()
+	}
+	return info, nil
+}
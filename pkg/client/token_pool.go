@@ -0,0 +1,205 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultSecondaryBackoff is how long a throttled secondary token is skipped when the API didn't
+// say how long to wait
+const defaultSecondaryBackoff = time.Second
+
+// SecondaryTokenStatus is the state of one secondary token; the token itself is never part of it
+type SecondaryTokenStatus struct {
+	// Index is the token's position in the configured list, starting at 1
+	Index    int `json:"index"`
+	Requests int `json:"requests"`
+	// Fallbacks counts the requests the API refused and the primary token sent instead
+	Fallbacks int `json:"fallbacks"`
+	// Revoked is set once the API rejects the token as unauthorized; it isn't used again
+	Revoked bool `json:"revoked,omitempty"`
+	// ThrottledUntil is when a token the API rate limited is used again
+	ThrottledUntil *time.Time `json:"throttledUntil,omitempty"`
+}
+
+// secondaryToken is a read-only token and what the API has said about it
+type secondaryToken struct {
+	header         string
+	requests       int
+	fallbacks      int
+	revoked        bool
+	throttledUntil time.Time
+}
+
+// tokenPool hands out secondary tokens in turn, skipping any the API has refused
+type tokenPool struct {
+	mu     sync.Mutex
+	tokens []*secondaryToken
+	next   int
+	now    func() time.Time
+}
+
+// SetSecondaryTokens configures read-only tokens of other agents. Public GETs that read the same
+// for every agent are spread across them, and every other call keeps using the primary token; see
+// publicEndpoint for the split. Passing no tokens sends everything with the primary token.
+func (c *Client) SetSecondaryTokens(tokens []string) {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	c.tokens.tokens = nil
+	c.tokens.next = 0
+	for _, token := range tokens {
+		if token = strings.TrimSpace(token); token != "" {
+			c.tokens.tokens = append(c.tokens.tokens, &secondaryToken{header: "Bearer " + token})
+		}
+	}
+}
+
+// SecondaryTokens returns the state of each configured secondary token, in configuration order
+func (c *Client) SecondaryTokens() []SecondaryTokenStatus {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+
+	now := c.tokens.clock()
+	statuses := make([]SecondaryTokenStatus, 0, len(c.tokens.tokens))
+	for i, token := range c.tokens.tokens {
+		status := SecondaryTokenStatus{
+			Index:     i + 1,
+			Requests:  token.requests,
+			Fallbacks: token.fallbacks,
+			Revoked:   token.revoked,
+		}
+		if token.throttledUntil.After(now) {
+			until := token.throttledUntil
+			status.ThrottledUntil = &until
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// clock returns the current time; the caller holds p.mu
+func (p *tokenPool) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// acquire returns the next secondary token that is neither revoked nor throttled, or nil when
+// none is usable
+func (p *tokenPool) acquire() *secondaryToken {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock()
+	for range p.tokens {
+		token := p.tokens[p.next%len(p.tokens)]
+		p.next = (p.next + 1) % len(p.tokens)
+		if token.revoked || token.throttledUntil.After(now) {
+			continue
+		}
+		token.requests++
+		return token
+	}
+	return nil
+}
+
+// refused records that the API turned down a request sent with token, so it is skipped: for
+// good when unauthorized, or until the API's Retry-After when rate limited
+func (p *tokenPool) refused(token *secondaryToken, resp *http.Response) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	token.fallbacks++
+	if resp.StatusCode == http.StatusUnauthorized {
+		token.revoked = true
+		return
+	}
+	backoff := defaultSecondaryBackoff
+	if seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil && seconds > 0 {
+		backoff = time.Duration(seconds * float64(time.Second))
+	}
+	token.throttledUntil = p.clock().Add(backoff)
+}
+
+// publicEndpoint reports whether a request reads the same whatever agent sends it, so any token
+// may. Only GETs qualify, and of those only:
+//
+//	/systems, /systems/{system}
+//	/systems/{system}/waypoints, /systems/{system}/waypoints/{waypoint}
+//	/systems/{system}/waypoints/{waypoint}/jump-gate
+//	/systems/{system}/waypoints/{waypoint}/construction
+//	/factions, /factions/{faction}
+//	/market/supply-chain
+//
+// Everything under /my acts as the agent, and market and shipyard reads include prices only when
+// one of the agent's ships is present, so those always use the primary token.
+func publicEndpoint(method, path string) bool {
+	if method != http.MethodGet {
+		return false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	// The base URL's path, such as /v2, comes first
+	for i, segment := range segments {
+		rest := segments[i+1:]
+		switch segment {
+		case "systems":
+			switch len(rest) {
+			case 0, 1:
+				return true
+			case 2, 3:
+				return rest[1] == "waypoints"
+			case 4:
+				return rest[1] == "waypoints" && (rest[3] == "jump-gate" || rest[3] == "construction")
+			}
+			return false
+		case "factions":
+			return len(rest) <= 1
+		case "market":
+			return len(rest) == 1 && rest[0] == "supply-chain"
+		case "my":
+			return false
+		}
+	}
+	return false
+}
+
+// tokenPoolTransport sends public GETs with the secondary tokens in turn, and everything else
+// with the primary token. A request a secondary token is refused for, unauthorized or rate
+// limited, is sent again with the primary token. Only primary responses reach the rate-limit
+// state, since each token has its own quota.
+type tokenPoolTransport struct {
+	primary   http.RoundTripper
+	secondary http.RoundTripper
+	state     *clientState
+}
+
+// RoundTrip sends the request with a secondary token when one may and is usable
+func (t tokenPoolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !publicEndpoint(req.Method, req.URL.Path) {
+		return t.primary.RoundTrip(req)
+	}
+	token := t.state.tokens.acquire()
+	if token == nil {
+		return t.primary.RoundTrip(req)
+	}
+
+	alt := req.Clone(req.Context())
+	alt.Header.Set("Authorization", token.header)
+	resp, err := t.secondary.RoundTrip(alt)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	t.state.tokens.refused(token, resp)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return t.primary.RoundTrip(req)
+}
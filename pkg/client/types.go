@@ -10,6 +10,13 @@ type Agent struct {
 	ShipCount       int     `json:"shipCount"`
 }
 
+// Account is the player account that owns the agent
+type Account struct {
+	ID        string  `json:"id"`
+	Email     *string `json:"email,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+}
+
 // Ship represents a ship with FIXED reactor integrity types
 type Ship struct {
 	Symbol       string       `json:"symbol"`
@@ -224,6 +231,12 @@ type System struct {
 	Factions     []SystemFaction  `json:"factions"`
 }
 
+// JumpGate represents a jump gate and the gate waypoints it connects to
+type JumpGate struct {
+	Symbol      string   `json:"symbol"`
+	Connections []string `json:"connections"`
+}
+
 // SystemWaypoint represents a waypoint in a system
 type SystemWaypoint struct {
 	Symbol    string             `json:"symbol"`
@@ -231,10 +244,13 @@ type SystemWaypoint struct {
 	X         int                `json:"x"`
 	Y         int                `json:"y"`
 	Orbitals  []WaypointOrbital  `json:"orbitals"`
+	Orbits    string             `json:"orbits,omitempty"`
 	Traits    []WaypointTrait    `json:"traits"`
 	Modifiers []WaypointModifier `json:"modifiers"`
 	Chart     *WaypointChart     `json:"chart,omitempty"`
 	Faction   *WaypointFaction   `json:"faction,omitempty"`
+	// IsUnderConstruction is only known for waypoints listed with their details, not from a system
+	IsUnderConstruction bool `json:"isUnderConstruction"`
 }
 
 // WaypointOrbital represents an orbital waypoint
@@ -431,6 +447,12 @@ type FactionTrait struct {
 	Description string `json:"description"`
 }
 
+// FactionReputation represents the agent's standing with a faction
+type FactionReputation struct {
+	Symbol     string `json:"symbol"`
+	Reputation int    `json:"reputation"`
+}
+
 // PurchaseShipRequest represents a ship purchase request
 type PurchaseShipRequest struct {
 	ShipType       string `json:"shipType"`
@@ -675,6 +697,43 @@ type RepairTransaction struct {
 	Timestamp      string `json:"timestamp"`
 }
 
+type ScrapShipResponse struct {
+	Data ScrapShipData `json:"data"`
+}
+
+type ScrapShipData struct {
+	Agent       Agent            `json:"agent"`
+	Transaction ScrapTransaction `json:"transaction"`
+}
+
+type ScrapTransaction struct {
+	WaypointSymbol string `json:"waypointSymbol"`
+	ShipSymbol     string `json:"shipSymbol"`
+	TotalPrice     int    `json:"totalPrice"`
+	Timestamp      string `json:"timestamp"`
+}
+
+type ShipModificationResponse struct {
+	Data ShipModificationData `json:"data"`
+}
+
+type ShipModificationData struct {
+	Agent       Agent                   `json:"agent"`
+	Mounts      []Mount                 `json:"mounts,omitempty"`
+	Modules     []Module                `json:"modules,omitempty"`
+	Cargo       Cargo                   `json:"cargo"`
+	Transaction ModificationTransaction `json:"transaction"`
+}
+
+// ModificationTransaction is the fee paid to install a mount or module
+type ModificationTransaction struct {
+	WaypointSymbol string `json:"waypointSymbol"`
+	ShipSymbol     string `json:"shipSymbol"`
+	TradeSymbol    string `json:"tradeSymbol"`
+	TotalPrice     int    `json:"totalPrice"`
+	Timestamp      string `json:"timestamp"`
+}
+
 type JumpResponse struct {
 	Data JumpData `json:"data"`
 }
@@ -0,0 +1,45 @@
+package client
+
+import "time"
+
+// WaypointCacheTTL is how long a system's waypoint list is reused before it is fetched again.
+// Waypoint positions and traits only change on a server reset or when a waypoint is charted.
+const WaypointCacheTTL = time.Hour
+
+// cachedWaypoints is a system's waypoint list and when it was fetched
+type cachedWaypoints struct {
+	waypoints []SystemWaypoint
+	fetchedAt time.Time
+}
+
+// GetCachedSystemWaypoints returns all waypoints in a system, reusing a previous fetch younger
+// than WaypointCacheTTL. The returned time is when the list was fetched from the API.
+func (c *Client) GetCachedSystemWaypoints(systemSymbol string) ([]SystemWaypoint, time.Time, error) {
+	c.waypointCacheMu.Lock()
+	entry, ok := c.waypointCache[systemSymbol]
+	c.waypointCacheMu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < WaypointCacheTTL {
+		return entry.waypoints, entry.fetchedAt, nil
+	}
+
+	return c.RefreshSystemWaypoints(systemSymbol)
+}
+
+// RefreshSystemWaypoints fetches all waypoints in a system from the API and caches them for
+// GetCachedSystemWaypoints, however fresh the cached list is
+func (c *Client) RefreshSystemWaypoints(systemSymbol string) ([]SystemWaypoint, time.Time, error) {
+	waypoints, err := c.GetAllSystemWaypoints(systemSymbol)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	entry := cachedWaypoints{waypoints: waypoints, fetchedAt: time.Now()}
+	c.waypointCacheMu.Lock()
+	if c.waypointCache == nil {
+		c.waypointCache = make(map[string]cachedWaypoints)
+	}
+	c.waypointCache[systemSymbol] = entry
+	c.waypointCacheMu.Unlock()
+
+	return entry.waypoints, entry.fetchedAt, nil
+}
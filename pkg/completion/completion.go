@@ -0,0 +1,183 @@
+package completion
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MaxValues is the most values an MCP completion response may carry
+const MaxValues = 100
+
+// Candidates lists the known values of an argument. Names are matched without regard to case
+// or underscores, so the {shipSymbol} template parameter and the ship_symbol prompt argument
+// complete the same way. arguments holds the other values filled in so far, which narrow
+// waypoints to a chosen system. Ships and the agent come from the fleet model while it is fresh,
+// so typing doesn't cost an API call per keystroke; a nil or stale model falls back to the API.
+// Unknown arguments and API failures give no candidates.
+func Candidates(c *client.Client, fleet *fleetstate.Model, name string, arguments map[string]string) []string {
+	switch normalize(name) {
+	case "shipsymbol":
+		return ShipSymbols(c, fleet)
+	case "systemsymbol":
+		return SystemSymbols(c, fleet)
+	case "waypointsymbol":
+		systemSymbol := ""
+		for key, value := range arguments {
+			if normalize(key) == "systemsymbol" {
+				systemSymbol = value
+			}
+		}
+		return WaypointSymbols(c, fleet, systemSymbol)
+	case "contractid":
+		return ContractIDs(c)
+	case "factionsymbol":
+		return FactionSymbols(c)
+	case "tradesymbol", "goodsymbol":
+		return TradeSymbols(c)
+	}
+	return nil
+}
+
+// normalize folds an argument name to lower case without underscores
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// ships returns the fleet from the model while it is fresh, and from the API otherwise
+func ships(c *client.Client, fleet *fleetstate.Model) ([]client.Ship, error) {
+	if ships, _, ok := fleet.Fleet(time.Now()); ok {
+		return ships, nil
+	}
+	return c.GetAllShips()
+}
+
+// ShipSymbols lists the symbols of every ship in the fleet
+func ShipSymbols(c *client.Client, fleet *fleetstate.Model) []string {
+	ships, err := ships(c, fleet)
+	if err != nil {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(ships))
+	for _, ship := range ships {
+		symbols = append(symbols, ship.Symbol)
+	}
+	return symbols
+}
+
+// SystemSymbols lists the systems the fleet is in, plus the headquarters system
+func SystemSymbols(c *client.Client, fleet *fleetstate.Model) []string {
+	var symbols []string
+	if agent, _, ok := fleet.Agent(time.Now()); ok && agent.Headquarters != "" {
+		symbols = append(symbols, travel.SystemSymbol(agent.Headquarters))
+	} else if agent, err := c.GetAgent(); err == nil && agent.Headquarters != "" {
+		symbols = append(symbols, travel.SystemSymbol(agent.Headquarters))
+	}
+	if ships, err := ships(c, fleet); err == nil {
+		for _, ship := range ships {
+			symbols = append(symbols, ship.Nav.SystemSymbol)
+		}
+	}
+	return symbols
+}
+
+// WaypointSymbols lists the waypoints of a system, or of every system the fleet is in
+// when no system has been chosen yet
+func WaypointSymbols(c *client.Client, fleet *fleetstate.Model, systemSymbol string) []string {
+	systems := []string{systemSymbol}
+	if systemSymbol == "" {
+		systems = SystemSymbols(c, fleet)
+	}
+
+	var symbols []string
+	seen := make(map[string]bool)
+	for _, system := range systems {
+		if seen[system] {
+			continue
+		}
+		seen[system] = true
+
+		waypoints, _, err := c.GetCachedSystemWaypoints(system)
+		if err != nil {
+			continue
+		}
+		for _, waypoint := range waypoints {
+			symbols = append(symbols, waypoint.Symbol)
+		}
+	}
+	return symbols
+}
+
+// ContractIDs lists the agent's contracts
+func ContractIDs(c *client.Client) []string {
+	contracts, err := c.GetAllContracts()
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		ids = append(ids, contract.ID)
+	}
+	return ids
+}
+
+// FactionSymbols lists every faction
+func FactionSymbols(c *client.Client) []string {
+	factions, err := c.GetAllFactions()
+	if err != nil {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(factions))
+	for _, faction := range factions {
+		symbols = append(symbols, faction.Symbol)
+	}
+	return symbols
+}
+
+// TradeSymbols lists every trade good in the supply chain, both the exports and the imports
+// they are made from
+func TradeSymbols(c *client.Client) []string {
+	supplyChain, _, err := c.GetCachedSupplyChain()
+	if err != nil {
+		return nil
+	}
+
+	var symbols []string
+	for export, imports := range supplyChain {
+		symbols = append(symbols, export)
+		symbols = append(symbols, imports...)
+	}
+	return symbols
+}
+
+// Filter keeps the unique candidates starting with prefix (case-insensitive), sorted and
+// capped at the MCP limit
+func Filter(candidates []string, prefix string) *mcp.Completion {
+	prefix = strings.ToUpper(prefix)
+	seen := make(map[string]bool)
+	values := []string{}
+	for _, candidate := range candidates {
+		if candidate == "" || seen[candidate] || !strings.HasPrefix(strings.ToUpper(candidate), prefix) {
+			continue
+		}
+		seen[candidate] = true
+		values = append(values, candidate)
+	}
+	sort.Strings(values)
+
+	completion := &mcp.Completion{Values: values, Total: len(values)}
+	if len(values) > MaxValues {
+		completion.Values = values[:MaxValues]
+		completion.HasMore = true
+	}
+	return completion
+}
@@ -0,0 +1,111 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/logging"
+)
+
+// newTestClient serves a small fleet, a contract and the supply chain, counting the requests
+// for each path in requests when it is not nil
+func newTestClient(t *testing.T, requests map[string]int) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			requests[r.URL.Path]++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/agent":
+			fmt.Fprint(w, `{"data": {"symbol": "TEST_AGENT", "headquarters": "X1-HQ-A1", "credits": 1000, "startingFaction": "COSMIC", "shipCount": 2}}`)
+		case "/my/ships":
+			fmt.Fprint(w, `{"data": [
+				{"symbol": "TEST-1", "nav": {"systemSymbol": "X1-HQ", "waypointSymbol": "X1-HQ-A1", "status": "DOCKED"}},
+				{"symbol": "TEST-2", "nav": {"systemSymbol": "X1-FAR", "waypointSymbol": "X1-FAR-B2", "status": "IN_ORBIT"}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`)
+		case "/my/contracts":
+			fmt.Fprint(w, `{"data": [{"id": "contract-123", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "terms": {"deliver": []}}], "meta": {"total": 1, "page": 1, "limit": 20}}`)
+		case "/systems/X1-FAR/waypoints":
+			fmt.Fprint(w, `{"data": [{"symbol": "X1-FAR-B2", "type": "PLANET", "systemSymbol": "X1-FAR", "x": 0, "y": 0}], "meta": {"total": 1, "page": 1, "limit": 20}}`)
+		case "/market/supply-chain":
+			fmt.Fprint(w, `{"data": {"exportToImportMap": {"FUEL": ["HYDROCARBON"]}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"message": "not found", "code": 404}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return client.NewClientWithBaseURL("test-token", server.URL)
+}
+
+func TestCandidates_MatchesTemplateAndPromptNames(t *testing.T) {
+	c := newTestClient(t, nil)
+
+	tests := []struct {
+		name      string
+		arguments map[string]string
+		prefix    string
+		expected  []string
+	}{
+		{"shipSymbol", nil, "", []string{"TEST-1", "TEST-2"}},
+		{"ship_symbol", nil, "test-2", []string{"TEST-2"}},
+		{"system_symbol", nil, "X1", []string{"X1-FAR", "X1-HQ"}},
+		{"waypointSymbol", map[string]string{"systemSymbol": "X1-FAR"}, "", []string{"X1-FAR-B2"}},
+		{"contractId", nil, "", []string{"contract-123"}},
+		{"trade_symbol", nil, "", []string{"FUEL", "HYDROCARBON"}},
+		{"unknown", nil, "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completion := Filter(Candidates(c, nil, tt.name, tt.arguments), tt.prefix)
+			if !reflect.DeepEqual(completion.Values, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, completion.Values)
+			}
+		})
+	}
+}
+
+func TestCandidates_ServesShipsFromFleetState(t *testing.T) {
+	requests := make(map[string]int)
+	c := newTestClient(t, requests)
+	fleet := fleetstate.New(context.Background(), c, logging.NewLogger(nil))
+	c.AddObserver(fleet.Observe)
+
+	// The model has never seen the fleet, so the first completion lists it from the API
+	if got := Filter(Candidates(c, fleet, "shipSymbol", nil), "").Values; !reflect.DeepEqual(got, []string{"TEST-1", "TEST-2"}) {
+		t.Fatalf("Expected both ships, got %v", got)
+	}
+	if requests["/my/ships"] != 1 {
+		t.Fatalf("Expected one fleet listing on a cache miss, got %d", requests["/my/ships"])
+	}
+
+	// That listing made the model fresh, so later completions don't ask the API again
+	Candidates(c, fleet, "ship_symbol", nil)
+	if got := Filter(Candidates(c, fleet, "system_symbol", nil), "").Values; !reflect.DeepEqual(got, []string{"X1-FAR", "X1-HQ"}) {
+		t.Errorf("Expected the fleet's systems from the model, got %v", got)
+	}
+	if requests["/my/ships"] != 1 {
+		t.Errorf("Expected completions served from fleet state, got %d fleet listings", requests["/my/ships"])
+	}
+}
+
+func TestFilter_CapsValues(t *testing.T) {
+	candidates := make([]string, 0, MaxValues+5)
+	for i := 0; i < MaxValues+5; i++ {
+		candidates = append(candidates, fmt.Sprintf("SHIP-%03d", i))
+	}
+
+	completion := Filter(candidates, "ship")
+	if len(completion.Values) != MaxValues || !completion.HasMore || completion.Total != MaxValues+5 {
+		t.Errorf("Expected %d of %d values with more available, got %d of %d (hasMore=%v)",
+			MaxValues, MaxValues+5, len(completion.Values), completion.Total, completion.HasMore)
+	}
+}
@@ -3,13 +3,96 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
 
 	"github.com/spf13/viper"
 )
 
+// DefaultConfirmSpendAbove is the purchase size, in credits, above which tools ask the user to
+// confirm when SPACETRADERS_CONFIRM_SPEND_ABOVE is not set
+const DefaultConfirmSpendAbove = 100000
+
+// DefaultTimeout is how long a tool call or resource read may run when SPACETRADERS_TIMEOUT is not set
+const DefaultTimeout = 2 * time.Minute
+
 // Config holds all configuration for the application
 type Config struct {
 	SpaceTradersAPIToken string
+
+	// SecondaryTokens are other agents' tokens public reads like systems and waypoints are spread
+	// across, to share the load between rate limits; agent calls always use SpaceTradersAPIToken
+	SecondaryTokens []string
+
+	// APIBaseURL overrides the SpaceTraders API URL, e.g. to point the server at a test double
+	APIBaseURL string
+
+	// AutoRefuel makes navigation tools refuel before departing when fuel is too low for the trip
+	AutoRefuel bool
+
+	// AutoCorrectState makes action tools dock or orbit the ship first when it is in the wrong state
+	AutoCorrectState bool
+
+	// TracingEndpoint is the OTLP/HTTP collector URL traces are exported to; tracing is off when empty
+	TracingEndpoint string
+
+	// HealthAddr is the address /healthz and /readyz are served on, e.g. ":8081"; they are off when empty
+	HealthAddr string
+
+	// SkipTokenCheck starts the server without first checking the token against the API
+	SkipTokenCheck bool
+
+	// PollStationedShips refreshes markets and shipyards wherever any ship stays parked, not only at deployed probes
+	PollStationedShips bool
+
+	// ConfirmSpendAbove is the purchase size, in credits, above which tools ask the user to confirm; 0 never asks
+	ConfirmSpendAbove int
+
+	// MaxPurchase is the most credits a single purchase may cost; 0 is no limit
+	MaxPurchase int
+
+	// ReserveCredits is the balance purchases may not drop the agent's credits below; 0 is no reserve
+	ReserveCredits int
+
+	// SessionSpendCap is the most credits tools may spend while the server runs; 0 is no cap
+	SessionSpendCap int
+
+	// WebhookURL is a Slack or Discord compatible webhook alerts are posted to; alerts are off when empty
+	WebhookURL string
+
+	// LowCreditsAlert is the balance below which a webhook alert is sent; 0 never alerts
+	LowCreditsAlert int
+
+	// ExplorationFile is where exploration progress is saved between sessions; it is kept in memory when empty
+	ExplorationFile string
+
+	// ShipMetadataFile is where ship labels, notes and tags are saved; they are kept in memory when empty
+	ShipMetadataFile string
+
+	// CheckpointFile is where the price database and running tasks are saved on shutdown and
+	// resumed from on the next start; they are lost on shutdown when empty
+	CheckpointFile string
+
+	// ResumeTasks restarts the tasks in the checkpoint on startup; otherwise they are only logged
+	ResumeTasks bool
+
+	// ExportDir is where export_data writes files; exporting is off when empty
+	ExportDir string
+
+	// Timeout is how long a tool call or resource read may run; 0 is no limit
+	Timeout time.Duration
+
+	// TimeoutOverrides replace Timeout for particular tools, by name, or resources, by URI
+	TimeoutOverrides map[string]time.Duration
+
+	// BreakerThreshold is how many API failures in a row pause calls to that part of the API; 0 never pauses
+	BreakerThreshold int
+
+	// BreakerCooldown is how long calls are paused before one is let through to check for recovery
+	BreakerCooldown time.Duration
 }
 
 // Load initializes and loads configuration using Viper
@@ -36,11 +119,45 @@ func Load() (*Config, error) {
 	}
 	// Silent success - no logging needed for normal operation
 
+	// Ask before large purchases unless configured otherwise
+	viper.SetDefault("SPACETRADERS_CONFIRM_SPEND_ABOVE", DefaultConfirmSpendAbove)
+	viper.SetDefault("SPACETRADERS_TIMEOUT", DefaultTimeout)
+	viper.SetDefault("SPACETRADERS_BREAKER_THRESHOLD", client.DefaultBreakerThreshold)
+	viper.SetDefault("SPACETRADERS_BREAKER_COOLDOWN", client.DefaultBreakerCooldown)
+
 	// Create config struct
 	config := &Config{
 		SpaceTradersAPIToken: viper.GetString("SPACETRADERS_API_TOKEN"),
+		APIBaseURL:           viper.GetString("SPACETRADERS_API_URL"),
+		AutoRefuel:           viper.GetBool("SPACETRADERS_AUTO_REFUEL"),
+		AutoCorrectState:     viper.GetBool("SPACETRADERS_AUTO_CORRECT_STATE"),
+		TracingEndpoint:      viper.GetString("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		HealthAddr:           viper.GetString("SPACETRADERS_HEALTH_ADDR"),
+		SkipTokenCheck:       viper.GetBool("SPACETRADERS_SKIP_TOKEN_CHECK"),
+		PollStationedShips:   viper.GetBool("SPACETRADERS_POLL_STATIONED_SHIPS"),
+		ConfirmSpendAbove:    viper.GetInt("SPACETRADERS_CONFIRM_SPEND_ABOVE"),
+		MaxPurchase:          viper.GetInt("SPACETRADERS_MAX_PURCHASE"),
+		ReserveCredits:       viper.GetInt("SPACETRADERS_RESERVE_CREDITS"),
+		SessionSpendCap:      viper.GetInt("SPACETRADERS_SESSION_SPEND_CAP"),
+		WebhookURL:           viper.GetString("SPACETRADERS_WEBHOOK_URL"),
+		LowCreditsAlert:      viper.GetInt("SPACETRADERS_LOW_CREDITS_ALERT"),
+		ExplorationFile:      cacheFile(viper.GetString("SPACETRADERS_EXPLORATION_FILE"), "exploration.json"),
+		ShipMetadataFile:     cacheFile(viper.GetString("SPACETRADERS_SHIP_METADATA_FILE"), "ships.json"),
+		CheckpointFile:       cacheFile(viper.GetString("SPACETRADERS_CHECKPOINT_FILE"), "checkpoint.zip"),
+		ResumeTasks:          viper.GetBool("SPACETRADERS_RESUME_TASKS"),
+		ExportDir:            cacheFile(viper.GetString("SPACETRADERS_EXPORT_DIR"), "exports"),
+		Timeout:              viper.GetDuration("SPACETRADERS_TIMEOUT"),
+		BreakerThreshold:     viper.GetInt("SPACETRADERS_BREAKER_THRESHOLD"),
+		BreakerCooldown:      viper.GetDuration("SPACETRADERS_BREAKER_COOLDOWN"),
 	}
 
+	overrides, err := parseTimeoutOverrides(viper.GetString("SPACETRADERS_TIMEOUT_OVERRIDES"))
+	if err != nil {
+		return nil, fmt.Errorf("SPACETRADERS_TIMEOUT_OVERRIDES: %w", err)
+	}
+	config.TimeoutOverrides = overrides
+	config.SecondaryTokens = parseList(viper.GetString("SPACETRADERS_SECONDARY_TOKENS"))
+
 	// Validate required configuration
 	if config.SpaceTradersAPIToken == "" {
 		return nil, fmt.Errorf("SPACETRADERS_API_TOKEN is required")
@@ -48,3 +165,53 @@ func Load() (*Config, error) {
 
 	return config, nil
 }
+
+// cacheFile resolves a file setting for state kept between sessions: "off" disables saving, and
+// an unset value falls back to name in the user cache directory
+func cacheFile(setting, name string) string {
+	switch setting {
+	case "off":
+		return ""
+	case "":
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		return filepath.Join(cacheDir, "spacetraders-mcp", name)
+	default:
+		return setting
+	}
+}
+
+// parseList reads a comma-separated setting, dropping empty entries
+func parseList(setting string) []string {
+	var items []string
+	for _, item := range strings.Split(setting, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTimeoutOverrides reads comma-separated name=duration pairs, such as
+// "find_trade_routes=5m,spacetraders://systems=10m"
+func parseTimeoutOverrides(setting string) (map[string]time.Duration, error) {
+	overrides := make(map[string]time.Duration)
+	for _, pair := range strings.Split(setting, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("expected name=duration, got %q", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("invalid duration for %s: %q", name, value)
+		}
+		overrides[strings.TrimSpace(name)] = timeout
+	}
+	return overrides, nil
+}
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
 
 	"github.com/spf13/viper"
 )
@@ -297,3 +300,291 @@ func TestLoad_InvalidEnvFile(t *testing.T) {
 		t.Errorf("Expected token from environment, got %s", config.SpaceTradersAPIToken)
 	}
 }
+
+func TestLoad_AutoRefuel(t *testing.T) {
+	// Reset viper state
+	viper.Reset()
+
+	for key, value := range map[string]string{
+		"SPACETRADERS_API_TOKEN":   "test-token",
+		"SPACETRADERS_AUTO_REFUEL": "true",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if !config.AutoRefuel {
+		t.Error("Expected AutoRefuel to be enabled")
+	}
+}
+
+func TestLoad_AutoCorrectState(t *testing.T) {
+	// Reset viper state
+	viper.Reset()
+
+	for key, value := range map[string]string{
+		"SPACETRADERS_API_TOKEN":          "test-token",
+		"SPACETRADERS_AUTO_CORRECT_STATE": "true",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if !config.AutoCorrectState {
+		t.Error("Expected AutoCorrectState to be enabled")
+	}
+}
+
+func TestLoad_ResumeTasks(t *testing.T) {
+	// Reset viper state
+	viper.Reset()
+
+	for key, value := range map[string]string{
+		"SPACETRADERS_API_TOKEN": "test-token",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if config.ResumeTasks {
+		t.Error("Expected checkpointed tasks not to be resumed by default")
+	}
+
+	if err := os.Setenv("SPACETRADERS_RESUME_TASKS", "true"); err != nil {
+		t.Fatalf("Failed to set environment variable: %v", err)
+	}
+	defer func() {
+		if err := os.Unsetenv("SPACETRADERS_RESUME_TASKS"); err != nil {
+			t.Errorf("Failed to unset environment variable: %v", err)
+		}
+	}()
+
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !config.ResumeTasks {
+		t.Error("Expected ResumeTasks to be enabled")
+	}
+}
+
+func TestLoad_ConfirmSpendAbove(t *testing.T) {
+	// Reset viper state
+	viper.Reset()
+
+	if err := os.Setenv("SPACETRADERS_API_TOKEN", "test-token"); err != nil {
+		t.Fatalf("Failed to set environment variable: %v", err)
+	}
+	defer func() {
+		if err := os.Unsetenv("SPACETRADERS_API_TOKEN"); err != nil {
+			t.Errorf("Failed to unset environment variable: %v", err)
+		}
+	}()
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if config.ConfirmSpendAbove != DefaultConfirmSpendAbove {
+		t.Errorf("Expected the default limit %d, got %d", DefaultConfirmSpendAbove, config.ConfirmSpendAbove)
+	}
+
+	viper.Reset()
+	if err := os.Setenv("SPACETRADERS_CONFIRM_SPEND_ABOVE", "0"); err != nil {
+		t.Fatalf("Failed to set environment variable: %v", err)
+	}
+	defer func() {
+		if err := os.Unsetenv("SPACETRADERS_CONFIRM_SPEND_ABOVE"); err != nil {
+			t.Errorf("Failed to unset environment variable: %v", err)
+		}
+	}()
+
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if config.ConfirmSpendAbove != 0 {
+		t.Errorf("Expected confirmation to be turned off, got %d", config.ConfirmSpendAbove)
+	}
+}
+
+func TestLoad_TracingEndpoint(t *testing.T) {
+	// Reset viper state
+	viper.Reset()
+
+	for key, value := range map[string]string{
+		"SPACETRADERS_API_TOKEN":      "test-token",
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if config.TracingEndpoint != "http://localhost:4318" {
+		t.Errorf("Expected tracing endpoint from environment, got %q", config.TracingEndpoint)
+	}
+}
+
+func TestCacheFile(t *testing.T) {
+	if got := cacheFile("off", "exploration.json"); got != "" {
+		t.Errorf("Expected off to disable the exploration file, got %q", got)
+	}
+	if got := cacheFile("/tmp/progress.json", "exploration.json"); got != "/tmp/progress.json" {
+		t.Errorf("Expected an explicit path to be kept, got %q", got)
+	}
+	if got := cacheFile("", "exploration.json"); got != "" && filepath.Base(got) != "exploration.json" {
+		t.Errorf("Expected the default to be exploration.json in the cache directory, got %q", got)
+	}
+	if got := cacheFile("", "ships.json"); got != "" && filepath.Base(got) != "ships.json" {
+		t.Errorf("Expected the ship metadata default to be ships.json in the cache directory, got %q", got)
+	}
+}
+
+func TestLoad_Timeouts(t *testing.T) {
+	// Reset viper state
+	viper.Reset()
+
+	if err := os.Setenv("SPACETRADERS_API_TOKEN", "test-token"); err != nil {
+		t.Fatalf("Failed to set environment variable: %v", err)
+	}
+	defer func() {
+		if err := os.Unsetenv("SPACETRADERS_API_TOKEN"); err != nil {
+			t.Errorf("Failed to unset environment variable: %v", err)
+		}
+	}()
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if config.Timeout != DefaultTimeout || len(config.TimeoutOverrides) != 0 {
+		t.Errorf("Expected the default timeout and no overrides, got %s and %v", config.Timeout, config.TimeoutOverrides)
+	}
+
+	viper.Reset()
+	for key, value := range map[string]string{
+		"SPACETRADERS_TIMEOUT":           "45s",
+		"SPACETRADERS_TIMEOUT_OVERRIDES": "find_trade_routes=5m, spacetraders://systems=0",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if config.Timeout != 45*time.Second {
+		t.Errorf("Expected a 45s timeout, got %s", config.Timeout)
+	}
+	if config.TimeoutOverrides["find_trade_routes"] != 5*time.Minute || config.TimeoutOverrides["spacetraders://systems"] != 0 {
+		t.Errorf("Unexpected overrides %v", config.TimeoutOverrides)
+	}
+}
+
+func TestLoad_CircuitBreaker(t *testing.T) {
+	viper.Reset()
+	for key, value := range map[string]string{
+		"SPACETRADERS_API_TOKEN":        "test-token",
+		"SPACETRADERS_BREAKER_COOLDOWN": "2m",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if config.BreakerThreshold != client.DefaultBreakerThreshold || config.BreakerCooldown != 2*time.Minute {
+		t.Errorf("Expected the default threshold and a 2m cooldown, got %d and %s", config.BreakerThreshold, config.BreakerCooldown)
+	}
+}
+
+func TestLoad_SecondaryTokens(t *testing.T) {
+	viper.Reset()
+	for key, value := range map[string]string{
+		"SPACETRADERS_API_TOKEN":        "test-token",
+		"SPACETRADERS_SECONDARY_TOKENS": " alt-one, ,alt-two ",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("Failed to set environment variable: %v", err)
+		}
+		defer func(key string) {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset environment variable: %v", err)
+			}
+		}(key)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(config.SecondaryTokens) != 2 || config.SecondaryTokens[0] != "alt-one" || config.SecondaryTokens[1] != "alt-two" {
+		t.Errorf("Expected [alt-one alt-two], got %q", config.SecondaryTokens)
+	}
+}
+
+func TestParseTimeoutOverrides_Invalid(t *testing.T) {
+	for _, setting := range []string{"find_trade_routes", "=5m", "find_trade_routes=soon", "find_trade_routes=-1m"} {
+		if _, err := parseTimeoutOverrides(setting); err == nil {
+			t.Errorf("Expected an error for %q", setting)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package cooldowns
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// Cooldown is the cooldown a ship was last put on
+type Cooldown struct {
+	ShipSymbol string `json:"shipSymbol"`
+	// Action is what started the cooldown, such as "extraction" or "waypoint_scan"; empty when
+	// the cooldown was fetched rather than seen starting
+	Action       string    `json:"action,omitempty"`
+	TotalSeconds int       `json:"totalSeconds"`
+	StartedAt    time.Time `json:"startedAt"`
+	Expiration   time.Time `json:"expiration"`
+}
+
+// Remaining returns the time left on the cooldown, or zero once it has expired
+func (c Cooldown) Remaining(now time.Time) time.Duration {
+	return max(c.Expiration.Sub(now), 0)
+}
+
+// Tracker remembers each ship's latest cooldown, so tools can tell whether a ship is ready
+// without asking the API
+type Tracker struct {
+	mu        sync.RWMutex
+	cooldowns map[string]Cooldown
+}
+
+// NewTracker creates an empty cooldown tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		cooldowns: make(map[string]Cooldown),
+	}
+}
+
+// Observe records cooldowns from client observations; it is meant to be passed to client.AddObserver
+func (t *Tracker) Observe(observation client.Observation) {
+	if observation.Kind != client.ObservedCooldown || observation.Cooldown == nil {
+		return
+	}
+	cooldown := observation.Cooldown
+	shipSymbol := observation.ShipSymbol
+	if shipSymbol == "" {
+		shipSymbol = cooldown.ShipSymbol
+	}
+	if shipSymbol == "" {
+		return
+	}
+
+	// The expiration is authoritative; remaining seconds only stand in when it is missing
+	expiration, err := time.Parse(time.RFC3339, cooldown.Expiration)
+	if err != nil {
+		expiration = observation.ObservedAt.Add(time.Duration(cooldown.RemainingSeconds) * time.Second)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !expiration.After(observation.ObservedAt) {
+		delete(t.cooldowns, shipSymbol)
+		return
+	}
+	t.cooldowns[shipSymbol] = Cooldown{
+		ShipSymbol:   shipSymbol,
+		Action:       observation.Action,
+		TotalSeconds: cooldown.TotalSeconds,
+		StartedAt:    expiration.Add(-time.Duration(cooldown.TotalSeconds) * time.Second),
+		Expiration:   expiration,
+	}
+}
+
+// Get returns a ship's cooldown if it is still running at now
+func (t *Tracker) Get(shipSymbol string, now time.Time) (Cooldown, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	cooldown, ok := t.cooldowns[shipSymbol]
+	if !ok || !cooldown.Expiration.After(now) {
+		return Cooldown{}, false
+	}
+	return cooldown, true
+}
+
+// Active returns the cooldowns still running at now, soonest to expire first
+func (t *Tracker) Active(now time.Time) []Cooldown {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	active := make([]Cooldown, 0, len(t.cooldowns))
+	for _, cooldown := range t.cooldowns {
+		if cooldown.Expiration.After(now) {
+			active = append(active, cooldown)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if !active[i].Expiration.Equal(active[j].Expiration) {
+			return active[i].Expiration.Before(active[j].Expiration)
+		}
+		return active[i].ShipSymbol < active[j].ShipSymbol
+	})
+	return active
+}
@@ -0,0 +1,66 @@
+package cooldowns
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestTracker_Observe(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker()
+
+	tracker.Observe(client.Observation{
+		Kind:       client.ObservedCooldown,
+		ShipSymbol: "MINER-1",
+		ObservedAt: now,
+		Action:     "extraction",
+		Cooldown: &client.Cooldown{
+			ShipSymbol:       "MINER-1",
+			TotalSeconds:     70,
+			RemainingSeconds: 70,
+			Expiration:       now.Add(70 * time.Second).Format("2006-01-02T15:04:05.000Z"),
+		},
+	})
+	// Without an expiration the remaining seconds are counted from the observation
+	tracker.Observe(client.Observation{
+		Kind:       client.ObservedCooldown,
+		ShipSymbol: "PROBE-1",
+		ObservedAt: now,
+		Action:     "waypoint_scan",
+		Cooldown:   &client.Cooldown{TotalSeconds: 60, RemainingSeconds: 30},
+	})
+	// Other observations are ignored
+	tracker.Observe(client.Observation{Kind: client.ObservedNavigation, ShipSymbol: "HAULER-1", ObservedAt: now})
+
+	cooldown, ok := tracker.Get("MINER-1", now.Add(10*time.Second))
+	if !ok || cooldown.Action != "extraction" || cooldown.Remaining(now.Add(10*time.Second)) != time.Minute {
+		t.Errorf("Expected a minute left on the extraction cooldown, got %+v (%v)", cooldown, ok)
+	}
+	if !cooldown.StartedAt.Equal(now) {
+		t.Errorf("Expected the cooldown to have started at %s, got %s", now, cooldown.StartedAt)
+	}
+
+	active := tracker.Active(now)
+	if len(active) != 2 || active[0].ShipSymbol != "PROBE-1" || active[1].ShipSymbol != "MINER-1" {
+		t.Errorf("Expected PROBE-1 then MINER-1, got %+v", active)
+	}
+	if active := tracker.Active(now.Add(45 * time.Second)); len(active) != 1 || active[0].ShipSymbol != "MINER-1" {
+		t.Errorf("Expected only MINER-1 after the probe's cooldown expired, got %+v", active)
+	}
+	if _, ok := tracker.Get("HAULER-1", now); ok {
+		t.Error("Expected no cooldown for a ship that never had one")
+	}
+
+	// A fetched cooldown with nothing remaining clears the ship
+	tracker.Observe(client.Observation{
+		Kind:       client.ObservedCooldown,
+		ShipSymbol: "MINER-1",
+		ObservedAt: now.Add(5 * time.Second),
+		Cooldown:   &client.Cooldown{ShipSymbol: "MINER-1"},
+	})
+	if _, ok := tracker.Get("MINER-1", now.Add(5*time.Second)); ok {
+		t.Error("Expected a fetched empty cooldown to clear MINER-1")
+	}
+}
@@ -0,0 +1,185 @@
+// Package credits keeps a time series of the agent's credit balance, sampled from every API
+// response that carries the agent, so trends can be answered from data
+package credits
+
+import (
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// maxSamples bounds the history; the oldest samples are dropped first
+const maxSamples = 5000
+
+// minSampleGap is how long an unchanged balance goes before it is sampled again, so a busy
+// loop of reads doesn't fill the history with repeats
+const minSampleGap = time.Minute
+
+// Sample is the credit balance at one moment
+type Sample struct {
+	At      time.Time `json:"at"`
+	Credits int64     `json:"credits"`
+}
+
+// Bucket is how the balance moved during one period
+type Bucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Open is the balance when the period began, carried over from earlier periods when needed
+	Open  int64 `json:"open"`
+	Close int64 `json:"close"`
+	Delta int64 `json:"delta"`
+	Low   int64 `json:"low"`
+	High  int64 `json:"high"`
+	// Samples is how many samples fell within the period
+	Samples int `json:"samples"`
+}
+
+// Trend summarizes how the balance moved between two samples
+type Trend struct {
+	From    Sample  `json:"from"`
+	To      Sample  `json:"to"`
+	Delta   int64   `json:"delta"`
+	PerHour float64 `json:"perHour"`
+}
+
+// History is the agent's credit balance over time
+type History struct {
+	mu      sync.RWMutex
+	samples []Sample
+}
+
+// New creates an empty credits history
+func New() *History {
+	return &History{}
+}
+
+// Observe samples the balance from client observations; it is meant to be passed to client.AddObserver
+func (h *History) Observe(observation client.Observation) {
+	if observation.Kind != client.ObservedAgent || observation.Agent == nil {
+		return
+	}
+	h.Record(observation.ObservedAt, observation.Agent.Credits)
+}
+
+// Record adds a sample, unless the balance is unchanged and was sampled moments ago
+func (h *History) Record(at time.Time, credits int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n := len(h.samples); n > 0 {
+		last := h.samples[n-1]
+		// Observations can arrive slightly out of order; keep the series in time order
+		if at.Before(last.At) {
+			at = last.At
+		}
+		if last.Credits == credits && at.Sub(last.At) < minSampleGap {
+			return
+		}
+	}
+	h.samples = append(h.samples, Sample{At: at, Credits: credits})
+	if excess := len(h.samples) - maxSamples; excess > 0 {
+		h.samples = append([]Sample(nil), h.samples[excess:]...)
+	}
+}
+
+// Samples returns the samples at or after since, oldest first. A zero since returns them all.
+func (h *History) Samples(since time.Time) []Sample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	samples := make([]Sample, 0, len(h.samples))
+	for _, sample := range h.samples {
+		if !sample.At.Before(since) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// Latest returns the most recent sample
+func (h *History) Latest() (Sample, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.samples) == 0 {
+		return Sample{}, false
+	}
+	return h.samples[len(h.samples)-1], true
+}
+
+// Trend summarizes how the balance moved from the last sample before since, or the first sample
+// after it, to the latest sample. It reports false with fewer than two samples to compare.
+func (h *History) Trend(since time.Time) (Trend, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	start, ok := h.balanceAtLocked(since)
+	if !ok || len(h.samples) < 2 {
+		return Trend{}, false
+	}
+	end := h.samples[len(h.samples)-1]
+	if !end.At.After(start.At) {
+		return Trend{}, false
+	}
+
+	trend := Trend{From: start, To: end, Delta: end.Credits - start.Credits}
+	trend.PerHour = float64(trend.Delta) / end.At.Sub(start.At).Hours()
+	return trend, true
+}
+
+// Buckets splits the time from since to now into periods of size, aligned to whole periods in
+// UTC, and reports how the balance moved in each. Periods before the first sample are left out.
+func (h *History) Buckets(size time.Duration, since, now time.Time) []Bucket {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	buckets := []Bucket{}
+	if size <= 0 || len(h.samples) == 0 {
+		return buckets
+	}
+	if first := h.samples[0].At; since.Before(first) {
+		since = first
+	}
+
+	i := 0
+	for start := since.UTC().Truncate(size); start.Before(now); start = start.Add(size) {
+		end := start.Add(size)
+		open, ok := h.balanceAtLocked(start)
+		if !ok {
+			continue
+		}
+		bucket := Bucket{Start: start, End: end, Open: open.Credits, Close: open.Credits, Low: open.Credits, High: open.Credits}
+
+		for i < len(h.samples) && h.samples[i].At.Before(start) {
+			i++
+		}
+		for ; i < len(h.samples) && h.samples[i].At.Before(end); i++ {
+			credits := h.samples[i].Credits
+			bucket.Close = credits
+			bucket.Low = min(bucket.Low, credits)
+			bucket.High = max(bucket.High, credits)
+			bucket.Samples++
+		}
+		bucket.Delta = bucket.Close - bucket.Open
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// balanceAtLocked returns the balance as of at: the last sample at or before it, or the first
+// sample after it when the history starts later
+func (h *History) balanceAtLocked(at time.Time) (Sample, bool) {
+	if len(h.samples) == 0 {
+		return Sample{}, false
+	}
+	found := h.samples[0]
+	for _, sample := range h.samples {
+		if sample.At.After(at) {
+			break
+		}
+		found = sample
+	}
+	return found, true
+}
@@ -0,0 +1,63 @@
+package credits
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestHistory_SamplesAgentObservations(t *testing.T) {
+	h := New()
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	observe := func(at time.Time, credits int64) {
+		h.Observe(client.Observation{Kind: client.ObservedAgent, ObservedAt: at, Agent: &client.Agent{Credits: credits}})
+	}
+
+	observe(start, 1000)
+	observe(start.Add(10*time.Second), 1000) // unchanged moments later, dropped
+	observe(start.Add(20*time.Second), 1200)
+	observe(start.Add(2*time.Minute), 1200) // unchanged but a while later, kept
+	h.Observe(client.Observation{Kind: client.ObservedMarket, ObservedAt: start})
+
+	samples := h.Samples(time.Time{})
+	if len(samples) != 3 {
+		t.Fatalf("Expected 3 samples, got %+v", samples)
+	}
+	if latest, _ := h.Latest(); latest.Credits != 1200 {
+		t.Errorf("Expected latest balance 1200, got %d", latest.Credits)
+	}
+}
+
+func TestHistory_BucketsAndTrend(t *testing.T) {
+	h := New()
+	start := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+	h.Record(start, 1000)
+	h.Record(start.Add(20*time.Minute), 800)              // 10:50
+	h.Record(start.Add(40*time.Minute), 1500)             // 11:10
+	h.Record(start.Add(2*time.Hour+10*time.Minute), 1700) // 12:40
+	now := start.Add(3 * time.Hour)
+
+	buckets := h.Buckets(time.Hour, start.Add(-5*time.Hour), now)
+	if len(buckets) != 4 {
+		t.Fatalf("Expected hourly buckets from 10:00 to 13:00, got %+v", buckets)
+	}
+	first := buckets[0]
+	if first.Open != 1000 || first.Close != 800 || first.Low != 800 || first.High != 1000 || first.Delta != -200 || first.Samples != 2 {
+		t.Errorf("Unexpected 10:00 bucket %+v", first)
+	}
+	if second := buckets[1]; second.Open != 800 || second.Close != 1500 || second.Delta != 700 {
+		t.Errorf("Unexpected 11:00 bucket %+v", second)
+	}
+	if last := buckets[3]; last.Open != 1700 || last.Delta != 0 || last.Samples != 0 {
+		t.Errorf("Expected a quiet 13:00 bucket carrying the balance over, got %+v", last)
+	}
+
+	trend, ok := h.Trend(start.Add(30 * time.Minute))
+	if !ok || trend.From.Credits != 800 || trend.Delta != 900 {
+		t.Fatalf("Expected a +900 trend from the 800 balance held at 11:00, got %+v (ok %v)", trend, ok)
+	}
+	if perHour := 900 / (110.0 / 60); trend.PerHour < perHour-0.01 || trend.PerHour > perHour+0.01 {
+		t.Errorf("Expected the rate over 110 minutes, got %v", trend.PerHour)
+	}
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// maxEvents bounds how many recent events are kept; the oldest are dropped first
+const maxEvents = 500
+
+// DamageThreshold is how many condition events a ship may accumulate before it is flagged for repair
+const DamageThreshold = 3
+
+// Event is a condition event reported for a ship, such as a component worn by navigation or extraction
+type Event struct {
+	Timestamp   time.Time `json:"timestamp"`
+	ShipSymbol  string    `json:"shipSymbol"`
+	Symbol      string    `json:"symbol"`
+	Component   string    `json:"component"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+// ShipDamage is the condition events a ship has accumulated since the server started
+type ShipDamage struct {
+	ShipSymbol  string         `json:"shipSymbol"`
+	Count       int            `json:"count"`
+	ByComponent map[string]int `json:"byComponent"`
+	LastEventAt time.Time      `json:"lastEventAt"`
+	Flagged     bool           `json:"flagged"`
+}
+
+// Log keeps the condition events the API reports, which are otherwise shown once and lost
+type Log struct {
+	mu     sync.RWMutex
+	events []Event
+	ships  map[string]*ShipDamage
+}
+
+// New creates an empty event log
+func New() *Log {
+	return &Log{
+		ships: make(map[string]*ShipDamage),
+	}
+}
+
+// Observe records ship condition events; it is meant to be passed to client.AddObserver
+func (l *Log) Observe(observation client.Observation) {
+	if observation.Kind != client.ObservedShipEvents {
+		return
+	}
+	for _, event := range observation.Events {
+		l.Record(Event{
+			Timestamp:   observation.ObservedAt,
+			ShipSymbol:  observation.ShipSymbol,
+			Symbol:      event.Symbol,
+			Component:   event.Component,
+			Name:        event.Name,
+			Description: event.Description,
+		})
+	}
+}
+
+// Record adds an event and counts it against its ship
+func (l *Log) Record(event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	if len(l.events) > maxEvents {
+		l.events = l.events[len(l.events)-maxEvents:]
+	}
+
+	damage, ok := l.ships[event.ShipSymbol]
+	if !ok {
+		damage = &ShipDamage{
+			ShipSymbol:  event.ShipSymbol,
+			ByComponent: make(map[string]int),
+		}
+		l.ships[event.ShipSymbol] = damage
+	}
+	damage.Count++
+	damage.ByComponent[event.Component]++
+	if event.Timestamp.After(damage.LastEventAt) {
+		damage.LastEventAt = event.Timestamp
+	}
+	damage.Flagged = damage.Count >= DamageThreshold
+}
+
+// Recent returns the most recent events, newest first, up to limit (all when limit is 0)
+func (l *Log) Recent(limit int) []Event {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if limit <= 0 || limit > len(l.events) {
+		limit = len(l.events)
+	}
+	recent := make([]Event, 0, limit)
+	for i := len(l.events) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, l.events[i])
+	}
+	return recent
+}
+
+// Ships returns the damage each ship has accumulated, most events first
+func (l *Log) Ships() []ShipDamage {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ships := make([]ShipDamage, 0, len(l.ships))
+	for _, damage := range l.ships {
+		copied := *damage
+		copied.ByComponent = make(map[string]int, len(damage.ByComponent))
+		for component, count := range damage.ByComponent {
+			copied.ByComponent[component] = count
+		}
+		ships = append(ships, copied)
+	}
+	sort.Slice(ships, func(i, j int) bool {
+		if ships[i].Count != ships[j].Count {
+			return ships[i].Count > ships[j].Count
+		}
+		return ships[i].ShipSymbol < ships[j].ShipSymbol
+	})
+	return ships
+}
+
+// Flagged returns the symbols of ships that have reached the damage threshold
+func (l *Log) Flagged() []string {
+	var flagged []string
+	for _, damage := range l.Ships() {
+		if damage.Flagged {
+			flagged = append(flagged, damage.ShipSymbol)
+		}
+	}
+	return flagged
+}
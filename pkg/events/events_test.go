@@ -0,0 +1,68 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestLog_ObserveAccumulatesAndFlags(t *testing.T) {
+	l := New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	observe := func(ship string, at time.Time, components ...string) {
+		events := make([]client.Event, 0, len(components))
+		for _, component := range components {
+			events = append(events, client.Event{Symbol: component + "_WEAR", Component: component, Name: "Wear"})
+		}
+		l.Observe(client.Observation{Kind: client.ObservedShipEvents, ShipSymbol: ship, ObservedAt: at, Events: events})
+	}
+
+	observe("MINER-1", base, "ENGINE")
+	observe("MINER-1", base.Add(time.Minute), "FRAME", "ENGINE")
+	observe("HAULER-1", base.Add(2*time.Minute), "REACTOR")
+	// Other observations are ignored
+	l.Observe(client.Observation{Kind: client.ObservedNavigation, ShipSymbol: "HAULER-1", Nav: &client.Navigation{}})
+
+	recent := l.Recent(0)
+	if len(recent) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(recent))
+	}
+	if recent[0].ShipSymbol != "HAULER-1" || recent[0].Component != "REACTOR" {
+		t.Errorf("Expected the newest event first, got %+v", recent[0])
+	}
+	if got := l.Recent(2); len(got) != 2 {
+		t.Errorf("Expected the limit to apply, got %d events", len(got))
+	}
+
+	ships := l.Ships()
+	if len(ships) != 2 || ships[0].ShipSymbol != "MINER-1" {
+		t.Fatalf("Expected MINER-1 first, got %+v", ships)
+	}
+	if ships[0].Count != 3 || ships[0].ByComponent["ENGINE"] != 2 || !ships[0].Flagged {
+		t.Errorf("Expected MINER-1 flagged with 3 events, 2 on the engine, got %+v", ships[0])
+	}
+	if !ships[0].LastEventAt.Equal(base.Add(time.Minute)) {
+		t.Errorf("Expected the last event time to be tracked, got %v", ships[0].LastEventAt)
+	}
+	if ships[1].Flagged {
+		t.Error("Expected HAULER-1 not to be flagged after one event")
+	}
+	if flagged := l.Flagged(); len(flagged) != 1 || flagged[0] != "MINER-1" {
+		t.Errorf("Expected only MINER-1 flagged, got %v", flagged)
+	}
+}
+
+func TestLog_RecordDropsOldest(t *testing.T) {
+	l := New()
+	for i := 0; i < maxEvents+10; i++ {
+		l.Record(Event{ShipSymbol: "MINER-1", Component: "ENGINE"})
+	}
+	if got := len(l.Recent(0)); got != maxEvents {
+		t.Errorf("Expected %d events kept, got %d", maxEvents, got)
+	}
+	// The per-ship count covers every event, not just the ones still listed
+	if got := l.Ships()[0].Count; got != maxEvents+10 {
+		t.Errorf("Expected %d events counted, got %d", maxEvents+10, got)
+	}
+}
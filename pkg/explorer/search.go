@@ -0,0 +1,176 @@
+package explorer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"spacetraders-mcp/pkg/travel"
+)
+
+// Query narrows down a search of what the tracker has recorded. Zero values match everything.
+// When any waypoint criterion is set the search returns waypoints, otherwise it returns systems.
+type Query struct {
+	SystemType string
+	// Faction matches waypoints the faction controls, or systems it is present in
+	Faction string
+
+	WaypointType string
+	// Traits must all be present on a waypoint
+	Traits []string
+	// ShipType matches waypoints whose shipyard sells it
+	ShipType string
+
+	// NearSystem and MaxDistance keep results within MaxDistance of a system. Without a
+	// MaxDistance results are still sorted by distance from NearSystem.
+	NearSystem  string
+	MaxDistance float64
+
+	Limit int
+}
+
+// waypointLevel reports whether the query has criteria only waypoints can meet
+func (q Query) waypointLevel() bool {
+	return q.WaypointType != "" || len(q.Traits) > 0 || q.ShipType != ""
+}
+
+// Match is a system, or a waypoint in it, found by a search
+type Match struct {
+	System   System    `json:"system"`
+	Waypoint *Waypoint `json:"waypoint,omitempty"`
+	// Distance is from the query's NearSystem, when it has one
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+// SearchResult is what a search found, and how many records it had to skip
+type SearchResult struct {
+	Matches []Match `json:"matches"`
+	// Total counts every match before the limit was applied
+	Total int `json:"total"`
+	// UnknownPosition counts systems left out of a distance search because their position
+	// has never been seen
+	UnknownPosition int `json:"unknownPosition,omitempty"`
+}
+
+// ErrUnknownSystem is returned when a search is measured from a system whose position has never been seen
+var ErrUnknownSystem = errors.New("system position unknown")
+
+// Search looks through the recorded systems and waypoints for those matching the query, nearest
+// to the query's NearSystem first, then by symbol. It makes no API calls, so it only finds what
+// has been listed, scanned or fetched before.
+func (t *Tracker) Search(q Query) (SearchResult, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var origin *System
+	if q.NearSystem != "" {
+		system, ok := t.progress.Systems[q.NearSystem]
+		if !ok || !system.PositionKnown() {
+			return SearchResult{}, fmt.Errorf("%w: %s", ErrUnknownSystem, q.NearSystem)
+		}
+		origin = system
+	}
+
+	result := SearchResult{Matches: make([]Match, 0)}
+	// distance measures a system from the origin, and reports whether it is within range
+	distance := func(system *System) (*float64, bool) {
+		if origin == nil {
+			return nil, true
+		}
+		if !system.PositionKnown() {
+			result.UnknownPosition++
+			return nil, false
+		}
+		d := travel.Distance(origin.X, origin.Y, system.X, system.Y)
+		return &d, q.MaxDistance <= 0 || d <= q.MaxDistance
+	}
+	systemMatches := func(system *System) bool {
+		return q.SystemType == "" || system.Type == q.SystemType
+	}
+
+	if q.waypointLevel() {
+		// Systems are measured once, however many of their waypoints match
+		type measured struct {
+			distance *float64
+			inRange  bool
+		}
+		systems := make(map[string]measured)
+		for _, waypoint := range t.progress.Waypoints {
+			if !waypointMatches(*waypoint, q) {
+				continue
+			}
+			system := t.progress.Systems[waypoint.SystemSymbol]
+			if system == nil || !systemMatches(system) {
+				continue
+			}
+			m, ok := systems[system.Symbol]
+			if !ok {
+				m.distance, m.inRange = distance(system)
+				systems[system.Symbol] = m
+			}
+			if !m.inRange {
+				continue
+			}
+			w := *waypoint
+			result.Matches = append(result.Matches, Match{System: *system, Waypoint: &w, Distance: m.distance})
+		}
+	} else {
+		for _, system := range t.progress.Systems {
+			if !systemMatches(system) || (q.Faction != "" && !t.factionInSystemLocked(system, q.Faction)) {
+				continue
+			}
+			d, inRange := distance(system)
+			if !inRange {
+				continue
+			}
+			result.Matches = append(result.Matches, Match{System: *system, Distance: d})
+		}
+	}
+
+	sort.Slice(result.Matches, func(i, j int) bool {
+		a, b := result.Matches[i], result.Matches[j]
+		if a.Distance != nil && b.Distance != nil && *a.Distance != *b.Distance {
+			return *a.Distance < *b.Distance
+		}
+		if a.System.Symbol != b.System.Symbol {
+			return a.System.Symbol < b.System.Symbol
+		}
+		return a.Waypoint != nil && b.Waypoint != nil && a.Waypoint.Symbol < b.Waypoint.Symbol
+	})
+	result.Total = len(result.Matches)
+	if q.Limit > 0 && len(result.Matches) > q.Limit {
+		result.Matches = result.Matches[:q.Limit]
+	}
+	return result, nil
+}
+
+// waypointMatches reports whether a waypoint meets the query's waypoint criteria
+func waypointMatches(waypoint Waypoint, q Query) bool {
+	if q.WaypointType != "" && waypoint.Type != q.WaypointType {
+		return false
+	}
+	if q.Faction != "" && waypoint.Faction != q.Faction {
+		return false
+	}
+	for _, trait := range q.Traits {
+		if !waypoint.HasTrait(trait) {
+			return false
+		}
+	}
+	return q.ShipType == "" || slices.Contains(waypoint.ShipTypes, q.ShipType)
+}
+
+// factionInSystemLocked reports whether a faction is present in a system, going by the system's
+// factions when it has been fetched and by its waypoints otherwise
+func (t *Tracker) factionInSystemLocked(system *System, faction string) bool {
+	if slices.Contains(system.Factions, faction) {
+		return true
+	}
+	for _, waypoint := range t.progress.Waypoints {
+		if waypoint.SystemSymbol == system.Symbol && waypoint.Faction == faction {
+			return true
+		}
+	}
+	return false
+}
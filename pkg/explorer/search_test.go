@@ -0,0 +1,100 @@
+package explorer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func searchTracker(t *testing.T) *Tracker {
+	t.Helper()
+	tracker, err := Open("")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	now := time.Now()
+	tracker.Observe(client.Observation{Kind: client.ObservedSystems, ObservedAt: now, Systems: []client.System{
+		{Symbol: "X1-TEST", Type: "RED_STAR", X: 0, Y: 0, Factions: []client.SystemFaction{{Symbol: "COSMIC"}}},
+		{Symbol: "X1-NEAR", Type: "RED_STAR", X: 30, Y: 40},
+		{Symbol: "X1-FAR", Type: "BLUE_STAR", X: 300, Y: 400},
+	}})
+	tracker.Observe(client.Observation{Kind: client.ObservedSystemWaypoints, ObservedAt: now, Waypoints: []client.SystemWaypoint{
+		{Symbol: "X1-TEST-A1", Type: "PLANET", Traits: []client.WaypointTrait{{Symbol: "MARKETPLACE"}, {Symbol: "SHIPYARD"}}, Faction: &client.WaypointFaction{Symbol: "COSMIC"}},
+		{Symbol: "X1-TEST-B2", Type: "ASTEROID", Traits: []client.WaypointTrait{{Symbol: "COMMON_METAL_DEPOSITS"}}},
+	}})
+	tracker.Observe(client.Observation{Kind: client.ObservedWaypointScan, ObservedAt: now, ScannedWaypoints: []client.ScannedWaypoint{
+		{Symbol: "X1-FAR-C3", SystemSymbol: "X1-FAR", Type: "ORBITAL_STATION", Traits: []client.WaypointTrait{{Symbol: "SHIPYARD"}}, Faction: &client.WaypointFaction{Symbol: "VOID"}},
+	}})
+	tracker.Observe(client.Observation{Kind: client.ObservedShipyard, ObservedAt: now, Shipyard: &client.Shipyard{
+		Symbol: "X1-TEST-A1", ShipTypes: []client.ShipyardShipType{{Type: "SHIP_PROBE"}, {Type: "SHIP_MINING_DRONE"}},
+	}})
+	tracker.Observe(client.Observation{Kind: client.ObservedShipyard, ObservedAt: now, Shipyard: &client.Shipyard{
+		Symbol: "X1-FAR-C3", ShipTypes: []client.ShipyardShipType{{Type: "SHIP_MINING_DRONE"}},
+	}})
+	// Seen only as a waypoint's system, so its position is unknown
+	tracker.Observe(client.Observation{Kind: client.ObservedSystemWaypoints, ObservedAt: now, Waypoints: []client.SystemWaypoint{
+		{Symbol: "X1-LOST-D4", Type: "PLANET"},
+	}})
+	return tracker
+}
+
+func TestTracker_SearchWaypoints(t *testing.T) {
+	tracker := searchTracker(t)
+
+	found, err := tracker.Search(Query{ShipType: "SHIP_MINING_DRONE", NearSystem: "X1-NEAR"})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if found.Total != 2 || found.Matches[0].Waypoint.Symbol != "X1-TEST-A1" || found.Matches[1].Waypoint.Symbol != "X1-FAR-C3" {
+		t.Fatalf("Expected both shipyards selling mining drones, nearest first, got %+v", found.Matches)
+	}
+	if *found.Matches[0].Distance != 50 {
+		t.Errorf("Expected X1-TEST to be 50 units from X1-NEAR, got %v", *found.Matches[0].Distance)
+	}
+
+	found, _ = tracker.Search(Query{ShipType: "SHIP_MINING_DRONE", NearSystem: "X1-NEAR", MaxDistance: 100})
+	if found.Total != 1 || found.Matches[0].Waypoint.Symbol != "X1-TEST-A1" {
+		t.Errorf("Expected only the shipyard within 100 units, got %+v", found.Matches)
+	}
+
+	found, _ = tracker.Search(Query{Traits: []string{"SHIPYARD"}, Faction: "VOID"})
+	if found.Total != 1 || found.Matches[0].Waypoint.Symbol != "X1-FAR-C3" {
+		t.Errorf("Expected the VOID shipyard, got %+v", found.Matches)
+	}
+
+	found, _ = tracker.Search(Query{Traits: []string{"MARKETPLACE", "SHIPYARD"}, SystemType: "BLUE_STAR"})
+	if found.Total != 0 {
+		t.Errorf("Expected no marketplace with a shipyard around a blue star, got %+v", found.Matches)
+	}
+}
+
+func TestTracker_SearchSystems(t *testing.T) {
+	tracker := searchTracker(t)
+
+	found, err := tracker.Search(Query{SystemType: "RED_STAR"})
+	if err != nil || found.Total != 2 || found.Matches[0].System.Symbol != "X1-NEAR" || found.Matches[0].Waypoint != nil {
+		t.Fatalf("Expected the two red star systems by symbol, got %+v (%v)", found.Matches, err)
+	}
+
+	// Factions count from a fetched system or from the waypoints they control
+	found, _ = tracker.Search(Query{Faction: "VOID"})
+	if found.Total != 1 || found.Matches[0].System.Symbol != "X1-FAR" {
+		t.Errorf("Expected X1-FAR for its VOID station, got %+v", found.Matches)
+	}
+	found, _ = tracker.Search(Query{Faction: "COSMIC"})
+	if found.Total != 1 || found.Matches[0].System.Symbol != "X1-TEST" {
+		t.Errorf("Expected X1-TEST for COSMIC, got %+v", found.Matches)
+	}
+
+	found, _ = tracker.Search(Query{NearSystem: "X1-TEST", Limit: 2})
+	if found.Total != 3 || len(found.Matches) != 2 || found.Matches[1].System.Symbol != "X1-NEAR" || found.UnknownPosition != 1 {
+		t.Errorf("Expected the 2 nearest of 3 systems with a known position, got %+v", found)
+	}
+
+	if _, err := tracker.Search(Query{NearSystem: "X1-LOST"}); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("Expected ErrUnknownSystem measuring from a system without a position, got %v", err)
+	}
+}
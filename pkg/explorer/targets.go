@@ -0,0 +1,118 @@
+package explorer
+
+import (
+	"sort"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/travel"
+)
+
+// Reasons a waypoint is suggested as an exploration target, in priority order
+const (
+	ReasonUncharted         = "uncharted"
+	ReasonUnvisitedMarket   = "unvisited_marketplace"
+	ReasonUnvisitedShipyard = "unvisited_shipyard"
+)
+
+// Target is a waypoint worth exploring and how far it is from the ship it was suggested for
+type Target struct {
+	Symbol   string   `json:"symbol"`
+	Type     string   `json:"type"`
+	Reason   string   `json:"reason"`
+	Distance float64  `json:"distance"`
+	Traits   []string `json:"traits,omitempty"`
+}
+
+// targetReason returns why a waypoint is worth exploring, or "" if it is not
+func targetReason(waypoint Waypoint) string {
+	switch {
+	case waypoint.Type == "":
+		return ""
+	case !waypoint.Charted:
+		return ReasonUncharted
+	case !waypoint.VisitedAt.IsZero():
+		return ""
+	case waypoint.HasTrait("MARKETPLACE"):
+		return ReasonUnvisitedMarket
+	case waypoint.HasTrait("SHIPYARD"):
+		return ReasonUnvisitedShipyard
+	default:
+		return ""
+	}
+}
+
+// reasonRank orders reasons so uncharted waypoints come first
+var reasonRank = map[string]int{
+	ReasonUncharted:         0,
+	ReasonUnvisitedMarket:   1,
+	ReasonUnvisitedShipyard: 2,
+}
+
+// Suggest returns up to limit waypoints in a system worth exploring from (x, y): uncharted
+// waypoints first, then marketplaces and shipyards no ship has visited, nearest first within each.
+// Only the given reasons are considered; none means all of them.
+func (t *Tracker) Suggest(systemSymbol string, x, y, limit int, reasons ...string) []Target {
+	wanted := make(map[string]bool, len(reasons))
+	for _, reason := range reasons {
+		wanted[reason] = true
+	}
+
+	var targets []Target
+	for _, waypoint := range t.Waypoints(systemSymbol) {
+		reason := targetReason(waypoint)
+		if reason == "" || (len(wanted) > 0 && !wanted[reason]) {
+			continue
+		}
+		targets = append(targets, Target{
+			Symbol:   waypoint.Symbol,
+			Type:     waypoint.Type,
+			Reason:   reason,
+			Distance: travel.Distance(x, y, waypoint.X, waypoint.Y),
+			Traits:   waypoint.Traits,
+		})
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		if reasonRank[targets[i].Reason] != reasonRank[targets[j].Reason] {
+			return reasonRank[targets[i].Reason] < reasonRank[targets[j].Reason]
+		}
+		return targets[i].Distance < targets[j].Distance
+	})
+	if len(targets) > limit {
+		targets = targets[:limit]
+	}
+	return targets
+}
+
+// ShipTargets is the exploration targets nearest one ship
+type ShipTargets struct {
+	ShipSymbol     string   `json:"shipSymbol"`
+	SystemSymbol   string   `json:"systemSymbol"`
+	WaypointSymbol string   `json:"waypointSymbol"`
+	Targets        []Target `json:"targets"`
+}
+
+// SuggestForShips returns targets near each ship, measured from where it is or, when in transit,
+// where it is headed. Each ship's system is listed through the client's waypoint cache first so
+// the tracker knows its waypoints.
+func (t *Tracker) SuggestForShips(c *client.Client, ships []client.Ship, limit int, reasons ...string) ([]ShipTargets, error) {
+	suggestions := make([]ShipTargets, 0, len(ships))
+	for _, ship := range ships {
+		if _, _, err := c.GetCachedSystemWaypoints(ship.Nav.SystemSymbol); err != nil {
+			return nil, err
+		}
+
+		position := ship.Nav.Route.Destination
+		targets := t.Suggest(ship.Nav.SystemSymbol, position.X, position.Y, limit, reasons...)
+		if targets == nil {
+			targets = []Target{}
+		}
+		suggestions = append(suggestions, ShipTargets{
+			ShipSymbol:     ship.Symbol,
+			SystemSymbol:   ship.Nav.SystemSymbol,
+			WaypointSymbol: ship.Nav.WaypointSymbol,
+			Targets:        targets,
+		})
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,411 @@
+package explorer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/travel"
+)
+
+// System is what the agent has learned about a system
+type System struct {
+	Symbol string `json:"symbol"`
+	Type   string `json:"type,omitempty"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	// Factions are the factions present in the system, known once the system has been fetched
+	Factions []string `json:"factions,omitempty"`
+
+	// VisitedAt is when a ship first arrived in the system
+	VisitedAt time.Time `json:"visitedAt,omitzero"`
+	// ScannedAt is when the system last showed up in a system scan, which also gives its position
+	ScannedAt time.Time `json:"scannedAt,omitzero"`
+	// ListedAt is when the system's waypoint list was last fetched
+	ListedAt time.Time `json:"listedAt,omitzero"`
+	// FetchedAt is when the system itself was last fetched, which also gives its position and factions
+	FetchedAt time.Time `json:"fetchedAt,omitzero"`
+}
+
+// PositionKnown reports whether the system's coordinates have been seen, from a scan or a fetch
+func (s System) PositionKnown() bool {
+	return !s.ScannedAt.IsZero() || !s.FetchedAt.IsZero()
+}
+
+// Waypoint is what the agent has learned about a waypoint
+type Waypoint struct {
+	Symbol       string   `json:"symbol"`
+	SystemSymbol string   `json:"systemSymbol"`
+	Type         string   `json:"type,omitempty"`
+	X            int      `json:"x"`
+	Y            int      `json:"y"`
+	Traits       []string `json:"traits,omitempty"`
+	Charted      bool     `json:"charted"`
+	// Faction is the faction controlling the waypoint, if any
+	Faction string `json:"faction,omitempty"`
+	// ShipTypes are the ship types sold by the waypoint's shipyard, known once it has been fetched
+	ShipTypes []string `json:"shipTypes,omitempty"`
+
+	// VisitedAt is when a ship first arrived at the waypoint
+	VisitedAt time.Time `json:"visitedAt,omitzero"`
+	// ScannedAt is when the waypoint last showed up in a waypoint scan
+	ScannedAt time.Time `json:"scannedAt,omitzero"`
+}
+
+// HasTrait reports whether the waypoint had the trait when it was last seen
+func (w Waypoint) HasTrait(trait string) bool {
+	for _, t := range w.Traits {
+		if t == trait {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary counts what has been explored so far
+type Summary struct {
+	SystemsKnown       int `json:"systemsKnown"`
+	SystemsVisited     int `json:"systemsVisited"`
+	WaypointsKnown     int `json:"waypointsKnown"`
+	WaypointsVisited   int `json:"waypointsVisited"`
+	WaypointsCharted   int `json:"waypointsCharted"`
+	WaypointsUncharted int `json:"waypointsUncharted"`
+}
+
+// progress is the tracker state written to disk
+type progress struct {
+	AgentSymbol string               `json:"agentSymbol,omitempty"`
+	ResetDate   string               `json:"resetDate,omitempty"`
+	Systems     map[string]*System   `json:"systems"`
+	Waypoints   map[string]*Waypoint `json:"waypoints"`
+}
+
+// Tracker remembers which systems and waypoints the agent has visited, scanned and charted.
+// With a file path it saves after every change, so progress survives server restarts.
+type Tracker struct {
+	mu       sync.RWMutex
+	path     string
+	progress progress
+	saveErr  error
+}
+
+// Open returns a tracker backed by the file at path, loading progress saved there before.
+// A missing file starts empty; an empty path keeps progress in memory only.
+func Open(path string) (*Tracker, error) {
+	t := &Tracker{path: path}
+	t.progress = progress{
+		Systems:   make(map[string]*System),
+		Waypoints: make(map[string]*Waypoint),
+	}
+	if path == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exploration progress: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &t.progress); err != nil {
+		return nil, fmt.Errorf("failed to parse exploration progress %s: %w", path, err)
+	}
+	if t.progress.Systems == nil {
+		t.progress.Systems = make(map[string]*System)
+	}
+	if t.progress.Waypoints == nil {
+		t.progress.Waypoints = make(map[string]*Waypoint)
+	}
+	return t, nil
+}
+
+// Path returns the file progress is saved to, or "" when it is kept in memory
+func (t *Tracker) Path() string {
+	return t.path
+}
+
+// SaveError returns the error from the last failed save, or nil if the last save succeeded
+func (t *Tracker) SaveError() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.saveErr
+}
+
+// Bind ties the saved progress to an agent and server reset, discarding progress recorded for a
+// different agent or before a reset, since the universe is regenerated on every reset.
+// Empty values are not compared.
+func (t *Tracker) Bind(agentSymbol, resetDate string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stale := (agentSymbol != "" && t.progress.AgentSymbol != "" && t.progress.AgentSymbol != agentSymbol) ||
+		(resetDate != "" && t.progress.ResetDate != "" && t.progress.ResetDate != resetDate)
+	if stale {
+		t.progress.Systems = make(map[string]*System)
+		t.progress.Waypoints = make(map[string]*Waypoint)
+	}
+	if agentSymbol != "" {
+		t.progress.AgentSymbol = agentSymbol
+	}
+	if resetDate != "" {
+		t.progress.ResetDate = resetDate
+	}
+	t.saveLocked()
+}
+
+// Export returns the progress in the form saved to the tracker's file
+func (t *Tracker) Export() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return json.MarshalIndent(t.progress, "", "  ")
+}
+
+// Restore replaces the progress with progress exported before, and saves it. Progress recorded
+// for a different agent or server reset than the tracker is bound to is refused, as Bind would
+// discard it.
+func (t *Tracker) Restore(data []byte) error {
+	var restored progress
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return fmt.Errorf("failed to parse exploration progress: %w", err)
+	}
+	if restored.Systems == nil {
+		restored.Systems = make(map[string]*System)
+	}
+	if restored.Waypoints == nil {
+		restored.Waypoints = make(map[string]*Waypoint)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if restored.AgentSymbol != "" && t.progress.AgentSymbol != "" && restored.AgentSymbol != t.progress.AgentSymbol {
+		return fmt.Errorf("exploration progress belongs to agent %s, not %s", restored.AgentSymbol, t.progress.AgentSymbol)
+	}
+	if restored.ResetDate != "" && t.progress.ResetDate != "" && restored.ResetDate != t.progress.ResetDate {
+		return fmt.Errorf("exploration progress is from the server reset of %s, not %s", restored.ResetDate, t.progress.ResetDate)
+	}
+	if restored.AgentSymbol == "" {
+		restored.AgentSymbol = t.progress.AgentSymbol
+	}
+	if restored.ResetDate == "" {
+		restored.ResetDate = t.progress.ResetDate
+	}
+	t.progress = restored
+	t.saveLocked()
+	return nil
+}
+
+// Observe records exploration from client observations; it is meant to be passed to client.AddObserver
+func (t *Tracker) Observe(observation client.Observation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := observation.ObservedAt
+	switch observation.Kind {
+	case client.ObservedNavigation:
+		nav := observation.Nav
+		if nav == nil {
+			return
+		}
+		// A ship in transit is recorded as visiting its destination when it arrives
+		visitedAt := now
+		if nav.Status == "IN_TRANSIT" {
+			if arrival, err := time.Parse(time.RFC3339, nav.Route.Arrival); err == nil {
+				visitedAt = arrival
+			}
+		}
+		destination := nav.Route.Destination
+		if destination.Symbol == "" {
+			destination = client.Waypoint{Symbol: nav.WaypointSymbol}
+		}
+		waypoint := t.waypointLocked(destination.Symbol)
+		if waypoint.Type == "" {
+			// Routes carry no traits, so the type is left for a waypoint listing to fill in
+			waypoint.X, waypoint.Y = destination.X, destination.Y
+		}
+		if waypoint.VisitedAt.IsZero() {
+			waypoint.VisitedAt = visitedAt
+		}
+		if system := t.systemLocked(waypoint.SystemSymbol); system.VisitedAt.IsZero() {
+			system.VisitedAt = visitedAt
+		}
+	case client.ObservedSystemWaypoints:
+		for _, w := range observation.Waypoints {
+			t.recordWaypointLocked(w.Symbol, w.Type, w.X, w.Y, w.Traits).Faction = factionSymbol(w.Faction)
+		}
+		if len(observation.Waypoints) > 0 {
+			t.systemLocked(travel.SystemSymbol(observation.Waypoints[0].Symbol)).ListedAt = now
+		}
+	case client.ObservedWaypointScan:
+		for _, w := range observation.ScannedWaypoints {
+			waypoint := t.recordWaypointLocked(w.Symbol, w.Type, w.X, w.Y, w.Traits)
+			waypoint.Faction = factionSymbol(w.Faction)
+			waypoint.ScannedAt = now
+		}
+	case client.ObservedSystemScan:
+		for _, s := range observation.ScannedSystems {
+			system := t.systemLocked(s.Symbol)
+			system.Type, system.X, system.Y = s.Type, s.X, s.Y
+			system.ScannedAt = now
+		}
+	case client.ObservedSystems:
+		for _, s := range observation.Systems {
+			system := t.systemLocked(s.Symbol)
+			system.Type, system.X, system.Y = s.Type, s.X, s.Y
+			system.Factions = make([]string, 0, len(s.Factions))
+			for _, faction := range s.Factions {
+				system.Factions = append(system.Factions, faction.Symbol)
+			}
+			system.FetchedAt = now
+		}
+	case client.ObservedShipyard:
+		shipyard := observation.Shipyard
+		if shipyard == nil {
+			return
+		}
+		waypoint := t.waypointLocked(shipyard.Symbol)
+		waypoint.ShipTypes = make([]string, 0, len(shipyard.ShipTypes))
+		for _, shipType := range shipyard.ShipTypes {
+			waypoint.ShipTypes = append(waypoint.ShipTypes, shipType.Type)
+		}
+		t.systemLocked(waypoint.SystemSymbol)
+	default:
+		return
+	}
+
+	t.saveLocked()
+}
+
+// recordWaypointLocked updates a waypoint's position, traits and chart status from an API listing
+func (t *Tracker) recordWaypointLocked(symbol, waypointType string, x, y int, traits []client.WaypointTrait) *Waypoint {
+	waypoint := t.waypointLocked(symbol)
+	waypoint.Type, waypoint.X, waypoint.Y = waypointType, x, y
+	waypoint.Traits = make([]string, 0, len(traits))
+	for _, trait := range traits {
+		waypoint.Traits = append(waypoint.Traits, trait.Symbol)
+	}
+	waypoint.Charted = !waypoint.HasTrait("UNCHARTED")
+	t.systemLocked(waypoint.SystemSymbol)
+	return waypoint
+}
+
+// factionSymbol returns the symbol of a waypoint's controlling faction, or "" when there is none
+func factionSymbol(faction *client.WaypointFaction) string {
+	if faction == nil {
+		return ""
+	}
+	return faction.Symbol
+}
+
+// waypointLocked returns the record for a waypoint, creating it if needed
+func (t *Tracker) waypointLocked(symbol string) *Waypoint {
+	waypoint, ok := t.progress.Waypoints[symbol]
+	if !ok {
+		waypoint = &Waypoint{Symbol: symbol, SystemSymbol: travel.SystemSymbol(symbol)}
+		t.progress.Waypoints[symbol] = waypoint
+	}
+	return waypoint
+}
+
+// systemLocked returns the record for a system, creating it if needed
+func (t *Tracker) systemLocked(symbol string) *System {
+	system, ok := t.progress.Systems[symbol]
+	if !ok {
+		system = &System{Symbol: symbol}
+		t.progress.Systems[symbol] = system
+	}
+	return system
+}
+
+// saveLocked writes progress to the tracker's file, replacing it atomically
+func (t *Tracker) saveLocked() {
+	if t.path == "" {
+		return
+	}
+
+	t.saveErr = func() error {
+		data, err := json.MarshalIndent(t.progress, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+			return err
+		}
+		tmp := t.path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o644); err != nil {
+			return err
+		}
+		return os.Rename(tmp, t.path)
+	}()
+}
+
+// Summary counts the systems and waypoints recorded so far
+func (t *Tracker) Summary() Summary {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	summary := Summary{
+		SystemsKnown:   len(t.progress.Systems),
+		WaypointsKnown: len(t.progress.Waypoints),
+	}
+	for _, system := range t.progress.Systems {
+		if !system.VisitedAt.IsZero() {
+			summary.SystemsVisited++
+		}
+	}
+	for _, waypoint := range t.progress.Waypoints {
+		if !waypoint.VisitedAt.IsZero() {
+			summary.WaypointsVisited++
+		}
+		if waypoint.Type == "" {
+			// Only seen as a destination, so its chart status is unknown
+			continue
+		}
+		if waypoint.Charted {
+			summary.WaypointsCharted++
+		} else {
+			summary.WaypointsUncharted++
+		}
+	}
+	return summary
+}
+
+// Waypoints returns the recorded waypoints in a system, sorted by symbol
+func (t *Tracker) Waypoints(systemSymbol string) []Waypoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var waypoints []Waypoint
+	for _, waypoint := range t.progress.Waypoints {
+		if waypoint.SystemSymbol == systemSymbol {
+			waypoints = append(waypoints, *waypoint)
+		}
+	}
+	sort.Slice(waypoints, func(i, j int) bool {
+		return waypoints[i].Symbol < waypoints[j].Symbol
+	})
+	return waypoints
+}
+
+// Systems returns the recorded systems, sorted by symbol
+func (t *Tracker) Systems() []System {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	systems := make([]System, 0, len(t.progress.Systems))
+	for _, system := range t.progress.Systems {
+		systems = append(systems, *system)
+	}
+	sort.Slice(systems, func(i, j int) bool {
+		return systems[i].Symbol < systems[j].Symbol
+	})
+	return systems
+}
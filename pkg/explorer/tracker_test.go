@@ -0,0 +1,95 @@
+package explorer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func testWaypoints() []client.SystemWaypoint {
+	return []client.SystemWaypoint{
+		{Symbol: "X1-TEST-A1", Type: "PLANET", X: 0, Y: 0, Traits: []client.WaypointTrait{{Symbol: "MARKETPLACE"}}},
+		{Symbol: "X1-TEST-B2", Type: "MOON", X: 30, Y: 40, Traits: []client.WaypointTrait{{Symbol: "UNCHARTED"}}},
+		{Symbol: "X1-TEST-C3", Type: "ASTEROID", X: 3, Y: 4, Traits: []client.WaypointTrait{{Symbol: "UNCHARTED"}}},
+		{Symbol: "X1-TEST-D4", Type: "PLANET", X: 10, Y: 0, Traits: []client.WaypointTrait{{Symbol: "SHIPYARD"}}},
+		{Symbol: "X1-TEST-E5", Type: "GAS_GIANT", X: 1, Y: 1},
+	}
+}
+
+func TestTracker_PersistsProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exploration", "progress.json")
+	tracker, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	now := time.Now()
+	tracker.Observe(client.Observation{Kind: client.ObservedSystemWaypoints, ObservedAt: now, Waypoints: testWaypoints()})
+	tracker.Observe(client.Observation{
+		Kind:       client.ObservedNavigation,
+		ShipSymbol: "SHIP-1",
+		ObservedAt: now,
+		Nav: &client.Navigation{
+			SystemSymbol:   "X1-TEST",
+			WaypointSymbol: "X1-TEST-A1",
+			Status:         "DOCKED",
+			Route:          client.Route{Destination: client.Waypoint{Symbol: "X1-TEST-A1", Type: "PLANET"}},
+		},
+	})
+	tracker.Observe(client.Observation{
+		Kind:           client.ObservedSystemScan,
+		ObservedAt:     now,
+		ScannedSystems: []client.ScannedSystem{{Symbol: "X1-OTHER", Type: "RED_STAR", X: 100, Y: 0}},
+	})
+	if err := tracker.SaveError(); err != nil {
+		t.Fatalf("Expected progress to be saved, got %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	want := Summary{SystemsKnown: 2, SystemsVisited: 1, WaypointsKnown: 5, WaypointsVisited: 1, WaypointsCharted: 3, WaypointsUncharted: 2}
+	if got := reopened.Summary(); got != want {
+		t.Errorf("Expected summary %+v after reopening, got %+v", want, got)
+	}
+
+	// Progress is tied to the first agent bound and discarded when another agent is bound
+	reopened.Bind("FIRST-AGENT", "")
+	if got := reopened.Summary(); got != want {
+		t.Errorf("Expected progress to be kept for the first agent, got %+v", got)
+	}
+	reopened.Bind("OTHER-AGENT", "")
+	if got := reopened.Summary(); got != (Summary{}) {
+		t.Errorf("Expected progress to be discarded for a new agent, got %+v", got)
+	}
+}
+
+func TestTracker_Suggest(t *testing.T) {
+	tracker, err := Open("")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	tracker.Observe(client.Observation{Kind: client.ObservedSystemWaypoints, ObservedAt: time.Now(), Waypoints: testWaypoints()})
+
+	targets := tracker.Suggest("X1-TEST", 0, 0, 10)
+	var got []string
+	for _, target := range targets {
+		got = append(got, target.Symbol+":"+target.Reason)
+	}
+	want := []string{"X1-TEST-C3:uncharted", "X1-TEST-B2:uncharted", "X1-TEST-A1:unvisited_marketplace", "X1-TEST-D4:unvisited_shipyard"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected targets %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected target %d to be %s, got %s", i, want[i], got[i])
+		}
+	}
+
+	if uncharted := tracker.Suggest("X1-TEST", 0, 0, 1, ReasonUncharted); len(uncharted) != 1 || uncharted[0].Distance != 5 {
+		t.Errorf("Expected the nearest uncharted waypoint 5 units away, got %+v", uncharted)
+	}
+}
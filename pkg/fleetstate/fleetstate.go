@@ -0,0 +1,419 @@
+// Package fleetstate keeps a local model of the fleet and the agent. The result of every action
+// the client sees is applied to the model, and the model is reconciled with the API now and
+// then, so most reads can be answered without a round trip and drift is noticed.
+package fleetstate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/polling"
+)
+
+// DefaultInterval is how often the model is reconciled with the API while ships are idle. It is
+// reconciled sooner when a ship is about to arrive or come off cooldown, and up to three times
+// less often once the whole fleet has sat unchanged for a while.
+const DefaultInterval = 3 * time.Minute
+
+// reconcileKey is the scheduler key of the model's single background job
+const reconcileKey = "reconcile"
+
+// maxDivergences is how many of the latest divergences are kept
+const maxDivergences = 50
+
+// Divergence is a difference between the local model and the API found while reconciling
+type Divergence struct {
+	// ShipSymbol is empty for differences in the agent
+	ShipSymbol string    `json:"shipSymbol,omitempty"`
+	Field      string    `json:"field"`
+	Local      string    `json:"local"`
+	Remote     string    `json:"remote"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// Status describes how current the model is and how often it has drifted
+type Status struct {
+	Ships int `json:"ships"`
+	// Fresh is set while reads can be answered from the model
+	Fresh           bool      `json:"fresh"`
+	SyncedAt        time.Time `json:"syncedAt,omitzero"`
+	AgentSyncedAt   time.Time `json:"agentSyncedAt,omitzero"`
+	AppliedUpdates  int       `json:"appliedUpdates"`
+	Reconciles      int       `json:"reconciles"`
+	LastReconcileAt time.Time `json:"lastReconcileAt,omitzero"`
+	LastError       string    `json:"lastError,omitempty"`
+	// Diverged is set when the last reconciliation found the model had drifted from the API
+	Diverged         bool         `json:"diverged"`
+	TotalDivergences int          `json:"totalDivergences"`
+	Divergences      []Divergence `json:"divergences"`
+}
+
+// Model is the local view of the fleet and the agent
+type Model struct {
+	client    *client.Client
+	logger    *logging.Logger
+	scheduler *polling.Scheduler
+	idle      *polling.IdleTracker
+	interval  time.Duration
+
+	mu sync.RWMutex
+	// ships holds every ship once a whole fleet listing has been seen
+	ships    map[string]client.Ship
+	syncedAt time.Time
+	// next is how long the latest reconciliation waits for the one after it
+	next    time.Duration
+	agent   *client.Agent
+	agentAt time.Time
+
+	applied          int
+	reconciles       int
+	lastReconcile    time.Time
+	lastError        string
+	diverged         bool
+	totalDivergences int
+	divergences      []Divergence
+}
+
+// New creates an empty model whose reconciliation stops when ctx is cancelled. Nothing is
+// reconciled until Start is called.
+func New(ctx context.Context, c *client.Client, logger *logging.Logger) *Model {
+	return &Model{
+		client:    c,
+		logger:    logger,
+		scheduler: polling.NewScheduler(ctx),
+		idle:      polling.NewIdleTracker(),
+		interval:  DefaultInterval,
+		ships:     make(map[string]client.Ship),
+	}
+}
+
+// Start reconciles the model now and then in the background, as often as the ships' activity calls for
+func (m *Model) Start() {
+	m.scheduler.Schedule(reconcileKey, func(ctx context.Context) time.Duration {
+		if err := m.Reconcile(ctx); err != nil {
+			m.logger.Error("Fleet state reconciliation failed: %v", err)
+			return m.interval
+		}
+		return m.schedule(time.Now())
+	})
+}
+
+// schedule works out how long to wait before reconciling again: just before the first arrival or
+// cooldown expiry, or longer the longer the fleet has gone unchanged. The model stays fresh until
+// two such waits have been missed.
+func (m *Model) schedule(now time.Time) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ships := make([]client.Ship, 0, len(m.ships))
+	idle := make(map[string]time.Duration, len(m.ships))
+	for symbol, ship := range m.ships {
+		ship = project(ship, now)
+		fingerprint := fmt.Sprintf("%s; fuel %d; %s", describeNav(ship.Nav), ship.Fuel.Current, describeCargo(ship.Cargo))
+		idle[symbol] = m.idle.Observe(symbol, fingerprint, now)
+		ships = append(ships, ship)
+	}
+	m.next = polling.ForInterval(m.interval).FleetInterval(ships, func(ship client.Ship) time.Duration { return idle[ship.Symbol] }, now)
+	return m.next
+}
+
+// window is how long a reconciliation is expected to last the model; the caller holds m.mu
+func (m *Model) window() time.Duration {
+	return max(m.interval, m.next)
+}
+
+// Observe applies the results of API responses to the model; it is meant to be passed to
+// client.AddObserver
+func (m *Model) Observe(observation client.Observation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch observation.Kind {
+	case client.ObservedFleet:
+		ships := make(map[string]client.Ship, len(observation.Ships))
+		for _, ship := range observation.Ships {
+			ships[ship.Symbol] = ship
+		}
+		m.ships = ships
+		m.syncedAt = observation.ObservedAt
+	case client.ObservedShip:
+		if ship := observation.Ship; ship != nil {
+			m.ships[ship.Symbol] = *ship
+			m.applied++
+		}
+	case client.ObservedNavigation:
+		if nav := observation.Nav; nav != nil {
+			m.update(observation.ShipSymbol, func(ship *client.Ship) { ship.Nav = *nav })
+		}
+	case client.ObservedCargo:
+		if cargo := observation.Cargo; cargo != nil {
+			m.update(observation.ShipSymbol, func(ship *client.Ship) { ship.Cargo = *cargo })
+		}
+	case client.ObservedFuel:
+		if fuel := observation.Fuel; fuel != nil {
+			m.update(observation.ShipSymbol, func(ship *client.Ship) { ship.Fuel = *fuel })
+		}
+	case client.ObservedCooldown:
+		if cooldown := observation.Cooldown; cooldown != nil {
+			m.update(observation.ShipSymbol, func(ship *client.Ship) {
+				ship.Cooldown = *cooldown
+				if ship.Cooldown.ShipSymbol == "" {
+					ship.Cooldown.ShipSymbol = ship.Symbol
+				}
+			})
+		}
+	case client.ObservedScrapTransaction:
+		if shipSymbol := strings.ToUpper(observation.ShipSymbol); m.ships[shipSymbol].Symbol != "" {
+			delete(m.ships, shipSymbol)
+			m.applied++
+		}
+	case client.ObservedAgent:
+		if agent := observation.Agent; agent != nil {
+			copied := *agent
+			m.agent = &copied
+			m.agentAt = observation.ObservedAt
+		}
+	}
+}
+
+// update changes a ship the model already holds; ships it has never seen whole are left alone
+func (m *Model) update(shipSymbol string, change func(*client.Ship)) {
+	shipSymbol = strings.ToUpper(shipSymbol)
+	ship, ok := m.ships[shipSymbol]
+	if !ok {
+		return
+	}
+	change(&ship)
+	m.ships[shipSymbol] = ship
+	m.applied++
+}
+
+// fresh reports whether the model holds a whole fleet listing recent enough to answer reads.
+// Two missed reconciliations in a row make it stale.
+func (m *Model) fresh(now time.Time) bool {
+	return !m.syncedAt.IsZero() && now.Sub(m.syncedAt) < 2*m.window()
+}
+
+// Fleet returns every ship as of now, sorted by symbol, and when the fleet was last reconciled.
+// It reports false when the model is not fresh enough to answer.
+func (m *Model) Fleet(now time.Time) ([]client.Ship, time.Time, bool) {
+	if m == nil {
+		return nil, time.Time{}, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.fresh(now) {
+		return nil, time.Time{}, false
+	}
+
+	ships := make([]client.Ship, 0, len(m.ships))
+	for _, ship := range m.ships {
+		ships = append(ships, project(ship, now))
+	}
+	sort.Slice(ships, func(i, j int) bool { return ships[i].Symbol < ships[j].Symbol })
+	return ships, m.syncedAt, true
+}
+
+// Ship returns one ship as of now and when the fleet was last reconciled. It reports false when
+// the model is not fresh enough to answer or doesn't know the ship.
+func (m *Model) Ship(shipSymbol string, now time.Time) (client.Ship, time.Time, bool) {
+	if m == nil {
+		return client.Ship{}, time.Time{}, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ship, ok := m.ships[strings.ToUpper(shipSymbol)]
+	if !ok || !m.fresh(now) {
+		return client.Ship{}, time.Time{}, false
+	}
+	return project(ship, now), m.syncedAt, true
+}
+
+// Agent returns the agent as last seen in any response, and when. It reports false when the
+// agent hasn't been seen within a reconciliation interval or two.
+func (m *Model) Agent(now time.Time) (client.Agent, time.Time, bool) {
+	if m == nil {
+		return client.Agent{}, time.Time{}, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.agent == nil || now.Sub(m.agentAt) >= 2*m.window() {
+		return client.Agent{}, time.Time{}, false
+	}
+	return *m.agent, m.agentAt, true
+}
+
+// Diverged reports whether the last reconciliation found the model had drifted from the API
+func (m *Model) Diverged() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.diverged
+}
+
+// Status describes the model as of now, with the latest divergences first
+func (m *Model) Status(now time.Time) Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	divergences := make([]Divergence, len(m.divergences))
+	for i, divergence := range m.divergences {
+		divergences[len(m.divergences)-1-i] = divergence
+	}
+	return Status{
+		Ships:            len(m.ships),
+		Fresh:            m.fresh(now),
+		SyncedAt:         m.syncedAt,
+		AgentSyncedAt:    m.agentAt,
+		AppliedUpdates:   m.applied,
+		Reconciles:       m.reconciles,
+		LastReconcileAt:  m.lastReconcile,
+		LastError:        m.lastError,
+		Diverged:         m.diverged,
+		TotalDivergences: m.totalDivergences,
+		Divergences:      divergences,
+	}
+}
+
+// Reconcile fetches the fleet and the agent, records where the model had drifted from them and
+// replaces the model with what the API returned
+func (m *Model) Reconcile(ctx context.Context) error {
+	m.mu.RLock()
+	var before map[string]client.Ship
+	if !m.syncedAt.IsZero() {
+		before = make(map[string]client.Ship, len(m.ships))
+		for symbol, ship := range m.ships {
+			before[symbol] = ship
+		}
+	}
+	var beforeAgent *client.Agent
+	if m.agent != nil {
+		copied := *m.agent
+		beforeAgent = &copied
+	}
+	m.mu.RUnlock()
+
+	// Both calls replace the model through Observe as they return
+	c := m.client.WithContext(ctx)
+	ships, err := c.GetAllShips()
+	if err == nil {
+		var agent *client.Agent
+		if agent, err = c.GetAgent(); err == nil {
+			m.record(time.Now(), before, ships, beforeAgent, agent)
+			return nil
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastError = err.Error()
+	return err
+}
+
+// record stores the outcome of a successful reconciliation
+func (m *Model) record(now time.Time, before map[string]client.Ship, ships []client.Ship, beforeAgent, agent *client.Agent) {
+	var found []Divergence
+	if before != nil {
+		found = diffFleet(before, ships, now)
+	}
+	if beforeAgent != nil && beforeAgent.Credits != agent.Credits {
+		found = append(found, Divergence{
+			Field:      "credits",
+			Local:      fmt.Sprintf("%d", beforeAgent.Credits),
+			Remote:     fmt.Sprintf("%d", agent.Credits),
+			DetectedAt: now,
+		})
+	}
+	if len(found) > 0 {
+		m.logger.Warn("Local fleet state had drifted from the API in %d places; something else may be acting on the account", len(found))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconciles++
+	m.lastReconcile = now
+	m.lastError = ""
+	m.diverged = len(found) > 0
+	m.totalDivergences += len(found)
+	m.divergences = append(m.divergences, found...)
+	if excess := len(m.divergences) - maxDivergences; excess > 0 {
+		m.divergences = append([]Divergence(nil), m.divergences[excess:]...)
+	}
+}
+
+// diffFleet compares the model's ships, brought forward to now, with the ships the API returned
+func diffFleet(before map[string]client.Ship, ships []client.Ship, now time.Time) []Divergence {
+	var found []Divergence
+	add := func(shipSymbol, field, local, remote string) {
+		found = append(found, Divergence{ShipSymbol: shipSymbol, Field: field, Local: local, Remote: remote, DetectedAt: now})
+	}
+
+	seen := make(map[string]bool, len(ships))
+	for _, remote := range ships {
+		seen[remote.Symbol] = true
+		local, ok := before[remote.Symbol]
+		if !ok {
+			add(remote.Symbol, "ship", "missing", "present")
+			continue
+		}
+		local = project(local, now)
+		if l, r := describeNav(local.Nav), describeNav(remote.Nav); l != r {
+			add(remote.Symbol, "nav", l, r)
+		}
+		if local.Fuel.Current != remote.Fuel.Current {
+			add(remote.Symbol, "fuel", fmt.Sprintf("%d", local.Fuel.Current), fmt.Sprintf("%d", remote.Fuel.Current))
+		}
+		if l, r := describeCargo(local.Cargo), describeCargo(remote.Cargo); l != r {
+			add(remote.Symbol, "cargo", l, r)
+		}
+	}
+	for symbol := range before {
+		if !seen[symbol] {
+			add(symbol, "ship", "present", "missing")
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].ShipSymbol < found[j].ShipSymbol })
+	return found
+}
+
+// project brings a ship forward to now: a transit whose arrival has passed has ended in orbit
+// at the destination, and the remaining cooldown counts down to its expiration
+func project(ship client.Ship, now time.Time) client.Ship {
+	if ship.Nav.Status == "IN_TRANSIT" {
+		if arrival, err := time.Parse(time.RFC3339, ship.Nav.Route.Arrival); err == nil && !arrival.After(now) {
+			ship.Nav.Status = "IN_ORBIT"
+		}
+	}
+	if expiration, err := time.Parse(time.RFC3339, ship.Cooldown.Expiration); err == nil {
+		ship.Cooldown.RemainingSeconds = int(max(expiration.Sub(now), 0).Round(time.Second).Seconds())
+	}
+	return ship
+}
+
+// describeNav summarizes where a ship is for comparison
+func describeNav(nav client.Navigation) string {
+	return fmt.Sprintf("%s at %s", nav.Status, nav.WaypointSymbol)
+}
+
+// describeCargo summarizes a cargo hold for comparison, listing goods in symbol order
+func describeCargo(cargo client.Cargo) string {
+	items := make([]string, 0, len(cargo.Inventory))
+	for _, item := range cargo.Inventory {
+		if item.Units > 0 {
+			items = append(items, fmt.Sprintf("%s×%d", item.Symbol, item.Units))
+		}
+	}
+	sort.Strings(items)
+	if len(items) == 0 {
+		return fmt.Sprintf("%d units", cargo.Units)
+	}
+	return fmt.Sprintf("%d units: %s", cargo.Units, strings.Join(items, ", "))
+}
@@ -0,0 +1,144 @@
+package fleetstate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+)
+
+func TestModel_AppliesActionResults(t *testing.T) {
+	m := New(context.Background(), nil, logging.NewLogger(nil))
+	now := time.Now()
+
+	// Nothing is answered until a whole fleet listing has been seen
+	m.Observe(client.Observation{Kind: client.ObservedNavigation, ShipSymbol: "SHIP-1", Nav: &client.Navigation{Status: "DOCKED"}, ObservedAt: now})
+	if _, _, ok := m.Fleet(now); ok {
+		t.Fatal("Expected no fleet before a listing was seen")
+	}
+
+	m.Observe(client.Observation{Kind: client.ObservedFleet, ObservedAt: now, Ships: []client.Ship{
+		{Symbol: "SHIP-2", Nav: client.Navigation{Status: "DOCKED", WaypointSymbol: "X1-A1"}},
+		{Symbol: "SHIP-1", Nav: client.Navigation{Status: "DOCKED", WaypointSymbol: "X1-A1"}, Fuel: client.Fuel{Current: 100, Capacity: 100}},
+	}})
+	m.Observe(client.Observation{Kind: client.ObservedNavigation, ShipSymbol: "ship-1", Nav: &client.Navigation{
+		Status:         "IN_TRANSIT",
+		WaypointSymbol: "X1-B2",
+		Route:          client.Route{Arrival: now.Add(-time.Second).UTC().Format(time.RFC3339)},
+	}})
+	m.Observe(client.Observation{Kind: client.ObservedFuel, ShipSymbol: "SHIP-1", Fuel: &client.Fuel{Current: 60, Capacity: 100}})
+	m.Observe(client.Observation{Kind: client.ObservedCargo, ShipSymbol: "SHIP-1", Cargo: &client.Cargo{Capacity: 40, Units: 5, Inventory: []client.CargoItem{{Symbol: "IRON_ORE", Units: 5}}}})
+	m.Observe(client.Observation{Kind: client.ObservedScrapTransaction, ShipSymbol: "SHIP-2"})
+
+	ships, syncedAt, ok := m.Fleet(now)
+	if !ok || len(ships) != 1 || !syncedAt.Equal(now) {
+		t.Fatalf("Expected one ship synced at %v, got %d ships at %v (ok %v)", now, len(ships), syncedAt, ok)
+	}
+	ship := ships[0]
+	if ship.Nav.Status != "IN_ORBIT" || ship.Nav.WaypointSymbol != "X1-B2" {
+		t.Errorf("Expected the finished transit to end in orbit at X1-B2, got %s at %s", ship.Nav.Status, ship.Nav.WaypointSymbol)
+	}
+	if ship.Fuel.Current != 60 || ship.Cargo.Units != 5 {
+		t.Errorf("Expected fuel 60 and 5 cargo units, got %d and %d", ship.Fuel.Current, ship.Cargo.Units)
+	}
+	if status := m.Status(now); status.AppliedUpdates != 4 {
+		t.Errorf("Expected 4 applied updates, got %d", status.AppliedUpdates)
+	}
+
+	// The model goes stale once it misses reconciliations
+	if _, _, ok := m.Ship("SHIP-1", now.Add(2*DefaultInterval)); ok {
+		t.Error("Expected a stale model not to answer")
+	}
+}
+
+func TestModel_ReconcileRecordsDivergence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "SHIP-1", "nav": {"systemSymbol": "X1", "waypointSymbol": "X1-A1", "status": "DOCKED"}, "fuel": {"current": 50, "capacity": 100}}
+			], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case "/my/agent":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "AGENT", "headquarters": "X1-A1", "credits": 1000, "startingFaction": "COSMIC", "shipCount": 1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	m := New(context.Background(), c, logging.NewLogger(nil))
+	c.AddObserver(m.Observe)
+
+	// The first reconciliation has nothing to compare with
+	if err := m.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if status := m.Status(time.Now()); status.Diverged || status.Reconciles != 1 || !status.Fresh {
+		t.Fatalf("Expected a fresh model without divergence, got %+v", status)
+	}
+
+	// Something else spends fuel and credits without this server seeing it
+	m.Observe(client.Observation{Kind: client.ObservedFuel, ShipSymbol: "SHIP-1", Fuel: &client.Fuel{Current: 80, Capacity: 100}})
+	m.Observe(client.Observation{Kind: client.ObservedAgent, Agent: &client.Agent{Symbol: "AGENT", Credits: 900}})
+
+	if err := m.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	status := m.Status(time.Now())
+	if !status.Diverged || status.TotalDivergences != 2 {
+		t.Fatalf("Expected fuel and credits divergences, got %+v", status.Divergences)
+	}
+	if d := status.Divergences[1]; d.ShipSymbol != "SHIP-1" || d.Field != "fuel" || d.Local != "80" || d.Remote != "50" {
+		t.Errorf("Expected the fuel divergence of SHIP-1, got %+v", d)
+	}
+	if d := status.Divergences[0]; d.Field != "credits" || d.Local != "900" || d.Remote != "1000" {
+		t.Errorf("Expected the credits divergence, got %+v", d)
+	}
+
+	// The model now holds what the API returned
+	if ship, _, ok := m.Ship("SHIP-1", time.Now()); !ok || ship.Fuel.Current != 50 {
+		t.Errorf("Expected the reconciled fuel 50, got %d (ok %v)", ship.Fuel.Current, ok)
+	}
+	if agent, _, ok := m.Agent(time.Now()); !ok || agent.Credits != 1000 {
+		t.Errorf("Expected the reconciled credits 1000, got %d (ok %v)", agent.Credits, ok)
+	}
+}
+
+func TestModel_ScheduleFollowsActivity(t *testing.T) {
+	m := New(context.Background(), nil, logging.NewLogger(nil))
+	now := time.Now()
+	docked := client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{Status: "DOCKED", WaypointSymbol: "X1-A1"}}
+	arriving := client.Ship{Symbol: "SHIP-2", Nav: client.Navigation{
+		Status:         "IN_TRANSIT",
+		WaypointSymbol: "X1-B2",
+		Route:          client.Route{Arrival: now.Add(time.Minute).UTC().Format("2006-01-02T15:04:05.000Z")},
+	}}
+
+	// A ship about to arrive brings the next reconciliation forward
+	m.Observe(client.Observation{Kind: client.ObservedFleet, ObservedAt: now, Ships: []client.Ship{docked, arriving}})
+	if next := m.schedule(now); next >= time.Minute || next < 50*time.Second {
+		t.Errorf("Expected the next reconciliation just before the arrival, got %v", next)
+	}
+
+	// An idle fleet is reconciled every interval, then less often once it has sat unchanged
+	m.Observe(client.Observation{Kind: client.ObservedFleet, ObservedAt: now, Ships: []client.Ship{docked}})
+	if next := m.schedule(now); next != DefaultInterval {
+		t.Errorf("Expected the next reconciliation in %v, got %v", DefaultInterval, next)
+	}
+	later := now.Add(3 * DefaultInterval)
+	m.Observe(client.Observation{Kind: client.ObservedFleet, ObservedAt: later, Ships: []client.Ship{docked}})
+	if next := m.schedule(later); next != 3*DefaultInterval {
+		t.Errorf("Expected the unchanged fleet to back off to %v, got %v", 3*DefaultInterval, next)
+	}
+
+	// The model stays fresh for as long as the longer wait calls for
+	if _, _, ok := m.Fleet(later.Add(2 * DefaultInterval)); !ok {
+		t.Error("Expected the model to stay fresh until two backed off reconciliations are missed")
+	}
+}
@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// DefaultTimeout bounds the API round trip of a check, so a slow API reports as unreachable
+// instead of hanging the caller
+const DefaultTimeout = 5 * time.Second
+
+// Status values reported by a check
+const (
+	StatusOK           = "ok"
+	StatusUnauthorized = "unauthorized"
+	StatusUnreachable  = "unreachable"
+	// StatusDegraded means the check was not sent because recent API calls kept failing
+	StatusDegraded = "degraded"
+)
+
+// Report is the result of a health check
+type Report struct {
+	Status       string                 `json:"status"`
+	APIReachable bool                   `json:"apiReachable"`
+	TokenValid   bool                   `json:"tokenValid"`
+	Agent        string                 `json:"agent,omitempty"`
+	Credits      int64                  `json:"credits,omitempty"`
+	LatencyMs    int64                  `json:"latencyMs"`
+	RateLimit    *client.RateLimit      `json:"rateLimit,omitempty"`
+	Cache        client.CacheStats      `json:"cache"`
+	Circuits     []client.CircuitStatus `json:"circuits,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+	CheckedAt    string                 `json:"checkedAt"`
+}
+
+// Ready reports whether the server can serve requests: the API answered and accepted the token
+func (r Report) Ready() bool {
+	return r.APIReachable && r.TokenValid
+}
+
+// Check verifies the token by fetching the agent, giving up after timeout
+func Check(ctx context.Context, c *client.Client, timeout time.Duration) Report {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := c.WithContext(ctx).Ping()
+
+	report := Report{
+		APIReachable: result.StatusCode != 0,
+		LatencyMs:    result.Latency.Milliseconds(),
+		RateLimit:    result.RateLimit,
+		Cache:        c.CacheStats(),
+		Circuits:     c.Circuits(),
+		CheckedAt:    time.Now().UTC().Format(time.RFC3339),
+	}
+
+	switch {
+	case err == nil:
+		report.Status = StatusOK
+		report.TokenValid = true
+		report.Agent = result.Agent.Symbol
+		report.Credits = result.Agent.Credits
+	case result.StatusCode == http.StatusUnauthorized:
+		report.Status = StatusUnauthorized
+		report.Error = "the API rejected the token; it may have expired with the last server reset"
+	case errors.Is(err, client.ErrUpstreamDegraded):
+		report.Status = StatusDegraded
+		report.Error = err.Error()
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		report.Status = StatusUnreachable
+		report.APIReachable = false
+		report.Error = "the API did not answer within " + timeout.String()
+	default:
+		report.Status = StatusUnreachable
+		report.Error = err.Error()
+	}
+
+	return report
+}
+
+// Handler serves /healthz, which answers 200 while the process is up, and /readyz, which
+// answers 503 until the API is reachable and the token is accepted. Both return the report.
+func Handler(c *client.Client) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Check(r.Context(), c, DefaultTimeout))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := Check(r.Context(), c, DefaultTimeout)
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+	return mux
+}
+
+// writeReport writes the report as a JSON response
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,193 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func newAgentServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my/agent" {
+			t.Errorf("Expected /my/agent, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Type", "IP-address")
+		w.Header().Set("X-Ratelimit-Remaining", "1")
+		w.Header().Set("X-Ratelimit-Limit-Burst", "30")
+		w.Header().Set("X-Ratelimit-Reset", "2024-01-01T00:00:01.000Z")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			_, _ = w.Write([]byte(`{"error": {"message": "Token invalid", "code": 401}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {
+			"accountId": "ACCOUNT",
+			"symbol": "TEST_AGENT",
+			"headquarters": "X1-TEST-A1",
+			"credits": 175000,
+			"startingFaction": "COSMIC",
+			"shipCount": 2
+		}}`))
+	}))
+}
+
+func TestCheck_OK(t *testing.T) {
+	server := newAgentServer(t, http.StatusOK)
+	defer server.Close()
+
+	report := Check(context.Background(), client.NewClientWithBaseURL("test-token", server.URL), time.Second)
+
+	if report.Status != StatusOK || !report.Ready() {
+		t.Fatalf("Expected a ready report, got %+v", report)
+	}
+	if report.Agent != "TEST_AGENT" || report.Credits != 175000 {
+		t.Errorf("Expected agent TEST_AGENT with 175000 credits, got %s with %d", report.Agent, report.Credits)
+	}
+	if report.RateLimit == nil || report.RateLimit.Remaining != 1 || report.RateLimit.Burst != 30 {
+		t.Errorf("Expected rate limit from headers, got %+v", report.RateLimit)
+	}
+}
+
+func TestCheck_Unauthorized(t *testing.T) {
+	server := newAgentServer(t, http.StatusUnauthorized)
+	defer server.Close()
+
+	report := Check(context.Background(), client.NewClientWithBaseURL("bad-token", server.URL), time.Second)
+
+	if report.Status != StatusUnauthorized {
+		t.Errorf("Expected status %q, got %q", StatusUnauthorized, report.Status)
+	}
+	if !report.APIReachable || report.TokenValid {
+		t.Errorf("Expected a reachable API rejecting the token, got %+v", report)
+	}
+}
+
+func TestCheck_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	report := Check(context.Background(), client.NewClientWithBaseURL("test-token", server.URL), 50*time.Millisecond)
+
+	if report.Status != StatusUnreachable || report.APIReachable {
+		t.Errorf("Expected an unreachable report, got %+v", report)
+	}
+}
+
+func TestCheck_Degraded(t *testing.T) {
+	server := newAgentServer(t, http.StatusServiceUnavailable)
+	defer server.Close()
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	c.SetCircuitBreaker(1, time.Minute)
+
+	if report := Check(context.Background(), c, time.Second); report.Status != StatusUnreachable {
+		t.Errorf("Expected the failing call to report unreachable, got %+v", report)
+	}
+	report := Check(context.Background(), c, time.Second)
+	if report.Status != StatusDegraded || report.Ready() {
+		t.Errorf("Expected a degraded report once the circuit is open, got %+v", report)
+	}
+	if len(report.Circuits) != 1 || report.Circuits[0].Class != "agents" || report.Circuits[0].State != client.CircuitOpen {
+		t.Errorf("Expected the open agents circuit in the report, got %+v", report.Circuits)
+	}
+}
+
+func TestHandler_Readyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiStatus  int
+		path       string
+		wantStatus int
+	}{
+		{"ready", http.StatusOK, "/readyz", http.StatusOK},
+		{"not ready", http.StatusUnauthorized, "/readyz", http.StatusServiceUnavailable},
+		{"alive while not ready", http.StatusUnauthorized, "/healthz", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newAgentServer(t, tt.apiStatus)
+			defer server.Close()
+
+			rec := httptest.NewRecorder()
+			Handler(client.NewClientWithBaseURL("test-token", server.URL)).
+				ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			var report Report
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("Expected a JSON report, got %q: %v", rec.Body.String(), err)
+			}
+		})
+	}
+}
+
+func TestValidateToken_RejectedIncludesResetDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{
+				"status": "SpaceTraders is currently online",
+				"version": "v2.3.0",
+				"resetDate": "2024-01-07",
+				"description": "",
+				"stats": {"agents": 1, "ships": 2, "systems": 3, "waypoints": 4},
+				"leaderboards": {"mostCredits": [], "mostSubmittedCharts": []},
+				"serverResets": {"next": "2024-01-21T16:00:00.000Z", "frequency": "fortnightly"},
+				"announcements": [],
+				"links": []
+			}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": {"message": "Token invalid", "code": 401}}`))
+	}))
+	defer server.Close()
+
+	_, err := ValidateToken(context.Background(), client.NewClientWithBaseURL("old-token", server.URL), time.Second)
+	if !errors.Is(err, ErrTokenRejected) {
+		t.Fatalf("Expected ErrTokenRejected, got %v", err)
+	}
+	for _, want := range []string{"2024-01-07", "2024-01-21T16:00:00.000Z"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %s, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestValidateToken_UnreachableIsNotRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := ValidateToken(context.Background(), client.NewClientWithBaseURL("test-token", server.URL), time.Second)
+	if err == nil || errors.Is(err, ErrTokenRejected) {
+		t.Errorf("Expected a non-rejection error, got %v", err)
+	}
+}
+
+func TestValidateToken_OK(t *testing.T) {
+	server := newAgentServer(t, http.StatusOK)
+	defer server.Close()
+
+	agent, err := ValidateToken(context.Background(), client.NewClientWithBaseURL("test-token", server.URL), time.Second)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if agent.Symbol != "TEST_AGENT" {
+		t.Errorf("Expected agent TEST_AGENT, got %s", agent.Symbol)
+	}
+}
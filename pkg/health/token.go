@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// ErrTokenRejected is returned by ValidateToken when the API answers 401 Unauthorized
+var ErrTokenRejected = errors.New("SPACETRADERS_API_TOKEN was rejected by the API (401 Unauthorized)")
+
+// ValidateToken fetches the agent to confirm the token works before the server starts.
+// A rejected token yields an error wrapping ErrTokenRejected that explains server resets;
+// any other failure (such as the API being unreachable) is returned as is.
+func ValidateToken(ctx context.Context, c *client.Client, timeout time.Duration) (*client.Agent, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := c.WithContext(ctx).Ping()
+	if err == nil {
+		return result.Agent, nil
+	}
+	if result.StatusCode != http.StatusUnauthorized {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w. %s", ErrTokenRejected, resetHint(c.WithContext(ctx)))
+}
+
+// resetHint explains the likely cause of a rejected token using the server's reset schedule
+func resetHint(c *client.Client) string {
+	hint := "Agent tokens stop working when the server resets"
+	if status, err := c.GetServerStatus(); err == nil && status.ResetDate != "" {
+		hint += fmt.Sprintf("; the last reset was on %s, so tokens issued before then are invalid", status.ResetDate)
+		if status.NextReset != "" {
+			hint += fmt.Sprintf(" (next reset: %s)", status.NextReset)
+		}
+	}
+	return hint + ". Register a new agent at https://my.spacetraders.io and update SPACETRADERS_API_TOKEN."
+}
@@ -0,0 +1,352 @@
+package ledger
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// Category classifies a ledger entry by the kind of transaction that produced it
+type Category string
+
+const (
+	CategoryMarketPurchase  Category = "market_purchase"
+	CategoryMarketSale      Category = "market_sale"
+	CategoryMiningSale      Category = "mining_sale"
+	CategoryRefuel          Category = "refuel"
+	CategoryShipPurchase    Category = "ship_purchase"
+	CategoryRepair          Category = "repair"
+	CategoryModification    Category = "ship_modification"
+	CategoryShipScrap       Category = "ship_scrap"
+	CategoryContractPayment Category = "contract_payment"
+)
+
+// Activity groups categories into the activities reported by profit summaries
+func (c Category) Activity() string {
+	switch c {
+	case CategoryMarketPurchase, CategoryMarketSale:
+		return "trading"
+	case CategoryMiningSale:
+		return "mining_sales"
+	case CategoryContractPayment:
+		return "contracts"
+	case CategoryRefuel:
+		return "fuel"
+	case CategoryRepair:
+		return "repairs"
+	case CategoryModification:
+		return "ship_modifications"
+	case CategoryShipPurchase:
+		return "ship_purchases"
+	case CategoryShipScrap:
+		return "ship_scrapping"
+	default:
+		return "other"
+	}
+}
+
+// Entry is a single credit movement observed by the server.
+// Amount is positive when credits were earned and negative when spent.
+type Entry struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Category       Category  `json:"category"`
+	ShipSymbol     string    `json:"shipSymbol,omitempty"`
+	WaypointSymbol string    `json:"waypointSymbol,omitempty"`
+	TradeSymbol    string    `json:"tradeSymbol,omitempty"`
+	ContractID     string    `json:"contractId,omitempty"`
+	Units          int       `json:"units,omitempty"`
+	PricePerUnit   int       `json:"pricePerUnit,omitempty"`
+	Amount         int       `json:"amount"`
+}
+
+// Filter narrows down which entries are returned by Query. Zero values match everything.
+type Filter struct {
+	ShipSymbol     string
+	WaypointSymbol string
+	Since          time.Time
+	Until          time.Time
+}
+
+// Matches reports whether the entry satisfies the filter
+func (f Filter) Matches(entry Entry) bool {
+	if f.ShipSymbol != "" && entry.ShipSymbol != f.ShipSymbol {
+		return false
+	}
+	if f.WaypointSymbol != "" && entry.WaypointSymbol != f.WaypointSymbol {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Ledger accumulates transactions observed by the server across all ships
+type Ledger struct {
+	mu      sync.RWMutex
+	entries []Entry
+
+	// mined tracks extracted units per ship and good that have not been sold yet,
+	// so sales of mined goods can be told apart from trading
+	mined map[string]map[string]int
+}
+
+// New creates an empty ledger
+func New() *Ledger {
+	return &Ledger{
+		mined: make(map[string]map[string]int),
+	}
+}
+
+// Observe records transactions from client observations; it is meant to be passed to client.AddObserver
+func (l *Ledger) Observe(observation client.Observation) {
+	switch observation.Kind {
+	case client.ObservedMarketTransaction:
+		if observation.MarketTransaction != nil {
+			for _, entry := range l.splitMiningSale(entryFromMarketTransaction(*observation.MarketTransaction, observation.ObservedAt)) {
+				l.Add(entry)
+			}
+		}
+	case client.ObservedExtraction:
+		if extraction := observation.Extraction; extraction != nil {
+			l.recordMined(observation.ShipSymbol, extraction.Yield.Symbol, extraction.Yield.Units)
+		}
+	case client.ObservedContractAccepted:
+		if contract := observation.Contract; contract != nil && contract.Terms.Payment.OnAccepted > 0 {
+			l.Add(Entry{
+				Timestamp:  observation.ObservedAt,
+				Category:   CategoryContractPayment,
+				ContractID: contract.ID,
+				Amount:     contract.Terms.Payment.OnAccepted,
+			})
+		}
+	case client.ObservedContractFulfilled:
+		if contract := observation.Contract; contract != nil && contract.Terms.Payment.OnFulfilled > 0 {
+			l.Add(Entry{
+				Timestamp:  observation.ObservedAt,
+				Category:   CategoryContractPayment,
+				ContractID: contract.ID,
+				Amount:     contract.Terms.Payment.OnFulfilled,
+			})
+		}
+	case client.ObservedShipyardTransaction:
+		if tx := observation.ShipyardTransaction; tx != nil {
+			l.Add(Entry{
+				Timestamp:      observation.ObservedAt,
+				Category:       CategoryShipPurchase,
+				ShipSymbol:     tx.ShipSymbol,
+				WaypointSymbol: tx.WaypointSymbol,
+				TradeSymbol:    tx.ShipType,
+				Units:          1,
+				PricePerUnit:   tx.Price,
+				Amount:         -tx.Price,
+			})
+		}
+	case client.ObservedScrapTransaction:
+		if tx := observation.ScrapTransaction; tx != nil {
+			l.Add(Entry{
+				Timestamp:      observation.ObservedAt,
+				Category:       CategoryShipScrap,
+				ShipSymbol:     tx.ShipSymbol,
+				WaypointSymbol: tx.WaypointSymbol,
+				Amount:         tx.TotalPrice,
+			})
+		}
+	case client.ObservedRepairTransaction:
+		if tx := observation.RepairTransaction; tx != nil {
+			l.Add(Entry{
+				Timestamp:      observation.ObservedAt,
+				Category:       CategoryRepair,
+				ShipSymbol:     tx.ShipSymbol,
+				WaypointSymbol: tx.WaypointSymbol,
+				Amount:         -tx.TotalPrice,
+			})
+		}
+	case client.ObservedModificationTransaction:
+		if tx := observation.ModificationTransaction; tx != nil {
+			l.Add(Entry{
+				Timestamp:      observation.ObservedAt,
+				Category:       CategoryModification,
+				ShipSymbol:     tx.ShipSymbol,
+				WaypointSymbol: tx.WaypointSymbol,
+				TradeSymbol:    tx.TradeSymbol,
+				Amount:         -tx.TotalPrice,
+			})
+		}
+	}
+}
+
+// entryFromMarketTransaction converts a market transaction into a ledger entry
+func entryFromMarketTransaction(tx client.MarketTransaction, observedAt time.Time) Entry {
+	entry := Entry{
+		Timestamp:      observedAt,
+		ShipSymbol:     tx.ShipSymbol,
+		WaypointSymbol: tx.WaypointSymbol,
+		TradeSymbol:    tx.TradeSymbol,
+		Units:          tx.Units,
+		PricePerUnit:   tx.PricePerUnit,
+	}
+
+	switch {
+	case tx.Type == "SELL":
+		entry.Category = CategoryMarketSale
+		entry.Amount = tx.TotalPrice
+	case tx.TradeSymbol == "FUEL":
+		entry.Category = CategoryRefuel
+		entry.Amount = -tx.TotalPrice
+	default:
+		entry.Category = CategoryMarketPurchase
+		entry.Amount = -tx.TotalPrice
+	}
+
+	return entry
+}
+
+// recordMined remembers extracted units so later sales can be attributed to mining
+func (l *Ledger) recordMined(shipSymbol, tradeSymbol string, units int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.mined[shipSymbol] == nil {
+		l.mined[shipSymbol] = make(map[string]int)
+	}
+	l.mined[shipSymbol][tradeSymbol] += units
+}
+
+// splitMiningSale re-categorizes the mined portion of a sale as a mining sale,
+// splitting the entry when only part of the units sold were extracted by the ship
+func (l *Ledger) splitMiningSale(entry Entry) []Entry {
+	if entry.Category != CategoryMarketSale {
+		return []Entry{entry}
+	}
+
+	l.mu.Lock()
+	minedUnits := l.mined[entry.ShipSymbol][entry.TradeSymbol]
+	minedSold := minedUnits
+	if minedSold > entry.Units {
+		minedSold = entry.Units
+	}
+	if minedSold > 0 {
+		l.mined[entry.ShipSymbol][entry.TradeSymbol] -= minedSold
+	}
+	l.mu.Unlock()
+
+	if minedSold == 0 {
+		return []Entry{entry}
+	}
+	if minedSold == entry.Units {
+		entry.Category = CategoryMiningSale
+		return []Entry{entry}
+	}
+
+	mining := entry
+	mining.Category = CategoryMiningSale
+	mining.Units = minedSold
+	mining.Amount = entry.Amount * minedSold / entry.Units
+
+	trading := entry
+	trading.Units = entry.Units - minedSold
+	trading.Amount = entry.Amount - mining.Amount
+
+	return []Entry{mining, trading}
+}
+
+// Add appends an entry to the ledger
+func (l *Ledger) Add(entry Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// Query returns the entries matching the filter, oldest first
+func (l *Ledger) Query(filter Filter) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make([]Entry, 0)
+	for _, entry := range l.entries {
+		if filter.Matches(entry) {
+			result = append(result, entry)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result
+}
+
+// Len returns the number of entries recorded
+func (l *Ledger) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+// Totals summarizes the credit flow of a set of entries
+type Totals struct {
+	Income  int            `json:"income"`
+	Expense int            `json:"expense"`
+	Net     int            `json:"net"`
+	PerShip map[string]int `json:"perShip"`
+	PerGood map[string]int `json:"perGood"`
+}
+
+// ComputeTotals calculates income, expenses and net profit/loss per ship and per good
+func ComputeTotals(entries []Entry) Totals {
+	totals := Totals{
+		PerShip: make(map[string]int),
+		PerGood: make(map[string]int),
+	}
+
+	for _, entry := range entries {
+		if entry.Amount >= 0 {
+			totals.Income += entry.Amount
+		} else {
+			totals.Expense += -entry.Amount
+		}
+		totals.Net += entry.Amount
+
+		if entry.ShipSymbol != "" {
+			totals.PerShip[entry.ShipSymbol] += entry.Amount
+		}
+		if entry.TradeSymbol != "" && (entry.Category == CategoryMarketPurchase || entry.Category == CategoryMarketSale || entry.Category == CategoryMiningSale) {
+			totals.PerGood[entry.TradeSymbol] += entry.Amount
+		}
+	}
+
+	return totals
+}
+
+// ActivityTotals summarizes credits earned and spent for one activity
+type ActivityTotals struct {
+	Earned       int `json:"earned"`
+	Spent        int `json:"spent"`
+	Net          int `json:"net"`
+	Transactions int `json:"transactions"`
+}
+
+// ComputeBreakdown groups entries by activity (trading, contracts, mining_sales, fuel, repairs, ship_purchases, ship_scrapping)
+func ComputeBreakdown(entries []Entry) map[string]ActivityTotals {
+	breakdown := make(map[string]ActivityTotals)
+
+	for _, entry := range entries {
+		activity := entry.Category.Activity()
+		totals := breakdown[activity]
+		if entry.Amount >= 0 {
+			totals.Earned += entry.Amount
+		} else {
+			totals.Spent += -entry.Amount
+		}
+		totals.Net += entry.Amount
+		totals.Transactions++
+		breakdown[activity] = totals
+	}
+
+	return breakdown
+}
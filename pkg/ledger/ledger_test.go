@@ -0,0 +1,185 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestLedger_Observe(t *testing.T) {
+	l := New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l.Observe(client.Observation{
+		Kind:       client.ObservedMarketTransaction,
+		ObservedAt: base,
+		MarketTransaction: &client.MarketTransaction{
+			WaypointSymbol: "X1-TEST-A1", ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE",
+			Type: "PURCHASE", Units: 10, PricePerUnit: 5, TotalPrice: 50,
+		},
+	})
+	l.Observe(client.Observation{
+		Kind:       client.ObservedMarketTransaction,
+		ObservedAt: base.Add(time.Hour),
+		MarketTransaction: &client.MarketTransaction{
+			WaypointSymbol: "X1-TEST-B2", ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE",
+			Type: "SELL", Units: 10, PricePerUnit: 12, TotalPrice: 120,
+		},
+	})
+	l.Observe(client.Observation{
+		Kind:       client.ObservedMarketTransaction,
+		ObservedAt: base.Add(2 * time.Hour),
+		MarketTransaction: &client.MarketTransaction{
+			WaypointSymbol: "X1-TEST-B2", ShipSymbol: "SHIP-2", TradeSymbol: "FUEL",
+			Type: "PURCHASE", Units: 1, PricePerUnit: 70, TotalPrice: 70,
+		},
+	})
+	l.Observe(client.Observation{
+		Kind:       client.ObservedRepairTransaction,
+		ObservedAt: base.Add(3 * time.Hour),
+		RepairTransaction: &client.RepairTransaction{
+			WaypointSymbol: "X1-TEST-B2", ShipSymbol: "SHIP-2", TotalPrice: 200,
+		},
+	})
+
+	if l.Len() != 4 {
+		t.Fatalf("Expected 4 entries, got %d", l.Len())
+	}
+
+	entries := l.Query(Filter{})
+	if entries[2].Category != CategoryRefuel {
+		t.Errorf("Expected fuel purchase to be categorized as refuel, got %s", entries[2].Category)
+	}
+
+	totals := ComputeTotals(entries)
+	if totals.Income != 120 || totals.Expense != 320 || totals.Net != -200 {
+		t.Errorf("Unexpected totals: %+v", totals)
+	}
+	if totals.PerShip["SHIP-1"] != 70 {
+		t.Errorf("Expected SHIP-1 net 70, got %d", totals.PerShip["SHIP-1"])
+	}
+	if totals.PerGood["IRON_ORE"] != 70 {
+		t.Errorf("Expected IRON_ORE net 70, got %d", totals.PerGood["IRON_ORE"])
+	}
+	if _, exists := totals.PerGood["FUEL"]; exists {
+		t.Error("Expected fuel to be excluded from per-good trading totals")
+	}
+}
+
+func TestLedger_QueryFilter(t *testing.T) {
+	l := New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l.Add(Entry{Timestamp: base, ShipSymbol: "SHIP-1", WaypointSymbol: "X1-TEST-A1", Amount: 10})
+	l.Add(Entry{Timestamp: base.Add(time.Hour), ShipSymbol: "SHIP-2", WaypointSymbol: "X1-TEST-A1", Amount: 20})
+	l.Add(Entry{Timestamp: base.Add(2 * time.Hour), ShipSymbol: "SHIP-1", WaypointSymbol: "X1-TEST-B2", Amount: 30})
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected int
+	}{
+		{"no filter", Filter{}, 3},
+		{"by ship", Filter{ShipSymbol: "SHIP-1"}, 2},
+		{"by waypoint", Filter{WaypointSymbol: "X1-TEST-A1"}, 2},
+		{"since", Filter{Since: base.Add(30 * time.Minute)}, 2},
+		{"until", Filter{Until: base.Add(30 * time.Minute)}, 1},
+		{"combined", Filter{ShipSymbol: "SHIP-1", WaypointSymbol: "X1-TEST-B2"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(l.Query(tt.filter)); got != tt.expected {
+				t.Errorf("Expected %d entries, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLedger_MiningSalesAndContracts(t *testing.T) {
+	l := New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l.Observe(client.Observation{
+		Kind:       client.ObservedExtraction,
+		ShipSymbol: "SHIP-1",
+		ObservedAt: base,
+		Extraction: &client.Extraction{
+			ShipSymbol: "SHIP-1",
+			Yield:      client.ExtractionYield{Symbol: "IRON_ORE", Units: 6},
+		},
+	})
+	l.Observe(client.Observation{
+		Kind:       client.ObservedMarketTransaction,
+		ObservedAt: base.Add(time.Hour),
+		MarketTransaction: &client.MarketTransaction{
+			WaypointSymbol: "X1-TEST-B2", ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE",
+			Type: "SELL", Units: 10, PricePerUnit: 10, TotalPrice: 100,
+		},
+	})
+	l.Observe(client.Observation{
+		Kind:       client.ObservedContractFulfilled,
+		ObservedAt: base.Add(2 * time.Hour),
+		Contract: &client.Contract{
+			ID:    "contract-1",
+			Terms: client.ContractTerms{Payment: client.ContractPayment{OnAccepted: 100, OnFulfilled: 500}},
+		},
+	})
+
+	entries := l.Query(Filter{})
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	breakdown := ComputeBreakdown(entries)
+	if breakdown["mining_sales"].Earned != 60 {
+		t.Errorf("Expected 60 credits from mining sales, got %d", breakdown["mining_sales"].Earned)
+	}
+	if breakdown["trading"].Earned != 40 {
+		t.Errorf("Expected 40 credits from trading, got %d", breakdown["trading"].Earned)
+	}
+	if breakdown["contracts"].Earned != 500 {
+		t.Errorf("Expected only the fulfillment reward to be recorded, got %d", breakdown["contracts"].Earned)
+	}
+}
+
+func TestLedger_ShipScrap(t *testing.T) {
+	l := New()
+	l.Observe(client.Observation{
+		Kind:       client.ObservedScrapTransaction,
+		ShipSymbol: "PROBE-1",
+		ObservedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		ScrapTransaction: &client.ScrapTransaction{
+			WaypointSymbol: "X1-TEST-A1", ShipSymbol: "PROBE-1", TotalPrice: 8500,
+		},
+	})
+
+	entries := l.Query(Filter{ShipSymbol: "PROBE-1"})
+	if len(entries) != 1 || entries[0].Category != CategoryShipScrap || entries[0].Amount != 8500 {
+		t.Fatalf("Expected a single scrap income entry, got %+v", entries)
+	}
+	if activity := entries[0].Category.Activity(); activity != "ship_scrapping" {
+		t.Errorf("Expected ship_scrapping activity, got %s", activity)
+	}
+}
+
+func TestLedger_Modification(t *testing.T) {
+	l := New()
+	l.Observe(client.Observation{
+		Kind:       client.ObservedModificationTransaction,
+		ShipSymbol: "MINER-1",
+		ObservedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		ModificationTransaction: &client.ModificationTransaction{
+			WaypointSymbol: "X1-TEST-A1", ShipSymbol: "MINER-1", TradeSymbol: "MOUNT_MINING_LASER_I", TotalPrice: 1200,
+		},
+	})
+
+	entries := l.Query(Filter{ShipSymbol: "MINER-1"})
+	if len(entries) != 1 || entries[0].Category != CategoryModification || entries[0].Amount != -1200 {
+		t.Fatalf("Expected a single modification fee entry, got %+v", entries)
+	}
+	if activity := entries[0].Category.Activity(); activity != "ship_modifications" {
+		t.Errorf("Expected ship_modifications activity, got %s", activity)
+	}
+}
@@ -2,59 +2,141 @@ package logging
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
+	"strings"
+	"sync/atomic"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// Logger provides structured logging for the SpaceTraders MCP server
+// Level is the severity of a log message
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// DefaultClientLevel is the minimum level sent to MCP clients until they choose one with logging/setLevel
+const DefaultClientLevel = LevelInfo
+
+// String returns the lowercase name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// mcpLevel converts the level to its MCP equivalent
+func (l Level) mcpLevel() mcp.LoggingLevel {
+	switch l {
+	case LevelDebug:
+		return mcp.LoggingLevelDebug
+	case LevelInfo:
+		return mcp.LoggingLevelInfo
+	case LevelWarn:
+		return mcp.LoggingLevelWarning
+	default:
+		return mcp.LoggingLevelError
+	}
+}
+
+// LevelFromMCP converts an MCP logging level to the nearest Level. MCP has more levels than
+// the logger: notice maps to info, and critical, alert and emergency map to error.
+func LevelFromMCP(level mcp.LoggingLevel) Level {
+	switch level {
+	case mcp.LoggingLevelDebug:
+		return LevelDebug
+	case mcp.LoggingLevelInfo, mcp.LoggingLevelNotice:
+		return LevelInfo
+	case mcp.LoggingLevelWarning:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
+
+// ParseLevel parses a level name such as "debug" or "warning"
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "notice":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error", "critical", "alert", "emergency":
+		return LevelError, nil
+	default:
+		return LevelError, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// Logger provides structured logging for the SpaceTraders MCP server.
+// Every message is written to stderr; messages at or above the client-selected
+// level are also sent to the MCP client as log notifications.
 type Logger struct {
 	errorLogger *log.Logger
+	warnLogger  *log.Logger
 	infoLogger  *log.Logger
 	debugLogger *log.Logger
 	mcpServer   *server.MCPServer
+	clientLevel atomic.Int32
 }
 
 // NewLogger creates a new logger instance
 func NewLogger(mcpServer *server.MCPServer) *Logger {
-	return &Logger{
+	logger := &Logger{
 		errorLogger: log.New(os.Stderr, "[ERROR] ", log.LstdFlags|log.Lshortfile),
+		warnLogger:  log.New(os.Stderr, "[WARN] ", log.LstdFlags),
 		infoLogger:  log.New(os.Stderr, "[INFO] ", log.LstdFlags),
 		debugLogger: log.New(os.Stderr, "[DEBUG] ", log.LstdFlags),
 		mcpServer:   mcpServer,
 	}
+	logger.SetLevel(DefaultClientLevel)
+	return logger
+}
+
+// SetLevel sets the minimum level of messages sent to the MCP client
+func (l *Logger) SetLevel(level Level) {
+	l.clientLevel.Store(int32(level))
+}
+
+// Level returns the minimum level of messages sent to the MCP client
+func (l *Logger) Level() Level {
+	return Level(l.clientLevel.Load())
 }
 
 // Info logs an informational message
 func (l *Logger) Info(message string, args ...interface{}) {
-	l.infoLogger.Printf(message, args...)
+	l.log(LevelInfo, "", nil, message, args)
+}
 
-	// Also send to MCP client if available
-	if l.mcpServer != nil {
-		l.sendMCPLog(mcp.LoggingLevelInfo, "spacetraders-mcp", message)
-	}
+// Warn logs a warning message
+func (l *Logger) Warn(message string, args ...interface{}) {
+	l.log(LevelWarn, "", nil, message, args)
 }
 
 // Error logs an error message
 func (l *Logger) Error(message string, args ...interface{}) {
-	l.errorLogger.Printf(message, args...)
-
-	// Also send to MCP client if available
-	if l.mcpServer != nil {
-		l.sendMCPLog(mcp.LoggingLevelError, "spacetraders-mcp", message)
-	}
+	l.log(LevelError, "", nil, message, args)
 }
 
 // Debug logs a debug message
 func (l *Logger) Debug(message string, args ...interface{}) {
-	l.debugLogger.Printf(message, args...)
-
-	// Also send to MCP client if available
-	if l.mcpServer != nil {
-		l.sendMCPLog(mcp.LoggingLevelDebug, "spacetraders-mcp", message)
-	}
+	l.log(LevelDebug, "", nil, message, args)
 }
 
 // WithContext adds context information to log messages
@@ -66,14 +148,78 @@ func (l *Logger) WithContext(ctx context.Context, component string) *ContextLogg
 	}
 }
 
-// sendMCPLog sends a log message to the MCP client
-func (l *Logger) sendMCPLog(level mcp.LoggingLevel, logger string, message string) {
-	// Create logging notification
-	notification := mcp.NewLoggingMessageNotification(level, logger, message)
+// log writes a message to stderr and, when the client level allows it, to the MCP client.
+// Fields are appended to the stderr line as key=value pairs and sent to the client as structured data.
+func (l *Logger) log(level Level, component string, fields []Field, message string, args []interface{}) {
+	text := fmt.Sprintf(message, args...)
 
-	// Send notification to client (this would be handled by the server framework)
-	// For now, we'll just ensure the structure is correct
-	_ = notification
+	line := text
+	if component != "" {
+		line = "[" + component + "] " + text
+	}
+	for _, field := range fields {
+		line += fmt.Sprintf(" %s=%v", field.Key, field.Value)
+	}
+
+	if target := l.stderrLogger(level); target != nil {
+		// Output rather than Printf so the caller's file and line are reported for errors
+		_ = target.Output(3, line)
+	}
+
+	if l.mcpServer == nil {
+		return
+	}
+
+	logger := "spacetraders-mcp"
+	if component == "" && len(fields) == 0 {
+		l.sendMCPLog(level.mcpLevel(), logger, text)
+		return
+	}
+
+	data := map[string]interface{}{
+		"message": text,
+	}
+	if component != "" {
+		data["component"] = component
+	}
+	for _, field := range fields {
+		data[field.Key] = field.Value
+	}
+	l.sendMCPLog(level.mcpLevel(), logger, data)
+}
+
+// stderrLogger returns the stderr logger for a level
+func (l *Logger) stderrLogger(level Level) *log.Logger {
+	switch level {
+	case LevelDebug:
+		return l.debugLogger
+	case LevelInfo:
+		return l.infoLogger
+	case LevelWarn:
+		return l.warnLogger
+	default:
+		return l.errorLogger
+	}
+}
+
+// sendMCPLog sends a log message to the MCP client if it is at or above the client-selected level
+func (l *Logger) sendMCPLog(level mcp.LoggingLevel, logger string, data interface{}) {
+	if l.mcpServer == nil || !level.ShouldSendTo(l.Level().mcpLevel()) {
+		return
+	}
+
+	notification := mcp.NewLoggingMessageNotification(level, logger, data)
+	l.mcpServer.SendNotificationToAllClients(notification.Method, map[string]any{
+		"level":  notification.Params.Level,
+		"logger": notification.Params.Logger,
+		"data":   notification.Params.Data,
+	})
+}
+
+// Field is a key-value pair attached to a log message
+type Field struct {
+	Key   string
+	Value interface{}
 }
 
 // ContextLogger provides logging with context information
@@ -81,24 +227,44 @@ type ContextLogger struct {
 	logger    *Logger
 	context   context.Context
 	component string
+	fields    []Field
+}
+
+// With returns a logger that attaches the given key-value pairs to every message,
+// e.g. With("ship", "SHIP-1", "waypoint", "X1-A1"). A trailing key without a value is ignored.
+func (cl *ContextLogger) With(keyvals ...interface{}) *ContextLogger {
+	fields := make([]Field, len(cl.fields), len(cl.fields)+len(keyvals)/2)
+	copy(fields, cl.fields)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		fields = append(fields, Field{Key: fmt.Sprint(keyvals[i]), Value: keyvals[i+1]})
+	}
+
+	return &ContextLogger{
+		logger:    cl.logger,
+		context:   cl.context,
+		component: cl.component,
+		fields:    fields,
+	}
 }
 
 // Info logs an informational message with context
 func (cl *ContextLogger) Info(message string, args ...interface{}) {
-	contextMessage := "[" + cl.component + "] " + message
-	cl.logger.Info(contextMessage, args...)
+	cl.logger.log(LevelInfo, cl.component, cl.fields, message, args)
+}
+
+// Warn logs a warning message with context
+func (cl *ContextLogger) Warn(message string, args ...interface{}) {
+	cl.logger.log(LevelWarn, cl.component, cl.fields, message, args)
 }
 
 // Error logs an error message with context
 func (cl *ContextLogger) Error(message string, args ...interface{}) {
-	contextMessage := "[" + cl.component + "] " + message
-	cl.logger.Error(contextMessage, args...)
+	cl.logger.log(LevelError, cl.component, cl.fields, message, args)
 }
 
 // Debug logs a debug message with context
 func (cl *ContextLogger) Debug(message string, args ...interface{}) {
-	contextMessage := "[" + cl.component + "] " + message
-	cl.logger.Debug(contextMessage, args...)
+	cl.logger.log(LevelDebug, cl.component, cl.fields, message, args)
 }
 
 // APICall logs an API call with timing information
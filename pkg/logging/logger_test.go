@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -373,3 +374,76 @@ func TestLogger_sendMCPLog(t *testing.T) {
 	// This should not panic
 	logger.sendMCPLog("info", "test-logger", "test message")
 }
+
+// testSession is an initialized MCP client session that collects notifications
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) SessionID() string                                   { return "test-session" }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+
+func TestLogger_ClientLevelFiltering(t *testing.T) {
+	s := server.NewMCPServer("Test Server", "1.0.0", server.WithLogging())
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	logger := NewLogger(s)
+	logger.debugLogger = log.New(&bytes.Buffer{}, "", 0)
+	logger.warnLogger = log.New(&bytes.Buffer{}, "", 0)
+	if logger.Level() != DefaultClientLevel {
+		t.Errorf("Expected default client level %s, got %s", DefaultClientLevel, logger.Level())
+	}
+
+	logger.SetLevel(LevelFromMCP(mcp.LoggingLevelWarning))
+	logger.Debug("hidden")
+	logger.WithContext(context.Background(), "tool").With("ship", "SHIP-1").Warn("low fuel %d%%", 5)
+
+	if len(session.notifications) != 1 {
+		t.Fatalf("Expected only the warning to reach the client, got %d notifications", len(session.notifications))
+	}
+	notification := <-session.notifications
+	fields := notification.Params.AdditionalFields
+	if fields["level"] != mcp.LoggingLevelWarning {
+		t.Errorf("Expected warning level, got %v", fields["level"])
+	}
+	data, ok := fields["data"].(map[string]interface{})
+	if !ok || data["message"] != "low fuel 5%" || data["ship"] != "SHIP-1" || data["component"] != "tool" {
+		t.Errorf("Expected structured data with message, component and ship, got %v", fields["data"])
+	}
+}
+
+func TestContextLogger_WithFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{
+		infoLogger: log.New(&buf, "[INFO] ", 0),
+	}
+
+	base := logger.WithContext(context.Background(), "nav")
+	base.With("ship", "SHIP-1").With("waypoint", "X1-A1", "dangling").Info("arrived")
+	base.Info("plain")
+
+	output := buf.String()
+	if !strings.Contains(output, "[nav] arrived ship=SHIP-1 waypoint=X1-A1\n") {
+		t.Errorf("Expected key=value fields after the message, got %q", output)
+	}
+	if !strings.Contains(output, "[nav] plain\n") {
+		t.Errorf("Expected fields not to leak into the parent logger, got %q", output)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, expected := range map[string]Level{"debug": LevelDebug, "INFO": LevelInfo, "warning": LevelWarn, "critical": LevelError} {
+		level, err := ParseLevel(name)
+		if err != nil || level != expected {
+			t.Errorf("ParseLevel(%q) = %s, %v; expected %s", name, level, err, expected)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,223 @@
+package mining
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// maxExtractions bounds how many extractions are kept; the oldest are dropped first
+const maxExtractions = 10000
+
+// Extraction is one extraction result
+type Extraction struct {
+	ExtractedAt    time.Time `json:"extractedAt"`
+	ShipSymbol     string    `json:"shipSymbol"`
+	WaypointSymbol string    `json:"waypointSymbol,omitempty"`
+	TradeSymbol    string    `json:"tradeSymbol"`
+	Units          int       `json:"units"`
+	Surveyed       bool      `json:"surveyed"`
+}
+
+// Locator looks up the waypoint a ship is at
+type Locator func(shipSymbol string) (string, error)
+
+// Recorder keeps every extraction the server sees and where it happened
+type Recorder struct {
+	mu          sync.RWMutex
+	extractions []Extraction
+	// locations is the last known waypoint of each ship, from navigation observations
+	locations map[string]string
+	locate    Locator
+}
+
+// NewRecorder creates an empty extraction recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		locations: make(map[string]string),
+	}
+}
+
+// WithLocator sets a lookup for ships whose location has not been observed yet. It is called at
+// most once per ship until the ship moves, since every move is observed.
+func (r *Recorder) WithLocator(locate Locator) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.locate = locate
+	return r
+}
+
+// Observe records extractions and ship locations from client observations; it is meant to be passed to client.AddObserver
+func (r *Recorder) Observe(observation client.Observation) {
+	switch observation.Kind {
+	case client.ObservedNavigation:
+		if nav := observation.Nav; nav != nil && nav.WaypointSymbol != "" {
+			r.mu.Lock()
+			r.locations[observation.ShipSymbol] = nav.WaypointSymbol
+			r.mu.Unlock()
+		}
+	case client.ObservedExtraction:
+		extraction := observation.Extraction
+		if extraction == nil {
+			return
+		}
+		record := Extraction{
+			ExtractedAt: observation.ObservedAt,
+			ShipSymbol:  observation.ShipSymbol,
+			TradeSymbol: extraction.Yield.Symbol,
+			Units:       extraction.Yield.Units,
+		}
+		if survey := observation.Survey; survey != nil {
+			record.Surveyed = true
+			record.WaypointSymbol = survey.Symbol
+		}
+		if record.WaypointSymbol == "" {
+			record.WaypointSymbol = r.location(observation.ShipSymbol)
+		}
+		r.Record(record)
+	}
+}
+
+// location returns a ship's known waypoint, looking it up once if it has not been observed
+func (r *Recorder) location(shipSymbol string) string {
+	r.mu.RLock()
+	waypoint, known := r.locations[shipSymbol]
+	locate := r.locate
+	r.mu.RUnlock()
+	if known || locate == nil {
+		return waypoint
+	}
+
+	waypoint, err := locate(shipSymbol)
+	if err != nil {
+		return ""
+	}
+	r.mu.Lock()
+	r.locations[shipSymbol] = waypoint
+	r.mu.Unlock()
+	return waypoint
+}
+
+// Record adds an extraction
+func (r *Recorder) Record(extraction Extraction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.extractions = append(r.extractions, extraction)
+	if len(r.extractions) > maxExtractions {
+		r.extractions = r.extractions[len(r.extractions)-maxExtractions:]
+	}
+}
+
+// Extractions returns the extractions at or after since (all of them for the zero time), oldest first
+func (r *Recorder) Extractions(since time.Time) []Extraction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Extraction, 0, len(r.extractions))
+	for _, extraction := range r.extractions {
+		if !extraction.ExtractedAt.Before(since) {
+			result = append(result, extraction)
+		}
+	}
+	return result
+}
+
+// Yield summarizes a group of extractions
+type Yield struct {
+	Key         string         `json:"key"`
+	Extractions int            `json:"extractions"`
+	Units       int            `json:"units"`
+	PerGood     map[string]int `json:"perGood"`
+	// UnitsPerExtraction is the average yield of one extraction
+	UnitsPerExtraction float64 `json:"unitsPerExtraction"`
+	// UnitsPerHour is the yield rate while mining, or 0 with fewer than two extractions to time
+	UnitsPerHour float64 `json:"unitsPerHour"`
+	// SurveyedUnitsPerExtraction and UnsurveyedUnitsPerExtraction compare yields with and without surveys
+	SurveyedUnitsPerExtraction   float64   `json:"surveyedUnitsPerExtraction,omitempty"`
+	UnsurveyedUnitsPerExtraction float64   `json:"unsurveyedUnitsPerExtraction,omitempty"`
+	First                        time.Time `json:"first"`
+	Last                         time.Time `json:"last"`
+}
+
+// Stats is a summary of extractions per ship and per waypoint
+type Stats struct {
+	Total      Yield   `json:"total"`
+	ByShip     []Yield `json:"byShip"`
+	ByWaypoint []Yield `json:"byWaypoint"`
+}
+
+// ComputeStats summarizes extractions per ship and per waypoint, each sorted by units per hour and
+// then units per extraction, best first. Extractions at an unknown waypoint are grouped under "".
+func ComputeStats(extractions []Extraction) Stats {
+	byShip := make(map[string][]Extraction)
+	byWaypoint := make(map[string][]Extraction)
+	for _, extraction := range extractions {
+		byShip[extraction.ShipSymbol] = append(byShip[extraction.ShipSymbol], extraction)
+		byWaypoint[extraction.WaypointSymbol] = append(byWaypoint[extraction.WaypointSymbol], extraction)
+	}
+
+	return Stats{
+		Total:      summarize("", extractions),
+		ByShip:     summarizeGroups(byShip),
+		ByWaypoint: summarizeGroups(byWaypoint),
+	}
+}
+
+// summarizeGroups summarizes each group, best yield first
+func summarizeGroups(groups map[string][]Extraction) []Yield {
+	yields := make([]Yield, 0, len(groups))
+	for key, group := range groups {
+		yields = append(yields, summarize(key, group))
+	}
+	sort.Slice(yields, func(i, j int) bool {
+		if yields[i].UnitsPerHour != yields[j].UnitsPerHour {
+			return yields[i].UnitsPerHour > yields[j].UnitsPerHour
+		}
+		if yields[i].UnitsPerExtraction != yields[j].UnitsPerExtraction {
+			return yields[i].UnitsPerExtraction > yields[j].UnitsPerExtraction
+		}
+		return yields[i].Key < yields[j].Key
+	})
+	return yields
+}
+
+// summarize totals a group of extractions, which must be in the order they happened
+func summarize(key string, extractions []Extraction) Yield {
+	yield := Yield{Key: key, PerGood: make(map[string]int)}
+	var surveyed, surveyedUnits, unsurveyedUnits int
+	for _, extraction := range extractions {
+		yield.Extractions++
+		yield.Units += extraction.Units
+		yield.PerGood[extraction.TradeSymbol] += extraction.Units
+		if extraction.Surveyed {
+			surveyed++
+			surveyedUnits += extraction.Units
+		} else {
+			unsurveyedUnits += extraction.Units
+		}
+	}
+	if yield.Extractions == 0 {
+		return yield
+	}
+
+	yield.First = extractions[0].ExtractedAt
+	yield.Last = extractions[len(extractions)-1].ExtractedAt
+	yield.UnitsPerExtraction = float64(yield.Units) / float64(yield.Extractions)
+	if surveyed > 0 {
+		yield.SurveyedUnitsPerExtraction = float64(surveyedUnits) / float64(surveyed)
+	}
+	if unsurveyed := yield.Extractions - surveyed; unsurveyed > 0 {
+		yield.UnsurveyedUnitsPerExtraction = float64(unsurveyedUnits) / float64(unsurveyed)
+	}
+
+	// n extractions cover n-1 gaps; counting one more average gap credits the last extraction
+	// with its own cooldown, so the rate is the average yield per gap
+	if span := yield.Last.Sub(yield.First); yield.Extractions > 1 && span > 0 {
+		gap := span / time.Duration(yield.Extractions-1)
+		yield.UnitsPerHour = float64(yield.Units) / (span + gap).Hours()
+	}
+	return yield
+}
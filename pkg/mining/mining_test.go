@@ -0,0 +1,87 @@
+package mining
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestRecorder_ObserveLocatesExtractions(t *testing.T) {
+	lookups := 0
+	r := NewRecorder().WithLocator(func(shipSymbol string) (string, error) {
+		lookups++
+		if shipSymbol == "LOST-1" {
+			return "", errors.New("not found")
+		}
+		return "X1-TEST-LOOKED-UP", nil
+	})
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	extract := func(ship string, at time.Time, units int, survey *client.Survey) {
+		r.Observe(client.Observation{
+			Kind:       client.ObservedExtraction,
+			ShipSymbol: ship,
+			ObservedAt: at,
+			Extraction: &client.Extraction{ShipSymbol: ship, Yield: client.ExtractionYield{Symbol: "IRON_ORE", Units: units}},
+			Survey:     survey,
+		})
+	}
+
+	// Unknown location: looked up once, then remembered
+	extract("MINER-1", base, 5, nil)
+	extract("MINER-1", base.Add(time.Minute), 5, nil)
+	// Navigation moves the ship without another lookup
+	r.Observe(client.Observation{Kind: client.ObservedNavigation, ShipSymbol: "MINER-1", Nav: &client.Navigation{WaypointSymbol: "X1-TEST-AST"}})
+	extract("MINER-1", base.Add(2*time.Minute), 7, nil)
+	// A survey names the waypoint itself
+	extract("MINER-2", base, 9, &client.Survey{Symbol: "X1-TEST-RICH"})
+	extract("LOST-1", base, 1, nil)
+
+	extractions := r.Extractions(time.Time{})
+	want := []string{"X1-TEST-LOOKED-UP", "X1-TEST-LOOKED-UP", "X1-TEST-AST", "X1-TEST-RICH", ""}
+	for i, waypoint := range want {
+		if extractions[i].WaypointSymbol != waypoint {
+			t.Errorf("Extraction %d: expected waypoint %q, got %q", i, waypoint, extractions[i].WaypointSymbol)
+		}
+	}
+	if !extractions[3].Surveyed {
+		t.Error("Expected the surveyed extraction to be marked")
+	}
+	if lookups != 2 {
+		t.Errorf("Expected one lookup for MINER-1 and one for LOST-1, got %d", lookups)
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	extractions := []Extraction{
+		{ExtractedAt: base, ShipSymbol: "MINER-1", WaypointSymbol: "X1-TEST-A", TradeSymbol: "IRON_ORE", Units: 10},
+		{ExtractedAt: base.Add(time.Minute), ShipSymbol: "MINER-1", WaypointSymbol: "X1-TEST-A", TradeSymbol: "COPPER_ORE", Units: 20, Surveyed: true},
+		{ExtractedAt: base.Add(2 * time.Minute), ShipSymbol: "MINER-1", WaypointSymbol: "X1-TEST-A", TradeSymbol: "IRON_ORE", Units: 30, Surveyed: true},
+		{ExtractedAt: base, ShipSymbol: "MINER-2", WaypointSymbol: "X1-TEST-B", TradeSymbol: "IRON_ORE", Units: 4},
+	}
+
+	stats := ComputeStats(extractions)
+	if stats.Total.Units != 64 || stats.Total.Extractions != 4 {
+		t.Errorf("Unexpected totals: %+v", stats.Total)
+	}
+
+	best := stats.ByWaypoint[0]
+	if best.Key != "X1-TEST-A" {
+		t.Fatalf("Expected X1-TEST-A first, got %+v", stats.ByWaypoint)
+	}
+	// 60 units over three one-minute gaps
+	if best.UnitsPerHour != 1200 {
+		t.Errorf("Expected 1200 units per hour, got %v", best.UnitsPerHour)
+	}
+	if best.UnitsPerExtraction != 20 || best.SurveyedUnitsPerExtraction != 25 || best.UnsurveyedUnitsPerExtraction != 10 {
+		t.Errorf("Unexpected per-extraction yields: %+v", best)
+	}
+	if best.PerGood["IRON_ORE"] != 40 {
+		t.Errorf("Expected 40 IRON_ORE, got %d", best.PerGood["IRON_ORE"])
+	}
+	if single := stats.ByShip[1]; single.Key != "MINER-2" || single.UnitsPerHour != 0 {
+		t.Errorf("Expected a single extraction to have no rate, got %+v", single)
+	}
+}
@@ -0,0 +1,100 @@
+package planning
+
+import "math"
+
+// assign solves the assignment problem for a value matrix of rows (ships) by columns (jobs),
+// returning the column given to each row, or -1 for none, such that no column is used twice
+// and the total value is as large as possible. Cells that are NaN or not positive are never
+// assigned, so a row is left out rather than given a job worth nothing.
+//
+// It is the Hungarian algorithm on a square cost matrix, padded with zero-value cells that
+// stand for leaving a row or column unassigned.
+func assign(values [][]float64) []int {
+	rows := len(values)
+	cols := 0
+	for _, row := range values {
+		cols = max(cols, len(row))
+	}
+	n := max(rows, cols)
+	if n == 0 {
+		return []int{}
+	}
+
+	// cost is the value given up by an assignment; padding and worthless cells cost nothing extra
+	best := 0.0
+	for _, row := range values {
+		for _, value := range row {
+			if value > best {
+				best = value
+			}
+		}
+	}
+	cost := func(i, j int) float64 {
+		if i < rows && j < len(values[i]) {
+			if value := values[i][j]; value > 0 {
+				return best - value
+			}
+		}
+		return best
+	}
+
+	// Potentials u (rows) and v (columns), and p[j] the row matched to column j, all 1-indexed
+	// with column 0 as the virtual start of each augmenting path
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1)
+	way := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if current := cost(i0-1, j-1) - u[i0] - v[j]; current < minv[j] {
+					minv[j], way[j] = current, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	result := make([]int, rows)
+	for i := range result {
+		result[i] = -1
+	}
+	for j := 1; j <= n; j++ {
+		i, col := p[j]-1, j-1
+		if i < rows && col < len(values[i]) && values[i][col] > 0 {
+			result[i] = col
+		}
+	}
+	return result
+}
@@ -0,0 +1,182 @@
+package planning
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/travel"
+)
+
+// JobKind says whether a job is a finite contract delivery or an open-ended trade route
+type JobKind string
+
+const (
+	// ContractJob delivers a fixed number of units for a contract payment
+	ContractJob JobKind = "contract"
+	// TradeJob buys at one market and sells at another for as long as the horizon lasts
+	TradeJob JobKind = "trade"
+)
+
+// flightMode is the mode every trip is planned in; it is the usual trade-off between time and fuel
+const flightMode = "CRUISE"
+
+// minJobDuration is the least time any job takes, covering docking and trading
+const minJobDuration = time.Minute
+
+// Location is a waypoint and its coordinates in its system
+type Location struct {
+	Waypoint string `json:"waypoint"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+}
+
+// Ship is a hauler that can be given a job
+type Ship struct {
+	Symbol   string
+	Location Location
+	// AvailableIn is how long until the ship reaches Location and can start, e.g. while in transit
+	AvailableIn time.Duration
+	Capacity    int
+	Speed       int
+}
+
+// Job is a hauling job: carry a good from Source to Destination
+type Job struct {
+	ID          string
+	Kind        JobKind
+	Good        string
+	Source      Location
+	Destination Location
+	// Units is how many units a contract still needs; trade routes ignore it
+	Units int
+	// UnitProfit is earned (or paid, when negative) per unit hauled: the margin of a trade route, or minus
+	// the purchase price of a contract good
+	UnitProfit int
+	// Payment is paid once the contract job is done
+	Payment int
+	// Deadline is when a contract job must be done by; zero means none
+	Deadline time.Time
+}
+
+// Estimate is what one ship would earn doing one job
+type Estimate struct {
+	Ship           string        `json:"ship"`
+	Job            string        `json:"job"`
+	Kind           JobKind       `json:"kind"`
+	Trips          int           `json:"trips"`
+	Profit         int           `json:"profit"`
+	Duration       time.Duration `json:"-"`
+	DurationString string        `json:"duration"`
+	CreditsPerHour float64       `json:"credits_per_hour"`
+}
+
+// Plan is the best assignment of ships to jobs found
+type Plan struct {
+	Assignments    []Estimate `json:"assignments"`
+	IdleShips      []string   `json:"idle_ships"`
+	OpenJobs       []string   `json:"open_jobs"`
+	CreditsPerHour float64    `json:"credits_per_hour"`
+}
+
+// Evaluate estimates what a ship earns doing a job, starting now and planning no further than horizon ahead.
+// A contract is hauled in as many trips as the ship's hold needs; a trade route is run back and forth until the
+// horizon. It reports false when the ship cannot do the job at a profit, or in time for the contract's deadline.
+func Evaluate(ship Ship, job Job, now time.Time, horizon time.Duration) (Estimate, bool) {
+	if ship.Capacity <= 0 {
+		return Estimate{}, false
+	}
+	if travel.SystemSymbol(ship.Location.Waypoint) != travel.SystemSymbol(job.Source.Waypoint) ||
+		travel.SystemSymbol(job.Source.Waypoint) != travel.SystemSymbol(job.Destination.Waypoint) {
+		return Estimate{}, false
+	}
+
+	approach := ship.AvailableIn + legTime(ship.Location, job.Source, ship.Speed)
+	leg := legTime(job.Source, job.Destination, ship.Speed)
+
+	estimate := Estimate{Ship: ship.Symbol, Job: job.ID, Kind: job.Kind}
+	switch job.Kind {
+	case ContractJob:
+		if job.Units <= 0 {
+			return Estimate{}, false
+		}
+		// Every trip but the last ends with flying back to the source to load again
+		estimate.Trips = (job.Units + ship.Capacity - 1) / ship.Capacity
+		estimate.Duration = approach + time.Duration(estimate.Trips)*leg + time.Duration(estimate.Trips-1)*leg
+		estimate.Profit = job.Payment + job.Units*job.UnitProfit
+		if !job.Deadline.IsZero() && now.Add(estimate.Duration).After(job.Deadline) {
+			return Estimate{}, false
+		}
+	case TradeJob:
+		// Count only full round trips that finish within the horizon
+		if leg == 0 || approach+leg > horizon {
+			return Estimate{}, false
+		}
+		estimate.Trips = 1 + int((horizon-approach-leg)/(2*leg))
+		estimate.Duration = approach + time.Duration(estimate.Trips)*leg + time.Duration(estimate.Trips-1)*leg
+		estimate.Profit = estimate.Trips * ship.Capacity * job.UnitProfit
+	default:
+		return Estimate{}, false
+	}
+
+	if estimate.Profit <= 0 {
+		return Estimate{}, false
+	}
+	// Docking and trading take a little while even when no flying is needed
+	estimate.Duration = max(estimate.Duration, minJobDuration)
+	estimate.DurationString = estimate.Duration.Round(time.Second).String()
+	estimate.CreditsPerHour = math.Round(float64(estimate.Profit)/estimate.Duration.Hours()*10) / 10
+	return estimate, true
+}
+
+// Optimize assigns at most one job to each ship, and each job to at most one ship, so that the fleet's combined
+// credits per hour is as high as possible. Ships and jobs left out are listed in the plan.
+func Optimize(ships []Ship, jobs []Job, now time.Time, horizon time.Duration) Plan {
+	values := make([][]float64, len(ships))
+	estimates := make([][]Estimate, len(ships))
+	for i, ship := range ships {
+		values[i] = make([]float64, len(jobs))
+		estimates[i] = make([]Estimate, len(jobs))
+		for j, job := range jobs {
+			if estimate, ok := Evaluate(ship, job, now, horizon); ok {
+				values[i][j] = estimate.CreditsPerHour
+				estimates[i][j] = estimate
+			}
+		}
+	}
+
+	plan := Plan{
+		Assignments: make([]Estimate, 0),
+		IdleShips:   make([]string, 0),
+		OpenJobs:    make([]string, 0),
+	}
+	taken := make([]bool, len(jobs))
+	for i, j := range assign(values) {
+		if j < 0 {
+			plan.IdleShips = append(plan.IdleShips, ships[i].Symbol)
+			continue
+		}
+		taken[j] = true
+		plan.Assignments = append(plan.Assignments, estimates[i][j])
+		plan.CreditsPerHour += estimates[i][j].CreditsPerHour
+	}
+	for j, job := range jobs {
+		if !taken[j] {
+			plan.OpenJobs = append(plan.OpenJobs, job.ID)
+		}
+	}
+
+	sort.SliceStable(plan.Assignments, func(i, j int) bool {
+		return plan.Assignments[i].CreditsPerHour > plan.Assignments[j].CreditsPerHour
+	})
+	plan.CreditsPerHour = math.Round(plan.CreditsPerHour*10) / 10
+	return plan
+}
+
+// legTime is how long a ship of the given engine speed takes between two locations
+func legTime(from, to Location, speed int) time.Duration {
+	if from.Waypoint == to.Waypoint {
+		return 0
+	}
+	return travel.TravelTime(travel.Distance(from.X, from.Y, to.X, to.Y), flightMode, speed)
+}
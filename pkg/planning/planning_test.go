@@ -0,0 +1,124 @@
+package planning
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAssign_BeatsGreedy(t *testing.T) {
+	// Greedy would give ship 0 job 0 (10) and leave ship 1 with job 1 (1); swapping earns 9+8
+	values := [][]float64{
+		{10, 9},
+		{8, 1},
+	}
+	if got := assign(values); !slices.Equal(got, []int{1, 0}) {
+		t.Errorf("assign = %v, want [1 0]", got)
+	}
+}
+
+func TestAssign_UnevenAndWorthless(t *testing.T) {
+	values := [][]float64{
+		{0, 0},
+		{5, 0},
+		{4, 0},
+	}
+	if got := assign(values); !slices.Equal(got, []int{-1, 0, -1}) {
+		t.Errorf("assign = %v, want [-1 0 -1]", got)
+	}
+	if got := assign([][]float64{{1, 2, 3}}); !slices.Equal(got, []int{2}) {
+		t.Errorf("assign = %v, want [2]", got)
+	}
+	if got := assign(nil); len(got) != 0 {
+		t.Errorf("assign(nil) = %v, want empty", got)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := Location{Waypoint: "X1-TEST-A1", X: 0, Y: 0}
+	destination := Location{Waypoint: "X1-TEST-B2", X: 30, Y: 40}
+	// 50 units at speed 25 in CRUISE: 50*25/25 + 15 = 65 seconds a leg
+	ship := Ship{Symbol: "SHIP-1", Location: source, Capacity: 40, Speed: 25}
+
+	contract := Job{ID: "contract", Kind: ContractJob, Source: source, Destination: destination, Units: 100, UnitProfit: -10, Payment: 5000}
+	estimate, ok := Evaluate(ship, contract, now, time.Hour)
+	if !ok {
+		t.Fatal("expected the contract to be feasible")
+	}
+	if estimate.Trips != 3 || estimate.Profit != 4000 || estimate.Duration != 5*65*time.Second {
+		t.Errorf("contract estimate = %+v, want 3 trips, 4000 profit, 325s", estimate)
+	}
+	if estimate.CreditsPerHour != 44307.7 {
+		t.Errorf("contract credits per hour = %v, want 44307.7", estimate.CreditsPerHour)
+	}
+
+	contract.Deadline = now.Add(5 * time.Minute)
+	if _, ok := Evaluate(ship, contract, now, time.Hour); ok {
+		t.Error("expected a contract that cannot meet its deadline to be infeasible")
+	}
+
+	trade := Job{ID: "trade", Kind: TradeJob, Source: source, Destination: destination, UnitProfit: 5}
+	estimate, ok = Evaluate(ship, trade, now, 10*time.Minute)
+	if !ok {
+		t.Fatal("expected the trade route to be feasible")
+	}
+	// 600s fits legs of 65s: 1 + (600-65)/130 = 5 full trips
+	if estimate.Trips != 5 || estimate.Profit != 1000 {
+		t.Errorf("trade estimate = %+v, want 5 trips and 1000 profit", estimate)
+	}
+
+	elsewhere := ship
+	elsewhere.Location = Location{Waypoint: "X1-OTHER-A1"}
+	if _, ok := Evaluate(elsewhere, trade, now, time.Hour); ok {
+		t.Error("expected a ship in another system to be unable to take the job")
+	}
+	trade.UnitProfit = -1
+	if _, ok := Evaluate(ship, trade, now, time.Hour); ok {
+		t.Error("expected a losing trade route to be rejected")
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := Location{Waypoint: "X1-TEST-A1", X: 0, Y: 0}
+	b := Location{Waypoint: "X1-TEST-B2", X: 100, Y: 0}
+	c := Location{Waypoint: "X1-TEST-C3", X: 0, Y: 100}
+	ships := []Ship{
+		{Symbol: "BIG", Location: a, Capacity: 80, Speed: 30},
+		{Symbol: "SMALL", Location: b, Capacity: 20, Speed: 30},
+		{Symbol: "SPARE", Location: c, Capacity: 20, Speed: 30},
+		{Symbol: "PROBE", Location: a, Capacity: 0, Speed: 10},
+	}
+	jobs := []Job{
+		{ID: "contract", Kind: ContractJob, Source: a, Destination: b, Units: 80, UnitProfit: -20, Payment: 10000},
+		{ID: "trade", Kind: TradeJob, Source: b, Destination: a, UnitProfit: 30},
+		{ID: "far", Kind: TradeJob, Source: Location{Waypoint: "X1-OTHER-A1"}, Destination: Location{Waypoint: "X1-OTHER-B2", X: 10}, UnitProfit: 100},
+	}
+
+	plan := Optimize(ships, jobs, now, 2*time.Hour)
+	if len(plan.Assignments) != 2 {
+		t.Fatalf("expected 2 assignments, got %+v", plan.Assignments)
+	}
+	got := map[string]string{}
+	total := 0.0
+	for _, assignment := range plan.Assignments {
+		got[assignment.Ship] = assignment.Job
+		total += assignment.CreditsPerHour
+	}
+	if got["BIG"] == "" || got["BIG"] == got["SMALL"] || got["SMALL"] == "" {
+		t.Errorf("expected BIG and SMALL to take the two jobs, got %v", got)
+	}
+	if !slices.Equal(plan.IdleShips, []string{"SPARE", "PROBE"}) {
+		t.Errorf("idle ships = %v, want [SPARE PROBE]", plan.IdleShips)
+	}
+	if !slices.Equal(plan.OpenJobs, []string{"far"}) {
+		t.Errorf("open jobs = %v, want [far]", plan.OpenJobs)
+	}
+	if plan.Assignments[0].CreditsPerHour < plan.Assignments[1].CreditsPerHour {
+		t.Error("expected assignments sorted by credits per hour")
+	}
+	if diff := plan.CreditsPerHour - total; diff > 0.1 || diff < -0.1 {
+		t.Errorf("plan credits per hour = %v, want about %v", plan.CreditsPerHour, total)
+	}
+}
@@ -0,0 +1,148 @@
+package policy
+
+import (
+	"fmt"
+	"sync"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// Limits are the spending rules purchase tools must follow. A zero limit is not enforced.
+type Limits struct {
+	// MaxPurchase is the most credits a single purchase may cost
+	MaxPurchase int `json:"maxPurchase"`
+	// ReserveFloor is the balance the agent's credits may not drop below
+	ReserveFloor int `json:"reserveFloor"`
+	// SessionSpendCap is the most credits that may be spent while the server runs
+	SessionSpendCap int `json:"sessionSpendCap"`
+}
+
+// Violation explains which limit a purchase would break
+type Violation struct {
+	Limit  string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("spending policy: %s", v.Reason)
+}
+
+// Policy enforces spending limits, counting what has been spent since the server started
+type Policy struct {
+	limits Limits
+
+	mu    sync.Mutex
+	spent int
+}
+
+// New creates a policy with the given limits
+func New(limits Limits) *Policy {
+	return &Policy{limits: limits}
+}
+
+// Limits returns the configured limits
+func (p *Policy) Limits() Limits {
+	if p == nil {
+		return Limits{}
+	}
+	return p.limits
+}
+
+// Enabled reports whether any limit is set. A nil policy has none.
+func (p *Policy) Enabled() bool {
+	return p != nil && (p.limits.MaxPurchase > 0 || p.limits.ReserveFloor > 0 || p.limits.SessionSpendCap > 0)
+}
+
+// Spent returns the credits spent since the server started
+func (p *Policy) Spent() int {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.spent
+}
+
+// Observe counts credits spent on goods, fuel, ships, repairs and modifications; it is meant to
+// be passed to client.AddObserver
+func (p *Policy) Observe(observation client.Observation) {
+	spent := 0
+	switch observation.Kind {
+	case client.ObservedMarketTransaction:
+		if tx := observation.MarketTransaction; tx != nil && tx.Type != "SELL" {
+			spent = tx.TotalPrice
+		}
+	case client.ObservedShipyardTransaction:
+		if tx := observation.ShipyardTransaction; tx != nil {
+			spent = tx.Price
+		}
+	case client.ObservedRepairTransaction:
+		if tx := observation.RepairTransaction; tx != nil {
+			spent = tx.TotalPrice
+		}
+	case client.ObservedModificationTransaction:
+		if tx := observation.ModificationTransaction; tx != nil {
+			spent = tx.TotalPrice
+		}
+	}
+	if spent <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	p.spent += spent
+	p.mu.Unlock()
+}
+
+// Check returns a *Violation when spending cost credits, with credits in the bank, would break
+// a limit, or nil when the purchase is allowed
+func (p *Policy) Check(cost int, credits int64) error {
+	if !p.Enabled() {
+		return nil
+	}
+
+	if p.limits.MaxPurchase > 0 && cost > p.limits.MaxPurchase {
+		return &Violation{
+			Limit:  "max_purchase",
+			Reason: fmt.Sprintf("this costs %d credits, more than the %d credits allowed for a single purchase", cost, p.limits.MaxPurchase),
+		}
+	}
+	if p.limits.ReserveFloor > 0 && credits-int64(cost) < int64(p.limits.ReserveFloor) {
+		return &Violation{
+			Limit: "reserve_floor",
+			Reason: fmt.Sprintf("this costs %d credits and would leave %d of your %d credits, below the %d credit reserve",
+				cost, credits-int64(cost), credits, p.limits.ReserveFloor),
+		}
+	}
+	if p.limits.SessionSpendCap > 0 {
+		spent := p.Spent()
+		if spent+cost > p.limits.SessionSpendCap {
+			return &Violation{
+				Limit: "session_spend_cap",
+				Reason: fmt.Sprintf("this costs %d credits, but %d of the %d credits allowed this session are already spent",
+					cost, spent, p.limits.SessionSpendCap),
+			}
+		}
+	}
+	return nil
+}
+
+// Allowance is the most a single purchase may cost right now with credits in the bank, for
+// tools that buy as much as they can. It is credits itself when no limit applies.
+func (p *Policy) Allowance(credits int64) int64 {
+	allowance := credits
+	if !p.Enabled() {
+		return allowance
+	}
+
+	if p.limits.MaxPurchase > 0 {
+		allowance = min(allowance, int64(p.limits.MaxPurchase))
+	}
+	if p.limits.ReserveFloor > 0 {
+		allowance = min(allowance, credits-int64(p.limits.ReserveFloor))
+	}
+	if p.limits.SessionSpendCap > 0 {
+		allowance = min(allowance, int64(p.limits.SessionSpendCap-p.Spent()))
+	}
+	return max(allowance, 0)
+}
@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	p := New(Limits{MaxPurchase: 50000, ReserveFloor: 10000, SessionSpendCap: 80000})
+
+	tests := []struct {
+		name    string
+		cost    int
+		credits int64
+		limit   string
+	}{
+		{"allowed", 20000, 100000, ""},
+		{"too large", 60000, 100000, "max_purchase"},
+		{"below reserve", 20000, 25000, "reserve_floor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(tt.cost, tt.credits)
+			var violation *Violation
+			if tt.limit == "" {
+				if err != nil {
+					t.Errorf("Expected the purchase to be allowed, got %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &violation) || violation.Limit != tt.limit {
+				t.Errorf("Expected a %s violation, got %v", tt.limit, err)
+			}
+		})
+	}
+}
+
+func TestPolicy_SessionSpendCap(t *testing.T) {
+	p := New(Limits{SessionSpendCap: 1000})
+
+	p.Observe(client.Observation{
+		Kind:              client.ObservedMarketTransaction,
+		MarketTransaction: &client.MarketTransaction{Type: "PURCHASE", TotalPrice: 700},
+	})
+	p.Observe(client.Observation{
+		Kind:              client.ObservedMarketTransaction,
+		MarketTransaction: &client.MarketTransaction{Type: "SELL", TotalPrice: 5000},
+	})
+
+	if p.Spent() != 700 {
+		t.Errorf("Expected 700 credits spent, got %d", p.Spent())
+	}
+	if err := p.Check(400, 100000); err == nil {
+		t.Error("Expected the session cap to refuse a purchase over the remaining 300 credits")
+	}
+	if allowance := p.Allowance(100000); allowance != 300 {
+		t.Errorf("Expected an allowance of 300, got %d", allowance)
+	}
+}
+
+func TestPolicy_NilAllowsEverything(t *testing.T) {
+	var p *Policy
+	if p.Enabled() {
+		t.Error("Expected a nil policy to be disabled")
+	}
+	if err := p.Check(1000000, 0); err != nil {
+		t.Errorf("Expected a nil policy to allow everything, got %v", err)
+	}
+	if allowance := p.Allowance(5000); allowance != 5000 {
+		t.Errorf("Expected the whole balance, got %d", allowance)
+	}
+}
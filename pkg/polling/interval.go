@@ -0,0 +1,105 @@
+package polling
+
+import (
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// Policy controls how often a ship is polled based on what it is doing
+type Policy struct {
+	// Min is the shortest interval ever returned, used when an event is imminent
+	Min time.Duration
+	// Max is the longest interval used while a ship is busy (transit or cooldown)
+	Max time.Duration
+	// Idle is the base interval for ships with nothing pending
+	Idle time.Duration
+	// Mothballed is the interval for ships that have stayed idle for a long time
+	Mothballed time.Duration
+	// Lead polls this long before an expected arrival or cooldown expiry
+	Lead time.Duration
+}
+
+// DefaultPolicy returns the polling policy used by background pollers
+func DefaultPolicy() Policy {
+	return Policy{
+		Min:        5 * time.Second,
+		Max:        5 * time.Minute,
+		Idle:       2 * time.Minute,
+		Mothballed: 15 * time.Minute,
+		Lead:       2 * time.Second,
+	}
+}
+
+// ForInterval returns the default policy built around a poller's usual interval: ships with
+// nothing pending are polled every interval, and ships unchanged for three intervals a third as
+// often. Busy ships are never left longer than an idle one.
+func ForInterval(interval time.Duration) Policy {
+	p := DefaultPolicy()
+	p.Idle = interval
+	p.Mothballed = 3 * interval
+	if p.Max > interval {
+		p.Max = interval
+	}
+	return p
+}
+
+// Activity describes what a ship is currently doing from a polling perspective
+type Activity string
+
+const (
+	ActivityTransit    Activity = "transit"
+	ActivityCooldown   Activity = "cooldown"
+	ActivityIdle       Activity = "idle"
+	ActivityMothballed Activity = "mothballed"
+)
+
+// ShipInterval returns how long to wait before polling the ship again.
+// idleFor is how long the ship has been observed without any state change;
+// ships idle for longer than the mothballed interval are polled rarely.
+func (p Policy) ShipInterval(ship client.Ship, idleFor time.Duration, now time.Time) (time.Duration, Activity) {
+	if ship.Nav.Status == "IN_TRANSIT" {
+		if arrivalIn, known := ship.ArrivalIn(now); known {
+			return p.untilEvent(arrivalIn), ActivityTransit
+		}
+		return p.Min, ActivityTransit
+	}
+
+	if remaining := ship.CooldownRemaining(now); remaining > 0 {
+		return p.untilEvent(remaining), ActivityCooldown
+	}
+
+	if idleFor >= p.Mothballed {
+		return p.Mothballed, ActivityMothballed
+	}
+
+	return p.Idle, ActivityIdle
+}
+
+// untilEvent schedules the next poll just before an expected event
+func (p Policy) untilEvent(remaining time.Duration) time.Duration {
+	next := remaining - p.Lead
+	if next < p.Min {
+		return p.Min
+	}
+	if next > p.Max {
+		return p.Max
+	}
+	return next
+}
+
+// FleetInterval returns how long to wait before polling a group of ships again: the shortest of
+// their intervals, so the next poll comes just before the first arrival or cooldown expiry.
+// idleFor reports how long each ship has gone unchanged. With no ships the idle interval is used.
+func (p Policy) FleetInterval(ships []client.Ship, idleFor func(client.Ship) time.Duration, now time.Time) time.Duration {
+	if len(ships) == 0 {
+		return p.Idle
+	}
+	next, _ := p.ShipInterval(ships[0], idleFor(ships[0]), now)
+	for _, ship := range ships[1:] {
+		if interval, _ := p.ShipInterval(ship, idleFor(ship), now); interval < next {
+			next = interval
+		}
+	}
+	return next
+}
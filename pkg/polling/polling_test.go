@@ -0,0 +1,155 @@
+package polling
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// timeLayout matches the timestamp format produced by pkg/client converters
+const timeLayout = "2006-01-02T15:04:05.000Z"
+
+func TestPolicy_ShipInterval(t *testing.T) {
+	policy := DefaultPolicy()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		ship             client.Ship
+		idleFor          time.Duration
+		expectedInterval time.Duration
+		expectedActivity Activity
+	}{
+		{
+			name: "arriving soon polls at minimum interval",
+			ship: client.Ship{Nav: client.Navigation{
+				Status: "IN_TRANSIT",
+				Route:  client.Route{Arrival: now.Add(3 * time.Second).Format(timeLayout)},
+			}},
+			expectedInterval: policy.Min,
+			expectedActivity: ActivityTransit,
+		},
+		{
+			name: "transit polls just before arrival",
+			ship: client.Ship{Nav: client.Navigation{
+				Status: "IN_TRANSIT",
+				Route:  client.Route{Arrival: now.Add(time.Minute).Format(timeLayout)},
+			}},
+			expectedInterval: time.Minute - policy.Lead,
+			expectedActivity: ActivityTransit,
+		},
+		{
+			name: "long transit is capped at max interval",
+			ship: client.Ship{Nav: client.Navigation{
+				Status: "IN_TRANSIT",
+				Route:  client.Route{Arrival: now.Add(time.Hour).Format(timeLayout)},
+			}},
+			expectedInterval: policy.Max,
+			expectedActivity: ActivityTransit,
+		},
+		{
+			name: "cooldown polls just before expiry",
+			ship: client.Ship{
+				Nav:      client.Navigation{Status: "IN_ORBIT"},
+				Cooldown: client.Cooldown{RemainingSeconds: 70},
+			},
+			expectedInterval: 70*time.Second - policy.Lead,
+			expectedActivity: ActivityCooldown,
+		},
+		{
+			name:             "idle ship uses idle interval",
+			ship:             client.Ship{Nav: client.Navigation{Status: "DOCKED"}},
+			idleFor:          time.Minute,
+			expectedInterval: policy.Idle,
+			expectedActivity: ActivityIdle,
+		},
+		{
+			name:             "long idle ship is mothballed",
+			ship:             client.Ship{Nav: client.Navigation{Status: "DOCKED"}},
+			idleFor:          time.Hour,
+			expectedInterval: policy.Mothballed,
+			expectedActivity: ActivityMothballed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, activity := policy.ShipInterval(tt.ship, tt.idleFor, now)
+			if interval != tt.expectedInterval {
+				t.Errorf("Expected interval %s, got %s", tt.expectedInterval, interval)
+			}
+			if activity != tt.expectedActivity {
+				t.Errorf("Expected activity %s, got %s", tt.expectedActivity, activity)
+			}
+		})
+	}
+}
+
+func TestPolicy_FleetInterval(t *testing.T) {
+	policy := ForInterval(3 * time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	docked := client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{Status: "DOCKED"}}
+	arriving := client.Ship{Symbol: "SHIP-2", Nav: client.Navigation{
+		Status: "IN_TRANSIT",
+		Route:  client.Route{Arrival: now.Add(30 * time.Second).Format(timeLayout)},
+	}}
+	idleFor := func(d time.Duration) func(client.Ship) time.Duration {
+		return func(client.Ship) time.Duration { return d }
+	}
+
+	if next := policy.FleetInterval(nil, idleFor(0), now); next != 3*time.Minute {
+		t.Errorf("Expected no ships to use the idle interval, got %s", next)
+	}
+	if next := policy.FleetInterval([]client.Ship{docked}, idleFor(time.Hour), now); next != 9*time.Minute {
+		t.Errorf("Expected a long idle fleet to back off to 9m, got %s", next)
+	}
+	if next := policy.FleetInterval([]client.Ship{docked, arriving}, idleFor(time.Hour), now); next != 30*time.Second-policy.Lead {
+		t.Errorf("Expected the next poll just before the arrival, got %s", next)
+	}
+}
+
+func TestIdleTracker_Observe(t *testing.T) {
+	tracker := NewIdleTracker()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if idle := tracker.Observe("SHIP-1", "DOCKED", start); idle != 0 {
+		t.Errorf("Expected first observation to be 0, got %s", idle)
+	}
+	if idle := tracker.Observe("SHIP-1", "DOCKED", start.Add(time.Minute)); idle != time.Minute {
+		t.Errorf("Expected unchanged state to report 1m idle, got %s", idle)
+	}
+	if idle := tracker.Observe("SHIP-1", "IN_ORBIT", start.Add(2*time.Minute)); idle != 0 {
+		t.Errorf("Expected changed state to reset idle time, got %s", idle)
+	}
+}
+
+func TestScheduler_ScheduleAndStop(t *testing.T) {
+	scheduler := NewScheduler(context.Background())
+
+	var runs int32
+	scheduler.Schedule("job", func(ctx context.Context) time.Duration {
+		atomic.AddInt32(&runs, 1)
+		return time.Millisecond
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&runs) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(scheduler.Keys()) != 1 {
+		t.Errorf("Expected 1 scheduled job, got %d", len(scheduler.Keys()))
+	}
+
+	scheduler.Stop()
+
+	if atomic.LoadInt32(&runs) < 3 {
+		t.Errorf("Expected job to run at least 3 times, got %d", runs)
+	}
+	if len(scheduler.Keys()) != 0 {
+		t.Errorf("Expected no scheduled jobs after Stop, got %d", len(scheduler.Keys()))
+	}
+}
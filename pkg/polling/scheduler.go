@@ -0,0 +1,117 @@
+package polling
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Job performs one poll and returns how long to wait before the next one
+type Job func(ctx context.Context) time.Duration
+
+// Scheduler runs keyed background jobs, each on its own adaptive interval
+type Scheduler struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler whose jobs stop when ctx is cancelled
+func NewScheduler(ctx context.Context) *Scheduler {
+	return &Scheduler{
+		ctx:     ctx,
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// Schedule starts (or replaces) the job registered under key. The first poll runs immediately.
+func (s *Scheduler) Schedule(key string, job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cancel, exists := s.cancels[key]; exists {
+		cancel()
+	}
+
+	jobCtx, cancel := context.WithCancel(s.ctx)
+	s.cancels[key] = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			next := job(jobCtx)
+			timer := time.NewTimer(next)
+			select {
+			case <-jobCtx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// Unschedule stops the job registered under key
+func (s *Scheduler) Unschedule(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cancel, exists := s.cancels[key]; exists {
+		cancel()
+		delete(s.cancels, key)
+	}
+}
+
+// Keys returns the keys of all scheduled jobs
+func (s *Scheduler) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.cancels))
+	for key := range s.cancels {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Stop cancels every job and waits for them to exit
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	for key, cancel := range s.cancels {
+		cancel()
+		delete(s.cancels, key)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// IdleTracker records when each key last changed so pollers can back off idle ships
+type IdleTracker struct {
+	mu          sync.Mutex
+	fingerprint map[string]string
+	since       map[string]time.Time
+}
+
+// NewIdleTracker creates an empty idle tracker
+func NewIdleTracker() *IdleTracker {
+	return &IdleTracker{
+		fingerprint: make(map[string]string),
+		since:       make(map[string]time.Time),
+	}
+}
+
+// Observe records the latest state fingerprint for key and returns how long it has been unchanged
+func (t *IdleTracker) Observe(key, fingerprint string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if previous, exists := t.fingerprint[key]; !exists || previous != fingerprint {
+		t.fingerprint[key] = fingerprint
+		t.since[key] = now
+		return 0
+	}
+	return now.Sub(t.since[key])
+}
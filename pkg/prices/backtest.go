@@ -0,0 +1,188 @@
+package prices
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// PriceStats summarizes a price over a backtest, weighting each price by how long it held
+type PriceStats struct {
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Latest int     `json:"latest"`
+}
+
+// BacktestPoint is the route's margin from one observation of either market until the next
+type BacktestPoint struct {
+	At        time.Time `json:"at"`
+	BuyPrice  int       `json:"buyPrice"`
+	SellPrice int       `json:"sellPrice"`
+	Margin    int       `json:"margin"`
+	// Units is the smaller trade volume of the two markets, the most one transaction at each moves
+	Units int `json:"units"`
+}
+
+// Backtest is how a buy-here, sell-there loop of one good would have done over a window
+type Backtest struct {
+	// Observations counts the snapshots of either market in the window that priced the good
+	Observations int             `json:"observations"`
+	Points       []BacktestPoint `json:"points"`
+	// From is when both markets first had a known price in the window, zero when they never did
+	From  time.Time  `json:"from"`
+	Until time.Time  `json:"until"`
+	Buy   PriceStats `json:"buy"`
+	Sell  PriceStats `json:"sell"`
+	// Margin is the sell price less the buy price, per unit
+	Margin PriceStats `json:"margin"`
+	// ProfitableShare is the share of the time the margin was positive, from 0 to 1
+	ProfitableShare float64 `json:"profitableShare"`
+}
+
+// timedPrice is a good's price in one snapshot
+type timedPrice struct {
+	at    time.Time
+	price Price
+}
+
+// Backtest replays the recorded prices of a good at two markets between since and until, as if
+// it had been bought at buyWaypoint and sold at sellWaypoint throughout. The prices known when
+// the window opened count from since, and each price holds until the market's next snapshot.
+func (db *DB) Backtest(buyWaypoint, sellWaypoint, tradeSymbol string, since, until time.Time) Backtest {
+	buys := pricesOf(db.History(buyWaypoint), tradeSymbol, until)
+	sells := pricesOf(db.History(sellWaypoint), tradeSymbol, until)
+
+	result := Backtest{Until: until}
+	changes := []time.Time{since}
+	for _, series := range [][]timedPrice{buys, sells} {
+		for _, p := range series {
+			if p.at.After(since) {
+				result.Observations++
+				changes = append(changes, p.at)
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Before(changes[j])
+	})
+
+	var weights []float64
+	var buyPrices, sellPrices, margins []int
+	for i, at := range changes {
+		if i > 0 && at.Equal(changes[i-1]) {
+			continue
+		}
+		buy, okBuy := priceAt(buys, at)
+		sell, okSell := priceAt(sells, at)
+		if !okBuy || !okSell {
+			continue
+		}
+		point := BacktestPoint{
+			At:        at,
+			BuyPrice:  buy.PurchasePrice,
+			SellPrice: sell.SellPrice,
+			Margin:    sell.SellPrice - buy.PurchasePrice,
+			Units:     min(buy.TradeVolume, sell.TradeVolume),
+		}
+		result.Points = append(result.Points, point)
+		buyPrices = append(buyPrices, point.BuyPrice)
+		sellPrices = append(sellPrices, point.SellPrice)
+		margins = append(margins, point.Margin)
+	}
+	if len(result.Points) == 0 {
+		return result
+	}
+	result.From = result.Points[0].At
+
+	// Each point holds until the next one, and the last until the end of the window
+	for i, point := range result.Points {
+		end := until
+		if i+1 < len(result.Points) {
+			end = result.Points[i+1].At
+		}
+		weights = append(weights, end.Sub(point.At).Seconds())
+	}
+	result.Buy = weightedStats(buyPrices, weights)
+	result.Sell = weightedStats(sellPrices, weights)
+	result.Margin = weightedStats(margins, weights)
+
+	var profitable, total float64
+	for i, margin := range margins {
+		total += weights[i]
+		if margin > 0 {
+			profitable += weights[i]
+		}
+	}
+	if total > 0 {
+		result.ProfitableShare = profitable / total
+	} else if margins[len(margins)-1] > 0 {
+		result.ProfitableShare = 1
+	}
+	return result
+}
+
+// pricesOf picks the good's price out of each snapshot observed by until, oldest first
+func pricesOf(history []Snapshot, tradeSymbol string, until time.Time) []timedPrice {
+	series := make([]timedPrice, 0, len(history))
+	for _, snapshot := range history {
+		if snapshot.ObservedAt.After(until) {
+			continue
+		}
+		if price, ok := snapshot.Price(tradeSymbol); ok {
+			series = append(series, timedPrice{at: snapshot.ObservedAt, price: price})
+		}
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].at.Before(series[j].at)
+	})
+	return series
+}
+
+// priceAt returns the latest price in series observed at or before at
+func priceAt(series []timedPrice, at time.Time) (Price, bool) {
+	i := sort.Search(len(series), func(i int) bool {
+		return series[i].at.After(at)
+	})
+	if i == 0 {
+		return Price{}, false
+	}
+	return series[i-1].price, true
+}
+
+// weightedStats summarizes values weighted by how long each held. When no time passed at all,
+// as with a single observation at the end of the window, every value counts the same.
+func weightedStats(values []int, weights []float64) PriceStats {
+	stats := PriceStats{Min: values[0], Max: values[0], Latest: values[len(values)-1]}
+
+	var total float64
+	for _, weight := range weights {
+		total += weight
+	}
+	weight := func(i int) float64 {
+		if total <= 0 {
+			return 1
+		}
+		return weights[i]
+	}
+	if total <= 0 {
+		total = float64(len(values))
+	}
+
+	var sum float64
+	for i, value := range values {
+		stats.Min = min(stats.Min, value)
+		stats.Max = max(stats.Max, value)
+		sum += float64(value) * weight(i)
+	}
+	stats.Mean = sum / total
+
+	var variance float64
+	for i, value := range values {
+		diff := float64(value) - stats.Mean
+		variance += diff * diff * weight(i)
+	}
+	stats.StdDev = math.Sqrt(variance / total)
+	return stats
+}
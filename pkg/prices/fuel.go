@@ -0,0 +1,42 @@
+package prices
+
+import (
+	"sort"
+
+	"spacetraders-mcp/pkg/travel"
+)
+
+// fuelSymbol is the trade symbol ships refuel with
+const fuelSymbol = "FUEL"
+
+// FuelIndex returns the latest fuel price at every market known to sell fuel, by system symbol and
+// cheapest first
+func (db *DB) FuelIndex() map[string][]Quote {
+	index := make(map[string][]Quote)
+	for _, quote := range db.Quotes(fuelSymbol) {
+		if quote.PurchasePrice <= 0 {
+			continue
+		}
+		system := travel.SystemSymbol(quote.WaypointSymbol)
+		index[system] = append(index[system], quote)
+	}
+	for _, quotes := range index {
+		sort.SliceStable(quotes, func(i, j int) bool {
+			return quotes[i].PurchasePrice < quotes[j].PurchasePrice
+		})
+	}
+	return index
+}
+
+// FuelPrice returns the latest price of a unit of fuel at a market, if the market is known to sell it
+func (db *DB) FuelPrice(waypointSymbol string) (int, bool) {
+	snapshot, ok := db.Latest(waypointSymbol)
+	if !ok {
+		return 0, false
+	}
+	price, ok := snapshot.Price(fuelSymbol)
+	if !ok || price.PurchasePrice <= 0 {
+		return 0, false
+	}
+	return price.PurchasePrice, true
+}
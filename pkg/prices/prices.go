@@ -0,0 +1,196 @@
+package prices
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// maxSnapshotsPerMarket bounds how much history is kept for each market; the oldest snapshots are dropped first
+const maxSnapshotsPerMarket = 200
+
+// Price is what one good traded for at a market
+type Price struct {
+	TradeSymbol   string `json:"tradeSymbol"`
+	Type          string `json:"type"`
+	Supply        string `json:"supply"`
+	Activity      string `json:"activity,omitempty"`
+	PurchasePrice int    `json:"purchasePrice"`
+	SellPrice     int    `json:"sellPrice"`
+	TradeVolume   int    `json:"tradeVolume"`
+}
+
+// Snapshot is the prices seen at a market at one moment
+type Snapshot struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ObservedAt     time.Time `json:"observedAt"`
+	Prices         []Price   `json:"prices"`
+}
+
+// Price returns the price of a good in the snapshot, if the market trades it
+func (s Snapshot) Price(tradeSymbol string) (Price, bool) {
+	for _, price := range s.Prices {
+		if price.TradeSymbol == tradeSymbol {
+			return price, true
+		}
+	}
+	return Price{}, false
+}
+
+// Quote is the latest known price of a good at one market
+type Quote struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ObservedAt     time.Time `json:"observedAt"`
+	Price
+}
+
+// DB is the price database: a history of market prices observed by any tool or task
+type DB struct {
+	mu        sync.RWMutex
+	snapshots map[string][]Snapshot
+}
+
+// New creates an empty price database
+func New() *DB {
+	return &DB{
+		snapshots: make(map[string][]Snapshot),
+	}
+}
+
+// Observe records prices from client observations; it is meant to be passed to client.AddObserver
+func (db *DB) Observe(observation client.Observation) {
+	if observation.Kind != client.ObservedMarket || observation.Market == nil {
+		return
+	}
+	market := observation.Market
+	if len(market.TradeGoods) == 0 {
+		// No ship at the market, so there are no prices to record
+		return
+	}
+
+	snapshot := Snapshot{
+		WaypointSymbol: market.Symbol,
+		ObservedAt:     observation.ObservedAt,
+		Prices:         make([]Price, 0, len(market.TradeGoods)),
+	}
+	for _, good := range market.TradeGoods {
+		snapshot.Prices = append(snapshot.Prices, Price{
+			TradeSymbol:   good.Symbol,
+			Type:          good.Type,
+			Supply:        good.Supply,
+			Activity:      good.Activity,
+			PurchasePrice: good.PurchasePrice,
+			SellPrice:     good.SellPrice,
+			TradeVolume:   good.TradeVolume,
+		})
+	}
+	db.Record(snapshot)
+}
+
+// Record adds a snapshot to a market's history
+func (db *DB) Record(snapshot Snapshot) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	history := append(db.snapshots[snapshot.WaypointSymbol], snapshot)
+	if len(history) > maxSnapshotsPerMarket {
+		history = history[len(history)-maxSnapshotsPerMarket:]
+	}
+	db.snapshots[snapshot.WaypointSymbol] = history
+}
+
+// Latest returns the most recent snapshot of a market
+func (db *DB) Latest(waypointSymbol string) (Snapshot, bool) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	history := db.snapshots[waypointSymbol]
+	if len(history) == 0 {
+		return Snapshot{}, false
+	}
+	return history[len(history)-1], true
+}
+
+// History returns every snapshot kept for a market, oldest first
+func (db *DB) History(waypointSymbol string) []Snapshot {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return append([]Snapshot(nil), db.snapshots[waypointSymbol]...)
+}
+
+// Markets returns the waypoints with recorded prices, sorted by symbol
+func (db *DB) Markets() []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	markets := make([]string, 0, len(db.snapshots))
+	for waypoint := range db.snapshots {
+		markets = append(markets, waypoint)
+	}
+	sort.Strings(markets)
+	return markets
+}
+
+// Quotes returns the latest price of a good at every market whose most recent snapshot includes it,
+// sorted by waypoint symbol
+func (db *DB) Quotes(tradeSymbol string) []Quote {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	quotes := make([]Quote, 0)
+	for waypoint, history := range db.snapshots {
+		latest := history[len(history)-1]
+		if price, ok := latest.Price(tradeSymbol); ok {
+			quotes = append(quotes, Quote{WaypointSymbol: waypoint, ObservedAt: latest.ObservedAt, Price: price})
+		}
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].WaypointSymbol < quotes[j].WaypointSymbol
+	})
+	return quotes
+}
+
+// All returns every snapshot in the database, by market symbol and then oldest first
+func (db *DB) All() []Snapshot {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	markets := make([]string, 0, len(db.snapshots))
+	for waypoint := range db.snapshots {
+		markets = append(markets, waypoint)
+	}
+	sort.Strings(markets)
+
+	all := make([]Snapshot, 0)
+	for _, waypoint := range markets {
+		all = append(all, db.snapshots[waypoint]...)
+	}
+	return all
+}
+
+// Replace swaps the whole database for the given snapshots, such as ones saved by All
+func (db *DB) Replace(snapshots []Snapshot) {
+	byMarket := make(map[string][]Snapshot)
+	for _, snapshot := range snapshots {
+		if snapshot.WaypointSymbol == "" {
+			continue
+		}
+		byMarket[snapshot.WaypointSymbol] = append(byMarket[snapshot.WaypointSymbol], snapshot)
+	}
+	for waypoint, history := range byMarket {
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].ObservedAt.Before(history[j].ObservedAt)
+		})
+		if len(history) > maxSnapshotsPerMarket {
+			history = history[len(history)-maxSnapshotsPerMarket:]
+		}
+		byMarket[waypoint] = history
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.snapshots = byMarket
+}
@@ -0,0 +1,154 @@
+package prices
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestDB_ObserveMarket(t *testing.T) {
+	db := New()
+	observedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A market fetched without a ship present carries no prices
+	db.Observe(client.Observation{
+		Kind:   client.ObservedMarket,
+		Market: &client.Market{Symbol: "X1-TEST-A1"},
+	})
+	if markets := db.Markets(); len(markets) != 0 {
+		t.Fatalf("Expected no markets without prices, got %v", markets)
+	}
+
+	db.Observe(client.Observation{
+		Kind:       client.ObservedMarket,
+		ObservedAt: observedAt,
+		Market: &client.Market{
+			Symbol: "X1-TEST-A1",
+			TradeGoods: []client.MarketTradeGood{
+				{Symbol: "FUEL", Type: "EXCHANGE", Supply: "ABUNDANT", PurchasePrice: 72, SellPrice: 68, TradeVolume: 100},
+			},
+		},
+	})
+
+	latest, ok := db.Latest("X1-TEST-A1")
+	if !ok || !latest.ObservedAt.Equal(observedAt) {
+		t.Fatalf("Expected a snapshot observed at %v, got %+v", observedAt, latest)
+	}
+	fuel, ok := latest.Price("FUEL")
+	if !ok || fuel.PurchasePrice != 72 || fuel.SellPrice != 68 || fuel.Supply != "ABUNDANT" {
+		t.Errorf("Expected fuel price to be recorded, got %+v", fuel)
+	}
+	if _, ok := latest.Price("IRON_ORE"); ok {
+		t.Errorf("Expected no price for a good the market does not trade")
+	}
+}
+
+func TestDB_HistoryIsBounded(t *testing.T) {
+	db := New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxSnapshotsPerMarket+10; i++ {
+		db.Record(Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	history := db.History("X1-TEST-A1")
+	if len(history) != maxSnapshotsPerMarket {
+		t.Fatalf("Expected %d snapshots, got %d", maxSnapshotsPerMarket, len(history))
+	}
+	if !history[0].ObservedAt.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("Expected the oldest snapshots to be dropped, first is %v", history[0].ObservedAt)
+	}
+}
+
+func TestDB_QuotesUseLatestSnapshot(t *testing.T) {
+	db := New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-B2", ObservedAt: base, Prices: []Price{{TradeSymbol: "IRON_ORE", SellPrice: 40}}})
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: base, Prices: []Price{{TradeSymbol: "IRON_ORE", SellPrice: 30}}})
+	// The market stopped listing the good, so its older price no longer counts
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-C3", ObservedAt: base, Prices: []Price{{TradeSymbol: "IRON_ORE", SellPrice: 99}}})
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-C3", ObservedAt: base.Add(time.Hour), Prices: []Price{{TradeSymbol: "FUEL", SellPrice: 70}}})
+
+	quotes := db.Quotes("IRON_ORE")
+	if len(quotes) != 2 || quotes[0].WaypointSymbol != "X1-TEST-A1" || quotes[1].SellPrice != 40 {
+		t.Errorf("Expected quotes from A1 and B2 only, got %+v", quotes)
+	}
+}
+
+func TestDB_FuelIndex(t *testing.T) {
+	db := New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: base, Prices: []Price{{TradeSymbol: "FUEL", PurchasePrice: 80}}})
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-B2", ObservedAt: base, Prices: []Price{{TradeSymbol: "FUEL", PurchasePrice: 65}}})
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-C3", ObservedAt: base, Prices: []Price{{TradeSymbol: "IRON_ORE", PurchasePrice: 20}}})
+	db.Record(Snapshot{WaypointSymbol: "X1-OTHER-D4", ObservedAt: base, Prices: []Price{{TradeSymbol: "FUEL", PurchasePrice: 90}}})
+	// A market that only buys fuel is no place to refuel
+	db.Record(Snapshot{WaypointSymbol: "X1-OTHER-E5", ObservedAt: base, Prices: []Price{{TradeSymbol: "FUEL", SellPrice: 50}}})
+
+	index := db.FuelIndex()
+	if len(index) != 2 {
+		t.Fatalf("Expected two systems, got %+v", index)
+	}
+	test := index["X1-TEST"]
+	if len(test) != 2 || test[0].WaypointSymbol != "X1-TEST-B2" || test[1].WaypointSymbol != "X1-TEST-A1" {
+		t.Errorf("Expected B2 then A1 in X1-TEST, got %+v", test)
+	}
+	if other := index["X1-OTHER"]; len(other) != 1 || other[0].PurchasePrice != 90 {
+		t.Errorf("Expected only D4 in X1-OTHER, got %+v", other)
+	}
+
+	if price, ok := db.FuelPrice("X1-TEST-B2"); !ok || price != 65 {
+		t.Errorf("Expected fuel at 65 in B2, got %d, %v", price, ok)
+	}
+	if _, ok := db.FuelPrice("X1-TEST-C3"); ok {
+		t.Error("Expected no fuel price at a market without fuel")
+	}
+}
+
+func TestDB_Backtest(t *testing.T) {
+	db := New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	buy := func(at time.Duration, price int) {
+		db.Record(Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: now.Add(at), Prices: []Price{{TradeSymbol: "IRON_ORE", PurchasePrice: price, TradeVolume: 60}}})
+	}
+	sell := func(at time.Duration, price int) {
+		db.Record(Snapshot{WaypointSymbol: "X1-TEST-B2", ObservedAt: now.Add(at), Prices: []Price{{TradeSymbol: "IRON_ORE", SellPrice: price, TradeVolume: 40}}})
+	}
+	// The buy price seen before the window still holds when it opens
+	buy(-5*time.Hour, 100)
+	sell(-3*time.Hour, 150)
+	buy(-2*time.Hour, 120)
+	sell(-time.Hour, 110)
+	// A snapshot without the good leaves its last price standing
+	db.Record(Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: now.Add(-30 * time.Minute), Prices: []Price{{TradeSymbol: "FUEL"}}})
+
+	result := db.Backtest("X1-TEST-A1", "X1-TEST-B2", "IRON_ORE", now.Add(-4*time.Hour), now)
+	if result.Observations != 3 || len(result.Points) != 3 || !result.From.Equal(now.Add(-3*time.Hour)) {
+		t.Fatalf("Expected 3 observations giving 3 points from 3 hours ago, got %+v", result)
+	}
+	margins := []int{50, 30, -10}
+	for i, point := range result.Points {
+		if point.Margin != margins[i] || point.Units != 40 {
+			t.Errorf("Point %d: expected margin %d on 40 units, got %+v", i, margins[i], point)
+		}
+	}
+	if result.Margin.Min != -10 || result.Margin.Max != 50 || result.Margin.Latest != -10 {
+		t.Errorf("Unexpected margin range %+v", result.Margin)
+	}
+	// Each margin held for an hour
+	if diff := result.Margin.Mean - 70.0/3; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Expected a mean margin of 23.33, got %f", result.Margin.Mean)
+	}
+	if diff := result.ProfitableShare - 2.0/3; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Expected the route to be profitable 2/3 of the time, got %f", result.ProfitableShare)
+	}
+	if result.Buy.Min != 100 || result.Buy.Max != 120 || result.Sell.StdDev == 0 {
+		t.Errorf("Unexpected price stats buy %+v sell %+v", result.Buy, result.Sell)
+	}
+
+	if empty := db.Backtest("X1-TEST-A1", "X1-TEST-C3", "IRON_ORE", now.Add(-4*time.Hour), now); len(empty.Points) != 0 || !empty.From.IsZero() {
+		t.Errorf("Expected no points without sell prices, got %+v", empty)
+	}
+}
@@ -0,0 +1,48 @@
+package prompts
+
+import (
+	"context"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/completion"
+	"spacetraders-mcp/pkg/fleetstate"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fixedArguments lists the values of prompt arguments that only take a few known values
+var fixedArguments = map[string][]string{
+	"detail_level": {"basic", "detailed", "full"},
+	"situation":    {situationStranded, situationBadContract, situationJunkCargo},
+}
+
+// CompletionProvider suggests values for prompt arguments such as ship_symbol and
+// system_symbol, based on the agent's fleet, contracts and the supply chain
+type CompletionProvider struct {
+	client *client.Client
+	fleet  *fleetstate.Model
+}
+
+// NewCompletionProvider creates a new prompt argument completion provider
+func NewCompletionProvider(client *client.Client) *CompletionProvider {
+	return &CompletionProvider{
+		client: client,
+	}
+}
+
+// WithFleetState completes ships and systems from the local fleet model while it is fresh,
+// instead of listing the fleet from the API on every request
+func (p *CompletionProvider) WithFleetState(model *fleetstate.Model) *CompletionProvider {
+	p.fleet = model
+	return p
+}
+
+// CompletePromptArgument returns the known values of a prompt argument that start with
+// what has been typed so far. Unknown arguments and API failures complete to nothing.
+func (p *CompletionProvider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	candidates, fixed := fixedArguments[argument.Name]
+	if !fixed {
+		candidates = completion.Candidates(p.client.WithContext(ctx), p.fleet, argument.Name, completeContext.Arguments)
+	}
+	return completion.Filter(candidates, argument.Value), nil
+}
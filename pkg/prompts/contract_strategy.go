@@ -0,0 +1,102 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ContractStrategyPrompt asks which contracts to take on, given the agent's current contracts and credits
+type ContractStrategyPrompt struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewContractStrategyPrompt creates a new contract strategy prompt handler
+func NewContractStrategyPrompt(client *client.Client, logger *logging.Logger) *ContractStrategyPrompt {
+	return &ContractStrategyPrompt{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Prompt returns the MCP prompt definition
+func (p *ContractStrategyPrompt) Prompt() mcp.Prompt {
+	return mcp.Prompt{
+		Name:        "contract_strategy",
+		Description: "Analyze available contracts and suggest the best ones to accept based on current capabilities",
+		Arguments:   []mcp.PromptArgument{},
+	}
+}
+
+// Handler returns the prompt handler function
+func (p *ContractStrategyPrompt) Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		ctxLogger := p.logger.WithContext(ctx, "contract-strategy-prompt")
+		apiClient := p.client.WithContext(ctx)
+
+		var prompt strings.Builder
+		prompt.WriteString("Help me develop a contract strategy.\n\n")
+
+		// Embed the current credits and contracts so the model starts from real IDs and numbers
+		agent, err := apiClient.GetAgent()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch agent for contract strategy prompt: %v", err)
+		} else {
+			fmt.Fprintf(&prompt, "I have %d credits.\n", agent.Credits)
+		}
+
+		contracts, err := apiClient.GetAllContracts()
+		switch {
+		case err != nil:
+			ctxLogger.Error("Failed to fetch contracts for contract strategy prompt: %v", err)
+		case len(contracts) == 0:
+			prompt.WriteString("I have no contracts yet.\n")
+		default:
+			prompt.WriteString("My contracts:\n")
+			for _, contract := range contracts {
+				prompt.WriteString(describeContract(contract))
+			}
+		}
+
+		prompt.WriteString("\nPlease:\n\n")
+		prompt.WriteString("1. Read my current contracts from spacetraders://contracts/list\n")
+		prompt.WriteString("2. Get my current status using get_status_summary\n")
+		prompt.WriteString("3. For each available contract, analyze:\n")
+		prompt.WriteString("   - Profitability (payment vs effort required)\n")
+		prompt.WriteString("   - Feasibility (do I have ships/cargo space?)\n")
+		prompt.WriteString("   - Location convenience (are delivery points near my ships?)\n")
+		prompt.WriteString("   - Time constraints (can I complete before deadline?)\n")
+		prompt.WriteString("4. Recommend which contracts to accept and why, accepting them with accept_contract by contract_id\n")
+		prompt.WriteString("5. If I need to move ships or buy cargo space, provide a plan\n")
+		prompt.WriteString("\nFocus on maximizing profit while minimizing risk and travel time.")
+
+		return userPrompt("Strategic contract analysis and recommendations", prompt.String()), nil
+	}
+}
+
+// describeContract summarizes a contract as one list item with its deliveries indented below
+func describeContract(contract client.Contract) string {
+	status := "not accepted, accept by " + contract.DeadlineToAccept
+	switch {
+	case contract.Fulfilled:
+		status = "fulfilled"
+	case contract.Accepted:
+		status = "accepted, due " + contract.Terms.Deadline
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "- %s: %s %s contract, %s, pays %d on accepting and %d on fulfilling\n",
+		contract.ID, contract.FactionSymbol, contract.Type, status,
+		contract.Terms.Payment.OnAccepted, contract.Terms.Payment.OnFulfilled)
+	for _, deliver := range contract.Terms.Deliver {
+		fmt.Fprintf(&text, "  - deliver %s to %s: %d of %d units done\n",
+			deliver.TradeSymbol, deliver.DestinationSymbol, deliver.UnitsFulfilled, deliver.UnitsRequired)
+	}
+	return text.String()
+}
@@ -0,0 +1,144 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultBriefingWindow is how far back the briefing looks when no last session time is given
+const defaultBriefingWindow = 24 * time.Hour
+
+// DailyBriefingPrompt is the "morning standup": what changed, what is due and what to do next
+type DailyBriefingPrompt struct {
+	client *client.Client
+	logger *logging.Logger
+	now    func() time.Time
+}
+
+// NewDailyBriefingPrompt creates a new daily briefing prompt handler
+func NewDailyBriefingPrompt(client *client.Client, logger *logging.Logger) *DailyBriefingPrompt {
+	return &DailyBriefingPrompt{
+		client: client,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Prompt returns the MCP prompt definition
+func (p *DailyBriefingPrompt) Prompt() mcp.Prompt {
+	return mcp.Prompt{
+		Name:        "daily_briefing",
+		Description: "Morning standup: review the dashboard, transactions since your last session, contract deadlines and idle ships, then produce a prioritized action plan",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "since",
+				Description: "When your last session ended, as an RFC3339 timestamp; defaults to 24 hours ago",
+				Required:    false,
+			},
+		},
+	}
+}
+
+// Handler returns the prompt handler function
+func (p *DailyBriefingPrompt) Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		ctxLogger := p.logger.WithContext(ctx, "daily-briefing-prompt")
+		now := p.now()
+
+		since := now.Add(-defaultBriefingWindow)
+		if value := argument(request, "since", ""); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("since must be an RFC3339 timestamp")
+			}
+			since = parsed
+		}
+		sinceText := since.UTC().Format(time.RFC3339)
+
+		var prompt strings.Builder
+		fmt.Fprintf(&prompt, "Give me my daily SpaceTraders briefing. My last session ended at %s.\n\n", sinceText)
+
+		// Deadlines are embedded so the plan can be ordered by urgency before anything is read
+		contracts, err := p.client.WithContext(ctx).GetAllContracts()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch contracts for daily briefing prompt: %v", err)
+		} else if deadlines := contractDeadlines(contracts, now); len(deadlines) > 0 {
+			prompt.WriteString("Contract deadlines, soonest first:\n")
+			for _, deadline := range deadlines {
+				prompt.WriteString(deadline)
+			}
+			prompt.WriteString("\n")
+		} else {
+			prompt.WriteString("No contracts have an open deadline.\n\n")
+		}
+
+		prompt.WriteString("Please:\n\n")
+		prompt.WriteString("1. Read spacetraders://dashboard for credits, fleet, contracts and running tasks\n")
+		fmt.Fprintf(&prompt, "2. Read spacetraders://ledger/transactions?since=%s for what I earned and spent since my last session\n", url.QueryEscape(sinceText))
+		prompt.WriteString("3. Check the contract deadlines above against how much is still to deliver\n")
+		prompt.WriteString("4. Use find_idle_ships to see which ships are doing nothing\n")
+		prompt.WriteString("5. Summarize what happened since my last session in a few lines\n")
+		prompt.WriteString("6. Produce a prioritized action plan:\n")
+		prompt.WriteString("   - First, anything that loses money or a contract if left alone\n")
+		prompt.WriteString("   - Then, work for idle ships\n")
+		prompt.WriteString("   - Then, investments such as new ships or contracts to accept\n")
+		prompt.WriteString("\nFor each action, name the ship or contract and the tool to use.")
+
+		return userPrompt("Daily briefing and prioritized action plan", prompt.String()), nil
+	}
+}
+
+// contractDeadlines lists the next deadline of every contract that is still open, soonest first:
+// the delivery deadline once accepted, or the deadline to accept otherwise
+func contractDeadlines(contracts []client.Contract, now time.Time) []string {
+	type deadline struct {
+		at   time.Time
+		line string
+	}
+
+	var deadlines []deadline
+	for _, contract := range contracts {
+		if contract.Fulfilled {
+			continue
+		}
+
+		due, label := contract.DeadlineToAccept, "accept by"
+		if contract.Accepted {
+			due, label = contract.Terms.Deadline, "deliver by"
+		}
+		at, err := time.Parse(time.RFC3339, due)
+		if err != nil || at.Before(now) {
+			continue
+		}
+
+		var remaining []string
+		for _, deliver := range contract.Terms.Deliver {
+			remaining = append(remaining, fmt.Sprintf("%d %s to %s",
+				deliver.UnitsRequired-deliver.UnitsFulfilled, deliver.TradeSymbol, deliver.DestinationSymbol))
+		}
+		deadlines = append(deadlines, deadline{
+			at: at,
+			line: fmt.Sprintf("- %s: %s %s (in %s), still to deliver: %s\n",
+				contract.ID, label, due, at.Sub(now).Round(time.Minute), strings.Join(remaining, ", ")),
+		})
+	}
+
+	sort.Slice(deadlines, func(i, j int) bool {
+		return deadlines[i].at.Before(deadlines[j].at)
+	})
+
+	lines := make([]string, len(deadlines))
+	for i, deadline := range deadlines {
+		lines[i] = deadline.line
+	}
+	return lines
+}
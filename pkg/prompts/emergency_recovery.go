@@ -0,0 +1,173 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Situations the emergency recovery prompt has a playbook for
+const (
+	situationStranded    = "stranded"
+	situationBadContract = "bad_contract"
+	situationJunkCargo   = "junk_cargo"
+)
+
+// EmergencyRecoveryPrompt walks through diagnosing and rescuing a stuck ship or contract
+type EmergencyRecoveryPrompt struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewEmergencyRecoveryPrompt creates a new emergency recovery prompt handler
+func NewEmergencyRecoveryPrompt(client *client.Client, logger *logging.Logger) *EmergencyRecoveryPrompt {
+	return &EmergencyRecoveryPrompt{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Prompt returns the MCP prompt definition
+func (p *EmergencyRecoveryPrompt) Prompt() mcp.Prompt {
+	return mcp.Prompt{
+		Name:        "emergency_recovery",
+		Description: "Get unstuck: a ship stranded without fuel, a contract that will lose money, or a hold full of junk cargo",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "ship_symbol",
+				Description: "Ship that is stuck; without one, every ship is checked",
+				Required:    false,
+			},
+			{
+				Name:        "situation",
+				Description: fmt.Sprintf("What went wrong (%s, %s or %s); without one, it is worked out from the ships", situationStranded, situationBadContract, situationJunkCargo),
+				Required:    false,
+			},
+		},
+	}
+}
+
+// Handler returns the prompt handler function
+func (p *EmergencyRecoveryPrompt) Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		ctxLogger := p.logger.WithContext(ctx, "emergency-recovery-prompt")
+		apiClient := p.client.WithContext(ctx)
+
+		situation := argument(request, "situation", "")
+		switch situation {
+		case "", situationStranded, situationBadContract, situationJunkCargo:
+		default:
+			return nil, fmt.Errorf("situation must be %s, %s or %s", situationStranded, situationBadContract, situationJunkCargo)
+		}
+		shipSymbol := argument(request, "ship_symbol", "")
+
+		// Look at the ships in trouble so the playbooks can name them
+		var ships []client.Ship
+		if shipSymbol != "" {
+			ship, err := apiClient.GetShip(shipSymbol)
+			if err != nil {
+				ctxLogger.Error("Failed to fetch ship %s for emergency recovery prompt: %v", shipSymbol, err)
+			} else {
+				ships = []client.Ship{*ship}
+			}
+		} else {
+			all, err := apiClient.GetAllShips()
+			if err != nil {
+				ctxLogger.Error("Failed to fetch ships for emergency recovery prompt: %v", err)
+			}
+			for _, ship := range all {
+				if len(shipTroubles(ship)) > 0 {
+					ships = append(ships, ship)
+				}
+			}
+		}
+
+		var prompt strings.Builder
+		prompt.WriteString("I'm stuck in SpaceTraders and need to recover.")
+		if shipSymbol != "" {
+			fmt.Fprintf(&prompt, " The ship in trouble is %s.", shipSymbol)
+		}
+		prompt.WriteString("\n\n")
+
+		// Work out which playbooks apply when the caller didn't say
+		situations := make(map[string]bool)
+		if situation != "" {
+			situations[situation] = true
+		}
+		if len(ships) > 0 {
+			prompt.WriteString("Ships that look stuck:\n")
+			for _, ship := range ships {
+				troubles := shipTroubles(ship)
+				fmt.Fprintf(&prompt, "- %s: %s at %s, fuel %d/%d, cargo %d/%d",
+					ship.Symbol, ship.Nav.Status, ship.Nav.WaypointSymbol,
+					ship.Fuel.Current, ship.Fuel.Capacity, ship.Cargo.Units, ship.Cargo.Capacity)
+				if len(troubles) > 0 {
+					fmt.Fprintf(&prompt, " (%s)", strings.Join(troubles, ", "))
+				}
+				prompt.WriteString("\n")
+				if situation == "" {
+					for _, trouble := range troubles {
+						situations[trouble] = true
+					}
+				}
+			}
+			prompt.WriteString("\n")
+		}
+		// Nothing stands out in the ships, so any of the playbooks could be needed
+		if len(situations) == 0 {
+			situations[situationStranded] = true
+			situations[situationBadContract] = true
+			situations[situationJunkCargo] = true
+		}
+
+		prompt.WriteString("Please start by diagnosing:\n\n")
+		prompt.WriteString("1. Read spacetraders://dashboard for credits, contracts and running tasks\n")
+		prompt.WriteString("2. Run fleet_audit to find every ship that is low on fuel, full or damaged\n")
+		if shipSymbol != "" {
+			fmt.Fprintf(&prompt, "3. Read spacetraders://ships/%s for the ship's nav, fuel and cargo\n", shipSymbol)
+		} else {
+			prompt.WriteString("3. Read spacetraders://ships/{shipSymbol} for each ship listed above\n")
+		}
+
+		prompt.WriteString("\nThen follow the playbooks that apply:\n")
+		if situations[situationStranded] {
+			prompt.WriteString("\n**Stranded without fuel**\n")
+			prompt.WriteString("- If the ship carries FUEL in its cargo, dock and use refuel_ship with from_cargo=true\n")
+			prompt.WriteString("- Otherwise use find_nearest with facility FUEL to find the closest fuel stop, and estimate_travel to see what each flight mode costs\n")
+			prompt.WriteString("- If no flight mode is affordable, switch to DRIFT with set_flight_mode, then navigate_ship to the fuel stop; drifting uses almost no fuel but is slow\n")
+			prompt.WriteString("- On arrival, dock, refuel_ship, and switch back to CRUISE with set_flight_mode\n")
+		}
+		if situations[situationBadContract] {
+			prompt.WriteString("\n**Contract that will lose money**\n")
+			prompt.WriteString("- Use get_contract_info and evaluate_contracts to compare the remaining payment with what the goods and fuel will cost\n")
+			prompt.WriteString("- Use source_goods to look for a cheaper market for the goods still to deliver\n")
+			prompt.WriteString("- If it still loses money, say how much, and compare that with the reputation lost by letting it expire; don't buy more goods for it until I decide\n")
+		}
+		if situations[situationJunkCargo] {
+			prompt.WriteString("\n**Cargo hold full of junk**\n")
+			prompt.WriteString("- Use where_to_trade to see whether anything in the hold sells nearby, and sell_all_cargo at a market that buys it, keeping contract goods with except\n")
+			prompt.WriteString("- Only if nothing nearby buys it, or the trip costs more than the cargo is worth, use jettison_cargo to drop it; jettisoned cargo is gone for good\n")
+		}
+
+		prompt.WriteString("\nTell me what you found before doing anything that can't be undone, such as jettisoning cargo, and give me the rescue steps in order.")
+
+		return userPrompt("Diagnose and recover from a stuck situation", prompt.String()), nil
+	}
+}
+
+// shipTroubles lists which emergency situations a ship is in
+func shipTroubles(ship client.Ship) []string {
+	var troubles []string
+	if ship.Fuel.Capacity > 0 && ship.Fuel.Current < ship.Fuel.Capacity/10 && ship.Nav.Status != "IN_TRANSIT" {
+		troubles = append(troubles, situationStranded)
+	}
+	if ship.Cargo.Capacity > 0 && ship.Cargo.Units >= ship.Cargo.Capacity {
+		troubles = append(troubles, situationJunkCargo)
+	}
+	return troubles
+}
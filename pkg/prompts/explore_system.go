@@ -0,0 +1,78 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ExploreSystemPrompt asks for a strategic survey of a system
+type ExploreSystemPrompt struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewExploreSystemPrompt creates a new explore system prompt handler
+func NewExploreSystemPrompt(client *client.Client, logger *logging.Logger) *ExploreSystemPrompt {
+	return &ExploreSystemPrompt{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Prompt returns the MCP prompt definition
+func (p *ExploreSystemPrompt) Prompt() mcp.Prompt {
+	return mcp.Prompt{
+		Name:        "explore_system",
+		Description: "Explore a specific system to find trading opportunities, shipyards, and points of interest",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "system_symbol",
+				Description: "System symbol to explore (e.g., X1-FM66); defaults to your headquarters system",
+				Required:    false,
+			},
+		},
+	}
+}
+
+// Handler returns the prompt handler function
+func (p *ExploreSystemPrompt) Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		ctxLogger := p.logger.WithContext(ctx, "explore-system-prompt")
+
+		// Without a system, explore the one the agent started in
+		systemSymbol := argument(request, "system_symbol", "")
+		if systemSymbol == "" {
+			agent, err := p.client.WithContext(ctx).GetAgent()
+			if err != nil {
+				ctxLogger.Error("Failed to fetch agent for explore system prompt: %v", err)
+				systemSymbol = "{SYSTEM_SYMBOL}"
+			} else {
+				systemSymbol = travel.SystemSymbol(agent.Headquarters)
+			}
+		}
+
+		var prompt strings.Builder
+		fmt.Fprintf(&prompt, "I want to explore system %s. Please:\n\n", systemSymbol)
+		fmt.Fprintf(&prompt, "1. Read the waypoints in this system from spacetraders://systems/%s/waypoints\n", systemSymbol)
+		prompt.WriteString("2. Identify which waypoints have:\n")
+		prompt.WriteString("   - Marketplaces (for trading)\n")
+		prompt.WriteString("   - Shipyards (for buying ships)\n")
+		prompt.WriteString("   - Mining sites (for resource extraction)\n")
+		prompt.WriteString("   - Other interesting traits\n")
+		prompt.WriteString("3. For any shipyards found, check what ships are available\n")
+		prompt.WriteString("4. Based on my current ships and credits, suggest:\n")
+		prompt.WriteString("   - Best trading opportunities\n")
+		prompt.WriteString("   - Whether I should buy new ships\n")
+		prompt.WriteString("   - Optimal travel routes within the system\n")
+		prompt.WriteString("\nProvide a strategic analysis of this system's potential.")
+
+		return userPrompt(fmt.Sprintf("Explore system %s for opportunities", systemSymbol), prompt.String()), nil
+	}
+}
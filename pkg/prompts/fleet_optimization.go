@@ -0,0 +1,77 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FleetOptimizationPrompt asks for a review of the fleet and how to improve it
+type FleetOptimizationPrompt struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewFleetOptimizationPrompt creates a new fleet optimization prompt handler
+func NewFleetOptimizationPrompt(client *client.Client, logger *logging.Logger) *FleetOptimizationPrompt {
+	return &FleetOptimizationPrompt{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Prompt returns the MCP prompt definition
+func (p *FleetOptimizationPrompt) Prompt() mcp.Prompt {
+	return mcp.Prompt{
+		Name:        "fleet_optimization",
+		Description: "Analyze current fleet and suggest optimizations for better efficiency and profit",
+		Arguments:   []mcp.PromptArgument{},
+	}
+}
+
+// Handler returns the prompt handler function
+func (p *FleetOptimizationPrompt) Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		ctxLogger := p.logger.WithContext(ctx, "fleet-optimization-prompt")
+
+		var prompt strings.Builder
+		prompt.WriteString("Help me optimize my fleet.\n\n")
+
+		// List the ships up front so the model can refer to them by symbol
+		ships, err := p.client.WithContext(ctx).GetAllShips()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships for fleet optimization prompt: %v", err)
+		} else {
+			fmt.Fprintf(&prompt, "My fleet has %d ships:\n", len(ships))
+			for _, ship := range ships {
+				fmt.Fprintf(&prompt, "- %s: %s, %s at %s, cargo %d/%d, fuel %d/%d\n",
+					ship.Symbol, ship.Registration.Role, ship.Nav.Status, ship.Nav.WaypointSymbol,
+					ship.Cargo.Units, ship.Cargo.Capacity, ship.Fuel.Current, ship.Fuel.Capacity)
+			}
+			prompt.WriteString("\n")
+		}
+
+		prompt.WriteString("Please:\n\n")
+		prompt.WriteString("1. Get my current status and ship details\n")
+		prompt.WriteString("2. Read my ships list from spacetraders://ships/list\n")
+		prompt.WriteString("3. Analyze my current fleet composition:\n")
+		prompt.WriteString("   - Ship types and roles\n")
+		prompt.WriteString("   - Cargo capacity utilization\n")
+		prompt.WriteString("   - Geographic distribution\n")
+		prompt.WriteString("   - Fuel efficiency\n")
+		prompt.WriteString("4. Check shipyards in systems where I have ships\n")
+		prompt.WriteString("5. Recommend fleet improvements:\n")
+		prompt.WriteString("   - Should I buy additional ships?\n")
+		prompt.WriteString("   - Are there better ship types for my activities?\n")
+		prompt.WriteString("   - Should I relocate ships to different systems?\n")
+		prompt.WriteString("   - Any upgrades or modifications needed?\n")
+		prompt.WriteString("\nProvide a strategic fleet development plan with cost-benefit analysis.")
+
+		return userPrompt("Fleet composition analysis and optimization recommendations", prompt.String()), nil
+	}
+}
@@ -0,0 +1,255 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func createMockLogger() *logging.Logger {
+	// Create a mock logger that doesn't require an MCP server
+	return logging.NewLogger(nil)
+}
+
+// newTestServer serves an agent with one open contract
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/agent":
+			fmt.Fprint(w, `{"data": {"symbol": "TEST_AGENT", "headquarters": "X1-TEST-A1", "credits": 175000, "startingFaction": "COSMIC", "shipCount": 2}}`)
+		case "/my/contracts":
+			fmt.Fprint(w, `{"data": [{"id": "contract-123", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "terms": {"deadline": "2025-02-01T00:00:00Z", "payment": {"onAccepted": 1000, "onFulfilled": 9000}, "deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-B2", "unitsRequired": 60, "unitsFulfilled": 0}]}, "accepted": false, "fulfilled": false, "expiration": "2025-01-20T00:00:00Z", "deadlineToAccept": "2025-01-20T00:00:00Z"}], "meta": {"total": 1, "page": 1, "limit": 20}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"message": "not found", "code": 404}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// promptText returns the text of a prompt result's single message
+func promptText(t *testing.T, result *mcp.GetPromptResult) string {
+	t.Helper()
+	if len(result.Messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(result.Messages))
+	}
+	content, ok := result.Messages[0].Content.(mcp.TextContent)
+	if !ok {
+		t.Fatalf("Expected text content, got %T", result.Messages[0].Content)
+	}
+	return content.Text
+}
+
+func TestRegistry_RegisterWithServer(t *testing.T) {
+	registry := NewRegistry(client.NewClient("test-token"), createMockLogger())
+
+	names := make(map[string]bool)
+	for _, prompt := range registry.GetPrompts() {
+		names[prompt.Name] = true
+	}
+	for _, expected := range []string{"status_check", "explore_system", "contract_strategy", "fleet_optimization", "daily_briefing", "emergency_recovery"} {
+		if !names[expected] {
+			t.Errorf("Expected prompt %s to be registered", expected)
+		}
+	}
+
+	s := server.NewMCPServer("Test Server", "1.0.0", server.WithPromptCapabilities(false))
+	registry.RegisterWithServer(s)
+}
+
+func TestContractStrategyPrompt_EmbedsContractsAndCredits(t *testing.T) {
+	server := newTestServer(t)
+	prompt := NewContractStrategyPrompt(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	result, err := prompt.Handler()(context.Background(), mcp.GetPromptRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := promptText(t, result)
+	for _, expected := range []string{"175000 credits", "contract-123", "IRON_ORE", "X1-TEST-B2", "0 of 60 units", "accept by 2025-01-20"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected prompt to mention %q, got:\n%s", expected, text)
+		}
+	}
+}
+
+func TestContractStrategyPrompt_APIFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	prompt := NewContractStrategyPrompt(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	result, err := prompt.Handler()(context.Background(), mcp.GetPromptRequest{})
+	if err != nil {
+		t.Fatalf("Expected the prompt without live data rather than an error, got %v", err)
+	}
+	if text := promptText(t, result); !strings.Contains(text, "spacetraders://contracts/list") {
+		t.Errorf("Expected the usual instructions, got:\n%s", text)
+	}
+}
+
+func TestExploreSystemPrompt_DefaultsToHeadquarters(t *testing.T) {
+	server := newTestServer(t)
+	prompt := NewExploreSystemPrompt(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	result, err := prompt.Handler()(context.Background(), mcp.GetPromptRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := promptText(t, result); !strings.Contains(text, "spacetraders://systems/X1-TEST/waypoints") {
+		t.Errorf("Expected the headquarters system, got:\n%s", text)
+	}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"system_symbol": "X1-OTHER"}
+	result, err = prompt.Handler()(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := promptText(t, result); !strings.Contains(text, "spacetraders://systems/X1-OTHER/waypoints") {
+		t.Errorf("Expected the requested system, got:\n%s", text)
+	}
+}
+
+func TestStatusCheckPrompt_DetailLevel(t *testing.T) {
+	server := newTestServer(t)
+	prompt := NewStatusCheckPrompt(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"detail_level": "full"}
+	result, err := prompt.Handler()(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := promptText(t, result)
+	if !strings.Contains(text, "TEST_AGENT with 175000 credits") {
+		t.Errorf("Expected the agent's credits, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Suggest 3-5 concrete next actions") {
+		t.Errorf("Expected the full checklist, got:\n%s", text)
+	}
+}
+
+func TestDailyBriefingPrompt_DeadlinesAndLedgerWindow(t *testing.T) {
+	server := newTestServer(t)
+	prompt := NewDailyBriefingPrompt(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+	prompt.now = func() time.Time { return time.Date(2025, 1, 19, 12, 0, 0, 0, time.UTC) }
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"since": "2025-01-18T20:00:00Z"}
+	result, err := prompt.Handler()(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := promptText(t, result)
+	for _, expected := range []string{
+		"spacetraders://dashboard",
+		"spacetraders://ledger/transactions?since=2025-01-18T20%3A00%3A00Z",
+		"contract-123: accept by",
+		"in 12h0m0s",
+		"60 IRON_ORE to X1-TEST-B2",
+		"find_idle_ships",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected briefing to mention %q, got:\n%s", expected, text)
+		}
+	}
+
+	request.Params.Arguments = map[string]string{"since": "yesterday"}
+	if _, err := prompt.Handler()(context.Background(), request); err == nil {
+		t.Error("Expected an error for a since that is not RFC3339")
+	}
+}
+
+func TestContractDeadlines_SkipsClosedContracts(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadlines := contractDeadlines([]client.Contract{
+		{ID: "late", Accepted: true, Terms: client.ContractTerms{Deadline: "2025-01-03T00:00:00Z"}},
+		{ID: "soon", Accepted: true, Terms: client.ContractTerms{Deadline: "2025-01-02T00:00:00Z"}},
+		{ID: "done", Accepted: true, Fulfilled: true, Terms: client.ContractTerms{Deadline: "2025-01-02T00:00:00Z"}},
+		{ID: "expired", DeadlineToAccept: "2024-12-31T00:00:00Z"},
+	}, now)
+
+	if len(deadlines) != 2 || !strings.HasPrefix(deadlines[0], "- soon:") || !strings.HasPrefix(deadlines[1], "- late:") {
+		t.Errorf("Expected soon then late, got %v", deadlines)
+	}
+}
+
+func TestEmergencyRecoveryPrompt_DetectsStrandedShip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": [
+			{"symbol": "SHIP-DRY", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-C3", "status": "IN_ORBIT", "flightMode": "CRUISE"}, "fuel": {"current": 2, "capacity": 400}, "cargo": {"capacity": 40, "units": 0, "inventory": []}},
+			{"symbol": "SHIP-OK", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED", "flightMode": "CRUISE"}, "fuel": {"current": 400, "capacity": 400}, "cargo": {"capacity": 40, "units": 10, "inventory": []}}
+		], "meta": {"total": 2, "page": 1, "limit": 20}}`)
+	}))
+	defer server.Close()
+
+	prompt := NewEmergencyRecoveryPrompt(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	result, err := prompt.Handler()(context.Background(), mcp.GetPromptRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := promptText(t, result)
+	for _, expected := range []string{"SHIP-DRY", "fuel 2/400", "(stranded)", "set_flight_mode", "from_cargo=true"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected prompt to mention %q, got:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "SHIP-OK") {
+		t.Errorf("Expected healthy ships to be left out, got:\n%s", text)
+	}
+	if strings.Contains(text, "jettison_cargo") {
+		t.Errorf("Expected only the stranded playbook, got:\n%s", text)
+	}
+}
+
+func TestEmergencyRecoveryPrompt_InvalidSituation(t *testing.T) {
+	prompt := NewEmergencyRecoveryPrompt(client.NewClient("test-token"), createMockLogger())
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"situation": "pirates"}
+	if _, err := prompt.Handler()(context.Background(), request); err == nil {
+		t.Error("Expected an error for an unknown situation")
+	}
+}
+
+func TestCompletionProvider_PromptArguments(t *testing.T) {
+	server := newTestServer(t)
+	provider := NewCompletionProvider(client.NewClientWithBaseURL("test-token", server.URL))
+
+	completion, err := provider.CompletePromptArgument(context.Background(), "emergency_recovery",
+		mcp.CompleteArgument{Name: "situation", Value: "s"}, mcp.CompleteContext{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(completion.Values) != 1 || completion.Values[0] != "stranded" {
+		t.Errorf("Expected [stranded], got %v", completion.Values)
+	}
+
+	completion, _ = provider.CompletePromptArgument(context.Background(), "explore_system",
+		mcp.CompleteArgument{Name: "system_symbol", Value: ""}, mcp.CompleteContext{})
+	if len(completion.Values) != 1 || completion.Values[0] != "X1-TEST" {
+		t.Errorf("Expected the headquarters system [X1-TEST], got %v", completion.Values)
+	}
+}
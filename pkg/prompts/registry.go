@@ -0,0 +1,88 @@
+package prompts
+
+import (
+	"context"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// PromptHandler defines the interface for all prompt handlers
+type PromptHandler interface {
+	Prompt() mcp.Prompt
+	Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+}
+
+// Registry manages all MCP prompts
+type Registry struct {
+	client   *client.Client
+	logger   *logging.Logger
+	handlers []PromptHandler
+}
+
+// NewRegistry creates a new prompt registry
+func NewRegistry(client *client.Client, logger *logging.Logger) *Registry {
+	registry := &Registry{
+		client:   client,
+		logger:   logger,
+		handlers: make([]PromptHandler, 0),
+	}
+
+	// Register all available prompts
+	registry.registerPrompts()
+
+	return registry
+}
+
+// registerPrompts registers all available prompt handlers
+func (r *Registry) registerPrompts() {
+	r.handlers = append(r.handlers, NewStatusCheckPrompt(r.client, r.logger))
+	r.handlers = append(r.handlers, NewExploreSystemPrompt(r.client, r.logger))
+	r.handlers = append(r.handlers, NewContractStrategyPrompt(r.client, r.logger))
+	r.handlers = append(r.handlers, NewFleetOptimizationPrompt(r.client, r.logger))
+	r.handlers = append(r.handlers, NewDailyBriefingPrompt(r.client, r.logger))
+	r.handlers = append(r.handlers, NewEmergencyRecoveryPrompt(r.client, r.logger))
+}
+
+// RegisterWithServer registers all prompts with the MCP server
+func (r *Registry) RegisterWithServer(s *server.MCPServer) {
+	for _, handler := range r.handlers {
+		s.AddPrompt(handler.Prompt(), handler.Handler())
+	}
+}
+
+// GetPrompts returns all registered prompts (useful for testing/debugging)
+func (r *Registry) GetPrompts() []mcp.Prompt {
+	prompts := make([]mcp.Prompt, 0, len(r.handlers))
+	for _, handler := range r.handlers {
+		prompts = append(prompts, handler.Prompt())
+	}
+	return prompts
+}
+
+// argument returns a prompt argument, or fallback when it was not given
+func argument(request mcp.GetPromptRequest, name, fallback string) string {
+	if value, exists := request.Params.Arguments[name]; exists && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// userPrompt wraps text as the single user message of a prompt result
+func userPrompt(description, text string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages: []mcp.PromptMessage{
+			{
+				Role: mcp.RoleUser,
+				Content: mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		},
+	}
+}
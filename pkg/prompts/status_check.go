@@ -0,0 +1,80 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StatusCheckPrompt asks for an overview of the agent, fleet and contracts
+type StatusCheckPrompt struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewStatusCheckPrompt creates a new status check prompt handler
+func NewStatusCheckPrompt(client *client.Client, logger *logging.Logger) *StatusCheckPrompt {
+	return &StatusCheckPrompt{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Prompt returns the MCP prompt definition
+func (p *StatusCheckPrompt) Prompt() mcp.Prompt {
+	return mcp.Prompt{
+		Name:        "status_check",
+		Description: "Get comprehensive status of your SpaceTraders agent including ships, contracts, and opportunities",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "detail_level",
+				Description: "Level of detail (basic, detailed, full)",
+				Required:    false,
+			},
+		},
+	}
+}
+
+// Handler returns the prompt handler function
+func (p *StatusCheckPrompt) Handler() func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		ctxLogger := p.logger.WithContext(ctx, "status-check-prompt")
+		detailLevel := argument(request, "detail_level", "basic")
+
+		var prompt strings.Builder
+		prompt.WriteString("I'd like to check my SpaceTraders status.")
+
+		// Start from what the agent looks like right now so the model knows what to expect
+		agent, err := p.client.WithContext(ctx).GetAgent()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch agent for status check prompt: %v", err)
+		} else {
+			fmt.Fprintf(&prompt, " I am %s with %d credits and %d ships, headquartered at %s.",
+				agent.Symbol, agent.Credits, agent.ShipCount, agent.Headquarters)
+		}
+
+		prompt.WriteString(" Please:\n\n")
+		prompt.WriteString("1. Use the get_status_summary tool to get my current agent status\n")
+		prompt.WriteString("2. Read my ships list from spacetraders://ships/list\n")
+		prompt.WriteString("3. Read my contracts from spacetraders://contracts/list\n")
+
+		if detailLevel == "detailed" || detailLevel == "full" {
+			prompt.WriteString("4. If I have ships in different systems, show waypoints for those systems\n")
+			prompt.WriteString("5. Check for any shipyards or marketplaces at my current locations\n")
+		}
+
+		if detailLevel == "full" {
+			prompt.WriteString("6. Suggest 3-5 concrete next actions based on my current situation\n")
+			prompt.WriteString("7. Identify any immediate opportunities (profitable contracts, good trade routes, etc.)\n")
+		}
+
+		prompt.WriteString("\nPlease provide a clear summary and actionable recommendations.")
+
+		return userPrompt("Comprehensive SpaceTraders status check", prompt.String()), nil
+	}
+}
@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const accountResourceURI = "spacetraders://account/info"
+
+// AccountResource shows which account and agent the server is acting as
+type AccountResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewAccountResource creates a new account information resource handler
+func NewAccountResource(client *client.Client, logger *logging.Logger) *AccountResource {
+	return &AccountResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *AccountResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         accountResourceURI,
+		Name:        "Account Information",
+		Description: "Which account and agent the server is operating as: the account ID, email and creation date, what the API token says about itself (agent, reset date, issue date), and whether they agree",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *AccountResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != accountResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "account-resource")
+		c := r.client.WithContext(ctx)
+
+		// Each part is reported on its own, so a token that may not read the account still shows its agent
+		data := make(map[string]interface{})
+
+		account, accountErr := c.GetMyAccount()
+		if accountErr != nil {
+			ctxLogger.Error("Failed to fetch account: %v", accountErr)
+			data["accountError"] = accountErr.Error()
+		} else {
+			data["account"] = account
+		}
+
+		agent, agentErr := c.GetAgent()
+		if agentErr != nil {
+			ctxLogger.Error("Failed to fetch agent: %v", agentErr)
+			data["agentError"] = agentErr.Error()
+		} else {
+			data["agent"] = map[string]interface{}{
+				"symbol":          agent.Symbol,
+				"accountId":       agent.AccountID,
+				"headquarters":    agent.Headquarters,
+				"startingFaction": agent.StartingFaction,
+			}
+		}
+
+		token, tokenErr := c.TokenInfo()
+		if tokenErr != nil {
+			data["tokenError"] = tokenErr.Error()
+		} else {
+			data["token"] = token
+		}
+
+		if accountErr != nil && agentErr != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error fetching account info: " + accountErr.Error(),
+				},
+			}, nil
+		}
+
+		// Mismatches mean the token belongs to a different agent or account than expected
+		if agentErr == nil && tokenErr == nil {
+			data["tokenMatchesAgent"] = token.Identifier == agent.Symbol
+		}
+		if agentErr == nil && accountErr == nil && agent.AccountID != nil {
+			data["agentMatchesAccount"] = *agent.AccountID == account.ID
+		}
+
+		result := liveEnvelope(data, 1,
+			Link{Rel: "agent", URI: "spacetraders://agent/info"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal account data to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting account information",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
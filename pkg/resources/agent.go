@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/fleetstate"
 	"spacetraders-mcp/pkg/logging"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,6 +15,7 @@ import (
 // AgentResource handles the agent information resource
 type AgentResource struct {
 	client *client.Client
+	fleet  *fleetstate.Model
 	logger *logging.Logger
 }
 
@@ -25,6 +27,13 @@ func NewAgentResource(client *client.Client, logger *logging.Logger) *AgentResou
 	}
 }
 
+// WithFleetState answers with the agent last seen in any response while that is recent, instead
+// of asking the API
+func (r *AgentResource) WithFleetState(model *fleetstate.Model) *AgentResource {
+	r.fleet = model
+	return r
+}
+
 // Resource returns the MCP resource definition
 func (r *AgentResource) Resource() mcp.Resource {
 	return mcp.Resource{
@@ -51,38 +60,53 @@ func (r *AgentResource) Handler() func(ctx context.Context, request mcp.ReadReso
 
 		// Set up context logger
 		ctxLogger := r.logger.WithContext(ctx, "agent-resource")
-		ctxLogger.Debug("Fetching agent information from API")
+		// Every response that changes credits carries the agent, so a recently seen one is current
+		local, seenAt, fromModel := r.fleet.Agent(time.Now())
+		agent := &local
+		if fromModel {
+			ctxLogger.Debug("Answering agent information from local fleet state")
+		} else {
+			ctxLogger.Debug("Fetching agent information from API")
 
-		// Get agent information from the API
-		start := time.Now()
-		agent, err := r.client.GetAgent()
-		duration := time.Since(start)
+			// Get agent information from the API
+			start := time.Now()
+			fetched, err := r.client.WithContext(ctx).GetAgent()
+			duration := time.Since(start)
 
-		if err != nil {
-			ctxLogger.Error("Failed to fetch agent info: %v", err)
-			ctxLogger.APICall("/my/agent", 0, duration.String())
-			return []mcp.ResourceContents{
-				&mcp.TextResourceContents{
-					URI:      request.Params.URI,
-					MIMEType: "text/plain",
-					Text:     "Error fetching agent info: " + err.Error(),
-				},
-			}, nil
-		}
+			if err != nil {
+				ctxLogger.Error("Failed to fetch agent info: %v", err)
+				ctxLogger.APICall("/my/agent", 0, duration.String())
+				return []mcp.ResourceContents{
+					&mcp.TextResourceContents{
+						URI:      request.Params.URI,
+						MIMEType: "text/plain",
+						Text:     "Error fetching agent info: " + err.Error(),
+					},
+				}, nil
+			}
 
-		ctxLogger.APICall("/my/agent", 200, duration.String())
-		ctxLogger.Info("Successfully retrieved agent info for: %s", agent.Symbol)
+			ctxLogger.APICall("/my/agent", 200, duration.String())
+			ctxLogger.Info("Successfully retrieved agent info for: %s", fetched.Symbol)
+			agent = fetched
+		}
 
 		// Format the response as structured JSON
-		result := map[string]interface{}{
-			"agent": map[string]interface{}{
-				"accountId":       agent.AccountID,
-				"symbol":          agent.Symbol,
-				"headquarters":    agent.Headquarters,
-				"credits":         agent.Credits,
-				"startingFaction": agent.StartingFaction,
-				"shipCount":       agent.ShipCount,
-			},
+		data := map[string]interface{}{
+			"accountId":       agent.AccountID,
+			"symbol":          agent.Symbol,
+			"headquarters":    agent.Headquarters,
+			"credits":         agent.Credits,
+			"startingFaction": agent.StartingFaction,
+			"shipCount":       agent.ShipCount,
+		}
+		links := []Link{
+			{Rel: "ships", URI: "spacetraders://ships/list"},
+			{Rel: "contracts", URI: "spacetraders://contracts/list"},
+			{Rel: "dashboard", URI: dashboardResourceURI},
+		}
+		result := liveEnvelope(data, 1, links...)
+		if fromModel {
+			result = localEnvelope(data, 1, seenAt, r.fleet.Diverged(), links...)
 		}
 
 		// Convert to JSON for response
@@ -0,0 +1,177 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	spacetraders "github.com/grantmd/spacetraders-mcp/spacetraders"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	tradeGoodsCatalogURI = "spacetraders://catalog/trade-goods"
+	shipTypesCatalogURI  = "spacetraders://catalog/ship-types"
+)
+
+// CatalogResource lists every value of a game enumeration the API accepts, with names and
+// descriptions taken from markets and shipyards fetched so far. Reading it makes no API call.
+type CatalogResource struct {
+	client      *client.Client
+	logger      *logging.Logger
+	uri         string
+	name        string
+	description string
+	key         string
+	entries     func(*client.Client) []catalogEntry
+}
+
+// catalogEntry is one value of an enumeration; the name and description appear once the server
+// has seen the value in an API response
+type catalogEntry struct {
+	Symbol      string `json:"symbol"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// SoldAt lists the shipyards known to sell a ship type
+	SoldAt []string `json:"soldAt,omitempty"`
+}
+
+// NewTradeGoodsCatalogResource creates a resource listing every trade symbol
+func NewTradeGoodsCatalogResource(client *client.Client, logger *logging.Logger) *CatalogResource {
+	return &CatalogResource{
+		client:      client,
+		logger:      logger,
+		uri:         tradeGoodsCatalogURI,
+		name:        "Trade Goods Catalog",
+		description: "Every trade symbol the API accepts, with the name and description of each good seen at a market so far. Use it to check a good's symbol before calling a tool; reading it makes no API call.",
+		key:         "tradeGoods",
+		entries:     tradeGoodEntries,
+	}
+}
+
+// NewShipTypesCatalogResource creates a resource listing every ship type
+func NewShipTypesCatalogResource(client *client.Client, logger *logging.Logger) *CatalogResource {
+	return &CatalogResource{
+		client:      client,
+		logger:      logger,
+		uri:         shipTypesCatalogURI,
+		name:        "Ship Types Catalog",
+		description: "Every ship type the API accepts, with the name and description of each type seen at a shipyard so far and the shipyards known to sell it. Use it to check a ship type before calling a tool; reading it makes no API call.",
+		key:         "shipTypes",
+		entries:     shipTypeEntries,
+	}
+}
+
+// tradeGoodEntries lists the trade symbols with the names markets gave them
+func tradeGoodEntries(c *client.Client) []catalogEntry {
+	known := c.KnownTradeGoods()
+	entries := make([]catalogEntry, 0, len(spacetraders.AllowedTradeSymbolEnumValues))
+	for _, symbol := range spacetraders.AllowedTradeSymbolEnumValues {
+		entry := catalogEntry{Symbol: string(symbol)}
+		if good, ok := known[entry.Symbol]; ok {
+			entry.Name = good.Name
+			entry.Description = good.Description
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// shipTypeEntries lists the ship types with the names and shipyards of the listings seen so far
+func shipTypeEntries(c *client.Client) []catalogEntry {
+	entries := make([]catalogEntry, 0, len(spacetraders.AllowedShipTypeEnumValues))
+	index := make(map[string]int, len(spacetraders.AllowedShipTypeEnumValues))
+	for _, shipType := range spacetraders.AllowedShipTypeEnumValues {
+		index[string(shipType)] = len(entries)
+		entries = append(entries, catalogEntry{Symbol: string(shipType)})
+	}
+
+	// Shipyards come sorted by waypoint, so each type's list of shipyards is too
+	for _, listing := range c.KnownShipyards() {
+		for _, ship := range listing.Shipyard.Ships {
+			i, ok := index[ship.Type]
+			if !ok {
+				continue
+			}
+			entries[i].Name = ship.Name
+			entries[i].Description = ship.Description
+		}
+		for _, shipType := range listing.Shipyard.ShipTypes {
+			if i, ok := index[shipType.Type]; ok {
+				entries[i].SoldAt = append(entries[i].SoldAt, listing.Shipyard.Symbol)
+			}
+		}
+	}
+	return entries
+}
+
+// Resource returns the MCP resource definition
+func (r *CatalogResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         r.uri,
+		Name:        r.name,
+		Description: r.description,
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *CatalogResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != r.uri {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "catalog-resource")
+
+		entries := r.entries(r.client)
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Symbol < entries[j].Symbol
+		})
+		described := 0
+		for _, entry := range entries {
+			if entry.Name != "" {
+				described++
+			}
+		}
+
+		// The symbols come from the API specification the client was generated from; names are
+		// filled in from responses already cached
+		result := cachedEnvelope(map[string]interface{}{
+			r.key:       entries,
+			"described": described,
+		}, len(entries), time.Now())
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal catalog to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting catalog",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"context"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/completion"
+	"spacetraders-mcp/pkg/fleetstate"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CompletionProvider suggests values for resource template parameters such as
+// {shipSymbol} and {systemSymbol}, based on the agent's fleet and contracts
+type CompletionProvider struct {
+	client *client.Client
+	fleet  *fleetstate.Model
+}
+
+// NewCompletionProvider creates a new resource template completion provider
+func NewCompletionProvider(client *client.Client) *CompletionProvider {
+	return &CompletionProvider{
+		client: client,
+	}
+}
+
+// WithFleetState completes ships and systems from the local fleet model while it is fresh,
+// instead of listing the fleet from the API on every request
+func (p *CompletionProvider) WithFleetState(model *fleetstate.Model) *CompletionProvider {
+	p.fleet = model
+	return p
+}
+
+// CompleteResourceArgument returns the known values of a template parameter that start
+// with what has been typed so far. Unknown parameters and API failures complete to nothing.
+func (p *CompletionProvider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	candidates := completion.Candidates(p.client.WithContext(ctx), p.fleet, argument.Name, completeContext.Arguments)
+	return completion.Filter(candidates, argument.Value), nil
+}
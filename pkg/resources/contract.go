@@ -0,0 +1,149 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// contractURIPattern matches spacetraders://contracts/{contractId}
+var contractURIPattern = regexp.MustCompile(`^spacetraders://contracts/([A-Za-z0-9]+)$`)
+
+// ContractResource handles individual contract resources
+type ContractResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewContractResource creates a new contract resource handler
+func NewContractResource(client *client.Client, logger *logging.Logger) *ContractResource {
+	return &ContractResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *ContractResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         "spacetraders://contracts/{contractId}",
+		Name:        "Contract Details",
+		Description: "A single contract with its terms and delivery progress, for checking progress without fetching the full contracts list",
+		MIMEType:    "application/json",
+	}
+}
+
+// ResourceTemplate returns the parameterized contract resource
+func (r *ContractResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://contracts/{contractId}",
+		"Contract Details",
+		mcp.WithTemplateDescription("A single contract by ID with its terms and delivery progress"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// deliveryProgress summarizes how much of one contract delivery is done
+type deliveryProgress struct {
+	TradeSymbol       string `json:"tradeSymbol"`
+	DestinationSymbol string `json:"destinationSymbol"`
+	UnitsRequired     int    `json:"unitsRequired"`
+	UnitsFulfilled    int    `json:"unitsFulfilled"`
+	UnitsRemaining    int    `json:"unitsRemaining"`
+	PercentComplete   int    `json:"percentComplete"`
+}
+
+// Handler returns the resource handler function
+func (r *ContractResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		matches := contractURIPattern.FindStringSubmatch(request.Params.URI)
+		if len(matches) != 2 || matches[1] == "list" {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid contract resource URI. Expected format: spacetraders://contracts/{contractId}",
+				},
+			}, nil
+		}
+		contractID := matches[1]
+
+		ctxLogger := r.logger.WithContext(ctx, "contract-resource")
+		ctxLogger.Debug("Fetching contract %s", contractID)
+
+		start := time.Now()
+		contract, err := r.client.WithContext(ctx).GetContract(contractID)
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch contract %s: %v", contractID, err)
+			ctxLogger.APICall(fmt.Sprintf("/my/contracts/%s", contractID), 0, duration.String())
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Error fetching contract %s: %s", contractID, err.Error()),
+				},
+			}, nil
+		}
+
+		ctxLogger.APICall(fmt.Sprintf("/my/contracts/%s", contractID), 200, duration.String())
+
+		result := liveEnvelope(map[string]interface{}{
+			"contract": contract,
+			"progress": contractProgress(*contract),
+		}, 1,
+			Link{Rel: "contracts", URI: "spacetraders://contracts/list"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal contract data to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting contract information",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Contract resource response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// contractProgress reports delivery progress for each good in a contract
+func contractProgress(contract client.Contract) []deliveryProgress {
+	progress := make([]deliveryProgress, 0, len(contract.Terms.Deliver))
+	for _, deliver := range contract.Terms.Deliver {
+		entry := deliveryProgress{
+			TradeSymbol:       deliver.TradeSymbol,
+			DestinationSymbol: deliver.DestinationSymbol,
+			UnitsRequired:     deliver.UnitsRequired,
+			UnitsFulfilled:    deliver.UnitsFulfilled,
+			UnitsRemaining:    max(0, deliver.UnitsRequired-deliver.UnitsFulfilled),
+			PercentComplete:   100,
+		}
+		if deliver.UnitsRequired > 0 {
+			entry.PercentComplete = min(100, deliver.UnitsFulfilled*100/deliver.UnitsRequired)
+		}
+		progress = append(progress, entry)
+	}
+	return progress
+}
@@ -55,7 +55,7 @@ func (r *ContractsResource) Handler() func(ctx context.Context, request mcp.Read
 
 		// Get contracts information from the API
 		start := time.Now()
-		contracts, err := r.client.GetAllContracts()
+		contracts, err := r.client.WithContext(ctx).GetAllContracts()
 		duration := time.Since(start)
 
 		if err != nil {
@@ -74,12 +74,9 @@ func (r *ContractsResource) Handler() func(ctx context.Context, request mcp.Read
 		ctxLogger.Info("Successfully retrieved %d contracts", len(contracts))
 
 		// Format the response as structured JSON
-		result := map[string]interface{}{
-			"contracts": contracts,
-			"meta": map[string]interface{}{
-				"count": len(contracts),
-			},
-		}
+		result := liveEnvelope(contracts, len(contracts),
+			Link{Rel: "contract", URI: "spacetraders://contracts/{contractId}"},
+		)
 
 		// Convert to JSON for response
 		jsonData, err := json.MarshalIndent(result, "", "  ")
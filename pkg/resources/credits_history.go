@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const creditsHistoryResourceURI = "spacetraders://agent/credits-history"
+
+// creditsHistoryHours is how many hourly periods the resource summarizes
+const creditsHistoryHours = 24
+
+// CreditsHistoryResource exposes the agent's credit balance over time
+type CreditsHistoryResource struct {
+	history *credits.History
+	logger  *logging.Logger
+}
+
+// NewCreditsHistoryResource creates a new credits history resource handler
+func NewCreditsHistoryResource(history *credits.History, logger *logging.Logger) *CreditsHistoryResource {
+	return &CreditsHistoryResource{
+		history: history,
+		logger:  logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *CreditsHistoryResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         creditsHistoryResourceURI,
+		Name:        "Credits History",
+		Description: "The agent's credit balance over time, sampled on every API response that includes the agent since the server started, oldest first, with the change in each of the last 24 hours and the overall trend. Use the credits_trend tool for daily periods or another window.",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *CreditsHistoryResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != creditsHistoryResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "credits-history-resource")
+
+		now := time.Now()
+		samples := r.history.Samples(time.Time{})
+		data := map[string]interface{}{
+			"samples": samples,
+			"hourly":  r.history.Buckets(time.Hour, now.Add(-creditsHistoryHours*time.Hour), now),
+		}
+		if trend, ok := r.history.Trend(time.Time{}); ok {
+			data["trend"] = trend
+		}
+
+		// Samples are recorded by the server as responses arrive, so the history is always current
+		result := cachedEnvelope(data, len(samples), now,
+			Link{Rel: "agent", URI: "spacetraders://agent/info"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal credits history to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting credits history",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
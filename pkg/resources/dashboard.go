@@ -0,0 +1,253 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const dashboardResourceURI = "spacetraders://dashboard"
+
+const (
+	// dashboardRecentEntries is how many of the latest ledger entries the dashboard lists
+	dashboardRecentEntries = 10
+	// dashboardLedgerWindow is the period the dashboard's profit totals cover
+	dashboardLedgerWindow = time.Hour
+)
+
+// DashboardResource aggregates agent, fleet, contracts, tasks and recent transactions into one document
+type DashboardResource struct {
+	client  *client.Client
+	ledger  *ledger.Ledger
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewDashboardResource creates a new dashboard resource handler. The ledger and task manager are
+// optional; their sections are left out when nil.
+func NewDashboardResource(client *client.Client, ledger *ledger.Ledger, manager *tasks.Manager, logger *logging.Logger) *DashboardResource {
+	return &DashboardResource{
+		client:  client,
+		ledger:  ledger,
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *DashboardResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         dashboardResourceURI,
+		Name:        "Dashboard",
+		Description: "Everything needed to decide what to do next in one compact read: agent credits, fleet status, active contracts with delivery progress, running background tasks and recent transactions",
+		MIMEType:    "application/json",
+	}
+}
+
+// dashboardContract is an accepted contract with its delivery progress
+type dashboardContract struct {
+	ID          string                  `json:"id"`
+	Type        string                  `json:"type"`
+	Deadline    string                  `json:"deadline"`
+	OnFulfilled int                     `json:"onFulfilled"`
+	Progress    int                     `json:"progressPercent"`
+	Deliveries  []dashboardContractGood `json:"deliveries,omitempty"`
+}
+
+// dashboardContractGood is one delivery requirement of a contract
+type dashboardContractGood struct {
+	TradeSymbol string `json:"tradeSymbol"`
+	Destination string `json:"destination"`
+	Fulfilled   int    `json:"fulfilled"`
+	Required    int    `json:"required"`
+}
+
+// dashboardTask is a running background task
+type dashboardTask struct {
+	ID          string    `json:"id"`
+	ShipSymbol  string    `json:"shipSymbol"`
+	Behavior    string    `json:"behavior"`
+	LastMessage string    `json:"lastMessage,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+	NextRunAt   time.Time `json:"nextRunAt,omitzero"`
+}
+
+// Handler returns the resource handler function
+func (r *DashboardResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != dashboardResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "dashboard-resource")
+		c := r.client.WithContext(ctx)
+		now := time.Now()
+
+		// Each section reports its own error so one failing call does not hide the rest
+		result := map[string]interface{}{}
+
+		if agent, err := c.GetAgent(); err != nil {
+			ctxLogger.Error("Failed to fetch agent: %v", err)
+			result["agent"] = map[string]interface{}{"error": err.Error()}
+		} else {
+			result["agent"] = map[string]interface{}{
+				"symbol":       agent.Symbol,
+				"credits":      agent.Credits,
+				"headquarters": agent.Headquarters,
+				"shipCount":    agent.ShipCount,
+			}
+		}
+
+		if ships, err := c.GetAllShips(); err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			result["fleet"] = map[string]interface{}{"error": err.Error()}
+		} else {
+			rows := make([]fleetSummaryRow, 0, len(ships))
+			statusCounts := make(map[string]int)
+			for _, ship := range ships {
+				row := summarizeShip(ship, activeBehavior(r.manager, ship.Symbol), now)
+				statusCounts[row.Status]++
+				rows = append(rows, row)
+			}
+			result["fleet"] = map[string]interface{}{
+				"count":    len(rows),
+				"byStatus": statusCounts,
+				"ships":    rows,
+			}
+		}
+
+		if contracts, err := c.GetAllContracts(); err != nil {
+			ctxLogger.Error("Failed to fetch contracts: %v", err)
+			result["contracts"] = map[string]interface{}{"error": err.Error()}
+		} else {
+			active := make([]dashboardContract, 0)
+			offered, fulfilled := 0, 0
+			for _, contract := range contracts {
+				switch {
+				case contract.Fulfilled:
+					fulfilled++
+				case !contract.Accepted:
+					offered++
+				default:
+					active = append(active, summarizeContract(contract))
+				}
+			}
+			result["contracts"] = map[string]interface{}{
+				"active":    active,
+				"offered":   offered,
+				"fulfilled": fulfilled,
+			}
+		}
+
+		if r.manager != nil {
+			running := make([]dashboardTask, 0)
+			for _, task := range r.manager.List() {
+				if !task.Active() {
+					continue
+				}
+				running = append(running, dashboardTask{
+					ID:          task.ID,
+					ShipSymbol:  task.ShipSymbol,
+					Behavior:    task.Behavior,
+					LastMessage: task.LastMessage,
+					LastError:   task.LastError,
+					NextRunAt:   task.NextRunAt,
+				})
+			}
+			result["tasks"] = map[string]interface{}{
+				"running": running,
+			}
+		}
+
+		if r.ledger != nil {
+			entries := r.ledger.Query(ledger.Filter{})
+			recent := entries[max(0, len(entries)-dashboardRecentEntries):]
+			window := r.ledger.Query(ledger.Filter{Since: now.Add(-dashboardLedgerWindow)})
+			totals := ledger.ComputeTotals(window)
+			result["ledger"] = map[string]interface{}{
+				"recent": recent,
+				"lastHour": map[string]interface{}{
+					"income":       totals.Income,
+					"expense":      totals.Expense,
+					"net":          totals.Net,
+					"transactions": len(window),
+				},
+			}
+		}
+
+		envelope := newEnvelope(result, 1, sourceLive, now,
+			Link{Rel: "agent", URI: "spacetraders://agent/info"},
+			Link{Rel: "fleet_summary", URI: fleetSummaryResourceURI},
+			Link{Rel: "contracts", URI: "spacetraders://contracts/list"},
+		)
+		if r.manager != nil {
+			envelope.Links = append(envelope.Links, Link{Rel: "tasks", URI: tasksResourceURI})
+		}
+		if r.ledger != nil {
+			envelope.Links = append(envelope.Links, Link{Rel: "ledger", URI: ledgerResourceURI})
+		}
+
+		// Not indented: the dashboard is meant to be read in one go, so it is kept small
+		jsonData, err := json.Marshal(envelope)
+		if err != nil {
+			ctxLogger.Error("Failed to marshal dashboard to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting dashboard",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Dashboard response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// summarizeContract condenses an accepted contract to its deliveries and overall progress
+func summarizeContract(contract client.Contract) dashboardContract {
+	summary := dashboardContract{
+		ID:          contract.ID,
+		Type:        contract.Type,
+		Deadline:    contract.Terms.Deadline,
+		OnFulfilled: contract.Terms.Payment.OnFulfilled,
+	}
+
+	required, fulfilled := 0, 0
+	for _, delivery := range contract.Terms.Deliver {
+		summary.Deliveries = append(summary.Deliveries, dashboardContractGood{
+			TradeSymbol: delivery.TradeSymbol,
+			Destination: delivery.DestinationSymbol,
+			Fulfilled:   delivery.UnitsFulfilled,
+			Required:    delivery.UnitsRequired,
+		})
+		required += delivery.UnitsRequired
+		fulfilled += min(delivery.UnitsFulfilled, delivery.UnitsRequired)
+	}
+	if required > 0 {
+		summary.Progress = fulfilled * 100 / required
+	}
+	return summary
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// detailQuery is the URI template query expression accepted by resources with detail levels
+const detailQuery = "{?detail}"
+
+// detailLevel is how much of the API payload a resource returns
+type detailLevel string
+
+const (
+	// detailFull returns the complete API payload
+	detailFull detailLevel = "full"
+	// detailSummary trims descriptions, requirements and other fields that cost context
+	// without helping decide what to do next
+	detailSummary detailLevel = "summary"
+)
+
+// verboseFields are dropped from every object in a summary
+var verboseFields = []string{"description", "requirements"}
+
+// detailFromQuery reads the detail parameter, which defaults to full
+func detailFromQuery(query url.Values) (detailLevel, error) {
+	switch detail := detailLevel(query.Get("detail")); detail {
+	case "", detailFull:
+		return detailFull, nil
+	case detailSummary:
+		return detailSummary, nil
+	default:
+		return "", fmt.Errorf("detail must be %s or %s", detailSummary, detailFull)
+	}
+}
+
+// summarize returns data with the verbose fields, and any extra fields the resource names,
+// removed from every object however deeply nested
+func summarize(data interface{}, extra ...string) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+
+	drop := append(slices.Clone(verboseFields), extra...)
+	return trimFields(decoded, drop), nil
+}
+
+// trimFields removes the named keys from every object in a decoded JSON value
+func trimFields(value interface{}, drop []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if slices.Contains(drop, key) {
+				delete(v, key)
+				continue
+			}
+			v[key] = trimFields(child, drop)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = trimFields(child, drop)
+		}
+	}
+	return value
+}
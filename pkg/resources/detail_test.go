@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDetailFromQuery(t *testing.T) {
+	for query, expected := range map[string]detailLevel{
+		"":               detailFull,
+		"detail=full":    detailFull,
+		"detail=summary": detailSummary,
+	} {
+		values, _ := url.ParseQuery(query)
+		detail, err := detailFromQuery(values)
+		if err != nil || detail != expected {
+			t.Errorf("Expected %q to give %s, got %s (%v)", query, expected, detail, err)
+		}
+	}
+
+	if _, err := detailFromQuery(url.Values{"detail": {"terse"}}); err == nil {
+		t.Error("Expected an error for an unknown detail level")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	reactor := client.Reactor{
+		Symbol:       "REACTOR_FISSION_I",
+		Description:  "A basic fission power reactor",
+		Requirements: client.ShipRequirements{Crew: 8},
+	}
+
+	summary, err := summarize(map[string]interface{}{
+		"reactor":      reactor,
+		"transactions": []string{"a"},
+	}, "transactions")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data := summary.(map[string]interface{})
+	if _, ok := data["transactions"]; ok {
+		t.Error("Expected the extra field to be dropped")
+	}
+	trimmed := data["reactor"].(map[string]interface{})
+	if trimmed["symbol"] != "REACTOR_FISSION_I" {
+		t.Errorf("Expected the symbol to be kept, got %v", trimmed)
+	}
+	if _, ok := trimmed["description"]; ok {
+		t.Error("Expected the nested description to be dropped")
+	}
+	if _, ok := trimmed["requirements"]; ok {
+		t.Error("Expected the nested requirements to be dropped")
+	}
+}
+
+func TestMarketResource_Handler_Summary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": {
+			"symbol": "X1-TEST-A1",
+			"exports": [{"symbol": "FUEL", "name": "Fuel", "description": "Refined hydrocarbons"}],
+			"imports": [],
+			"exchange": [],
+			"transactions": [{"waypointSymbol": "X1-TEST-A1", "shipSymbol": "SHIP-1", "tradeSymbol": "FUEL", "type": "PURCHASE", "units": 10, "pricePerUnit": 70, "totalPrice": 700, "timestamp": "2025-01-01T00:00:00Z"}],
+			"tradeGoods": [{"symbol": "FUEL", "type": "EXPORT", "tradeVolume": 100, "supply": "HIGH", "purchasePrice": 72, "sellPrice": 68}]
+		}}`)
+	}))
+	defer server.Close()
+
+	resource := NewMarketResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	uri := "spacetraders://systems/X1-TEST/waypoints/X1-TEST-A1/market?detail=summary"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected market template to match %s", uri)
+	}
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("Expected only the JSON content in a summary, got %d contents", len(contents))
+	}
+
+	var result struct {
+		Market map[string]interface{} `json:"market"`
+	}
+	if _, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &result); err != nil {
+		t.Fatalf("Invalid envelope: %v", err)
+	}
+	if _, ok := result.Market["transactions"]; ok {
+		t.Error("Expected transactions to be dropped from the summary")
+	}
+	exports := result.Market["exports"].([]interface{})
+	if export := exports[0].(map[string]interface{}); export["symbol"] != "FUEL" || export["description"] != nil {
+		t.Errorf("Expected the export without its description, got %v", export)
+	}
+	if goods := result.Market["trade_goods"].([]interface{}); len(goods) != 1 {
+		t.Errorf("Expected trade goods to be kept, got %v", goods)
+	}
+}
@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"time"
+)
+
+// Sources of the data in a resource read
+const (
+	// sourceLive means the data was fetched from the API for this read
+	sourceLive = "live"
+	// sourceCache means the data was already held by the server: an API response cached
+	// earlier, or records the server keeps itself such as the ledger
+	sourceCache = "cache"
+	// sourceLocal means the data comes from the server's model of the fleet, kept current by
+	// applying the results of every action and reconciled with the API at fetched_at
+	sourceLocal = "local"
+)
+
+// Envelope is the shape every resource returns: the payload under data, facts about the
+// read under meta, and related resources under links
+type Envelope struct {
+	Data  interface{} `json:"data"`
+	Meta  Meta        `json:"meta"`
+	Links []Link      `json:"links,omitempty"`
+}
+
+// Meta describes the data of a resource read
+type Meta struct {
+	// Count is the number of items the data covers: the entries of its main list, or 1 for a single object
+	Count     int    `json:"count"`
+	FetchedAt string `json:"fetched_at"`
+	// AgeSeconds is how long before the read the data was fetched, so stale data is obvious
+	// without comparing clocks; live data is 0 seconds old
+	AgeSeconds int    `json:"age_seconds"`
+	Source     string `json:"source"`
+	// Pagination is set when the read asked for one page of a list resource
+	Pagination *Pagination `json:"pagination,omitempty"`
+	// Diverged is set on local reads when the last reconciliation found the local model had
+	// drifted from the API, a sign something else is acting on the account
+	Diverged bool `json:"diverged,omitempty"`
+}
+
+// Link points to a related resource. URI is a template, such as
+// spacetraders://ships/{shipSymbol}, when the link leads to a family of resources.
+type Link struct {
+	Rel string `json:"rel"`
+	URI string `json:"uri"`
+}
+
+// liveEnvelope wraps data fetched from the API for this read
+func liveEnvelope(data interface{}, count int, links ...Link) Envelope {
+	return newEnvelope(data, count, sourceLive, time.Now(), links...)
+}
+
+// cachedEnvelope wraps data the server already held, fetched or recorded at fetchedAt
+func cachedEnvelope(data interface{}, count int, fetchedAt time.Time, links ...Link) Envelope {
+	return newEnvelope(data, count, sourceCache, fetchedAt, links...)
+}
+
+// fetchedEnvelope wraps data from a cache that fetches from the API when it is empty or stale,
+// marking it live when the fetch happened for this read, which began at start
+func fetchedEnvelope(data interface{}, count int, fetchedAt, start time.Time, links ...Link) Envelope {
+	if fetchedAt.Before(start) {
+		return cachedEnvelope(data, count, fetchedAt, links...)
+	}
+	return newEnvelope(data, count, sourceLive, fetchedAt, links...)
+}
+
+// localEnvelope wraps data answered from the local fleet model, last reconciled at syncedAt
+func localEnvelope(data interface{}, count int, syncedAt time.Time, diverged bool, links ...Link) Envelope {
+	e := newEnvelope(data, count, sourceLocal, syncedAt, links...)
+	e.Meta.Diverged = diverged
+	return e
+}
+
+// withPagination records that the envelope holds one page of a longer list
+func (e Envelope) withPagination(p Pagination) Envelope {
+	e.Meta.Pagination = &p
+	return e
+}
+
+// newEnvelope wraps data from a source
+func newEnvelope(data interface{}, count int, source string, fetchedAt time.Time, links ...Link) Envelope {
+	return Envelope{
+		Data: data,
+		Meta: Meta{
+			Count:      count,
+			FetchedAt:  fetchedAt.UTC().Format(time.RFC3339),
+			AgeSeconds: max(0, int(time.Since(fetchedAt).Seconds())),
+			Source:     source,
+		},
+		Links: links,
+	}
+}
@@ -0,0 +1,144 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/events"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/session"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// decodeEnvelope checks that text is a resource envelope and decodes its data into data
+func decodeEnvelope(text string, data interface{}) (Meta, error) {
+	var envelope struct {
+		Data  json.RawMessage `json:"data"`
+		Meta  *Meta           `json:"meta"`
+		Links []Link          `json:"links"`
+	}
+	if err := json.Unmarshal([]byte(text), &envelope); err != nil {
+		return Meta{}, err
+	}
+	if len(envelope.Data) == 0 || string(envelope.Data) == "null" {
+		return Meta{}, fmt.Errorf("envelope has no data: %s", text)
+	}
+	if envelope.Meta == nil {
+		return Meta{}, fmt.Errorf("envelope has no meta: %s", text)
+	}
+	meta := *envelope.Meta
+	if meta.Count < 0 {
+		return meta, fmt.Errorf("negative count %d", meta.Count)
+	}
+	if _, err := time.Parse(time.RFC3339, meta.FetchedAt); err != nil {
+		return meta, fmt.Errorf("fetched_at %q is not RFC 3339: %w", meta.FetchedAt, err)
+	}
+	if meta.AgeSeconds < 0 {
+		return meta, fmt.Errorf("negative age %d", meta.AgeSeconds)
+	}
+	if meta.Source != sourceLive && meta.Source != sourceCache && meta.Source != sourceLocal {
+		return meta, fmt.Errorf("source %q is none of %s, %s or %s", meta.Source, sourceLive, sourceCache, sourceLocal)
+	}
+	for _, link := range envelope.Links {
+		if link.Rel == "" || link.URI == "" {
+			return meta, fmt.Errorf("incomplete link %+v", link)
+		}
+	}
+	return meta, json.Unmarshal(envelope.Data, data)
+}
+
+func TestNewEnvelope(t *testing.T) {
+	fetchedAt := time.Date(2025, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	envelope := cachedEnvelope([]string{"a", "b"}, 2, fetchedAt, Link{Rel: "dashboard", URI: dashboardResourceURI})
+
+	text, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Failed to marshal envelope: %v", err)
+	}
+
+	var data []string
+	meta, err := decodeEnvelope(string(text), &data)
+	if err != nil {
+		t.Fatalf("Invalid envelope: %v", err)
+	}
+	if len(data) != 2 || meta.Count != 2 || meta.Source != sourceCache {
+		t.Errorf("Unexpected envelope %s", text)
+	}
+	if meta.FetchedAt != "2025-03-01T11:30:00Z" {
+		t.Errorf("Expected fetched_at in UTC, got %s", meta.FetchedAt)
+	}
+	if want := int(time.Since(fetchedAt).Seconds()); meta.AgeSeconds < want-1 || meta.AgeSeconds > want+1 {
+		t.Errorf("Expected age_seconds near %d, got %d", want, meta.AgeSeconds)
+	}
+
+	if text, _ := json.Marshal(liveEnvelope(map[string]int{}, 1)); contains(string(text), "links") {
+		t.Errorf("Expected links to be omitted when there are none, got %s", text)
+	}
+}
+
+// TestResources_EnvelopeContract reads every concrete resource and checks it returns an envelope
+func TestResources_EnvelopeContract(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/agent":
+			fmt.Fprint(w, `{"data": {"accountId": "test-account", "symbol": "TEST_AGENT", "headquarters": "X1-TEST-HQ", "credits": 50000, "startingFaction": "COSMIC", "shipCount": 0}}`)
+		case "/market/supply-chain":
+			fmt.Fprint(w, `{"data": {"exportToImports": {"FUEL": ["HYDROCARBON"]}}}`)
+		default:
+			// Every list is empty, which is also the case most likely to produce a null
+			fmt.Fprint(w, `{"data": [], "meta": {"total": 0, "page": 1, "limit": 20}}`)
+		}
+	}))
+	defer server.Close()
+
+	tracker, err := explorer.Open(filepath.Join(t.TempDir(), "exploration.json"))
+	if err != nil {
+		t.Fatalf("Failed to open exploration tracker: %v", err)
+	}
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registry := NewRegistry(c, createMockLogger(),
+		WithLedger(ledger.New()),
+		WithTasks(tasks.NewManager(ctx, c, createMockLogger())),
+		WithExplorer(tracker),
+		WithMining(mining.NewRecorder()),
+		WithSession(session.NewRecorder()),
+		WithEvents(events.New()),
+	)
+
+	for _, handler := range registry.handlers {
+		uri := handler.Resource().URI
+		if isTemplateURI(uri) {
+			continue
+		}
+		t.Run(uri, func(t *testing.T) {
+			contents, err := handler.Handler()(context.Background(), mcp.ReadResourceRequest{
+				Params: mcp.ReadResourceParams{URI: uri},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			textContent, ok := contents[0].(*mcp.TextResourceContents)
+			if !ok || textContent.MIMEType != "application/json" {
+				t.Fatalf("Expected JSON text content, got %+v", contents[0])
+			}
+			var data interface{}
+			if _, err := decodeEnvelope(textContent.Text, &data); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"spacetraders-mcp/pkg/events"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const recentEventsResourceURI = "spacetraders://events/recent"
+
+// RecentEventsResource exposes ship condition events reported by navigation and extraction,
+// and the ships that have accumulated enough of them to need a repair
+type RecentEventsResource struct {
+	log    *events.Log
+	logger *logging.Logger
+}
+
+// NewRecentEventsResource creates a new recent events resource handler
+func NewRecentEventsResource(log *events.Log, logger *logging.Logger) *RecentEventsResource {
+	return &RecentEventsResource{
+		log:    log,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *RecentEventsResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         recentEventsResourceURI,
+		Name:        "Recent Ship Events",
+		Description: fmt.Sprintf("Component damage and other condition events from navigation and extraction since the server started, newest first, with the events each ship has accumulated; ships with %d or more are flagged for repair", events.DamageThreshold),
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *RecentEventsResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != recentEventsResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "recent-events-resource")
+
+		recent := r.log.Recent(0)
+		flagged := r.log.Flagged()
+		data := map[string]interface{}{
+			"events":          recent,
+			"ships":           r.log.Ships(),
+			"flagged":         flagged,
+			"damageThreshold": events.DamageThreshold,
+		}
+		if len(flagged) > 0 {
+			data["advice"] = "Flagged ships have taken repeated component damage; check get_repair_cost and repair_ship at a shipyard before their condition affects travel and extraction"
+		}
+
+		// Events are recorded by the server as they happen, so the log is always current
+		result := cachedEnvelope(data, len(recent), time.Now(),
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal recent events to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting recent events",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
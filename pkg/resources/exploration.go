@@ -0,0 +1,121 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const explorationResourceURI = "spacetraders://exploration/progress"
+
+// explorationNearbyLimit is how many uncharted waypoints are listed for each ship
+const explorationNearbyLimit = 5
+
+// ExplorationResource exposes which systems and waypoints have been explored
+type ExplorationResource struct {
+	client  *client.Client
+	tracker *explorer.Tracker
+	logger  *logging.Logger
+}
+
+// NewExplorationResource creates a new exploration progress resource handler
+func NewExplorationResource(client *client.Client, tracker *explorer.Tracker, logger *logging.Logger) *ExplorationResource {
+	return &ExplorationResource{
+		client:  client,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *ExplorationResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         explorationResourceURI,
+		Name:        "Exploration Progress",
+		Description: "How many systems and waypoints have been visited, scanned and charted, remembered across sessions, plus the uncharted waypoints nearest each ship",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *ExplorationResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != explorationResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "exploration-resource")
+
+		c := r.client.WithContext(ctx)
+		ships, err := c.GetAllShips()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error fetching ships: " + err.Error(),
+				},
+			}, nil
+		}
+
+		nearby, err := r.tracker.SuggestForShips(c, ships, explorationNearbyLimit, explorer.ReasonUncharted)
+		if err != nil {
+			ctxLogger.Error("Failed to list waypoints near ships: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error listing waypoints near ships: " + err.Error(),
+				},
+			}, nil
+		}
+
+		data := map[string]interface{}{
+			"summary":         r.tracker.Summary(),
+			"unchartedNearby": nearby,
+			"file":            r.tracker.Path(),
+		}
+		if err := r.tracker.SaveError(); err != nil {
+			data["saveError"] = err.Error()
+		}
+		// Progress is recorded by the server, but the nearby waypoints are looked up from live ship positions
+		result := liveEnvelope(data, len(nearby),
+			Link{Rel: "ships", URI: "spacetraders://ships/list"},
+			Link{Rel: "waypoints", URI: "spacetraders://systems/{systemSymbol}/waypoints"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal exploration progress to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting exploration progress",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FactionReputationResource handles the agent's per-faction reputation resource
+type FactionReputationResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewFactionReputationResource creates a new faction reputation resource handler
+func NewFactionReputationResource(client *client.Client, logger *logging.Logger) *FactionReputationResource {
+	return &FactionReputationResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *FactionReputationResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         "spacetraders://factions/reputation",
+		Name:        "Faction Reputation",
+		Description: "Your agent's reputation with each faction, highest first, for weighing contract and waypoint decisions",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *FactionReputationResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		// Validate the resource URI
+		if request.Params.URI != "spacetraders://factions/reputation" {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "faction-reputation-resource")
+		ctxLogger.Debug("Fetching faction reputation from API")
+
+		start := time.Now()
+		reputations, err := r.client.WithContext(ctx).GetMyFactions()
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch faction reputation: %v", err)
+			ctxLogger.APICall("/my/factions", 0, duration.String())
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error fetching faction reputation: " + err.Error(),
+				},
+			}, nil
+		}
+
+		ctxLogger.APICall("/my/factions", 200, duration.String())
+
+		// Highest standing first so the factions worth working for lead the list
+		sort.SliceStable(reputations, func(i, j int) bool {
+			return reputations[i].Reputation > reputations[j].Reputation
+		})
+		if reputations == nil {
+			reputations = []client.FactionReputation{}
+		}
+
+		result := liveEnvelope(reputations, len(reputations),
+			Link{Rel: "faction", URI: "spacetraders://factions/{factionSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal faction reputation data to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting faction reputation",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Faction reputation resource response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/travel"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -27,16 +28,26 @@ func NewFactionsResource(client *client.Client, logger *logging.Logger) *Faction
 	}
 }
 
-// Resource returns the MCP resource definition
+// Resource returns the MCP resource definition for the full faction list
 func (r *FactionsResource) Resource() mcp.Resource {
 	return mcp.Resource{
-		URI:         "spacetraders://factions/*",
+		URI:         "spacetraders://factions",
 		Name:        "Factions Data",
-		Description: "Factions information - use 'spacetraders://factions' for all factions or 'spacetraders://factions/{factionSymbol}' for specific faction details",
+		Description: "All factions - use the 'spacetraders://factions/{factionSymbol}' template for a specific faction",
 		MIMEType:    "application/json",
 	}
 }
 
+// ResourceTemplate returns the parameterized resource for a specific faction
+func (r *FactionsResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://factions/{factionSymbol}",
+		"Faction Details",
+		mcp.WithTemplateDescription("Details of a specific faction by symbol"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *FactionsResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
@@ -67,7 +78,7 @@ func (r *FactionsResource) handleFactionsList(ctx context.Context, request mcp.R
 
 	// Get factions from the API
 	start := time.Now()
-	factions, err := r.client.GetAllFactions()
+	factions, err := r.client.WithContext(ctx).GetAllFactions()
 	duration := time.Since(start)
 
 	if err != nil {
@@ -86,13 +97,10 @@ func (r *FactionsResource) handleFactionsList(ctx context.Context, request mcp.R
 	ctxLogger.Info("Successfully retrieved %d factions", len(factions))
 
 	// Format the response
-	result := map[string]interface{}{
-		"factions": r.formatFactionsList(factions),
-		"meta": map[string]interface{}{
-			"total":     len(factions),
-			"retrieved": time.Now().UTC().Format(time.RFC3339),
-		},
-	}
+	result := liveEnvelope(r.formatFactionsList(factions), len(factions),
+		Link{Rel: "faction", URI: "spacetraders://factions/{factionSymbol}"},
+		Link{Rel: "reputation", URI: "spacetraders://factions/reputation"},
+	)
 
 	// Convert to JSON
 	jsonData, err := json.MarshalIndent(result, "", "  ")
@@ -138,7 +146,7 @@ func (r *FactionsResource) handleSpecificFaction(ctx context.Context, request mc
 
 	// Get faction details from the API
 	start := time.Now()
-	faction, err := r.client.GetFaction(factionSymbol)
+	faction, err := r.client.WithContext(ctx).GetFaction(factionSymbol)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -157,11 +165,11 @@ func (r *FactionsResource) handleSpecificFaction(ctx context.Context, request mc
 	ctxLogger.Info("Successfully retrieved faction details for: %s", factionSymbol)
 
 	// Format the response
-	result := map[string]interface{}{
-		"faction": r.formatFactionDetails(faction),
-		"meta": map[string]interface{}{
-			"retrieved": time.Now().UTC().Format(time.RFC3339),
-		},
+	result := liveEnvelope(r.formatFactionDetails(faction), 1,
+		Link{Rel: "factions", URI: "spacetraders://factions"},
+	)
+	if faction.Headquarters != "" {
+		result.Links = append(result.Links, Link{Rel: "headquarters", URI: "spacetraders://systems/" + travel.SystemSymbol(faction.Headquarters)})
 	}
 
 	// Convert to JSON
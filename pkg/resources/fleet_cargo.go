@@ -0,0 +1,262 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const fleetCargoResourceURI = "spacetraders://fleet/cargo"
+
+// FleetCargoResource adds up the cargo held across every ship
+type FleetCargoResource struct {
+	client *client.Client
+	meta   *shipmeta.Store
+	logger *logging.Logger
+}
+
+// NewFleetCargoResource creates a new fleet cargo resource handler
+func NewFleetCargoResource(client *client.Client, logger *logging.Logger) *FleetCargoResource {
+	return &FleetCargoResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithShipMeta adds each ship's label to its row
+func (r *FleetCargoResource) WithShipMeta(store *shipmeta.Store) *FleetCargoResource {
+	r.meta = store
+	return r
+}
+
+// Resource returns the MCP resource definition
+func (r *FleetCargoResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         fleetCargoResourceURI,
+		Name:        "Fleet Cargo Manifest",
+		Description: "Cargo across the whole fleet: total units of each good and which ships hold them, free capacity per ship, and how much of each accepted contract's remaining delivery is already aboard",
+		MIMEType:    "application/json",
+	}
+}
+
+// cargoHolder is one ship's share of a good
+type cargoHolder struct {
+	ShipSymbol string `json:"shipSymbol"`
+	Units      int    `json:"units"`
+	Waypoint   string `json:"waypoint"`
+}
+
+// cargoGood is the fleet's total of one good
+type cargoGood struct {
+	Symbol     string        `json:"symbol"`
+	Name       string        `json:"name"`
+	TotalUnits int           `json:"totalUnits"`
+	Holders    []cargoHolder `json:"holders"`
+}
+
+// cargoShip is one ship's hold
+type cargoShip struct {
+	Symbol   string `json:"symbol"`
+	Label    string `json:"label,omitempty"`
+	Waypoint string `json:"waypoint"`
+	Status   string `json:"status"`
+	Capacity int    `json:"capacity"`
+	Units    int    `json:"units"`
+	Free     int    `json:"free"`
+}
+
+// cargoContractNeed compares what a contract still needs with what the fleet holds
+type cargoContractNeed struct {
+	ContractID     string `json:"contractId"`
+	TradeSymbol    string `json:"tradeSymbol"`
+	Destination    string `json:"destination"`
+	UnitsRemaining int    `json:"unitsRemaining"`
+	UnitsHeld      int    `json:"unitsHeld"`
+	// Shortfall is how many more units must be bought or mined; 0 when the fleet holds enough
+	Shortfall int `json:"shortfall"`
+}
+
+// fleetCargo is the fleet cargo manifest
+type fleetCargo struct {
+	Goods  []cargoGood `json:"goods"`
+	Ships  []cargoShip `json:"ships"`
+	Totals struct {
+		Capacity int `json:"capacity"`
+		Units    int `json:"units"`
+		Free     int `json:"free"`
+	} `json:"totals"`
+	ContractNeeds []cargoContractNeed `json:"contractNeeds"`
+	// ContractNeedsError says why contract needs are missing when contracts couldn't be fetched
+	ContractNeedsError string `json:"contractNeedsError,omitempty"`
+}
+
+// Handler returns the resource handler function
+func (r *FleetCargoResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != fleetCargoResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "fleet-cargo-resource")
+
+		start := time.Now()
+		ships, err := r.client.WithContext(ctx).GetAllShips()
+		duration := time.Since(start)
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships info: %v", err)
+			ctxLogger.APICall("/my/ships", 0, duration.String())
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error fetching ships info: " + err.Error(),
+				},
+			}, nil
+		}
+		ctxLogger.APICall("/my/ships", 200, duration.String())
+
+		manifest := buildFleetCargo(ships, r.meta)
+
+		// Contract needs are extra; the manifest is still useful without them
+		contracts, err := r.client.WithContext(ctx).GetAllContracts()
+		if err != nil {
+			ctxLogger.Warn("Failed to fetch contracts for fleet cargo: %v", err)
+			manifest.ContractNeedsError = err.Error()
+		} else {
+			manifest.ContractNeeds = contractNeeds(contracts, manifest.Goods)
+		}
+
+		result := liveEnvelope(manifest, len(manifest.Goods),
+			Link{Rel: "ship_cargo", URI: "spacetraders://ships/{shipSymbol}/cargo"},
+			Link{Rel: "fleet_summary", URI: fleetSummaryResourceURI},
+			Link{Rel: "contracts", URI: "spacetraders://contracts/list"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal fleet cargo to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting fleet cargo",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Fleet cargo response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// buildFleetCargo adds up the goods in every ship's hold, most units first, and lists each ship's
+// free capacity
+func buildFleetCargo(ships []client.Ship, store *shipmeta.Store) fleetCargo {
+	var manifest fleetCargo
+	manifest.Ships = make([]cargoShip, 0, len(ships))
+	goods := make(map[string]*cargoGood)
+
+	for _, ship := range ships {
+		row := cargoShip{
+			Symbol:   ship.Symbol,
+			Waypoint: ship.Nav.WaypointSymbol,
+			Status:   ship.Nav.Status,
+			Capacity: ship.Cargo.Capacity,
+			Units:    ship.Cargo.Units,
+			Free:     max(ship.Cargo.Capacity-ship.Cargo.Units, 0),
+		}
+		if store != nil {
+			if meta, ok := store.Get(ship.Symbol); ok {
+				row.Label = meta.Label
+			}
+		}
+		manifest.Ships = append(manifest.Ships, row)
+		manifest.Totals.Capacity += row.Capacity
+		manifest.Totals.Units += row.Units
+		manifest.Totals.Free += row.Free
+
+		for _, item := range ship.Cargo.Inventory {
+			if item.Units <= 0 {
+				continue
+			}
+			good, ok := goods[item.Symbol]
+			if !ok {
+				good = &cargoGood{Symbol: item.Symbol, Name: item.Name}
+				goods[item.Symbol] = good
+			}
+			good.TotalUnits += item.Units
+			good.Holders = append(good.Holders, cargoHolder{
+				ShipSymbol: ship.Symbol,
+				Units:      item.Units,
+				Waypoint:   ship.Nav.WaypointSymbol,
+			})
+		}
+	}
+
+	manifest.Goods = make([]cargoGood, 0, len(goods))
+	for _, good := range goods {
+		sort.SliceStable(good.Holders, func(i, j int) bool {
+			return good.Holders[i].Units > good.Holders[j].Units
+		})
+		manifest.Goods = append(manifest.Goods, *good)
+	}
+	sort.Slice(manifest.Goods, func(i, j int) bool {
+		if manifest.Goods[i].TotalUnits != manifest.Goods[j].TotalUnits {
+			return manifest.Goods[i].TotalUnits > manifest.Goods[j].TotalUnits
+		}
+		return manifest.Goods[i].Symbol < manifest.Goods[j].Symbol
+	})
+	return manifest
+}
+
+// contractNeeds compares the remaining deliveries of accepted, unfulfilled contracts with the
+// units the fleet holds
+func contractNeeds(contracts []client.Contract, goods []cargoGood) []cargoContractNeed {
+	held := make(map[string]int, len(goods))
+	for _, good := range goods {
+		held[good.Symbol] = good.TotalUnits
+	}
+
+	needs := make([]cargoContractNeed, 0)
+	for _, contract := range contracts {
+		if !contract.Accepted || contract.Fulfilled {
+			continue
+		}
+		for _, deliver := range contract.Terms.Deliver {
+			remaining := deliver.UnitsRequired - deliver.UnitsFulfilled
+			if remaining <= 0 {
+				continue
+			}
+			needs = append(needs, cargoContractNeed{
+				ContractID:     contract.ID,
+				TradeSymbol:    deliver.TradeSymbol,
+				Destination:    deliver.DestinationSymbol,
+				UnitsRemaining: remaining,
+				UnitsHeld:      held[deliver.TradeSymbol],
+				Shortfall:      max(remaining-held[deliver.TradeSymbol], 0),
+			})
+		}
+	}
+	return needs
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const fleetStateResourceURI = "spacetraders://fleet/state"
+
+// FleetStateResource reports how current the local fleet model is and where it last drifted
+// from the API
+type FleetStateResource struct {
+	model  *fleetstate.Model
+	logger *logging.Logger
+}
+
+// NewFleetStateResource creates a new fleet state resource handler
+func NewFleetStateResource(model *fleetstate.Model, logger *logging.Logger) *FleetStateResource {
+	return &FleetStateResource{
+		model:  model,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *FleetStateResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         fleetStateResourceURI,
+		Name:        "Local Fleet State",
+		Description: "How current the server's local model of the fleet and agent is: when it was last reconciled with the API, how many action results were applied since, and the latest places where it had drifted from the API. Ship, ship part and agent reads are answered from this model while it is fresh, marked with source \"local\".",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *FleetStateResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != fleetStateResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "fleet-state-resource")
+
+		status := r.model.Status(time.Now())
+		data := map[string]interface{}{
+			"state": status,
+		}
+		if status.Diverged {
+			data["advice"] = "The last reconciliation found the local model had drifted from the API, so something outside this server may be acting on the account; expect local reads to lag until the next reconciliation"
+		}
+
+		result := cachedEnvelope(data, len(status.Divergences), time.Now(),
+			Link{Rel: "ships", URI: "spacetraders://ships/list"},
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal fleet state to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting fleet state",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,256 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const fleetSummaryResourceURI = "spacetraders://fleet/summary"
+
+// FleetSummaryResource condenses all ships into a compact table with derived status
+type FleetSummaryResource struct {
+	client *client.Client
+	meta   *shipmeta.Store
+	tasks  *tasks.Manager
+	logger *logging.Logger
+}
+
+// NewFleetSummaryResource creates a new fleet summary resource handler
+func NewFleetSummaryResource(client *client.Client, logger *logging.Logger) *FleetSummaryResource {
+	return &FleetSummaryResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithShipMeta adds each ship's label and tags to its row and allows filtering by tag
+func (r *FleetSummaryResource) WithShipMeta(store *shipmeta.Store) *FleetSummaryResource {
+	r.meta = store
+	return r
+}
+
+// WithTasks adds the background task driving each ship to its row and status
+func (r *FleetSummaryResource) WithTasks(manager *tasks.Manager) *FleetSummaryResource {
+	r.tasks = manager
+	return r
+}
+
+// Resource returns the MCP resource definition
+func (r *FleetSummaryResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         fleetSummaryResourceURI,
+		Name:        "Fleet Summary",
+		Description: "Compact one-row-per-ship overview of the fleet: label, tags, role, location, a status derived from navigation, cooldown, cargo, fuel and background task (e.g. 'mining, cooling down', 'idle, cargo full', 'in transit'), fuel %, cargo %, cooldown remaining and route ETA",
+		MIMEType:    "application/json",
+	}
+}
+
+// ResourceTemplate returns the form of the fleet summary filtered to ships with a tag
+func (r *FleetSummaryResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		fleetSummaryResourceURI+"{?tag}",
+		"Fleet Summary by Tag",
+		mcp.WithTemplateDescription("Fleet summary of only the ships carrying a tag set with tag_ship (e.g., ?tag=mining)"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// fleetSummaryRow is a single ship in the fleet summary
+type fleetSummaryRow struct {
+	Symbol           string   `json:"symbol"`
+	Label            string   `json:"label,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	Role             string   `json:"role"`
+	System           string   `json:"system"`
+	Waypoint         string   `json:"waypoint"`
+	Status           string   `json:"status"`
+	NavStatus        string   `json:"navStatus"`
+	Task             string   `json:"task,omitempty"`
+	FuelPercent      int      `json:"fuelPercent"`
+	CargoPercent     int      `json:"cargoPercent"`
+	CooldownSeconds  int      `json:"cooldownSeconds"`
+	Destination      string   `json:"destination,omitempty"`
+	ArrivalInSeconds *int     `json:"arrivalInSeconds,omitempty"`
+}
+
+// Handler returns the resource handler function
+func (r *FleetSummaryResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		// Validate the resource URI
+		uri, query, err := splitQuery(request.Params.URI, "tag")
+		if err != nil || uri != fleetSummaryResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+		tag := shipmeta.NormalizeTag(query.Get("tag"))
+		if query.Has("tag") && (tag == "" || r.meta == nil) {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid tag filter: give a tag set with tag_ship, such as ?tag=mining",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "fleet-summary-resource")
+		ctxLogger.Debug("Fetching ships for fleet summary")
+
+		start := time.Now()
+		ships, err := r.client.WithContext(ctx).GetAllShips()
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships info: %v", err)
+			ctxLogger.APICall("/my/ships", 0, duration.String())
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error fetching ships info: " + err.Error(),
+				},
+			}, nil
+		}
+
+		ctxLogger.APICall("/my/ships", 200, duration.String())
+
+		now := time.Now()
+		rows := make([]fleetSummaryRow, 0, len(ships))
+		statusCounts := make(map[string]int)
+		for _, ship := range ships {
+			row := summarizeShip(ship, activeBehavior(r.tasks, ship.Symbol), now)
+			if r.meta != nil {
+				meta, _ := r.meta.Get(ship.Symbol)
+				if tag != "" && !meta.HasTag(tag) {
+					continue
+				}
+				row.Label = meta.Label
+				row.Tags = meta.Tags
+			}
+			statusCounts[row.Status]++
+			rows = append(rows, row)
+		}
+
+		result := newEnvelope(map[string]interface{}{
+			"ships":    rows,
+			"byStatus": statusCounts,
+		}, len(rows), sourceLive, now,
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+			Link{Rel: "ships", URI: "spacetraders://ships/list"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal fleet summary to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting fleet summary",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Fleet summary response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// taskActivities describes what a ship running each background behavior is doing
+var taskActivities = map[string]string{
+	"mine_loop":     "mining",
+	"trade_loop":    "trading",
+	"contract_haul": "hauling",
+	"market_scan":   "scanning markets",
+	"rescue":        "rescuing",
+}
+
+// activeBehavior returns the behavior of the background task driving a ship, or "" when none is
+func activeBehavior(manager *tasks.Manager, shipSymbol string) string {
+	if manager == nil {
+		return ""
+	}
+	if task, ok := manager.ActiveTask(shipSymbol); ok {
+		return task.Behavior
+	}
+	return ""
+}
+
+// lowFuelPercent is the fuel level below which a ship's status warns about fuel
+const lowFuelPercent = 25
+
+// summarizeShip derives the compact status row for a ship, given the behavior of the
+// background task driving it, if any
+func summarizeShip(ship client.Ship, behavior string, now time.Time) fleetSummaryRow {
+	row := fleetSummaryRow{
+		Symbol:          ship.Symbol,
+		Role:            ship.Registration.Role,
+		System:          ship.Nav.SystemSymbol,
+		Waypoint:        ship.Nav.WaypointSymbol,
+		NavStatus:       ship.Nav.Status,
+		Task:            behavior,
+		FuelPercent:     ship.FuelPercent(),
+		CargoPercent:    ship.CargoPercent(),
+		CooldownSeconds: int(ship.CooldownRemaining(now).Round(time.Second) / time.Second),
+	}
+	row.Status = shipStatus(ship, behavior, row.CooldownSeconds)
+
+	if ship.Nav.Status == "IN_TRANSIT" {
+		row.Destination = ship.Nav.Route.Destination.Symbol
+		if arrivalIn, known := ship.ArrivalIn(now); known {
+			seconds := int(arrivalIn.Round(time.Second) / time.Second)
+			row.ArrivalInSeconds = &seconds
+		}
+	}
+
+	return row
+}
+
+// shipStatus describes what a ship is doing, followed by anything holding it up, such as
+// "mining, cooling down" or "idle, cargo full"
+func shipStatus(ship client.Ship, behavior string, cooldownSeconds int) string {
+	activity := "idle"
+	switch {
+	case ship.Nav.Status == "IN_TRANSIT":
+		activity = "in transit"
+	case behavior != "":
+		activity = taskActivities[behavior]
+		if activity == "" {
+			activity = "running " + behavior
+		}
+	}
+
+	parts := []string{activity}
+	if cooldownSeconds > 0 {
+		parts = append(parts, "cooling down")
+	}
+	if ship.Cargo.Capacity > 0 && ship.Cargo.Units >= ship.Cargo.Capacity {
+		parts = append(parts, "cargo full")
+	}
+	if ship.Fuel.Capacity > 0 && ship.FuelPercent() < lowFuelPercent {
+		parts = append(parts, "low fuel")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const fuelIndexResourceURI = "spacetraders://markets/fuel-index"
+
+// FuelIndexResource exposes the cheapest known places to refuel in each system
+type FuelIndexResource struct {
+	prices *prices.DB
+	logger *logging.Logger
+}
+
+// NewFuelIndexResource creates a new fuel price index resource handler
+func NewFuelIndexResource(db *prices.DB, logger *logging.Logger) *FuelIndexResource {
+	return &FuelIndexResource{
+		prices: db,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *FuelIndexResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         fuelIndexResourceURI,
+		Name:        "Fuel Price Index",
+		Description: "The latest recorded fuel price at every market known to sell fuel, by system and cheapest first, with how old each price is",
+		MIMEType:    "application/json",
+	}
+}
+
+// fuelStation is one market's latest fuel price
+type fuelStation struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	PurchasePrice  int       `json:"purchasePrice"`
+	Supply         string    `json:"supply,omitempty"`
+	TradeVolume    int       `json:"tradeVolume"`
+	ObservedAt     time.Time `json:"observedAt"`
+	AgeSeconds     int       `json:"ageSeconds"`
+}
+
+// systemFuelIndex is the fuel stations of one system, cheapest first
+type systemFuelIndex struct {
+	SystemSymbol string        `json:"systemSymbol"`
+	Cheapest     fuelStation   `json:"cheapest"`
+	Stations     []fuelStation `json:"stations"`
+}
+
+// Handler returns the resource handler function
+func (r *FuelIndexResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != fuelIndexResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "fuel-index-resource")
+
+		now := time.Now()
+		index := r.prices.FuelIndex()
+		systems := make([]systemFuelIndex, 0, len(index))
+		for system, quotes := range index {
+			entry := systemFuelIndex{SystemSymbol: system, Stations: make([]fuelStation, 0, len(quotes))}
+			for _, quote := range quotes {
+				entry.Stations = append(entry.Stations, fuelStation{
+					WaypointSymbol: quote.WaypointSymbol,
+					PurchasePrice:  quote.PurchasePrice,
+					Supply:         quote.Supply,
+					TradeVolume:    quote.TradeVolume,
+					ObservedAt:     quote.ObservedAt,
+					AgeSeconds:     int(now.Sub(quote.ObservedAt).Seconds()),
+				})
+			}
+			entry.Cheapest = entry.Stations[0]
+			systems = append(systems, entry)
+		}
+		sort.Slice(systems, func(i, j int) bool {
+			return systems[i].SystemSymbol < systems[j].SystemSymbol
+		})
+
+		// Prices are recorded whenever a market is viewed with a ship present; each station carries its own age
+		result := cachedEnvelope(map[string]interface{}{
+			"systems": systems,
+		}, len(systems), now,
+			Link{Rel: "market", URI: "spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/market"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal fuel index to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting fuel index",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// JumpGateGraphResource handles the jump gate network resource
+type JumpGateGraphResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewJumpGateGraphResource creates a new jump gate graph resource handler
+func NewJumpGateGraphResource(client *client.Client, logger *logging.Logger) *JumpGateGraphResource {
+	return &JumpGateGraphResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *JumpGateGraphResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         "spacetraders://universe/jumpgate-graph",
+		Name:        "Jump Gate Graph",
+		Description: "Connectivity graph of the known jump gate network, crawled from the gates in your fleet's systems and previously seen systems. Gates that are uncharted or beyond the crawl limit are listed as unexplored.",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *JumpGateGraphResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		// Validate the resource URI
+		if request.Params.URI != "spacetraders://universe/jumpgate-graph" {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "jumpgate-graph-resource")
+		ctxLogger.Debug("Crawling jump gate graph")
+
+		c := r.client.WithContext(ctx)
+		start := time.Now()
+		seeds, err := fleetJumpGates(c)
+		if err != nil {
+			ctxLogger.Error("Failed to find fleet jump gates: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error finding jump gates: " + err.Error(),
+				},
+			}, nil
+		}
+		seeds = append(seeds, c.KnownJumpGates()...)
+
+		graph := travel.CrawlGates(seeds, travel.DefaultGateCrawlLimit, func(gate string) ([]string, error) {
+			jumpGate, err := c.GetCachedJumpGate(travel.SystemSymbol(gate), gate)
+			if err != nil {
+				return nil, err
+			}
+			return jumpGate.Connections, nil
+		}, nil)
+		ctxLogger.Debug("Crawled %d jump gates in %s", len(graph.Connections), time.Since(start))
+
+		// Gates already in the jump gate cache are not fetched again, but the fleet's gates and
+		// any gate not seen before are looked up for this read
+		result := liveEnvelope(map[string]interface{}{
+			"connections": graph.Connections,
+			"unexplored":  graph.Unexplored,
+			"systems":     graph.Systems(),
+			"crawlLimit":  travel.DefaultGateCrawlLimit,
+		}, len(graph.Connections),
+			Link{Rel: "system", URI: "spacetraders://systems/{systemSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal jump gate graph to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting jump gate graph",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Jump gate graph resource response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// fleetJumpGates returns the jump gates in the systems the agent's ships are in, so the graph is
+// useful before any system has been looked at
+func fleetJumpGates(c *client.Client) ([]string, error) {
+	ships, err := c.GetAllShips()
+	if err != nil {
+		return nil, err
+	}
+
+	var gates []string
+	seen := make(map[string]bool)
+	for _, ship := range ships {
+		if seen[ship.Nav.SystemSymbol] {
+			continue
+		}
+		seen[ship.Nav.SystemSymbol] = true
+
+		waypoints, _, err := c.GetCachedSystemWaypoints(ship.Nav.SystemSymbol)
+		if err != nil {
+			return nil, err
+		}
+		for _, waypoint := range waypoints {
+			if waypoint.Type == "JUMP_GATE" {
+				gates = append(gates, waypoint.Symbol)
+			}
+		}
+	}
+	return gates, nil
+}
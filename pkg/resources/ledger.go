@@ -0,0 +1,148 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const ledgerResourceURI = "spacetraders://ledger/transactions"
+
+// LedgerResource exposes the transactions ledger accumulated by the server
+type LedgerResource struct {
+	ledger *ledger.Ledger
+	logger *logging.Logger
+}
+
+// NewLedgerResource creates a new ledger resource handler
+func NewLedgerResource(l *ledger.Ledger, logger *logging.Logger) *LedgerResource {
+	return &LedgerResource{
+		ledger: l,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *LedgerResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         ledgerResourceURI,
+		Name:        "Transactions Ledger",
+		Description: "All market, shipyard, repair, refuel and contract payment transactions observed by this server, with net profit/loss per ship and per good",
+		MIMEType:    "application/json",
+	}
+}
+
+// ResourceTemplate returns the filterable variant of the ledger resource
+func (r *LedgerResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		ledgerResourceURI+"{?ship,waypoint,since,until}",
+		"Filtered Transactions Ledger",
+		mcp.WithTemplateDescription("Ledger entries filtered by ship symbol, waypoint symbol, and URL-encoded RFC3339 time range (since/until)"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// Handler returns the resource handler function
+func (r *LedgerResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		ctxLogger := r.logger.WithContext(ctx, "ledger-resource")
+
+		filter, err := parseLedgerFilter(request.Params.URI)
+		if err != nil {
+			ctxLogger.Error("Invalid ledger URI %s: %v", request.Params.URI, err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid ledger resource URI: " + err.Error(),
+				},
+			}, nil
+		}
+
+		entries := r.ledger.Query(filter)
+
+		// The ledger is recorded by the server as transactions happen, so it is always current
+		result := cachedEnvelope(map[string]interface{}{
+			"entries": entries,
+			"totals":  ledger.ComputeTotals(entries),
+			"filter": map[string]interface{}{
+				"ship":     filter.ShipSymbol,
+				"waypoint": filter.WaypointSymbol,
+				"since":    formatFilterTime(filter.Since),
+				"until":    formatFilterTime(filter.Until),
+			},
+			"totalEntries": r.ledger.Len(),
+		}, len(entries), time.Now(),
+			Link{Rel: "filtered", URI: ledgerResourceURI + "{?ship,waypoint,since,until}"},
+			Link{Rel: "dashboard", URI: dashboardResourceURI},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal ledger data to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting ledger information",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Ledger resource response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// parseLedgerFilter builds a ledger filter from the query parameters of a ledger URI
+func parseLedgerFilter(uri string) (ledger.Filter, error) {
+	var filter ledger.Filter
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return filter, err
+	}
+	if parsed.Scheme+"://"+parsed.Host+parsed.Path != ledgerResourceURI {
+		return filter, fmt.Errorf("expected %s", ledgerResourceURI)
+	}
+
+	query := parsed.Query()
+	filter.ShipSymbol = query.Get("ship")
+	filter.WaypointSymbol = query.Get("waypoint")
+
+	if since := query.Get("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return filter, fmt.Errorf("since must be an RFC3339 timestamp")
+		}
+	}
+	if until := query.Get("until"); until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return filter, fmt.Errorf("until must be an RFC3339 timestamp")
+		}
+	}
+
+	return filter, nil
+}
+
+// formatFilterTime formats an optional filter bound
+func formatFilterTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -2,12 +2,15 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -16,6 +19,7 @@ import (
 type MarketResource struct {
 	client *client.Client
 	logger *logging.Logger
+	prices *prices.DB
 }
 
 // NewMarketResource creates a new market resource
@@ -26,6 +30,12 @@ func NewMarketResource(client *client.Client, logger *logging.Logger) *MarketRes
 	}
 }
 
+// WithPrices fills in the latest recorded prices when no ship is at the market to see them live
+func (r *MarketResource) WithPrices(db *prices.DB) *MarketResource {
+	r.prices = db
+	return r
+}
+
 // Resource returns the MCP resource definition
 func (r *MarketResource) Resource() mcp.Resource {
 	return mcp.Resource{
@@ -36,21 +46,46 @@ func (r *MarketResource) Resource() mcp.Resource {
 	}
 }
 
+// ResourceTemplate returns the parameterized form of the market resource
+func (r *MarketResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/market{?detail,refresh}",
+		"Market Data",
+		mcp.WithTemplateDescription("Market prices and trade goods at a waypoint, by system and waypoint symbol. detail=summary drops good descriptions, recent transactions and the markdown rendering; detail=full (the default) keeps the complete payload. Prices are only listed while one of your ships is there; otherwise the latest recorded prices are served from the cache, with their age. refresh=true returns only what the API shows now"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *MarketResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 		contextLogger := r.logger.WithContext(ctx, "market-resource")
 
-		// Parse URI to extract system and waypoint symbols
+		// Parse URI to extract system and waypoint symbols and the detail level
 		uri := request.Params.URI
-		if !strings.HasPrefix(uri, "spacetraders://systems/") {
+		path, query, err := splitQuery(uri, "detail", refreshParam)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Invalid market URI query: %s", uri))
+			return []mcp.ResourceContents{}, fmt.Errorf("invalid market URI: %w", err)
+		}
+		detail, err := detailFromQuery(query)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Invalid market URI query: %s", uri))
+			return []mcp.ResourceContents{}, fmt.Errorf("invalid market URI: %w", err)
+		}
+		refresh, err := refreshFromQuery(query)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Invalid market URI query: %s", uri))
+			return []mcp.ResourceContents{}, fmt.Errorf("invalid market URI: %w", err)
+		}
+		if !strings.HasPrefix(path, "spacetraders://systems/") {
 			contextLogger.Error(fmt.Sprintf("Invalid URI format: %s", uri))
 			return []mcp.ResourceContents{}, fmt.Errorf("invalid URI format")
 		}
 
 		// Extract system and waypoint symbols from URI
 		// Format: spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/market
-		parts := strings.Split(strings.TrimPrefix(uri, "spacetraders://systems/"), "/")
+		parts := strings.Split(strings.TrimPrefix(path, "spacetraders://systems/"), "/")
 		if len(parts) != 4 || parts[1] != "waypoints" || parts[3] != "market" {
 			contextLogger.Error(fmt.Sprintf("Invalid market URI format: %s", uri))
 			return []mcp.ResourceContents{}, fmt.Errorf("invalid market URI format")
@@ -62,7 +97,8 @@ func (r *MarketResource) Handler() func(ctx context.Context, request mcp.ReadRes
 		contextLogger.Debug(fmt.Sprintf("Fetching market data for %s at %s from API", waypointSymbol, systemSymbol))
 
 		// Get market data from the API
-		market, err := r.client.GetMarket(systemSymbol, waypointSymbol)
+		start := time.Now()
+		market, err := r.client.WithContext(ctx).GetMarket(systemSymbol, waypointSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to fetch market data for %s: %v", waypointSymbol, err))
 			return []mcp.ResourceContents{}, fmt.Errorf("failed to fetch market data: %w", err)
@@ -70,8 +106,20 @@ func (r *MarketResource) Handler() func(ctx context.Context, request mcp.ReadRes
 
 		contextLogger.Info(fmt.Sprintf("Successfully retrieved market data for %s at %s", waypointSymbol, systemSymbol))
 
-		// Create the resource content
-		content := map[string]interface{}{
+		// Without a ship present there are no prices, so the latest recorded ones are filled in,
+		// marked as cached with their age
+		fetchedAt := start
+		if len(market.TradeGoods) == 0 && !refresh && r.prices != nil {
+			if snapshot, ok := r.prices.Latest(waypointSymbol); ok {
+				contextLogger.Debug(fmt.Sprintf("No ship at %s, serving prices recorded at %s", waypointSymbol, snapshot.ObservedAt.Format(time.RFC3339)))
+				recorded := *market
+				recorded.TradeGoods = tradeGoodsFromPrices(snapshot.Prices)
+				market, fetchedAt = &recorded, snapshot.ObservedAt
+			}
+		}
+
+		// Create the resource content; summaries leave out descriptions and the transaction log
+		var data interface{} = map[string]interface{}{
 			"system":   systemSymbol,
 			"waypoint": waypointSymbol,
 			"market": map[string]interface{}{
@@ -84,17 +132,45 @@ func (r *MarketResource) Handler() func(ctx context.Context, request mcp.ReadRes
 			},
 			"analysis": r.analyzeMarket(market),
 		}
+		if detail == detailSummary {
+			if data, err = summarize(data, "transactions"); err != nil {
+				contextLogger.Error(fmt.Sprintf("Failed to summarize market data for %s: %v", waypointSymbol, err))
+				return []mcp.ResourceContents{}, fmt.Errorf("failed to format market data: %w", err)
+			}
+		}
+		content := fetchedEnvelope(data, 1, fetchedAt, start,
+			Link{Rel: "waypoints", URI: "spacetraders://systems/" + systemSymbol + "/waypoints"},
+			Link{Rel: "supply_chain", URI: "spacetraders://markets/supply-chain"},
+		)
+
+		jsonData, err := json.MarshalIndent(content, "", "  ")
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to marshal market data for %s: %v", waypointSymbol, err))
+			return []mcp.ResourceContents{}, fmt.Errorf("failed to format market data: %w", err)
+		}
 
 		contextLogger.Info("Resource read successful: " + uri)
-		contextLogger.Debug(fmt.Sprintf("Market resource response size: %d bytes", len(fmt.Sprintf("%+v", content))))
+		contextLogger.Debug(fmt.Sprintf("Market resource response size: %d bytes", len(jsonData)))
 
-		return []mcp.ResourceContents{
+		contents := []mcp.ResourceContents{
 			&mcp.TextResourceContents{
 				URI:      uri,
 				MIMEType: "application/json",
-				Text:     r.formatMarketAsText(market, systemSymbol, waypointSymbol),
+				Text:     string(jsonData),
 			},
-		}, nil
+		}
+		if detail == detailSummary {
+			return contents, nil
+		}
+
+		// The same market as readable text follows the JSON for clients that show resources as-is
+		return append(contents,
+			&mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "text/markdown",
+				Text:     r.formatMarketAsText(market, systemSymbol, waypointSymbol, fetchedAt.Before(start), fetchedAt),
+			},
+		), nil
 	}
 }
 
@@ -129,6 +205,23 @@ func (r *MarketResource) formatTradeGoodsWithPrices(goods []client.MarketTradeGo
 	return result
 }
 
+// tradeGoodsFromPrices turns recorded prices back into the market's trade goods
+func tradeGoodsFromPrices(recorded []prices.Price) []client.MarketTradeGood {
+	goods := make([]client.MarketTradeGood, 0, len(recorded))
+	for _, price := range recorded {
+		goods = append(goods, client.MarketTradeGood{
+			Symbol:        price.TradeSymbol,
+			Type:          price.Type,
+			TradeVolume:   price.TradeVolume,
+			Supply:        price.Supply,
+			Activity:      price.Activity,
+			PurchasePrice: price.PurchasePrice,
+			SellPrice:     price.SellPrice,
+		})
+	}
+	return goods
+}
+
 // formatTransactions formats recent transactions
 func (r *MarketResource) formatTransactions(transactions []client.MarketTransaction) []map[string]interface{} {
 	var result []map[string]interface{}
@@ -190,8 +283,9 @@ func (r *MarketResource) analyzeMarket(market *client.Market) map[string]interfa
 	return analysis
 }
 
-// formatMarketAsText creates a human-readable text representation
-func (r *MarketResource) formatMarketAsText(market *client.Market, systemSymbol, waypointSymbol string) string {
+// formatMarketAsText creates a human-readable text representation; recorded says the prices were
+// recorded at pricesAt rather than seen live
+func (r *MarketResource) formatMarketAsText(market *client.Market, systemSymbol, waypointSymbol string, recorded bool, pricesAt time.Time) string {
 	var text strings.Builder
 
 	fmt.Fprintf(&text, "# Market Data: %s\n\n", waypointSymbol)
@@ -227,7 +321,11 @@ func (r *MarketResource) formatMarketAsText(market *client.Market, systemSymbol,
 
 	// Trade Goods with Prices
 	if len(market.TradeGoods) > 0 {
-		text.WriteString("## 💰 Current Prices\n\n")
+		if recorded {
+			fmt.Fprintf(&text, "## 💰 Recorded Prices (%s, %s ago)\n\n", pricesAt.UTC().Format(time.RFC3339), time.Since(pricesAt).Round(time.Minute))
+		} else {
+			text.WriteString("## 💰 Current Prices\n\n")
+		}
 
 		// Sort by sell price descending for better readability
 		sortedGoods := make([]client.MarketTradeGood, len(market.TradeGoods))
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const miningStatsResourceURI = "spacetraders://mining/stats"
+
+// MiningStatsResource exposes extraction yields per ship and per waypoint
+type MiningStatsResource struct {
+	recorder *mining.Recorder
+	logger   *logging.Logger
+}
+
+// NewMiningStatsResource creates a new mining statistics resource handler
+func NewMiningStatsResource(recorder *mining.Recorder, logger *logging.Logger) *MiningStatsResource {
+	return &MiningStatsResource{
+		recorder: recorder,
+		logger:   logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *MiningStatsResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         miningStatsResourceURI,
+		Name:        "Mining Statistics",
+		Description: "Yield of every extraction since the server started, per ship and per waypoint, best first: units per hour, units per extraction with and without surveys, and units of each good",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *MiningStatsResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != miningStatsResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "mining-stats-resource")
+
+		extractions := r.recorder.Extractions(time.Time{})
+		stats := mining.ComputeStats(extractions)
+
+		// Extractions are recorded by the server as they happen, so the stats are always current
+		result := cachedEnvelope(map[string]interface{}{
+			"total":      stats.Total,
+			"byShip":     stats.ByShip,
+			"byWaypoint": stats.ByWaypoint,
+		}, len(extractions), time.Now(),
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal mining stats to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting mining stats",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
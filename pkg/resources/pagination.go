@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// pageQuery is the URI template query expression accepted by paged list resources
+const pageQuery = "{?page,limit,cursor}"
+
+// pageRequest is a slice of a list resource asked for through its URI query
+type pageRequest struct {
+	Page  int
+	Limit int
+}
+
+// Pagination describes the slice of a list a paged resource read returned
+type Pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Total is the number of items across all pages
+	Total int `json:"total"`
+	// NextCursor reads the following page when passed as the cursor parameter; it is
+	// omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// pageParams are the query parameters that select a page of a list resource
+var pageParams = []string{"page", "limit", "cursor"}
+
+// splitPageQuery separates a resource URI from its page, limit and cursor query parameters.
+// The returned request is nil when the URI asks for no particular page, in which case the
+// resource returns the whole list.
+func splitPageQuery(uri string) (string, *pageRequest, error) {
+	base, query, err := splitQuery(uri, pageParams...)
+	if err != nil {
+		return base, nil, err
+	}
+	page, err := pageFromQuery(query)
+	return base, page, err
+}
+
+// splitQuery separates a resource URI from its query, rejecting parameters other than accepted
+func splitQuery(uri string, accepted ...string) (string, url.Values, error) {
+	base, rawQuery, found := strings.Cut(uri, "?")
+	if !found {
+		return uri, url.Values{}, nil
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return base, nil, fmt.Errorf("invalid query: %w", err)
+	}
+	for key := range query {
+		if !slices.Contains(accepted, key) {
+			return base, nil, fmt.Errorf("unknown parameter %q, expected %s", key, strings.Join(accepted, ", "))
+		}
+	}
+
+	return base, query, nil
+}
+
+// pageFromQuery reads the page asked for by page, limit and cursor parameters, or nil when
+// there are none
+func pageFromQuery(query url.Values) (*pageRequest, error) {
+	if !query.Has("page") && !query.Has("limit") && !query.Has("cursor") {
+		return nil, nil
+	}
+
+	var err error
+	request := &pageRequest{Page: 1, Limit: client.MaxPageLimit}
+	if cursor := query.Get("cursor"); cursor != "" {
+		if query.Has("page") || query.Has("limit") {
+			return nil, fmt.Errorf("cursor cannot be combined with page or limit")
+		}
+		if request.Page, request.Limit, err = decodeCursor(cursor); err != nil {
+			return nil, err
+		}
+		return request, nil
+	}
+
+	if page := query.Get("page"); page != "" {
+		if request.Page, err = strconv.Atoi(page); err != nil || request.Page < 1 {
+			return nil, fmt.Errorf("page must be a positive integer")
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if request.Limit, err = strconv.Atoi(limit); err != nil || request.Limit < 1 || request.Limit > client.MaxPageLimit {
+			return nil, fmt.Errorf("limit must be an integer from 1 to %d", client.MaxPageLimit)
+		}
+	}
+
+	return request, nil
+}
+
+// pagination describes the page a request returned given the total number of items
+func (p pageRequest) pagination(total int) Pagination {
+	pagination := Pagination{Page: p.Page, Limit: p.Limit, Total: total}
+	if p.Page*p.Limit < total {
+		pagination.NextCursor = encodeCursor(p.Page+1, p.Limit)
+	}
+	return pagination
+}
+
+// encodeCursor builds the opaque cursor that reads a page
+func encodeCursor(page, limit int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", page, limit)))
+}
+
+// decodeCursor recovers the page and limit from a cursor made by encodeCursor
+func decodeCursor(cursor string) (int, int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cursor")
+	}
+	var page, limit int
+	if _, err := fmt.Sscanf(string(decoded), "%d:%d", &page, &limit); err != nil || page < 1 || limit < 1 || limit > client.MaxPageLimit {
+		return 0, 0, fmt.Errorf("invalid cursor")
+	}
+	return page, limit, nil
+}
@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSplitPageQuery(t *testing.T) {
+	tests := []struct {
+		uri     string
+		base    string
+		page    *pageRequest
+		wantErr bool
+	}{
+		{uri: "spacetraders://systems", base: "spacetraders://systems"},
+		{uri: "spacetraders://systems?page=3", base: "spacetraders://systems", page: &pageRequest{Page: 3, Limit: client.MaxPageLimit}},
+		{uri: "spacetraders://systems?limit=5&page=2", base: "spacetraders://systems", page: &pageRequest{Page: 2, Limit: 5}},
+		{uri: "spacetraders://systems?cursor=" + encodeCursor(4, 10), base: "spacetraders://systems", page: &pageRequest{Page: 4, Limit: 10}},
+		{uri: "spacetraders://systems?page=0", wantErr: true},
+		{uri: "spacetraders://systems?limit=21", wantErr: true},
+		{uri: "spacetraders://systems?cursor=bogus", wantErr: true},
+		{uri: "spacetraders://systems?cursor=" + encodeCursor(2, 5) + "&page=1", wantErr: true},
+		{uri: "spacetraders://systems?sort=name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			base, page, err := splitPageQuery(tt.uri)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got page %+v", page)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if base != tt.base {
+				t.Errorf("Expected base %s, got %s", tt.base, base)
+			}
+			if (page == nil) != (tt.page == nil) || (page != nil && *page != *tt.page) {
+				t.Errorf("Expected page %+v, got %+v", tt.page, page)
+			}
+		})
+	}
+}
+
+func TestPageRequest_Pagination(t *testing.T) {
+	middle := pageRequest{Page: 2, Limit: 10}.pagination(25)
+	if middle.Total != 25 || middle.NextCursor != encodeCursor(3, 10) {
+		t.Errorf("Expected a cursor to page 3, got %+v", middle)
+	}
+
+	last := pageRequest{Page: 3, Limit: 10}.pagination(25)
+	if last.NextCursor != "" {
+		t.Errorf("Expected no cursor on the last page, got %+v", last)
+	}
+}
+
+func TestSystemsResource_Handler_Page(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/systems" {
+			t.Errorf("Expected path /systems, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") != "2" || r.URL.Query().Get("limit") != "1" {
+			t.Errorf("Expected only page 2 of size 1 to be fetched, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": [{"symbol": "X1-B", "sectorSymbol": "X1", "type": "RED_STAR", "x": 1, "y": 2, "waypoints": [], "factions": []}], "meta": {"total": 3, "page": 2, "limit": 1}}`)
+	}))
+	defer server.Close()
+
+	resource := NewSystemsResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	uri := "spacetraders://systems?page=2&limit=1"
+	if !resource.PagedResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected paged systems template to match %s", uri)
+	}
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON text content, got %+v", contents[0])
+	}
+
+	var systems []map[string]interface{}
+	meta, err := decodeEnvelope(textContent.Text, &systems)
+	if err != nil {
+		t.Fatalf("Invalid envelope: %v", err)
+	}
+	if len(systems) != 1 || systems[0]["symbol"] != "X1-B" {
+		t.Errorf("Expected only X1-B, got %+v", systems)
+	}
+	if meta.Pagination == nil || meta.Pagination.Total != 3 || meta.Pagination.NextCursor != encodeCursor(3, 1) {
+		t.Errorf("Expected pagination to page 3 of 3, got %+v", meta.Pagination)
+	}
+}
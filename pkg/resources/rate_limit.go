@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const rateLimitResourceURI = "spacetraders://api/rate-limit"
+
+// recentThrottle is how long after a 429 the resource keeps advising callers to slow down
+const recentThrottle = time.Minute
+
+// RateLimitResource exposes the rate limit the API reported on its latest response
+type RateLimitResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewRateLimitResource creates a new rate limit resource handler
+func NewRateLimitResource(client *client.Client, logger *logging.Logger) *RateLimitResource {
+	return &RateLimitResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *RateLimitResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         rateLimitResourceURI,
+		Name:        "API Rate Limit",
+		Description: "The SpaceTraders API rate limit from the latest response: requests remaining, the per-second and burst limits, when the quota resets, and when the API last refused a request with 429. Reading it makes no API call.",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *RateLimitResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != rateLimitResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "rate-limit-resource")
+
+		status, observed := r.client.RateLimitStatus()
+		data := map[string]interface{}{
+			"observed": observed,
+		}
+		fetchedAt := time.Now()
+		count := 0
+		if observed {
+			data["rateLimit"] = status
+			if !status.ObservedAt.IsZero() {
+				fetchedAt = status.ObservedAt
+			}
+			count = 1
+
+			throttled := status.ThrottledAt != nil && time.Since(*status.ThrottledAt) < recentThrottle
+			data["throttled"] = throttled
+			if throttled || (!status.ObservedAt.IsZero() && status.Remaining == 0) {
+				data["advice"] = "The request quota is used up; wait until it resets before calling more tools, and prefer cached resources over live ones"
+			}
+		}
+
+		// Each secondary token has its own quota, so only their use and refusals are reported
+		if secondary := r.client.SecondaryTokens(); len(secondary) > 0 {
+			data["secondaryTokens"] = secondary
+		}
+
+		// The headers were recorded from the latest API response, not fetched for this read
+		result := cachedEnvelope(data, count, fetchedAt)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal rate limit to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting rate limit",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
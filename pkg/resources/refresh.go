@@ -0,0 +1,27 @@
+package resources
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// refreshParam is the query parameter that makes a resource that may answer from a cache
+// fetch from the API instead
+const refreshParam = "refresh"
+
+// refreshQuery is appended to the templates of resources that only take the refresh parameter
+const refreshQuery = "{?refresh}"
+
+// refreshFromQuery reads the refresh parameter, which defaults to false
+func refreshFromQuery(query url.Values) (bool, error) {
+	value := query.Get(refreshParam)
+	if value == "" {
+		return false, nil
+	}
+	refresh, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("refresh must be true or false")
+	}
+	return refresh, nil
+}
@@ -0,0 +1,197 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestRefreshFromQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    bool
+		wantErr bool
+	}{
+		{"", false, false},
+		{"refresh=true", true, false},
+		{"refresh=1", true, false},
+		{"refresh=false", false, false},
+		{"refresh=soon", false, true},
+	}
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		got, err := refreshFromQuery(query)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("refreshFromQuery(%q) = %v, %v; want %v, error %v", tt.query, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFetchedEnvelope(t *testing.T) {
+	start := time.Now()
+	if meta := fetchedEnvelope(nil, 0, start.Add(-time.Hour), start).Meta; meta.Source != sourceCache || meta.AgeSeconds < 3599 {
+		t.Errorf("Expected data fetched before the read to be an hour old cache, got %+v", meta)
+	}
+	if meta := fetchedEnvelope(nil, 0, start, start).Meta; meta.Source != sourceLive || meta.AgeSeconds != 0 {
+		t.Errorf("Expected data fetched for the read to be live, got %+v", meta)
+	}
+}
+
+// readResource reads uri and decodes its envelope into data
+func readResource(t *testing.T, handler ResourceHandler, uri string, data interface{}) Meta {
+	t.Helper()
+	contents, err := handler.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error reading %s: %v", uri, err)
+	}
+	meta, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, data)
+	if err != nil {
+		t.Fatalf("Invalid envelope from %s: %v", uri, err)
+	}
+	return meta
+}
+
+func TestMarketResource_Handler_RecordedPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// No ship at the market, so there are no trade goods
+		fmt.Fprint(w, `{"data": {"symbol": "X1-TEST-A1", "exports": [{"symbol": "FUEL", "name": "Fuel", "description": "Fuel"}], "imports": [], "exchange": []}}`)
+	}))
+	defer server.Close()
+
+	db := prices.New()
+	observedAt := time.Now().Add(-2 * time.Hour)
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: observedAt, Prices: []prices.Price{
+		{TradeSymbol: "FUEL", Type: "EXPORT", Supply: "HIGH", PurchasePrice: 72, SellPrice: 68, TradeVolume: 100},
+	}})
+	resource := NewMarketResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger()).WithPrices(db)
+
+	var result struct {
+		Market struct {
+			TradeGoods []map[string]interface{} `json:"trade_goods"`
+		} `json:"market"`
+	}
+	meta := readResource(t, resource, "spacetraders://systems/X1-TEST/waypoints/X1-TEST-A1/market?detail=summary", &result)
+	if meta.Source != sourceCache || meta.FetchedAt != observedAt.UTC().Format(time.RFC3339) || meta.AgeSeconds < 7199 {
+		t.Errorf("Expected the recorded prices to be marked as two hour old cache, got %+v", meta)
+	}
+	if len(result.Market.TradeGoods) != 1 || result.Market.TradeGoods[0]["sell_price"] != float64(68) {
+		t.Errorf("Expected the recorded FUEL price, got %v", result.Market.TradeGoods)
+	}
+
+	// A refresh shows only what the API has now
+	uri := "spacetraders://systems/X1-TEST/waypoints/X1-TEST-A1/market?refresh=true"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected market template to match %s", uri)
+	}
+	result.Market.TradeGoods = nil
+	meta = readResource(t, resource, uri, &result)
+	if meta.Source != sourceLive || meta.AgeSeconds != 0 || len(result.Market.TradeGoods) != 0 {
+		t.Errorf("Expected live data without prices, got %+v %v", meta, result.Market.TradeGoods)
+	}
+}
+
+func TestShipyardResource_Handler_KnownListing(t *testing.T) {
+	docked := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ships := ""
+		if docked {
+			ships = `, "ships": [{"type": "SHIP_MINING_DRONE", "name": "Mining Drone", "purchasePrice": 45000, "supply": "MODERATE"}]`
+		}
+		fmt.Fprintf(w, `{"data": {"symbol": "X1-TEST-A1", "shipTypes": [{"type": "SHIP_MINING_DRONE"}], "modificationsFee": 100%s}}`, ships)
+	}))
+	defer server.Close()
+
+	resource := NewShipyardResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+	uri := "spacetraders://systems/X1-TEST/waypoints/X1-TEST-A1/shipyard"
+
+	var result struct {
+		Summary struct {
+			TotalShipsAvailable int `json:"totalShipsAvailable"`
+		} `json:"summary"`
+	}
+	if meta := readResource(t, resource, uri, &result); meta.Source != sourceLive || result.Summary.TotalShipsAvailable != 1 {
+		t.Fatalf("Expected a live listing with one ship, got %+v %+v", meta, result)
+	}
+
+	// Once the ship leaves, the listing it saw is served from the cache
+	docked = false
+	if meta := readResource(t, resource, uri, &result); meta.Source != sourceCache || result.Summary.TotalShipsAvailable != 1 {
+		t.Errorf("Expected the cached listing with one ship, got %+v %+v", meta, result)
+	}
+
+	refreshURI := uri + "?refresh=true"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(refreshURI) {
+		t.Fatalf("Expected shipyard template to match %s", refreshURI)
+	}
+	if meta := readResource(t, resource, refreshURI, &result); meta.Source != sourceLive || result.Summary.TotalShipsAvailable != 0 {
+		t.Errorf("Expected a live listing without ships, got %+v %+v", meta, result)
+	}
+}
+
+func TestSupplyChainResource_Handler_Refresh(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": {"exportToImportMap": {"FUEL": ["HYDROCARBON"]}}}`)
+	}))
+	defer server.Close()
+
+	resource := NewSupplyChainResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	var result interface{}
+	for i, want := range []struct {
+		uri    string
+		source string
+	}{
+		{"spacetraders://markets/supply-chain", sourceLive},
+		{"spacetraders://markets/supply-chain", sourceCache},
+		{"spacetraders://markets/supply-chain?refresh=true", sourceLive},
+	} {
+		if meta := readResource(t, resource, want.uri, &result); meta.Source != want.source {
+			t.Errorf("Read %d of %s: expected source %s, got %s", i+1, want.uri, want.source, meta.Source)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected the refresh to fetch the supply chain again, got %d requests", requests)
+	}
+}
+
+func TestWaypointsResource_Handler_Refresh(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": [{"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 1, "y": 2, "orbitals": [], "traits": []}], "meta": {"total": 1, "page": 1, "limit": 20}}`)
+	}))
+	defer server.Close()
+
+	resource := NewWaypointsResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	var result interface{}
+	sources := make([]string, 0, 3)
+	for _, uri := range []string{
+		"spacetraders://systems/X1-TEST/waypoints",
+		"spacetraders://systems/X1-TEST/waypoints",
+		"spacetraders://systems/X1-TEST/waypoints?refresh=true",
+	} {
+		sources = append(sources, readResource(t, resource, uri, &result).Source)
+	}
+	if got := strings.Join(sources, ","); got != "live,cache,live" || requests != 2 {
+		t.Errorf("Expected live,cache,live from 2 requests, got %s from %d", got, requests)
+	}
+}
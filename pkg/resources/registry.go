@@ -2,8 +2,21 @@ package resources
 
 import (
 	"context"
+	"strings"
+
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/events"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/ledger"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/session"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -15,21 +28,133 @@ type ResourceHandler interface {
 	Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)
 }
 
+// ResourceTemplateHandler is implemented by resources whose URI accepts parameters.
+// They are registered as MCP resource templates, and their static URI is only listed
+// as a plain resource when it has no parameters.
+type ResourceTemplateHandler interface {
+	ResourceTemplate() mcp.ResourceTemplate
+}
+
+// PagedResourceHandler is implemented by list resources with a plain URI that also accept
+// page, limit and cursor query parameters. The paged form is registered as an extra template.
+type PagedResourceHandler interface {
+	PagedResourceTemplate() mcp.ResourceTemplate
+}
+
+// Option configures optional subsystems used by resources
+type Option func(*Registry)
+
+// WithLedger enables resources backed by the transactions ledger
+func WithLedger(l *ledger.Ledger) Option {
+	return func(r *Registry) {
+		r.ledger = l
+	}
+}
+
+// WithTasks enables resources backed by the background task manager
+func WithTasks(m *tasks.Manager) Option {
+	return func(r *Registry) {
+		r.tasks = m
+	}
+}
+
+// WithExplorer enables resources backed by the exploration tracker
+func WithExplorer(t *explorer.Tracker) Option {
+	return func(r *Registry) {
+		r.explorer = t
+	}
+}
+
+// WithMining enables resources backed by the extraction recorder
+func WithMining(m *mining.Recorder) Option {
+	return func(r *Registry) {
+		r.mining = m
+	}
+}
+
+// WithSession enables the session summary resource
+func WithSession(s *session.Recorder) Option {
+	return func(r *Registry) {
+		r.session = s
+	}
+}
+
+// WithEvents enables the recent ship events resource
+func WithEvents(l *events.Log) Option {
+	return func(r *Registry) {
+		r.events = l
+	}
+}
+
+// WithShipMeta adds ship labels, notes and tags to the fleet resources, and enables the
+// squadrons resource
+func WithShipMeta(s *shipmeta.Store) Option {
+	return func(r *Registry) {
+		r.shipMeta = s
+	}
+}
+
+// WithCooldowns names the action behind each ship cooldown in the timers resource
+func WithCooldowns(t *cooldowns.Tracker) Option {
+	return func(r *Registry) {
+		r.cooldowns = t
+	}
+}
+
+// WithFleetState answers ship and agent reads from the local fleet model while it is fresh,
+// and enables the fleet state resource
+func WithFleetState(m *fleetstate.Model) Option {
+	return func(r *Registry) {
+		r.fleetState = m
+	}
+}
+
+// WithCredits enables the credits history resource
+func WithCredits(h *credits.History) Option {
+	return func(r *Registry) {
+		r.credits = h
+	}
+}
+
+// WithPrices fills in a market's prices from the price database when no ship is there to see
+// them live
+func WithPrices(db *prices.DB) Option {
+	return func(r *Registry) {
+		r.prices = db
+	}
+}
+
 // Registry manages all MCP resources
 type Registry struct {
-	client   *client.Client
-	logger   *logging.Logger
-	handlers []ResourceHandler
+	client    *client.Client
+	logger    *logging.Logger
+	ledger    *ledger.Ledger
+	tasks     *tasks.Manager
+	explorer  *explorer.Tracker
+	mining    *mining.Recorder
+	session   *session.Recorder
+	events    *events.Log
+	shipMeta  *shipmeta.Store
+	cooldowns *cooldowns.Tracker
+	// fleetState is left nil to always read ships and the agent from the API
+	fleetState *fleetstate.Model
+	credits    *credits.History
+	prices     *prices.DB
+	handlers   []ResourceHandler
 }
 
 // NewRegistry creates a new resource registry
-func NewRegistry(client *client.Client, logger *logging.Logger) *Registry {
+func NewRegistry(client *client.Client, logger *logging.Logger, opts ...Option) *Registry {
 	registry := &Registry{
 		client:   client,
 		logger:   logger,
 		handlers: make([]ResourceHandler, 0),
 	}
 
+	for _, opt := range opts {
+		opt(registry)
+	}
+
 	// Register all available resources
 	registry.registerResources()
 
@@ -39,48 +164,170 @@ func NewRegistry(client *client.Client, logger *logging.Logger) *Registry {
 // registerResources registers all available resource handlers
 func (r *Registry) registerResources() {
 	// Agent information resource
-	r.handlers = append(r.handlers, NewAgentResource(r.client, r.logger))
+	r.handlers = append(r.handlers, NewAgentResource(r.client, r.logger).WithFleetState(r.fleetState))
+
+	// Account information resource
+	r.handlers = append(r.handlers, NewAccountResource(r.client, r.logger))
 
 	// Ships list resource
-	r.handlers = append(r.handlers, NewShipsResource(r.client, r.logger))
+	r.handlers = append(r.handlers, NewShipsResource(r.client, r.logger).WithShipMeta(r.shipMeta).WithFleetState(r.fleetState))
+
+	// Fleet summary resource
+	r.handlers = append(r.handlers, NewFleetSummaryResource(r.client, r.logger).WithShipMeta(r.shipMeta).WithTasks(r.tasks))
+
+	// Fleet cargo manifest resource
+	r.handlers = append(r.handlers, NewFleetCargoResource(r.client, r.logger).WithShipMeta(r.shipMeta))
+
+	// Dashboard resource; the ledger and task sections appear when those are enabled
+	r.handlers = append(r.handlers, NewDashboardResource(r.client, r.ledger, r.tasks, r.logger))
 
 	// Contracts list resource
 	r.handlers = append(r.handlers, NewContractsResource(r.client, r.logger))
 
+	// Individual contract resource
+	r.handlers = append(r.handlers, NewContractResource(r.client, r.logger))
+
 	// System waypoints resource
 	r.handlers = append(r.handlers, NewWaypointsResource(r.client, r.logger))
 
 	// Shipyard resource
 	r.handlers = append(r.handlers, NewShipyardResource(r.client, r.logger))
 
-	// Market resource
-	r.handlers = append(r.handlers, NewMarketResource(r.client, r.logger))
+	// Market resource; recorded prices stand in for missing live ones when the price database is enabled
+	r.handlers = append(r.handlers, NewMarketResource(r.client, r.logger).WithPrices(r.prices))
+
+	// Supply chain resource
+	r.handlers = append(r.handlers, NewSupplyChainResource(r.client, r.logger))
+
+	// Trade good and ship type catalog resources
+	r.handlers = append(r.handlers, NewTradeGoodsCatalogResource(r.client, r.logger))
+	r.handlers = append(r.handlers, NewShipTypesCatalogResource(r.client, r.logger))
+
+	// System trade map resource
+	r.handlers = append(r.handlers, NewTradeMapResource(r.client, r.logger))
 
 	// Systems resource
 	r.handlers = append(r.handlers, NewSystemsResource(r.client, r.logger))
 
+	// Jump gate graph resource
+	r.handlers = append(r.handlers, NewJumpGateGraphResource(r.client, r.logger))
+
 	// Factions resource
 	r.handlers = append(r.handlers, NewFactionsResource(r.client, r.logger))
 
+	// Faction reputation resource
+	r.handlers = append(r.handlers, NewFactionReputationResource(r.client, r.logger))
+
 	// Individual ship resource
-	r.handlers = append(r.handlers, NewShipResource(r.client, r.logger))
+	r.handlers = append(r.handlers, NewShipResource(r.client, r.logger).WithShipMeta(r.shipMeta).WithFleetState(r.fleetState))
 
 	// Ship cooldown resource
 	r.handlers = append(r.handlers, NewShipCooldownResource(r.client, r.logger))
+
+	// Ship nav, cargo and fuel resources
+	r.handlers = append(r.handlers, NewShipNavResource(r.client, r.logger).WithFleetState(r.fleetState))
+	r.handlers = append(r.handlers, NewShipCargoResource(r.client, r.logger).WithFleetState(r.fleetState))
+	r.handlers = append(r.handlers, NewShipFuelResource(r.client, r.logger).WithFleetState(r.fleetState))
+
+	// Pending timers resource; cooldown actions and task ownership appear when those are enabled
+	r.handlers = append(r.handlers, NewTimersResource(r.client, r.logger).WithCooldowns(r.cooldowns).WithTasks(r.tasks))
+
+	// API rate limit resource
+	r.handlers = append(r.handlers, NewRateLimitResource(r.client, r.logger))
+
+	// Transactions ledger resource
+	if r.ledger != nil {
+		r.handlers = append(r.handlers, NewLedgerResource(r.ledger, r.logger))
+	}
+
+	// Background tasks resource
+	if r.tasks != nil {
+		r.handlers = append(r.handlers, NewTasksResource(r.tasks, r.logger))
+	}
+
+	// Exploration progress resource
+	if r.explorer != nil {
+		r.handlers = append(r.handlers, NewExplorationResource(r.client, r.explorer, r.logger))
+	}
+
+	// Mining statistics resource
+	if r.mining != nil {
+		r.handlers = append(r.handlers, NewMiningStatsResource(r.mining, r.logger))
+	}
+
+	// Session summary resource; ledger totals appear when the ledger is enabled
+	if r.session != nil {
+		r.handlers = append(r.handlers, NewSessionSummaryResource(r.client, r.session, r.ledger, r.logger))
+	}
+
+	// Recent ship events resource
+	if r.events != nil {
+		r.handlers = append(r.handlers, NewRecentEventsResource(r.events, r.logger))
+	}
+
+	// Squadrons resource
+	if r.shipMeta != nil {
+		r.handlers = append(r.handlers, NewSquadronsResource(r.client, r.shipMeta, r.logger).WithTasks(r.tasks))
+	}
+
+	// Credits history resource
+	if r.credits != nil {
+		r.handlers = append(r.handlers, NewCreditsHistoryResource(r.credits, r.logger))
+	}
+
+	// Fuel price index resource
+	if r.prices != nil {
+		r.handlers = append(r.handlers, NewFuelIndexResource(r.prices, r.logger))
+	}
+
+	// Local fleet state resource
+	if r.fleetState != nil {
+		r.handlers = append(r.handlers, NewFleetStateResource(r.fleetState, r.logger))
+	}
 }
 
 // RegisterWithServer registers all resources with the MCP server
 func (r *Registry) RegisterWithServer(s *server.MCPServer) {
 	for _, handler := range r.handlers {
-		s.AddResource(handler.Resource(), handler.Handler())
+		if !isTemplateURI(handler.Resource().URI) {
+			s.AddResource(handler.Resource(), handler.Handler())
+		}
+		if templateHandler, ok := handler.(ResourceTemplateHandler); ok {
+			s.AddResourceTemplate(templateHandler.ResourceTemplate(), handler.Handler())
+		}
+		if pagedHandler, ok := handler.(PagedResourceHandler); ok {
+			s.AddResourceTemplate(pagedHandler.PagedResourceTemplate(), handler.Handler())
+		}
 	}
 }
 
-// GetResources returns all registered resources (useful for testing/debugging)
+// GetResources returns all registered concrete resources (useful for testing/debugging)
 func (r *Registry) GetResources() []mcp.Resource {
-	resources := make([]mcp.Resource, len(r.handlers))
-	for i, handler := range r.handlers {
-		resources[i] = handler.Resource()
+	resources := make([]mcp.Resource, 0, len(r.handlers))
+	for _, handler := range r.handlers {
+		if !isTemplateURI(handler.Resource().URI) {
+			resources = append(resources, handler.Resource())
+		}
 	}
 	return resources
 }
+
+// GetResourceTemplates returns all registered resource templates (useful for testing/debugging)
+func (r *Registry) GetResourceTemplates() []mcp.ResourceTemplate {
+	var templates []mcp.ResourceTemplate
+	for _, handler := range r.handlers {
+		if templateHandler, ok := handler.(ResourceTemplateHandler); ok {
+			templates = append(templates, templateHandler.ResourceTemplate())
+		}
+		if pagedHandler, ok := handler.(PagedResourceHandler); ok {
+			templates = append(templates, pagedHandler.PagedResourceTemplate())
+		}
+	}
+	return templates
+}
+
+// isTemplateURI reports whether a resource URI contains template parameters and so
+// can only be served through its resource template
+func isTemplateURI(uri string) bool {
+	return strings.Contains(uri, "{")
+}
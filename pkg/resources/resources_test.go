@@ -2,13 +2,24 @@ package resources
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/events"
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/ledger"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/session"
+	"spacetraders-mcp/pkg/shipmeta"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -174,7 +185,7 @@ func TestWaypointsResource_Handler_Success(t *testing.T) {
 
 	// Parse the JSON response to verify structure
 	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(textContent.Text), &result); err != nil {
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
 		t.Fatalf("Failed to parse response JSON: %v", err)
 	}
 
@@ -193,6 +204,97 @@ func TestWaypointsResource_Handler_Success(t *testing.T) {
 	}
 }
 
+func TestWaypointsResource_Handler_KeepsWaypointDetails(t *testing.T) {
+	// Raw API response, so every field the resource shows goes through the client's converters
+	responseJSON := `{
+		"data": [
+			{
+				"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 10, "y": 20,
+				"orbitals": [{"symbol": "X1-TEST-A2"}],
+				"traits": [
+					{"symbol": "MARKETPLACE", "name": "Marketplace", "description": "A thriving marketplace"},
+					{"symbol": "SHIPYARD", "name": "Shipyard", "description": "Shipyard for purchasing ships"}
+				],
+				"modifiers": [{"symbol": "STRIPPED", "name": "Stripped", "description": "Resources are scarce"}],
+				"chart": {"waypointSymbol": "X1-TEST-A1", "submittedBy": "COSMIC", "submittedOn": "2025-01-01T00:00:00Z"},
+				"faction": {"symbol": "COSMIC"},
+				"isUnderConstruction": false
+			},
+			{
+				"symbol": "X1-TEST-A2", "type": "MOON", "systemSymbol": "X1-TEST", "x": 10, "y": 20,
+				"orbitals": [], "orbits": "X1-TEST-A1",
+				"traits": [{"symbol": "BARREN", "name": "Barren", "description": "Nothing here"}],
+				"isUnderConstruction": true
+			}
+		],
+		"meta": {"total": 2, "page": 1, "limit": 20}
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(responseJSON)); err != nil {
+			t.Errorf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	resource := NewWaypointsResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://systems/X1-TEST/waypoints"},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned error: %v", err)
+	}
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON content, got %#v", contents[0])
+	}
+
+	var result struct {
+		Waypoints []client.SystemWaypoint `json:"waypoints"`
+		Summary   struct {
+			Shipyards []string `json:"shipyards"`
+			Markets   []string `json:"markets"`
+		} `json:"summary"`
+	}
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+		t.Fatalf("Failed to parse response JSON: %v", err)
+	}
+	if len(result.Waypoints) != 2 {
+		t.Fatalf("Expected 2 waypoints, got %d", len(result.Waypoints))
+	}
+
+	planet := result.Waypoints[0]
+	var traits []string
+	for _, trait := range planet.Traits {
+		traits = append(traits, trait.Symbol)
+	}
+	if strings.Join(traits, ",") != "MARKETPLACE,SHIPYARD" {
+		t.Errorf("Expected MARKETPLACE and SHIPYARD traits, got %v", traits)
+	}
+	if len(planet.Modifiers) != 1 || planet.Modifiers[0].Symbol != "STRIPPED" {
+		t.Errorf("Expected the STRIPPED modifier, got %v", planet.Modifiers)
+	}
+	if planet.Chart == nil || planet.Chart.SubmittedBy != "COSMIC" {
+		t.Errorf("Expected the chart submitted by COSMIC, got %v", planet.Chart)
+	}
+	if planet.Faction == nil || planet.Faction.Symbol != "COSMIC" {
+		t.Errorf("Expected the COSMIC faction, got %v", planet.Faction)
+	}
+
+	moon := result.Waypoints[1]
+	if moon.Orbits != "X1-TEST-A1" || !moon.IsUnderConstruction {
+		t.Errorf("Expected the moon to orbit X1-TEST-A1 and be under construction, got %+v", moon)
+	}
+
+	if len(result.Summary.Markets) != 1 || result.Summary.Markets[0] != "X1-TEST-A1" {
+		t.Errorf("Expected X1-TEST-A1 listed as a market, got %v", result.Summary.Markets)
+	}
+	if len(result.Summary.Shipyards) != 1 || result.Summary.Shipyards[0] != "X1-TEST-A1" {
+		t.Errorf("Expected X1-TEST-A1 listed as a shipyard, got %v", result.Summary.Shipyards)
+	}
+}
+
 func TestWaypointsResource_Handler_InvalidURI(t *testing.T) {
 	client := client.NewClient("test-token")
 	logger := createMockLogger()
@@ -378,7 +480,7 @@ func TestShipyardResource_Handler_Success(t *testing.T) {
 
 	// Parse the JSON response to verify structure
 	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(textContent.Text), &result); err != nil {
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
 		t.Fatalf("Failed to parse response JSON: %v", err)
 	}
 
@@ -517,14 +619,14 @@ func TestAgentResource_Handler_Success(t *testing.T) {
 
 	// Parse and verify JSON content
 	var jsonResult map[string]interface{}
-	err = json.Unmarshal([]byte(content.Text), &jsonResult)
+	meta, err := decodeEnvelope(content.Text, &jsonResult)
 	if err != nil {
 		t.Fatalf("Failed to parse JSON response: %v", err)
 	}
 
-	agent, ok := jsonResult["agent"].(map[string]interface{})
-	if !ok {
-		t.Fatal("Expected agent object in response")
+	agent := jsonResult
+	if meta.Count != 1 || meta.Source != sourceLive {
+		t.Errorf("Expected one live agent, got %+v", meta)
 	}
 
 	if agent["symbol"] != "TEST_AGENT" {
@@ -852,28 +954,18 @@ func TestShipsResource_Handler_Success(t *testing.T) {
 	}
 
 	// Parse and verify JSON content
-	var jsonResult map[string]interface{}
-	err = json.Unmarshal([]byte(content.Text), &jsonResult)
+	var ships []interface{}
+	meta, err := decodeEnvelope(content.Text, &ships)
 	if err != nil {
 		t.Fatalf("Failed to parse JSON response: %v", err)
 	}
 
-	ships, ok := jsonResult["ships"].([]interface{})
-	if !ok {
-		t.Fatal("Expected ships array in response")
-	}
-
 	if len(ships) != 2 {
 		t.Errorf("Expected 2 ships, got %d", len(ships))
 	}
 
-	meta, ok := jsonResult["meta"].(map[string]interface{})
-	if !ok {
-		t.Fatal("Expected meta object in response")
-	}
-
-	if meta["count"] != float64(2) {
-		t.Errorf("Expected count 2, got %v", meta["count"])
+	if meta.Count != 2 {
+		t.Errorf("Expected count 2, got %d", meta.Count)
 	}
 }
 
@@ -895,6 +987,141 @@ func TestContractsResource_Resource(t *testing.T) {
 	}
 }
 
+func TestContractResource_Handler_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my/contracts/clm0n4k8q00a3s60cg6qk2r3y" {
+			t.Errorf("Expected single contract endpoint, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {
+			"id": "clm0n4k8q00a3s60cg6qk2r3y", "factionSymbol": "COSMIC", "type": "PROCUREMENT",
+			"terms": {
+				"deadline": "2025-01-08T00:00:00.000Z",
+				"payment": {"onAccepted": 1000, "onFulfilled": 9000},
+				"deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-A1", "unitsRequired": 40, "unitsFulfilled": 10}]
+			},
+			"accepted": true, "fulfilled": false,
+			"expiration": "2025-01-02T00:00:00.000Z", "deadlineToAccept": "2025-01-02T00:00:00.000Z"
+		}}`))
+	}))
+	defer server.Close()
+
+	resource := NewContractResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	uri := "spacetraders://contracts/clm0n4k8q00a3s60cg6qk2r3y"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected contract template to match %s", uri)
+	}
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON text content, got %+v", contents[0])
+	}
+
+	var result struct {
+		Contract client.Contract    `json:"contract"`
+		Progress []deliveryProgress `json:"progress"`
+	}
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+		t.Fatalf("Failed to parse contract JSON: %v", err)
+	}
+
+	if result.Contract.ID != "clm0n4k8q00a3s60cg6qk2r3y" || !result.Contract.Accepted {
+		t.Errorf("Unexpected contract: %+v", result.Contract)
+	}
+	if len(result.Progress) != 1 || result.Progress[0].UnitsRemaining != 30 || result.Progress[0].PercentComplete != 25 {
+		t.Errorf("Expected 30 units remaining at 25%%, got %+v", result.Progress)
+	}
+}
+
+func TestContractResource_Handler_InvalidURI(t *testing.T) {
+	resource := NewContractResource(client.NewClient("test-token"), createMockLogger())
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://contracts/"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent := contents[0].(*mcp.TextResourceContents)
+	if textContent.MIMEType != "text/plain" || !contains(textContent.Text, "Invalid contract resource URI") {
+		t.Errorf("Expected plain-text URI error, got %s", textContent.Text)
+	}
+}
+
+func TestShipCargoResource_Handler_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my/ships/SHIP-1/cargo" {
+			t.Errorf("Expected cargo endpoint, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"capacity": 40, "units": 10, "inventory": [
+			{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "Raw iron", "units": 10}
+		]}}`))
+	}))
+	defer server.Close()
+
+	resource := NewShipCargoResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	uri := "spacetraders://ships/SHIP-1/cargo"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected cargo template to match %s", uri)
+	}
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON text content, got %+v", contents[0])
+	}
+
+	var result struct {
+		ShipSymbol   string       `json:"shipSymbol"`
+		Cargo        client.Cargo `json:"cargo"`
+		FreeUnits    int          `json:"freeUnits"`
+		CargoPercent int          `json:"cargoPercent"`
+	}
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+		t.Fatalf("Failed to parse cargo JSON: %v", err)
+	}
+
+	if result.ShipSymbol != "SHIP-1" || len(result.Cargo.Inventory) != 1 {
+		t.Errorf("Unexpected cargo result: %+v", result)
+	}
+	if result.FreeUnits != 30 || result.CargoPercent != 25 {
+		t.Errorf("Expected 30 free units at 25%%, got %d at %d%%", result.FreeUnits, result.CargoPercent)
+	}
+}
+
+func TestShipPartResources_InvalidURI(t *testing.T) {
+	resource := NewShipNavResource(client.NewClient("test-token"), createMockLogger())
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://ships/SHIP-1/cargo"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent := contents[0].(*mcp.TextResourceContents)
+	if textContent.MIMEType != "text/plain" || !contains(textContent.Text, "spacetraders://ships/{shipSymbol}/nav") {
+		t.Errorf("Expected plain-text URI error for nav, got %s", textContent.Text)
+	}
+}
+
 func TestRegistry_NewRegistry(t *testing.T) {
 	client := client.NewClient("test-token")
 	logger := createMockLogger()
@@ -967,9 +1194,77 @@ func TestResourceHandler_Interface(t *testing.T) {
 	// Verify all resource types implement ResourceHandler interface
 	var _ ResourceHandler = NewAgentResource(client, logger)
 	var _ ResourceHandler = NewShipsResource(client, logger)
+	var _ ResourceHandler = NewFleetSummaryResource(client, logger)
+	var _ ResourceHandler = NewDashboardResource(client, nil, nil, logger)
 	var _ ResourceHandler = NewContractsResource(client, logger)
-	var _ ResourceHandler = NewSystemsResource(client, logger)
-	var _ ResourceHandler = NewFactionsResource(client, logger)
+	var _ ResourceHandler = NewMiningStatsResource(mining.NewRecorder(), logger)
+	var _ ResourceTemplateHandler = NewContractResource(client, logger)
+	var _ ResourceTemplateHandler = NewShipNavResource(client, logger)
+	var _ ResourceTemplateHandler = NewShipCargoResource(client, logger)
+	var _ ResourceTemplateHandler = NewShipFuelResource(client, logger)
+	var _ ResourceTemplateHandler = NewShipResource(client, logger)
+	var _ ResourceTemplateHandler = NewShipCooldownResource(client, logger)
+	var _ ResourceTemplateHandler = NewWaypointsResource(client, logger)
+	var _ ResourceTemplateHandler = NewMarketResource(client, logger)
+	var _ ResourceTemplateHandler = NewShipyardResource(client, logger)
+	var _ ResourceTemplateHandler = NewSystemsResource(client, logger)
+	var _ ResourceTemplateHandler = NewFactionsResource(client, logger)
+}
+
+func TestRegistry_TemplatedResourcesOnlyListedAsTemplates(t *testing.T) {
+	registry := NewRegistry(client.NewClient("test-token"), createMockLogger())
+
+	for _, resource := range registry.GetResources() {
+		if contains(resource.URI, "{") || contains(resource.URI, "*") {
+			t.Errorf("Expected no parameterized URI in plain resources, got %s", resource.URI)
+		}
+	}
+
+	templates := make(map[string]bool)
+	for _, template := range registry.GetResourceTemplates() {
+		templates[template.URITemplate.Raw()] = true
+	}
+	for _, expected := range []string{
+		"spacetraders://ships/{shipSymbol}{?detail}",
+		"spacetraders://systems/{systemSymbol}",
+		"spacetraders://systems{?page,limit,cursor}",
+		"spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/market{?detail,refresh}",
+		"spacetraders://factions/{factionSymbol}",
+		"spacetraders://systems/{systemSymbol}/trade-map",
+	} {
+		if !templates[expected] {
+			t.Errorf("Expected resource template %s", expected)
+		}
+	}
+}
+
+func TestCompletionProvider_FactionSymbol(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"symbol": "COSMIC", "name": "Cosmic Engineers", "description": "", "traits": [], "isRecruiting": true},
+			{"symbol": "CORSAIRS", "name": "Corsairs", "description": "", "traits": [], "isRecruiting": false},
+			{"symbol": "GALACTIC", "name": "Galactic Alliance", "description": "", "traits": [], "isRecruiting": true}
+		], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+	}))
+	defer server.Close()
+
+	provider := NewCompletionProvider(client.NewClientWithBaseURL("test-token", server.URL))
+
+	completion, err := provider.CompleteResourceArgument(context.Background(), "spacetraders://factions/{factionSymbol}",
+		mcp.CompleteArgument{Name: "factionSymbol", Value: "co"}, mcp.CompleteContext{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(completion.Values) != 2 || completion.Values[0] != "CORSAIRS" || completion.Values[1] != "COSMIC" {
+		t.Errorf("Expected [CORSAIRS COSMIC], got %v", completion.Values)
+	}
+
+	completion, _ = provider.CompleteResourceArgument(context.Background(), "spacetraders://factions/{factionSymbol}",
+		mcp.CompleteArgument{Name: "unknown", Value: ""}, mcp.CompleteContext{})
+	if len(completion.Values) != 0 {
+		t.Errorf("Expected no completions for an unknown parameter, got %v", completion.Values)
+	}
 }
 
 func TestSystemsResource_Resource(t *testing.T) {
@@ -979,8 +1274,13 @@ func TestSystemsResource_Resource(t *testing.T) {
 
 	resourceDef := resource.Resource()
 
-	if resourceDef.URI != "spacetraders://systems/*" {
-		t.Errorf("Expected URI 'spacetraders://systems/*', got %s", resourceDef.URI)
+	if resourceDef.URI != "spacetraders://systems" {
+		t.Errorf("Expected URI 'spacetraders://systems', got %s", resourceDef.URI)
+	}
+
+	template := resource.ResourceTemplate()
+	if template.Name != "System Details" || !template.URITemplate.Regexp().MatchString("spacetraders://systems/X1-TEST") {
+		t.Errorf("Expected system template to match a specific system, got %s", template.Name)
 	}
 
 	if resourceDef.Name != "Systems Data" {
@@ -1003,8 +1303,13 @@ func TestFactionsResource_Resource(t *testing.T) {
 
 	resourceDef := resource.Resource()
 
-	if resourceDef.URI != "spacetraders://factions/*" {
-		t.Errorf("Expected URI 'spacetraders://factions/*', got %s", resourceDef.URI)
+	if resourceDef.URI != "spacetraders://factions" {
+		t.Errorf("Expected URI 'spacetraders://factions', got %s", resourceDef.URI)
+	}
+
+	template := resource.ResourceTemplate()
+	if template.Name != "Faction Details" || !template.URITemplate.Regexp().MatchString("spacetraders://factions/X1-TEST") {
+		t.Errorf("Expected faction template to match a specific faction, got %s", template.Name)
 	}
 
 	if resourceDef.Name != "Factions Data" {
@@ -1094,6 +1399,115 @@ func TestFactionsResource_Handler_InvalidURI(t *testing.T) {
 	}
 }
 
+func TestFactionReputationResource_Handler_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my/factions" {
+			t.Errorf("Expected /my/factions, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"symbol": "GALACTIC", "reputation": 10},
+			{"symbol": "COSMIC", "reputation": 120}
+		], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+	}))
+	defer server.Close()
+
+	resource := NewFactionReputationResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://factions/reputation"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON text content, got %+v", contents[0])
+	}
+
+	var reputations []client.FactionReputation
+	if _, err := decodeEnvelope(textContent.Text, &reputations); err != nil {
+		t.Fatalf("Failed to parse reputation JSON: %v", err)
+	}
+
+	if len(reputations) != 2 || reputations[0].Symbol != "COSMIC" || reputations[0].Reputation != 120 {
+		t.Errorf("Expected COSMIC first with 120 reputation, got %+v", reputations)
+	}
+}
+
+func TestSupplyChainResource_Handler_Cached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/market/supply-chain" {
+			t.Errorf("Expected /market/supply-chain, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"exportToImportMap": {
+			"FUEL": ["HYDROCARBON"],
+			"PLASTICS": ["HYDROCARBON", "LIQUID_HYDROGEN"]
+		}}}`))
+	}))
+	defer server.Close()
+
+	resource := NewSupplyChainResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	var result struct {
+		ExportToImports map[string][]string `json:"exportToImports"`
+		ImportToExports map[string][]string `json:"importToExports"`
+	}
+	for i := 0; i < 2; i++ {
+		contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{URI: "spacetraders://markets/supply-chain"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		textContent := contents[0].(*mcp.TextResourceContents)
+		if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+			t.Fatalf("Failed to parse supply chain JSON: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected the supply chain to be fetched once, got %d requests", requests)
+	}
+	if len(result.ExportToImports["PLASTICS"]) != 2 {
+		t.Errorf("Expected PLASTICS to have 2 imports, got %v", result.ExportToImports["PLASTICS"])
+	}
+	if got := result.ImportToExports["HYDROCARBON"]; len(got) != 2 || got[0] != "FUEL" || got[1] != "PLASTICS" {
+		t.Errorf("Expected HYDROCARBON to feed [FUEL PLASTICS], got %v", got)
+	}
+}
+
+func TestAgentResource_Handler_CancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no API call after cancellation, got %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	resource := NewAgentResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	contents, err := resource.Handler()(ctx, mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://agent/info"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent := contents[0].(*mcp.TextResourceContents)
+	if textContent.MIMEType != "text/plain" || !contains(textContent.Text, "context canceled") {
+		t.Errorf("Expected a cancellation error, got %s", textContent.Text)
+	}
+}
+
 func TestSystemsResource_parseSystemSymbol(t *testing.T) {
 	client := client.NewClient("test-token")
 	logger := createMockLogger()
@@ -1147,3 +1561,965 @@ func TestFactionsResource_parseFactionSymbol(t *testing.T) {
 		t.Error("Expected error for empty faction symbol")
 	}
 }
+
+func TestLedgerResource_Handler_Filters(t *testing.T) {
+	l := ledger.New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	l.Add(ledger.Entry{Timestamp: base, Category: ledger.CategoryMarketPurchase, ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE", Amount: -50})
+	l.Add(ledger.Entry{Timestamp: base.Add(time.Hour), Category: ledger.CategoryMarketSale, ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE", Amount: 120})
+	l.Add(ledger.Entry{Timestamp: base.Add(2 * time.Hour), Category: ledger.CategoryRepair, ShipSymbol: "SHIP-2", Amount: -200})
+
+	resource := NewLedgerResource(l, createMockLogger())
+	handler := resource.Handler()
+
+	uri := "spacetraders://ledger/transactions?ship=SHIP-1&since=2025-01-01T12%3A30%3A00Z"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected ledger template to match %s", uri)
+	}
+
+	contents, err := handler(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON text content, got %+v", contents[0])
+	}
+
+	var result struct {
+		Entries []ledger.Entry `json:"entries"`
+		Totals  ledger.Totals  `json:"totals"`
+	}
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+		t.Fatalf("Failed to parse ledger JSON: %v", err)
+	}
+
+	if len(result.Entries) != 1 || result.Entries[0].Amount != 120 {
+		t.Errorf("Expected only the SHIP-1 sale, got %+v", result.Entries)
+	}
+	if result.Totals.Net != 120 {
+		t.Errorf("Expected net 120, got %d", result.Totals.Net)
+	}
+}
+
+func TestLedgerResource_Handler_InvalidFilter(t *testing.T) {
+	resource := NewLedgerResource(ledger.New(), createMockLogger())
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://ledger/transactions?since=yesterday"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent := contents[0].(*mcp.TextResourceContents)
+	if textContent.MIMEType != "text/plain" || !contains(textContent.Text, "since") {
+		t.Errorf("Expected plain-text error about since, got %s", textContent.Text)
+	}
+}
+
+func TestDashboardResource_Handler_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/my/agent" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "unavailable", "code": 400}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 1500, "startingFaction": "COSMIC", "shipCount": 2}}`))
+	}))
+	defer server.Close()
+
+	l := ledger.New()
+	for i := 0; i < dashboardRecentEntries+2; i++ {
+		l.Add(ledger.Entry{Timestamp: time.Now().Add(time.Duration(i-20) * time.Minute), Category: ledger.CategoryMarketSale, ShipSymbol: "SHIP-1", Amount: i})
+	}
+
+	resource := NewDashboardResource(client.NewClientWithBaseURL("test-token", server.URL), l, nil, createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://dashboard"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textContent, ok := contents[0].(*mcp.TextResourceContents)
+	if !ok || textContent.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON text content, got %+v", contents[0])
+	}
+
+	var result map[string]json.RawMessage
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+		t.Fatalf("Failed to parse dashboard JSON: %v", err)
+	}
+
+	var agent struct {
+		Credits int64 `json:"credits"`
+	}
+	if err := json.Unmarshal(result["agent"], &agent); err != nil || agent.Credits != 1500 {
+		t.Errorf("Expected agent with 1500 credits, got %s", result["agent"])
+	}
+	if !contains(string(result["fleet"]), "error") || !contains(string(result["contracts"]), "error") {
+		t.Errorf("Expected fleet and contracts to report their errors, got %s and %s", result["fleet"], result["contracts"])
+	}
+	if _, ok := result["tasks"]; ok {
+		t.Error("Expected no tasks section without a task manager")
+	}
+
+	var ledgerSection struct {
+		Recent []ledger.Entry `json:"recent"`
+	}
+	if err := json.Unmarshal(result["ledger"], &ledgerSection); err != nil {
+		t.Fatalf("Failed to parse ledger section: %v", err)
+	}
+	if len(ledgerSection.Recent) != dashboardRecentEntries || ledgerSection.Recent[len(ledgerSection.Recent)-1].Amount != dashboardRecentEntries+1 {
+		t.Errorf("Expected the %d latest entries, got %+v", dashboardRecentEntries, ledgerSection.Recent)
+	}
+}
+
+func TestDashboard_SummarizeContract(t *testing.T) {
+	contract := client.Contract{
+		ID:   "contract-1",
+		Type: "PROCUREMENT",
+		Terms: client.ContractTerms{
+			Deadline: "2025-01-02T00:00:00.000Z",
+			Payment:  client.ContractPayment{OnAccepted: 1000, OnFulfilled: 9000},
+			Deliver: []client.ContractDeliverGood{
+				{TradeSymbol: "IRON_ORE", DestinationSymbol: "X1-TEST-B2", UnitsRequired: 30, UnitsFulfilled: 30},
+				{TradeSymbol: "COPPER_ORE", DestinationSymbol: "X1-TEST-B2", UnitsRequired: 10, UnitsFulfilled: 0},
+			},
+		},
+		Accepted: true,
+	}
+
+	summary := summarizeContract(contract)
+	if summary.Progress != 75 {
+		t.Errorf("Expected 75%% progress, got %d", summary.Progress)
+	}
+	if summary.OnFulfilled != 9000 || len(summary.Deliveries) != 2 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestFleetSummary_SummarizeShip(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ship := client.Ship{
+		Symbol:       "TEST_SHIP_1",
+		Registration: client.Registration{Role: "HAULER"},
+		Nav: client.Navigation{
+			SystemSymbol:   "X1-TEST",
+			WaypointSymbol: "X1-TEST-A1",
+			Status:         "IN_TRANSIT",
+			Route: client.Route{
+				Destination: client.Waypoint{Symbol: "X1-TEST-B2"},
+				Arrival:     now.Add(90 * time.Second).Format("2006-01-02T15:04:05.000Z"),
+			},
+		},
+		Cooldown: client.Cooldown{RemainingSeconds: 30},
+		Cargo:    client.Cargo{Capacity: 80, Units: 20},
+		Fuel:     client.Fuel{Current: 300, Capacity: 400},
+	}
+
+	row := summarizeShip(ship, "", now)
+
+	if row.FuelPercent != 75 {
+		t.Errorf("Expected fuel 75%%, got %d", row.FuelPercent)
+	}
+	if row.CargoPercent != 25 {
+		t.Errorf("Expected cargo 25%%, got %d", row.CargoPercent)
+	}
+	if row.CooldownSeconds != 30 {
+		t.Errorf("Expected 30s cooldown, got %d", row.CooldownSeconds)
+	}
+	if row.Destination != "X1-TEST-B2" {
+		t.Errorf("Expected destination X1-TEST-B2, got %s", row.Destination)
+	}
+	if row.ArrivalInSeconds == nil || *row.ArrivalInSeconds != 90 {
+		t.Errorf("Expected arrival in 90s, got %v", row.ArrivalInSeconds)
+	}
+
+	ship.Nav.Status = "DOCKED"
+	if docked := summarizeShip(ship, "", now); docked.ArrivalInSeconds != nil || docked.Destination != "" {
+		t.Errorf("Expected no route ETA for docked ship, got %+v", docked)
+	}
+}
+
+func TestFleetSummary_DerivesStatus(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ship := func(navStatus string, cooldown, cargoUnits, fuel int) client.Ship {
+		return client.Ship{
+			Symbol:   "TEST_SHIP_1",
+			Nav:      client.Navigation{Status: navStatus},
+			Cooldown: client.Cooldown{RemainingSeconds: cooldown},
+			Cargo:    client.Cargo{Capacity: 40, Units: cargoUnits},
+			Fuel:     client.Fuel{Current: fuel, Capacity: 400},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		ship     client.Ship
+		behavior string
+		want     string
+	}{
+		{"idle", ship("DOCKED", 0, 10, 400), "", "idle"},
+		{"in transit", ship("IN_TRANSIT", 0, 10, 400), "mine_loop", "in transit"},
+		{"mining on cooldown", ship("IN_ORBIT", 45, 10, 400), "mine_loop", "mining, cooling down"},
+		{"idle with a full hold", ship("IN_ORBIT", 0, 40, 400), "", "idle, cargo full"},
+		{"low on fuel", ship("DOCKED", 0, 0, 40), "", "idle, low fuel"},
+		{"trading", ship("DOCKED", 0, 40, 400), "trade_loop", "trading, cargo full"},
+		{"unknown behavior", ship("DOCKED", 0, 0, 400), "patrol", "running patrol"},
+		{"everything at once", ship("IN_TRANSIT", 10, 40, 0), "", "in transit, cooling down, cargo full, low fuel"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			row := summarizeShip(tc.ship, tc.behavior, now)
+			if row.Status != tc.want {
+				t.Errorf("Expected status %q, got %q", tc.want, row.Status)
+			}
+			if row.NavStatus != tc.ship.Nav.Status || row.Task != tc.behavior {
+				t.Errorf("Expected the nav status and task alongside, got %+v", row)
+			}
+		})
+	}
+}
+
+func TestJumpGateGraphResource_Handler_CrawlsFromFleet(t *testing.T) {
+	gates := map[string]string{
+		"/systems/X1-A/waypoints/X1-A-GATE/jump-gate": `{"symbol": "X1-A-GATE", "connections": ["X1-B-GATE"]}`,
+		"/systems/X1-B/waypoints/X1-B-GATE/jump-gate": `{"symbol": "X1-B-GATE", "connections": ["X1-A-GATE", "X1-C-GATE"]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [{"symbol": "SHIP-1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-A1", "status": "DOCKED"}}], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case r.URL.Path == "/systems/X1-A/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-A-A1", "type": "PLANET", "systemSymbol": "X1-A", "x": 0, "y": 0},
+				{"symbol": "X1-A-GATE", "type": "JUMP_GATE", "systemSymbol": "X1-A", "x": 5, "y": 5}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		case gates[r.URL.Path] != "":
+			_, _ = w.Write([]byte(`{"data": ` + gates[r.URL.Path] + `}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "Waypoint is not charted", "code": 4001}}`))
+		}
+	}))
+	defer server.Close()
+
+	resource := NewJumpGateGraphResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://universe/jumpgate-graph"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var result struct {
+		Connections map[string][]string `json:"connections"`
+		Unexplored  []string            `json:"unexplored"`
+		Systems     []string            `json:"systems"`
+	}
+	textContent := contents[0].(*mcp.TextResourceContents)
+	if _, err := decodeEnvelope(textContent.Text, &result); err != nil {
+		t.Fatalf("Failed to parse jump gate graph JSON: %v (%s)", err, textContent.Text)
+	}
+
+	if len(result.Connections) != 2 {
+		t.Errorf("Expected 2 explored gates, got %v", result.Connections)
+	}
+	if len(result.Unexplored) != 1 || result.Unexplored[0] != "X1-C-GATE" {
+		t.Errorf("Expected the uncharted gate X1-C-GATE to be unexplored, got %v", result.Unexplored)
+	}
+	if len(result.Systems) != 3 {
+		t.Errorf("Expected 3 systems, got %v", result.Systems)
+	}
+}
+
+func TestSessionSummaryResource_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/agent":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "TEST_AGENT", "headquarters": "X1-TEST-A1", "credits": 130000, "startingFaction": "COSMIC", "shipCount": 3}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": [], "meta": {"total": 0, "page": 1, "limit": 20}}`))
+		}
+	}))
+	defer server.Close()
+
+	recorder := session.NewRecorder()
+	recorder.SetStartingCredits(150000)
+	recorder.Observe(client.Observation{
+		Kind:                client.ObservedShipyardTransaction,
+		ObservedAt:          time.Now(),
+		ShipyardTransaction: &client.Transaction{ShipSymbol: "TEST_AGENT-3", ShipType: "SHIP_PROBE", WaypointSymbol: "X1-TEST-A1", Price: 20000},
+	})
+	resource := NewSessionSummaryResource(client.NewClientWithBaseURL("test-token", server.URL), recorder, nil, createMockLogger())
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: sessionSummaryResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var summary struct {
+		Credits struct {
+			AtStart int64 `json:"atStart"`
+			Now     int64 `json:"now"`
+			Change  int64 `json:"change"`
+		} `json:"credits"`
+		Ships struct {
+			Count  int      `json:"count"`
+			Bought []string `json:"bought"`
+		} `json:"ships"`
+		Events []session.Event `json:"events"`
+	}
+	if _, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Credits.Change != -20000 || summary.Credits.Now != 130000 {
+		t.Errorf("Expected a change of -20000 credits, got %+v", summary.Credits)
+	}
+	if summary.Ships.Count != 3 || len(summary.Ships.Bought) != 1 || summary.Ships.Bought[0] != "TEST_AGENT-3" {
+		t.Errorf("Expected the bought ship, got %+v", summary.Ships)
+	}
+	if len(summary.Events) != 1 || summary.Events[0].Kind != session.EventShipPurchased {
+		t.Errorf("Expected the purchase event, got %+v", summary.Events)
+	}
+}
+
+func TestRecentEventsResource_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"fuel": {}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_TRANSIT"}, "events": [
+			{"symbol": "ENGINE_ACTUATOR_FAILURE", "component": "ENGINE", "name": "Engine Actuator Failure", "description": "The engine actuators strained."},
+			{"symbol": "REACTOR_OVERLOAD", "component": "REACTOR", "name": "Reactor Overload", "description": "The reactor ran hot."}
+		]}}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	log := events.New()
+	c.AddObserver(log.Observe)
+
+	// Two navigations give the ship four events, enough to be flagged
+	for i := 0; i < 2; i++ {
+		if _, err := c.NavigateShip("TEST-1", "X1-TEST-A1"); err != nil {
+			t.Fatalf("Unexpected navigation error: %v", err)
+		}
+	}
+
+	resource := NewRecentEventsResource(log, createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: recentEventsResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var data struct {
+		Events  []events.Event      `json:"events"`
+		Ships   []events.ShipDamage `json:"ships"`
+		Flagged []string            `json:"flagged"`
+		Advice  string              `json:"advice"`
+	}
+	if _, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Events) != 4 || data.Events[0].ShipSymbol != "TEST-1" || data.Events[0].Timestamp.IsZero() {
+		t.Errorf("Expected every event with its ship and time, got %+v", data.Events)
+	}
+	if len(data.Ships) != 1 || data.Ships[0].ByComponent["ENGINE"] != 2 {
+		t.Errorf("Expected the damage summed per component, got %+v", data.Ships)
+	}
+	if len(data.Flagged) != 1 || data.Flagged[0] != "TEST-1" || !strings.Contains(data.Advice, "repair_ship") {
+		t.Errorf("Expected TEST-1 flagged for repair, got %v %q", data.Flagged, data.Advice)
+	}
+}
+
+func TestRateLimitResource_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		w.Header().Set("X-Ratelimit-Limit-Burst", "30")
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": {"message": "You have reached your API limit.", "code": 429}}`))
+	}))
+	defer server.Close()
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	resource := NewRateLimitResource(c, createMockLogger())
+
+	read := func() map[string]interface{} {
+		t.Helper()
+		contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{URI: "spacetraders://api/rate-limit"},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned error: %v", err)
+		}
+		var data map[string]interface{}
+		if _, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &data); err != nil {
+			t.Fatalf("Failed to parse response JSON: %v", err)
+		}
+		return data
+	}
+
+	if data := read(); data["observed"] != false {
+		t.Errorf("Expected nothing observed before any request, got %v", data)
+	}
+
+	if _, err := c.GetAgent(); err == nil {
+		t.Fatal("Expected the throttled request to fail")
+	}
+	data := read()
+	rateLimit, ok := data["rateLimit"].(map[string]interface{})
+	if !ok || rateLimit["remaining"] != float64(0) || rateLimit["burst"] != float64(30) || rateLimit["retryAfterSeconds"] != float64(2) {
+		t.Errorf("Unexpected rate limit %v", data["rateLimit"])
+	}
+	if data["throttled"] != true || data["advice"] == nil {
+		t.Errorf("Expected a recent 429 to be flagged with advice, got %v", data)
+	}
+	if _, ok := data["secondaryTokens"]; ok {
+		t.Errorf("Expected no secondary tokens without any configured, got %v", data["secondaryTokens"])
+	}
+
+	c.SetSecondaryTokens([]string{"alt-token"})
+	if secondary, ok := read()["secondaryTokens"].([]interface{}); !ok || len(secondary) != 1 {
+		t.Errorf("Expected the secondary token reported, got %v", secondary)
+	}
+}
+
+func TestFleetResources_MergeShipMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"symbol": "SHIP-1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-A1", "status": "DOCKED"}},
+			{"symbol": "SHIP-2", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-B2", "status": "IN_ORBIT"}}
+		], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+	}))
+	defer server.Close()
+
+	store, _ := shipmeta.Open("")
+	notes := "Works the asteroid field"
+	_, _ = store.SetLabel("SHIP-2", "Ore Hauler #2", &notes)
+	_, _ = store.Tag("SHIP-2", []string{"mining"}, nil)
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	read := func(handler ResourceHandler, uri string) *mcp.TextResourceContents {
+		t.Helper()
+		contents, err := handler.Handler()(context.Background(), mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{URI: uri},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned error: %v", err)
+		}
+		return contents[0].(*mcp.TextResourceContents)
+	}
+
+	var ships []labeledShip
+	text := read(NewShipsResource(c, createMockLogger()).WithShipMeta(store), "spacetraders://ships/list")
+	if _, err := decodeEnvelope(text.Text, &ships); err != nil {
+		t.Fatalf("Failed to parse ships list: %v", err)
+	}
+	if len(ships) != 2 || ships[0].Label != "" || ships[1].Label != "Ore Hauler #2" || ships[1].Notes != notes {
+		t.Errorf("Expected only SHIP-2 to carry its label and notes, got %+v", ships)
+	}
+	if ships[1].Nav.WaypointSymbol != "X1-A-B2" {
+		t.Errorf("Expected the ship fields to stay at the top level, got %+v", ships[1])
+	}
+
+	fleet := NewFleetSummaryResource(c, createMockLogger()).WithShipMeta(store)
+	var summary struct {
+		Ships []fleetSummaryRow `json:"ships"`
+	}
+	text = read(fleet, fleetSummaryResourceURI+"?tag=Mining")
+	if _, err := decodeEnvelope(text.Text, &summary); err != nil {
+		t.Fatalf("Failed to parse fleet summary: %v", err)
+	}
+	if len(summary.Ships) != 1 || summary.Ships[0].Symbol != "SHIP-2" || summary.Ships[0].Label != "Ore Hauler #2" {
+		t.Errorf("Expected only the ship tagged mining, got %+v", summary.Ships)
+	}
+	if !fleet.ResourceTemplate().URITemplate.Regexp().MatchString(fleetSummaryResourceURI + "?tag=mining") {
+		t.Error("Expected the tag template to match a tag filter")
+	}
+
+	if text = read(NewFleetSummaryResource(c, createMockLogger()), fleetSummaryResourceURI+"?tag=mining"); text.MIMEType != "text/plain" {
+		t.Errorf("Expected a tag filter without ship metadata to be refused, got %s", text.Text)
+	}
+}
+
+func TestSquadronsResource_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"symbol": "DRONE-1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-B4", "status": "IN_ORBIT"}},
+			{"symbol": "HAULER-1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-A1", "status": "DOCKED"}}
+		], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+	}))
+	defer server.Close()
+
+	store, _ := shipmeta.Open("")
+	_, _ = store.SaveSquadron("miners", []string{"DRONE-1", "DRONE-9"})
+	_, _ = store.SetLabel("DRONE-1", "Rock Biter", nil)
+
+	resource := NewSquadronsResource(client.NewClientWithBaseURL("test-token", server.URL), store, createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: squadronsResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned error: %v", err)
+	}
+	text := contents[0].(*mcp.TextResourceContents)
+	if text.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON, got %s", text.Text)
+	}
+
+	var squadrons []squadronView
+	if _, err := decodeEnvelope(text.Text, &squadrons); err != nil {
+		t.Fatalf("Failed to parse squadrons: %v", err)
+	}
+	if len(squadrons) != 1 || squadrons[0].Name != "miners" {
+		t.Fatalf("Expected the miners squadron, got %+v", squadrons)
+	}
+	miners := squadrons[0]
+	if len(miners.Ships) != 1 || miners.Ships[0].Symbol != "DRONE-1" || miners.Ships[0].Label != "Rock Biter" {
+		t.Errorf("Expected DRONE-1 with its label, got %+v", miners.Ships)
+	}
+	if len(miners.Missing) != 1 || miners.Missing[0] != "DRONE-9" || miners.ByStatus["idle"] != 1 {
+		t.Errorf("Expected DRONE-9 missing and one idle ship, got %+v", miners)
+	}
+}
+
+func TestFleetCargoResource_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "HAULER-1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-A1", "status": "DOCKED"},
+					"cargo": {"capacity": 40, "units": 30, "inventory": [
+						{"symbol": "ALUMINUM_ORE", "name": "Aluminum Ore", "units": 20},
+						{"symbol": "IRON_ORE", "name": "Iron Ore", "units": 10}
+					]}},
+				{"symbol": "DRONE-1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-B4", "status": "IN_ORBIT"},
+					"cargo": {"capacity": 15, "units": 15, "inventory": [{"symbol": "ALUMINUM_ORE", "name": "Aluminum Ore", "units": 15}]}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		case "/my/contracts":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "c-1", "accepted": true, "fulfilled": false, "terms": {"deliver": [
+					{"tradeSymbol": "ALUMINUM_ORE", "destinationSymbol": "X1-A-C3", "unitsRequired": 60, "unitsFulfilled": 10}
+				]}},
+				{"id": "c-2", "accepted": false, "fulfilled": false, "terms": {"deliver": [
+					{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-A-C3", "unitsRequired": 5, "unitsFulfilled": 0}
+				]}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resource := NewFleetCargoResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: fleetCargoResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned error: %v", err)
+	}
+	text := contents[0].(*mcp.TextResourceContents)
+	if text.MIMEType != "application/json" {
+		t.Fatalf("Expected JSON, got %s", text.Text)
+	}
+
+	var manifest fleetCargo
+	if _, err := decodeEnvelope(text.Text, &manifest); err != nil {
+		t.Fatalf("Failed to parse fleet cargo: %v", err)
+	}
+	if len(manifest.Goods) != 2 || manifest.Goods[0].Symbol != "ALUMINUM_ORE" || manifest.Goods[0].TotalUnits != 35 {
+		t.Fatalf("Expected 35 ALUMINUM_ORE listed first, got %+v", manifest.Goods)
+	}
+	if holders := manifest.Goods[0].Holders; len(holders) != 2 || holders[0].ShipSymbol != "HAULER-1" || holders[1].Waypoint != "X1-A-B4" {
+		t.Errorf("Expected both holders, largest first, got %+v", holders)
+	}
+	if manifest.Totals.Capacity != 55 || manifest.Totals.Units != 45 || manifest.Totals.Free != 10 || manifest.Ships[1].Free != 0 {
+		t.Errorf("Expected capacity totals and free space per ship, got %+v %+v", manifest.Totals, manifest.Ships)
+	}
+	want := cargoContractNeed{ContractID: "c-1", TradeSymbol: "ALUMINUM_ORE", Destination: "X1-A-C3", UnitsRemaining: 50, UnitsHeld: 35, Shortfall: 15}
+	if len(manifest.ContractNeeds) != 1 || manifest.ContractNeeds[0] != want {
+		t.Errorf("Expected only the accepted contract's shortfall %+v, got %+v", want, manifest.ContractNeeds)
+	}
+}
+
+func TestTimersResource_Handler_SoonestFirst(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) string { return now.Add(d).UTC().Format(time.RFC3339) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "SHIP-1", "nav": {"waypointSymbol": "X1-TEST-B2", "status": "IN_TRANSIT", "route": {"origin": {"symbol": "X1-TEST-A1"}, "arrival": "` + at(10*time.Minute) + `"}}},
+				{"symbol": "SHIP-2", "nav": {"waypointSymbol": "X1-TEST-C3", "status": "IN_ORBIT"}, "cooldown": {"shipSymbol": "SHIP-2", "totalSeconds": 70, "remainingSeconds": 60, "expiration": "` + at(time.Minute) + `"}},
+				{"symbol": "SHIP-3", "nav": {"waypointSymbol": "X1-TEST-C3", "status": "DOCKED"}}
+			], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+		case "/my/contracts":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "contract-1", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "accepted": true, "terms": {"deadline": "` + at(48*time.Hour) + `"}},
+				{"id": "contract-2", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "deadlineToAccept": "` + at(-time.Hour) + `", "terms": {}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tracker := cooldowns.NewTracker()
+	tracker.Observe(client.Observation{Kind: client.ObservedCooldown, ShipSymbol: "SHIP-2", Action: "extraction", ObservedAt: now,
+		Cooldown: &client.Cooldown{TotalSeconds: 70, Expiration: at(time.Minute)}})
+	resource := NewTimersResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger()).WithCooldowns(tracker)
+
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://timers"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var data struct {
+		Timers      []timer `json:"timers"`
+		ShipsReady  int     `json:"ships_ready"`
+		WaitSeconds int     `json:"wait_seconds"`
+	}
+	if _, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &data); err != nil {
+		t.Fatalf("Failed to parse timers JSON: %v", err)
+	}
+
+	// The expired offer is left out
+	if len(data.Timers) != 3 {
+		t.Fatalf("Expected a cooldown, an arrival and a contract deadline, got %+v", data.Timers)
+	}
+	kinds := []string{data.Timers[0].Kind, data.Timers[1].Kind, data.Timers[2].Kind}
+	if kinds[0] != timerCooldown || kinds[1] != timerArrival || kinds[2] != timerContractDeadline {
+		t.Errorf("Expected the timers soonest first, got %v", kinds)
+	}
+	if data.Timers[0].Detail != "cooldown from extraction" || data.WaitSeconds < 55 || data.WaitSeconds > 60 {
+		t.Errorf("Expected the tracked extraction cooldown a minute out, got %+v waiting %d", data.Timers[0], data.WaitSeconds)
+	}
+	if data.ShipsReady != 1 {
+		t.Errorf("Expected only SHIP-3 ready, got %d", data.ShipsReady)
+	}
+}
+
+func TestShipPartResources_AnswerFromFleetState(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+
+	model := fleetstate.New(context.Background(), c, createMockLogger())
+	model.Observe(client.Observation{Kind: client.ObservedFleet, ObservedAt: time.Now(), Ships: []client.Ship{
+		{Symbol: "SHIP-1", Cargo: client.Cargo{Capacity: 40}},
+	}})
+	model.Observe(client.Observation{Kind: client.ObservedCargo, ShipSymbol: "SHIP-1", Cargo: &client.Cargo{
+		Capacity: 40, Units: 10, Inventory: []client.CargoItem{{Symbol: "IRON_ORE", Units: 10}},
+	}})
+
+	contents, err := NewShipCargoResource(c, createMockLogger()).WithFleetState(model).Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://ships/SHIP-1/cargo"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var data struct {
+		Cargo     client.Cargo `json:"cargo"`
+		FreeUnits int          `json:"freeUnits"`
+	}
+	meta, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &data)
+	if err != nil {
+		t.Fatalf("Failed to decode envelope: %v", err)
+	}
+	if requests != 0 || meta.Source != sourceLocal {
+		t.Errorf("Expected a local read without API requests, got source %q after %d requests", meta.Source, requests)
+	}
+	if data.Cargo.Units != 10 || data.FreeUnits != 30 {
+		t.Errorf("Expected the cargo applied after the listing, got %d units and %d free", data.Cargo.Units, data.FreeUnits)
+	}
+
+	// Ships the model doesn't know are still fetched from the API
+	if _, err := NewShipCargoResource(c, createMockLogger()).WithFleetState(model).Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://ships/SHIP-9/cargo"},
+	}); err != nil || requests == 0 {
+		t.Errorf("Expected an unknown ship to be fetched from the API, got %d requests (err %v)", requests, err)
+	}
+}
+
+func TestTradeMapResource_Handler(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		marketplace := `[{"symbol": "MARKETPLACE", "name": "Marketplace", "description": ""}]`
+		switch r.URL.Path {
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 0, "y": 0, "traits": ` + marketplace + `},
+				{"symbol": "X1-TEST-B2", "systemSymbol": "X1-TEST", "type": "MOON", "x": 10, "y": 0, "traits": ` + marketplace + `},
+				{"symbol": "X1-TEST-C3", "systemSymbol": "X1-TEST", "type": "MOON", "x": 20, "y": 0, "traits": ` + marketplace + `},
+				{"symbol": "X1-TEST-D4", "systemSymbol": "X1-TEST", "type": "ASTEROID", "x": 30, "y": 0, "traits": []}
+			], "meta": {"total": 4, "page": 1, "limit": 20}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "exports": [{"symbol": "IRON"}], "imports": [{"symbol": "IRON_ORE"}], "exchange": [{"symbol": "FUEL"}]}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-B2/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-B2", "exports": [{"symbol": "IRON_ORE"}], "imports": [{"symbol": "MACHINERY"}], "exchange": []}}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error": {"message": "Bad gateway", "code": 502}}`))
+		}
+	}))
+	defer server.Close()
+
+	resource := NewTradeMapResource(client.NewClientWithBaseURL("test-token", server.URL), createMockLogger())
+	uri := "spacetraders://systems/X1-TEST/trade-map"
+	if !resource.ResourceTemplate().URITemplate.Regexp().MatchString(uri) {
+		t.Fatalf("Expected trade map template to match %s", uri)
+	}
+
+	var result struct {
+		Goods       map[string]GoodTraders `json:"goods"`
+		Markets     []string               `json:"markets"`
+		Unavailable map[string]string      `json:"unavailable"`
+		Summary     struct {
+			TradableLocally []string `json:"tradableLocally"`
+		} `json:"summary"`
+	}
+	readResource(t, resource, uri, &result)
+
+	ironOre := result.Goods["IRON_ORE"]
+	if len(ironOre.Producers) != 1 || ironOre.Producers[0] != "X1-TEST-B2" || len(ironOre.Consumers) != 1 || ironOre.Consumers[0] != "X1-TEST-A1" {
+		t.Errorf("Expected IRON_ORE produced at B2 and consumed at A1, got %+v", ironOre)
+	}
+	if fuel := result.Goods["FUEL"]; len(fuel.Exchanges) != 1 || len(fuel.Producers) != 0 {
+		t.Errorf("Expected FUEL exchanged at A1 only, got %+v", fuel)
+	}
+	if got := strings.Join(result.Summary.TradableLocally, ","); got != "IRON_ORE" {
+		t.Errorf("Expected only IRON_ORE to be tradable within the system, got %s", got)
+	}
+	if len(result.Markets) != 2 || result.Unavailable["X1-TEST-C3"] == "" {
+		t.Errorf("Expected two mapped markets and C3 unavailable, got %v %v", result.Markets, result.Unavailable)
+	}
+
+	// Listings are cached, so a second read only retries the market that failed
+	if meta := readResource(t, resource, uri, &result); meta.Source != sourceCache {
+		t.Errorf("Expected the second map to come from the cache, got %+v", meta)
+	}
+	if requests["/systems/X1-TEST/waypoints/X1-TEST-A1/market"] != 1 || requests["/systems/X1-TEST/waypoints"] != 1 {
+		t.Errorf("Expected cached waypoints and markets to be reused, got %v", requests)
+	}
+}
+
+func TestFuelIndexResource_Handler(t *testing.T) {
+	db := prices.New()
+	seen := time.Now().Add(-10 * time.Minute)
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: seen, Prices: []prices.Price{{TradeSymbol: "FUEL", PurchasePrice: 80, Supply: "MODERATE"}}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-B2", ObservedAt: seen, Prices: []prices.Price{{TradeSymbol: "FUEL", PurchasePrice: 64, Supply: "ABUNDANT"}}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-OTHER-C3", ObservedAt: seen, Prices: []prices.Price{{TradeSymbol: "FUEL", PurchasePrice: 90}}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-OTHER-D4", ObservedAt: seen, Prices: []prices.Price{{TradeSymbol: "IRON_ORE", PurchasePrice: 20}}})
+
+	resource := NewFuelIndexResource(db, createMockLogger())
+	contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: fuelIndexResourceURI},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var data struct {
+		Systems []systemFuelIndex `json:"systems"`
+	}
+	meta, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Count != 2 || len(data.Systems) != 2 || data.Systems[0].SystemSymbol != "X1-OTHER" || data.Systems[1].SystemSymbol != "X1-TEST" {
+		t.Fatalf("Expected X1-OTHER and X1-TEST in order, got %+v", data.Systems)
+	}
+	test := data.Systems[1]
+	if test.Cheapest.WaypointSymbol != "X1-TEST-B2" || test.Cheapest.PurchasePrice != 64 || len(test.Stations) != 2 {
+		t.Errorf("Expected B2 cheapest of two stations in X1-TEST, got %+v", test)
+	}
+	if test.Cheapest.AgeSeconds < 590 {
+		t.Errorf("Expected the age of the price, got %d", test.Cheapest.AgeSeconds)
+	}
+}
+
+func TestAccountResource_Handler(t *testing.T) {
+	accountReadable := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/account":
+			if !accountReadable {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error": {"message": "This endpoint requires an account token", "code": 4103}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"account": {"id": "account-1", "email": "pilot@example.com", "createdAt": "2025-01-01T00:00:00.000Z"}}}`))
+		case "/my/agent":
+			_, _ = w.Write([]byte(`{"data": {"accountId": "account-1", "symbol": "TEST_AGENT", "headquarters": "X1-TEST-A1", "credits": 1000, "startingFaction": "COSMIC", "shipCount": 2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"identifier": "TEST_AGENT", "reset_date": "2025-06-01", "sub": "agent-token"}`)) + ".signature"
+	resource := NewAccountResource(client.NewClientWithBaseURL(token, server.URL), createMockLogger())
+	read := func() map[string]interface{} {
+		t.Helper()
+		contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{URI: accountResourceURI},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var data map[string]interface{}
+		if _, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, &data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	data := read()
+	account, _ := data["account"].(map[string]interface{})
+	if account["id"] != "account-1" || account["email"] != "pilot@example.com" {
+		t.Errorf("Expected the account, got %+v", data)
+	}
+	tokenInfo, _ := data["token"].(map[string]interface{})
+	if tokenInfo["identifier"] != "TEST_AGENT" || tokenInfo["resetDate"] != "2025-06-01" {
+		t.Errorf("Expected the token claims, got %+v", data)
+	}
+	if raw, _ := json.Marshal(data); strings.Contains(string(raw), token) {
+		t.Error("Expected the token itself never to be shown")
+	}
+	if data["tokenMatchesAgent"] != true || data["agentMatchesAccount"] != true {
+		t.Errorf("Expected the token, agent and account to agree, got %+v", data)
+	}
+
+	// An agent token that may not read the account still shows the agent and token
+	accountReadable = false
+	data = read()
+	if _, ok := data["account"]; ok || !strings.Contains(data["accountError"].(string), "account token") {
+		t.Errorf("Expected the account error, got %+v", data)
+	}
+	if data["tokenMatchesAgent"] != true {
+		t.Errorf("Expected the token checked against the agent, got %+v", data)
+	}
+}
+
+func TestCatalogResources_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "imports": [], "exchange": [],
+				"exports": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "A common ore"}]}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-B2/shipyard":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-B2", "modificationsFee": 100,
+				"shipTypes": [{"type": "SHIP_PROBE"}, {"type": "SHIP_LIGHT_HAULER"}],
+				"ships": [{"type": "SHIP_PROBE", "name": "Probe", "description": "A small satellite", "supply": "ABUNDANT", "purchasePrice": 20000,
+					"frame": {"symbol": "FRAME_PROBE", "name": "Probe", "description": "", "moduleSlots": 0, "mountingPoints": 0, "fuelCapacity": 0, "condition": 1, "integrity": 1, "quality": 1, "requirements": {}},
+					"reactor": {"symbol": "REACTOR_SOLAR_I", "name": "Solar", "description": "", "powerOutput": 3, "condition": 1, "integrity": 1, "quality": 1, "requirements": {}},
+					"engine": {"symbol": "ENGINE_IMPULSE_DRIVE_I", "name": "Impulse", "description": "", "speed": 3, "condition": 1, "integrity": 1, "quality": 1, "requirements": {}},
+					"modules": [], "mounts": [], "crew": {"required": 0, "capacity": 0}}]}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+
+	read := func(resource *CatalogResource, uri string, data interface{}) Meta {
+		t.Helper()
+		contents, err := resource.Handler()(context.Background(), mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{URI: uri},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		meta, err := decodeEnvelope(contents[0].(*mcp.TextResourceContents).Text, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return meta
+	}
+
+	var goods struct {
+		TradeGoods []catalogEntry `json:"tradeGoods"`
+		Described  int            `json:"described"`
+	}
+	goodsResource := NewTradeGoodsCatalogResource(c, createMockLogger())
+	if meta := read(goodsResource, tradeGoodsCatalogURI, &goods); meta.Count < 100 || meta.Count != len(goods.TradeGoods) || goods.Described != 0 {
+		t.Fatalf("Expected every trade symbol without names, got %d described of %d", goods.Described, meta.Count)
+	}
+	for i := 1; i < len(goods.TradeGoods); i++ {
+		if goods.TradeGoods[i-1].Symbol >= goods.TradeGoods[i].Symbol {
+			t.Fatalf("Expected trade goods sorted by symbol, got %s before %s", goods.TradeGoods[i-1].Symbol, goods.TradeGoods[i].Symbol)
+		}
+	}
+
+	if _, err := c.GetMarket("X1-TEST", "X1-TEST-A1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.GetShipyard("X1-TEST", "X1-TEST-B2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	read(goodsResource, tradeGoodsCatalogURI, &goods)
+	if goods.Described != 1 {
+		t.Errorf("Expected one described good, got %d", goods.Described)
+	}
+	for _, good := range goods.TradeGoods {
+		if good.Symbol == "IRON_ORE" && (good.Name != "Iron Ore" || good.Description != "A common ore") {
+			t.Errorf("Expected the market's name for IRON_ORE, got %+v", good)
+		}
+	}
+
+	var ships struct {
+		ShipTypes []catalogEntry `json:"shipTypes"`
+		Described int            `json:"described"`
+	}
+	read(NewShipTypesCatalogResource(c, createMockLogger()), shipTypesCatalogURI, &ships)
+	if ships.Described != 1 {
+		t.Errorf("Expected one described ship type, got %d", ships.Described)
+	}
+	found := 0
+	for _, ship := range ships.ShipTypes {
+		switch ship.Symbol {
+		case "SHIP_PROBE":
+			found++
+			if ship.Name != "Probe" || len(ship.SoldAt) != 1 || ship.SoldAt[0] != "X1-TEST-B2" {
+				t.Errorf("Expected the probe named and sold at B2, got %+v", ship)
+			}
+		case "SHIP_LIGHT_HAULER":
+			found++
+			if ship.Name != "" || len(ship.SoldAt) != 1 {
+				t.Errorf("Expected the hauler sold at B2 without a name, got %+v", ship)
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf("Expected both ship types listed, got %+v", ships.ShipTypes)
+	}
+}
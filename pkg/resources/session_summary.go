@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/session"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const sessionSummaryResourceURI = "spacetraders://session/summary"
+
+// SessionSummaryResource rolls up what changed since the server started, so a new conversation
+// can pick up where the last one left off without refetching everything
+type SessionSummaryResource struct {
+	client   *client.Client
+	recorder *session.Recorder
+	ledger   *ledger.Ledger
+	logger   *logging.Logger
+}
+
+// NewSessionSummaryResource creates a new session summary resource handler. The ledger is
+// optional; its totals are left out when nil.
+func NewSessionSummaryResource(client *client.Client, recorder *session.Recorder, ledger *ledger.Ledger, logger *logging.Logger) *SessionSummaryResource {
+	return &SessionSummaryResource{
+		client:   client,
+		recorder: recorder,
+		ledger:   ledger,
+		logger:   logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *SessionSummaryResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         sessionSummaryResourceURI,
+		Name:        "Session Summary",
+		Description: "Handoff for a new conversation: what changed since the server started (credits gained or lost, ships bought and scrapped, contracts accepted and fulfilled, notable events) plus the contracts still in progress",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *SessionSummaryResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != sessionSummaryResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "session-summary-resource")
+		c := r.client.WithContext(ctx)
+		now := time.Now()
+		summary := r.recorder.Summary()
+
+		result := map[string]interface{}{
+			"startedAt": summary.StartedAt,
+			"uptime":    now.Sub(summary.StartedAt).Round(time.Second).String(),
+			"events":    summary.Events,
+		}
+
+		// Each section reports its own error so one failing call does not hide the rest
+		credits := map[string]interface{}{}
+		if summary.StartingCredits != nil {
+			credits["atStart"] = *summary.StartingCredits
+		}
+		shipCount := 0
+		if agent, err := c.GetAgent(); err != nil {
+			ctxLogger.Error("Failed to fetch agent: %v", err)
+			credits["error"] = err.Error()
+		} else {
+			credits["now"] = agent.Credits
+			if summary.StartingCredits != nil {
+				credits["change"] = agent.Credits - *summary.StartingCredits
+			}
+			shipCount = agent.ShipCount
+		}
+		result["credits"] = credits
+
+		result["ships"] = map[string]interface{}{
+			"count":  shipCount,
+			"bought": summary.ShipsBought,
+			"lost":   summary.ShipsLost,
+		}
+
+		contracts := map[string]interface{}{
+			"accepted":  summary.ContractsAccepted,
+			"fulfilled": summary.ContractsFulfilled,
+		}
+		if all, err := c.GetAllContracts(); err != nil {
+			ctxLogger.Error("Failed to fetch contracts: %v", err)
+			contracts["error"] = err.Error()
+		} else {
+			active := make([]dashboardContract, 0)
+			for _, contract := range all {
+				if contract.Accepted && !contract.Fulfilled {
+					active = append(active, summarizeContract(contract))
+				}
+			}
+			contracts["active"] = active
+		}
+		result["contracts"] = contracts
+
+		if r.ledger != nil {
+			entries := r.ledger.Query(ledger.Filter{Since: summary.StartedAt})
+			totals := ledger.ComputeTotals(entries)
+			result["ledger"] = map[string]interface{}{
+				"income":       totals.Income,
+				"expense":      totals.Expense,
+				"net":          totals.Net,
+				"transactions": len(entries),
+				"byActivity":   ledger.ComputeBreakdown(entries),
+			}
+		}
+
+		envelope := newEnvelope(result, 1, sourceLive, now,
+			Link{Rel: "dashboard", URI: dashboardResourceURI},
+			Link{Rel: "contracts", URI: "spacetraders://contracts/list"},
+		)
+		if r.ledger != nil {
+			envelope.Links = append(envelope.Links, Link{Rel: "ledger", URI: ledgerResourceURI})
+		}
+
+		// Not indented: the summary is meant to seed a new conversation, so it is kept small
+		jsonData, err := json.Marshal(envelope)
+		if err != nil {
+			ctxLogger.Error("Failed to marshal session summary to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting session summary",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
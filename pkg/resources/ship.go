@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/fleetstate"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -17,6 +19,8 @@ import (
 // ShipResource handles individual ship information resources
 type ShipResource struct {
 	client *client.Client
+	meta   *shipmeta.Store
+	fleet  *fleetstate.Model
 	logger *logging.Logger
 }
 
@@ -28,6 +32,18 @@ func NewShipResource(client *client.Client, logger *logging.Logger) *ShipResourc
 	}
 }
 
+// WithShipMeta adds the label, notes and tags recorded for the ship
+func (r *ShipResource) WithShipMeta(store *shipmeta.Store) *ShipResource {
+	r.meta = store
+	return r
+}
+
+// WithFleetState answers from the local fleet model while it is fresh instead of asking the API
+func (r *ShipResource) WithFleetState(model *fleetstate.Model) *ShipResource {
+	r.fleet = model
+	return r
+}
+
 // Resource returns the MCP resource definition
 func (r *ShipResource) Resource() mcp.Resource {
 	return mcp.Resource{
@@ -38,11 +54,41 @@ func (r *ShipResource) Resource() mcp.Resource {
 	}
 }
 
+// ResourceTemplate returns the parameterized form of the individual ship resource
+func (r *ShipResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://ships/{shipSymbol}"+detailQuery,
+		"Individual Ship Details",
+		mcp.WithTemplateDescription("Detailed information about a specific ship by symbol. detail=summary drops component descriptions and requirements; detail=full (the default) keeps the complete payload"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *ShipResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		// Extract ship symbol from URI
-		shipSymbol := r.extractShipSymbol(request.Params.URI)
+		// Extract ship symbol and detail level from URI
+		uri, query, err := splitQuery(request.Params.URI, "detail")
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid ship resource URI: " + err.Error(),
+				},
+			}, nil
+		}
+		detail, err := detailFromQuery(query)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid ship resource URI: " + err.Error(),
+				},
+			}, nil
+		}
+		shipSymbol := r.extractShipSymbol(uri)
 		if shipSymbol == "" {
 			return []mcp.ResourceContents{
 				&mcp.TextResourceContents{
@@ -55,16 +101,16 @@ func (r *ShipResource) Handler() func(ctx context.Context, request mcp.ReadResou
 
 		// Set up context logger
 		ctxLogger := r.logger.WithContext(ctx, "ship-resource")
-		ctxLogger.Debug("Fetching ship details for %s", shipSymbol)
-
-		// Get ship information from the API
-		start := time.Now()
-		ship, err := r.client.GetShip(shipSymbol)
-		duration := time.Since(start)
 
-		if err != nil {
+		// Answer from the local fleet model while it is fresh, otherwise ask the API
+		var ship *client.Ship
+		var cooldown *client.Cooldown
+		local, syncedAt, fromModel := r.fleet.Ship(shipSymbol, time.Now())
+		if fromModel {
+			ctxLogger.Debug("Answering ship %s from local fleet state", shipSymbol)
+			ship = &local
+		} else if ship, cooldown, err = r.fetch(ctx, ctxLogger, shipSymbol); err != nil {
 			ctxLogger.Error("Failed to fetch ship %s: %v", shipSymbol, err)
-			ctxLogger.APICall(fmt.Sprintf("/my/ships/%s", shipSymbol), 0, duration.String())
 			return []mcp.ResourceContents{
 				&mcp.TextResourceContents{
 					URI:      request.Params.URI,
@@ -74,18 +120,35 @@ func (r *ShipResource) Handler() func(ctx context.Context, request mcp.ReadResou
 			}, nil
 		}
 
-		ctxLogger.APICall(fmt.Sprintf("/my/ships/%s", shipSymbol), 200, duration.String())
-		ctxLogger.Info("Successfully retrieved ship %s", shipSymbol)
-
-		// Get detailed cooldown information
-		cooldown, cooldownErr := r.client.GetShipCooldown(shipSymbol)
-		if cooldownErr != nil {
-			ctxLogger.Debug("Could not get detailed cooldown for %s: %v", shipSymbol, cooldownErr)
-			// Don't fail the entire request, just use the cooldown from ship data
-		}
-
 		// Create enhanced ship data with additional analysis
-		result := r.createEnhancedShipData(ship, cooldown)
+		enhanced := r.createEnhancedShipData(ship, cooldown)
+		if shipData, ok := enhanced["ship"].(map[string]interface{}); ok {
+			addShipMeta(shipData, ship.Symbol, r.meta)
+		}
+		var data interface{} = enhanced
+		if detail == detailSummary {
+			if data, err = summarize(data); err != nil {
+				ctxLogger.Error("Failed to summarize ship data: %v", err)
+				return []mcp.ResourceContents{
+					&mcp.TextResourceContents{
+						URI:      request.Params.URI,
+						MIMEType: "text/plain",
+						Text:     "Error formatting ship information",
+					},
+				}, nil
+			}
+		}
+		links := []Link{
+			{Rel: "nav", URI: "spacetraders://ships/" + ship.Symbol + "/nav"},
+			{Rel: "cargo", URI: "spacetraders://ships/" + ship.Symbol + "/cargo"},
+			{Rel: "fuel", URI: "spacetraders://ships/" + ship.Symbol + "/fuel"},
+			{Rel: "cooldown", URI: "spacetraders://ships/" + ship.Symbol + "/cooldown"},
+			{Rel: "ships", URI: "spacetraders://ships/list"},
+		}
+		result := liveEnvelope(data, 1, links...)
+		if fromModel {
+			result = localEnvelope(data, 1, syncedAt, r.fleet.Diverged(), links...)
+		}
 
 		// Convert to JSON for response
 		jsonData, err := json.MarshalIndent(result, "", "  ")
@@ -113,6 +176,32 @@ func (r *ShipResource) Handler() func(ctx context.Context, request mcp.ReadResou
 	}
 }
 
+// fetch reads the ship and its detailed cooldown from the API. A failed cooldown read only
+// leaves the cooldown nil, so the one in the ship data is used instead.
+func (r *ShipResource) fetch(ctx context.Context, ctxLogger *logging.ContextLogger, shipSymbol string) (*client.Ship, *client.Cooldown, error) {
+	ctxLogger.Debug("Fetching ship details for %s", shipSymbol)
+
+	// Get ship information from the API
+	start := time.Now()
+	ship, err := r.client.WithContext(ctx).GetShip(shipSymbol)
+	duration := time.Since(start)
+
+	if err != nil {
+		ctxLogger.APICall(fmt.Sprintf("/my/ships/%s", shipSymbol), 0, duration.String())
+		return nil, nil, err
+	}
+
+	ctxLogger.APICall(fmt.Sprintf("/my/ships/%s", shipSymbol), 200, duration.String())
+	ctxLogger.Info("Successfully retrieved ship %s", shipSymbol)
+
+	// Get detailed cooldown information
+	cooldown, cooldownErr := r.client.WithContext(ctx).GetShipCooldown(shipSymbol)
+	if cooldownErr != nil {
+		ctxLogger.Debug("Could not get detailed cooldown for %s: %v", shipSymbol, cooldownErr)
+	}
+	return ship, cooldown, nil
+}
+
 // extractShipSymbol extracts the ship symbol from the URI
 func (r *ShipResource) extractShipSymbol(uri string) string {
 	// Match pattern: spacetraders://ships/{shipSymbol}
@@ -169,10 +258,6 @@ func (r *ShipResource) createEnhancedShipData(ship *client.Ship, detailedCooldow
 		},
 		"capabilities":    r.analyzeCapabilities(ship),
 		"recommendations": r.generateRecommendations(ship, cooldownStatus, cargoUtilization),
-		"meta": map[string]interface{}{
-			"last_updated": time.Now().Format(time.RFC3339),
-			"ship_symbol":  ship.Symbol,
-		},
 	}
 
 	return result
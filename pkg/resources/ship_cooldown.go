@@ -37,6 +37,16 @@ func (r *ShipCooldownResource) Resource() mcp.Resource {
 	}
 }
 
+// ResourceTemplate returns the parameterized form of the ship cooldown resource
+func (r *ShipCooldownResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://ships/{shipSymbol}/cooldown",
+		"Ship Cooldown Status",
+		mcp.WithTemplateDescription("Cooldown status for a specific ship by symbol"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *ShipCooldownResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
@@ -58,7 +68,7 @@ func (r *ShipCooldownResource) Handler() func(ctx context.Context, request mcp.R
 
 		// Get cooldown information from the API
 		start := time.Now()
-		cooldown, err := r.client.GetShipCooldown(shipSymbol)
+		cooldown, err := r.client.WithContext(ctx).GetShipCooldown(shipSymbol)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -76,7 +86,9 @@ func (r *ShipCooldownResource) Handler() func(ctx context.Context, request mcp.R
 		ctxLogger.APICall(fmt.Sprintf("/my/ships/%s/cooldown", shipSymbol), 200, duration.String())
 
 		// Create cooldown analysis
-		result := r.createCooldownAnalysis(shipSymbol, cooldown)
+		result := liveEnvelope(r.createCooldownAnalysis(shipSymbol, cooldown), 1,
+			Link{Rel: "ship", URI: "spacetraders://ships/" + shipSymbol},
+		)
 
 		// Convert to JSON for response
 		jsonData, err := json.MarshalIndent(result, "", "  ")
@@ -151,10 +163,6 @@ func (r *ShipCooldownResource) createCooldownAnalysis(shipSymbol string, cooldow
 				"can_navigate": true,
 				"can_survey":   true,
 			},
-			"meta": map[string]interface{}{
-				"last_checked": now.Format(time.RFC3339),
-				"ship_symbol":  shipSymbol,
-			},
 		}
 	}
 
@@ -196,10 +204,6 @@ func (r *ShipCooldownResource) createCooldownAnalysis(shipSymbol string, cooldow
 			"blocked_actions": blockedActions,
 		},
 		"recommendations": r.generateCooldownRecommendations(shipSymbol, cooldown.RemainingSeconds),
-		"meta": map[string]interface{}{
-			"last_checked": now.Format(time.RFC3339),
-			"ship_symbol":  shipSymbol,
-		},
 	}
 }
 
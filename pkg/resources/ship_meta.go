@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/shipmeta"
+)
+
+// labeledShip is a ship with the label, notes and tags the user gave it on this server
+type labeledShip struct {
+	client.Ship
+	Label string   `json:"label,omitempty"`
+	Notes string   `json:"notes,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// labelShips adds each ship's metadata from the store, which may be nil
+func labelShips(ships []client.Ship, store *shipmeta.Store) []labeledShip {
+	labeled := make([]labeledShip, len(ships))
+	for i, ship := range ships {
+		labeled[i] = labeledShip{Ship: ship}
+		if store == nil {
+			continue
+		}
+		if meta, ok := store.Get(ship.Symbol); ok {
+			labeled[i].Label = meta.Label
+			labeled[i].Notes = meta.Notes
+			labeled[i].Tags = meta.Tags
+		}
+	}
+	return labeled
+}
+
+// addShipMeta sets the label, notes and tags fields of a ship map from the store, which may be nil
+func addShipMeta(ship map[string]interface{}, shipSymbol string, store *shipmeta.Store) {
+	if store == nil {
+		return
+	}
+	meta, ok := store.Get(shipSymbol)
+	if !ok {
+		return
+	}
+	if meta.Label != "" {
+		ship["label"] = meta.Label
+	}
+	if meta.Notes != "" {
+		ship["notes"] = meta.Notes
+	}
+	if len(meta.Tags) > 0 {
+		ship["tags"] = meta.Tags
+	}
+}
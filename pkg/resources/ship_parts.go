@@ -0,0 +1,230 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/fleetstate"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ShipPartResource serves one part of a ship's state (nav, cargo or fuel) so clients
+// polling for arrival or cargo changes do not need to fetch the whole ship
+type ShipPartResource struct {
+	client      *client.Client
+	logger      *logging.Logger
+	part        string
+	name        string
+	description string
+	pattern     *regexp.Regexp
+	fetch       func(c *client.Client, shipSymbol string) (map[string]interface{}, error)
+	// describe builds the same data from a ship held in the local fleet model
+	describe func(ship client.Ship) map[string]interface{}
+	fleet    *fleetstate.Model
+}
+
+// NewShipNavResource creates a resource for a ship's navigation state
+func NewShipNavResource(client *client.Client, logger *logging.Logger) *ShipPartResource {
+	return newShipPartResource(client, logger, "nav", "Ship Navigation",
+		"Navigation status, location, flight mode and route of a specific ship, with seconds until arrival while in transit",
+		fetchShipNav, describeShipNav)
+}
+
+// NewShipCargoResource creates a resource for a ship's cargo hold
+func NewShipCargoResource(client *client.Client, logger *logging.Logger) *ShipPartResource {
+	return newShipPartResource(client, logger, "cargo", "Ship Cargo",
+		"Cargo capacity, units used and inventory of a specific ship",
+		fetchShipCargo, describeShipCargo)
+}
+
+// NewShipFuelResource creates a resource for a ship's fuel tank
+func NewShipFuelResource(client *client.Client, logger *logging.Logger) *ShipPartResource {
+	return newShipPartResource(client, logger, "fuel", "Ship Fuel",
+		"Current fuel, capacity and last consumption of a specific ship",
+		fetchShipFuel, describeShipFuel)
+}
+
+func newShipPartResource(c *client.Client, logger *logging.Logger, part, name, description string, fetch func(*client.Client, string) (map[string]interface{}, error), describe func(client.Ship) map[string]interface{}) *ShipPartResource {
+	return &ShipPartResource{
+		client:      c,
+		logger:      logger,
+		part:        part,
+		name:        name,
+		description: description,
+		pattern:     regexp.MustCompile(`^spacetraders://ships/([A-Za-z0-9_-]+)/` + part + `$`),
+		fetch:       fetch,
+		describe:    describe,
+	}
+}
+
+// WithFleetState answers from the local fleet model while it is fresh instead of asking the API
+func (r *ShipPartResource) WithFleetState(model *fleetstate.Model) *ShipPartResource {
+	r.fleet = model
+	return r
+}
+
+// uriTemplate returns the URI of the resource with a placeholder for the ship symbol
+func (r *ShipPartResource) uriTemplate() string {
+	return "spacetraders://ships/{shipSymbol}/" + r.part
+}
+
+// Resource returns the MCP resource definition
+func (r *ShipPartResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         r.uriTemplate(),
+		Name:        r.name,
+		Description: r.description,
+		MIMEType:    "application/json",
+	}
+}
+
+// ResourceTemplate returns the parameterized ship part resource
+func (r *ShipPartResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		r.uriTemplate(),
+		r.name,
+		mcp.WithTemplateDescription(r.description),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// Handler returns the resource handler function
+func (r *ShipPartResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		matches := r.pattern.FindStringSubmatch(request.Params.URI)
+		if len(matches) != 2 {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid ship %s resource URI. Expected format: %s", r.part, r.uriTemplate()),
+				},
+			}, nil
+		}
+		shipSymbol := matches[1]
+
+		ctxLogger := r.logger.WithContext(ctx, "ship-"+r.part+"-resource")
+		links := []Link{{Rel: "ship", URI: "spacetraders://ships/" + shipSymbol}}
+
+		if ship, syncedAt, ok := r.fleet.Ship(shipSymbol, time.Now()); ok {
+			ctxLogger.Debug("Answering %s for ship %s from local fleet state", r.part, shipSymbol)
+			data := r.describe(ship)
+			data["shipSymbol"] = shipSymbol
+			return r.respond(ctxLogger, request.Params.URI, localEnvelope(data, 1, syncedAt, r.fleet.Diverged(), links...))
+		}
+		ctxLogger.Debug("Fetching %s for ship %s", r.part, shipSymbol)
+
+		endpoint := fmt.Sprintf("/my/ships/%s/%s", shipSymbol, r.part)
+		if r.part == "fuel" {
+			// The API has no fuel endpoint, so fuel is read from the full ship
+			endpoint = fmt.Sprintf("/my/ships/%s", shipSymbol)
+		}
+
+		start := time.Now()
+		data, err := r.fetch(r.client.WithContext(ctx), shipSymbol)
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch %s for ship %s: %v", r.part, shipSymbol, err)
+			ctxLogger.APICall(endpoint, 0, duration.String())
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Error fetching %s for ship %s: %s", r.part, shipSymbol, err.Error()),
+				},
+			}, nil
+		}
+
+		ctxLogger.APICall(endpoint, 200, duration.String())
+
+		data["shipSymbol"] = shipSymbol
+		return r.respond(ctxLogger, request.Params.URI, liveEnvelope(data, 1, links...))
+	}
+}
+
+// respond encodes the ship part for the client
+func (r *ShipPartResource) respond(ctxLogger *logging.ContextLogger, uri string, result Envelope) ([]mcp.ResourceContents, error) {
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		ctxLogger.Error("Failed to marshal ship %s data to JSON: %v", r.part, err)
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "text/plain",
+				Text:     fmt.Sprintf("Error formatting ship %s information", r.part),
+			},
+		}, nil
+	}
+
+	ctxLogger.ResourceRead(uri, true)
+
+	return []mcp.ResourceContents{
+		&mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+// fetchShipNav reads the ship's nav and adds the time left until arrival
+func fetchShipNav(c *client.Client, shipSymbol string) (map[string]interface{}, error) {
+	nav, err := c.GetShipNav(shipSymbol)
+	if err != nil {
+		return nil, err
+	}
+	return describeShipNav(client.Ship{Nav: *nav}), nil
+}
+
+// describeShipNav adds the time left until arrival to a ship's nav
+func describeShipNav(ship client.Ship) map[string]interface{} {
+	result := map[string]interface{}{
+		"nav": ship.Nav,
+	}
+	if arrivalIn, known := ship.ArrivalIn(time.Now()); ship.Nav.Status == "IN_TRANSIT" && known {
+		result["arrivalInSeconds"] = int(arrivalIn.Seconds())
+	}
+	return result
+}
+
+// fetchShipCargo reads the ship's cargo hold and adds how full it is
+func fetchShipCargo(c *client.Client, shipSymbol string) (map[string]interface{}, error) {
+	cargo, err := c.GetShipCargo(shipSymbol)
+	if err != nil {
+		return nil, err
+	}
+	return describeShipCargo(client.Ship{Cargo: *cargo}), nil
+}
+
+// describeShipCargo adds how full a ship's cargo hold is
+func describeShipCargo(ship client.Ship) map[string]interface{} {
+	return map[string]interface{}{
+		"cargo":        ship.Cargo,
+		"freeUnits":    ship.Cargo.Capacity - ship.Cargo.Units,
+		"cargoPercent": ship.CargoPercent(),
+	}
+}
+
+// fetchShipFuel reads the ship's fuel tank and adds the fill level
+func fetchShipFuel(c *client.Client, shipSymbol string) (map[string]interface{}, error) {
+	ship, err := c.GetShip(shipSymbol)
+	if err != nil {
+		return nil, err
+	}
+	return describeShipFuel(*ship), nil
+}
+
+// describeShipFuel adds the fill level of a ship's fuel tank
+func describeShipFuel(ship client.Ship) map[string]interface{} {
+	return map[string]interface{}{
+		"fuel":        ship.Fuel,
+		"fuelPercent": ship.FuelPercent(),
+	}
+}
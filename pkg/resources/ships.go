@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/fleetstate"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -14,6 +16,8 @@ import (
 // ShipsResource handles the ships information resource
 type ShipsResource struct {
 	client *client.Client
+	meta   *shipmeta.Store
+	fleet  *fleetstate.Model
 	logger *logging.Logger
 }
 
@@ -25,12 +29,24 @@ func NewShipsResource(client *client.Client, logger *logging.Logger) *ShipsResou
 	}
 }
 
+// WithShipMeta adds the labels, notes and tags recorded for each ship
+func (r *ShipsResource) WithShipMeta(store *shipmeta.Store) *ShipsResource {
+	r.meta = store
+	return r
+}
+
+// WithFleetState answers from the local fleet model while it is fresh instead of asking the API
+func (r *ShipsResource) WithFleetState(model *fleetstate.Model) *ShipsResource {
+	r.fleet = model
+	return r
+}
+
 // Resource returns the MCP resource definition
 func (r *ShipsResource) Resource() mcp.Resource {
 	return mcp.Resource{
 		URI:         "spacetraders://ships/list",
 		Name:        "Ships List",
-		Description: "List of all ships owned by the agent with their status, location, and cargo information",
+		Description: "List of all ships owned by the agent with their status, location, and cargo information, plus any label, notes and tags set with set_ship_label and tag_ship",
 		MIMEType:    "application/json",
 	}
 }
@@ -51,11 +67,20 @@ func (r *ShipsResource) Handler() func(ctx context.Context, request mcp.ReadReso
 
 		// Set up context logger
 		ctxLogger := r.logger.WithContext(ctx, "ships-resource")
+		links := []Link{
+			{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+			{Rel: "fleet_summary", URI: fleetSummaryResourceURI},
+		}
+
+		if ships, syncedAt, ok := r.fleet.Fleet(time.Now()); ok {
+			ctxLogger.Debug("Answering ships list from local fleet state")
+			return r.respond(ctxLogger, request.Params.URI, localEnvelope(labelShips(ships, r.meta), len(ships), syncedAt, r.fleet.Diverged(), links...))
+		}
 		ctxLogger.Debug("Fetching ships list from API")
 
 		// Get ships information from the API
 		start := time.Now()
-		ships, err := r.client.GetAllShips()
+		ships, err := r.client.WithContext(ctx).GetAllShips()
 		duration := time.Since(start)
 
 		if err != nil {
@@ -74,35 +99,32 @@ func (r *ShipsResource) Handler() func(ctx context.Context, request mcp.ReadReso
 		ctxLogger.Info("Successfully retrieved %d ships", len(ships))
 
 		// Format the response as structured JSON
-		result := map[string]interface{}{
-			"ships": ships,
-			"meta": map[string]interface{}{
-				"count": len(ships),
-			},
-		}
-
-		// Convert to JSON for response
-		jsonData, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			ctxLogger.Error("Failed to marshal ships data to JSON: %v", err)
-			return []mcp.ResourceContents{
-				&mcp.TextResourceContents{
-					URI:      request.Params.URI,
-					MIMEType: "text/plain",
-					Text:     "Error formatting ships information",
-				},
-			}, nil
-		}
-
-		ctxLogger.ResourceRead(request.Params.URI, true)
-		ctxLogger.Debug("Ships resource response size: %d bytes", len(jsonData))
+		return r.respond(ctxLogger, request.Params.URI, liveEnvelope(labelShips(ships, r.meta), len(ships), links...))
+	}
+}
 
+// respond encodes the ships list for the client
+func (r *ShipsResource) respond(ctxLogger *logging.ContextLogger, uri string, result Envelope) ([]mcp.ResourceContents, error) {
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		ctxLogger.Error("Failed to marshal ships data to JSON: %v", err)
 		return []mcp.ResourceContents{
 			&mcp.TextResourceContents{
-				URI:      request.Params.URI,
-				MIMEType: "application/json",
-				Text:     string(jsonData),
+				URI:      uri,
+				MIMEType: "text/plain",
+				Text:     "Error formatting ships information",
 			},
 		}, nil
 	}
+
+	ctxLogger.ResourceRead(uri, true)
+	ctxLogger.Debug("Ships resource response size: %d bytes", len(jsonData))
+
+	return []mcp.ResourceContents{
+		&mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
 }
@@ -38,11 +38,41 @@ func (r *ShipyardResource) Resource() mcp.Resource {
 	}
 }
 
+// ResourceTemplate returns the parameterized form of the shipyard resource
+func (r *ShipyardResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/shipyard"+refreshQuery,
+		"Shipyard Information",
+		mcp.WithTemplateDescription("Ships for sale and prices at a shipyard, by system and waypoint symbol. Ships and prices are only listed while one of your ships is there; otherwise the last listing seen is served from the cache, with its age. refresh=true returns only what the API shows now"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *ShipyardResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		// Parse the system and waypoint symbols from the URI
-		systemSymbol, waypointSymbol, err := r.parseShipyardURI(request.Params.URI)
+		// Parse the system and waypoint symbols and whether to skip the cache from the URI
+		uri, query, err := splitQuery(request.Params.URI, refreshParam)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+		refresh, err := refreshFromQuery(query)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+		systemSymbol, waypointSymbol, err := r.parseShipyardURI(uri)
 		if err != nil {
 			return []mcp.ResourceContents{
 				&mcp.TextResourceContents{
@@ -59,7 +89,7 @@ func (r *ShipyardResource) Handler() func(ctx context.Context, request mcp.ReadR
 
 		// Get shipyard information from the API
 		start := time.Now()
-		shipyard, err := r.client.GetShipyard(systemSymbol, waypointSymbol)
+		shipyard, err := r.client.WithContext(ctx).GetShipyard(systemSymbol, waypointSymbol)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -77,8 +107,18 @@ func (r *ShipyardResource) Handler() func(ctx context.Context, request mcp.ReadR
 		ctxLogger.APICall(fmt.Sprintf("/systems/%s/waypoints/%s/shipyard", systemSymbol, waypointSymbol), 200, duration.String())
 		ctxLogger.Info("Successfully retrieved shipyard info for %s at %s", waypointSymbol, systemSymbol)
 
+		// Without a ship present there are no ships or prices, so the last listing that had them is
+		// served instead, marked as cached with its age
+		fetchedAt := start
+		if len(shipyard.Ships) == 0 && !refresh {
+			if listing, ok := r.client.KnownShipyard(waypointSymbol); ok {
+				ctxLogger.Debug("No ship at %s, serving the listing fetched at %s", waypointSymbol, listing.FetchedAt.Format(time.RFC3339))
+				shipyard, fetchedAt = &listing.Shipyard, listing.FetchedAt
+			}
+		}
+
 		// Format the response as structured JSON with additional analysis
-		result := map[string]interface{}{
+		result := fetchedEnvelope(map[string]interface{}{
 			"system":   systemSymbol,
 			"waypoint": waypointSymbol,
 			"shipyard": shipyard,
@@ -91,7 +131,9 @@ func (r *ShipyardResource) Handler() func(ctx context.Context, request mcp.ReadR
 				"modificationsFee":    shipyard.ModificationsFee,
 				"recentTransactions":  len(shipyard.Transactions),
 			},
-		}
+		}, 1, fetchedAt, start,
+			Link{Rel: "waypoints", URI: "spacetraders://systems/" + systemSymbol + "/waypoints"},
+		)
 
 		// Convert to JSON for response
 		jsonData, err := json.MarshalIndent(result, "", "  ")
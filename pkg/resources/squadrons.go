@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const squadronsResourceURI = "spacetraders://squadrons/list"
+
+// SquadronsResource lists the squadrons created with create_squadron and the state of their ships
+type SquadronsResource struct {
+	client *client.Client
+	store  *shipmeta.Store
+	tasks  *tasks.Manager
+	logger *logging.Logger
+}
+
+// NewSquadronsResource creates a new squadrons resource handler
+func NewSquadronsResource(client *client.Client, store *shipmeta.Store, logger *logging.Logger) *SquadronsResource {
+	return &SquadronsResource{
+		client: client,
+		store:  store,
+		logger: logger,
+	}
+}
+
+// WithTasks adds the background task driving each member ship to its row and status
+func (r *SquadronsResource) WithTasks(manager *tasks.Manager) *SquadronsResource {
+	r.tasks = manager
+	return r
+}
+
+// Resource returns the MCP resource definition
+func (r *SquadronsResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         squadronsResourceURI,
+		Name:        "Squadrons",
+		Description: "Squadrons created with create_squadron, with a fleet summary row for each member ship and any members the agent no longer owns",
+		MIMEType:    "application/json",
+	}
+}
+
+// squadronView is a squadron with the current state of its ships
+type squadronView struct {
+	Name      string            `json:"name"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	Ships     []fleetSummaryRow `json:"ships"`
+	// Missing are members the agent no longer owns, such as scrapped ships
+	Missing []string `json:"missing,omitempty"`
+	// ByStatus counts the members present in each nav status
+	ByStatus map[string]int `json:"byStatus"`
+}
+
+// Handler returns the resource handler function
+func (r *SquadronsResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != squadronsResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "squadrons-resource")
+
+		squadrons := r.store.Squadrons()
+		var ships []client.Ship
+		if len(squadrons) > 0 {
+			start := time.Now()
+			var err error
+			ships, err = r.client.WithContext(ctx).GetAllShips()
+			duration := time.Since(start)
+			if err != nil {
+				ctxLogger.Error("Failed to fetch ships info: %v", err)
+				ctxLogger.APICall("/my/ships", 0, duration.String())
+				return []mcp.ResourceContents{
+					&mcp.TextResourceContents{
+						URI:      request.Params.URI,
+						MIMEType: "text/plain",
+						Text:     "Error fetching ships info: " + err.Error(),
+					},
+				}, nil
+			}
+			ctxLogger.APICall("/my/ships", 200, duration.String())
+		}
+
+		now := time.Now()
+		bySymbol := make(map[string]client.Ship, len(ships))
+		for _, ship := range ships {
+			bySymbol[ship.Symbol] = ship
+		}
+		views := make([]squadronView, 0, len(squadrons))
+		for _, squadron := range squadrons {
+			view := squadronView{
+				Name:      squadron.Name,
+				CreatedAt: squadron.CreatedAt,
+				UpdatedAt: squadron.UpdatedAt,
+				Ships:     make([]fleetSummaryRow, 0, len(squadron.Ships)),
+				ByStatus:  make(map[string]int),
+			}
+			for _, symbol := range squadron.Ships {
+				ship, ok := bySymbol[symbol]
+				if !ok {
+					view.Missing = append(view.Missing, symbol)
+					continue
+				}
+				row := summarizeShip(ship, activeBehavior(r.tasks, symbol), now)
+				meta, _ := r.store.Get(symbol)
+				row.Label = meta.Label
+				row.Tags = meta.Tags
+				view.ByStatus[row.Status]++
+				view.Ships = append(view.Ships, row)
+			}
+			views = append(views, view)
+		}
+
+		result := newEnvelope(views, len(views), sourceLive, now,
+			Link{Rel: "fleet_summary", URI: fleetSummaryResourceURI},
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal squadrons to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting squadrons",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,147 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SupplyChainResource handles the trade good supply chain resource
+type SupplyChainResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewSupplyChainResource creates a new supply chain resource handler
+func NewSupplyChainResource(client *client.Client, logger *logging.Logger) *SupplyChainResource {
+	return &SupplyChainResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *SupplyChainResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         "spacetraders://markets/supply-chain",
+		Name:        "Supply Chain",
+		Description: "Which goods are inputs to which exports, for planning production-chain trading. Static per server reset, so it is cached.",
+		MIMEType:    "application/json",
+	}
+}
+
+// ResourceTemplate returns the parameterized form of the supply chain resource
+func (r *SupplyChainResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://markets/supply-chain"+refreshQuery,
+		"Supply Chain",
+		mcp.WithTemplateDescription(fmt.Sprintf("Which goods are inputs to which exports. Cached for up to %s; refresh=true fetches it from the API", client.SupplyChainCacheTTL)),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// Handler returns the resource handler function
+func (r *SupplyChainResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		// Validate the resource URI
+		uri, query, err := splitQuery(request.Params.URI, refreshParam)
+		if err != nil || uri != "spacetraders://markets/supply-chain" {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+		refresh, err := refreshFromQuery(query)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "supply-chain-resource")
+		ctxLogger.Debug("Fetching supply chain")
+
+		start := time.Now()
+		var chain map[string][]string
+		var fetchedAt time.Time
+		if refresh {
+			chain, fetchedAt, err = r.client.WithContext(ctx).RefreshSupplyChain()
+		} else {
+			chain, fetchedAt, err = r.client.WithContext(ctx).GetCachedSupplyChain()
+		}
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch supply chain: %v", err)
+			ctxLogger.APICall("/market/supply-chain", 0, duration.String())
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error fetching supply chain: " + err.Error(),
+				},
+			}, nil
+		}
+
+		ctxLogger.APICall("/market/supply-chain", 200, duration.String())
+
+		// The supply chain is cached, so it was only fetched for this read if the cache was empty or stale
+		result := fetchedEnvelope(map[string]interface{}{
+			"exportToImports": chain,
+			"importToExports": invertSupplyChain(chain),
+		}, len(chain), fetchedAt, start,
+			Link{Rel: "market", URI: "spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/market"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal supply chain data to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting supply chain",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Supply chain resource response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// invertSupplyChain maps each input good to the exports it is used to produce
+func invertSupplyChain(chain map[string][]string) map[string][]string {
+	inverted := make(map[string][]string)
+	for export, imports := range chain {
+		for _, input := range imports {
+			inverted[input] = append(inverted[input], export)
+		}
+	}
+	for input := range inverted {
+		sort.Strings(inverted[input])
+	}
+	return inverted
+}
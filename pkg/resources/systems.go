@@ -27,16 +27,36 @@ func NewSystemsResource(client *client.Client, logger *logging.Logger) *SystemsR
 	}
 }
 
-// Resource returns the MCP resource definition
+// Resource returns the MCP resource definition for the full system list
 func (r *SystemsResource) Resource() mcp.Resource {
 	return mcp.Resource{
-		URI:         "spacetraders://systems/*",
+		URI:         "spacetraders://systems",
 		Name:        "Systems Data",
-		Description: "Systems information - use 'spacetraders://systems' for all systems or 'spacetraders://systems/{systemSymbol}' for specific system details",
+		Description: "All systems - use the 'spacetraders://systems/{systemSymbol}' template for a specific system",
 		MIMEType:    "application/json",
 	}
 }
 
+// ResourceTemplate returns the parameterized resource for a specific system
+func (r *SystemsResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://systems/{systemSymbol}",
+		"System Details",
+		mcp.WithTemplateDescription("Details of a specific system by symbol"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// PagedResourceTemplate returns the form of the system list that reads one page at a time
+func (r *SystemsResource) PagedResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://systems"+pageQuery,
+		"Systems Page",
+		mcp.WithTemplateDescription(fmt.Sprintf("One page of the system list: page starts at 1, limit is at most %d, and cursor is the next_cursor from a previous page's meta", client.MaxPageLimit)),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *SystemsResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
@@ -45,8 +65,19 @@ func (r *SystemsResource) Handler() func(ctx context.Context, request mcp.ReadRe
 		ctxLogger.Debug("Processing systems resource request")
 
 		// Check if this is a request for a specific system or all systems
-		if request.Params.URI == "spacetraders://systems" {
-			return r.handleSystemsList(ctx, request, ctxLogger)
+		uri, page, err := splitPageQuery(request.Params.URI)
+		if err != nil {
+			ctxLogger.Error("Invalid systems URI %s: %v", request.Params.URI, err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid systems resource URI: " + err.Error(),
+				},
+			}, nil
+		}
+		if uri == "spacetraders://systems" {
+			return r.handleSystemsList(ctx, request, page, ctxLogger)
 		} else if strings.HasPrefix(request.Params.URI, "spacetraders://systems/") {
 			return r.handleSpecificSystem(ctx, request, ctxLogger)
 		} else {
@@ -61,13 +92,20 @@ func (r *SystemsResource) Handler() func(ctx context.Context, request mcp.ReadRe
 	}
 }
 
-// handleSystemsList handles requests for the full systems list
-func (r *SystemsResource) handleSystemsList(ctx context.Context, request mcp.ReadResourceRequest, ctxLogger *logging.ContextLogger) ([]mcp.ResourceContents, error) {
+// handleSystemsList handles requests for the systems list, or one page of it when page is set
+func (r *SystemsResource) handleSystemsList(ctx context.Context, request mcp.ReadResourceRequest, page *pageRequest, ctxLogger *logging.ContextLogger) ([]mcp.ResourceContents, error) {
 	ctxLogger.Debug("Fetching systems list from API")
 
-	// Get systems from the API
+	// Get systems from the API, only fetching the requested page when there is one
+	var systems []client.System
+	var total int
+	var err error
 	start := time.Now()
-	systems, err := r.client.GetAllSystems()
+	if page != nil {
+		systems, total, err = r.client.WithContext(ctx).GetSystemsPage(page.Page, page.Limit)
+	} else {
+		systems, err = r.client.WithContext(ctx).GetAllSystems()
+	}
 	duration := time.Since(start)
 
 	if err != nil {
@@ -86,12 +124,13 @@ func (r *SystemsResource) handleSystemsList(ctx context.Context, request mcp.Rea
 	ctxLogger.Info("Successfully retrieved %d systems", len(systems))
 
 	// Format the response
-	result := map[string]interface{}{
-		"systems": r.formatSystemsList(systems),
-		"meta": map[string]interface{}{
-			"total":     len(systems),
-			"retrieved": time.Now().UTC().Format(time.RFC3339),
-		},
+	result := liveEnvelope(r.formatSystemsList(systems), len(systems),
+		Link{Rel: "system", URI: "spacetraders://systems/{systemSymbol}"},
+		Link{Rel: "jumpgate_graph", URI: "spacetraders://universe/jumpgate-graph"},
+		Link{Rel: "paged", URI: "spacetraders://systems" + pageQuery},
+	)
+	if page != nil {
+		result = result.withPagination(page.pagination(total))
 	}
 
 	// Convert to JSON
@@ -138,7 +177,7 @@ func (r *SystemsResource) handleSpecificSystem(ctx context.Context, request mcp.
 
 	// Get system details from the API
 	start := time.Now()
-	system, err := r.client.GetSystem(systemSymbol)
+	system, err := r.client.WithContext(ctx).GetSystem(systemSymbol)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -157,12 +196,10 @@ func (r *SystemsResource) handleSpecificSystem(ctx context.Context, request mcp.
 	ctxLogger.Info("Successfully retrieved system details for: %s", systemSymbol)
 
 	// Format the response
-	result := map[string]interface{}{
-		"system": r.formatSystemDetails(system),
-		"meta": map[string]interface{}{
-			"retrieved": time.Now().UTC().Format(time.RFC3339),
-		},
-	}
+	result := liveEnvelope(r.formatSystemDetails(system), 1,
+		Link{Rel: "waypoints", URI: "spacetraders://systems/" + systemSymbol + "/waypoints"},
+		Link{Rel: "systems", URI: "spacetraders://systems"},
+	)
 
 	// Convert to JSON
 	jsonData, err := json.MarshalIndent(result, "", "  ")
@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const tasksResourceURI = "spacetraders://tasks/list"
+
+// TasksResource exposes the background tasks assigned to ships
+type TasksResource struct {
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewTasksResource creates a new tasks resource handler
+func NewTasksResource(manager *tasks.Manager, logger *logging.Logger) *TasksResource {
+	return &TasksResource{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Resource returns the MCP resource definition
+func (r *TasksResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         tasksResourceURI,
+		Name:        "Background Tasks",
+		Description: "Background tasks assigned to ships with their status, progress and last result, plus the behaviors that can be assigned",
+		MIMEType:    "application/json",
+	}
+}
+
+// Handler returns the resource handler function
+func (r *TasksResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != tasksResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "tasks-resource")
+
+		list := r.manager.List()
+		active := 0
+		for _, task := range list {
+			if task.Active() {
+				active++
+			}
+		}
+
+		// Tasks are run by the server itself, so the list is always current
+		result := cachedEnvelope(map[string]interface{}{
+			"tasks":     list,
+			"active":    active,
+			"behaviors": tasks.Behaviors(),
+		}, len(list), time.Now(),
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal tasks to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting tasks",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,252 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const timersResourceURI = "spacetraders://timers"
+
+// Kinds of pending timers
+const (
+	timerCooldown         = "cooldown"
+	timerArrival          = "arrival"
+	timerContractDeadline = "contract_deadline"
+	timerContractOffer    = "contract_offer"
+)
+
+// TimersResource lists every pending timer across the fleet and contracts, soonest first
+type TimersResource struct {
+	client    *client.Client
+	cooldowns *cooldowns.Tracker
+	tasks     *tasks.Manager
+	logger    *logging.Logger
+}
+
+// NewTimersResource creates a new timers resource handler
+func NewTimersResource(client *client.Client, logger *logging.Logger) *TimersResource {
+	return &TimersResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithCooldowns names the action that started each cooldown the tracker saw
+func (r *TimersResource) WithCooldowns(tracker *cooldowns.Tracker) *TimersResource {
+	r.cooldowns = tracker
+	return r
+}
+
+// WithTasks marks the timers of ships a background task is already driving
+func (r *TimersResource) WithTasks(manager *tasks.Manager) *TimersResource {
+	r.tasks = manager
+	return r
+}
+
+// Resource returns the MCP resource definition
+func (r *TimersResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         timersResourceURI,
+		Name:        "Pending Timers",
+		Description: "Every pending timer across the fleet, soonest first: ship cooldowns, transit arrivals, accepted contract deadlines and contract offers waiting to be accepted. Tells an agent loop exactly how long to wait before the next productive action.",
+		MIMEType:    "application/json",
+	}
+}
+
+// timer is one pending countdown
+type timer struct {
+	Kind string `json:"kind"`
+	// Subject is the ship or contract the timer belongs to
+	Subject          string `json:"subject"`
+	ExpiresAt        string `json:"expires_at"`
+	RemainingSeconds int    `json:"remaining_seconds"`
+	Detail           string `json:"detail"`
+	// Task is the background task driving the ship, which acts on the timer by itself
+	Task string `json:"task,omitempty"`
+
+	expiresAt time.Time
+}
+
+// Handler returns the resource handler function
+func (r *TimersResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if request.Params.URI != timersResourceURI {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Invalid resource URI",
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "timers-resource")
+		c := r.client.WithContext(ctx)
+
+		ships, err := c.GetAllShips()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Error fetching ships: %s", err.Error()),
+				},
+			}, nil
+		}
+		// Contracts only add deadlines, so the fleet's timers are still worth returning without them
+		contracts, contractsErr := c.GetAllContracts()
+		if contractsErr != nil {
+			ctxLogger.Error("Failed to fetch contracts: %v", contractsErr)
+		}
+
+		now := time.Now()
+		timers := append(r.fleetTimers(ships, now), contractTimers(contracts, now)...)
+		if timers == nil {
+			timers = []timer{}
+		}
+		sort.SliceStable(timers, func(i, j int) bool {
+			return timers[i].expiresAt.Before(timers[j].expiresAt)
+		})
+
+		ready := 0
+		for _, ship := range ships {
+			if shipReady(ship.Symbol, timers) {
+				ready++
+			}
+		}
+
+		data := map[string]interface{}{
+			"timers":      timers,
+			"ships_ready": ready,
+			"ships_total": len(ships),
+		}
+		if len(timers) > 0 {
+			data["next"] = timers[0]
+			data["wait_seconds"] = timers[0].RemainingSeconds
+		}
+		if contractsErr != nil {
+			data["contracts_error"] = contractsErr.Error()
+		}
+
+		result := liveEnvelope(data, len(timers),
+			Link{Rel: "ship", URI: "spacetraders://ships/{shipSymbol}"},
+			Link{Rel: "contract", URI: "spacetraders://contracts/{contractId}"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal timers to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting timers",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// fleetTimers collects the running cooldowns and transit arrivals of the ships
+func (r *TimersResource) fleetTimers(ships []client.Ship, now time.Time) []timer {
+	var timers []timer
+	add := func(kind, ship string, expiresAt time.Time, detail string) {
+		t := timer{
+			Kind:             kind,
+			Subject:          ship,
+			ExpiresAt:        expiresAt.UTC().Format(time.RFC3339),
+			RemainingSeconds: int(expiresAt.Sub(now).Round(time.Second).Seconds()),
+			Detail:           detail,
+			expiresAt:        expiresAt,
+		}
+		if r.tasks != nil {
+			if task, ok := r.tasks.ActiveTask(ship); ok {
+				t.Task = task.ID
+			}
+		}
+		timers = append(timers, t)
+	}
+
+	for _, ship := range ships {
+		// The tracker knows what started the cooldown; the ship listing covers cooldowns it never saw
+		var cooldown cooldowns.Cooldown
+		tracked := false
+		if r.cooldowns != nil {
+			cooldown, tracked = r.cooldowns.Get(ship.Symbol, now)
+		}
+		if tracked {
+			detail := "cooldown"
+			if cooldown.Action != "" {
+				detail = fmt.Sprintf("cooldown from %s", cooldown.Action)
+			}
+			add(timerCooldown, ship.Symbol, cooldown.Expiration, detail)
+		} else if expiration, err := time.Parse(time.RFC3339, ship.Cooldown.Expiration); err == nil && expiration.After(now) {
+			add(timerCooldown, ship.Symbol, expiration, "cooldown")
+		}
+
+		if ship.Nav.Status == "IN_TRANSIT" {
+			if arrival, err := time.Parse(time.RFC3339, ship.Nav.Route.Arrival); err == nil && arrival.After(now) {
+				add(timerArrival, ship.Symbol, arrival, fmt.Sprintf("arrives at %s from %s", ship.Nav.WaypointSymbol, ship.Nav.Route.Origin.Symbol))
+			}
+		}
+	}
+	return timers
+}
+
+// contractTimers collects the deadlines of accepted contracts and of offers still open
+func contractTimers(contracts []client.Contract, now time.Time) []timer {
+	var timers []timer
+	for _, contract := range contracts {
+		if contract.Fulfilled {
+			continue
+		}
+		kind, deadline, detail := timerContractOffer, contract.DeadlineToAccept, fmt.Sprintf("%s offer from %s must be accepted", contract.Type, contract.FactionSymbol)
+		if contract.Accepted {
+			kind, deadline, detail = timerContractDeadline, contract.Terms.Deadline, fmt.Sprintf("%s for %s must be fulfilled", contract.Type, contract.FactionSymbol)
+		}
+		expiresAt, err := time.Parse(time.RFC3339, deadline)
+		if err != nil || !expiresAt.After(now) {
+			continue
+		}
+		timers = append(timers, timer{
+			Kind:             kind,
+			Subject:          contract.ID,
+			ExpiresAt:        expiresAt.UTC().Format(time.RFC3339),
+			RemainingSeconds: int(expiresAt.Sub(now).Round(time.Second).Seconds()),
+			Detail:           detail,
+			expiresAt:        expiresAt,
+		})
+	}
+	return timers
+}
+
+// shipReady reports whether a ship has no cooldown or arrival pending
+func shipReady(shipSymbol string, timers []timer) bool {
+	for _, t := range timers {
+		if t.Subject == shipSymbol && (t.Kind == timerCooldown || t.Kind == timerArrival) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,247 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TradeMapResource handles the per-system trade map resource
+type TradeMapResource struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewTradeMapResource creates a new trade map resource handler
+func NewTradeMapResource(client *client.Client, logger *logging.Logger) *TradeMapResource {
+	return &TradeMapResource{
+		client: client,
+		logger: logger,
+	}
+}
+
+// GoodTraders lists the markets in a system that trade one good, by how they trade it
+type GoodTraders struct {
+	// Producers export the good, so it is bought there cheaply
+	Producers []string `json:"producers"`
+	// Consumers import the good, so it sells there for the most
+	Consumers []string `json:"consumers"`
+	// Exchanges both buy and sell the good at middling prices
+	Exchanges []string `json:"exchanges,omitempty"`
+}
+
+// Resource returns the MCP resource definition
+func (r *TradeMapResource) Resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         "spacetraders://systems/{systemSymbol}/trade-map",
+		Name:        "System Trade Map",
+		Description: "Which markets in a system produce (export), consume (import) or exchange each trade good, for planning hauls within the system",
+		MIMEType:    "application/json",
+	}
+}
+
+// ResourceTemplate returns the parameterized form of the trade map resource
+func (r *TradeMapResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://systems/{systemSymbol}/trade-map",
+		"System Trade Map",
+		mcp.WithTemplateDescription(fmt.Sprintf("Each trade good in a system mapped to the markets that produce, consume and exchange it, by system symbol. Built from the market listing cache, so markets already looked at in the last %s cost no API calls. No prices; read a market for those", client.MarketListingCacheTTL)),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// Handler returns the resource handler function
+func (r *TradeMapResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		systemSymbol, err := r.parseSystemSymbol(request.Params.URI)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+
+		ctxLogger := r.logger.WithContext(ctx, "trade-map-resource")
+		ctxLogger.Debug("Building trade map for system %s", systemSymbol)
+
+		c := r.client.WithContext(ctx)
+		start := time.Now()
+		waypoints, fetchedAt, err := c.GetCachedSystemWaypoints(systemSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to fetch waypoints for system %s: %v", systemSymbol, err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Error fetching waypoints for system %s: %s", systemSymbol, err.Error()),
+				},
+			}, nil
+		}
+
+		// A market that can't be read is listed rather than failing the whole map
+		var markets []client.Market
+		unavailable := make(map[string]string)
+		for _, waypoint := range waypoints {
+			if !hasTrait(waypoint, "MARKETPLACE") {
+				continue
+			}
+			market, listedAt, err := c.GetCachedMarketListing(systemSymbol, waypoint.Symbol)
+			if err != nil {
+				ctxLogger.Error("Failed to fetch market at %s: %v", waypoint.Symbol, err)
+				unavailable[waypoint.Symbol] = err.Error()
+				continue
+			}
+			markets = append(markets, *market)
+			if listedAt.Before(fetchedAt) {
+				fetchedAt = listedAt
+			}
+		}
+		ctxLogger.Debug("Mapped %d markets in system %s in %s", len(markets), systemSymbol, time.Since(start))
+
+		goods := buildTradeMap(markets)
+		var local []string
+		for symbol, traders := range goods {
+			if traders.tradableLocally() {
+				local = append(local, symbol)
+			}
+		}
+		sort.Strings(local)
+
+		marketSymbols := make([]string, 0, len(markets))
+		for _, market := range markets {
+			marketSymbols = append(marketSymbols, market.Symbol)
+		}
+		sort.Strings(marketSymbols)
+
+		data := map[string]interface{}{
+			"system":  systemSymbol,
+			"goods":   goods,
+			"markets": marketSymbols,
+			"summary": map[string]interface{}{
+				"markets": len(markets),
+				"goods":   len(goods),
+				// Goods that can be both bought and sold without leaving the system
+				"tradableLocally": local,
+			},
+		}
+		if len(unavailable) > 0 {
+			data["unavailable"] = unavailable
+		}
+
+		// The map is as old as the oldest listing it was built from
+		result := fetchedEnvelope(data, len(goods), fetchedAt, start,
+			Link{Rel: "system", URI: "spacetraders://systems/" + systemSymbol},
+			Link{Rel: "market", URI: "spacetraders://systems/" + systemSymbol + "/waypoints/{waypointSymbol}/market"},
+			Link{Rel: "supply_chain", URI: "spacetraders://markets/supply-chain"},
+		)
+
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			ctxLogger.Error("Failed to marshal trade map to JSON: %v", err)
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     "Error formatting trade map",
+				},
+			}, nil
+		}
+
+		ctxLogger.ResourceRead(request.Params.URI, true)
+		ctxLogger.Debug("Trade map resource response size: %d bytes", len(jsonData))
+
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// parseSystemSymbol extracts the system symbol from the resource URI
+func (r *TradeMapResource) parseSystemSymbol(uri string) (string, error) {
+	// Expected format: spacetraders://systems/{systemSymbol}/trade-map
+	systemSymbol, found := strings.CutSuffix(strings.TrimPrefix(uri, "spacetraders://systems/"), "/trade-map")
+	if !found || !strings.HasPrefix(uri, "spacetraders://systems/") || strings.Contains(systemSymbol, "/") {
+		return "", fmt.Errorf("expected spacetraders://systems/{systemSymbol}/trade-map")
+	}
+	if systemSymbol == "" {
+		return "", fmt.Errorf("system symbol cannot be empty")
+	}
+
+	decoded, err := url.QueryUnescape(systemSymbol)
+	if err != nil {
+		return "", fmt.Errorf("invalid system symbol encoding: %w", err)
+	}
+	return decoded, nil
+}
+
+// tradableLocally reports whether the good can be bought at one market in the system and sold at
+// another: an exchange counts as both
+func (t *GoodTraders) tradableLocally() bool {
+	if len(t.Producers)+len(t.Exchanges) == 0 || len(t.Consumers)+len(t.Exchanges) == 0 {
+		return false
+	}
+	markets := make(map[string]bool)
+	for _, list := range [][]string{t.Producers, t.Consumers, t.Exchanges} {
+		for _, market := range list {
+			markets[market] = true
+		}
+	}
+	return len(markets) > 1
+}
+
+// buildTradeMap maps each good traded by the markets to the markets trading it, each list sorted
+func buildTradeMap(markets []client.Market) map[string]*GoodTraders {
+	goods := make(map[string]*GoodTraders)
+	traders := func(symbol string) *GoodTraders {
+		if goods[symbol] == nil {
+			goods[symbol] = &GoodTraders{Producers: []string{}, Consumers: []string{}}
+		}
+		return goods[symbol]
+	}
+	for _, market := range markets {
+		for _, good := range market.Exports {
+			t := traders(good.Symbol)
+			t.Producers = append(t.Producers, market.Symbol)
+		}
+		for _, good := range market.Imports {
+			t := traders(good.Symbol)
+			t.Consumers = append(t.Consumers, market.Symbol)
+		}
+		for _, good := range market.Exchange {
+			t := traders(good.Symbol)
+			t.Exchanges = append(t.Exchanges, market.Symbol)
+		}
+	}
+	for _, t := range goods {
+		sort.Strings(t.Producers)
+		sort.Strings(t.Consumers)
+		sort.Strings(t.Exchanges)
+	}
+	return goods
+}
+
+// hasTrait reports whether a waypoint has a trait
+func hasTrait(waypoint client.SystemWaypoint, trait string) bool {
+	for _, t := range waypoint.Traits {
+		if t.Symbol == trait {
+			return true
+		}
+	}
+	return false
+}
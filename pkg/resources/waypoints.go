@@ -38,11 +38,61 @@ func (r *WaypointsResource) Resource() mcp.Resource {
 	}
 }
 
+// ResourceTemplate returns the parameterized form of the system waypoints resource
+func (r *WaypointsResource) ResourceTemplate() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		"spacetraders://systems/{systemSymbol}/waypoints{?page,limit,cursor,detail,refresh}",
+		"System Waypoints",
+		mcp.WithTemplateDescription(fmt.Sprintf("All waypoints in a system, by system symbol. Add page (from 1) and limit (at most %d), or the cursor from a previous page's next_cursor, to read one page at a time. detail=summary drops trait and modifier descriptions; detail=full (the default) keeps the complete payload. The whole list is cached for up to %s; refresh=true fetches it from the API. Pages are always fetched from the API", client.MaxPageLimit, client.WaypointCacheTTL)),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
 // Handler returns the resource handler function
 func (r *WaypointsResource) Handler() func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		// Parse the system symbol from the URI
-		systemSymbol, err := r.parseSystemSymbol(request.Params.URI)
+		// Parse the system symbol and any requested page from the URI
+		uri, query, err := splitQuery(request.Params.URI, append(pageParams, "detail", refreshParam)...)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+		page, err := pageFromQuery(query)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+		detail, err := detailFromQuery(query)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+		refresh, err := refreshFromQuery(query)
+		if err != nil {
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     fmt.Sprintf("Invalid resource URI: %s", err.Error()),
+				},
+			}, nil
+		}
+		systemSymbol, err := r.parseSystemSymbol(uri)
 		if err != nil {
 			return []mcp.ResourceContents{
 				&mcp.TextResourceContents{
@@ -57,9 +107,22 @@ func (r *WaypointsResource) Handler() func(ctx context.Context, request mcp.Read
 		ctxLogger := r.logger.WithContext(ctx, "waypoints-resource")
 		ctxLogger.Debug("Fetching waypoints for system %s from API", systemSymbol)
 
-		// Get waypoints information from the API
+		// Get waypoints information from the API, only fetching the requested page when there is one.
+		// The whole list comes from the waypoint cache unless a refresh was asked for.
+		var waypoints []client.SystemWaypoint
+		var total int
 		start := time.Now()
-		waypoints, err := r.client.GetAllSystemWaypoints(systemSymbol)
+		fetchedAt := start
+		switch {
+		case page != nil:
+			waypoints, total, err = r.client.WithContext(ctx).GetSystemWaypointsPage(systemSymbol, page.Page, page.Limit)
+		case refresh:
+			waypoints, fetchedAt, err = r.client.WithContext(ctx).RefreshSystemWaypoints(systemSymbol)
+			total = len(waypoints)
+		default:
+			waypoints, fetchedAt, err = r.client.WithContext(ctx).GetCachedSystemWaypoints(systemSymbol)
+			total = len(waypoints)
+		}
 		duration := time.Since(start)
 
 		if err != nil {
@@ -83,16 +146,38 @@ func (r *WaypointsResource) Handler() func(ctx context.Context, request mcp.Read
 			waypointsByType[waypoint.Type] = append(waypointsByType[waypoint.Type], waypoint)
 		}
 
-		// Format the response as structured JSON
-		result := map[string]interface{}{
+		// Summaries leave out trait and modifier descriptions
+		var listed interface{} = waypoints
+		if detail == detailSummary {
+			if listed, err = summarize(waypoints); err != nil {
+				ctxLogger.Error("Failed to summarize waypoints: %v", err)
+				return []mcp.ResourceContents{
+					&mcp.TextResourceContents{
+						URI:      request.Params.URI,
+						MIMEType: "text/plain",
+						Text:     "Error formatting waypoints information",
+					},
+				}, nil
+			}
+		}
+
+		// Format the response as structured JSON; on a page the summary only covers that page
+		result := fetchedEnvelope(map[string]interface{}{
 			"system":    systemSymbol,
-			"waypoints": waypoints,
+			"waypoints": listed,
 			"summary": map[string]interface{}{
-				"total":     len(waypoints),
+				"total":     total,
 				"byType":    r.getWaypointTypeCounts(waypoints),
 				"shipyards": r.getShipyardWaypoints(waypoints),
 				"markets":   r.getMarketWaypoints(waypoints),
 			},
+		}, len(waypoints), fetchedAt, start,
+			Link{Rel: "system", URI: "spacetraders://systems/" + systemSymbol},
+			Link{Rel: "shipyard", URI: "spacetraders://systems/" + systemSymbol + "/waypoints/{waypointSymbol}/shipyard"},
+			Link{Rel: "market", URI: "spacetraders://systems/" + systemSymbol + "/waypoints/{waypointSymbol}/market"},
+		)
+		if page != nil {
+			result = result.withPagination(page.pagination(total))
 		}
 
 		// Convert to JSON for response
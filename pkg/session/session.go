@@ -0,0 +1,143 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// maxEvents bounds how many notable events are kept; the oldest are dropped first
+const maxEvents = 100
+
+// Kinds of notable events
+const (
+	EventShipPurchased     = "ship_purchased"
+	EventShipScrapped      = "ship_scrapped"
+	EventContractAccepted  = "contract_accepted"
+	EventContractFulfilled = "contract_fulfilled"
+)
+
+// Event is something that changed the agent's position during the session
+type Event struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Kind        string    `json:"kind"`
+	ShipSymbol  string    `json:"shipSymbol,omitempty"`
+	ContractID  string    `json:"contractId,omitempty"`
+	Credits     int       `json:"credits,omitempty"`
+	Description string    `json:"description"`
+}
+
+// Summary is what changed since the server started
+type Summary struct {
+	StartedAt          time.Time `json:"startedAt"`
+	StartingCredits    *int64    `json:"startingCredits,omitempty"`
+	ShipsBought        []string  `json:"shipsBought"`
+	ShipsLost          []string  `json:"shipsLost"`
+	ContractsAccepted  []string  `json:"contractsAccepted"`
+	ContractsFulfilled []string  `json:"contractsFulfilled"`
+	Events             []Event   `json:"events"`
+}
+
+// Recorder keeps track of what changes during a session from client observations
+type Recorder struct {
+	mu              sync.RWMutex
+	startedAt       time.Time
+	startingCredits *int64
+	events          []Event
+
+	shipsBought        []string
+	shipsLost          []string
+	contractsAccepted  []string
+	contractsFulfilled []string
+}
+
+// NewRecorder creates a recorder for a session starting now
+func NewRecorder() *Recorder {
+	return &Recorder{startedAt: time.Now()}
+}
+
+// SetStartingCredits records the agent's balance when the session started. Only the first call counts.
+func (r *Recorder) SetStartingCredits(credits int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.startingCredits == nil {
+		r.startingCredits = &credits
+	}
+}
+
+// Observe records ship purchases, scrapped ships and contract changes; it is meant to be passed
+// to client.AddObserver
+func (r *Recorder) Observe(observation client.Observation) {
+	switch observation.Kind {
+	case client.ObservedShipyardTransaction:
+		if tx := observation.ShipyardTransaction; tx != nil {
+			r.record(&r.shipsBought, tx.ShipSymbol, Event{
+				Timestamp:   observation.ObservedAt,
+				Kind:        EventShipPurchased,
+				ShipSymbol:  tx.ShipSymbol,
+				Credits:     -tx.Price,
+				Description: fmt.Sprintf("Bought %s (%s) at %s for %d credits", tx.ShipSymbol, tx.ShipType, tx.WaypointSymbol, tx.Price),
+			})
+		}
+	case client.ObservedScrapTransaction:
+		if tx := observation.ScrapTransaction; tx != nil {
+			r.record(&r.shipsLost, tx.ShipSymbol, Event{
+				Timestamp:   observation.ObservedAt,
+				Kind:        EventShipScrapped,
+				ShipSymbol:  tx.ShipSymbol,
+				Credits:     tx.TotalPrice,
+				Description: fmt.Sprintf("Scrapped %s at %s for %d credits", tx.ShipSymbol, tx.WaypointSymbol, tx.TotalPrice),
+			})
+		}
+	case client.ObservedContractAccepted:
+		if contract := observation.Contract; contract != nil {
+			r.record(&r.contractsAccepted, contract.ID, Event{
+				Timestamp:   observation.ObservedAt,
+				Kind:        EventContractAccepted,
+				ContractID:  contract.ID,
+				Credits:     contract.Terms.Payment.OnAccepted,
+				Description: fmt.Sprintf("Accepted %s contract %s for %s, due %s", contract.Type, contract.ID, contract.FactionSymbol, contract.Terms.Deadline),
+			})
+		}
+	case client.ObservedContractFulfilled:
+		if contract := observation.Contract; contract != nil {
+			r.record(&r.contractsFulfilled, contract.ID, Event{
+				Timestamp:   observation.ObservedAt,
+				Kind:        EventContractFulfilled,
+				ContractID:  contract.ID,
+				Credits:     contract.Terms.Payment.OnFulfilled,
+				Description: fmt.Sprintf("Fulfilled contract %s for %d credits", contract.ID, contract.Terms.Payment.OnFulfilled),
+			})
+		}
+	}
+}
+
+// record adds symbol to a list of changes and remembers the event
+func (r *Recorder) record(list *[]string, symbol string, event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*list = append(*list, symbol)
+	r.events = append(r.events, event)
+	if len(r.events) > maxEvents {
+		r.events = r.events[len(r.events)-maxEvents:]
+	}
+}
+
+// Summary returns what changed since the session started
+func (r *Recorder) Summary() Summary {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return Summary{
+		StartedAt:          r.startedAt,
+		StartingCredits:    r.startingCredits,
+		ShipsBought:        append([]string{}, r.shipsBought...),
+		ShipsLost:          append([]string{}, r.shipsLost...),
+		ContractsAccepted:  append([]string{}, r.contractsAccepted...),
+		ContractsFulfilled: append([]string{}, r.contractsFulfilled...),
+		Events:             append([]Event{}, r.events...),
+	}
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestRecorder_Observe(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.SetStartingCredits(150000)
+	recorder.SetStartingCredits(1)
+
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder.Observe(client.Observation{
+		Kind:                client.ObservedShipyardTransaction,
+		ObservedAt:          at,
+		ShipyardTransaction: &client.Transaction{ShipSymbol: "AGENT-3", ShipType: "SHIP_PROBE", WaypointSymbol: "X1-TEST-A1", Price: 25000},
+	})
+	recorder.Observe(client.Observation{
+		Kind:             client.ObservedScrapTransaction,
+		ObservedAt:       at,
+		ScrapTransaction: &client.ScrapTransaction{ShipSymbol: "AGENT-2", WaypointSymbol: "X1-TEST-A1", TotalPrice: 5000},
+	})
+	contract := &client.Contract{ID: "contract-1", Type: "PROCUREMENT", FactionSymbol: "COSMIC"}
+	contract.Terms.Payment.OnFulfilled = 40000
+	recorder.Observe(client.Observation{Kind: client.ObservedContractAccepted, ObservedAt: at, Contract: contract})
+	recorder.Observe(client.Observation{Kind: client.ObservedContractFulfilled, ObservedAt: at, Contract: contract})
+	recorder.Observe(client.Observation{Kind: client.ObservedNavigation, ObservedAt: at, Nav: &client.Navigation{}})
+
+	summary := recorder.Summary()
+	if summary.StartingCredits == nil || *summary.StartingCredits != 150000 {
+		t.Errorf("Expected the first starting balance to be kept, got %v", summary.StartingCredits)
+	}
+	if len(summary.ShipsBought) != 1 || summary.ShipsBought[0] != "AGENT-3" {
+		t.Errorf("Expected AGENT-3 bought, got %v", summary.ShipsBought)
+	}
+	if len(summary.ShipsLost) != 1 || summary.ShipsLost[0] != "AGENT-2" {
+		t.Errorf("Expected AGENT-2 lost, got %v", summary.ShipsLost)
+	}
+	if len(summary.ContractsAccepted) != 1 || len(summary.ContractsFulfilled) != 1 {
+		t.Errorf("Expected one accepted and one fulfilled contract, got %v and %v", summary.ContractsAccepted, summary.ContractsFulfilled)
+	}
+	if len(summary.Events) != 4 || summary.Events[3].Kind != EventContractFulfilled || summary.Events[3].Credits != 40000 {
+		t.Errorf("Expected 4 events ending with the fulfilled contract, got %+v", summary.Events)
+	}
+}
+
+func TestRecorder_BoundsEvents(t *testing.T) {
+	recorder := NewRecorder()
+	for i := 0; i < maxEvents+10; i++ {
+		recorder.Observe(client.Observation{
+			Kind:                client.ObservedShipyardTransaction,
+			ShipyardTransaction: &client.Transaction{ShipSymbol: "AGENT-1", Price: i},
+		})
+	}
+
+	summary := recorder.Summary()
+	if len(summary.Events) != maxEvents {
+		t.Errorf("Expected %d events, got %d", maxEvents, len(summary.Events))
+	}
+	if summary.Events[0].Credits != -10 {
+		t.Errorf("Expected the oldest events to be dropped, got %+v", summary.Events[0])
+	}
+}
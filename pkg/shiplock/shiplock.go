@@ -0,0 +1,101 @@
+// Package shiplock serializes actions on each ship, so an interactive tool call and a
+// background task never act on the same ship at once while different ships proceed in parallel.
+package shiplock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locks holds one lock per ship. A nil *Locks never blocks, so callers can use it unconditionally.
+type Locks struct {
+	mu    sync.Mutex
+	ships map[string]*shipLock
+}
+
+// shipLock is a ship's lock and who holds it. The channel has room for one token: sending
+// takes the lock and receiving releases it, so waiting can be abandoned when a context ends.
+type shipLock struct {
+	token   chan struct{}
+	waiters int
+
+	holder string
+	since  time.Time
+}
+
+// New creates an empty set of ship locks
+func New() *Locks {
+	return &Locks{
+		ships: make(map[string]*shipLock),
+	}
+}
+
+// Lock waits until no other action holds the ship, then holds it for holder, a short
+// description such as a tool name or task ID. It returns a function that releases the lock,
+// or an error naming the current holder if ctx ends first.
+func (l *Locks) Lock(ctx context.Context, shipSymbol, holder string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	shipSymbol = strings.ToUpper(shipSymbol)
+
+	l.mu.Lock()
+	lock, ok := l.ships[shipSymbol]
+	if !ok {
+		lock = &shipLock{token: make(chan struct{}, 1)}
+		l.ships[shipSymbol] = lock
+	}
+	lock.waiters++
+	l.mu.Unlock()
+
+	select {
+	case lock.token <- struct{}{}:
+	case <-ctx.Done():
+		l.mu.Lock()
+		lock.waiters--
+		busy := lock.holder
+		l.forgetLocked(shipSymbol, lock)
+		l.mu.Unlock()
+		return nil, fmt.Errorf("%s is busy with %s: %w", shipSymbol, busy, ctx.Err())
+	}
+
+	l.mu.Lock()
+	lock.holder, lock.since = holder, time.Now()
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			lock.holder, lock.since = "", time.Time{}
+			lock.waiters--
+			<-lock.token
+			l.forgetLocked(shipSymbol, lock)
+		})
+	}, nil
+}
+
+// Holder returns what holds a ship's lock and since when, or "" when the ship is free
+func (l *Locks) Holder(shipSymbol string) (string, time.Time) {
+	if l == nil {
+		return "", time.Time{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.ships[strings.ToUpper(shipSymbol)]; ok {
+		return lock.holder, lock.since
+	}
+	return "", time.Time{}
+}
+
+// forgetLocked drops a ship's lock once nobody holds or waits for it, so the map only keeps
+// ships in use
+func (l *Locks) forgetLocked(shipSymbol string, lock *shipLock) {
+	if lock.waiters == 0 && l.ships[shipSymbol] == lock {
+		delete(l.ships, shipSymbol)
+	}
+}
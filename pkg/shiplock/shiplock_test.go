@@ -0,0 +1,82 @@
+package shiplock
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocks_SerializesOneShip(t *testing.T) {
+	locks := New()
+
+	unlock, err := locks.Lock(context.Background(), "ship-1", "navigate_ship")
+	if err != nil {
+		t.Fatalf("Lock returned error: %v", err)
+	}
+	if holder, _ := locks.Holder("SHIP-1"); holder != "navigate_ship" {
+		t.Errorf("Expected navigate_ship to hold SHIP-1, got %q", holder)
+	}
+
+	// Another ship is not held up
+	other, err := locks.Lock(context.Background(), "SHIP-2", "task-1")
+	if err != nil {
+		t.Fatalf("Expected SHIP-2 to lock while SHIP-1 is held, got %v", err)
+	}
+	other()
+
+	// A second action on the same ship waits for the first
+	var order []string
+	var mu sync.Mutex
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		release, err := locks.Lock(context.Background(), "SHIP-1", "task-2")
+		if err != nil {
+			t.Errorf("Lock returned error: %v", err)
+			return
+		}
+		mu.Lock()
+		order = append(order, "task-2")
+		mu.Unlock()
+		release()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	order = append(order, "navigate_ship")
+	mu.Unlock()
+	unlock()
+	unlock() // releasing twice is harmless
+	<-done
+
+	if len(order) != 2 || order[0] != "navigate_ship" {
+		t.Errorf("Expected the waiting action to run after the release, got %v", order)
+	}
+	if holder, _ := locks.Holder("SHIP-1"); holder != "" || len(locks.ships) != 0 {
+		t.Errorf("Expected every lock released and forgotten, got holder %q and %d locks", holder, len(locks.ships))
+	}
+}
+
+func TestLocks_GivesUpWhenContextEnds(t *testing.T) {
+	locks := New()
+	unlock, _ := locks.Lock(context.Background(), "SHIP-1", "task-1")
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := locks.Lock(ctx, "SHIP-1", "sell_cargo")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "busy with task-1") {
+		t.Errorf("Expected a deadline error naming the holder, got %v", err)
+	}
+}
+
+func TestLocks_NilNeverBlocks(t *testing.T) {
+	var locks *Locks
+	unlock, err := locks.Lock(context.Background(), "SHIP-1", "task-1")
+	if err != nil {
+		t.Fatalf("Expected a nil Locks to lock freely, got %v", err)
+	}
+	unlock()
+}
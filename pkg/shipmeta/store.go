@@ -0,0 +1,356 @@
+package shipmeta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxLabelLength is the longest label a ship may have
+const MaxLabelLength = 64
+
+// Meta is what the user has recorded about a ship. The API has no way to rename ships, so
+// labels, notes and tags only exist on this server.
+type Meta struct {
+	Label     string    `json:"label,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+}
+
+// IsZero reports whether nothing is recorded about the ship
+func (m Meta) IsZero() bool {
+	return m.Label == "" && m.Notes == "" && len(m.Tags) == 0
+}
+
+// HasTag reports whether the ship carries the tag
+func (m Meta) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Squadron is a named group of ships that tools can act on together
+type Squadron struct {
+	Name      string    `json:"name"`
+	Ships     []string  `json:"ships"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// state is the metadata written to disk
+type state struct {
+	Ships     map[string]Meta     `json:"ships"`
+	Squadrons map[string]Squadron `json:"squadrons"`
+}
+
+// Store keeps labels, notes and tags for ships by symbol, and squadrons by name. With a file
+// path it saves after every change, so metadata survives server restarts.
+type Store struct {
+	mu      sync.RWMutex
+	path    string
+	state   state
+	saveErr error
+}
+
+// Open returns a store backed by the file at path, loading metadata saved there before.
+// A missing file starts empty; an empty path keeps metadata in memory only.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	s.state = state{
+		Ships:     make(map[string]Meta),
+		Squadrons: make(map[string]Squadron),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ship metadata: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to parse ship metadata %s: %w", path, err)
+	}
+	if s.state.Ships == nil {
+		s.state.Ships = make(map[string]Meta)
+	}
+	if s.state.Squadrons == nil {
+		s.state.Squadrons = make(map[string]Squadron)
+	}
+	return s, nil
+}
+
+// Path returns the file metadata is saved to, or "" when it is kept in memory
+func (s *Store) Path() string {
+	return s.path
+}
+
+// SaveError returns the error from the last failed save, or nil if the last save succeeded
+func (s *Store) SaveError() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.saveErr
+}
+
+// Get returns the metadata recorded for a ship, and false when there is none
+func (s *Store) Get(shipSymbol string) (Meta, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	meta, ok := s.state.Ships[strings.ToUpper(shipSymbol)]
+	return cloneMeta(meta), ok
+}
+
+// All returns the metadata of every ship that has some, by ship symbol
+func (s *Store) All() map[string]Meta {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]Meta, len(s.state.Ships))
+	for symbol, meta := range s.state.Ships {
+		all[symbol] = cloneMeta(meta)
+	}
+	return all
+}
+
+// Export returns the metadata in the form saved to the store's file
+func (s *Store) Export() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return json.MarshalIndent(s.state, "", "  ")
+}
+
+// Restore replaces every label, note, tag and squadron with metadata exported before, and saves it
+func (s *Store) Restore(data []byte) error {
+	var restored state
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return fmt.Errorf("failed to parse ship metadata: %w", err)
+	}
+	if restored.Ships == nil {
+		restored.Ships = make(map[string]Meta)
+	}
+	if restored.Squadrons == nil {
+		restored.Squadrons = make(map[string]Squadron)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = restored
+	s.saveLocked()
+	return nil
+}
+
+// ShipsTagged returns the symbols of the ships carrying tag, sorted
+func (s *Store) ShipsTagged(tag string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var symbols []string
+	for symbol, meta := range s.state.Ships {
+		if meta.HasTag(tag) {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// SetLabel gives a ship a label and, when notes is not nil, replaces its notes. An empty label
+// removes the label.
+func (s *Store) SetLabel(shipSymbol, label string, notes *string) (Meta, error) {
+	label = strings.TrimSpace(label)
+	if len([]rune(label)) > MaxLabelLength {
+		return Meta{}, fmt.Errorf("label is longer than %d characters", MaxLabelLength)
+	}
+
+	return s.update(shipSymbol, func(meta *Meta) {
+		meta.Label = label
+		if notes != nil {
+			meta.Notes = strings.TrimSpace(*notes)
+		}
+	}), nil
+}
+
+// Tag adds and removes tags on a ship and returns its metadata afterwards. Tags are lowercased,
+// and removing a tag the ship doesn't carry does nothing.
+func (s *Store) Tag(shipSymbol string, add, remove []string) (Meta, error) {
+	var added, removed []string
+	for _, tag := range add {
+		normalized := NormalizeTag(tag)
+		if normalized == "" {
+			return Meta{}, fmt.Errorf("invalid tag %q: tags need at least one letter or digit", tag)
+		}
+		added = append(added, normalized)
+	}
+	for _, tag := range remove {
+		removed = append(removed, NormalizeTag(tag))
+	}
+
+	return s.update(shipSymbol, func(meta *Meta) {
+		tags := make(map[string]bool, len(meta.Tags)+len(added))
+		for _, tag := range meta.Tags {
+			tags[tag] = true
+		}
+		for _, tag := range added {
+			tags[tag] = true
+		}
+		for _, tag := range removed {
+			delete(tags, tag)
+		}
+		meta.Tags = meta.Tags[:0]
+		for tag := range tags {
+			meta.Tags = append(meta.Tags, tag)
+		}
+		sort.Strings(meta.Tags)
+		if len(meta.Tags) == 0 {
+			meta.Tags = nil
+		}
+	}), nil
+}
+
+// SaveSquadron creates a squadron, or replaces the ships of an existing one. Names are
+// normalized like tags, and ship symbols are uppercased with duplicates dropped.
+func (s *Store) SaveSquadron(name string, ships []string) (Squadron, error) {
+	key := NormalizeTag(name)
+	if key == "" {
+		return Squadron{}, fmt.Errorf("invalid squadron name %q: names need at least one letter or digit", name)
+	}
+	members := make([]string, 0, len(ships))
+	seen := make(map[string]bool, len(ships))
+	for _, ship := range ships {
+		ship = strings.ToUpper(strings.TrimSpace(ship))
+		if ship == "" || seen[ship] {
+			continue
+		}
+		seen[ship] = true
+		members = append(members, ship)
+	}
+	if len(members) == 0 {
+		return Squadron{}, fmt.Errorf("squadron %s needs at least one ship", key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	squadron, ok := s.state.Squadrons[key]
+	if !ok {
+		squadron = Squadron{Name: key, CreatedAt: now}
+	}
+	squadron.Ships = members
+	squadron.UpdatedAt = now
+	s.state.Squadrons[key] = squadron
+	s.saveLocked()
+	return cloneSquadron(squadron), nil
+}
+
+// Squadron returns the squadron with a name, and false when there is none
+func (s *Store) Squadron(name string) (Squadron, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	squadron, ok := s.state.Squadrons[NormalizeTag(name)]
+	return cloneSquadron(squadron), ok
+}
+
+// Squadrons returns every squadron, sorted by name
+func (s *Store) Squadrons() []Squadron {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	squadrons := make([]Squadron, 0, len(s.state.Squadrons))
+	for _, squadron := range s.state.Squadrons {
+		squadrons = append(squadrons, cloneSquadron(squadron))
+	}
+	sort.Slice(squadrons, func(i, j int) bool {
+		return squadrons[i].Name < squadrons[j].Name
+	})
+	return squadrons
+}
+
+// DisbandSquadron forgets a squadron, reporting whether it existed. The ships are not affected.
+func (s *Store) DisbandSquadron(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := NormalizeTag(name)
+	if _, ok := s.state.Squadrons[key]; !ok {
+		return false
+	}
+	delete(s.state.Squadrons, key)
+	s.saveLocked()
+	return true
+}
+
+// update changes a ship's metadata and saves it, dropping ships left with nothing recorded
+func (s *Store) update(shipSymbol string, change func(*Meta)) Meta {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shipSymbol = strings.ToUpper(shipSymbol)
+	meta := cloneMeta(s.state.Ships[shipSymbol])
+	change(&meta)
+	meta.UpdatedAt = time.Now().UTC()
+	if meta.IsZero() {
+		delete(s.state.Ships, shipSymbol)
+	} else {
+		s.state.Ships[shipSymbol] = meta
+	}
+	s.saveLocked()
+	return cloneMeta(meta)
+}
+
+// saveLocked writes the metadata to disk when the store is backed by a file. The caller must
+// hold the write lock.
+func (s *Store) saveLocked() {
+	if s.path == "" {
+		return
+	}
+
+	s.saveErr = func() error {
+		data, err := json.MarshalIndent(s.state, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+			return err
+		}
+		tmp := s.path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o644); err != nil {
+			return err
+		}
+		return os.Rename(tmp, s.path)
+	}()
+}
+
+// NormalizeTag lowercases a tag and joins its words with dashes, so "Ore Hauler" and
+// "ore-hauler" are the same tag
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_')
+	}), "-")
+}
+
+// cloneSquadron copies a squadron so callers can't change the store's member slice
+func cloneSquadron(squadron Squadron) Squadron {
+	squadron.Ships = append([]string(nil), squadron.Ships...)
+	return squadron
+}
+
+// cloneMeta copies metadata so callers can't change the store's tag slices
+func cloneMeta(meta Meta) Meta {
+	meta.Tags = append([]string(nil), meta.Tags...)
+	if len(meta.Tags) == 0 {
+		meta.Tags = nil
+	}
+	return meta
+}
@@ -0,0 +1,142 @@
+package shipmeta
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStore_PersistsMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta", "ships.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	notes := "Mines iron at the asteroid field"
+	if _, err := store.SetLabel("ship-1", " Ore Hauler #2 ", &notes); err != nil {
+		t.Fatalf("SetLabel returned error: %v", err)
+	}
+	if _, err := store.Tag("SHIP-1", []string{"Mining", "ore hauler"}, nil); err != nil {
+		t.Fatalf("Tag returned error: %v", err)
+	}
+	if _, err := store.Tag("SHIP-2", []string{"mining"}, nil); err != nil {
+		t.Fatalf("Tag returned error: %v", err)
+	}
+	if err := store.SaveError(); err != nil {
+		t.Fatalf("Expected metadata to be saved, got %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	meta, ok := reopened.Get("SHIP-1")
+	if !ok {
+		t.Fatal("Expected SHIP-1 metadata after reopening")
+	}
+	if meta.Label != "Ore Hauler #2" || meta.Notes != notes {
+		t.Errorf("Expected label and notes to survive, got %+v", meta)
+	}
+	if want := []string{"mining", "ore-hauler"}; !reflect.DeepEqual(meta.Tags, want) {
+		t.Errorf("Expected tags %v, got %v", want, meta.Tags)
+	}
+	if got, want := reopened.ShipsTagged("MINING"), []string{"SHIP-1", "SHIP-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected ships tagged mining %v, got %v", want, got)
+	}
+}
+
+func TestStore_SetLabelKeepsNotesUnlessGiven(t *testing.T) {
+	store, _ := Open("")
+	notes := "Keep docked at HQ"
+	_, _ = store.SetLabel("SHIP-1", "Courier", &notes)
+
+	meta, _ := store.SetLabel("SHIP-1", "Fast Courier", nil)
+	if meta.Label != "Fast Courier" || meta.Notes != notes {
+		t.Errorf("Expected the new label with the old notes, got %+v", meta)
+	}
+
+	if _, err := store.SetLabel("SHIP-1", strings.Repeat("x", MaxLabelLength+1), nil); err == nil {
+		t.Error("Expected an error for a label that is too long")
+	}
+}
+
+func TestStore_DropsShipsWithNothingRecorded(t *testing.T) {
+	store, _ := Open("")
+	_, _ = store.SetLabel("SHIP-1", "Scout", nil)
+	_, _ = store.Tag("SHIP-1", []string{"probe"}, nil)
+
+	_, _ = store.SetLabel("SHIP-1", "", nil)
+	meta, _ := store.Tag("SHIP-1", nil, []string{"Probe", "never-added"})
+	if !meta.IsZero() {
+		t.Errorf("Expected no metadata left, got %+v", meta)
+	}
+	if _, ok := store.Get("SHIP-1"); ok {
+		t.Error("Expected SHIP-1 to be forgotten once its label and tags were removed")
+	}
+	if len(store.All()) != 0 {
+		t.Errorf("Expected an empty store, got %v", store.All())
+	}
+}
+
+func TestStore_RejectsEmptyTags(t *testing.T) {
+	store, _ := Open("")
+	if _, err := store.Tag("SHIP-1", []string{"  ", "!!"}, nil); err == nil {
+		t.Error("Expected an error for tags without letters or digits")
+	}
+	if _, ok := store.Get("SHIP-1"); ok {
+		t.Error("Expected a rejected tag call to record nothing")
+	}
+}
+
+func TestOpen_RejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ships.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Expected an error opening a corrupt metadata file")
+	}
+}
+
+func TestStore_Squadrons(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ships.json")
+	store, _ := Open(path)
+
+	if _, err := store.SaveSquadron("Mining Fleet", []string{"drone-1", "DRONE-2", "drone-1", " "}); err != nil {
+		t.Fatalf("SaveSquadron returned error: %v", err)
+	}
+	if _, err := store.SaveSquadron("haulers", []string{"HAULER-1"}); err != nil {
+		t.Fatalf("SaveSquadron returned error: %v", err)
+	}
+	if _, err := store.SaveSquadron("empty", nil); err == nil {
+		t.Error("Expected an error for a squadron without ships")
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	squadron, ok := reopened.Squadron("MINING FLEET")
+	if !ok {
+		t.Fatal("Expected the mining-fleet squadron after reopening")
+	}
+	if squadron.Name != "mining-fleet" || !reflect.DeepEqual(squadron.Ships, []string{"DRONE-1", "DRONE-2"}) {
+		t.Errorf("Expected mining-fleet with DRONE-1 and DRONE-2, got %+v", squadron)
+	}
+
+	// Saving again replaces the ships but keeps when the squadron was created
+	replaced, _ := reopened.SaveSquadron("mining-fleet", []string{"DRONE-3"})
+	if !reflect.DeepEqual(replaced.Ships, []string{"DRONE-3"}) || !replaced.CreatedAt.Equal(squadron.CreatedAt) {
+		t.Errorf("Expected the ships replaced and the creation time kept, got %+v", replaced)
+	}
+
+	if !reopened.DisbandSquadron("haulers") || reopened.DisbandSquadron("haulers") {
+		t.Error("Expected disbanding to report whether the squadron existed")
+	}
+	if names := reopened.Squadrons(); len(names) != 1 || names[0].Name != "mining-fleet" {
+		t.Errorf("Expected only mining-fleet left, got %+v", names)
+	}
+}
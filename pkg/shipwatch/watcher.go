@@ -0,0 +1,461 @@
+package shipwatch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/polling"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/travel"
+)
+
+// DefaultInterval is how often watched shipyards are checked while ships there are idle. Checks
+// come sooner when a ship is about to arrive at one, and up to three times less often once the
+// ships there have sat unchanged for a while.
+const DefaultInterval = 10 * time.Minute
+
+// maxHistory bounds how many prices are kept per ship type at one shipyard; the oldest go first
+const maxHistory = 200
+
+// sweepKey is the scheduler key of the watcher's single background job
+const sweepKey = "shipwatch"
+
+// PricePoint is the price of a ship type at a shipyard when it was seen
+type PricePoint struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ObservedAt     time.Time `json:"observedAt"`
+	PurchasePrice  int       `json:"purchasePrice"`
+	Supply         string    `json:"supply"`
+	Activity       string    `json:"activity,omitempty"`
+}
+
+// Watch waits for a ship type to be sold at or below a target price
+type Watch struct {
+	ID          string `json:"id"`
+	ShipType    string `json:"shipType"`
+	TargetPrice int    `json:"targetPrice"`
+	// Waypoints are the shipyards checked on a schedule; with none, any shipyard seen counts
+	Waypoints []string  `json:"waypoints,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Best is the cheapest latest price of the ship type at the shipyards watched
+	Best          *PricePoint `json:"best,omitempty"`
+	LastCheckedAt time.Time   `json:"lastCheckedAt,omitzero"`
+	LastError     string      `json:"lastError,omitempty"`
+	Alerts        int         `json:"alerts"`
+	LastAlertAt   time.Time   `json:"lastAlertAt,omitzero"`
+
+	// below remembers the shipyards last seen at or below the target, so each drop alerts once
+	below map[string]bool
+}
+
+// Alert is a watched ship type seen at or below its target price
+type Alert struct {
+	Watch Watch      `json:"watch"`
+	Price PricePoint `json:"price"`
+}
+
+// Message describes the alert in one line
+func (a Alert) Message() string {
+	return fmt.Sprintf("🚀 %s is selling for %d credits at %s (supply %s), at or below the %d credit target of watch %s",
+		a.Watch.ShipType, a.Price.PurchasePrice, a.Price.WaypointSymbol, a.Price.Supply, a.Watch.TargetPrice, a.Watch.ID)
+}
+
+// Watcher records the price of every ship type at every shipyard the client fetches with a ship
+// present, and alerts when a watched ship type reaches its target price. Watched shipyards are
+// also fetched on a schedule while one of the agent's ships is there to see the prices.
+type Watcher struct {
+	client    *client.Client
+	logger    *logging.Logger
+	scheduler *polling.Scheduler
+	limiter   *tasks.RateLimiter
+	idle      *polling.IdleTracker
+	interval  time.Duration
+
+	mu      sync.Mutex
+	history map[string]map[string][]PricePoint
+	watches map[string]*Watch
+	nextID  int
+	onAlert func(Alert)
+}
+
+// New creates a shipyard watcher whose scheduled checks stop when ctx is cancelled. Nothing is
+// checked until Start is called, but prices are recorded as soon as it observes the client.
+func New(ctx context.Context, client *client.Client, logger *logging.Logger) *Watcher {
+	return &Watcher{
+		client:    client,
+		logger:    logger,
+		scheduler: polling.NewScheduler(ctx),
+		limiter:   tasks.NewRateLimiter(tasks.DefaultRequestInterval),
+		idle:      polling.NewIdleTracker(),
+		interval:  DefaultInterval,
+		history:   make(map[string]map[string][]PricePoint),
+		watches:   make(map[string]*Watch),
+	}
+}
+
+// OnAlert sets a function called with every alert
+func (w *Watcher) OnAlert(alert func(Alert)) *Watcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onAlert = alert
+	return w
+}
+
+// Start begins checking watched shipyards in the background; the first check runs immediately
+func (w *Watcher) Start() {
+	w.scheduler.Schedule(sweepKey, w.sweep)
+}
+
+// Stop cancels the scheduled checks and waits for a running one to finish
+func (w *Watcher) Stop() {
+	w.scheduler.Stop()
+}
+
+// Observe records the ship prices of fetched shipyards; it is meant to be passed to client.AddObserver
+func (w *Watcher) Observe(observation client.Observation) {
+	if observation.Kind != client.ObservedShipyard || observation.Shipyard == nil {
+		return
+	}
+	at := observation.ObservedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	w.Record(observation.Shipyard.Symbol, at, observation.Shipyard.Ships)
+}
+
+// Record adds the prices of the ships listed at a shipyard and alerts on any watch they meet.
+// Shipyards without a ship of ours present list no ships, so nothing is recorded for them.
+func (w *Watcher) Record(waypointSymbol string, at time.Time, ships []client.ShipyardShip) {
+	if len(ships) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	var alerts []Alert
+	for _, ship := range ships {
+		point := PricePoint{
+			WaypointSymbol: waypointSymbol,
+			ObservedAt:     at,
+			PurchasePrice:  ship.PurchasePrice,
+			Supply:         ship.Supply,
+			Activity:       ship.Activity,
+		}
+		byShipyard := w.history[ship.Type]
+		if byShipyard == nil {
+			byShipyard = make(map[string][]PricePoint)
+			w.history[ship.Type] = byShipyard
+		}
+		points := append(byShipyard[waypointSymbol], point)
+		if len(points) > maxHistory {
+			points = points[len(points)-maxHistory:]
+		}
+		byShipyard[waypointSymbol] = points
+
+		for _, watch := range w.watches {
+			if watch.ShipType != ship.Type || !watch.covers(waypointSymbol) {
+				continue
+			}
+			w.updateBest(watch)
+			met := point.PurchasePrice <= watch.TargetPrice
+			if met && !watch.below[waypointSymbol] {
+				watch.Alerts++
+				watch.LastAlertAt = at
+				alerts = append(alerts, Alert{Watch: watch.snapshot(), Price: point})
+			}
+			watch.below[waypointSymbol] = met
+		}
+	}
+	onAlert := w.onAlert
+	w.mu.Unlock()
+
+	for _, alert := range alerts {
+		w.logger.Info("%s", alert.Message())
+		if onAlert != nil {
+			onAlert(alert)
+		}
+	}
+}
+
+// Watch starts waiting for a ship type to sell at or below targetPrice at any of the waypoints,
+// or at any shipyard when none are given. Watching the same ship type at the same shipyards again
+// only changes the target. Prices already known are checked straight away.
+func (w *Watcher) Watch(shipType string, targetPrice int, waypoints []string) Watch {
+	waypoints = slices.Clone(waypoints)
+	sort.Strings(waypoints)
+	waypoints = slices.Compact(waypoints)
+
+	w.mu.Lock()
+	var watch *Watch
+	for _, existing := range w.watches {
+		if existing.ShipType == shipType && slices.Equal(existing.Waypoints, waypoints) {
+			watch = existing
+			break
+		}
+	}
+	if watch == nil {
+		w.nextID++
+		watch = &Watch{
+			ID:        fmt.Sprintf("watch-%d", w.nextID),
+			ShipType:  shipType,
+			Waypoints: waypoints,
+			CreatedAt: time.Now(),
+		}
+		w.watches[watch.ID] = watch
+	}
+	watch.TargetPrice = targetPrice
+	watch.below = make(map[string]bool)
+	w.updateBest(watch)
+
+	var alerts []Alert
+	for _, point := range w.latestLocked(shipType) {
+		if !watch.covers(point.WaypointSymbol) {
+			continue
+		}
+		met := point.PurchasePrice <= targetPrice
+		if met {
+			watch.Alerts++
+			watch.LastAlertAt = time.Now()
+			alerts = append(alerts, Alert{Watch: watch.snapshot(), Price: point})
+		}
+		watch.below[point.WaypointSymbol] = met
+	}
+	result := watch.snapshot()
+	onAlert := w.onAlert
+	w.mu.Unlock()
+
+	w.logger.Info("Watching %s for %d credits or less", shipType, targetPrice)
+	for _, alert := range alerts {
+		w.logger.Info("%s", alert.Message())
+		if onAlert != nil {
+			onAlert(alert)
+		}
+	}
+	return result
+}
+
+// Unwatch stops a watch, reporting whether it existed
+func (w *Watcher) Unwatch(id string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, exists := w.watches[id]; !exists {
+		return false
+	}
+	delete(w.watches, id)
+	return true
+}
+
+// Watches returns every watch, oldest first
+func (w *Watcher) Watches() []Watch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	result := make([]Watch, 0, len(w.watches))
+	for _, watch := range w.watches {
+		result = append(result, watch.snapshot())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// ShipTypes returns every ship type with a recorded price, sorted
+func (w *Watcher) ShipTypes() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	types := make([]string, 0, len(w.history))
+	for shipType := range w.history {
+		types = append(types, shipType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// History returns the prices of a ship type seen since the given time, oldest first. With a
+// waypoint only that shipyard's prices are returned.
+func (w *Watcher) History(shipType, waypointSymbol string, since time.Time) []PricePoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var result []PricePoint
+	for waypoint, points := range w.history[shipType] {
+		if waypointSymbol != "" && waypoint != waypointSymbol {
+			continue
+		}
+		for _, point := range points {
+			if !point.ObservedAt.Before(since) {
+				result = append(result, point)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].ObservedAt.Equal(result[j].ObservedAt) {
+			return result[i].ObservedAt.Before(result[j].ObservedAt)
+		}
+		return result[i].WaypointSymbol < result[j].WaypointSymbol
+	})
+	return result
+}
+
+// Latest returns the most recent price of a ship type at every shipyard that sold it, cheapest first
+func (w *Watcher) Latest(shipType string) []PricePoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.latestLocked(shipType)
+}
+
+// latestLocked is Latest for a caller holding the lock
+func (w *Watcher) latestLocked(shipType string) []PricePoint {
+	result := make([]PricePoint, 0, len(w.history[shipType]))
+	for _, points := range w.history[shipType] {
+		result = append(result, points[len(points)-1])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].PurchasePrice != result[j].PurchasePrice {
+			return result[i].PurchasePrice < result[j].PurchasePrice
+		}
+		return result[i].WaypointSymbol < result[j].WaypointSymbol
+	})
+	return result
+}
+
+// updateBest sets a watch's best price from the latest prices; the caller holds the lock
+func (w *Watcher) updateBest(watch *Watch) {
+	watch.Best = nil
+	for _, point := range w.latestLocked(watch.ShipType) {
+		if watch.covers(point.WaypointSymbol) {
+			best := point
+			watch.Best = &best
+			return
+		}
+	}
+}
+
+// covers reports whether prices at a shipyard count for the watch
+func (watch *Watch) covers(waypointSymbol string) bool {
+	return len(watch.Waypoints) == 0 || slices.Contains(watch.Waypoints, waypointSymbol)
+}
+
+// snapshot copies a watch for use outside the lock
+func (watch *Watch) snapshot() Watch {
+	result := *watch
+	result.Waypoints = slices.Clone(watch.Waypoints)
+	if watch.Best != nil {
+		best := *watch.Best
+		result.Best = &best
+	}
+	result.below = nil
+	return result
+}
+
+// sweep fetches every watched shipyard that has a ship of ours present and hasn't been fetched
+// within an interval, and returns how long to wait before the next sweep: just before a ship
+// reaches a watched shipyard, or longer while the ships at them sit idle
+func (w *Watcher) sweep(ctx context.Context) time.Duration {
+	w.mu.Lock()
+	var waypoints []string
+	for _, watch := range w.watches {
+		waypoints = append(waypoints, watch.Waypoints...)
+	}
+	w.mu.Unlock()
+	sort.Strings(waypoints)
+	waypoints = slices.Compact(waypoints)
+	if len(waypoints) == 0 {
+		return w.interval
+	}
+
+	c := w.client.WithContext(ctx)
+	var ships []client.Ship
+	err := w.call(ctx, func() (err error) {
+		ships, err = c.GetAllShips()
+		return err
+	})
+	if err != nil {
+		w.logger.Error("Shipyard watch failed to list ships: %v", err)
+		w.recordChecks(waypoints, func(string) error { return err })
+		return w.interval
+	}
+	now := time.Now()
+	present := make(map[string]bool)
+	idle := make(map[string]time.Duration)
+	var watched []client.Ship
+	for _, ship := range ships {
+		if ship.Nav.Status != "IN_TRANSIT" {
+			present[ship.Nav.WaypointSymbol] = true
+		}
+		// A ship in transit is bound for its waypoint, so ships on the way count as well
+		if !slices.Contains(waypoints, ship.Nav.WaypointSymbol) {
+			continue
+		}
+		idle[ship.Symbol] = w.idle.Observe(ship.Symbol, ship.Nav.Status+" at "+ship.Nav.WaypointSymbol, now)
+		// Only where ships are matters here, so cooldowns don't bring sweeps forward
+		ship.Cooldown = client.Cooldown{}
+		watched = append(watched, ship)
+	}
+
+	outcomes := make(map[string]error, len(waypoints))
+	for _, waypoint := range waypoints {
+		// A stationed probe or a tool may already have fetched the shipyard
+		if listing, ok := c.KnownShipyard(waypoint); ok && time.Since(listing.FetchedAt) < w.interval {
+			outcomes[waypoint] = nil
+			continue
+		}
+		if !present[waypoint] {
+			outcomes[waypoint] = fmt.Errorf("no ship at %s to see its prices; station a probe there with deploy_probe", waypoint)
+			continue
+		}
+		outcomes[waypoint] = w.call(ctx, func() error {
+			_, err := c.GetShipyard(travel.SystemSymbol(waypoint), waypoint)
+			return err
+		})
+		if outcomes[waypoint] != nil {
+			w.logger.Error("Shipyard watch failed to fetch %s: %v", waypoint, outcomes[waypoint])
+		}
+	}
+	w.recordChecks(waypoints, func(waypoint string) error { return outcomes[waypoint] })
+	return polling.ForInterval(w.interval).FleetInterval(watched, func(ship client.Ship) time.Duration { return idle[ship.Symbol] }, now)
+}
+
+// recordChecks notes when each watch's shipyards were checked and why any couldn't be
+func (w *Watcher) recordChecks(waypoints []string, outcome func(waypoint string) error) {
+	now := time.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, watch := range w.watches {
+		if len(watch.Waypoints) == 0 {
+			continue
+		}
+		var failures []string
+		for _, waypoint := range watch.Waypoints {
+			if !slices.Contains(waypoints, waypoint) {
+				continue
+			}
+			if err := outcome(waypoint); err != nil {
+				failures = append(failures, err.Error())
+			}
+		}
+		watch.LastCheckedAt = now
+		watch.LastError = strings.Join(slices.Compact(failures), "; ")
+	}
+}
+
+// call waits for a rate limit slot and then runs fn
+func (w *Watcher) call(ctx context.Context, fn func() error) error {
+	if err := w.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return fn()
+}
@@ -0,0 +1,167 @@
+package shipwatch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+)
+
+func drones(price int) []client.ShipyardShip {
+	return []client.ShipyardShip{{Type: "SHIP_MINING_DRONE", PurchasePrice: price, Supply: "MODERATE"}}
+}
+
+func TestWatcher_AlertsOncePerDrop(t *testing.T) {
+	w := New(context.Background(), nil, logging.NewLogger(nil))
+	var alerts []Alert
+	w.OnAlert(func(alert Alert) {
+		alerts = append(alerts, alert)
+	})
+
+	now := time.Now()
+	w.Record("X1-A1", now.Add(-time.Hour), drones(52000))
+	watch := w.Watch("SHIP_MINING_DRONE", 45000, nil)
+	if len(alerts) != 0 || watch.Best == nil || watch.Best.PurchasePrice != 52000 {
+		t.Fatalf("Expected no alert and a best price of 52000, got %v %+v", alerts, watch.Best)
+	}
+
+	// The first drop below the target alerts, staying there doesn't, and a second drop does
+	for _, price := range []int{44000, 43000, 50000, 45000} {
+		now = now.Add(time.Minute)
+		w.Record("X1-A1", now, drones(price))
+	}
+	if len(alerts) != 2 || alerts[0].Price.PurchasePrice != 44000 || alerts[1].Price.PurchasePrice != 45000 {
+		t.Errorf("Expected alerts at 44000 and 45000, got %+v", alerts)
+	}
+	if !strings.Contains(alerts[0].Message(), "SHIP_MINING_DRONE is selling for 44000 credits at X1-A1") {
+		t.Errorf("Unexpected alert message: %s", alerts[0].Message())
+	}
+
+	// Watching again only moves the target, and checks the known prices straight away
+	again := w.Watch("SHIP_MINING_DRONE", 46000, nil)
+	if again.ID != watch.ID || len(w.Watches()) != 1 || len(alerts) != 3 {
+		t.Errorf("Expected the same watch to alert on the latest price, got %+v and %d alerts", again, len(alerts))
+	}
+
+	if history := w.History("SHIP_MINING_DRONE", "", now.Add(-30*time.Minute)); len(history) != 4 {
+		t.Errorf("Expected 4 prices in the last half hour, got %v", history)
+	}
+	if !w.Unwatch(watch.ID) || w.Unwatch(watch.ID) {
+		t.Error("Expected the watch to be removed exactly once")
+	}
+}
+
+func TestWatcher_OnlyWatchedShipyards(t *testing.T) {
+	w := New(context.Background(), nil, logging.NewLogger(nil))
+	w.Watch("SHIP_MINING_DRONE", 45000, []string{"X1-B2", "X1-A1", "X1-B2"})
+	w.Record("X1-C3", time.Now(), drones(30000))
+	w.Record("X1-B2", time.Now(), drones(47000))
+
+	watch := w.Watches()[0]
+	if strings.Join(watch.Waypoints, ",") != "X1-A1,X1-B2" {
+		t.Errorf("Expected sorted unique waypoints, got %v", watch.Waypoints)
+	}
+	if watch.Alerts != 0 || watch.Best == nil || watch.Best.WaypointSymbol != "X1-B2" {
+		t.Errorf("Expected the cheaper unwatched shipyard to be ignored, got %+v", watch)
+	}
+	if latest := w.Latest("SHIP_MINING_DRONE"); len(latest) != 2 || latest[0].WaypointSymbol != "X1-C3" {
+		t.Errorf("Expected the latest prices cheapest first, got %v", latest)
+	}
+}
+
+func TestWatcher_SweepFetchesShipyardsWithShips(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [{"symbol": "PROBE-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT"}}], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-A1/shipyard":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "shipTypes": [{"type": "SHIP_MINING_DRONE"}], "ships": [{"type": "SHIP_MINING_DRONE", "purchasePrice": 41000, "supply": "HIGH"}], "modificationsFee": 0}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	w := New(context.Background(), c, logging.NewLogger(nil))
+	w.limiter = tasks.NewRateLimiter(0)
+	c.AddObserver(w.Observe)
+	var alerts []Alert
+	w.OnAlert(func(alert Alert) {
+		alerts = append(alerts, alert)
+	})
+	w.Watch("SHIP_MINING_DRONE", 45000, []string{"X1-TEST-A1", "X1-TEST-B2"})
+
+	w.sweep(context.Background())
+	if got := strings.Join(requests, ","); got != "/my/ships,/systems/X1-TEST/waypoints/X1-TEST-A1/shipyard" {
+		t.Errorf("Expected only the shipyard with a ship to be fetched, got %s", got)
+	}
+	if len(alerts) != 1 || alerts[0].Price.PurchasePrice != 41000 {
+		t.Errorf("Expected an alert at 41000, got %+v", alerts)
+	}
+	watch := w.Watches()[0]
+	if !strings.Contains(watch.LastError, "no ship at X1-TEST-B2") || watch.LastCheckedAt.IsZero() {
+		t.Errorf("Expected the shipyard without a ship to be reported, got %+v", watch)
+	}
+
+	// A shipyard fetched within the interval isn't fetched again
+	requests = nil
+	w.sweep(context.Background())
+	if fmt.Sprint(requests) != "[/my/ships]" {
+		t.Errorf("Expected the fresh shipyard to be skipped, got %v", requests)
+	}
+}
+
+func TestWatcher_SweepsSoonerBeforeArrival(t *testing.T) {
+	var mu sync.Mutex
+	nav := `"status": "IN_TRANSIT", "route": {"arrival": "` + time.Now().Add(time.Minute).UTC().Format(time.RFC3339) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [{"symbol": "PROBE-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", ` + nav + `}}], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-A1/shipyard":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "shipTypes": [], "modificationsFee": 0}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	w := New(context.Background(), client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	w.limiter = tasks.NewRateLimiter(0)
+	if next := w.sweep(context.Background()); next != DefaultInterval {
+		t.Errorf("Expected the next sweep in %v with nothing watched, got %v", DefaultInterval, next)
+	}
+	w.Watch("SHIP_MINING_DRONE", 45000, []string{"X1-TEST-A1"})
+
+	// The probe on its way to the watched shipyard brings the next sweep forward
+	if next := w.sweep(context.Background()); next >= time.Minute || next < 50*time.Second {
+		t.Errorf("Expected the next sweep just under a minute away, got %v", next)
+	}
+
+	// Once it is there, sweeps go back to the interval
+	mu.Lock()
+	nav = `"status": "IN_ORBIT"`
+	mu.Unlock()
+	if next := w.sweep(context.Background()); next != DefaultInterval {
+		t.Errorf("Expected the next sweep in %v once the probe arrived, got %v", DefaultInterval, next)
+	}
+}
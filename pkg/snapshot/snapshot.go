@@ -0,0 +1,321 @@
+// Package snapshot saves what the server has accumulated locally to a single zip archive and
+// restores it, so the knowledge survives moving to another machine or reinstalling
+package snapshot
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+)
+
+// FormatVersion is the archive layout written by Save; Restore refuses newer layouts
+const FormatVersion = 1
+
+// Parts of an archive, each a JSON file in the zip. Exploration and ship metadata use the same
+// format as the files those stores save between sessions.
+const (
+	PartPrices      = "prices"
+	PartExploration = "exploration"
+	PartShipMeta    = "ships"
+	PartTasks       = "tasks"
+)
+
+const manifestFile = "manifest.json"
+
+// maxPartSize bounds how much of one archive part is read, so a corrupt or hostile archive
+// can't exhaust memory
+const maxPartSize = 256 << 20
+
+// State is the local state an archive is saved from and restored into. Nil parts are left out
+// of saved archives and skipped when restoring.
+type State struct {
+	Prices   *prices.DB
+	Explorer *explorer.Tracker
+	ShipMeta *shipmeta.Store
+	Tasks    *tasks.Manager
+}
+
+// Manifest describes an archive: when it was made and how much each part holds
+type Manifest struct {
+	FormatVersion int            `json:"formatVersion"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	ServerVersion string         `json:"serverVersion,omitempty"`
+	Counts        map[string]int `json:"counts"`
+}
+
+// TaskSpec is what is needed to start a task again: the ship, behavior and parameters
+type TaskSpec struct {
+	ShipSymbol string            `json:"shipSymbol"`
+	Behavior   string            `json:"behavior"`
+	Params     map[string]string `json:"params,omitempty"`
+}
+
+// Report describes what Restore did with each part of an archive
+type Report struct {
+	Manifest Manifest `json:"manifest"`
+	// Restored counts what each restored part held
+	Restored map[string]int `json:"restored"`
+	// Skipped explains why a part in the archive was not restored
+	Skipped map[string]string `json:"skipped,omitempty"`
+	// Tasks are the tasks that were running when the archive was saved
+	Tasks []TaskSpec `json:"tasks,omitempty"`
+	// Resumed are the tasks started again, and TaskErrors why others were not
+	Resumed    []tasks.Task `json:"resumed,omitempty"`
+	TaskErrors []string     `json:"taskErrors,omitempty"`
+}
+
+// Save writes the state to w as a zip archive and returns its manifest
+func Save(w io.Writer, state State, serverVersion string, now time.Time) (Manifest, error) {
+	manifest := Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     now.UTC(),
+		ServerVersion: serverVersion,
+		Counts:        make(map[string]int),
+	}
+	parts := make(map[string][]byte)
+
+	if state.Prices != nil {
+		snapshots := state.Prices.All()
+		data, err := json.MarshalIndent(snapshots, "", "  ")
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to encode prices: %w", err)
+		}
+		parts[PartPrices] = data
+		manifest.Counts[PartPrices] = len(snapshots)
+	}
+	if state.Explorer != nil {
+		data, err := state.Explorer.Export()
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to encode exploration progress: %w", err)
+		}
+		parts[PartExploration] = data
+		summary := state.Explorer.Summary()
+		manifest.Counts[PartExploration] = summary.SystemsKnown + summary.WaypointsKnown
+	}
+	if state.ShipMeta != nil {
+		data, err := state.ShipMeta.Export()
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to encode ship metadata: %w", err)
+		}
+		parts[PartShipMeta] = data
+		manifest.Counts[PartShipMeta] = len(state.ShipMeta.All()) + len(state.ShipMeta.Squadrons())
+	}
+	if state.Tasks != nil {
+		specs := make([]TaskSpec, 0)
+		for _, task := range state.Tasks.List() {
+			if task.Active() {
+				specs = append(specs, TaskSpec{ShipSymbol: task.ShipSymbol, Behavior: task.Behavior, Params: task.Params})
+			}
+		}
+		data, err := json.MarshalIndent(specs, "", "  ")
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to encode tasks: %w", err)
+		}
+		parts[PartTasks] = data
+		manifest.Counts[PartTasks] = len(specs)
+	}
+
+	zw := zip.NewWriter(w)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := writePart(zw, manifestFile, manifestData, now); err != nil {
+		return Manifest{}, err
+	}
+	for _, part := range []string{PartPrices, PartExploration, PartShipMeta, PartTasks} {
+		if data, ok := parts[part]; ok {
+			if err := writePart(zw, part+".json", data, now); err != nil {
+				return Manifest{}, err
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// Restore replaces the state with what an archive holds. Every part is read before any is
+// applied, so an unreadable archive changes nothing. A part that the state can't take, such as
+// exploration progress for another agent, is skipped and the rest are still restored. Tasks are
+// only started again when resumeTasks is set, since they act on the ships, and each ship is
+// checked against the API first.
+func Restore(r io.ReaderAt, size int64, state State, resumeTasks bool) (Report, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Report{}, fmt.Errorf("not a snapshot archive: %w", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	report := Report{Restored: make(map[string]int), Skipped: make(map[string]string)}
+	manifestData, err := readPart(files, manifestFile)
+	if err != nil {
+		return Report{}, fmt.Errorf("not a snapshot archive: %w", err)
+	}
+	if err := json.Unmarshal(manifestData, &report.Manifest); err != nil {
+		return Report{}, fmt.Errorf("failed to parse snapshot manifest: %w", err)
+	}
+	if report.Manifest.FormatVersion > FormatVersion {
+		return Report{}, fmt.Errorf("snapshot format %d is newer than this server supports (%d); upgrade the server to restore it", report.Manifest.FormatVersion, FormatVersion)
+	}
+
+	// Decode every part first so a damaged archive is refused as a whole
+	var snapshots []prices.Snapshot
+	var specs []TaskSpec
+	raw := make(map[string][]byte)
+	for _, part := range []string{PartPrices, PartExploration, PartShipMeta, PartTasks} {
+		data, err := readPart(files, part+".json")
+		if errors.Is(err, errMissing) {
+			continue
+		}
+		if err != nil {
+			return Report{}, err
+		}
+		switch part {
+		case PartPrices:
+			err = json.Unmarshal(data, &snapshots)
+		case PartTasks:
+			err = json.Unmarshal(data, &specs)
+		default:
+			err = json.Unmarshal(data, new(json.RawMessage))
+		}
+		if err != nil {
+			return Report{}, fmt.Errorf("failed to parse %s in the snapshot: %w", part, err)
+		}
+		raw[part] = data
+	}
+
+	if _, ok := raw[PartPrices]; ok {
+		if state.Prices == nil {
+			report.Skipped[PartPrices] = "the price database is not available"
+		} else {
+			state.Prices.Replace(snapshots)
+			report.Restored[PartPrices] = len(snapshots)
+		}
+	}
+	if data, ok := raw[PartExploration]; ok {
+		if state.Explorer == nil {
+			report.Skipped[PartExploration] = "exploration tracking is not available"
+		} else if err := state.Explorer.Restore(data); err != nil {
+			report.Skipped[PartExploration] = err.Error()
+		} else {
+			summary := state.Explorer.Summary()
+			report.Restored[PartExploration] = summary.SystemsKnown + summary.WaypointsKnown
+		}
+	}
+	if data, ok := raw[PartShipMeta]; ok {
+		if state.ShipMeta == nil {
+			report.Skipped[PartShipMeta] = "ship labels are not available"
+		} else if err := state.ShipMeta.Restore(data); err != nil {
+			report.Skipped[PartShipMeta] = err.Error()
+		} else {
+			report.Restored[PartShipMeta] = len(state.ShipMeta.All()) + len(state.ShipMeta.Squadrons())
+		}
+	}
+	if _, ok := raw[PartTasks]; ok {
+		report.Tasks = specs
+		switch {
+		case len(specs) == 0:
+		case !resumeTasks:
+			report.Skipped[PartTasks] = "tasks are only started again when asked to resume them"
+		case state.Tasks == nil:
+			report.Skipped[PartTasks] = "background tasks are not available"
+		default:
+			for _, spec := range specs {
+				task, err := state.Tasks.Resume(spec.ShipSymbol, spec.Behavior, spec.Params)
+				if err != nil {
+					report.TaskErrors = append(report.TaskErrors, fmt.Sprintf("%s: %v", spec.ShipSymbol, err))
+					continue
+				}
+				report.Resumed = append(report.Resumed, task)
+			}
+			report.Restored[PartTasks] = len(report.Resumed)
+		}
+	}
+	return report, nil
+}
+
+// WriteFile saves the state to an archive at path, replacing it atomically
+func WriteFile(path string, state State, serverVersion string, now time.Time) (Manifest, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Manifest{}, err
+	}
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return Manifest{}, err
+	}
+	manifest, err := Save(file, state, serverVersion, now)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return Manifest{}, err
+	}
+	return manifest, os.Rename(tmp, path)
+}
+
+// RestoreFile restores the state from the archive at path; see Restore
+func RestoreFile(path string, state State, resumeTasks bool) (Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Report{}, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return Report{}, err
+	}
+	return Restore(file, info.Size(), state, resumeTasks)
+}
+
+// errMissing marks an archive part that isn't in the archive
+var errMissing = errors.New("missing from the archive")
+
+// readPart reads one file of the archive
+func readPart(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, errMissing)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s in the snapshot: %w", name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxPartSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s in the snapshot: %w", name, err)
+	}
+	if len(data) > maxPartSize {
+		return nil, fmt.Errorf("%s in the snapshot is larger than %d MB", name, maxPartSize>>20)
+	}
+	return data, nil
+}
+
+// writePart adds one file to the archive
+func writePart(zw *zip.Writer, name string, data []byte, now time.Time) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to the snapshot: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to the snapshot: %w", name, err)
+	}
+	return nil
+}
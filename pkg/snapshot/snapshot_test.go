@@ -0,0 +1,196 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+)
+
+func newState(t *testing.T) State {
+	t.Helper()
+	tracker, err := explorer.Open("")
+	if err != nil {
+		t.Fatalf("Failed to open tracker: %v", err)
+	}
+	store, err := shipmeta.Open("")
+	if err != nil {
+		t.Fatalf("Failed to open ship metadata: %v", err)
+	}
+	return State{Prices: prices.New(), Explorer: tracker, ShipMeta: store}
+}
+
+func TestSaveAndRestore(t *testing.T) {
+	now := time.Now()
+	source := newState(t)
+	source.Prices.Record(prices.Snapshot{WaypointSymbol: "X1-A1", ObservedAt: now.Add(-time.Hour), Prices: []prices.Price{{TradeSymbol: "IRON_ORE", SellPrice: 40}}})
+	source.Prices.Record(prices.Snapshot{WaypointSymbol: "X1-A1", ObservedAt: now, Prices: []prices.Price{{TradeSymbol: "IRON_ORE", SellPrice: 45}}})
+	if err := source.Explorer.Restore([]byte(`{"agentSymbol": "AGENT", "systems": {"X1": {"symbol": "X1"}}, "waypoints": {"X1-A1": {"symbol": "X1-A1", "systemSymbol": "X1", "type": "PLANET", "charted": true}}}`)); err != nil {
+		t.Fatalf("Failed to seed exploration: %v", err)
+	}
+	if _, err := source.ShipMeta.SetLabel("SHIP-1", "Hauler", nil); err != nil {
+		t.Fatalf("Failed to label ship: %v", err)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := Save(&buf, source, "test", now)
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if manifest.Counts[PartPrices] != 2 || manifest.Counts[PartExploration] != 2 || manifest.Counts[PartShipMeta] != 1 {
+		t.Errorf("Unexpected manifest counts: %v", manifest.Counts)
+	}
+	if _, ok := manifest.Counts[PartTasks]; ok {
+		t.Error("Expected no tasks part without a task manager")
+	}
+
+	target := newState(t)
+	target.Prices.Record(prices.Snapshot{WaypointSymbol: "X1-Z9", ObservedAt: now})
+	report, err := Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()), target, false)
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(report.Skipped) != 0 {
+		t.Errorf("Expected every part to be restored, skipped %v", report.Skipped)
+	}
+	if markets := target.Prices.Markets(); len(markets) != 1 || markets[0] != "X1-A1" {
+		t.Errorf("Expected the price database to be replaced, got markets %v", markets)
+	}
+	if latest, ok := target.Prices.Latest("X1-A1"); !ok || latest.Prices[0].SellPrice != 45 {
+		t.Errorf("Expected the latest restored price to be 45, got %+v", latest)
+	}
+	if summary := target.Explorer.Summary(); summary.SystemsKnown != 1 || summary.WaypointsCharted != 1 {
+		t.Errorf("Expected the exploration progress to be restored, got %+v", summary)
+	}
+	if meta, ok := target.ShipMeta.Get("SHIP-1"); !ok || meta.Label != "Hauler" {
+		t.Errorf("Expected SHIP-1 to be labelled Hauler, got %+v", meta)
+	}
+}
+
+func TestWriteFileAndRestoreFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "checkpoint.zip")
+	source := State{Prices: prices.New()}
+	source.Prices.Record(prices.Snapshot{WaypointSymbol: "X1-A1", ObservedAt: time.Now()})
+
+	if _, err := WriteFile(path, source, "test", time.Now()); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected the temporary file to be gone")
+	}
+
+	target := State{Prices: prices.New()}
+	report, err := RestoreFile(path, target, false)
+	if err != nil {
+		t.Fatalf("RestoreFile returned error: %v", err)
+	}
+	if report.Restored[PartPrices] != 1 || len(target.Prices.Markets()) != 1 {
+		t.Errorf("Expected one restored price snapshot, got %v", report.Restored)
+	}
+	if _, err := RestoreFile(filepath.Join(t.TempDir(), "missing.zip"), target, false); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not-exist error for a missing file, got %v", err)
+	}
+}
+
+func TestRestore_SkipsExplorationOfAnotherAgent(t *testing.T) {
+	source := newState(t)
+	source.Explorer.Bind("AGENT-A", "")
+
+	var buf bytes.Buffer
+	if _, err := Save(&buf, source, "test", time.Now()); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	target := newState(t)
+	target.Explorer.Bind("AGENT-B", "")
+	report, err := Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()), target, false)
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if reason := report.Skipped[PartExploration]; !strings.Contains(reason, "AGENT-A") {
+		t.Errorf("Expected exploration to be skipped as another agent's, got %q", reason)
+	}
+	if _, ok := report.Restored[PartShipMeta]; !ok {
+		t.Error("Expected ship metadata to be restored anyway")
+	}
+}
+
+func TestRestore_ListsTasksWithoutResuming(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, map[string]string{
+		manifestFile:        `{"formatVersion": 1, "counts": {"tasks": 1}}`,
+		PartTasks + ".json": `[{"shipSymbol": "SHIP-1", "behavior": "mine_loop", "params": {"market": "X1-A1"}}]`,
+	}); err != nil {
+		t.Fatalf("Failed to build archive: %v", err)
+	}
+
+	manager := tasks.NewManager(context.Background(), nil, logging.NewLogger(nil))
+	defer manager.Stop()
+	report, err := Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()), State{Tasks: manager}, false)
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(report.Tasks) != 1 || report.Tasks[0].ShipSymbol != "SHIP-1" || report.Skipped[PartTasks] == "" {
+		t.Errorf("Expected the saved task to be listed but not started, got %+v", report)
+	}
+	if len(manager.List()) != 0 {
+		t.Error("Expected no task to be started")
+	}
+}
+
+func TestRestore_RefusesBadArchives(t *testing.T) {
+	target := newState(t)
+	target.Prices.Record(prices.Snapshot{WaypointSymbol: "X1-A1", ObservedAt: time.Now()})
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"no manifest", map[string]string{PartPrices + ".json": `[]`}, "not a snapshot archive"},
+		{"newer format", map[string]string{manifestFile: `{"formatVersion": 99}`}, "newer than this server supports"},
+		{"damaged part", map[string]string{manifestFile: `{"formatVersion": 1}`, PartPrices + ".json": `[]`, PartShipMeta + ".json": `{`}, "failed to parse ships"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeArchive(&buf, tt.files); err != nil {
+				t.Fatalf("Failed to build archive: %v", err)
+			}
+			_, err := Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()), target, false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error mentioning %q, got %v", tt.want, err)
+			}
+			// Nothing is applied from an archive that is refused
+			if len(target.Prices.Markets()) != 1 {
+				t.Error("Expected the price database to be left alone")
+			}
+		})
+	}
+
+	if _, err := Restore(bytes.NewReader([]byte("not a zip")), 9, target, false); err == nil {
+		t.Error("Expected an error for a file that isn't a zip")
+	}
+}
+
+// writeArchive builds a zip archive from file names and contents
+func writeArchive(buf *bytes.Buffer, files map[string]string) error {
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		if err := writePart(zw, name, []byte(content), time.Now()); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,365 @@
+package stations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/polling"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/travel"
+)
+
+// DefaultInterval is how often markets and shipyards at stations are refreshed
+const DefaultInterval = 5 * time.Minute
+
+// sweepKey is the scheduler key of the poller's single background job
+const sweepKey = "stations"
+
+// Station is a ship parked at a waypoint to keep its market or shipyard data fresh
+type Station struct {
+	ShipSymbol     string    `json:"shipSymbol"`
+	WaypointSymbol string    `json:"waypointSymbol"`
+	Market         bool      `json:"market"`
+	Shipyard       bool      `json:"shipyard"`
+	StationedAt    time.Time `json:"stationedAt"`
+	LastPolledAt   time.Time `json:"lastPolledAt,omitzero"`
+	Polls          int       `json:"polls"`
+	LastError      string    `json:"lastError,omitempty"`
+}
+
+// target is a waypoint refreshed during a sweep
+type target struct {
+	waypoint string
+	market   bool
+	shipyard bool
+	// known is set when a station at the waypoint already says what it has
+	known bool
+}
+
+// Poller refreshes market and shipyard data at waypoints where ships are stationed. Ships are
+// stationed explicitly with Station, or, with WatchFleet, by staying at a waypoint for a whole
+// interval. Fetching a market with a ship present records its prices through the client's observers.
+// Sweeps come sooner while a watched ship is about to arrive, but each waypoint is refreshed at
+// most once an interval.
+type Poller struct {
+	client    *client.Client
+	logger    *logging.Logger
+	scheduler *polling.Scheduler
+	limiter   *tasks.RateLimiter
+	idle      *polling.IdleTracker
+	interval  time.Duration
+
+	mu       sync.RWMutex
+	stations map[string]*Station
+	// refreshed is when each waypoint was last refreshed
+	refreshed map[string]time.Time
+	fleet     bool
+	notify    func(uri string)
+}
+
+// NewPoller creates a station poller whose background work stops when ctx is cancelled.
+// Nothing is polled until Start is called.
+func NewPoller(ctx context.Context, client *client.Client, logger *logging.Logger) *Poller {
+	return &Poller{
+		client:    client,
+		logger:    logger,
+		scheduler: polling.NewScheduler(ctx),
+		limiter:   tasks.NewRateLimiter(tasks.DefaultRequestInterval),
+		idle:      polling.NewIdleTracker(),
+		interval:  DefaultInterval,
+		stations:  make(map[string]*Station),
+		refreshed: make(map[string]time.Time),
+	}
+}
+
+// WatchFleet also polls every market and shipyard where any ship has stayed for a whole interval
+func (p *Poller) WatchFleet(enabled bool) *Poller {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fleet = enabled
+	return p
+}
+
+// OnUpdate sets a function called with the URI of every market and shipyard resource a sweep refreshes
+func (p *Poller) OnUpdate(notify func(uri string)) *Poller {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.notify = notify
+	return p
+}
+
+// Start begins sweeping stations in the background; the first sweep runs immediately
+func (p *Poller) Start() {
+	p.scheduler.Schedule(sweepKey, p.sweep)
+}
+
+// Station registers a ship at a waypoint, replacing any earlier station for the ship.
+// A ship still travelling to the waypoint is polled once it arrives.
+func (p *Poller) Station(station Station) Station {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	station.StationedAt = time.Now()
+	station.LastPolledAt = time.Time{}
+	station.Polls = 0
+	station.LastError = ""
+	p.stations[station.ShipSymbol] = &station
+	p.logger.Info("Stationed %s at %s", station.ShipSymbol, station.WaypointSymbol)
+
+	return station
+}
+
+// Remove forgets a ship's station, reporting whether it had one
+func (p *Poller) Remove(shipSymbol string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.stations[shipSymbol]; !exists {
+		return false
+	}
+	delete(p.stations, shipSymbol)
+	return true
+}
+
+// List returns a snapshot of every station, sorted by ship symbol
+func (p *Poller) List() []Station {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result := make([]Station, 0, len(p.stations))
+	for _, station := range p.stations {
+		result = append(result, *station)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ShipSymbol < result[j].ShipSymbol
+	})
+	return result
+}
+
+// Stop cancels all polling and waits for a running sweep to finish
+func (p *Poller) Stop() {
+	p.scheduler.Stop()
+}
+
+// sweep refreshes every waypoint with a stationed ship and returns how long to wait before the next sweep
+func (p *Poller) sweep(ctx context.Context) time.Duration {
+	c := p.client.WithContext(ctx)
+
+	var ships []client.Ship
+	err := p.call(ctx, func() (err error) {
+		ships, err = c.GetAllShips()
+		return err
+	})
+	if err != nil {
+		p.logger.Error("Station sweep failed to list ships: %v", err)
+		return p.interval
+	}
+
+	now := time.Now()
+	p.mu.RLock()
+	stationed := make(map[string]Station, len(p.stations))
+	for symbol, station := range p.stations {
+		stationed[symbol] = *station
+	}
+	fleet, notify := p.fleet, p.notify
+	p.mu.RUnlock()
+
+	// Explicit stations are polled as soon as their ship arrives; other ships must first
+	// stay put for a whole interval, so ships passing through on tasks are left alone
+	targets := make(map[string]*target)
+	outcomes := make(map[string]error)
+	idle := make(map[string]time.Duration, len(ships))
+	var watched []client.Ship
+	for _, ship := range ships {
+		location := ship.Nav.WaypointSymbol
+		if ship.Nav.Status == "IN_TRANSIT" {
+			location = ""
+		}
+		idleFor := p.idle.Observe(ship.Symbol, location, now)
+		idle[ship.Symbol] = idleFor
+		station, isStationed := stationed[ship.Symbol]
+		if isStationed || fleet {
+			// Only where ships are matters here, so cooldowns don't bring sweeps forward
+			moving := ship
+			moving.Cooldown = client.Cooldown{}
+			watched = append(watched, moving)
+		}
+		if location == "" {
+			continue
+		}
+
+		if isStationed {
+			if location != station.WaypointSymbol {
+				outcomes[ship.Symbol] = fmt.Errorf("ship is at %s, away from its station", location)
+				continue
+			}
+			// A ship that has just reached its station is polled straight away
+			if !station.LastPolledAt.IsZero() && p.refreshedWithin(location, now) {
+				continue
+			}
+			t := targetFor(targets, location)
+			t.market = t.market || station.Market
+			t.shipyard = t.shipyard || station.Shipyard
+			t.known = true
+			continue
+		}
+		if fleet && idleFor >= p.interval && !p.refreshedWithin(location, now) {
+			targetFor(targets, location)
+		}
+	}
+
+	p.fillFacilities(ctx, c, targets)
+
+	waypoints := make([]string, 0, len(targets))
+	for waypoint := range targets {
+		waypoints = append(waypoints, waypoint)
+	}
+	sort.Strings(waypoints)
+
+	results := make(map[string]error, len(targets))
+	for _, waypoint := range waypoints {
+		results[waypoint] = p.refresh(ctx, c, targets[waypoint], notify)
+		p.mu.Lock()
+		p.refreshed[waypoint] = now
+		p.mu.Unlock()
+	}
+
+	for symbol, station := range stationed {
+		if err, away := outcomes[symbol]; away {
+			p.record(symbol, now, err)
+		} else if err, polled := results[station.WaypointSymbol]; polled {
+			p.record(symbol, now, err)
+		}
+	}
+	return p.policy().FleetInterval(watched, func(ship client.Ship) time.Duration { return idle[ship.Symbol] }, now)
+}
+
+// policy paces sweeps around the interval. Markets change whether or not the ships watching them
+// move, so ships parked for a long time don't slow sweeps down.
+func (p *Poller) policy() polling.Policy {
+	policy := polling.ForInterval(p.interval)
+	policy.Mothballed = p.interval
+	return policy
+}
+
+// refreshedWithin reports whether a waypoint was refreshed less than an interval before now
+func (p *Poller) refreshedWithin(waypoint string, now time.Time) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	at, ok := p.refreshed[waypoint]
+	return ok && now.Sub(at) < p.interval
+}
+
+// targetFor returns the sweep target for a waypoint, adding it if needed
+func targetFor(targets map[string]*target, waypoint string) *target {
+	if t, exists := targets[waypoint]; exists {
+		return t
+	}
+	t := &target{waypoint: waypoint}
+	targets[waypoint] = t
+	return t
+}
+
+// fillFacilities looks up whether fleet waypoints have a market or shipyard; explicit stations already know
+func (p *Poller) fillFacilities(ctx context.Context, c *client.Client, targets map[string]*target) {
+	systems := make(map[string][]client.SystemWaypoint)
+	for waypoint, t := range targets {
+		if t.known {
+			continue
+		}
+		systemSymbol := travel.SystemSymbol(waypoint)
+		waypoints, listed := systems[systemSymbol]
+		if !listed {
+			err := p.call(ctx, func() (err error) {
+				waypoints, _, err = c.GetCachedSystemWaypoints(systemSymbol)
+				return err
+			})
+			if err != nil {
+				p.logger.Error("Station sweep failed to list waypoints in %s: %v", systemSymbol, err)
+			}
+			systems[systemSymbol] = waypoints
+		}
+		for _, w := range waypoints {
+			if w.Symbol != waypoint {
+				continue
+			}
+			for _, trait := range w.Traits {
+				switch trait.Symbol {
+				case "MARKETPLACE":
+					t.market = true
+				case "SHIPYARD":
+					t.shipyard = true
+				}
+			}
+		}
+	}
+}
+
+// refresh fetches the market and shipyard at a waypoint and reports their resources as updated
+func (p *Poller) refresh(ctx context.Context, c *client.Client, t *target, notify func(uri string)) error {
+	systemSymbol := travel.SystemSymbol(t.waypoint)
+	base := fmt.Sprintf("spacetraders://systems/%s/waypoints/%s", systemSymbol, t.waypoint)
+
+	var failures []string
+	if t.market {
+		err := p.call(ctx, func() error {
+			_, err := c.GetMarket(systemSymbol, t.waypoint)
+			return err
+		})
+		if err != nil {
+			failures = append(failures, err.Error())
+		} else if notify != nil {
+			notify(base + "/market")
+		}
+	}
+	if t.shipyard {
+		err := p.call(ctx, func() error {
+			_, err := c.GetShipyard(systemSymbol, t.waypoint)
+			return err
+		})
+		if err != nil {
+			failures = append(failures, err.Error())
+		} else if notify != nil {
+			notify(base + "/shipyard")
+		}
+	}
+
+	if len(failures) > 0 {
+		err := fmt.Errorf("%s", strings.Join(failures, "; "))
+		p.logger.Error("Station sweep failed to refresh %s: %v", t.waypoint, err)
+		return err
+	}
+	return nil
+}
+
+// call waits for a rate limit slot and then runs fn
+func (p *Poller) call(ctx context.Context, fn func() error) error {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// record notes the outcome of a poll on the station, if the ship is still stationed
+func (p *Poller) record(shipSymbol string, at time.Time, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	station, exists := p.stations[shipSymbol]
+	if !exists {
+		return
+	}
+	station.LastPolledAt = at
+	if err != nil {
+		station.LastError = err.Error()
+		return
+	}
+	station.Polls++
+	station.LastError = ""
+}
@@ -0,0 +1,159 @@
+package stations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+)
+
+// fleetServer serves a probe and a hauler; probeAt is where the probe currently is, or is
+// travelling to when probeArrival is set
+type fleetServer struct {
+	mu           sync.Mutex
+	probeAt      string
+	probeArrival time.Time
+	requests     []string
+}
+
+func (f *fleetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.URL.Path)
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/my/ships":
+		probeNav := `"status": "IN_ORBIT"`
+		if !f.probeArrival.IsZero() {
+			probeNav = `"status": "IN_TRANSIT", "route": {"arrival": "` + f.probeArrival.UTC().Format(time.RFC3339) + `"}`
+		}
+		_, _ = w.Write([]byte(`{"data": [
+			{"symbol": "PROBE-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "` + f.probeAt + `", ` + probeNav + `}},
+			{"symbol": "HAULER-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-B2", "status": "DOCKED"}}
+		], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+	case r.URL.Path == "/systems/X1-TEST/waypoints":
+		_, _ = w.Write([]byte(`{"data": [
+			{"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0, "traits": [{"symbol": "MARKETPLACE"}]},
+			{"symbol": "X1-TEST-B2", "type": "MOON", "systemSymbol": "X1-TEST", "x": 5, "y": 0, "traits": [{"symbol": "MARKETPLACE"}, {"symbol": "SHIPYARD"}]}
+		], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+	case strings.HasSuffix(r.URL.Path, "/market"):
+		_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST", "exports": [], "imports": [], "exchange": []}}`))
+	case strings.HasSuffix(r.URL.Path, "/shipyard"):
+		_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-B2", "shipTypes": [], "modificationsFee": 0}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// take returns the requests made since the last call
+func (f *fleetServer) take() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func newTestPoller(t *testing.T, fleet *fleetServer) (*Poller, *[]string) {
+	t.Helper()
+	server := httptest.NewServer(fleet)
+	t.Cleanup(server.Close)
+
+	p := NewPoller(context.Background(), client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	p.limiter = tasks.NewRateLimiter(0)
+	var updated []string
+	p.OnUpdate(func(uri string) {
+		updated = append(updated, uri)
+	})
+	return p, &updated
+}
+
+func TestPoller_SweepsExplicitStations(t *testing.T) {
+	fleet := &fleetServer{probeAt: "X1-TEST-A1"}
+	p, updated := newTestPoller(t, fleet)
+	p.Station(Station{ShipSymbol: "PROBE-1", WaypointSymbol: "X1-TEST-A1", Market: true})
+
+	if next := p.sweep(context.Background()); next != DefaultInterval {
+		t.Errorf("Expected next sweep in %v, got %v", DefaultInterval, next)
+	}
+	requests := fleet.take()
+	if strings.Join(requests, ",") != "/my/ships,/systems/X1-TEST/waypoints/X1-TEST-A1/market" {
+		t.Errorf("Expected only the stationed market to be fetched, got %v", requests)
+	}
+	if len(*updated) != 1 || (*updated)[0] != "spacetraders://systems/X1-TEST/waypoints/X1-TEST-A1/market" {
+		t.Errorf("Expected an update for the market resource, got %v", *updated)
+	}
+	if stations := p.List(); stations[0].Polls != 1 || stations[0].LastError != "" {
+		t.Errorf("Expected a successful poll to be recorded, got %+v", stations[0])
+	}
+
+	// A ship that has wandered off is reported instead of polled
+	fleet.probeAt = "X1-TEST-B2"
+	p.sweep(context.Background())
+	if requests := fleet.take(); len(requests) != 1 {
+		t.Errorf("Expected no fetches while the probe is away, got %v", requests)
+	}
+	if stations := p.List(); stations[0].LastError == "" {
+		t.Errorf("Expected the probe being away to be recorded, got %+v", stations[0])
+	}
+
+	if !p.Remove("PROBE-1") || p.Remove("PROBE-1") || len(p.List()) != 0 {
+		t.Errorf("Expected the station to be removed exactly once")
+	}
+}
+
+func TestPoller_SweepsSoonerBeforeArrival(t *testing.T) {
+	fleet := &fleetServer{probeAt: "X1-TEST-A1", probeArrival: time.Now().Add(time.Minute)}
+	p, _ := newTestPoller(t, fleet)
+	p.Station(Station{ShipSymbol: "PROBE-1", WaypointSymbol: "X1-TEST-A1", Market: true})
+
+	// The next sweep comes just before the probe reaches its station, not a whole interval later
+	next := p.sweep(context.Background())
+	if next >= time.Minute || next < 50*time.Second {
+		t.Errorf("Expected the next sweep just under a minute away, got %v", next)
+	}
+	if requests := fleet.take(); len(requests) != 1 {
+		t.Errorf("Expected no fetches while the probe is travelling, got %v", requests)
+	}
+
+	// Once it is there the market is polled and sweeps go back to the interval
+	fleet.probeArrival = time.Time{}
+	if next := p.sweep(context.Background()); next != DefaultInterval {
+		t.Errorf("Expected the next sweep in %v once the probe arrived, got %v", DefaultInterval, next)
+	}
+	if requests := fleet.take(); len(requests) != 2 {
+		t.Errorf("Expected the market to be fetched on arrival, got %v", requests)
+	}
+
+	// An early sweep doesn't refresh a market polled within the interval
+	p.sweep(context.Background())
+	if requests := fleet.take(); len(requests) != 1 {
+		t.Errorf("Expected the fresh market to be skipped, got %v", requests)
+	}
+}
+
+func TestPoller_WatchFleet(t *testing.T) {
+	fleet := &fleetServer{probeAt: "X1-TEST-A1"}
+	p, updated := newTestPoller(t, fleet)
+	p.WatchFleet(true)
+	p.interval = 0
+
+	p.sweep(context.Background())
+	requests := strings.Join(fleet.take(), ",")
+	for _, want := range []string{"/systems/X1-TEST/waypoints/X1-TEST-A1/market", "/systems/X1-TEST/waypoints/X1-TEST-B2/market", "/systems/X1-TEST/waypoints/X1-TEST-B2/shipyard"} {
+		if !strings.Contains(requests, want) {
+			t.Errorf("Expected %s to be fetched, got %s", want, requests)
+		}
+	}
+	if len(*updated) != 3 {
+		t.Errorf("Expected 3 resource updates, got %v", *updated)
+	}
+}
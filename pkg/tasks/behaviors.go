@@ -0,0 +1,451 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// stepFunc performs one step of a behavior for a ship that is not in transit
+type stepFunc func(r *runner, params map[string]string, ship *client.Ship) (stepResult, error)
+
+// behavior is a named long-running loop that can be assigned to a ship
+type behavior struct {
+	Description string
+	Required    []string
+	Optional    []string
+	Step        stepFunc
+}
+
+var behaviors = map[string]behavior{
+	"mine_loop": {
+		Description: "Extract at the asteroid waypoint until cargo is full, sell everything at the market waypoint, and repeat",
+		Required:    []string{"asteroid", "market"},
+		Step:        mineLoopStep,
+	},
+	"trade_loop": {
+		Description: "Buy a good at one waypoint, sell it at another, and repeat; stops when the margin per unit drops below min_margin",
+		Required:    []string{"good", "buy_at", "sell_at"},
+		Optional:    []string{"units", "min_margin"},
+		Step:        tradeLoopStep,
+	},
+	"contract_haul": {
+		Description: "Buy a contract's required good, deliver it to the contract destination until the contract is complete, then fulfill it",
+		Required:    []string{"contract_id", "buy_at"},
+		Optional:    []string{"good"},
+		Step:        contractHaulStep,
+	},
+	"market_scan": {
+		Description: "Visit each marketplace in waypoints (comma-separated) in order, docking and recording the market's prices, then stop",
+		Required:    []string{"waypoints"},
+		Step:        marketScanStep,
+	},
+	"rescue": {
+		Description: "Switch to DRIFT, which needs almost no fuel, fly to the market waypoint, refuel there and switch back to flight_mode, then stop",
+		Required:    []string{"market"},
+		Optional:    []string{"flight_mode"},
+		Step:        rescueStep,
+	},
+}
+
+// BehaviorInfo describes a behavior for tool documentation
+type BehaviorInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Required    []string `json:"required"`
+	Optional    []string `json:"optional,omitempty"`
+}
+
+// BehaviorNames returns the names of all behaviors, sorted
+func BehaviorNames() []string {
+	names := make([]string, 0, len(behaviors))
+	for name := range behaviors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Behaviors describes every behavior that can be assigned, sorted by name
+func Behaviors() []BehaviorInfo {
+	result := make([]BehaviorInfo, 0, len(behaviors))
+	for _, name := range BehaviorNames() {
+		b := behaviors[name]
+		result = append(result, BehaviorInfo{
+			Name:        name,
+			Description: b.Description,
+			Required:    b.Required,
+			Optional:    b.Optional,
+		})
+	}
+	return result
+}
+
+// mineLoopStep extracts until the hold is full, then sells everything at the market
+func mineLoopStep(r *runner, params map[string]string, ship *client.Ship) (stepResult, error) {
+	if ship.Cargo.Capacity > 0 && ship.Cargo.Units >= ship.Cargo.Capacity {
+		arrived, result, err := r.moveTo(ship, params["market"])
+		if err != nil || !arrived {
+			return result, err
+		}
+		if err := r.dock(ship); err != nil {
+			return stepResult{}, err
+		}
+
+		market, err := r.market(ship)
+		if err != nil {
+			return stepResult{}, err
+		}
+
+		// Goods are sold in chunks no larger than their trade volume, which the API requires
+		sold, credits := 0, 0
+		var lastErr error
+		for _, item := range ship.Cargo.Inventory {
+			tradeVolume := 0
+			if entry := tradeGood(market, item.Symbol); entry != nil {
+				tradeVolume = entry.TradeVolume
+			}
+			order, err := r.sell(ship, item.Symbol, item.Units, tradeVolume)
+			sold += order.Units
+			credits += order.TotalPrice
+			if err != nil {
+				lastErr = err
+			}
+		}
+		if sold == 0 && lastErr != nil {
+			return stepResult{}, lastErr
+		}
+		return waitFor(0, "sold %d units for %d credits at %s", sold, credits, params["market"]), nil
+	}
+
+	arrived, result, err := r.moveTo(ship, params["asteroid"])
+	if err != nil || !arrived {
+		return result, err
+	}
+	if err := r.orbit(ship); err != nil {
+		return stepResult{}, err
+	}
+	if remaining := ship.CooldownRemaining(time.Now()); remaining > 0 {
+		return waitFor(remaining, "waiting for cooldown"), nil
+	}
+
+	var resp *client.ExtractResponse
+	err = r.call(func() (err error) {
+		resp, err = r.client.ExtractResources(ship.Symbol, nil)
+		return err
+	})
+	if err != nil {
+		return stepResult{}, fmt.Errorf("failed to extract: %w", err)
+	}
+
+	yield := resp.Data.Extraction.Yield
+	cooldown := time.Duration(resp.Data.Cooldown.RemainingSeconds) * time.Second
+	return waitFor(cooldown, "extracted %d %s (cargo %d/%d)", yield.Units, yield.Symbol, resp.Data.Cargo.Units, resp.Data.Cargo.Capacity), nil
+}
+
+// tradeLoopStep buys a good at one market and sells it at another. When min_margin is set,
+// the loop stops once the spread between the buy and sell price falls below it.
+func tradeLoopStep(r *runner, params map[string]string, ship *client.Ship) (stepResult, error) {
+	good := params["good"]
+	minMargin, checkMargin := 0, false
+	if value, err := strconv.Atoi(params["min_margin"]); err == nil {
+		minMargin, checkMargin = value, true
+	}
+
+	held := cargoUnits(ship, good)
+	free := ship.Cargo.Capacity - ship.Cargo.Units
+	requested, _ := strconv.Atoi(params["units"])
+	loaded := free <= 0 || (requested > 0 && held >= requested)
+
+	// Keep buying while at the source market (trade volume can limit a single purchase),
+	// then haul whatever is on board to the destination
+	if held > 0 && (loaded || ship.Nav.WaypointSymbol != params["buy_at"] || r.memory["stopping"] == 1) {
+		arrived, result, err := r.moveTo(ship, params["sell_at"])
+		if err != nil || !arrived {
+			return result, err
+		}
+		if err := r.dock(ship); err != nil {
+			return stepResult{}, err
+		}
+
+		// The API rejects a sale larger than the good's trade volume, so the rest is sold in later steps
+		entry, err := r.marketGood(ship, good)
+		if err != nil {
+			return stepResult{}, err
+		}
+		units := held
+		if entry != nil && entry.TradeVolume > 0 && entry.TradeVolume < units {
+			units = entry.TradeVolume
+		}
+
+		var resp *client.SellCargoResponse
+		err = r.call(func() (err error) {
+			resp, err = r.client.SellCargo(ship.Symbol, good, units)
+			return err
+		})
+		if err != nil {
+			return stepResult{}, fmt.Errorf("failed to sell %s: %w", good, err)
+		}
+
+		sellPrice := resp.Data.Transaction.PricePerUnit
+		r.memory["sell_price"] = sellPrice
+		if units == held && r.memory["stopping"] == 1 {
+			return done("stopped after selling the remaining cargo: margin fell below minimum %d", minMargin), nil
+		}
+		if checkMargin && units == held {
+			if buyPrice, known := r.memory["buy_price"]; known && sellPrice-buyPrice < minMargin {
+				return done("stopped: margin fell to %d credits/unit (bought at %d, sold at %d), below minimum %d", sellPrice-buyPrice, buyPrice, sellPrice, minMargin), nil
+			}
+		}
+		return waitFor(0, "sold %d %s for %d credits", units, good, resp.Data.Transaction.TotalPrice), nil
+	}
+
+	arrived, result, err := r.moveTo(ship, params["buy_at"])
+	if err != nil || !arrived {
+		return result, err
+	}
+	if err := r.dock(ship); err != nil {
+		return stepResult{}, err
+	}
+
+	units := free
+	if requested > 0 && requested-held < units {
+		units = requested - held
+	}
+	if units <= 0 {
+		return stepResult{}, fmt.Errorf("no free cargo space to buy %s", good)
+	}
+
+	entry, err := r.marketGood(ship, good)
+	if err != nil {
+		return stepResult{}, err
+	}
+	if entry == nil {
+		return stepResult{}, fmt.Errorf("%s is not traded at %s", good, params["buy_at"])
+	}
+	if sellPrice, known := r.memory["sell_price"]; checkMargin && known && sellPrice-entry.PurchasePrice < minMargin {
+		if held > 0 {
+			r.memory["stopping"] = 1
+			return waitFor(0, "margin fell to %d credits/unit, selling remaining cargo before stopping", sellPrice-entry.PurchasePrice), nil
+		}
+		return done("stopped: margin fell to %d credits/unit (buy %d, last sell %d), below minimum %d", sellPrice-entry.PurchasePrice, entry.PurchasePrice, sellPrice, minMargin), nil
+	}
+	// As with sales, larger purchases are made over several steps
+	if entry.TradeVolume > 0 && entry.TradeVolume < units {
+		units = entry.TradeVolume
+	}
+
+	if err := r.checkSpend(units*entry.PurchasePrice, fmt.Sprintf("%d %s", units, good)); err != nil {
+		return stepResult{}, err
+	}
+
+	var resp *client.BuyCargoResponse
+	err = r.call(func() (err error) {
+		resp, err = r.client.BuyCargo(ship.Symbol, good, units)
+		return err
+	})
+	if err != nil {
+		return stepResult{}, fmt.Errorf("failed to buy %s: %w", good, err)
+	}
+	r.memory["buy_price"] = resp.Data.Transaction.PricePerUnit
+	return waitFor(0, "bought %d %s for %d credits", units, good, resp.Data.Transaction.TotalPrice), nil
+}
+
+// contractHaulStep sources a contract's good and delivers it until the contract can be fulfilled
+func contractHaulStep(r *runner, params map[string]string, ship *client.Ship) (stepResult, error) {
+	contractID := params["contract_id"]
+
+	var contracts []client.Contract
+	err := r.call(func() (err error) {
+		contracts, err = r.client.GetAllContracts()
+		return err
+	})
+	if err != nil {
+		return stepResult{}, fmt.Errorf("failed to fetch contracts: %w", err)
+	}
+
+	var contract *client.Contract
+	for i := range contracts {
+		if contracts[i].ID == contractID {
+			contract = &contracts[i]
+			break
+		}
+	}
+	if contract == nil {
+		return stepResult{}, fmt.Errorf("contract %s not found", contractID)
+	}
+	if contract.Fulfilled {
+		return done("contract %s is fulfilled", contractID), nil
+	}
+	if !contract.Accepted {
+		return stepResult{}, fmt.Errorf("contract %s has not been accepted", contractID)
+	}
+
+	var delivery *client.ContractDeliverGood
+	for i := range contract.Terms.Deliver {
+		d := &contract.Terms.Deliver[i]
+		if params["good"] != "" && d.TradeSymbol != params["good"] {
+			continue
+		}
+		if d.UnitsFulfilled < d.UnitsRequired {
+			delivery = d
+			break
+		}
+	}
+
+	if delivery == nil {
+		err := r.call(func() error {
+			_, err := r.client.FulfillContract(contractID)
+			return err
+		})
+		if err != nil {
+			return stepResult{}, fmt.Errorf("failed to fulfill contract: %w", err)
+		}
+		return done("fulfilled contract %s", contractID), nil
+	}
+
+	remaining := delivery.UnitsRequired - delivery.UnitsFulfilled
+	held := cargoUnits(ship, delivery.TradeSymbol)
+	free := ship.Cargo.Capacity - ship.Cargo.Units
+
+	if held > 0 && (held >= remaining || free <= 0) {
+		arrived, result, err := r.moveTo(ship, delivery.DestinationSymbol)
+		if err != nil || !arrived {
+			return result, err
+		}
+		if err := r.dock(ship); err != nil {
+			return stepResult{}, err
+		}
+
+		units := held
+		if units > remaining {
+			units = remaining
+		}
+		err = r.call(func() error {
+			_, err := r.client.DeliverContract(contractID, ship.Symbol, delivery.TradeSymbol, units)
+			return err
+		})
+		if err != nil {
+			return stepResult{}, fmt.Errorf("failed to deliver %s: %w", delivery.TradeSymbol, err)
+		}
+		return waitFor(0, "delivered %d %s (%d remaining)", units, delivery.TradeSymbol, remaining-units), nil
+	}
+
+	arrived, result, err := r.moveTo(ship, params["buy_at"])
+	if err != nil || !arrived {
+		return result, err
+	}
+	if err := r.dock(ship); err != nil {
+		return stepResult{}, err
+	}
+
+	units := remaining - held
+	if units > free {
+		units = free
+	}
+	if units <= 0 {
+		return stepResult{}, fmt.Errorf("no free cargo space to load %s", delivery.TradeSymbol)
+	}
+
+	entry, err := r.marketGood(ship, delivery.TradeSymbol)
+	if err != nil {
+		return stepResult{}, err
+	}
+	if entry == nil {
+		return stepResult{}, fmt.Errorf("%s is not traded at %s", delivery.TradeSymbol, params["buy_at"])
+	}
+
+	if err := r.checkSpend(units*entry.PurchasePrice, fmt.Sprintf("%d %s", units, delivery.TradeSymbol)); err != nil {
+		return stepResult{}, err
+	}
+
+	order, err := r.buy(ship, delivery.TradeSymbol, units, entry.TradeVolume)
+	if err != nil {
+		if order.Units == 0 {
+			return stepResult{}, err
+		}
+		// Whatever was bought is delivered; the rest is bought on a later trip
+		return waitFor(0, "bought %d of %d %s for contract %s: %v", order.Units, units, delivery.TradeSymbol, contractID, err), nil
+	}
+	return waitFor(0, "bought %d %s for %d credits for contract %s", units, delivery.TradeSymbol, order.TotalPrice, contractID), nil
+}
+
+// marketScanStep travels to the next marketplace on the list, docks, and fetches its market so the
+// prices are recorded in the price database
+func marketScanStep(r *runner, params map[string]string, ship *client.Ship) (stepResult, error) {
+	waypoints := strings.Split(params["waypoints"], ",")
+	next := r.memory["next"]
+	if next >= len(waypoints) {
+		return done("scanned %d markets", len(waypoints)), nil
+	}
+
+	waypoint := waypoints[next]
+	arrived, result, err := r.moveTo(ship, waypoint)
+	if err != nil || !arrived {
+		return result, err
+	}
+	if err := r.dock(ship); err != nil {
+		return stepResult{}, err
+	}
+
+	var market *client.Market
+	err = r.call(func() (err error) {
+		market, err = r.client.GetMarket(ship.Nav.SystemSymbol, waypoint)
+		return err
+	})
+	if err != nil {
+		return stepResult{}, fmt.Errorf("failed to get market at %s: %w", waypoint, err)
+	}
+
+	r.memory["next"] = next + 1
+	if next+1 == len(waypoints) {
+		return done("recorded %d prices at %s; scanned all %d markets", len(market.TradeGoods), waypoint, len(waypoints)), nil
+	}
+	return waitFor(0, "recorded %d prices at %s (%d/%d markets)", len(market.TradeGoods), waypoint, next+1, len(waypoints)), nil
+}
+
+// rescueStep gets a ship that is out of fuel to a market selling it: it drifts there, refuels,
+// and switches back to the flight mode it had before
+func rescueStep(r *runner, params map[string]string, ship *client.Ship) (stepResult, error) {
+	market := params["market"]
+	if ship.Nav.WaypointSymbol != market {
+		if ship.Nav.FlightMode != "DRIFT" {
+			if err := r.setFlightMode(ship, "DRIFT"); err != nil {
+				return stepResult{}, err
+			}
+			return waitFor(0, "switched to DRIFT to reach %s", market), nil
+		}
+		_, result, err := r.moveTo(ship, market)
+		return result, err
+	}
+
+	if r.memory["refueled"] == 0 {
+		if err := r.dock(ship); err != nil {
+			return stepResult{}, err
+		}
+		r.memory["refueled"] = 1
+		if ship.Fuel.Current >= ship.Fuel.Capacity {
+			return waitFor(0, "fuel already full at %s", market), nil
+		}
+		resp, err := r.refuel(ship)
+		if err != nil {
+			r.memory["refueled"] = 0
+			return stepResult{}, fmt.Errorf("failed to refuel at %s: %w", market, err)
+		}
+		transaction := resp.Data.Transaction
+		return waitFor(0, "refueled %d units for %d credits at %s", transaction.Units, transaction.TotalPrice, market), nil
+	}
+
+	mode := params["flight_mode"]
+	if mode == "" || mode == ship.Nav.FlightMode {
+		return done("rescued at %s with %d/%d fuel", market, ship.Fuel.Current, ship.Fuel.Capacity), nil
+	}
+	if err := r.setFlightMode(ship, mode); err != nil {
+		return stepResult{}, err
+	}
+	return done("rescued at %s with %d/%d fuel; switched back to %s", market, ship.Fuel.Current, ship.Fuel.Capacity, mode), nil
+}
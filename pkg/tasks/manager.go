@@ -0,0 +1,330 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/polling"
+	"spacetraders-mcp/pkg/shiplock"
+)
+
+// Status is the lifecycle state of a task
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// maxConsecutiveErrors is how many failed steps in a row mark a task as failed
+const maxConsecutiveErrors = 3
+
+// Task is a long-running behavior attached to a ship
+type Task struct {
+	ID          string            `json:"id"`
+	ShipSymbol  string            `json:"shipSymbol"`
+	Behavior    string            `json:"behavior"`
+	Params      map[string]string `json:"params,omitempty"`
+	Status      Status            `json:"status"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	LastRunAt   time.Time         `json:"lastRunAt,omitempty"`
+	NextRunAt   time.Time         `json:"nextRunAt,omitempty"`
+	Steps       int               `json:"steps"`
+	LastMessage string            `json:"lastMessage,omitempty"`
+	LastError   string            `json:"lastError,omitempty"`
+
+	consecutiveErrors int
+}
+
+// Active reports whether the task is still running
+func (t Task) Active() bool {
+	return t.Status == StatusRunning
+}
+
+// Manager assigns behaviors to ships and runs them in the background
+type Manager struct {
+	client    *client.Client
+	logger    *logging.Logger
+	scheduler *polling.Scheduler
+	limiter   *RateLimiter
+
+	locks    *shiplock.Locks
+	policy   *policy.Policy
+	mu       sync.RWMutex
+	tasks    map[string]*Task
+	nextID   int
+	onFinish func(Task)
+}
+
+// NewManager creates a task manager whose background work stops when ctx is cancelled
+func NewManager(ctx context.Context, client *client.Client, logger *logging.Logger) *Manager {
+	return &Manager{
+		client:    client,
+		logger:    logger,
+		scheduler: polling.NewScheduler(ctx),
+		limiter:   NewRateLimiter(DefaultRequestInterval),
+		tasks:     make(map[string]*Task),
+	}
+}
+
+// OnFinish sets a function called with every task that completes or fails. Cancelled tasks
+// are not reported, since the caller cancelling them already knows.
+func (m *Manager) OnFinish(onFinish func(Task)) *Manager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onFinish = onFinish
+	return m
+}
+
+// WithLocks makes each step hold its ship's lock, so steps never run alongside a tool call
+// acting on the same ship
+func (m *Manager) WithLocks(locks *shiplock.Locks) *Manager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.locks = locks
+	return m
+}
+
+// WithPolicy makes steps that buy goods or fuel check the spending policy first, failing the
+// step when it refuses
+func (m *Manager) WithPolicy(p *policy.Policy) *Manager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.policy = p
+	return m
+}
+
+// Assign attaches a behavior to a ship and starts running it immediately.
+// A ship can only have one active task at a time.
+func (m *Manager) Assign(shipSymbol, behaviorName string, params map[string]string) (Task, error) {
+	b, exists := behaviors[behaviorName]
+	if !exists {
+		return Task{}, fmt.Errorf("unknown behavior '%s'. Available behaviors: %v", behaviorName, BehaviorNames())
+	}
+	for _, required := range b.Required {
+		if params[required] == "" {
+			return Task{}, fmt.Errorf("behavior %s requires parameter '%s'", behaviorName, required)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, exists := m.tasks[shipSymbol]; exists && existing.Active() {
+		return Task{}, fmt.Errorf("ship %s is already running task %s (%s); cancel it first", shipSymbol, existing.ID, existing.Behavior)
+	}
+
+	m.nextID++
+	task := &Task{
+		ID:         fmt.Sprintf("task-%d", m.nextID),
+		ShipSymbol: shipSymbol,
+		Behavior:   behaviorName,
+		Params:     params,
+		Status:     StatusRunning,
+		CreatedAt:  time.Now(),
+	}
+	m.tasks[shipSymbol] = task
+
+	m.scheduler.Schedule(shipSymbol, m.job(task, b))
+	m.logger.Info("Assigned %s to %s as %s", behaviorName, shipSymbol, task.ID)
+
+	return *task, nil
+}
+
+// Resume starts a task again that was running before the server stopped or a snapshot was
+// saved. The ship is checked against the API first, so a task isn't resumed on a ship that has
+// been sold or scrapped since, or that belongs to another agent. Every step reads the ship's
+// state afresh, so a resumed task carries on from wherever the ship is now.
+func (m *Manager) Resume(shipSymbol, behaviorName string, params map[string]string) (Task, error) {
+	if _, exists := behaviors[behaviorName]; !exists {
+		return Task{}, fmt.Errorf("unknown behavior '%s'. Available behaviors: %v", behaviorName, BehaviorNames())
+	}
+	ship, err := m.client.GetShip(shipSymbol)
+	if err != nil {
+		return Task{}, fmt.Errorf("could not check ship %s, so its %s task was not resumed: %w", shipSymbol, behaviorName, err)
+	}
+
+	task, err := m.Assign(ship.Symbol, behaviorName, params)
+	if err != nil {
+		return Task{}, err
+	}
+	m.logger.Info("Resumed %s on %s as %s", behaviorName, ship.Symbol, task.ID)
+	return task, nil
+}
+
+// Cancel stops the active task on a ship, identified by ship symbol or task ID
+func (m *Manager) Cancel(shipOrTaskID string) (Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task := m.findLocked(shipOrTaskID)
+	if task == nil {
+		return Task{}, fmt.Errorf("no task found for '%s'", shipOrTaskID)
+	}
+	if !task.Active() {
+		return *task, fmt.Errorf("task %s is already %s", task.ID, task.Status)
+	}
+
+	m.scheduler.Unschedule(task.ShipSymbol)
+	task.Status = StatusCancelled
+	task.NextRunAt = time.Time{}
+	m.logger.Info("Cancelled %s (%s) on %s", task.ID, task.Behavior, task.ShipSymbol)
+
+	return *task, nil
+}
+
+// List returns a snapshot of every task, most recently created first
+func (m *Manager) List() []Task {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]Task, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		result = append(result, *task)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result
+}
+
+// ActiveTask returns the running task for a ship, if any
+func (m *Manager) ActiveTask(shipSymbol string) (Task, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if task, exists := m.tasks[shipSymbol]; exists && task.Active() {
+		return *task, true
+	}
+	return Task{}, false
+}
+
+// Stop cancels all background work and waits for running steps to finish
+func (m *Manager) Stop() {
+	m.scheduler.Stop()
+}
+
+// findLocked looks up a task by ship symbol or ID; callers must hold m.mu
+func (m *Manager) findLocked(shipOrTaskID string) *Task {
+	if task, exists := m.tasks[shipOrTaskID]; exists {
+		return task
+	}
+	for _, task := range m.tasks {
+		if task.ID == shipOrTaskID {
+			return task
+		}
+	}
+	return nil
+}
+
+// job wraps a behavior in a polling job that records progress on the task
+func (m *Manager) job(task *Task, b behavior) polling.Job {
+	memory := make(map[string]int)
+	return func(ctx context.Context) time.Duration {
+		m.mu.RLock()
+		params, locks := task.Params, m.locks
+		r := &runner{ctx: ctx, client: m.client.WithContext(ctx), limiter: m.limiter, policy: m.policy, memory: memory}
+		m.mu.RUnlock()
+
+		unlock, err := locks.Lock(ctx, task.ShipSymbol, task.ID)
+		if err != nil {
+			// Only a stopping manager gives up waiting for the ship
+			return time.Second
+		}
+		result, err := m.step(r, task.ShipSymbol, params, b)
+		unlock()
+
+		wait, finished := m.record(ctx, task, result, err)
+		if finished {
+			m.mu.RLock()
+			snapshot, onFinish := *task, m.onFinish
+			m.mu.RUnlock()
+			if onFinish != nil {
+				onFinish(snapshot)
+			}
+		}
+		return wait
+	}
+}
+
+// record updates a task with the outcome of a step and returns how long to wait before the
+// next one, and whether the task just completed or failed
+func (m *Manager) record(ctx context.Context, task *Task, result stepResult, err error) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ctx.Err() != nil || !task.Active() {
+		return time.Second, false
+	}
+
+	now := time.Now()
+	task.LastRunAt = now
+	task.Steps++
+
+	var open *client.CircuitOpenError
+	if errors.As(err, &open) {
+		// The API is degraded rather than the step failing, so the task waits for the circuit to
+		// let a probe through without using up its error budget
+		task.LastError = err.Error()
+		m.logger.Warn("Task %s on %s is paused until %s: %v", task.ID, task.ShipSymbol, open.RetryAt.Format(time.RFC3339), err)
+		result.Wait = time.Until(open.RetryAt)
+	} else if err != nil {
+		task.consecutiveErrors++
+		task.LastError = err.Error()
+		m.logger.Error("Task %s on %s failed a step: %v", task.ID, task.ShipSymbol, err)
+
+		if task.consecutiveErrors >= maxConsecutiveErrors {
+			task.Status = StatusFailed
+			task.NextRunAt = time.Time{}
+			m.scheduler.Unschedule(task.ShipSymbol)
+			return time.Second, true
+		}
+		result.Wait = errorBackoff * time.Duration(task.consecutiveErrors)
+	} else {
+		task.consecutiveErrors = 0
+		task.LastError = ""
+		task.LastMessage = result.Message
+	}
+
+	if result.Done {
+		task.Status = StatusCompleted
+		task.NextRunAt = time.Time{}
+		m.logger.Info("Task %s on %s completed: %s", task.ID, task.ShipSymbol, result.Message)
+		m.scheduler.Unschedule(task.ShipSymbol)
+		return time.Second, true
+	}
+
+	if result.Wait < minStepInterval {
+		result.Wait = minStepInterval
+	}
+	task.NextRunAt = now.Add(result.Wait)
+	return result.Wait, false
+}
+
+// step refreshes the ship and runs one step of the behavior unless the ship is still travelling
+func (m *Manager) step(r *runner, shipSymbol string, params map[string]string, b behavior) (stepResult, error) {
+	var ship *client.Ship
+	err := r.call(func() (err error) {
+		ship, err = r.client.GetShip(shipSymbol)
+		return err
+	})
+	if err != nil {
+		return stepResult{}, fmt.Errorf("failed to refresh ship: %w", err)
+	}
+
+	if ship.Nav.Status == "IN_TRANSIT" {
+		arrivalIn, _ := ship.ArrivalIn(time.Now())
+		return waitFor(arrivalIn+time.Second, "in transit to %s", ship.Nav.Route.Destination.Symbol), nil
+	}
+
+	return b.Step(r, params, ship)
+}
@@ -0,0 +1,303 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/trade"
+	"spacetraders-mcp/pkg/travel"
+)
+
+const (
+	// DefaultRequestInterval spaces out API calls made by background tasks to stay under
+	// the SpaceTraders limit of 2 requests per second, leaving headroom for interactive tools
+	DefaultRequestInterval = 600 * time.Millisecond
+
+	// minStepInterval is the shortest delay between two steps of the same task
+	minStepInterval = time.Second
+
+	// errorBackoff is multiplied by the number of consecutive errors to delay retries
+	errorBackoff = 10 * time.Second
+)
+
+// RateLimiter hands out evenly spaced slots for API calls shared by all tasks
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter creates a limiter allowing one call per interval
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return &RateLimiter{interval: interval}
+}
+
+// Wait blocks until the caller may make an API call or ctx is cancelled
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// stepResult tells the manager what happened in a step and when to run the next one
+type stepResult struct {
+	Wait    time.Duration
+	Message string
+	Done    bool
+}
+
+// waitFor builds a step result that runs again after d
+func waitFor(d time.Duration, format string, args ...interface{}) stepResult {
+	return stepResult{Wait: d, Message: fmt.Sprintf(format, args...)}
+}
+
+// done builds a step result that completes the task
+func done(format string, args ...interface{}) stepResult {
+	return stepResult{Done: true, Message: fmt.Sprintf(format, args...)}
+}
+
+// runner gives behaviors rate-limited access to the API for a single step.
+// memory persists across steps of the same task so behaviors can remember prices.
+type runner struct {
+	ctx     context.Context
+	client  *client.Client
+	limiter *RateLimiter
+	policy  *policy.Policy
+	memory  map[string]int
+}
+
+// call waits for a rate limit slot and then runs fn
+func (r *runner) call(fn func() error) error {
+	if err := r.limiter.Wait(r.ctx); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// moveTo gets the ship on its way to waypoint. It returns arrived=true when the ship is already there;
+// otherwise the returned result waits for the next step (after undocking or until arrival).
+func (r *runner) moveTo(ship *client.Ship, waypoint string) (bool, stepResult, error) {
+	if ship.Nav.WaypointSymbol == waypoint {
+		return true, stepResult{}, nil
+	}
+
+	if ship.Nav.Status == "DOCKED" {
+		// Top up before leaving; not every waypoint sells fuel, so failures are ignored unless
+		// the spending policy refused the bill
+		if ship.Fuel.Capacity > 0 && ship.Fuel.Current < ship.Fuel.Capacity {
+			var violation *policy.Violation
+			if _, err := r.refuel(ship); errors.As(err, &violation) {
+				return false, stepResult{}, err
+			}
+		}
+		if err := r.orbit(ship); err != nil {
+			return false, stepResult{}, err
+		}
+	}
+
+	var nav *client.NavigateResponse
+	err := r.call(func() (err error) {
+		nav, err = r.client.NavigateShip(ship.Symbol, waypoint)
+		return err
+	})
+	if err != nil {
+		return false, stepResult{}, fmt.Errorf("failed to navigate to %s: %w", waypoint, err)
+	}
+
+	travelling := client.Ship{Nav: nav.Data.Nav}
+	arrivalIn, _ := travelling.ArrivalIn(time.Now())
+	return false, waitFor(arrivalIn+time.Second, "navigating to %s", waypoint), nil
+}
+
+// checkSpend asks the spending policy whether buying what for cost credits may go ahead. What
+// is spent is counted by the policy itself, which observes the client's transactions.
+func (r *runner) checkSpend(cost int, what string) error {
+	if !r.policy.Enabled() {
+		return nil
+	}
+	var agent *client.Agent
+	err := r.call(func() (err error) {
+		agent, err = r.client.GetAgent()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check %s against the spending policy: %w", what, err)
+	}
+	if err := r.policy.Check(cost, agent.Credits); err != nil {
+		return fmt.Errorf("refused to buy %s: %w", what, err)
+	}
+	return nil
+}
+
+// refuel fills the ship's tank at its waypoint, once the spending policy allows the bill
+func (r *runner) refuel(ship *client.Ship) (*client.RefuelResponse, error) {
+	if r.policy.Enabled() {
+		fuel, err := r.marketGood(ship, "FUEL")
+		if err != nil {
+			return nil, err
+		}
+		if fuel == nil {
+			return nil, fmt.Errorf("%s does not sell fuel", ship.Nav.WaypointSymbol)
+		}
+		missing := ship.Fuel.Capacity - ship.Fuel.Current
+		if err := r.checkSpend(travel.RefuelCost(missing, fuel.PurchasePrice), fmt.Sprintf("%d fuel", missing)); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *client.RefuelResponse
+	err := r.call(func() (err error) {
+		resp, err = r.client.RefuelShip(ship.Symbol, nil, false)
+		return err
+	})
+	return resp, err
+}
+
+// dock docks the ship if it is not already docked
+func (r *runner) dock(ship *client.Ship) error {
+	if ship.Nav.Status == "DOCKED" {
+		return nil
+	}
+	err := r.call(func() error {
+		_, err := r.client.DockShip(ship.Symbol)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dock: %w", err)
+	}
+	ship.Nav.Status = "DOCKED"
+	return nil
+}
+
+// orbit puts the ship in orbit if it is not already there
+func (r *runner) orbit(ship *client.Ship) error {
+	if ship.Nav.Status == "IN_ORBIT" {
+		return nil
+	}
+	err := r.call(func() error {
+		_, err := r.client.OrbitShip(ship.Symbol)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to orbit: %w", err)
+	}
+	ship.Nav.Status = "IN_ORBIT"
+	return nil
+}
+
+// setFlightMode switches the ship's flight mode if it is not already in it
+func (r *runner) setFlightMode(ship *client.Ship, mode string) error {
+	if ship.Nav.FlightMode == mode {
+		return nil
+	}
+	err := r.call(func() error {
+		_, err := r.client.PatchShipNav(ship.Symbol, mode)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to switch to %s: %w", mode, err)
+	}
+	ship.Nav.FlightMode = mode
+	return nil
+}
+
+// market fetches the market at the ship's waypoint
+func (r *runner) market(ship *client.Ship) (*client.Market, error) {
+	var market *client.Market
+	err := r.call(func() (err error) {
+		market, err = r.client.GetMarket(ship.Nav.SystemSymbol, ship.Nav.WaypointSymbol)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market at %s: %w", ship.Nav.WaypointSymbol, err)
+	}
+	return market, nil
+}
+
+// marketGood returns the current market entry for a good at the ship's waypoint, or nil if it is not traded there
+func (r *runner) marketGood(ship *client.Ship, tradeSymbol string) (*client.MarketTradeGood, error) {
+	market, err := r.market(ship)
+	if err != nil {
+		return nil, err
+	}
+	return tradeGood(market, tradeSymbol), nil
+}
+
+// tradeGood returns a good's entry in the market's trade goods, or nil if the market does not trade it
+func tradeGood(market *client.Market, tradeSymbol string) *client.MarketTradeGood {
+	for i := range market.TradeGoods {
+		if market.TradeGoods[i].Symbol == tradeSymbol {
+			return &market.TradeGoods[i]
+		}
+	}
+	return nil
+}
+
+// sell sells units of a good in tradeVolume-sized transactions, each waiting for a rate limit
+// slot. It stops at the first failed transaction, returning what was sold so far with the error.
+func (r *runner) sell(ship *client.Ship, good string, units, tradeVolume int) (*trade.Order, error) {
+	order := trade.NewOrder(good)
+	for _, size := range trade.ChunkSizes(units, tradeVolume) {
+		var resp *client.SellCargoResponse
+		err := r.call(func() (err error) {
+			resp, err = r.client.SellCargo(ship.Symbol, good, size)
+			return err
+		})
+		if err != nil {
+			return order, fmt.Errorf("failed to sell %s after selling %d of %d units: %w", good, order.Units, units, err)
+		}
+		order.Add(resp.Data.Transaction, resp.Data.Agent, resp.Data.Cargo)
+	}
+	return order, nil
+}
+
+// buy buys units of a good in tradeVolume-sized transactions, like sell
+func (r *runner) buy(ship *client.Ship, good string, units, tradeVolume int) (*trade.Order, error) {
+	order := trade.NewOrder(good)
+	for _, size := range trade.ChunkSizes(units, tradeVolume) {
+		var resp *client.BuyCargoResponse
+		err := r.call(func() (err error) {
+			resp, err = r.client.BuyCargo(ship.Symbol, good, size)
+			return err
+		})
+		if err != nil {
+			return order, fmt.Errorf("failed to buy %s after buying %d of %d units: %w", good, order.Units, units, err)
+		}
+		order.Add(resp.Data.Transaction, resp.Data.Agent, resp.Data.Cargo)
+	}
+	return order, nil
+}
+
+// cargoUnits returns how many units of a good the ship carries
+func cargoUnits(ship *client.Ship, tradeSymbol string) int {
+	for _, item := range ship.Cargo.Inventory {
+		if item.Symbol == tradeSymbol {
+			return item.Units
+		}
+	}
+	return 0
+}
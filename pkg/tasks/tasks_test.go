@@ -0,0 +1,664 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+)
+
+// newTestManager returns a manager whose client talks to a server that always fails
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager := NewManager(ctx, client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	t.Cleanup(func() {
+		cancel()
+		manager.Stop()
+	})
+	return manager
+}
+
+func TestManager_AssignValidation(t *testing.T) {
+	manager := newTestManager(t)
+
+	if _, err := manager.Assign("SHIP-1", "fly_to_the_moon", nil); err == nil {
+		t.Error("Expected error for unknown behavior")
+	}
+	if _, err := manager.Assign("SHIP-1", "mine_loop", map[string]string{"asteroid": "X1-TEST-B4"}); err == nil {
+		t.Error("Expected error for missing market parameter")
+	}
+	if len(manager.List()) != 0 {
+		t.Errorf("Expected no tasks after failed assignments, got %d", len(manager.List()))
+	}
+}
+
+func TestManager_AssignAndCancel(t *testing.T) {
+	manager := newTestManager(t)
+	params := map[string]string{"asteroid": "X1-TEST-B4", "market": "X1-TEST-A1"}
+
+	task, err := manager.Assign("SHIP-1", "mine_loop", params)
+	if err != nil {
+		t.Fatalf("Assign returned error: %v", err)
+	}
+	if task.Status != StatusRunning {
+		t.Errorf("Expected running task, got %s", task.Status)
+	}
+
+	if _, err := manager.Assign("SHIP-1", "mine_loop", params); err == nil {
+		t.Error("Expected error when assigning a second task to the same ship")
+	}
+	if _, active := manager.ActiveTask("SHIP-1"); !active {
+		t.Error("Expected SHIP-1 to have an active task")
+	}
+
+	cancelled, err := manager.Cancel(task.ID)
+	if err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+	if cancelled.Status != StatusCancelled {
+		t.Errorf("Expected cancelled task, got %s", cancelled.Status)
+	}
+	if _, active := manager.ActiveTask("SHIP-1"); active {
+		t.Error("Expected no active task after cancel")
+	}
+	if _, err := manager.Cancel("SHIP-1"); err == nil {
+		t.Error("Expected error when cancelling an already cancelled task")
+	}
+
+	// The ship is free for a new assignment once its task is cancelled
+	if _, err := manager.Assign("SHIP-1", "trade_loop", map[string]string{"good": "FUEL", "buy_at": "X1-TEST-A1", "sell_at": "X1-TEST-B2"}); err != nil {
+		t.Errorf("Expected reassignment to succeed, got %v", err)
+	}
+}
+
+func TestManager_ResumeChecksShip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/my/ships/SHIP-1" {
+			_, _ = w.Write([]byte(`{"data": {"symbol": "SHIP-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED"}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"message": "Ship not found", "code": 404}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager := NewManager(ctx, client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	t.Cleanup(func() {
+		cancel()
+		manager.Stop()
+	})
+	params := map[string]string{"asteroid": "X1-TEST-B4", "market": "X1-TEST-A1"}
+
+	if _, err := manager.Resume("SHIP-GONE", "mine_loop", params); err == nil {
+		t.Error("Expected a ship that no longer exists not to be resumed")
+	}
+	task, err := manager.Resume("SHIP-1", "mine_loop", params)
+	if err != nil {
+		t.Fatalf("Resume returned error: %v", err)
+	}
+	if task.ShipSymbol != "SHIP-1" || task.Status != StatusRunning {
+		t.Errorf("Expected a running task on SHIP-1, got %+v", task)
+	}
+	if len(manager.List()) != 1 {
+		t.Errorf("Expected only the resumed task, got %d tasks", len(manager.List()))
+	}
+}
+
+func TestManager_StepErrorsAreRecorded(t *testing.T) {
+	manager := newTestManager(t)
+
+	task, err := manager.Assign("SHIP-1", "mine_loop", map[string]string{"asteroid": "X1-TEST-B4", "market": "X1-TEST-A1"})
+	if err != nil {
+		t.Fatalf("Assign returned error: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if current, _ := manager.ActiveTask(task.ShipSymbol); current.LastError != "" {
+			if current.Steps != 1 {
+				t.Errorf("Expected 1 step, got %d", current.Steps)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Expected the failed ship refresh to be recorded on the task")
+}
+
+func TestManager_RecordReportsFinishedTasks(t *testing.T) {
+	manager := newTestManager(t)
+	ctx := context.Background()
+
+	task := &Task{ID: "task-1", ShipSymbol: "SHIP-1", Status: StatusRunning}
+	if _, finished := manager.record(ctx, task, stepResult{Wait: time.Minute, Message: "mining"}, nil); finished {
+		t.Error("Expected a task that keeps going not to be reported as finished")
+	}
+	if _, finished := manager.record(ctx, task, stepResult{Done: true, Message: "contract fulfilled"}, nil); !finished || task.Status != StatusCompleted {
+		t.Errorf("Expected the task to complete, got finished=%v status=%s", finished, task.Status)
+	}
+	// A step recorded after the task finished is ignored
+	if _, finished := manager.record(ctx, task, stepResult{Done: true}, nil); finished {
+		t.Error("Expected a finished task to be reported only once")
+	}
+
+	failing := &Task{ID: "task-2", ShipSymbol: "SHIP-2", Status: StatusRunning}
+	for i := 1; i <= maxConsecutiveErrors; i++ {
+		_, finished := manager.record(ctx, failing, stepResult{}, errors.New("ship not found"))
+		if finished != (i == maxConsecutiveErrors) {
+			t.Errorf("Step %d: expected finished=%v, got %v", i, i == maxConsecutiveErrors, finished)
+		}
+	}
+	if failing.Status != StatusFailed {
+		t.Errorf("Expected the task to fail, got %s", failing.Status)
+	}
+}
+
+func TestManager_RecordWaitsOutOpenCircuit(t *testing.T) {
+	manager := newTestManager(t)
+	ctx := context.Background()
+
+	task := &Task{ID: "task-1", ShipSymbol: "SHIP-1", Status: StatusRunning}
+	retryAt := time.Now().Add(time.Minute)
+	open := fmt.Errorf("failed to get ship: %w", &client.CircuitOpenError{Class: "fleet", Failures: 5, LastError: "503 Service Unavailable", RetryAt: retryAt})
+	for i := 0; i <= maxConsecutiveErrors; i++ {
+		wait, finished := manager.record(ctx, task, stepResult{}, open)
+		if finished || task.Status != StatusRunning {
+			t.Fatalf("Expected the task to keep running while the API is degraded, got %s", task.Status)
+		}
+		if wait < 59*time.Second || wait > time.Minute {
+			t.Errorf("Expected to wait until the circuit retries, got %s", wait)
+		}
+	}
+	if task.LastError == "" {
+		t.Error("Expected the rejection to be shown on the task")
+	}
+}
+
+func TestRateLimiter_SpacesCalls(t *testing.T) {
+	limiter := NewRateLimiter(20 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("Expected 4 calls to take at least 60ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_Cancelled(t *testing.T) {
+	limiter := NewRateLimiter(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("First call should not wait, got %v", err)
+	}
+	cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Error("Expected error when context is cancelled")
+	}
+}
+
+func TestMarketScanStep_RecordsPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/systems/X1-TEST/waypoints/X1-TEST-A1/market" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "exports": [], "imports": [], "exchange": [], "tradeGoods": [{"symbol": "FUEL", "type": "EXCHANGE", "tradeVolume": 100, "supply": "ABUNDANT", "purchasePrice": 72, "sellPrice": 68}]}}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	var observed []client.Observation
+	c.AddObserver(func(o client.Observation) {
+		observed = append(observed, o)
+	})
+
+	r := &runner{ctx: context.Background(), client: c, limiter: NewRateLimiter(0), memory: make(map[string]int)}
+	ship := &client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: "X1-TEST-A1", Status: "DOCKED"}}
+	params := map[string]string{"waypoints": "X1-TEST-A1,X1-TEST-B2"}
+
+	result, err := marketScanStep(r, params, ship)
+	if err != nil {
+		t.Fatalf("Step returned error: %v", err)
+	}
+	if result.Done || result.Message != "recorded 1 prices at X1-TEST-A1 (1/2 markets)" {
+		t.Errorf("Unexpected step result %+v", result)
+	}
+	if r.memory["next"] != 1 {
+		t.Errorf("Expected the scan to move on to the second market, got next=%d", r.memory["next"])
+	}
+	if len(observed) != 1 || observed[0].Kind != client.ObservedMarket || len(observed[0].Market.TradeGoods) != 1 {
+		t.Errorf("Expected the market to be observed with its prices, got %+v", observed)
+	}
+}
+
+func TestRescueStep_DriftsRefuelsAndRestores(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/SHIP-1/nav":
+			_, _ = w.Write([]byte(`{"data": {"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT", "flightMode": "DRIFT"}, "events": []}}`))
+		case "/my/ships/SHIP-1/navigate":
+			_, _ = w.Write([]byte(`{"data": {"fuel": {"current": 0, "capacity": 400}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-B2", "status": "IN_TRANSIT", "flightMode": "DRIFT"}, "events": []}}`))
+		case "/my/ships/SHIP-1/dock":
+			_, _ = w.Write([]byte(`{"data": {"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-B2", "status": "DOCKED"}}}`))
+		case "/my/ships/SHIP-1/refuel":
+			_, _ = w.Write([]byte(`{"data": {"agent": {"symbol": "TEST", "credits": 1000}, "fuel": {"current": 400, "capacity": 400}, "transaction": {"waypointSymbol": "X1-TEST-B2", "shipSymbol": "SHIP-1", "tradeSymbol": "FUEL", "type": "PURCHASE", "units": 400, "pricePerUnit": 2, "totalPrice": 800, "timestamp": "2030-01-01T00:00:00Z"}}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), memory: make(map[string]int)}
+	params := map[string]string{"market": "X1-TEST-B2", "flight_mode": "CRUISE"}
+	ship := &client.Ship{
+		Symbol: "SHIP-1",
+		Nav:    client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: "X1-TEST-A1", Status: "IN_ORBIT", FlightMode: "CRUISE"},
+		Fuel:   client.Fuel{Current: 0, Capacity: 400},
+	}
+
+	var messages []string
+	for i := 0; i < 5; i++ {
+		result, err := rescueStep(r, params, ship)
+		if err != nil {
+			t.Fatalf("Step %d returned error: %v", i+1, err)
+		}
+		messages = append(messages, result.Message)
+		if result.Done {
+			break
+		}
+		// The manager refreshes the ship between steps; stand in for the trip and the refuel
+		if ship.Nav.WaypointSymbol != "X1-TEST-B2" && strings.HasPrefix(result.Message, "navigating") {
+			ship.Nav.WaypointSymbol = "X1-TEST-B2"
+		}
+		if strings.HasPrefix(result.Message, "refueled") {
+			ship.Fuel.Current = 400
+		}
+	}
+
+	expected := []string{
+		"switched to DRIFT to reach X1-TEST-B2",
+		"navigating to X1-TEST-B2",
+		"refueled 400 units for 800 credits at X1-TEST-B2",
+		"rescued at X1-TEST-B2 with 400/400 fuel; switched back to CRUISE",
+	}
+	if fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("Expected steps %q, got %q", expected, messages)
+	}
+	if got := strings.Join(requests, ","); got != "PATCH /my/ships/SHIP-1/nav,POST /my/ships/SHIP-1/navigate,POST /my/ships/SHIP-1/dock,POST /my/ships/SHIP-1/refuel,PATCH /my/ships/SHIP-1/nav" {
+		t.Errorf("Unexpected requests %s", got)
+	}
+}
+
+// tradeMarkets stands in for a source market at X1-TEST-A1 and a destination at X1-TEST-B2,
+// tracking the ship's cargo and location so tests can change prices between steps
+type tradeMarkets struct {
+	held      int
+	at        string
+	buyPrice  int
+	sellPrice int
+	buyVolume int
+	// sellVolume limits how much B2 takes in one sale
+	sellVolume int
+	// delivered is how much of the 60 IRON_ORE contract-1 asks for has been delivered
+	delivered int
+	orders    []string
+}
+
+func newTradeMarketServer(t *testing.T, m *tradeMarkets) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		nav := func(status string) string {
+			return fmt.Sprintf(`{"systemSymbol": "X1-TEST", "waypointSymbol": "%s", "status": "%s", "flightMode": "CRUISE"}`, m.at, status)
+		}
+		trade := func(kind string, price int) {
+			var req struct {
+				Units int `json:"units"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			m.orders = append(m.orders, fmt.Sprintf("%s %d@%d", kind, req.Units, price))
+			if kind == "PURCHASE" {
+				m.held += req.Units
+			} else {
+				m.held -= req.Units
+			}
+			_, _ = fmt.Fprintf(w, `{"data": {
+				"agent": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 100000, "startingFaction": "COSMIC", "shipCount": 1},
+				"cargo": {"capacity": 40, "units": %d, "inventory": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "", "units": %d}]},
+				"transaction": {"waypointSymbol": "%s", "shipSymbol": "SHIP-1", "tradeSymbol": "IRON_ORE", "type": "%s", "units": %d, "pricePerUnit": %d, "totalPrice": %d, "timestamp": "2030-01-01T00:00:00.000Z"}
+			}}`, m.held, m.held, m.at, kind, req.Units, price, req.Units*price)
+		}
+		market := func(symbol string, volume, purchase, sell int) {
+			_, _ = fmt.Fprintf(w, `{"data": {"symbol": "%s", "exports": [], "imports": [], "exchange": [], "tradeGoods": [{"symbol": "IRON_ORE", "type": "EXCHANGE", "tradeVolume": %d, "supply": "MODERATE", "purchasePrice": %d, "sellPrice": %d}]}}`, symbol, volume, purchase, sell)
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/SHIP-1":
+			_, _ = fmt.Fprintf(w, `{"data": {"symbol": "SHIP-1", "nav": %s, "cargo": {"capacity": 40, "units": %d, "inventory": []}, "fuel": {"current": 0, "capacity": 0}}}`, nav("DOCKED"), m.held)
+		case "GET /my/agent":
+			_, _ = w.Write([]byte(`{"data": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 100000, "startingFaction": "COSMIC", "shipCount": 1}}`))
+		case "GET /my/contracts":
+			_, _ = fmt.Fprintf(w, `{"data": [{"id": "contract-1", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "terms": {"deadline": "2030-02-01T00:00:00.000Z", "payment": {"onAccepted": 1000, "onFulfilled": 9000}, "deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-B2", "unitsRequired": 60, "unitsFulfilled": %d}]}, "accepted": true, "fulfilled": false, "expiration": "2030-01-20T00:00:00.000Z", "deadlineToAccept": "2030-01-20T00:00:00.000Z"}], "meta": {"total": 1, "page": 1, "limit": 20}}`, m.delivered)
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			market("X1-TEST-A1", m.buyVolume, m.buyPrice, m.buyPrice-5)
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-B2/market":
+			market("X1-TEST-B2", m.sellVolume, m.sellPrice+5, m.sellPrice)
+		case "POST /my/ships/SHIP-1/purchase":
+			trade("PURCHASE", m.buyPrice)
+		case "POST /my/ships/SHIP-1/sell":
+			trade("SELL", m.sellPrice)
+		case "POST /my/ships/SHIP-1/orbit":
+			_, _ = fmt.Fprintf(w, `{"data": {"nav": %s}}`, nav("IN_ORBIT"))
+		case "POST /my/ships/SHIP-1/dock":
+			_, _ = fmt.Fprintf(w, `{"data": {"nav": %s}}`, nav("DOCKED"))
+		case "POST /my/ships/SHIP-1/navigate":
+			var req struct {
+				WaypointSymbol string `json:"waypointSymbol"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			m.at = req.WaypointSymbol
+			_, _ = fmt.Fprintf(w, `{"data": {"fuel": {"current": 0, "capacity": 0}, "nav": %s, "events": []}}`, nav("IN_TRANSIT"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// refresh brings the ship's location and hold up to date with the markets
+func (m *tradeMarkets) refresh(ship *client.Ship) {
+	if ship.Nav.WaypointSymbol != m.at {
+		ship.Nav.WaypointSymbol, ship.Nav.Status = m.at, "IN_ORBIT"
+	}
+	ship.Cargo = client.Cargo{Capacity: 40, Units: m.held}
+	if m.held > 0 {
+		ship.Cargo.Inventory = []client.CargoItem{{Symbol: "IRON_ORE", Units: m.held}}
+	}
+}
+
+// runTradeLoop runs trade loop steps until the task finishes, refreshing the ship from the
+// fake markets between steps as the manager would. before is called ahead of each step.
+func runTradeLoop(t *testing.T, r *runner, m *tradeMarkets, params map[string]string, before func()) []string {
+	t.Helper()
+
+	ship := &client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: m.at, Status: "DOCKED"}}
+	var messages []string
+	for step := 0; step < 20; step++ {
+		if before != nil {
+			before()
+		}
+		m.refresh(ship)
+
+		result, err := tradeLoopStep(r, params, ship)
+		if err != nil {
+			t.Fatalf("Step %d returned error: %v", step+1, err)
+		}
+		messages = append(messages, result.Message)
+		if result.Done {
+			return messages
+		}
+	}
+	t.Fatalf("Expected the trade loop to finish, got %q", messages)
+	return nil
+}
+
+func TestTradeLoopStep_DrainsCargoWhenMarginFalls(t *testing.T) {
+	m := &tradeMarkets{at: "X1-TEST-A1", buyPrice: 50, sellPrice: 70, buyVolume: 20, sellVolume: 15}
+	server := newTradeMarketServer(t, m)
+	defer server.Close()
+
+	r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), memory: make(map[string]int)}
+	params := map[string]string{"good": "IRON_ORE", "buy_at": "X1-TEST-A1", "sell_at": "X1-TEST-B2", "min_margin": "10"}
+
+	// The spread narrows below min_margin after the first purchase of the second round
+	messages := runTradeLoop(t, r, m, params, func() {
+		if strings.Count(strings.Join(m.orders, ","), "PURCHASE") == 3 {
+			m.buyPrice = 65
+		}
+	})
+
+	expected := []string{
+		"bought 20 IRON_ORE for 1000 credits",
+		"bought 20 IRON_ORE for 1000 credits",
+		"navigating to X1-TEST-B2",
+		"sold 15 IRON_ORE for 1050 credits",
+		"sold 15 IRON_ORE for 1050 credits",
+		"sold 10 IRON_ORE for 700 credits",
+		"navigating to X1-TEST-A1",
+		"bought 20 IRON_ORE for 1000 credits",
+		"margin fell to 5 credits/unit, selling remaining cargo before stopping",
+		"navigating to X1-TEST-B2",
+		"sold 15 IRON_ORE for 1050 credits",
+		"stopped after selling the remaining cargo: margin fell below minimum 10",
+	}
+	if fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("Expected steps %q, got %q", expected, messages)
+	}
+	if m.held != 0 {
+		t.Errorf("Expected the cargo to be sold before stopping, %d units left", m.held)
+	}
+	// Orders are clamped to each market's trade volume
+	if got := strings.Join(m.orders, ","); got != "PURCHASE 20@50,PURCHASE 20@50,SELL 15@70,SELL 15@70,SELL 10@70,PURCHASE 20@50,SELL 15@70,SELL 5@70" {
+		t.Errorf("Unexpected orders %s", got)
+	}
+	if r.memory["buy_price"] != 50 || r.memory["sell_price"] != 70 {
+		t.Errorf("Expected the last buy and sell prices remembered, got %v", r.memory)
+	}
+}
+
+func TestTradeLoopStep_StopsBelowMinMargin(t *testing.T) {
+	tests := []struct {
+		name     string
+		markets  tradeMarkets
+		memory   map[string]int
+		expected string
+		orders   string
+	}{
+		{
+			name:     "spread at the source market",
+			markets:  tradeMarkets{at: "X1-TEST-A1", buyPrice: 65, sellPrice: 70, buyVolume: 20, sellVolume: 20},
+			memory:   map[string]int{"buy_price": 50, "sell_price": 70},
+			expected: "stopped: margin fell to 5 credits/unit (buy 65, last sell 70), below minimum 10",
+		},
+		{
+			name:     "sale below the price paid plus margin",
+			markets:  tradeMarkets{held: 10, at: "X1-TEST-B2", buyPrice: 50, sellPrice: 55, buyVolume: 20, sellVolume: 20},
+			memory:   map[string]int{"buy_price": 50, "sell_price": 70},
+			expected: "stopped: margin fell to 5 credits/unit (bought at 50, sold at 55), below minimum 10",
+			orders:   "SELL 10@55",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.markets
+			server := newTradeMarketServer(t, &m)
+			defer server.Close()
+
+			r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), memory: tt.memory}
+			params := map[string]string{"good": "IRON_ORE", "buy_at": "X1-TEST-A1", "sell_at": "X1-TEST-B2", "min_margin": "10"}
+
+			messages := runTradeLoop(t, r, &m, params, nil)
+			if len(messages) != 1 || messages[0] != tt.expected {
+				t.Errorf("Expected to stop with %q, got %q", tt.expected, messages)
+			}
+			if got := strings.Join(m.orders, ","); got != tt.orders {
+				t.Errorf("Expected orders %q, got %q", tt.orders, got)
+			}
+		})
+	}
+}
+
+func TestTradeLoopStep_ClampsOrdersWithoutMinMargin(t *testing.T) {
+	m := &tradeMarkets{at: "X1-TEST-A1", buyPrice: 50, sellPrice: 70, buyVolume: 20, sellVolume: 15}
+	server := newTradeMarketServer(t, m)
+	defer server.Close()
+
+	r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), memory: make(map[string]int)}
+	params := map[string]string{"good": "IRON_ORE", "buy_at": "X1-TEST-A1", "sell_at": "X1-TEST-B2"}
+
+	ship := &client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: m.at, Status: "DOCKED"}}
+	for step := 0; step < 7; step++ {
+		m.refresh(ship)
+		result, err := tradeLoopStep(r, params, ship)
+		if err != nil {
+			t.Fatalf("Step %d returned error: %v", step+1, err)
+		}
+		if result.Done {
+			t.Fatalf("Expected the loop to keep going without min_margin, stopped with %q", result.Message)
+		}
+	}
+
+	if got := strings.Join(m.orders, ","); got != "PURCHASE 20@50,PURCHASE 20@50,SELL 15@70,SELL 15@70,SELL 10@70" {
+		t.Errorf("Expected orders clamped to each market's trade volume, got %s", got)
+	}
+	if m.at != "X1-TEST-A1" {
+		t.Errorf("Expected the ship to head back to buy once the hold is empty, at %s", m.at)
+	}
+}
+
+func TestMineLoopStep_SellsInTradeVolumeChunks(t *testing.T) {
+	m := &tradeMarkets{held: 40, at: "X1-TEST-B2", buyPrice: 50, sellPrice: 70, buyVolume: 20, sellVolume: 15}
+	server := newTradeMarketServer(t, m)
+	defer server.Close()
+
+	r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), memory: make(map[string]int)}
+	ship := &client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: m.at, Status: "DOCKED"}}
+	m.refresh(ship)
+
+	result, err := mineLoopStep(r, map[string]string{"asteroid": "X1-TEST-A1", "market": "X1-TEST-B2"}, ship)
+	if err != nil {
+		t.Fatalf("Step returned error: %v", err)
+	}
+	if result.Message != "sold 40 units for 2800 credits at X1-TEST-B2" {
+		t.Errorf("Unexpected step result %+v", result)
+	}
+	if got := strings.Join(m.orders, ","); got != "SELL 15@70,SELL 15@70,SELL 10@70" {
+		t.Errorf("Expected the hold sold in chunks of 15, got %s", got)
+	}
+}
+
+func TestContractHaulStep_BuysInTradeVolumeChunks(t *testing.T) {
+	m := &tradeMarkets{at: "X1-TEST-A1", buyPrice: 50, sellPrice: 70, buyVolume: 15, sellVolume: 15, delivered: 25}
+	server := newTradeMarketServer(t, m)
+	defer server.Close()
+
+	r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), memory: make(map[string]int)}
+	ship := &client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: m.at, Status: "DOCKED"}}
+	m.refresh(ship)
+
+	result, err := contractHaulStep(r, map[string]string{"contract_id": "contract-1", "buy_at": "X1-TEST-A1"}, ship)
+	if err != nil {
+		t.Fatalf("Step returned error: %v", err)
+	}
+	if result.Message != "bought 35 IRON_ORE for 1750 credits for contract contract-1" {
+		t.Errorf("Unexpected step result %+v", result)
+	}
+	if got := strings.Join(m.orders, ","); got != "PURCHASE 15@50,PURCHASE 15@50,PURCHASE 5@50" {
+		t.Errorf("Expected the 35 units still owed bought in chunks of 15, got %s", got)
+	}
+}
+
+func TestTasks_SpendingPolicyRefusesPurchases(t *testing.T) {
+	tests := []struct {
+		name string
+		step stepFunc
+		// params for the step; both buy 20 IRON_ORE at 50 credits from X1-TEST-A1
+		params map[string]string
+	}{
+		{name: "trade loop", step: tradeLoopStep, params: map[string]string{"good": "IRON_ORE", "buy_at": "X1-TEST-A1", "sell_at": "X1-TEST-B2"}},
+		{name: "contract haul", step: contractHaulStep, params: map[string]string{"contract_id": "contract-1", "buy_at": "X1-TEST-A1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &tradeMarkets{at: "X1-TEST-A1", buyPrice: 50, sellPrice: 70, buyVolume: 20, sellVolume: 20, delivered: 40}
+			server := newTradeMarketServer(t, m)
+			defer server.Close()
+
+			spending := policy.New(policy.Limits{MaxPurchase: 500})
+			r := &runner{ctx: context.Background(), client: client.NewClientWithBaseURL("test-token", server.URL), limiter: NewRateLimiter(0), policy: spending, memory: make(map[string]int)}
+			ship := &client.Ship{Symbol: "SHIP-1", Nav: client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: m.at, Status: "DOCKED"}}
+			m.refresh(ship)
+
+			_, err := tt.step(r, tt.params, ship)
+			var violation *policy.Violation
+			if !errors.As(err, &violation) || violation.Limit != "max_purchase" {
+				t.Fatalf("Expected the step to fail on the max purchase limit, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "refused to buy 20 IRON_ORE") {
+				t.Errorf("Expected the refusal to name the purchase, got %v", err)
+			}
+			if len(m.orders) != 0 {
+				t.Errorf("Expected nothing bought, got %v", m.orders)
+			}
+		})
+	}
+}
+
+func TestManager_SpendingPolicyFailsTaskStep(t *testing.T) {
+	m := &tradeMarkets{at: "X1-TEST-A1", buyPrice: 50, sellPrice: 70, buyVolume: 20, sellVolume: 20}
+	server := newTradeMarketServer(t, m)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager := NewManager(ctx, client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).
+		WithPolicy(policy.New(policy.Limits{ReserveFloor: 99500}))
+	manager.limiter = NewRateLimiter(0)
+	t.Cleanup(func() {
+		cancel()
+		manager.Stop()
+	})
+
+	task, err := manager.Assign("SHIP-1", "trade_loop", map[string]string{"good": "IRON_ORE", "buy_at": "X1-TEST-A1", "sell_at": "X1-TEST-B2"})
+	if err != nil {
+		t.Fatalf("Assign returned error: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if current, _ := manager.ActiveTask(task.ShipSymbol); current.LastError != "" {
+			if !strings.Contains(current.LastError, "below the 99500 credit reserve") {
+				t.Errorf("Expected the step to fail on the credit reserve, got %q", current.LastError)
+			}
+			if len(m.orders) != 0 {
+				t.Errorf("Expected nothing bought, got %v", m.orders)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Expected the refused purchase to be recorded on the task")
+}
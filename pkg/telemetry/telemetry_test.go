@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestToolMiddleware_APICallIsChildSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: Transport(http.DefaultTransport)}
+	handler := ToolMiddleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/my/agent", nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		return &mcp.CallToolResult{IsError: true}, nil
+	})
+
+	if _, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "get_status_summary"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected a tool span and an API span, got %d spans", len(spans))
+	}
+
+	apiSpan, toolSpan := spans[0], spans[1]
+	if apiSpan.Name != "GET /my/agent" || toolSpan.Name != "tool get_status_summary" {
+		t.Errorf("Unexpected span names %q and %q", apiSpan.Name, toolSpan.Name)
+	}
+	if apiSpan.Parent.SpanID() != toolSpan.SpanContext.SpanID() {
+		t.Error("Expected the API span to be a child of the tool span")
+	}
+	if toolSpan.Status.Code != codes.Error {
+		t.Errorf("Expected an error status for an error result, got %v", toolSpan.Status.Code)
+	}
+}
+
+func TestSetup_DisabledWithoutEndpoint(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "", "test")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected no-op shutdown, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package timeouts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// LongOperations are the built-in overrides for reads that page through the whole universe,
+// and for the wait tool, which blocks for up to 10 minutes by design. Configured overrides take
+// precedence.
+var LongOperations = map[string]time.Duration{
+	"spacetraders://systems":                 10 * time.Minute,
+	"spacetraders://universe/jumpgate-graph": 10 * time.Minute,
+	"wait":                                   11 * time.Minute,
+}
+
+// Timeouts decides how long each tool call and resource read may run, and enforces it by giving
+// the handler a context with that deadline
+type Timeouts struct {
+	defaultTimeout time.Duration
+	overrides      map[string]time.Duration
+}
+
+// New creates timeouts with a default for every tool and resource, and overrides keyed by tool
+// name or resource URI. Resource URIs may be templates like
+// spacetraders://systems/{systemSymbol}/waypoints. A zero timeout is no limit.
+func New(defaultTimeout time.Duration, overrides map[string]time.Duration) *Timeouts {
+	merged := make(map[string]time.Duration, len(LongOperations)+len(overrides))
+	for name, timeout := range LongOperations {
+		merged[name] = timeout
+	}
+	for name, timeout := range overrides {
+		merged[name] = timeout
+	}
+	return &Timeouts{
+		defaultTimeout: defaultTimeout,
+		overrides:      merged,
+	}
+}
+
+// For returns the timeout for a tool name or resource URI
+func (t *Timeouts) For(name string) time.Duration {
+	if timeout, ok := t.overrides[name]; ok {
+		return timeout
+	}
+
+	// Paged reads share the timeout of the resource they page through
+	uri, _, _ := strings.Cut(name, "?")
+	if timeout, ok := t.overrides[uri]; ok {
+		return timeout
+	}
+	for pattern, timeout := range t.overrides {
+		if matchTemplate(pattern, uri) {
+			return timeout
+		}
+	}
+	return t.defaultTimeout
+}
+
+// matchTemplate reports whether uri matches a resource URI template, where each {name} stands
+// for one path segment
+func matchTemplate(template, uri string) bool {
+	if !strings.Contains(template, "{") {
+		return false
+	}
+	templateParts := strings.Split(template, "/")
+	uriParts := strings.Split(uri, "/")
+	if len(templateParts) != len(uriParts) {
+		return false
+	}
+	for i, part := range templateParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if uriParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != uriParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// withDeadline returns a context that expires after timeout, or ctx itself for no limit
+func withDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timedOut reports whether ctx hit its own deadline, rather than the caller cancelling parent
+func timedOut(ctx, parent context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil
+}
+
+// ToolMiddleware gives each tool call its timeout. A call that fails because it ran out of time
+// gets a timeout error in place of the API error its cancelled request produced.
+func (t *Timeouts) ToolMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout := t.For(request.Params.Name)
+			timeoutCtx, cancel := withDeadline(ctx, timeout)
+			defer cancel()
+
+			result, err := next(timeoutCtx, request)
+			if !timedOut(timeoutCtx, ctx) || (err == nil && result != nil && !result.IsError) {
+				return result, err
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("⏱️ Timed out: %s did not finish within %s. This is not an API error, "+
+						"but anything the tool did before the deadline has still happened; check the game state before retrying. "+
+						"Raise the limit with SPACETRADERS_TIMEOUT_OVERRIDES=%s=<duration>.",
+						request.Params.Name, timeout, request.Params.Name)),
+				},
+				IsError: true,
+			}, nil
+		}
+	}
+}
+
+// ResourceMiddleware gives each resource read its timeout, answering with a timeout message
+// when the read ran out of time
+func (t *Timeouts) ResourceMiddleware() server.ResourceHandlerMiddleware {
+	return func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			timeout := t.For(request.Params.URI)
+			timeoutCtx, cancel := withDeadline(ctx, timeout)
+			defer cancel()
+
+			contents, err := next(timeoutCtx, request)
+			if !timedOut(timeoutCtx, ctx) {
+				return contents, err
+			}
+			// A handler that ignored the deadline and finished anyway still has its answer
+			if err == nil && len(contents) > 0 {
+				if text, ok := contents[0].(*mcp.TextResourceContents); ok && text.MIMEType == "application/json" {
+					return contents, nil
+				}
+			}
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text: fmt.Sprintf("Timed out: reading %s did not finish within %s. This is not an API error; "+
+						"for a list resource, read one page with ?page=1, or raise the limit with SPACETRADERS_TIMEOUT_OVERRIDES.",
+						request.Params.URI, timeout),
+				},
+			}, nil
+		}
+	}
+}
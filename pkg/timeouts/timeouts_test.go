@@ -0,0 +1,103 @@
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestTimeouts_For(t *testing.T) {
+	timeouts := New(time.Minute, map[string]time.Duration{
+		"find_trade_routes": 5 * time.Minute,
+		"spacetraders://systems/{systemSymbol}/waypoints": 3 * time.Minute,
+		"spacetraders://systems":                          20 * time.Minute,
+	})
+
+	for name, want := range map[string]time.Duration{
+		"get_status":                                    time.Minute,
+		"find_trade_routes":                             5 * time.Minute,
+		"spacetraders://systems":                        20 * time.Minute,
+		"spacetraders://systems?page=2":                 20 * time.Minute,
+		"spacetraders://universe/jumpgate-graph":        LongOperations["spacetraders://universe/jumpgate-graph"],
+		"spacetraders://systems/X1-TEST/waypoints":      3 * time.Minute,
+		"spacetraders://systems/X1-TEST/waypoints?page": 3 * time.Minute,
+		"spacetraders://systems//waypoints":             time.Minute,
+		"spacetraders://systems/X1-TEST/waypoints/A1":   time.Minute,
+	} {
+		if got := timeouts.For(name); got != want {
+			t.Errorf("Expected %s for %s, got %s", want, name, got)
+		}
+	}
+}
+
+func TestTimeouts_ToolMiddleware(t *testing.T) {
+	timeouts := New(20*time.Millisecond, map[string]time.Duration{"unlimited": 0})
+
+	// Tools report the cancelled request as an API failure
+	slow := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("Failed to get agent: %v", ctx.Err()))},
+			IsError: true,
+		}, nil
+	}
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_agent"
+
+	result, err := timeouts.ToolMiddleware()(slow)(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "Timed out: get_agent did not finish within 20ms") {
+		t.Errorf("Expected a timeout error, got %q", text)
+	}
+	if strings.Contains(text, "Failed to get agent") {
+		t.Errorf("Expected the API error to be replaced, got %q", text)
+	}
+
+	// Cancelled by the caller rather than the deadline
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, _ = timeouts.ToolMiddleware()(slow)(parent, request)
+	if text := result.Content[0].(mcp.TextContent).Text; strings.Contains(text, "Timed out") {
+		t.Errorf("Expected a cancelled call to keep its own error, got %q", text)
+	}
+
+	// A zero override has no deadline
+	request.Params.Name = "unlimited"
+	deadline := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("Expected no deadline for a zero timeout")
+		}
+		return mcp.NewToolResultText("ok"), nil
+	}
+	if result, _ := timeouts.ToolMiddleware()(deadline)(context.Background(), request); result.IsError {
+		t.Errorf("Unexpected error result %+v", result)
+	}
+}
+
+func TestTimeouts_ResourceMiddleware(t *testing.T) {
+	timeouts := New(20*time.Millisecond, nil)
+	slow := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		<-ctx.Done()
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/plain", Text: "Error fetching agent"},
+		}, nil
+	}
+
+	contents, err := timeouts.ResourceMiddleware()(slow)(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "spacetraders://agent/info"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	text := contents[0].(*mcp.TextResourceContents).Text
+	if !strings.HasPrefix(text, "Timed out: reading spacetraders://agent/info did not finish within 20ms") {
+		t.Errorf("Expected a timeout message, got %q", text)
+	}
+}
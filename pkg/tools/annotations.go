@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool annotations tell MCP hosts what calling a tool does, so they can run reads freely and ask
+// the user before anything that cannot be undone. Every tool is registered with one of these.
+var (
+	// readOnly tools only look at game state
+	readOnly = annotation(true, false, true, true)
+	// localReadOnly tools only read what the server has already recorded and make no API calls
+	localReadOnly = annotation(true, false, true, false)
+	// localDestructive tools overwrite what the server has recorded, such as restoring a snapshot, and make no API calls
+	localDestructive = annotation(false, true, false, false)
+	// localIdempotent tools only change what the server records, such as ship labels, and repeating a call has no further effect
+	localIdempotent = annotation(false, false, true, false)
+	// idempotent tools change game state, but repeating a call with the same arguments has no further effect
+	idempotent = annotation(false, false, true, true)
+	// action tools change game state, and every call does so again (spending credits, fuel or cooldowns)
+	action = annotation(false, false, false, true)
+	// destructive tools throw away ships, cargo or work, which cannot be undone
+	destructive = annotation(false, true, false, true)
+)
+
+// annotation builds a tool annotation with every hint set
+func annotation(readOnly, destructive, idempotent, openWorld bool) mcp.ToolAnnotation {
+	return mcp.ToolAnnotation{
+		ReadOnlyHint:    mcp.ToBoolPtr(readOnly),
+		DestructiveHint: mcp.ToBoolPtr(destructive),
+		IdempotentHint:  mcp.ToBoolPtr(idempotent),
+		OpenWorldHint:   mcp.ToBoolPtr(openWorld),
+	}
+}
+
+// annotatedTool adds the registry's annotations to a tool's definition
+type annotatedTool struct {
+	ToolHandler
+	annotation mcp.ToolAnnotation
+}
+
+// Tool returns the wrapped tool's definition with its annotations, keeping any title it sets
+func (a annotatedTool) Tool() mcp.Tool {
+	tool := a.ToolHandler.Tool()
+	title := tool.Annotations.Title
+	tool.Annotations = a.annotation
+	tool.Annotations.Title = title
+	return tool
+}
+
+// register adds a tool handler with the annotation describing what calling it does
+func (r *Registry) register(annotation mcp.ToolAnnotation, handler ToolHandler) {
+	r.handlers = append(r.handlers, annotatedTool{ToolHandler: handler, annotation: annotation})
+}
@@ -0,0 +1,135 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AssignSquadronTaskTool assigns the same background behavior to every ship in a squadron
+type AssignSquadronTaskTool struct {
+	manager *tasks.Manager
+	store   *shipmeta.Store
+	logger  *logging.Logger
+}
+
+// squadronAssignment is the task assigned to one ship, or why it couldn't be
+type squadronAssignment struct {
+	ShipSymbol string `json:"shipSymbol"`
+	TaskID     string `json:"taskId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewAssignSquadronTaskTool creates a new assign squadron task tool
+func NewAssignSquadronTaskTool(manager *tasks.Manager, store *shipmeta.Store, logger *logging.Logger) *AssignSquadronTaskTool {
+	return &AssignSquadronTaskTool{
+		manager: manager,
+		store:   store,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *AssignSquadronTaskTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "assign_squadron_task",
+		Description: "Assign the same background behavior and parameters to every ship in a squadron, as assign_task does for one ship. Ships already running a task keep it and are reported as failed; the others still get the new task.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"squadron": utils.SquadronProperty,
+				"behavior": map[string]interface{}{
+					"type":        "string",
+					"description": "Behavior to run on every ship; see assign_task for what each needs",
+					"enum":        tasks.BehaviorNames(),
+				},
+				"params": map[string]interface{}{
+					"type":        "object",
+					"description": "Behavior parameters shared by every ship, e.g. {\"asteroid\": \"X1-FM66-B4\", \"market\": \"X1-FM66-A1\"} for mine_loop",
+					"additionalProperties": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"squadron", "behavior"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"squadron":    map[string]interface{}{"type": "string"},
+			"behavior":    map[string]interface{}{"type": "string"},
+			"assignments": map[string]interface{}{"type": "array", "description": "Task ID or error per ship"},
+			"assigned":    map[string]interface{}{"type": "integer"},
+			"failed":      map[string]interface{}{"type": "integer"},
+		}, "squadron", "behavior", "assignments", "assigned", "failed"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *AssignSquadronTaskTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "assign-squadron-task-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		name := v.RequireString("squadron")
+		behaviorName := v.RequireChoice("behavior", tasks.BehaviorNames()...)
+		params := taskParams(v)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		squadron, err := utils.FindSquadron(t.store, name)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent("❌ " + err.Error()),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		assignments := make([]squadronAssignment, 0, len(squadron.Ships))
+		assigned := 0
+		for _, shipSymbol := range squadron.Ships {
+			task, err := t.manager.Assign(shipSymbol, behaviorName, maps.Clone(params))
+			if err != nil {
+				ctxLogger.Error("Failed to assign task to %s: %v", shipSymbol, err)
+				assignments = append(assignments, squadronAssignment{ShipSymbol: shipSymbol, Error: err.Error()})
+				continue
+			}
+			assigned++
+			assignments = append(assignments, squadronAssignment{ShipSymbol: shipSymbol, TaskID: task.ID})
+		}
+
+		ctxLogger.ToolCall("assign_squadron_task", assigned > 0)
+
+		textSummary := "## 🤖 Squadron Tasks Assigned\n\n"
+		textSummary += fmt.Sprintf("**Squadron:** %s\n", squadron.Name)
+		textSummary += fmt.Sprintf("**Behavior:** %s\n", behaviorName)
+		textSummary += fmt.Sprintf("**Assigned:** %d of %d ships\n\n", assigned, len(assignments))
+		for _, assignment := range assignments {
+			if assignment.Error == "" {
+				textSummary += fmt.Sprintf("- ✅ **%s**: %s\n", assignment.ShipSymbol, assignment.TaskID)
+			} else {
+				textSummary += fmt.Sprintf("- ❌ **%s**: %s\n", assignment.ShipSymbol, assignment.Error)
+			}
+		}
+		textSummary += "\nThe tasks run in the background. Check progress with the spacetraders://tasks/list resource and stop them with cancel_task."
+
+		result := utils.NewResult(textSummary, map[string]interface{}{
+			"squadron":    squadron.Name,
+			"behavior":    behaviorName,
+			"assignments": assignments,
+			"assigned":    assigned,
+			"failed":      len(assignments) - assigned,
+		})
+		result.IsError = assigned == 0
+		return result, nil
+	}
+}
@@ -0,0 +1,55 @@
+package automation
+
+import (
+	"context"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAssignSquadronTaskTool_AssignsEveryShip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.NewLogger(nil)
+	manager := tasks.NewManager(ctx, client.NewClientWithBaseURL("test-token", "http://127.0.0.1:1"), logger)
+
+	store, _ := shipmeta.Open("")
+	_, _ = store.SaveSquadron("miners", []string{"DRONE-1", "DRONE-2"})
+	params := map[string]string{"asteroid": "X1-TEST-B4", "market": "X1-TEST-A1"}
+	if _, err := manager.Assign("DRONE-2", "mine_loop", params); err != nil {
+		t.Fatalf("Assign returned error: %v", err)
+	}
+
+	tool := NewAssignSquadronTaskTool(manager, store, logger)
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "assign_squadron_task",
+			Arguments: map[string]interface{}{
+				"squadron": "miners",
+				"behavior": "mine_loop",
+				"params":   map[string]interface{}{"asteroid": "x1-test-b4", "market": "x1-test-a1"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success while one ship was free, got %v", result.Content)
+	}
+
+	data := result.StructuredContent.(map[string]interface{})
+	assignments := data["assignments"].([]squadronAssignment)
+	if data["assigned"] != 1 || assignments[0].TaskID == "" || assignments[1].Error == "" {
+		t.Errorf("Expected DRONE-1 assigned and DRONE-2 refused for its running task, got %+v", assignments)
+	}
+	task, ok := manager.ActiveTask("DRONE-1")
+	if !ok || task.Params["asteroid"] != "X1-TEST-B4" {
+		t.Errorf("Expected DRONE-1's task to have the uppercased params, got %+v", task)
+	}
+}
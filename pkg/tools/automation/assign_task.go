@@ -0,0 +1,135 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// taskOutputSchema describes the task returned by the tools that assign or cancel one
+var taskOutputSchema = utils.OutputSchema(map[string]interface{}{
+	"id":          map[string]interface{}{"type": "string"},
+	"shipSymbol":  map[string]interface{}{"type": "string"},
+	"behavior":    map[string]interface{}{"type": "string"},
+	"params":      map[string]interface{}{"type": "object"},
+	"status":      map[string]interface{}{"type": "string"},
+	"createdAt":   map[string]interface{}{"type": "string"},
+	"lastRunAt":   map[string]interface{}{"type": "string"},
+	"nextRunAt":   map[string]interface{}{"type": "string"},
+	"steps":       map[string]interface{}{"type": "integer", "description": "Steps run so far"},
+	"lastMessage": map[string]interface{}{"type": "string"},
+	"lastError":   map[string]interface{}{"type": "string"},
+}, "id", "shipSymbol", "behavior", "status", "createdAt", "steps")
+
+// AssignTaskTool attaches a long-running behavior to a ship
+type AssignTaskTool struct {
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewAssignTaskTool creates a new assign task tool
+func NewAssignTaskTool(manager *tasks.Manager, logger *logging.Logger) *AssignTaskTool {
+	return &AssignTaskTool{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *AssignTaskTool) Tool() mcp.Tool {
+	description := "Assign a long-running background behavior to a ship. The server runs it automatically, waiting for travel and cooldowns, until it completes or is cancelled with cancel_task. Behaviors:"
+	for _, b := range tasks.Behaviors() {
+		description += fmt.Sprintf(" %s (%s; params: %s", b.Name, b.Description, strings.Join(b.Required, ", "))
+		if len(b.Optional) > 0 {
+			description += fmt.Sprintf("; optional: %s", strings.Join(b.Optional, ", "))
+		}
+		description += ")."
+	}
+
+	return mcp.Tool{
+		Name:        "assign_task",
+		Description: description,
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to assign the task to",
+				},
+				"behavior": map[string]interface{}{
+					"type":        "string",
+					"description": "Behavior to run",
+					"enum":        tasks.BehaviorNames(),
+				},
+				"params": map[string]interface{}{
+					"type":        "object",
+					"description": "Behavior parameters, e.g. {\"asteroid\": \"X1-FM66-B4\", \"market\": \"X1-FM66-A1\"} for mine_loop",
+					"additionalProperties": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"ship_symbol", "behavior"},
+		},
+		OutputSchema: taskOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *AssignTaskTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "assign-task-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		behaviorName := v.RequireChoice("behavior", tasks.BehaviorNames()...)
+		params := taskParams(v)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		task, err := t.manager.Assign(shipSymbol, behaviorName, params)
+		if err != nil {
+			ctxLogger.Error("Failed to assign task: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to assign task: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.ToolCall("assign_task", true)
+
+		textSummary := "## 🤖 Task Assigned\n\n"
+		textSummary += fmt.Sprintf("**Task:** %s\n", task.ID)
+		textSummary += fmt.Sprintf("**Ship:** %s\n", task.ShipSymbol)
+		textSummary += fmt.Sprintf("**Behavior:** %s\n", task.Behavior)
+		textSummary += "\nThe task runs in the background. Check progress with the spacetraders://tasks/list resource and stop it with cancel_task."
+
+		return utils.NewResult(textSummary, task), nil
+	}
+}
+
+// taskParams reads the params argument of a task tool, uppercasing game symbols
+func taskParams(v *utils.Validator) map[string]string {
+	var values map[string]interface{}
+	v.OptionalObject("params", &values)
+
+	params := make(map[string]string, len(values))
+	for key, value := range values {
+		params[key] = strings.TrimSpace(fmt.Sprint(value))
+		// Contract IDs are case-sensitive, unlike game symbols
+		if key != "contract_id" {
+			params[key] = strings.ToUpper(params[key])
+		}
+	}
+	return params
+}
@@ -0,0 +1,420 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// starterMiningShip is the ship type a new agent buys first to mine alongside its command ship
+const starterMiningShip = "SHIP_MINING_DRONE"
+
+// BootstrapAgentTool runs the usual opening moves of a new agent in one call
+type BootstrapAgentTool struct {
+	client  *client.Client
+	manager *tasks.Manager
+	logger  *logging.Logger
+
+	policy            *policy.Policy
+	confirmSpendAbove int
+}
+
+// NewBootstrapAgentTool creates a new agent bootstrap tool
+func NewBootstrapAgentTool(client *client.Client, manager *tasks.Manager, logger *logging.Logger) *BootstrapAgentTool {
+	return &BootstrapAgentTool{
+		client:  client,
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// WithPolicy makes the tool skip the mining drone when buying it would break the spending policy
+func (t *BootstrapAgentTool) WithPolicy(p *policy.Policy) *BootstrapAgentTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the drone price, in credits, above which the user is asked to confirm buying it
+func (t *BootstrapAgentTool) WithConfirmSpendAbove(credits int) *BootstrapAgentTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *BootstrapAgentTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "bootstrap_new_agent",
+		Description: "Run the usual opening moves of a brand new agent in one call: accept the starting contract, find the engineered asteroid in the command ship's system and send the command ship there, buy a mining drone if a shipyard in the system sells one you can afford, and start mine_loop tasks that sell at the marketplace nearest the asteroid. Each step is reported; steps already done, such as an accepted contract, a drone you already own or a running task, are skipped, so it is safe to call again.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"buy_drone": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Whether to buy a mining drone when one is affordable (default true)",
+					"default":     true,
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":  map[string]interface{}{"type": "boolean"},
+			"asteroid": map[string]interface{}{"type": "string", "description": "The asteroid the fleet mines"},
+			"market":   map[string]interface{}{"type": "string", "description": "The marketplace the mining loops sell at"},
+			"steps":    map[string]interface{}{"type": "array", "description": "Each step with status done, skipped or failed"},
+		}, "success", "steps"),
+	}
+}
+
+// bootstrapStep is one step of bootstrapping and how it went
+type bootstrapStep struct {
+	Step   string `json:"step"`
+	Status string `json:"status"` // done, skipped or failed
+	Detail string `json:"detail"`
+}
+
+// Handler returns the tool handler function
+func (t *BootstrapAgentTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "bootstrap-agent-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		buyDrone := v.OptionalBool("buy_drone", true)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		ships, err := c.GetAllShips()
+		if err != nil {
+			ctxLogger.Error("Failed to get ships: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get ships: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		command := commandShip(ships)
+		if command == nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent("❌ The agent has no ships to start with"),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		var steps []bootstrapStep
+		record := func(step, status, format string, args ...interface{}) {
+			steps = append(steps, bootstrapStep{Step: step, Status: status, Detail: fmt.Sprintf(format, args...)})
+		}
+
+		t.acceptStartingContract(c, record)
+
+		asteroid, market, err := miningSite(c, command)
+		if err != nil {
+			record("Find the engineered asteroid", "failed", "%s", err.Error())
+		} else {
+			record("Find the engineered asteroid", "done", "%s (%s), selling at the marketplace %s", asteroid.Symbol, asteroid.Type, market.Symbol)
+		}
+
+		miners := []string{command.Symbol}
+		if drone := t.buyMiningDrone(ctx, c, ships, command, buyDrone, record); drone != "" {
+			miners = append(miners, drone)
+		}
+
+		if asteroid == nil {
+			record("Send the command ship to the asteroid", "skipped", "no asteroid to mine")
+			record("Start mining", "skipped", "no asteroid to mine")
+		} else {
+			sendToAsteroid(c, command, asteroid.Symbol, record)
+			for _, shipSymbol := range miners {
+				t.startMining(shipSymbol, asteroid.Symbol, market.Symbol, record)
+			}
+		}
+
+		failed := false
+		for _, step := range steps {
+			failed = failed || step.Status == "failed"
+		}
+
+		result := map[string]interface{}{
+			"success": !failed,
+			"steps":   steps,
+		}
+		if asteroid != nil {
+			result["asteroid"] = asteroid.Symbol
+			result["market"] = market.Symbol
+		}
+
+		textSummary := "## 🌱 New Agent Bootstrap\n\n"
+		if failed {
+			textSummary = "## ⚠️ New Agent Bootstrap Incomplete\n\n"
+		}
+		for _, step := range steps {
+			icon := "✅"
+			switch step.Status {
+			case "failed":
+				icon = "❌"
+			case "skipped":
+				icon = "⏭️"
+			}
+			textSummary += fmt.Sprintf("%s **%s:** %s\n", icon, step.Step, step.Detail)
+		}
+		if failed {
+			textSummary += "\nFix the failed steps with the individual tools (accept_contract, navigate_ship, purchase_ship, assign_task), or call bootstrap_new_agent again: finished steps are skipped.\n"
+		} else {
+			textSummary += "\nThe mining loops run in the background. Check progress with the spacetraders://tasks/list resource, and deliver the contract's goods once the miners have them.\n"
+		}
+
+		ctxLogger.ToolCall("bootstrap_new_agent", !failed)
+
+		callResult := utils.NewResult(textSummary, result)
+		callResult.IsError = failed
+		return callResult, nil
+	}
+}
+
+// acceptStartingContract accepts the first contract offer still open, unless a contract is
+// already under way
+func (t *BootstrapAgentTool) acceptStartingContract(c *client.Client, record func(step, status, format string, args ...interface{})) {
+	const step = "Accept the starting contract"
+
+	contracts, err := c.GetAllContracts()
+	if err != nil {
+		record(step, "failed", "%s", err.Error())
+		return
+	}
+
+	now := time.Now()
+	var offer *client.Contract
+	for i, contract := range contracts {
+		if contract.Fulfilled {
+			continue
+		}
+		if contract.Accepted {
+			record(step, "skipped", "contract %s is already accepted", contract.ID)
+			return
+		}
+		deadline, err := time.Parse(time.RFC3339, contract.DeadlineToAccept)
+		if offer == nil && (err != nil || deadline.After(now)) {
+			offer = &contracts[i]
+		}
+	}
+	if offer == nil {
+		record(step, "skipped", "there are no contract offers; negotiate one at a faction's waypoint with negotiate_contract")
+		return
+	}
+
+	accepted, err := c.AcceptContract(offer.ID)
+	if err != nil {
+		record(step, "failed", "accepting %s: %s", offer.ID, err.Error())
+		return
+	}
+	var goods []string
+	for _, deliver := range accepted.Data.Contract.Terms.Deliver {
+		goods = append(goods, fmt.Sprintf("%d %s to %s", deliver.UnitsRequired-deliver.UnitsFulfilled, deliver.TradeSymbol, deliver.DestinationSymbol))
+	}
+	record(step, "done", "accepted %s from %s for %d credits now and %d on delivery of %s",
+		offer.ID, offer.FactionSymbol, offer.Terms.Payment.OnAccepted, offer.Terms.Payment.OnFulfilled, strings.Join(goods, ", "))
+}
+
+// miningSite finds the engineered asteroid in the ship's system, or the nearest asteroid when
+// there is none, and the marketplace nearest to it to sell at
+func miningSite(c *client.Client, ship *client.Ship) (*client.SystemWaypoint, *client.SystemWaypoint, error) {
+	waypoints, _, err := c.GetCachedSystemWaypoints(ship.Nav.SystemSymbol)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing %s: %w", ship.Nav.SystemSymbol, err)
+	}
+
+	nearest := func(x, y int, matches func(client.SystemWaypoint) bool) *client.SystemWaypoint {
+		var best *client.SystemWaypoint
+		bestDistance := 0.0
+		for i, waypoint := range waypoints {
+			if !matches(waypoint) {
+				continue
+			}
+			if distance := travel.Distance(x, y, waypoint.X, waypoint.Y); best == nil || distance < bestDistance {
+				best, bestDistance = &waypoints[i], distance
+			}
+		}
+		return best
+	}
+
+	x, y := ship.Nav.Route.Destination.X, ship.Nav.Route.Destination.Y
+	asteroid := nearest(x, y, func(w client.SystemWaypoint) bool { return w.Type == "ENGINEERED_ASTEROID" })
+	if asteroid == nil {
+		asteroid = nearest(x, y, func(w client.SystemWaypoint) bool { return w.Type == "ASTEROID" })
+	}
+	if asteroid == nil {
+		return nil, nil, fmt.Errorf("%s has no asteroid to mine", ship.Nav.SystemSymbol)
+	}
+	market := nearest(asteroid.X, asteroid.Y, func(w client.SystemWaypoint) bool {
+		return slices.ContainsFunc(w.Traits, func(trait client.WaypointTrait) bool { return trait.Symbol == "MARKETPLACE" })
+	})
+	if market == nil {
+		return nil, nil, fmt.Errorf("%s has no marketplace to sell ore at", ship.Nav.SystemSymbol)
+	}
+	return asteroid, market, nil
+}
+
+// buyMiningDrone buys a mining drone at a shipyard in the command ship's system when one is
+// for sale at a price the agent can afford and the policy allows. It returns the new drone's
+// symbol, or "" when none was bought.
+func (t *BootstrapAgentTool) buyMiningDrone(ctx context.Context, c *client.Client, ships []client.Ship, command *client.Ship, buyDrone bool, record func(step, status, format string, args ...interface{})) string {
+	const step = "Buy a mining drone"
+
+	if !buyDrone {
+		record(step, "skipped", "buy_drone is false")
+		return ""
+	}
+	for _, ship := range ships {
+		if ship.Frame.Symbol == "FRAME_DRONE" && hasMiningLaser(ship) {
+			record(step, "skipped", "%s is already a mining drone", ship.Symbol)
+			return ""
+		}
+	}
+
+	waypoints, _, err := c.GetCachedSystemWaypoints(command.Nav.SystemSymbol)
+	if err != nil {
+		record(step, "failed", "listing %s: %s", command.Nav.SystemSymbol, err.Error())
+		return ""
+	}
+	shipyard, price := "", 0
+	var unpriced []string
+	for _, waypoint := range waypoints {
+		if !slices.ContainsFunc(waypoint.Traits, func(trait client.WaypointTrait) bool { return trait.Symbol == "SHIPYARD" }) {
+			continue
+		}
+		listing, err := c.GetShipyard(command.Nav.SystemSymbol, waypoint.Symbol)
+		if err != nil || !slices.ContainsFunc(listing.ShipTypes, func(s client.ShipyardShipType) bool { return s.Type == starterMiningShip }) {
+			continue
+		}
+		// Prices only show while one of our ships is at the shipyard
+		found := false
+		for _, offer := range listing.Ships {
+			if offer.Type == starterMiningShip && (shipyard == "" || offer.PurchasePrice < price) {
+				shipyard, price, found = waypoint.Symbol, offer.PurchasePrice, true
+			}
+		}
+		if !found && shipyard != waypoint.Symbol {
+			unpriced = append(unpriced, waypoint.Symbol)
+		}
+	}
+	if shipyard == "" {
+		if len(unpriced) > 0 {
+			record(step, "skipped", "%s sells mining drones, but prices only show while one of your ships is there; move a ship there and use purchase_ship", strings.Join(unpriced, ", "))
+		} else {
+			record(step, "skipped", "no shipyard in %s sells mining drones", command.Nav.SystemSymbol)
+		}
+		return ""
+	}
+
+	agent, err := c.GetAgent()
+	if err != nil {
+		record(step, "failed", "checking credits: %s", err.Error())
+		return ""
+	}
+	if int64(price) > agent.Credits {
+		record(step, "skipped", "a drone costs %d credits at %s but you have %d", price, shipyard, agent.Credits)
+		return ""
+	}
+	if err := t.policy.Check(price, agent.Credits); err != nil {
+		record(step, "skipped", "the spending policy refused %d credits: %s", price, err.Error())
+		return ""
+	}
+	if t.confirmSpendAbove > 0 && price > t.confirmSpendAbove && utils.CanConfirm(ctx) {
+		message := fmt.Sprintf("Spend %d credits on a mining drone at %s? This is above the %d credit confirmation limit.", price, shipyard, t.confirmSpendAbove)
+		if approved, err := utils.Confirm(ctx, message); err != nil || !approved {
+			record(step, "skipped", "you did not confirm spending %d credits at %s", price, shipyard)
+			return ""
+		}
+	}
+
+	purchase, err := c.PurchaseShip(client.PurchaseShipRequest{ShipType: starterMiningShip, WaypointSymbol: shipyard})
+	if err != nil {
+		record(step, "failed", "buying at %s: %s", shipyard, err.Error())
+		return ""
+	}
+	record(step, "done", "bought %s at %s for %d credits, %d credits left", purchase.Data.Ship.Symbol, shipyard, purchase.Data.Transaction.Price, purchase.Data.Agent.Credits)
+	return purchase.Data.Ship.Symbol
+}
+
+// hasMiningLaser reports whether a ship has a mining laser mounted
+func hasMiningLaser(ship client.Ship) bool {
+	return slices.ContainsFunc(ship.Mounts, func(mount client.Mount) bool {
+		return strings.HasPrefix(mount.Symbol, "MOUNT_MINING_LASER")
+	})
+}
+
+// sendToAsteroid puts the command ship in orbit and flies it to the asteroid, unless it is
+// there or on its way already
+func sendToAsteroid(c *client.Client, command *client.Ship, asteroid string, record func(step, status, format string, args ...interface{})) {
+	const step = "Send the command ship to the asteroid"
+
+	if command.Nav.WaypointSymbol == asteroid {
+		if command.Nav.Status == "IN_TRANSIT" {
+			record(step, "skipped", "%s is already on its way, arriving %s", command.Symbol, command.Nav.Route.Arrival)
+		} else {
+			record(step, "skipped", "%s is already at %s", command.Symbol, asteroid)
+		}
+		return
+	}
+	if command.Nav.Status == "IN_TRANSIT" {
+		record(step, "skipped", "%s is in transit to %s; its mining loop flies it to %s after it arrives", command.Symbol, command.Nav.WaypointSymbol, asteroid)
+		return
+	}
+
+	if command.Nav.Status == "DOCKED" {
+		if _, err := c.OrbitShip(command.Symbol); err != nil {
+			record(step, "failed", "orbiting %s first: %s", command.Symbol, err.Error())
+			return
+		}
+	}
+	navigation, err := c.NavigateShip(command.Symbol, asteroid)
+	if err != nil {
+		record(step, "failed", "%s", err.Error())
+		return
+	}
+	record(step, "done", "%s is on its way, arriving %s", command.Symbol, navigation.Data.Nav.Route.Arrival)
+}
+
+// startMining assigns a mine_loop task to a ship, unless it is already running a task
+func (t *BootstrapAgentTool) startMining(shipSymbol, asteroid, market string, record func(step, status, format string, args ...interface{})) {
+	step := fmt.Sprintf("Start mining with %s", shipSymbol)
+
+	if existing, ok := t.manager.ActiveTask(shipSymbol); ok {
+		record(step, "skipped", "%s is already running task %s (%s)", shipSymbol, existing.ID, existing.Behavior)
+		return
+	}
+	task, err := t.manager.Assign(shipSymbol, "mine_loop", map[string]string{"asteroid": asteroid, "market": market})
+	if err != nil {
+		record(step, "failed", "%s", err.Error())
+		return
+	}
+	record(step, "done", "task %s mines %s and sells at %s", task.ID, asteroid, market)
+}
+
+// commandShip returns the agent's command ship, or its first ship when none has the COMMAND role
+func commandShip(ships []client.Ship) *client.Ship {
+	for i, ship := range ships {
+		if ship.Registration.Role == "COMMAND" {
+			return &ships[i]
+		}
+	}
+	if len(ships) == 0 {
+		return nil
+	}
+	return &ships[0]
+}
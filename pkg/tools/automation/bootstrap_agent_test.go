@@ -0,0 +1,127 @@
+package automation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+func TestBootstrapAgentTool(t *testing.T) {
+	var accepted, purchased, navigated bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/my/ships" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "CMD-1", "registration": {"role": "COMMAND"}, "frame": {"symbol": "FRAME_FRIGATE"}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED", "route": {"destination": {"symbol": "X1-TEST-A1", "x": 10, "y": 0}}}},
+				{"symbol": "CMD-2", "registration": {"role": "SATELLITE"}, "frame": {"symbol": "FRAME_PROBE"}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED"}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		case r.URL.Path == "/my/contracts":
+			_, _ = w.Write([]byte(`{"data": [{"id": "contract-1", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "deadlineToAccept": "2099-01-01T00:00:00.000Z", "terms": {"payment": {"onAccepted": 1000, "onFulfilled": 9000}, "deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-A1", "unitsRequired": 50}]}}], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case r.URL.Path == "/my/contracts/contract-1/accept":
+			accepted = true
+			_, _ = w.Write([]byte(`{"data": {"contract": {"id": "contract-1", "accepted": true, "terms": {"deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-A1", "unitsRequired": 50}]}}, "agent": {"credits": 51000}}}`))
+		case r.URL.Path == "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-ROCK", "type": "ASTEROID", "systemSymbol": "X1-TEST", "x": 12, "y": 0},
+				{"symbol": "X1-TEST-ENG", "type": "ENGINEERED_ASTEROID", "systemSymbol": "X1-TEST", "x": 40, "y": 0},
+				{"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 10, "y": 0, "traits": [{"symbol": "MARKETPLACE"}, {"symbol": "SHIPYARD"}]},
+				{"symbol": "X1-TEST-B2", "type": "MOON", "systemSymbol": "X1-TEST", "x": 45, "y": 0, "traits": [{"symbol": "MARKETPLACE"}]}
+			], "meta": {"total": 4, "page": 1, "limit": 20}}`))
+		case r.URL.Path == "/systems/X1-TEST/waypoints/X1-TEST-A1/shipyard":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "shipTypes": [{"type": "SHIP_PROBE"}, {"type": "SHIP_MINING_DRONE"}], "ships": [{"type": "SHIP_MINING_DRONE", "purchasePrice": 20000}]}}`))
+		case r.URL.Path == "/my/agent":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "TEST_AGENT", "credits": 51000}}`))
+		case r.URL.Path == "/my/ships" && r.Method == http.MethodPost:
+			purchased = true
+			_, _ = w.Write([]byte(`{"data": {"ship": {"symbol": "CMD-3"}, "transaction": {"shipType": "SHIP_MINING_DRONE", "price": 20000}, "agent": {"credits": 31000}}}`))
+		case r.URL.Path == "/my/ships/CMD-1/orbit":
+			_, _ = w.Write([]byte(`{"data": {"nav": {"status": "IN_ORBIT"}}}`))
+		case r.URL.Path == "/my/ships/CMD-1/navigate":
+			navigated = true
+			_, _ = w.Write([]byte(`{"data": {"fuel": {}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-ENG", "status": "IN_TRANSIT", "route": {"arrival": "2030-01-01T00:00:00.000Z"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.NewLogger(nil)
+	// The tasks fly against an unreachable API so they stay idle while the test inspects them
+	manager := tasks.NewManager(ctx, client.NewClientWithBaseURL("test-token", "http://127.0.0.1:1"), logger)
+	tool := NewBootstrapAgentTool(client.NewClientWithBaseURL("test-token", server.URL), manager, logger)
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "bootstrap_new_agent"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected every step to succeed, got %v", result.Content)
+	}
+	if !accepted || !purchased || !navigated {
+		t.Errorf("Expected the contract accepted, a drone bought and the command ship sent, got %v %v %v", accepted, purchased, navigated)
+	}
+
+	data := result.StructuredContent.(map[string]interface{})
+	if data["asteroid"] != "X1-TEST-ENG" || data["market"] != "X1-TEST-B2" {
+		t.Errorf("Expected the engineered asteroid and the marketplace nearest it, got %v and %v", data["asteroid"], data["market"])
+	}
+	for _, ship := range []string{"CMD-1", "CMD-3"} {
+		task, ok := manager.ActiveTask(ship)
+		if !ok || task.Behavior != "mine_loop" || task.Params["asteroid"] != "X1-TEST-ENG" {
+			t.Errorf("Expected %s to be mining X1-TEST-ENG, got %+v", ship, task)
+		}
+	}
+	// A drone above the confirmation limit is only bought once the user approves it
+	purchased = false
+	session := &decliningSession{}
+	result, err = tool.WithConfirmSpendAbove(10000).Handler()(mcpserver.NewMCPServer("Test Server", "1.0.0").WithContext(context.Background(), session), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "bootstrap_new_agent"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if purchased {
+		t.Error("Expected no drone bought when the user declines")
+	}
+	if len(session.messages) != 1 || !strings.Contains(session.messages[0], "20000 credits") {
+		t.Errorf("Expected the user to be asked about the drone's price, got %v", session.messages)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "you did not confirm spending 20000 credits") {
+		t.Errorf("Expected the skipped purchase in the summary, got:\n%s", text)
+	}
+}
+
+// decliningSession is a client session with elicitation that turns down every confirmation
+type decliningSession struct {
+	messages []string
+}
+
+func (s *decliningSession) Initialize()                                         {}
+func (s *decliningSession) Initialized() bool                                   { return true }
+func (s *decliningSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *decliningSession) SessionID() string                                   { return "test-session" }
+func (s *decliningSession) GetClientInfo() mcp.Implementation                   { return mcp.Implementation{} }
+func (s *decliningSession) SetClientInfo(mcp.Implementation)                    {}
+func (s *decliningSession) SetClientCapabilities(mcp.ClientCapabilities)        {}
+func (s *decliningSession) GetClientCapabilities() mcp.ClientCapabilities {
+	return mcp.ClientCapabilities{Elicitation: &mcp.ElicitationCapability{}}
+}
+
+func (s *decliningSession) RequestElicitation(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	s.messages = append(s.messages, request.Params.Message)
+	return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}}, nil
+}
@@ -0,0 +1,82 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CancelTaskTool stops a ship's background task
+type CancelTaskTool struct {
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewCancelTaskTool creates a new cancel task tool
+func NewCancelTaskTool(manager *tasks.Manager, logger *logging.Logger) *CancelTaskTool {
+	return &CancelTaskTool{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *CancelTaskTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "cancel_task",
+		Description: "Cancel the background task running on a ship. The ship stops after its current step and is left wherever it is.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_or_task_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Ship symbol or task ID (e.g., task-3) to cancel",
+				},
+			},
+			Required: []string{"ship_or_task_id"},
+		},
+		OutputSchema: taskOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *CancelTaskTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "cancel-task-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		target := v.RequireString("ship_or_task_id")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		// Ship symbols are upper case, task IDs are lower case
+		if !strings.HasPrefix(target, "task-") {
+			target = strings.ToUpper(target)
+		}
+
+		task, err := t.manager.Cancel(target)
+		if err != nil {
+			ctxLogger.Error("Failed to cancel task: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to cancel task: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.ToolCall("cancel_task", true)
+
+		textSummary := fmt.Sprintf("🛑 Cancelled %s (%s) on %s after %d steps.", task.ID, task.Behavior, task.ShipSymbol, task.Steps)
+
+		return utils.NewResult(textSummary, task), nil
+	}
+}
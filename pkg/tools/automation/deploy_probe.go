@@ -0,0 +1,206 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/stations"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DeployProbeTool sends a probe to a market or shipyard and keeps that waypoint's data refreshed
+type DeployProbeTool struct {
+	client *client.Client
+	poller *stations.Poller
+	logger *logging.Logger
+}
+
+// NewDeployProbeTool creates a new deploy probe tool
+func NewDeployProbeTool(client *client.Client, poller *stations.Poller, logger *logging.Logger) *DeployProbeTool {
+	return &DeployProbeTool{
+		client: client,
+		poller: poller,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *DeployProbeTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "deploy_probe",
+		Description: fmt.Sprintf("Send a probe to a marketplace or shipyard in its system and station it there. The server then refreshes that waypoint's market and shipyard data every %s in the background, recording prices in the price database. Probes use no fuel while stationed.", stations.DefaultInterval),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"probe_ship": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the probe or satellite to deploy",
+				},
+				"target_waypoint": map[string]interface{}{
+					"type":        "string",
+					"description": "Marketplace or shipyard waypoint in the probe's system (e.g., 'X1-FM66-A1')",
+				},
+			},
+			Required: []string{"probe_ship", "target_waypoint"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"station": map[string]interface{}{"type": "object", "description": "The probe station"},
+			"arrival": map[string]interface{}{"type": "string", "description": "When the probe reaches its station, if it is travelling"},
+		}, "station"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *DeployProbeTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "deploy-probe-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("probe_ship")
+		waypointSymbol := v.RequireWaypoint("target_waypoint")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		systemSymbol := travel.SystemSymbol(waypointSymbol)
+		if systemSymbol != ship.Nav.SystemSymbol {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s is in %s but %s is in %s. Move the probe to that system first with jump_ship or warp_ship.", waypointSymbol, systemSymbol, shipSymbol, ship.Nav.SystemSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if ship.Nav.Status == "IN_TRANSIT" && ship.Nav.WaypointSymbol != waypointSymbol {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s is in transit to %s; deploy it once it arrives", shipSymbol, ship.Nav.Route.Destination.Symbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get waypoints for %s: %v", systemSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get waypoints for system %s: %s", systemSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		station := stations.Station{ShipSymbol: shipSymbol, WaypointSymbol: waypointSymbol}
+		found := false
+		for _, waypoint := range waypoints {
+			if waypoint.Symbol != waypointSymbol {
+				continue
+			}
+			found = true
+			for _, trait := range waypoint.Traits {
+				switch trait.Symbol {
+				case "MARKETPLACE":
+					station.Market = true
+				case "SHIPYARD":
+					station.Shipyard = true
+				}
+			}
+		}
+		if !found {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Waypoint %s not found in system %s", waypointSymbol, systemSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if !station.Market && !station.Shipyard {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s has no marketplace or shipyard, so there is nothing for a probe to watch", waypointSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Set off unless the probe is already there or on its way
+		arrival := ""
+		if ship.Nav.WaypointSymbol != waypointSymbol {
+			if ship.Nav.Status == "DOCKED" {
+				if _, err := c.OrbitShip(shipSymbol); err != nil {
+					ctxLogger.Error("Failed to orbit %s: %v", shipSymbol, err)
+					return &mcp.CallToolResult{
+						Content: []mcp.Content{
+							mcp.NewTextContent(fmt.Sprintf("❌ Failed to undock %s: %s", shipSymbol, err.Error())),
+						},
+						IsError: true,
+					}, nil
+				}
+			}
+			nav, err := c.NavigateShip(shipSymbol, waypointSymbol)
+			if err != nil {
+				ctxLogger.Error("Failed to navigate %s to %s: %v", shipSymbol, waypointSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to send %s to %s: %s", shipSymbol, waypointSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			arrival = nav.Data.Nav.Route.Arrival
+		} else if ship.Nav.Status == "IN_TRANSIT" {
+			arrival = ship.Nav.Route.Arrival
+		}
+
+		station = t.poller.Station(station)
+
+		ctxLogger.ToolCall("deploy_probe", true)
+
+		var watching []string
+		if station.Market {
+			watching = append(watching, "market")
+		}
+		if station.Shipyard {
+			watching = append(watching, "shipyard")
+		}
+
+		result := map[string]interface{}{
+			"station": station,
+		}
+		textSummary := "## 🛰️ Probe Deployed\n\n"
+		textSummary += fmt.Sprintf("**Probe:** %s\n", shipSymbol)
+		textSummary += fmt.Sprintf("**Station:** %s (%s)\n", waypointSymbol, strings.Join(watching, " and "))
+		if arrival != "" {
+			result["arrival"] = arrival
+			textSummary += fmt.Sprintf("**Arrival:** %s\n", arrival)
+		} else {
+			textSummary += "**Status:** already on station\n"
+		}
+		textSummary += fmt.Sprintf("\nThe %s data is refreshed every %s once the probe is on station.", strings.Join(watching, " and "), stations.DefaultInterval)
+		if ship.Registration.Role != "SATELLITE" && ship.Frame.Symbol != "FRAME_PROBE" {
+			textSummary += fmt.Sprintf("\n\n⚠️ %s is a %s ship, not a probe; it stays tied up while stationed.", shipSymbol, ship.Registration.Role)
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
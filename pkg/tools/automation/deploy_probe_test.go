@@ -0,0 +1,79 @@
+package automation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/stations"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDeployProbeTool(t *testing.T) {
+	navigated := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/PROBE-1":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "PROBE-1", "registration": {"role": "SATELLITE"}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-HOME", "status": "IN_ORBIT"}}}`))
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-ROCK", "type": "ASTEROID", "systemSymbol": "X1-TEST", "x": 5, "y": 0},
+				{"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 10, "y": 0, "traits": [{"symbol": "MARKETPLACE"}, {"symbol": "SHIPYARD"}]}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		case "/my/ships/PROBE-1/navigate":
+			navigated = true
+			_, _ = w.Write([]byte(`{"data": {"fuel": {}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_TRANSIT", "route": {"arrival": "2030-01-01T00:00:00.000Z"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller := stations.NewPoller(ctx, c, logging.NewLogger(nil))
+	defer poller.Stop()
+	tool := NewDeployProbeTool(c, poller, logging.NewLogger(nil))
+
+	call := func(waypoint string) *mcp.CallToolResult {
+		result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "deploy_probe",
+				Arguments: map[string]interface{}{"probe_ship": "probe-1", "target_waypoint": waypoint},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return result
+	}
+
+	if result := call("X1-TEST-ROCK"); !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "no marketplace or shipyard") {
+		t.Errorf("Expected a waypoint without a market or shipyard to be rejected, got %v", result.Content)
+	}
+	if result := call("X1-OTHER-A1"); !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "jump_ship or warp_ship") {
+		t.Errorf("Expected a waypoint in another system to be rejected, got %v", result.Content)
+	}
+	if navigated || len(poller.List()) != 0 {
+		t.Fatalf("Expected rejected deployments to leave the probe alone")
+	}
+
+	result := call("X1-TEST-A1")
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	if !navigated {
+		t.Error("Expected the probe to be sent to its station")
+	}
+	list := poller.List()
+	if len(list) != 1 || list[0].WaypointSymbol != "X1-TEST-A1" || !list[0].Market || !list[0].Shipyard {
+		t.Errorf("Expected the probe to be stationed watching the market and shipyard, got %+v", list)
+	}
+}
@@ -0,0 +1,200 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxRescueMarkets caps how many of the nearest marketplaces are checked for fuel
+const maxRescueMarkets = 10
+
+// RescueShipTool drifts a ship that has run out of fuel to the nearest market selling it
+type RescueShipTool struct {
+	client  *client.Client
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewRescueShipTool creates a new rescue ship tool
+func NewRescueShipTool(client *client.Client, manager *tasks.Manager, logger *logging.Logger) *RescueShipTool {
+	return &RescueShipTool{
+		client:  client,
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *RescueShipTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "rescue_ship",
+		Description: "Rescue a ship stranded without fuel: finds the nearest marketplace in its system that sells fuel, switches to DRIFT (which needs almost no fuel), flies there, refuels, and switches back to the ship's previous flight mode. Runs as a background task reporting each step; drifting is slow, so track it with the spacetraders://tasks/list resource.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the stranded ship",
+				},
+				"market": map[string]interface{}{
+					"type":        "string",
+					"description": "Marketplace waypoint to refuel at (optional - defaults to the nearest one selling fuel)",
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: taskOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *RescueShipTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "rescue-ship-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		market := v.OptionalWaypoint("market")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		if ship.Fuel.Capacity == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s does not use fuel, so it cannot be stranded without it", shipSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		if market != "" && travel.SystemSymbol(market) != ship.Nav.SystemSymbol {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s is not in %s's system %s; a stranded ship can only drift within its system", market, shipSymbol, ship.Nav.SystemSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if market == "" {
+			market, err = nearestFuelMarket(c, ship)
+			if err != nil {
+				ctxLogger.Error("Failed to find fuel for %s: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ %s", err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+		}
+
+		// A ship already drifting has no faster mode to go back to that we know of
+		previousMode := ship.Nav.FlightMode
+		params := map[string]string{"market": market}
+		if previousMode != "" && previousMode != "DRIFT" {
+			params["flight_mode"] = previousMode
+		}
+
+		task, err := t.manager.Assign(shipSymbol, "rescue", params)
+		if err != nil {
+			ctxLogger.Error("Failed to start rescue: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to start rescue: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.ToolCall("rescue_ship", true)
+
+		textSummary := "## 🛟 Rescue Started\n\n"
+		textSummary += fmt.Sprintf("**Task:** %s\n", task.ID)
+		textSummary += fmt.Sprintf("**Ship:** %s at %s with %d/%d fuel\n", shipSymbol, ship.Nav.WaypointSymbol, ship.Fuel.Current, ship.Fuel.Capacity)
+		textSummary += fmt.Sprintf("**Refuel at:** %s\n\n", market)
+		textSummary += "**Plan:**\n"
+		step := 1
+		if ship.Nav.WaypointSymbol != market {
+			if previousMode != "DRIFT" {
+				textSummary += fmt.Sprintf("%d. Switch from %s to DRIFT\n", step, previousMode)
+				step++
+			}
+			textSummary += fmt.Sprintf("%d. Drift to %s", step, market)
+			if distance, _, err := travel.RouteDistance(c, ship.Nav.WaypointSymbol, market); err == nil {
+				textSummary += fmt.Sprintf(" (%.1f units, about %s)", distance, travel.TravelTime(distance, "DRIFT", ship.Engine.Speed))
+			}
+			textSummary += "\n"
+			step++
+		}
+		textSummary += fmt.Sprintf("%d. Dock and refuel\n", step)
+		if mode, ok := params["flight_mode"]; ok {
+			textSummary += fmt.Sprintf("%d. Switch back to %s\n", step+1, mode)
+		}
+		textSummary += "\nEach step is reported on the task in the spacetraders://tasks/list resource; stop it with cancel_task."
+
+		return utils.NewResult(textSummary, task), nil
+	}
+}
+
+// nearestFuelMarket returns the marketplace in the ship's system closest to it that sells fuel,
+// checking the nearest few markets
+func nearestFuelMarket(c *client.Client, ship *client.Ship) (string, error) {
+	waypoints, _, err := c.GetCachedSystemWaypoints(ship.Nav.SystemSymbol)
+	if err != nil {
+		return "", fmt.Errorf("failed to list waypoints in %s: %w", ship.Nav.SystemSymbol, err)
+	}
+	index := slices.IndexFunc(waypoints, func(w client.SystemWaypoint) bool { return w.Symbol == ship.Nav.WaypointSymbol })
+	if index < 0 {
+		return "", fmt.Errorf("waypoint %s not found in system %s", ship.Nav.WaypointSymbol, ship.Nav.SystemSymbol)
+	}
+	origin := waypoints[index]
+
+	var markets []client.SystemWaypoint
+	for _, waypoint := range waypoints {
+		if slices.ContainsFunc(waypoint.Traits, func(trait client.WaypointTrait) bool { return trait.Symbol == "MARKETPLACE" }) {
+			markets = append(markets, waypoint)
+		}
+	}
+	sort.SliceStable(markets, func(i, j int) bool {
+		return travel.Distance(origin.X, origin.Y, markets[i].X, markets[i].Y) < travel.Distance(origin.X, origin.Y, markets[j].X, markets[j].Y)
+	})
+
+	for i, waypoint := range markets {
+		if i == maxRescueMarkets {
+			break
+		}
+		market, err := c.GetMarket(ship.Nav.SystemSymbol, waypoint.Symbol)
+		if err != nil {
+			continue
+		}
+		for _, goods := range [][]client.TradeGood{market.Exports, market.Exchange} {
+			if slices.ContainsFunc(goods, func(good client.TradeGood) bool { return good.Symbol == "FUEL" }) {
+				return waypoint.Symbol, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no marketplace among the %d nearest to %s sells fuel; pass a market to drift to", min(len(markets), maxRescueMarkets), ship.Nav.WaypointSymbol)
+}
@@ -0,0 +1,81 @@
+package automation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestRescueShipTool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/HAULER-1":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "HAULER-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT", "flightMode": "BURN"}, "engine": {"speed": 30}, "fuel": {"current": 0, "capacity": 400}}}`))
+		case "/systems/X1-TEST":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST", "sectorSymbol": "X1", "type": "RED_STAR", "x": 0, "y": 0, "waypoints": [
+				{"symbol": "X1-TEST-A1", "type": "ASTEROID", "x": 0, "y": 0},
+				{"symbol": "X1-TEST-DRY", "type": "MOON", "x": 10, "y": 0},
+				{"symbol": "X1-TEST-FUEL", "type": "PLANET", "x": 30, "y": 40}
+			], "factions": []}}`))
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "type": "ASTEROID", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+				{"symbol": "X1-TEST-DRY", "type": "MOON", "systemSymbol": "X1-TEST", "x": 10, "y": 0, "traits": [{"symbol": "MARKETPLACE"}]},
+				{"symbol": "X1-TEST-FUEL", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 30, "y": 40, "traits": [{"symbol": "MARKETPLACE"}]}
+			], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-DRY/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-DRY", "exports": [], "imports": [{"symbol": "FUEL"}], "exchange": []}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-FUEL/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-FUEL", "exports": [], "imports": [], "exchange": [{"symbol": "FUEL"}]}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.NewLogger(nil)
+	// The task flies against an unreachable API so it stays idle while the test inspects it
+	manager := tasks.NewManager(ctx, client.NewClientWithBaseURL("test-token", "http://127.0.0.1:1"), logger)
+	tool := NewRescueShipTool(client.NewClientWithBaseURL("test-token", server.URL), manager, logger)
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "rescue_ship", Arguments: map[string]interface{}{"ship_symbol": "hauler-1"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected the rescue to start, got %v", result.Content)
+	}
+
+	task, active := manager.ActiveTask("HAULER-1")
+	if !active || task.Behavior != "rescue" || task.Params["market"] != "X1-TEST-FUEL" || task.Params["flight_mode"] != "BURN" {
+		t.Errorf("Expected a rescue to the market selling fuel that restores BURN, got %+v", task)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, expected := range []string{"1. Switch from BURN to DRIFT", "2. Drift to X1-TEST-FUEL (50.0 units, about 7m12s)", "3. Dock and refuel", "4. Switch back to BURN"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in the plan, got: %s", expected, text)
+		}
+	}
+
+	// A market in another system can't be drifted to
+	result, _ = tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "rescue_ship", Arguments: map[string]interface{}{"ship_symbol": "HAULER-1", "market": "X1-OTHER-A1"}},
+	})
+	if !result.IsError {
+		t.Error("Expected a market in another system to be rejected")
+	}
+}
@@ -0,0 +1,103 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxScanWaypoints caps how many markets one scan may visit
+const maxScanWaypoints = 50
+
+// ScanMarketsTool sends a ship through a list of marketplaces to record their prices
+type ScanMarketsTool struct {
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewScanMarketsTool creates a new scan markets along route tool
+func NewScanMarketsTool(manager *tasks.Manager, logger *logging.Logger) *ScanMarketsTool {
+	return &ScanMarketsTool{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *ScanMarketsTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "scan_markets_along_route",
+		Description: "Send a ship (typically a probe) through a list of marketplaces in order, docking at each and recording its prices in the price database. Runs as a background task that stops after the last market; stop it early with cancel_task.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to send",
+				},
+				"waypoints": map[string]interface{}{
+					"type":        "array",
+					"description": fmt.Sprintf("Marketplace waypoints to visit in order, all in the ship's system (e.g., ['X1-FM66-A1', 'X1-FM66-B2']); at most %d", maxScanWaypoints),
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"ship_symbol", "waypoints"},
+		},
+		OutputSchema: taskOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *ScanMarketsTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "scan-markets-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		var waypoints []string
+		for _, waypoint := range v.RequireWaypoints("waypoints") {
+			// Visiting the same market twice in a row records nothing new
+			if len(waypoints) == 0 || waypoints[len(waypoints)-1] != waypoint {
+				waypoints = append(waypoints, waypoint)
+			}
+		}
+		if len(waypoints) > maxScanWaypoints {
+			v.Fail("waypoints", fmt.Sprintf("lists %d waypoints", len(waypoints)), fmt.Sprintf("at most %d", maxScanWaypoints))
+		}
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		task, err := t.manager.Assign(shipSymbol, "market_scan", map[string]string{
+			"waypoints": strings.Join(waypoints, ","),
+		})
+		if err != nil {
+			ctxLogger.Error("Failed to start market scan: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to start market scan: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.ToolCall("scan_markets_along_route", true)
+
+		textSummary := "## 🛰️ Market Scan Started\n\n"
+		textSummary += fmt.Sprintf("**Task:** %s\n", task.ID)
+		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
+		textSummary += fmt.Sprintf("**Route:** %s\n", strings.Join(waypoints, " → "))
+		textSummary += "\nThe ship docks at each market and records its prices in the price database. Track progress with the spacetraders://tasks/list resource and stop early with cancel_task."
+
+		return utils.NewResult(textSummary, task), nil
+	}
+}
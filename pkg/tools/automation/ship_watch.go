@@ -0,0 +1,156 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipwatch"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WatchShipPriceTool waits for a ship type to sell at or below a target price
+type WatchShipPriceTool struct {
+	watcher *shipwatch.Watcher
+	logger  *logging.Logger
+}
+
+// NewWatchShipPriceTool creates a new ship price watch tool
+func NewWatchShipPriceTool(watcher *shipwatch.Watcher, logger *logging.Logger) *WatchShipPriceTool {
+	return &WatchShipPriceTool{
+		watcher: watcher,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *WatchShipPriceTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "watch_ship_price",
+		Description: fmt.Sprintf("Alert when a ship type sells at or below a target price. Every shipyard viewed with a ship present records its prices; shipyards listed in waypoints are also checked every %s while one of your ships is there, so station a probe at each with deploy_probe. Alerts go to the server log and the alert webhook, once each time the price drops to the target. Watching the same ship type at the same shipyards again changes the target. Watches last until the server stops.", shipwatch.DefaultInterval),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Ship type to watch (e.g., 'SHIP_MINING_DRONE')",
+				},
+				"target_price": map[string]interface{}{
+					"type":        "integer",
+					"description": "Alert when the ship sells for this many credits or less",
+					"minimum":     1,
+				},
+				"waypoints": map[string]interface{}{
+					"type":        "array",
+					"description": "Shipyards to watch and check on a schedule (optional - without them, any shipyard seen counts but none is checked)",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"ship_type", "target_price"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"watch": map[string]interface{}{"type": "object", "description": "The watch, with the best price seen so far"},
+		}, "watch"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *WatchShipPriceTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "watch-ship-price-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipType := v.RequireSymbol("ship_type", utils.ShipTypes)
+		targetPrice := v.RequireInt("target_price", 1)
+		waypoints := v.OptionalWaypoints("waypoints")
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		watch := t.watcher.Watch(shipType, targetPrice, waypoints)
+		ctxLogger.ToolCall("watch_ship_price", true)
+
+		where := "any shipyard seen"
+		if len(watch.Waypoints) > 0 {
+			where = strings.Join(watch.Waypoints, ", ")
+		}
+		summary := fmt.Sprintf("👀 Watch %s: alerting when %s sells for %d credits or less at %s.\n", watch.ID, watch.ShipType, watch.TargetPrice, where)
+		switch {
+		case watch.Best == nil:
+			summary += "No price has been seen for it yet.\n"
+		case watch.Best.PurchasePrice <= watch.TargetPrice:
+			summary += fmt.Sprintf("✅ Already there: %d credits at %s.\n", watch.Best.PurchasePrice, watch.Best.WaypointSymbol)
+		default:
+			summary += fmt.Sprintf("Best price so far: %d credits at %s.\n", watch.Best.PurchasePrice, watch.Best.WaypointSymbol)
+		}
+		if len(watch.Waypoints) > 0 {
+			summary += "Shipyards only show prices while one of your ships is there; keep a probe at each with deploy_probe.\n"
+		}
+
+		return utils.NewResult(summary, map[string]interface{}{"watch": watch}), nil
+	}
+}
+
+// UnwatchShipPriceTool stops a ship price watch
+type UnwatchShipPriceTool struct {
+	watcher *shipwatch.Watcher
+	logger  *logging.Logger
+}
+
+// NewUnwatchShipPriceTool creates a new tool to stop ship price watches
+func NewUnwatchShipPriceTool(watcher *shipwatch.Watcher, logger *logging.Logger) *UnwatchShipPriceTool {
+	return &UnwatchShipPriceTool{
+		watcher: watcher,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *UnwatchShipPriceTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "unwatch_ship_price",
+		Description: "Stop a ship price watch set with watch_ship_price. Prices keep being recorded.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"watch_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the watch (e.g., 'watch-1'), as listed by ship_price_history",
+				},
+			},
+			Required: []string{"watch_id"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"watch_id": map[string]interface{}{"type": "string"},
+			"removed":  map[string]interface{}{"type": "boolean"},
+		}, "watch_id", "removed"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *UnwatchShipPriceTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "unwatch-ship-price-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		id := strings.ToLower(v.RequireString("watch_id"))
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		removed := t.watcher.Unwatch(id)
+		ctxLogger.ToolCall("unwatch_ship_price", true)
+
+		summary := fmt.Sprintf("🛑 Stopped watch %s", id)
+		if !removed {
+			summary = fmt.Sprintf("No watch %s; it may already have been stopped", id)
+		}
+		return utils.NewResult(summary, map[string]interface{}{"watch_id": id, "removed": removed}), nil
+	}
+}
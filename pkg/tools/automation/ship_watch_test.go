@@ -0,0 +1,49 @@
+package automation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipwatch"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestWatchShipPriceTool(t *testing.T) {
+	watcher := shipwatch.New(context.Background(), nil, logging.NewLogger(nil))
+	watcher.Record("X1-TEST-A1", time.Now(), []client.ShipyardShip{{Type: "SHIP_MINING_DRONE", PurchasePrice: 52000, Supply: "LIMITED"}})
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return result
+	}
+
+	watch := NewWatchShipPriceTool(watcher, logging.NewLogger(nil)).Handler()
+	result := call(watch, map[string]interface{}{"ship_type": "ship mining drone", "target_price": float64(45000), "waypoints": []interface{}{"x1-test-a1"}})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+	text, _ := mcp.AsTextContent(result.Content[0])
+	if !strings.Contains(text.Text, "SHIP_MINING_DRONE sells for 45000 credits or less at X1-TEST-A1") || !strings.Contains(text.Text, "Best price so far: 52000") {
+		t.Errorf("Unexpected watch summary: %s", text.Text)
+	}
+
+	if result := call(watch, map[string]interface{}{"ship_type": "SHIP_SPACESHIP", "target_price": float64(1)}); !result.IsError {
+		t.Error("Expected an error for an unknown ship type")
+	}
+
+	unwatch := NewUnwatchShipPriceTool(watcher, logging.NewLogger(nil)).Handler()
+	id := watcher.Watches()[0].ID
+	result = call(unwatch, map[string]interface{}{"watch_id": strings.ToUpper(id)})
+	if data, _ := result.StructuredContent.(map[string]interface{}); data["removed"] != true || len(watcher.Watches()) != 0 {
+		t.Errorf("Expected %s to be removed, got %v", id, result.StructuredContent)
+	}
+}
@@ -0,0 +1,123 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultMinMargin is the smallest per-unit profit a trade loop accepts when none is given
+const defaultMinMargin = 1
+
+// StartTradeLoopTool starts an automated buy/haul/sell loop between two markets
+type StartTradeLoopTool struct {
+	manager *tasks.Manager
+	logger  *logging.Logger
+}
+
+// NewStartTradeLoopTool creates a new start trade loop tool
+func NewStartTradeLoopTool(manager *tasks.Manager, logger *logging.Logger) *StartTradeLoopTool {
+	return &StartTradeLoopTool{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *StartTradeLoopTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "start_trade_loop",
+		Description: "Continuously buy a good at one market, haul it, and sell it at another, refueling along the way. The loop stops by itself when the profit per unit drops below min_margin. Runs as a background task; stop it early with cancel_task.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to run the trade loop",
+				},
+				"buy_waypoint": map[string]interface{}{
+					"type":        "string",
+					"description": "Waypoint of the market to buy at (e.g., X1-FM66-A1)",
+				},
+				"sell_waypoint": map[string]interface{}{
+					"type":        "string",
+					"description": "Waypoint of the market to sell at (e.g., X1-FM66-B2)",
+				},
+				"good": map[string]interface{}{
+					"type":        "string",
+					"description": "Trade symbol of the good to trade (e.g., IRON_ORE)",
+				},
+				"min_margin": map[string]interface{}{
+					"type":        "number",
+					"description": "Stop when sell price minus buy price per unit falls below this many credits (default 1)",
+				},
+				"units": map[string]interface{}{
+					"type":        "number",
+					"description": "Units to carry per trip (optional - defaults to a full cargo hold)",
+				},
+			},
+			Required: []string{"ship_symbol", "buy_waypoint", "sell_waypoint", "good"},
+		},
+		OutputSchema: taskOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *StartTradeLoopTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "start-trade-loop-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		buyWaypoint := v.RequireWaypoint("buy_waypoint")
+		sellWaypoint := v.RequireWaypoint("sell_waypoint")
+		good := v.RequireSymbol("good", utils.TradeSymbols)
+		minMargin := v.OptionalInt("min_margin", defaultMinMargin, math.MinInt, math.MaxInt)
+		units := v.OptionalInt("units", 0, 1, math.MaxInt)
+		if buyWaypoint != "" && buyWaypoint == sellWaypoint {
+			v.Fail("sell_waypoint", "is the same market as buy_waypoint", "a different market to sell at")
+		}
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		params := map[string]string{
+			"good":       good,
+			"buy_at":     buyWaypoint,
+			"sell_at":    sellWaypoint,
+			"min_margin": strconv.Itoa(minMargin),
+		}
+		if units > 0 {
+			params["units"] = strconv.Itoa(units)
+		}
+
+		task, err := t.manager.Assign(shipSymbol, "trade_loop", params)
+		if err != nil {
+			ctxLogger.Error("Failed to start trade loop: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to start trade loop: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.ToolCall("start_trade_loop", true)
+
+		textSummary := "## 🔁 Trade Loop Started\n\n"
+		textSummary += fmt.Sprintf("**Task:** %s\n", task.ID)
+		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
+		textSummary += fmt.Sprintf("**Route:** buy %s at %s → sell at %s\n", good, buyWaypoint, sellWaypoint)
+		textSummary += fmt.Sprintf("**Stops when margin drops below:** %d credits/unit\n", minMargin)
+		textSummary += "\nTrack progress with the spacetraders://tasks/list resource and stop early with cancel_task."
+
+		return utils.NewResult(textSummary, task), nil
+	}
+}
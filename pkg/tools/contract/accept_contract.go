@@ -2,9 +2,9 @@ package contract
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -36,6 +36,12 @@ func (t *AcceptContractTool) Tool() mcp.Tool {
 			},
 			Required: []string{"contract_id"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":  map[string]interface{}{"type": "boolean"},
+			"message":  map[string]interface{}{"type": "string"},
+			"contract": map[string]interface{}{"type": "object", "description": "The accepted contract and its terms"},
+			"agent":    map[string]interface{}{"type": "object", "description": "Agent after the advance payment"},
+		}, "success", "message", "contract", "agent"),
 	}
 }
 
@@ -63,7 +69,7 @@ func (t *AcceptContractTool) Handler() func(ctx context.Context, request mcp.Cal
 		}
 
 		// Accept the contract
-		resp, err := t.client.AcceptContract(contractID)
+		resp, err := t.client.WithContext(ctx).AcceptContract(contractID)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -102,20 +108,12 @@ func (t *AcceptContractTool) Handler() func(ctx context.Context, request mcp.Cal
 			},
 		}
 
-		resultJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return &mcp.CallToolResult{
-				IsError: true,
-				Content: []mcp.Content{
-					mcp.NewTextContent(fmt.Sprintf("Failed to format response: %v", err)),
-				},
-			}, nil
-		}
+		textSummary := fmt.Sprintf("✅ **Accepted contract %s**\n\n", contractID)
+		textSummary += fmt.Sprintf("**Faction:** %s\n", resp.Data.Contract.FactionSymbol)
+		textSummary += fmt.Sprintf("**Paid on accept:** %d credits (%d more on fulfillment)\n", resp.Data.Contract.Terms.Payment.OnAccepted, resp.Data.Contract.Terms.Payment.OnFulfilled)
+		textSummary += fmt.Sprintf("**Deadline:** %s\n", resp.Data.Contract.Terms.Deadline)
+		textSummary += fmt.Sprintf("**Credits:** %d\n", resp.Data.Agent.Credits)
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(string(resultJSON)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
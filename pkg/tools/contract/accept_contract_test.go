@@ -150,20 +150,27 @@ func TestAcceptContractTool_Handler_Success(t *testing.T) {
 		t.Fatalf("Handler returned error result: %v", result.Content)
 	}
 
-	if len(result.Content) != 1 {
-		t.Fatalf("Expected 1 content item, got %d", len(result.Content))
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected a summary and a JSON block, got %d content items", len(result.Content))
 	}
 
 	textContent, ok := mcp.AsTextContent(result.Content[0])
 	if !ok {
 		t.Fatalf("Expected TextContent, got %T", result.Content[0])
 	}
+	if !contains(textContent.Text, "Accepted contract test-contract-123") {
+		t.Errorf("Expected summary to name the contract, got '%s'", textContent.Text)
+	}
 
-	// Parse the JSON response
+	// Parse the structured response the way a client would receive it
+	structured, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatalf("Failed to marshal structured content: %v", err)
+	}
 	var response map[string]interface{}
-	err = json.Unmarshal([]byte(textContent.Text), &response)
+	err = json.Unmarshal(structured, &response)
 	if err != nil {
-		t.Fatalf("Failed to parse JSON response: %v", err)
+		t.Fatalf("Failed to parse structured response: %v", err)
 	}
 
 	// Verify response structure
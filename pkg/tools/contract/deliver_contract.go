@@ -3,8 +3,6 @@ package contract
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
@@ -18,6 +16,8 @@ import (
 type DeliverContractTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState bool
 }
 
 // NewDeliverContractTool creates a new deliver contract tool
@@ -28,6 +28,12 @@ func NewDeliverContractTool(client *client.Client, logger *logging.Logger) *Deli
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *DeliverContractTool) WithAutoCorrectState(enabled bool) *DeliverContractTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *DeliverContractTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -53,9 +59,21 @@ func (t *DeliverContractTool) Tool() mcp.Tool {
 					"description": "Number of units to deliver",
 					"minimum":     1,
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"contract_id", "ship_symbol", "trade_symbol", "units"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"message":          map[string]interface{}{"type": "string"},
+			"contract_id":      map[string]interface{}{"type": "string"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"trade_symbol":     map[string]interface{}{"type": "string"},
+			"units":            map[string]interface{}{"type": "integer"},
+			"contract":         map[string]interface{}{"type": "object", "description": "Contract delivery progress"},
+			"cargo":            map[string]interface{}{"type": "object", "description": "Ship cargo after the delivery"},
+			"state_correction": map[string]interface{}{"type": "string", "description": "Ship state fixed up before delivering, if any"},
+		}, "success", "message", "contract_id", "ship_symbol", "trade_symbol", "units", "contract", "cargo"),
 	}
 }
 
@@ -66,93 +84,37 @@ func (t *DeliverContractTool) Handler() func(ctx context.Context, request mcp.Ca
 		ctxLogger := t.logger.WithContext(ctx, "deliver-contract-tool")
 		ctxLogger.Debug("Processing contract delivery request")
 
-		// Parse arguments
-		contractID := ""
-		shipSymbol := ""
-		tradeSymbol := ""
-		units := 0
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required arguments: contract_id, ship_symbol, trade_symbol, units"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if cid, exists := argsMap["contract_id"]; exists {
-				if cidStr, ok := cid.(string); ok {
-					contractID = strings.TrimSpace(cidStr)
-				}
-			}
-			if ss, exists := argsMap["ship_symbol"]; exists {
-				if ssStr, ok := ss.(string); ok {
-					shipSymbol = strings.TrimSpace(ssStr)
-				}
-			}
-			if ts, exists := argsMap["trade_symbol"]; exists {
-				if tsStr, ok := ts.(string); ok {
-					tradeSymbol = strings.TrimSpace(tsStr)
-				}
-			}
-			if u, exists := argsMap["units"]; exists {
-				switch v := u.(type) {
-				case int:
-					units = v
-				case float64:
-					units = int(v)
-				case string:
-					if parsed, err := strconv.Atoi(v); err == nil {
-						units = parsed
-					}
-				}
-			}
-		}
-
-		// Validate required arguments
-		if contractID == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ contract_id is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if shipSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if tradeSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ trade_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if units <= 0 {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ units must be a positive integer"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		contractID := v.RequireString("contract_id")
+		shipSymbol := v.RequireShip("ship_symbol")
+		tradeSymbol := v.RequireSymbol("trade_symbol", utils.TradeSymbols)
+		units := v.RequireInt("units", 1)
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			return result, nil
 		}
 
 		ctxLogger.Info("Attempting to deliver %d units of %s from ship %s to contract %s", units, tradeSymbol, shipSymbol, contractID)
 
 		// Deliver goods to contract
 		start := time.Now()
-		resp, err := t.client.DeliverContract(contractID, shipSymbol, tradeSymbol, units)
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusDocked)
+			if err != nil {
+				ctxLogger.Error("Failed to dock ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
+		}
+
+		resp, err := t.client.WithContext(ctx).DeliverContract(contractID, shipSymbol, tradeSymbol, units)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -222,11 +184,17 @@ func (t *DeliverContractTool) Handler() func(ctx context.Context, request mcp.Ca
 				"inventory": cargoItems,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
 
 		jsonData := utils.FormatJSON(result)
 
 		// Create formatted text summary
 		textSummary := "📦 **Contract Goods Delivered Successfully!**\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Contract ID:** %s\n", contractID)
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Delivered:** %d units of %s\n", units, tradeSymbol)
@@ -314,11 +282,6 @@ func (t *DeliverContractTool) Handler() func(ctx context.Context, request mcp.Ca
 		ctxLogger.ToolCall("deliver_contract", true)
 		ctxLogger.Debug("Deliver contract response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
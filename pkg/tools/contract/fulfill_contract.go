@@ -3,7 +3,6 @@ package contract
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
@@ -42,6 +41,13 @@ func (t *FulfillContractTool) Tool() mcp.Tool {
 			},
 			Required: []string{"contract_id"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":     map[string]interface{}{"type": "boolean"},
+			"message":     map[string]interface{}{"type": "string"},
+			"contract_id": map[string]interface{}{"type": "string"},
+			"contract":    map[string]interface{}{"type": "object"},
+			"agent":       map[string]interface{}{"type": "object", "description": "Agent after payment"},
+		}, "success", "message", "contract_id", "contract", "agent"),
 	}
 }
 
@@ -52,40 +58,18 @@ func (t *FulfillContractTool) Handler() func(ctx context.Context, request mcp.Ca
 		ctxLogger := t.logger.WithContext(ctx, "fulfill-contract-tool")
 		ctxLogger.Debug("Processing contract fulfillment request")
 
-		// Parse arguments
-		contractID := ""
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required argument: contract_id"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if cid, exists := argsMap["contract_id"]; exists {
-				if cidStr, ok := cid.(string); ok {
-					contractID = strings.TrimSpace(cidStr)
-				}
-			}
-		}
-
-		if contractID == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ contract_id is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		contractID := v.RequireString("contract_id")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		ctxLogger.Info("Attempting to fulfill contract %s", contractID)
 
 		// Fulfill the contract
 		start := time.Now()
-		resp, err := t.client.FulfillContract(contractID)
+		resp, err := t.client.WithContext(ctx).FulfillContract(contractID)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -199,11 +183,6 @@ func (t *FulfillContractTool) Handler() func(ctx context.Context, request mcp.Ca
 		ctxLogger.ToolCall("fulfill_contract", true)
 		ctxLogger.Debug("Fulfill contract response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,36 @@
+package exploration
+
+import (
+	"fmt"
+	"time"
+
+	"spacetraders-mcp/pkg/cooldowns"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// coolingDown returns an error result without calling the API when the tracker knows the ship is
+// still on cooldown, since the scan would be rejected anyway. It returns nil when the ship is ready
+// or no tracker is set.
+func coolingDown(tracker *cooldowns.Tracker, shipSymbol string) *mcp.CallToolResult {
+	if tracker == nil {
+		return nil
+	}
+	now := time.Now()
+	cooldown, ok := tracker.Get(shipSymbol, now)
+	if !ok {
+		return nil
+	}
+
+	cause := ""
+	if cooldown.Action != "" {
+		cause = fmt.Sprintf(" after its last %s", cooldown.Action)
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(fmt.Sprintf("⏳ %s is on cooldown%s for another %s (until %s), so it can't scan yet. Try again then, or scan with another ship.",
+				shipSymbol, cause, cooldown.Remaining(now).Round(time.Second), cooldown.Expiration.Format(time.RFC3339))),
+		},
+		IsError: true,
+	}
+}
@@ -45,6 +45,15 @@ func (t *CurrentLocationTool) Tool() mcp.Tool {
 				},
 			},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"total_ships":       map[string]interface{}{"type": "integer"},
+			"systems_occupied":  map[string]interface{}{"type": "integer"},
+			"ship_locations":    map[string]interface{}{"type": "array", "description": "Location and status of each ship"},
+			"system_summary":    map[string]interface{}{"type": "object", "description": "Ships per system"},
+			"status_summary":    map[string]interface{}{"type": "object", "description": "Ship count per navigation status"},
+			"recommendations":   map[string]interface{}{"type": "array"},
+			"nearby_facilities": map[string]interface{}{"type": "object", "description": "Facilities near each ship, by ship"},
+		}, "total_ships", "systems_occupied", "ship_locations", "system_summary", "status_summary", "recommendations"),
 	}
 }
 
@@ -53,28 +62,18 @@ func (t *CurrentLocationTool) Handler() func(ctx context.Context, request mcp.Ca
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "current-location-tool")
 
-		// Extract parameters
-		includeNearby := true
-		var specificShip string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["include_nearby"]; exists {
-					if b, ok := val.(bool); ok {
-						includeNearby = b
-					}
-				}
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						specificShip = strings.ToUpper(s)
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		includeNearby := v.OptionalBool("include_nearby", true)
+		specificShip := v.OptionalShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		contextLogger.Info("Analyzing current ship locations")
 
 		// Get all ships
-		ships, err := t.client.GetAllShips()
+		ships, err := t.client.WithContext(ctx).GetAllShips()
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to get ships: %v", err))
 			return &mcp.CallToolResult{
@@ -107,7 +106,7 @@ func (t *CurrentLocationTool) Handler() func(ctx context.Context, request mcp.Ca
 		}
 
 		// Analyze locations
-		locationAnalysis := t.analyzeShipLocations(shipsToAnalyze, includeNearby)
+		locationAnalysis := t.analyzeShipLocations(ctx, shipsToAnalyze, includeNearby)
 
 		contextLogger.ToolCall("current_location", true)
 		contextLogger.Info(fmt.Sprintf("Analyzed %d ships across %d systems", len(shipsToAnalyze), len(locationAnalysis.SystemSummary)))
@@ -129,12 +128,7 @@ func (t *CurrentLocationTool) Handler() func(ctx context.Context, request mcp.Ca
 		// Create text summary
 		textSummary := t.generateLocationSummary(locationAnalysis, includeNearby, specificShip)
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
 
@@ -148,7 +142,7 @@ type LocationAnalysis struct {
 }
 
 // analyzeShipLocations performs comprehensive analysis of ship locations
-func (t *CurrentLocationTool) analyzeShipLocations(ships []client.Ship, includeNearby bool) *LocationAnalysis {
+func (t *CurrentLocationTool) analyzeShipLocations(ctx context.Context, ships []client.Ship, includeNearby bool) *LocationAnalysis {
 	analysis := &LocationAnalysis{
 		ShipLocations:    []map[string]interface{}{},
 		SystemSummary:    make(map[string]map[string]interface{}),
@@ -210,7 +204,7 @@ func (t *CurrentLocationTool) analyzeShipLocations(ships []client.Ship, includeN
 	// Get nearby facilities for each system
 	if includeNearby {
 		for system := range systemsToCheck {
-			facilities := t.getNearbyFacilities(system)
+			facilities := t.getNearbyFacilities(ctx, system)
 			if len(facilities) > 0 {
 				analysis.NearbyFacilities[system] = facilities
 			}
@@ -224,8 +218,8 @@ func (t *CurrentLocationTool) analyzeShipLocations(ships []client.Ship, includeN
 }
 
 // getNearbyFacilities gets key facilities in a system
-func (t *CurrentLocationTool) getNearbyFacilities(systemSymbol string) []map[string]interface{} {
-	waypoints, err := t.client.GetAllSystemWaypoints(systemSymbol)
+func (t *CurrentLocationTool) getNearbyFacilities(ctx context.Context, systemSymbol string) []map[string]interface{} {
+	waypoints, err := t.client.WithContext(ctx).GetAllSystemWaypoints(systemSymbol)
 	if err != nil {
 		return []map[string]interface{}{}
 	}
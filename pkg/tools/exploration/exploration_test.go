@@ -7,8 +7,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/explorer"
 	"spacetraders-mcp/pkg/logging"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -500,3 +503,308 @@ func TestCurrentLocationTool_Handler_Success(t *testing.T) {
 		t.Errorf("Expected text to contain system symbol, got: %s", textContent.Text)
 	}
 }
+
+func TestSuggestTargetsTool_Handler_UnchartedFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/PROBE-1":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "PROBE-1", "nav": {
+				"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT",
+				"route": {"destination": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0}}
+			}}}`))
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0, "traits": [{"symbol": "MARKETPLACE", "name": "", "description": ""}]},
+				{"symbol": "X1-TEST-B2", "type": "MOON", "systemSymbol": "X1-TEST", "x": 30, "y": 40, "traits": [{"symbol": "UNCHARTED", "name": "", "description": ""}]}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	tracker, err := explorer.Open("")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	c.AddObserver(tracker.Observe)
+
+	tool := NewSuggestTargetsTool(c, tracker, logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "suggest_exploration_targets",
+			Arguments: map[string]interface{}{"ship_symbol": "probe-1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	uncharted := strings.Index(text, "**X1-TEST-B2** (MOON) - uncharted, 50 units away")
+	market := strings.Index(text, "**X1-TEST-A1** (PLANET) - marketplace not visited yet")
+	if uncharted < 0 || market < 0 || uncharted > market {
+		t.Errorf("Expected the uncharted moon before the unvisited marketplace, got %q", text)
+	}
+}
+
+func TestFindByCapabilityTool_Handler_WhereToSell(t *testing.T) {
+	marketFetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 0, "y": 0, "orbitals": [], "traits": [], "isUnderConstruction": false},
+				{"symbol": "X1-TEST-FAR", "systemSymbol": "X1-TEST", "type": "MOON", "x": 100, "y": 0, "orbitals": [], "isUnderConstruction": false,
+					"traits": [{"symbol": "MARKETPLACE", "name": "Marketplace", "description": ""}]},
+				{"symbol": "X1-TEST-NEAR", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 10, "y": 0, "orbitals": [], "isUnderConstruction": false,
+					"traits": [{"symbol": "MARKETPLACE", "name": "Marketplace", "description": ""}]},
+				{"symbol": "X1-TEST-OTHER", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 5, "y": 0, "orbitals": [], "isUnderConstruction": false,
+					"traits": [{"symbol": "MARKETPLACE", "name": "Marketplace", "description": ""}]}
+			], "meta": {"total": 4, "page": 1, "limit": 20}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-FAR/market":
+			marketFetches++
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-FAR", "imports": [{"symbol": "PRECIOUS_STONES", "name": "Precious Stones", "description": ""}], "exports": [], "exchange": []}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-NEAR/market":
+			marketFetches++
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-NEAR", "imports": [], "exports": [], "exchange": [{"symbol": "PRECIOUS_STONES", "name": "Precious Stones", "description": ""}]}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-OTHER/market":
+			marketFetches++
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-OTHER", "imports": [{"symbol": "FUEL", "name": "Fuel", "description": ""}], "exports": [], "exchange": []}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewFindByCapabilityTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "find_waypoints_by_capability",
+			Arguments: map[string]interface{}{
+				"near": "x1-test-a1",
+				"buys": "precious stones",
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := tool.Handler()(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("Expected success, got error: %v", result.Content)
+		}
+
+		text, _ := mcp.AsTextContent(result.Content[0])
+		near := strings.Index(text.Text, "X1-TEST-NEAR")
+		far := strings.Index(text.Text, "X1-TEST-FAR")
+		if near == -1 || far == -1 || near > far {
+			t.Errorf("Expected NEAR listed before FAR, got: %s", text.Text)
+		}
+		if strings.Contains(text.Text, "X1-TEST-OTHER") || strings.Contains(text.Text, "X1-TEST-A1 (") {
+			t.Errorf("Expected only markets buying PRECIOUS_STONES, got: %s", text.Text)
+		}
+		if !strings.Contains(text.Text, "buys PRECIOUS_STONES as exchange") || !strings.Contains(text.Text, "buys PRECIOUS_STONES as import") {
+			t.Errorf("Expected the trade role of each market, got: %s", text.Text)
+		}
+	}
+
+	if marketFetches != 3 {
+		t.Errorf("Expected each market fetched once and then cached, got %d fetches", marketFetches)
+	}
+}
+
+func TestFindByCapabilityTool_Handler_RequiresCapability(t *testing.T) {
+	tool := NewFindByCapabilityTool(client.NewClient("test-token"), logging.NewLogger(nil))
+
+	for _, args := range []map[string]interface{}{
+		{"near": "X1-TEST"},
+		{"near": "X1-TEST", "buys": "NOT_A_GOOD"},
+		{"buys": "FUEL"},
+	} {
+		result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "find_waypoints_by_capability", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("Expected an error for arguments %v", args)
+		}
+	}
+}
+
+func TestScanWaypointsTool_Handler_WaitsForCooldown(t *testing.T) {
+	scans := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/my/ships/PROBE-1/scan/waypoints" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		scans++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data": {
+			"cooldown": {"shipSymbol": "PROBE-1", "totalSeconds": 60, "remainingSeconds": 60, "expiration": "` + time.Now().Add(time.Minute).UTC().Format(time.RFC3339) + `"},
+			"waypoints": []
+		}}`))
+	}))
+	defer server.Close()
+
+	apiClient := client.NewClientWithBaseURL("test-token", server.URL)
+	tracker := cooldowns.NewTracker()
+	apiClient.AddObserver(tracker.Observe)
+	tool := NewScanWaypointsTool(apiClient, logging.NewLogger(nil)).WithCooldowns(tracker)
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "scan_waypoints", Arguments: map[string]interface{}{"ship_symbol": "PROBE-1"}},
+	}
+	first, err := tool.Handler()(context.Background(), request)
+	if err != nil || first.IsError {
+		t.Fatalf("Expected the first scan to succeed, got %v %v", first, err)
+	}
+
+	second, err := tool.Handler()(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !second.IsError || scans != 1 {
+		t.Fatalf("Expected the second scan to be refused without an API call, got %d scans and %v", scans, second.Content)
+	}
+	if text := second.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "waypoint_scan") {
+		t.Errorf("Expected the refusal to say what started the cooldown, got %q", text)
+	}
+}
+
+func TestSearchUniverseTool_Handler_FetchesReferenceSystem(t *testing.T) {
+	fetched := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systems/X1-HOME":
+			fetched++
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-HOME", "sectorSymbol": "X1", "type": "ORANGE_STAR", "x": 0, "y": 0, "waypoints": [], "factions": []}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	tracker, err := explorer.Open("")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	c.AddObserver(tracker.Observe)
+	tracker.Observe(client.Observation{Kind: client.ObservedSystemScan, ObservedAt: time.Now(), ScannedSystems: []client.ScannedSystem{
+		{Symbol: "X1-TEST", Type: "RED_STAR", X: 30, Y: 40},
+	}})
+	tracker.Observe(client.Observation{Kind: client.ObservedWaypointScan, ObservedAt: time.Now(), ScannedWaypoints: []client.ScannedWaypoint{
+		{Symbol: "X1-TEST-A1", SystemSymbol: "X1-TEST", Type: "PLANET", Traits: []client.WaypointTrait{{Symbol: "SHIPYARD"}}},
+	}})
+	tracker.Observe(client.Observation{Kind: client.ObservedShipyard, ObservedAt: time.Now(), Shipyard: &client.Shipyard{
+		Symbol: "X1-TEST-A1", ShipTypes: []client.ShipyardShipType{{Type: "SHIP_MINING_DRONE"}},
+	}})
+
+	tool := NewSearchUniverseTool(c, tracker, logging.NewLogger(nil))
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "search_universe", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]interface{}{"sells_ship": "ship mining drone", "near_system": "x1-home", "max_distance": float64(60)})
+	if result.IsError || fetched != 1 {
+		t.Fatalf("Expected the reference system to be fetched once, got %v after %d fetches", result.Content, fetched)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "**X1-TEST-A1** (PLANET) - SHIPYARD - sells SHIP_MINING_DRONE, 50 units away") {
+		t.Errorf("Expected the shipyard 50 units away, got %q", text)
+	}
+
+	// The fetched system is remembered, so the next search makes no requests
+	if result := call(map[string]interface{}{"near_system": "X1-HOME", "max_distance": float64(40)}); result.IsError || fetched != 1 {
+		t.Errorf("Expected a search from the remembered system, got %v after %d fetches", result.Content, fetched)
+	}
+
+	if result := call(map[string]interface{}{"system_type": "PURPLE_STAR"}); !result.IsError {
+		t.Error("Expected an invalid system type to be rejected")
+	}
+}
+
+func TestHomeSystemTool_Handler_MapsHeadquartersSystem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/agent":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "TEST_AGENT", "headquarters": "X1-HOME-A1", "credits": 1000, "startingFaction": "COSMIC"}}`))
+		case "/systems/X1-HOME/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-HOME-A1", "type": "PLANET", "systemSymbol": "X1-HOME", "x": 0, "y": 0, "traits": [{"symbol": "MARKETPLACE"}, {"symbol": "SHIPYARD"}]},
+				{"symbol": "X1-HOME-B2", "type": "FUEL_STATION", "systemSymbol": "X1-HOME", "x": 30, "y": 40, "traits": [{"symbol": "MARKETPLACE"}]},
+				{"symbol": "X1-HOME-C3", "type": "ASTEROID", "systemSymbol": "X1-HOME", "x": 3, "y": 4, "traits": [{"symbol": "COMMON_METAL_DEPOSITS"}]},
+				{"symbol": "X1-HOME-D4", "type": "ENGINEERED_ASTEROID", "systemSymbol": "X1-HOME", "x": 33, "y": 40, "traits": [{"symbol": "MINERAL_DEPOSITS"}]},
+				{"symbol": "X1-HOME-I5", "type": "JUMP_GATE", "systemSymbol": "X1-HOME", "x": -60, "y": 80}
+			], "meta": {"total": 5, "page": 1, "limit": 20}}`))
+		case "/systems/X1-HOME/waypoints/X1-HOME-A1/shipyard":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-HOME-A1", "shipTypes": [{"type": "SHIP_PROBE"}, {"type": "SHIP_MINING_DRONE"}]}}`))
+		case "/systems/X1-HOME/waypoints/X1-HOME-A1/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-HOME-A1", "exports": [{"symbol": "IRON"}], "imports": [{"symbol": "IRON_ORE"}], "exchange": []}}`))
+		case "/systems/X1-HOME/waypoints/X1-HOME-B2/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-HOME-B2", "exports": [], "imports": [], "exchange": [{"symbol": "FUEL"}]}}`))
+		case "/systems/X1-HOME/waypoints/X1-HOME-I5/jump-gate":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-HOME-I5", "connections": ["X1-NEXT-I1"]}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewHomeSystemTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "analyze_home_system"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("Expected success, got %v (%v)", result.Content, err)
+	}
+
+	data := result.StructuredContent.(map[string]interface{})
+	if data["system_symbol"] != "X1-HOME" || data["total_waypoints"] != 5 {
+		t.Errorf("Expected the five waypoints of X1-HOME, got %v with %v", data["system_symbol"], data["total_waypoints"])
+	}
+	asteroids := data["asteroids"].([]homeWaypoint)
+	if len(asteroids) != 2 || asteroids[0].Symbol != "X1-HOME-C3" || asteroids[0].DistanceFromHQ != 5 || asteroids[0].Deposits[0] != "COMMON_METAL_DEPOSITS" {
+		t.Errorf("Expected both asteroids nearest headquarters first with their deposits, got %+v", asteroids)
+	}
+	fuel := data["fuel_stations"].([]homeWaypoint)
+	if len(fuel) != 1 || fuel[0].Symbol != "X1-HOME-B2" {
+		t.Errorf("Expected only the fuel station to sell fuel, got %+v", fuel)
+	}
+	if gate := data["jump_gate"].(*homeWaypoint); gate.Connections[0] != "X1-NEXT-I1" {
+		t.Errorf("Expected the jump gate's connections, got %+v", gate)
+	}
+
+	// The engineered asteroid wins over the one closer to headquarters, and sells at the
+	// marketplace buying ore rather than the nearer fuel station
+	highlights := data["highlights"].(homeHighlights)
+	if highlights.NearestFuel != "X1-HOME-B2" || highlights.MiningAsteroid != "X1-HOME-D4" || highlights.MiningMarket != "X1-HOME-A1" {
+		t.Errorf("Expected fuel at B2 and mining D4 selling at A1, got %+v", highlights)
+	}
+}
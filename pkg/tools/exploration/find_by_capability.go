@@ -0,0 +1,315 @@
+package exploration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultCapabilityLimit = 10
+	maxCapabilityLimit     = 50
+)
+
+// FindByCapabilityTool answers "where can I do X near Y" by combining waypoint traits, market trade lists and distance
+type FindByCapabilityTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewFindByCapabilityTool creates a new capability search tool
+func NewFindByCapabilityTool(client *client.Client, logger *logging.Logger) *FindByCapabilityTool {
+	return &FindByCapabilityTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *FindByCapabilityTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "find_waypoints_by_capability",
+		Description: "Find waypoints in a system that can do something, nearest first: where a good can be sold (buys) or bought (sells), which waypoints have a trait, or both. Combines waypoint traits, what each market imports, exports and exchanges, and distance, so answering \"where can I sell PRECIOUS_STONES near X1-FM66-A1\" takes one call.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"near": map[string]interface{}{
+					"type":        "string",
+					"description": "Waypoint to measure distances from (e.g., 'X1-FM66-A1'), or a system symbol (e.g., 'X1-FM66') to measure from its star",
+				},
+				"buys": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Trade good the market must buy, as an import or exchange good. Use this to find where to sell (e.g., 'PRECIOUS_STONES')",
+				},
+				"sells": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Trade good the market must sell, as an export or exchange good. Use this to find where to buy (e.g., 'FUEL')",
+				},
+				"trait": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Waypoint trait required (e.g., 'SHIPYARD', 'MARKETPLACE', 'COMMON_METAL_DEPOSITS')",
+				},
+				"waypoint_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Waypoint type required (e.g., 'ASTEROID', 'PLANET', 'MOON')",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of results (default %d, max %d)", defaultCapabilityLimit, maxCapabilityLimit),
+					"minimum":     1,
+					"maximum":     maxCapabilityLimit,
+				},
+			},
+			Required: []string{"near"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"near":              map[string]interface{}{"type": "string"},
+			"system_symbol":     map[string]interface{}{"type": "string"},
+			"buys":              map[string]interface{}{"type": "string"},
+			"sells":             map[string]interface{}{"type": "string"},
+			"trait":             map[string]interface{}{"type": "string"},
+			"waypoint_type":     map[string]interface{}{"type": "string"},
+			"matches":           map[string]interface{}{"type": "array", "description": "Matching waypoints, closest first"},
+			"count":             map[string]interface{}{"type": "integer"},
+			"unchecked_markets": map[string]interface{}{"type": "array", "description": "Markets whose goods are not known yet"},
+		}, "near", "system_symbol", "matches", "count"),
+	}
+}
+
+// capabilityMatch is a waypoint that has every requested capability
+type capabilityMatch struct {
+	Symbol   string   `json:"symbol"`
+	Type     string   `json:"type"`
+	X        int      `json:"x"`
+	Y        int      `json:"y"`
+	Distance float64  `json:"distance"`
+	Traits   []string `json:"traits"`
+	// BuysAs and SellsAs say how the market trades the requested good: IMPORT, EXPORT or EXCHANGE
+	BuysAs  string `json:"buys_as,omitempty"`
+	SellsAs string `json:"sells_as,omitempty"`
+}
+
+// Handler returns the tool handler function
+func (t *FindByCapabilityTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "find-by-capability-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		near := strings.ToUpper(v.RequireString("near"))
+		filterErrors := len(v.Errors())
+		buys := v.OptionalSymbol("buys", utils.TradeSymbols, "")
+		sells := v.OptionalSymbol("sells", utils.TradeSymbols, "")
+		trait := v.OptionalSymbol("trait", utils.WaypointTraits, "")
+		waypointType := v.OptionalSymbol("waypoint_type", utils.WaypointTypes, "")
+		if buys == "" && sells == "" && trait == "" && waypointType == "" && len(v.Errors()) == filterErrors {
+			v.Fail("buys", "no capability given", "at least one of buys, sells, trait or waypoint_type")
+		}
+		limit := v.OptionalInt("limit", defaultCapabilityLimit, 1, maxCapabilityLimit)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		// Waypoint symbols have a third part (X1-FM66-A1); system symbols do not (X1-FM66)
+		systemSymbol := near
+		if strings.Count(near, "-") >= 2 {
+			systemSymbol = travel.SystemSymbol(near)
+		}
+
+		contextLogger.Info(fmt.Sprintf("Searching %s for buys=%q sells=%q trait=%q type=%q near %s", systemSymbol, buys, sells, trait, waypointType, near))
+
+		c := t.client.WithContext(ctx)
+		waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get waypoints for system %s: %v", systemSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to retrieve waypoints for system %s: %v", systemSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Distances are measured from the star at (0, 0) unless a waypoint was given
+		originX, originY := 0, 0
+		if near != systemSymbol {
+			origin := waypointBySymbol(waypoints, near)
+			if origin == nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("Error: waypoint %s not found in system %s", near, systemSymbol)),
+					},
+					IsError: true,
+				}, nil
+			}
+			originX, originY = origin.X, origin.Y
+		}
+
+		// Cheap filters first, so markets are only fetched for waypoints that could match
+		candidates := make([]capabilityMatch, 0)
+		for _, waypoint := range waypoints {
+			if waypointType != "" && waypoint.Type != waypointType {
+				continue
+			}
+			traits := make([]string, 0, len(waypoint.Traits))
+			for _, wt := range waypoint.Traits {
+				traits = append(traits, wt.Symbol)
+			}
+			if trait != "" && !containsSymbol(traits, trait) {
+				continue
+			}
+			if (buys != "" || sells != "") && !containsSymbol(traits, "MARKETPLACE") {
+				continue
+			}
+			candidates = append(candidates, capabilityMatch{
+				Symbol:   waypoint.Symbol,
+				Type:     waypoint.Type,
+				X:        waypoint.X,
+				Y:        waypoint.Y,
+				Distance: travel.Distance(originX, originY, waypoint.X, waypoint.Y),
+				Traits:   traits,
+			})
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Distance < candidates[j].Distance
+		})
+
+		matches := make([]capabilityMatch, 0, limit)
+		var unchecked []string
+		for _, candidate := range candidates {
+			if len(matches) == limit {
+				break
+			}
+			if buys != "" || sells != "" {
+				market, _, err := c.GetCachedMarketListing(systemSymbol, candidate.Symbol)
+				if err != nil {
+					contextLogger.Error(fmt.Sprintf("Failed to get market at %s: %v", candidate.Symbol, err))
+					unchecked = append(unchecked, candidate.Symbol)
+					continue
+				}
+				if buys != "" {
+					if candidate.BuysAs = tradeRole(market, buys, market.Imports, "IMPORT"); candidate.BuysAs == "" {
+						continue
+					}
+				}
+				if sells != "" {
+					if candidate.SellsAs = tradeRole(market, sells, market.Exports, "EXPORT"); candidate.SellsAs == "" {
+						continue
+					}
+				}
+			}
+			matches = append(matches, candidate)
+		}
+
+		contextLogger.ToolCall("find_waypoints_by_capability", true)
+
+		criteria := describeCapability(buys, sells, trait, waypointType)
+		result := map[string]interface{}{
+			"near":          near,
+			"system_symbol": systemSymbol,
+			"buys":          buys,
+			"sells":         sells,
+			"trait":         trait,
+			"waypoint_type": waypointType,
+			"matches":       matches,
+			"count":         len(matches),
+		}
+		if len(unchecked) > 0 {
+			result["unchecked_markets"] = unchecked
+		}
+
+		textSummary := fmt.Sprintf("## Waypoints that %s near %s\n\n", criteria, near)
+		if len(matches) == 0 {
+			textSummary += fmt.Sprintf("❌ **No waypoints in %s** %s.\n", systemSymbol, criteria)
+			if buys != "" || sells != "" {
+				textSummary += "\nOnly this system was searched. Try a neighbouring system, or `find_nearest` with include_adjacent to look one jump away.\n"
+			}
+		} else {
+			textSummary += fmt.Sprintf("✅ **Found %d waypoint(s)**, nearest first:\n\n", len(matches))
+			for i, match := range matches {
+				textSummary += fmt.Sprintf("%d. **%s** (%s) at (%d, %d) - %.1f units", i+1, match.Symbol, match.Type, match.X, match.Y, match.Distance)
+				var roles []string
+				if match.BuysAs != "" {
+					roles = append(roles, fmt.Sprintf("buys %s as %s", buys, strings.ToLower(match.BuysAs)))
+				}
+				if match.SellsAs != "" {
+					roles = append(roles, fmt.Sprintf("sells %s as %s", sells, strings.ToLower(match.SellsAs)))
+				}
+				if len(roles) > 0 {
+					textSummary += " - " + strings.Join(roles, ", ")
+				}
+				textSummary += "\n"
+			}
+			if buys != "" || sells != "" {
+				textSummary += "\nPrices are not included. Read a waypoint's `spacetraders://systems/{systemSymbol}/waypoints/{waypointSymbol}/market` resource for current prices (a ship must be present to see them).\n"
+			}
+		}
+		if len(unchecked) > 0 {
+			textSummary += fmt.Sprintf("\n⚠️ Could not check the markets at %s.\n", strings.Join(unchecked, ", "))
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// waypointBySymbol returns the waypoint with the given symbol, or nil if it is not in the list
+func waypointBySymbol(waypoints []client.SystemWaypoint, symbol string) *client.SystemWaypoint {
+	for i := range waypoints {
+		if waypoints[i].Symbol == symbol {
+			return &waypoints[i]
+		}
+	}
+	return nil
+}
+
+// containsSymbol reports whether symbols contains symbol
+func containsSymbol(symbols []string, symbol string) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// tradeRole reports how a market trades a good: role when it is in list (the market's imports or
+// exports), EXCHANGE when the market exchanges it, or "" when the market does not trade it that way
+func tradeRole(market *client.Market, tradeSymbol string, list []client.TradeGood, role string) string {
+	for _, good := range list {
+		if good.Symbol == tradeSymbol {
+			return role
+		}
+	}
+	for _, good := range market.Exchange {
+		if good.Symbol == tradeSymbol {
+			return "EXCHANGE"
+		}
+	}
+	return ""
+}
+
+// describeCapability phrases the search criteria for the summary heading
+func describeCapability(buys, sells, trait, waypointType string) string {
+	var parts []string
+	if buys != "" {
+		parts = append(parts, "buy "+buys)
+	}
+	if sells != "" {
+		parts = append(parts, "sell "+sells)
+	}
+	if trait != "" {
+		parts = append(parts, "have "+trait)
+	}
+	if waypointType != "" {
+		parts = append(parts, "are "+waypointType)
+	}
+	return strings.Join(parts, " and ")
+}
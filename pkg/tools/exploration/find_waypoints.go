@@ -49,6 +49,13 @@ func (t *FindWaypointsTool) Tool() mcp.Tool {
 			},
 			Required: []string{"system_symbol", "trait"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"system_symbol":        map[string]interface{}{"type": "string"},
+			"searched_trait":       map[string]interface{}{"type": "string"},
+			"waypoint_type_filter": map[string]interface{}{"type": "string"},
+			"total_found":          map[string]interface{}{"type": "integer"},
+			"waypoints":            map[string]interface{}{"type": "array"},
+		}, "system_symbol", "searched_trait", "total_found", "waypoints"),
 	}
 }
 
@@ -57,52 +64,19 @@ func (t *FindWaypointsTool) Handler() func(ctx context.Context, request mcp.Call
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "find-waypoints-tool")
 
-		// Extract parameters
-		var systemSymbol, trait, waypointType string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["system_symbol"]; exists {
-					if s, ok := val.(string); ok {
-						systemSymbol = strings.ToUpper(s)
-					}
-				}
-				if val, exists := argsMap["trait"]; exists {
-					if s, ok := val.(string); ok {
-						trait = strings.ToUpper(s)
-					}
-				}
-				if val, exists := argsMap["waypoint_type"]; exists {
-					if s, ok := val.(string); ok {
-						waypointType = strings.ToUpper(s)
-					}
-				}
-			}
-		}
-
-		if systemSymbol == "" {
-			contextLogger.Error("Missing system_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: system_symbol parameter is required"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if trait == "" {
-			contextLogger.Error("Missing trait parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: trait parameter is required"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		systemSymbol := v.RequireSystem("system_symbol")
+		trait := v.RequireSymbol("trait", utils.WaypointTraits)
+		waypointType := v.OptionalSymbol("waypoint_type", utils.WaypointTypes, "")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Searching for waypoints with trait '%s' in system %s", trait, systemSymbol))
 
 		// Get waypoints from the system
-		waypoints, err := t.client.GetAllSystemWaypoints(systemSymbol)
+		waypoints, err := t.client.WithContext(ctx).GetAllSystemWaypoints(systemSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to get waypoints for system %s: %v", systemSymbol, err))
 			return &mcp.CallToolResult{
@@ -244,11 +218,6 @@ func (t *FindWaypointsTool) Handler() func(ctx context.Context, request mcp.Call
 			textSummary += "\nTo navigate to a waypoint, use: `navigate_ship` tool with your ship symbol and chosen waypoint.\n"
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
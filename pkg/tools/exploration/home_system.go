@@ -0,0 +1,356 @@
+package exploration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// HomeSystemTool maps the agent's headquarters system for early game play
+type HomeSystemTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewHomeSystemTool creates a new home system analysis tool
+func NewHomeSystemTool(client *client.Client, logger *logging.Logger) *HomeSystemTool {
+	return &HomeSystemTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *HomeSystemTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "analyze_home_system",
+		Description: "Map the agent's headquarters system: every shipyard with the ships it sells, every marketplace with what it imports and exports, the asteroids and their deposits, where fuel is sold and the jump gate with its connections, each with its distance from headquarters. Also picks the nearest fuel and the asteroid to mine with the nearest marketplace buying ore. Use it at the start of a session as a stable map of the early game.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"system_symbol":   map[string]interface{}{"type": "string"},
+			"headquarters":    map[string]interface{}{"type": "string"},
+			"faction":         map[string]interface{}{"type": "string"},
+			"total_waypoints": map[string]interface{}{"type": "integer"},
+			"shipyards":       map[string]interface{}{"type": "array", "description": "Shipyards and the ship types they sell, nearest headquarters first"},
+			"marketplaces":    map[string]interface{}{"type": "array", "description": "Marketplaces and what they trade, nearest headquarters first"},
+			"asteroids":       map[string]interface{}{"type": "array", "description": "Asteroids and their deposits, nearest headquarters first"},
+			"fuel_stations":   map[string]interface{}{"type": "array", "description": "Marketplaces selling fuel, nearest headquarters first"},
+			"jump_gate":       map[string]interface{}{"type": "object", "description": "The system's jump gate and its connections"},
+			"highlights":      map[string]interface{}{"type": "object", "description": "Nearest fuel and the suggested asteroid and marketplace for mining"},
+		}, "system_symbol", "headquarters", "total_waypoints", "shipyards", "marketplaces", "asteroids", "fuel_stations", "highlights"),
+	}
+}
+
+// homeWaypoint is a waypoint of the home system with what is known about its facilities
+type homeWaypoint struct {
+	Symbol         string   `json:"symbol"`
+	Type           string   `json:"type"`
+	X              int      `json:"x"`
+	Y              int      `json:"y"`
+	DistanceFromHQ float64  `json:"distance_from_hq"`
+	Traits         []string `json:"traits,omitempty"`
+
+	ShipTypes []string `json:"ship_types,omitempty"`
+
+	Exports  []string `json:"exports,omitempty"`
+	Imports  []string `json:"imports,omitempty"`
+	Exchange []string `json:"exchange,omitempty"`
+
+	Deposits []string `json:"deposits,omitempty"`
+
+	Connections       []string `json:"connections,omitempty"`
+	UnderConstruction bool     `json:"under_construction,omitempty"`
+}
+
+// sellsFuel reports whether the waypoint's market sells fuel
+func (w homeWaypoint) sellsFuel() bool {
+	return slices.Contains(w.Exports, "FUEL") || slices.Contains(w.Exchange, "FUEL")
+}
+
+// homeHighlights are the places a new agent usually needs first
+type homeHighlights struct {
+	NearestFuel    string `json:"nearest_fuel,omitempty"`
+	MiningAsteroid string `json:"mining_asteroid,omitempty"`
+	MiningMarket   string `json:"mining_market,omitempty"`
+	// MiningDistance is between the mining asteroid and its market
+	MiningDistance float64 `json:"mining_distance,omitempty"`
+}
+
+// Handler returns the tool handler function
+func (t *HomeSystemTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "home-system-tool")
+		c := t.client.WithContext(ctx)
+
+		agent, err := c.GetAgent()
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get agent: %v", err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get agent: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		systemSymbol := travel.SystemSymbol(agent.Headquarters)
+
+		waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get waypoints for system %s: %v", systemSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to retrieve waypoints for system %s: %s", systemSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		hqX, hqY := 0, 0
+		for _, waypoint := range waypoints {
+			if waypoint.Symbol == agent.Headquarters {
+				hqX, hqY = waypoint.X, waypoint.Y
+			}
+		}
+
+		var shipyards, marketplaces, asteroids, fuelStations []homeWaypoint
+		var gate *homeWaypoint
+		var unavailable []string
+		for _, waypoint := range waypoints {
+			home := homeWaypoint{
+				Symbol:            waypoint.Symbol,
+				Type:              waypoint.Type,
+				X:                 waypoint.X,
+				Y:                 waypoint.Y,
+				DistanceFromHQ:    travel.Distance(hqX, hqY, waypoint.X, waypoint.Y),
+				UnderConstruction: waypoint.IsUnderConstruction,
+			}
+			for _, trait := range waypoint.Traits {
+				home.Traits = append(home.Traits, trait.Symbol)
+				if isDeposit(trait.Symbol) {
+					home.Deposits = append(home.Deposits, trait.Symbol)
+				}
+			}
+
+			if slices.Contains(home.Traits, "SHIPYARD") {
+				if shipyard, err := c.GetShipyard(systemSymbol, waypoint.Symbol); err == nil {
+					for _, shipType := range shipyard.ShipTypes {
+						home.ShipTypes = append(home.ShipTypes, shipType.Type)
+					}
+				} else {
+					unavailable = append(unavailable, fmt.Sprintf("shipyard at %s: %s", waypoint.Symbol, err.Error()))
+				}
+				shipyards = append(shipyards, home)
+			}
+			if slices.Contains(home.Traits, "MARKETPLACE") {
+				if market, _, err := c.GetCachedMarketListing(systemSymbol, waypoint.Symbol); err == nil {
+					home.Exports = goodSymbols(market.Exports)
+					home.Imports = goodSymbols(market.Imports)
+					home.Exchange = goodSymbols(market.Exchange)
+				} else {
+					unavailable = append(unavailable, fmt.Sprintf("market at %s: %s", waypoint.Symbol, err.Error()))
+				}
+				marketplaces = append(marketplaces, home)
+				if home.sellsFuel() {
+					fuelStations = append(fuelStations, home)
+				}
+			}
+			switch waypoint.Type {
+			case "ASTEROID", "ENGINEERED_ASTEROID", "ASTEROID_FIELD":
+				asteroids = append(asteroids, home)
+			case "JUMP_GATE":
+				if jumpGate, err := c.GetCachedJumpGate(systemSymbol, waypoint.Symbol); err == nil {
+					home.Connections = jumpGate.Connections
+				} else {
+					unavailable = append(unavailable, fmt.Sprintf("jump gate at %s: %s", waypoint.Symbol, err.Error()))
+				}
+				gate = &home
+			}
+		}
+		for _, list := range [][]homeWaypoint{shipyards, marketplaces, asteroids, fuelStations} {
+			sortByDistanceFromHQ(list)
+		}
+
+		highlights := pickHighlights(asteroids, marketplaces, fuelStations)
+
+		contextLogger.ToolCall("analyze_home_system", true)
+
+		result := map[string]interface{}{
+			"system_symbol":   systemSymbol,
+			"headquarters":    agent.Headquarters,
+			"faction":         agent.StartingFaction,
+			"total_waypoints": len(waypoints),
+			"shipyards":       orEmpty(shipyards),
+			"marketplaces":    orEmpty(marketplaces),
+			"asteroids":       orEmpty(asteroids),
+			"fuel_stations":   orEmpty(fuelStations),
+			"highlights":      highlights,
+		}
+		if gate != nil {
+			result["jump_gate"] = gate
+		}
+		if len(unavailable) > 0 {
+			result["unavailable"] = unavailable
+		}
+
+		return utils.NewResult(homeSystemSummary(agent, systemSymbol, len(waypoints), shipyards, marketplaces, asteroids, fuelStations, gate, highlights, unavailable), result), nil
+	}
+}
+
+// isDeposit reports whether a waypoint trait is a resource that can be extracted
+func isDeposit(trait string) bool {
+	return strings.HasSuffix(trait, "_DEPOSITS") || trait == "ICE_CRYSTALS" || trait == "EXPLOSIVE_GASES"
+}
+
+// goodSymbols lists the symbols of trade goods
+func goodSymbols(goods []client.TradeGood) []string {
+	var symbols []string
+	for _, good := range goods {
+		symbols = append(symbols, good.Symbol)
+	}
+	return symbols
+}
+
+// sortByDistanceFromHQ orders waypoints nearest headquarters first, then by symbol
+func sortByDistanceFromHQ(waypoints []homeWaypoint) {
+	sort.Slice(waypoints, func(i, j int) bool {
+		if waypoints[i].DistanceFromHQ != waypoints[j].DistanceFromHQ {
+			return waypoints[i].DistanceFromHQ < waypoints[j].DistanceFromHQ
+		}
+		return waypoints[i].Symbol < waypoints[j].Symbol
+	})
+}
+
+// orEmpty keeps an empty category a JSON array rather than null
+func orEmpty(waypoints []homeWaypoint) []homeWaypoint {
+	if waypoints == nil {
+		return []homeWaypoint{}
+	}
+	return waypoints
+}
+
+// buysOre reports whether the waypoint's market buys anything mined from asteroids
+func (w homeWaypoint) buysOre() bool {
+	return slices.ContainsFunc(append(slices.Clone(w.Imports), w.Exchange...), func(good string) bool {
+		return strings.HasSuffix(good, "_ORE") || good == "ICE_WATER" || good == "QUARTZ_SAND" || good == "SILICON_CRYSTALS"
+	})
+}
+
+// pickHighlights finds the nearest fuel to headquarters and the asteroid to mine: the
+// engineered asteroid when there is one, otherwise the asteroid closest to a marketplace.
+// Marketplaces buying ore are preferred for selling what is mined.
+func pickHighlights(asteroids, marketplaces, fuelStations []homeWaypoint) homeHighlights {
+	var highlights homeHighlights
+	if len(fuelStations) > 0 {
+		highlights.NearestFuel = fuelStations[0].Symbol
+	}
+	if buyers := slices.DeleteFunc(slices.Clone(marketplaces), func(w homeWaypoint) bool { return !w.buysOre() }); len(buyers) > 0 {
+		marketplaces = buyers
+	}
+	if len(marketplaces) == 0 {
+		return highlights
+	}
+
+	engineered := slices.ContainsFunc(asteroids, func(w homeWaypoint) bool { return w.Type == "ENGINEERED_ASTEROID" })
+	for _, asteroid := range asteroids {
+		if engineered && asteroid.Type != "ENGINEERED_ASTEROID" {
+			continue
+		}
+		for _, market := range marketplaces {
+			distance := travel.Distance(asteroid.X, asteroid.Y, market.X, market.Y)
+			if highlights.MiningAsteroid == "" || distance < highlights.MiningDistance {
+				highlights.MiningAsteroid, highlights.MiningMarket, highlights.MiningDistance = asteroid.Symbol, market.Symbol, distance
+			}
+		}
+	}
+	return highlights
+}
+
+// homeSystemSummary renders the home system map as markdown
+func homeSystemSummary(agent *client.Agent, systemSymbol string, total int, shipyards, marketplaces, asteroids, fuelStations []homeWaypoint, gate *homeWaypoint, highlights homeHighlights, unavailable []string) string {
+	summary := fmt.Sprintf("# 🏠 Home System: %s\n\n", systemSymbol)
+	summary += fmt.Sprintf("**Headquarters:** %s\n", agent.Headquarters)
+	if agent.StartingFaction != "" {
+		summary += fmt.Sprintf("**Faction:** %s\n", agent.StartingFaction)
+	}
+	summary += fmt.Sprintf("**Waypoints:** %d\n\n", total)
+
+	summary += "## ⭐ Highlights\n"
+	if highlights.NearestFuel != "" {
+		summary += fmt.Sprintf("- **Nearest fuel:** %s\n", highlights.NearestFuel)
+	} else {
+		summary += "- **Nearest fuel:** none found; watch fuel before leaving headquarters\n"
+	}
+	if highlights.MiningAsteroid != "" {
+		summary += fmt.Sprintf("- **Mining:** %s, selling at %s (%.0f units apart)\n", highlights.MiningAsteroid, highlights.MiningMarket, highlights.MiningDistance)
+	}
+	summary += "\n"
+
+	line := func(w homeWaypoint, detail string) string {
+		text := fmt.Sprintf("- **%s** (%s), %.0f units from HQ", w.Symbol, w.Type, w.DistanceFromHQ)
+		if detail != "" {
+			text += " - " + detail
+		}
+		return text + "\n"
+	}
+
+	summary += fmt.Sprintf("## 🚀 Shipyards (%d)\n", len(shipyards))
+	for _, w := range shipyards {
+		summary += line(w, strings.Join(w.ShipTypes, ", "))
+	}
+	summary += fmt.Sprintf("\n## 🏪 Marketplaces (%d)\n", len(marketplaces))
+	for _, w := range marketplaces {
+		var trades []string
+		if len(w.Exports) > 0 {
+			trades = append(trades, "exports "+strings.Join(w.Exports, ", "))
+		}
+		if len(w.Imports) > 0 {
+			trades = append(trades, "imports "+strings.Join(w.Imports, ", "))
+		}
+		if len(w.Exchange) > 0 {
+			trades = append(trades, "exchanges "+strings.Join(w.Exchange, ", "))
+		}
+		summary += line(w, strings.Join(trades, "; "))
+	}
+	summary += fmt.Sprintf("\n## ⛏️ Asteroids (%d)\n", len(asteroids))
+	for _, w := range asteroids {
+		summary += line(w, strings.Join(w.Deposits, ", "))
+	}
+	summary += fmt.Sprintf("\n## ⛽ Fuel (%d)\n", len(fuelStations))
+	for _, w := range fuelStations {
+		summary += line(w, "")
+	}
+
+	summary += "\n## 🚪 Jump Gate\n"
+	if gate == nil {
+		summary += "None in this system.\n"
+	} else {
+		detail := fmt.Sprintf("%d connections", len(gate.Connections))
+		if len(gate.Connections) > 0 {
+			detail += ": " + strings.Join(gate.Connections, ", ")
+		}
+		if gate.UnderConstruction {
+			detail += " (under construction)"
+		}
+		summary += line(*gate, detail)
+	}
+
+	if len(unavailable) > 0 {
+		summary += "\n## ⚠️ Not Fetched\n"
+		for _, reason := range unavailable {
+			summary += fmt.Sprintf("- %s\n", reason)
+		}
+	}
+	return summary
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
 	"spacetraders-mcp/pkg/logging"
 	"spacetraders-mcp/pkg/tools/utils"
 
@@ -14,8 +15,9 @@ import (
 
 // ScanShipsTool allows scanning for ships around a ship
 type ScanShipsTool struct {
-	client *client.Client
-	logger *logging.Logger
+	client    *client.Client
+	cooldowns *cooldowns.Tracker
+	logger    *logging.Logger
 }
 
 // NewScanShipsTool creates a new scan ships tool
@@ -26,6 +28,12 @@ func NewScanShipsTool(client *client.Client, logger *logging.Logger) *ScanShipsT
 	}
 }
 
+// WithCooldowns refuses scans while the tracker knows the ship is still on cooldown
+func (t *ScanShipsTool) WithCooldowns(tracker *cooldowns.Tracker) *ScanShipsTool {
+	t.cooldowns = tracker
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *ScanShipsTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -41,6 +49,12 @@ func (t *ScanShipsTool) Tool() mcp.Tool {
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol": map[string]interface{}{"type": "string"},
+			"ships_found": map[string]interface{}{"type": "integer"},
+			"cooldown":    map[string]interface{}{"type": "object", "description": "Sensor cooldown"},
+			"ships":       map[string]interface{}{"type": "array"},
+		}, "ship_symbol", "ships_found", "cooldown", "ships"),
 	}
 }
 
@@ -49,32 +63,22 @@ func (t *ScanShipsTool) Handler() func(ctx context.Context, request mcp.CallTool
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "scan-ships-tool")
 
-		// Extract parameters
-		var shipSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok {
-						shipSymbol = strings.ToUpper(s)
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
-		if shipSymbol == "" {
-			contextLogger.Error("Missing ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required"),
-				},
-				IsError: true,
-			}, nil
+		if result := coolingDown(t.cooldowns, shipSymbol); result != nil {
+			contextLogger.Info(fmt.Sprintf("Not scanning with ship %s while it is on cooldown", shipSymbol))
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Scanning for ships using ship %s", shipSymbol))
 
 		// Perform the scan
-		scanData, err := t.client.ScanShips(shipSymbol)
+		scanData, err := t.client.WithContext(ctx).ScanShips(shipSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to scan ships with ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -244,11 +248,6 @@ func (t *ScanShipsTool) Handler() func(ctx context.Context, request mcp.CallTool
 			textSummary += "- Ensure your ship has appropriate scanning equipment\n"
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -3,9 +3,9 @@ package exploration
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
 	"spacetraders-mcp/pkg/logging"
 	"spacetraders-mcp/pkg/tools/utils"
 
@@ -14,8 +14,9 @@ import (
 
 // ScanSystemsTool allows scanning for systems around a ship
 type ScanSystemsTool struct {
-	client *client.Client
-	logger *logging.Logger
+	client    *client.Client
+	cooldowns *cooldowns.Tracker
+	logger    *logging.Logger
 }
 
 // NewScanSystemsTool creates a new scan systems tool
@@ -26,6 +27,12 @@ func NewScanSystemsTool(client *client.Client, logger *logging.Logger) *ScanSyst
 	}
 }
 
+// WithCooldowns refuses scans while the tracker knows the ship is still on cooldown
+func (t *ScanSystemsTool) WithCooldowns(tracker *cooldowns.Tracker) *ScanSystemsTool {
+	t.cooldowns = tracker
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *ScanSystemsTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -41,6 +48,12 @@ func (t *ScanSystemsTool) Tool() mcp.Tool {
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":   map[string]interface{}{"type": "string"},
+			"systems_found": map[string]interface{}{"type": "integer"},
+			"cooldown":      map[string]interface{}{"type": "object", "description": "Sensor cooldown"},
+			"systems":       map[string]interface{}{"type": "array"},
+		}, "ship_symbol", "systems_found", "cooldown", "systems"),
 	}
 }
 
@@ -49,32 +62,22 @@ func (t *ScanSystemsTool) Handler() func(ctx context.Context, request mcp.CallTo
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "scan-systems-tool")
 
-		// Extract parameters
-		var shipSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok {
-						shipSymbol = strings.ToUpper(s)
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
-		if shipSymbol == "" {
-			contextLogger.Error("Missing ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required"),
-				},
-				IsError: true,
-			}, nil
+		if result := coolingDown(t.cooldowns, shipSymbol); result != nil {
+			contextLogger.Info(fmt.Sprintf("Not scanning with ship %s while it is on cooldown", shipSymbol))
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Scanning for systems using ship %s", shipSymbol))
 
 		// Perform the scan
-		scanData, err := t.client.ScanSystems(shipSymbol)
+		scanData, err := t.client.WithContext(ctx).ScanSystems(shipSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to scan systems with ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -157,11 +160,6 @@ func (t *ScanSystemsTool) Handler() func(ctx context.Context, request mcp.CallTo
 			textSummary += "- Ensure your ship has appropriate scanning equipment\n"
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
 	"spacetraders-mcp/pkg/logging"
 	"spacetraders-mcp/pkg/tools/utils"
 
@@ -14,8 +15,9 @@ import (
 
 // ScanWaypointsTool allows scanning for waypoints around a ship
 type ScanWaypointsTool struct {
-	client *client.Client
-	logger *logging.Logger
+	client    *client.Client
+	cooldowns *cooldowns.Tracker
+	logger    *logging.Logger
 }
 
 // NewScanWaypointsTool creates a new scan waypoints tool
@@ -26,6 +28,12 @@ func NewScanWaypointsTool(client *client.Client, logger *logging.Logger) *ScanWa
 	}
 }
 
+// WithCooldowns refuses scans while the tracker knows the ship is still on cooldown
+func (t *ScanWaypointsTool) WithCooldowns(tracker *cooldowns.Tracker) *ScanWaypointsTool {
+	t.cooldowns = tracker
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *ScanWaypointsTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -41,6 +49,12 @@ func (t *ScanWaypointsTool) Tool() mcp.Tool {
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":     map[string]interface{}{"type": "string"},
+			"waypoints_found": map[string]interface{}{"type": "integer"},
+			"cooldown":        map[string]interface{}{"type": "object", "description": "Sensor cooldown"},
+			"waypoints":       map[string]interface{}{"type": "array"},
+		}, "ship_symbol", "waypoints_found", "cooldown", "waypoints"),
 	}
 }
 
@@ -49,32 +63,22 @@ func (t *ScanWaypointsTool) Handler() func(ctx context.Context, request mcp.Call
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "scan-waypoints-tool")
 
-		// Extract parameters
-		var shipSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok {
-						shipSymbol = strings.ToUpper(s)
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
-		if shipSymbol == "" {
-			contextLogger.Error("Missing ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required"),
-				},
-				IsError: true,
-			}, nil
+		if result := coolingDown(t.cooldowns, shipSymbol); result != nil {
+			contextLogger.Info(fmt.Sprintf("Not scanning with ship %s while it is on cooldown", shipSymbol))
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Scanning for waypoints using ship %s", shipSymbol))
 
 		// Perform the scan
-		scanData, err := t.client.ScanWaypoints(shipSymbol)
+		scanData, err := t.client.WithContext(ctx).ScanWaypoints(shipSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to scan waypoints with ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -227,11 +231,6 @@ func (t *ScanWaypointsTool) Handler() func(ctx context.Context, request mcp.Call
 			textSummary += "- Ensure your ship has appropriate scanning equipment\n"
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
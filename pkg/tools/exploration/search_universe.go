@@ -0,0 +1,204 @@
+package exploration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchUniverseTool searches the systems and waypoints remembered across sessions
+type SearchUniverseTool struct {
+	client  *client.Client
+	tracker *explorer.Tracker
+	logger  *logging.Logger
+}
+
+// NewSearchUniverseTool creates a new universe search tool
+func NewSearchUniverseTool(client *client.Client, tracker *explorer.Tracker, logger *logging.Logger) *SearchUniverseTool {
+	return &SearchUniverseTool{
+		client:  client,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *SearchUniverseTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "search_universe",
+		Description: "Search the systems and waypoints remembered across sessions by any combination of system type, waypoint type, traits, faction, ship sold at a shipyard and distance from a system, nearest first. Answers from what has already been listed, scanned or fetched instead of paging through the API, so it is instant but only knows places seen before. With any waypoint criterion it returns waypoints, otherwise systems.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"system_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Only systems of this type (e.g., 'RED_STAR', 'NEUTRON_STAR')",
+				},
+				"waypoint_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Only waypoints of this type (e.g., 'ASTEROID', 'JUMP_GATE')",
+				},
+				"traits": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Only waypoints with all of these traits (e.g., ['MARKETPLACE', 'SHIPYARD'])",
+				},
+				"faction": map[string]interface{}{
+					"type":        "string",
+					"description": "Only waypoints this faction controls, or systems it is present in (e.g., 'COSMIC')",
+				},
+				"sells_ship": map[string]interface{}{
+					"type":        "string",
+					"description": "Only waypoints whose shipyard sells this ship type (e.g., 'SHIP_MINING_DRONE')",
+				},
+				"near_system": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort by distance from this system (e.g., 'X1-DF55')",
+				},
+				"max_distance": map[string]interface{}{
+					"type":        "number",
+					"description": "Only results within this distance of near_system",
+					"minimum":     0,
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of results (default %d, max %d)", defaultSearchLimit, maxSearchLimit),
+					"minimum":     1,
+					"maximum":     maxSearchLimit,
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"matches":          map[string]interface{}{"type": "array", "description": "Matching systems or waypoints, nearest first"},
+			"total":            map[string]interface{}{"type": "integer", "description": "Matches before the limit was applied"},
+			"unknown_position": map[string]interface{}{"type": "integer", "description": "Systems left out of a distance search because their position has never been seen"},
+			"progress":         map[string]interface{}{"type": "object", "description": "How much of the universe is known"},
+		}, "matches", "total", "progress"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *SearchUniverseTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "search-universe-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		query := searchQuery(v)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		found, err := t.tracker.Search(query)
+		if errors.Is(err, explorer.ErrUnknownSystem) {
+			// One fetch of the reference system tells the tracker where it is
+			if _, fetchErr := t.client.WithContext(ctx).GetSystem(query.NearSystem); fetchErr == nil {
+				found, err = t.tracker.Search(query)
+			}
+		}
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to search the universe: %v", err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Can't measure distances from %s: its position is unknown. Check the system symbol.", query.NearSystem)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("search_universe", true)
+
+		summary := t.tracker.Summary()
+		result := map[string]interface{}{
+			"matches":  found.Matches,
+			"total":    found.Total,
+			"progress": summary,
+		}
+		if found.UnknownPosition > 0 {
+			result["unknown_position"] = found.UnknownPosition
+		}
+
+		textSummary := "## 🔎 Universe Search\n\n"
+		textSummary += fmt.Sprintf("**Known so far:** %d systems and %d waypoints\n", summary.SystemsKnown, summary.WaypointsKnown)
+		if found.Total > len(found.Matches) {
+			textSummary += fmt.Sprintf("**Found:** %d, showing the first %d\n\n", found.Total, len(found.Matches))
+		} else {
+			textSummary += fmt.Sprintf("**Found:** %d\n\n", found.Total)
+		}
+
+		for _, match := range found.Matches {
+			textSummary += "- " + describeMatch(match) + "\n"
+		}
+		if found.Total == 0 {
+			textSummary += "Nothing matched. The search only knows places seen before: list a system's waypoints, scan or fetch systems, or view shipyards to learn more.\n"
+		}
+		if found.UnknownPosition > 0 {
+			textSummary += fmt.Sprintf("\n%d systems were left out because their position has never been seen; scan_systems or fetching them reveals it.\n", found.UnknownPosition)
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// searchQuery reads the search criteria from the tool arguments, validating symbols
+// against the game's enumerations
+func searchQuery(v *utils.Validator) explorer.Query {
+	query := explorer.Query{
+		SystemType:   v.OptionalSymbol("system_type", utils.SystemTypes, ""),
+		WaypointType: v.OptionalSymbol("waypoint_type", utils.WaypointTypes, ""),
+		Faction:      v.OptionalSymbol("faction", utils.FactionSymbols, ""),
+		ShipType:     v.OptionalSymbol("sells_ship", utils.ShipTypes, ""),
+		Traits:       v.OptionalSymbols("traits", utils.WaypointTraits),
+		NearSystem:   v.OptionalSystem("near_system"),
+		MaxDistance:  v.OptionalNumber("max_distance", 0, 0, math.MaxFloat64),
+		Limit:        v.OptionalInt("limit", defaultSearchLimit, 1, maxSearchLimit),
+	}
+	if query.MaxDistance > 0 && query.NearSystem == "" {
+		v.Fail("max_distance", "needs near_system to measure from", "")
+	}
+	return query
+}
+
+// describeMatch summarizes a matching system or waypoint on one line
+func describeMatch(match explorer.Match) string {
+	var line string
+	if waypoint := match.Waypoint; waypoint != nil {
+		line = fmt.Sprintf("**%s** (%s)", waypoint.Symbol, waypoint.Type)
+		if waypoint.Faction != "" {
+			line += " - " + waypoint.Faction
+		}
+		if len(waypoint.Traits) > 0 {
+			line += " - " + strings.Join(waypoint.Traits, ", ")
+		}
+		if len(waypoint.ShipTypes) > 0 {
+			line += " - sells " + strings.Join(waypoint.ShipTypes, ", ")
+		}
+	} else {
+		system := match.System
+		line = fmt.Sprintf("**%s**", system.Symbol)
+		if system.Type != "" {
+			line += fmt.Sprintf(" (%s)", system.Type)
+		}
+		if len(system.Factions) > 0 {
+			line += " - " + strings.Join(system.Factions, ", ")
+		}
+	}
+	if match.Distance != nil {
+		line += fmt.Sprintf(", %.0f units away", *match.Distance)
+	}
+	return line
+}
@@ -0,0 +1,154 @@
+package exploration
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultTargetLimit = 5
+	maxTargetLimit     = 20
+)
+
+// SuggestTargetsTool suggests waypoints for ships to explore next
+type SuggestTargetsTool struct {
+	client  *client.Client
+	tracker *explorer.Tracker
+	logger  *logging.Logger
+}
+
+// NewSuggestTargetsTool creates a new exploration target suggestion tool
+func NewSuggestTargetsTool(client *client.Client, tracker *explorer.Tracker, logger *logging.Logger) *SuggestTargetsTool {
+	return &SuggestTargetsTool{
+		client:  client,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *SuggestTargetsTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "suggest_exploration_targets",
+		Description: "Suggest where ships should explore next, using the exploration progress remembered across sessions: uncharted waypoints first, then marketplaces and shipyards no ship has visited yet, nearest first",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Only suggest targets for this ship (e.g., 'SHIP_1234'); all ships when omitted",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of targets per ship (default %d, max %d)", defaultTargetLimit, maxTargetLimit),
+					"minimum":     1,
+					"maximum":     maxTargetLimit,
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"suggestions": map[string]interface{}{"type": "array", "description": "Suggested targets per ship"},
+			"progress":    map[string]interface{}{"type": "object", "description": "Exploration progress so far"},
+		}, "suggestions", "progress"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *SuggestTargetsTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "suggest-exploration-targets-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.OptionalShip("ship_symbol")
+		limit := v.OptionalInt("limit", defaultTargetLimit, 1, maxTargetLimit)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		var ships []client.Ship
+		if shipSymbol != "" {
+			ship, err := c.GetShip(shipSymbol)
+			if err != nil {
+				contextLogger.Error(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err))
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err)),
+					},
+					IsError: true,
+				}, nil
+			}
+			ships = []client.Ship{*ship}
+		} else {
+			var err error
+			ships, err = c.GetAllShips()
+			if err != nil {
+				contextLogger.Error(fmt.Sprintf("Failed to get ships: %v", err))
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("Failed to get ships: %v", err)),
+					},
+					IsError: true,
+				}, nil
+			}
+		}
+
+		suggestions, err := t.tracker.SuggestForShips(c, ships, limit)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to suggest exploration targets: %v", err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to list waypoints near ships: %v", err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("suggest_exploration_targets", true)
+
+		summary := t.tracker.Summary()
+		result := map[string]interface{}{
+			"suggestions": suggestions,
+			"progress":    summary,
+		}
+
+		textSummary := "## 🔭 Exploration Targets\n\n"
+		textSummary += fmt.Sprintf("**Explored so far:** %d/%d systems visited, %d/%d waypoints visited, %d uncharted waypoints known\n\n",
+			summary.SystemsVisited, summary.SystemsKnown, summary.WaypointsVisited, summary.WaypointsKnown, summary.WaypointsUncharted)
+		for _, suggestion := range suggestions {
+			textSummary += fmt.Sprintf("### %s (at %s)\n", suggestion.ShipSymbol, suggestion.WaypointSymbol)
+			if len(suggestion.Targets) == 0 {
+				textSummary += fmt.Sprintf("Nothing left to explore in %s. Scan for or jump to other systems.\n\n", suggestion.SystemSymbol)
+				continue
+			}
+			for _, target := range suggestion.Targets {
+				textSummary += fmt.Sprintf("- **%s** (%s) - %s, %.0f units away\n", target.Symbol, target.Type, targetReasonText(target.Reason), target.Distance)
+			}
+			textSummary += "\n"
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// targetReasonText describes why a waypoint was suggested
+func targetReasonText(reason string) string {
+	switch reason {
+	case explorer.ReasonUncharted:
+		return "uncharted"
+	case explorer.ReasonUnvisitedMarket:
+		return "marketplace not visited yet"
+	case explorer.ReasonUnvisitedShipyard:
+		return "shipyard not visited yet"
+	default:
+		return reason
+	}
+}
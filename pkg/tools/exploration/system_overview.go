@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
@@ -47,6 +46,19 @@ func (t *SystemOverviewTool) Tool() mcp.Tool {
 			},
 			Required: []string{"system_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"system_symbol":     map[string]interface{}{"type": "string"},
+			"total_waypoints":   map[string]interface{}{"type": "integer"},
+			"waypoint_types":    map[string]interface{}{"type": "object", "description": "Waypoint count per type"},
+			"key_facilities":    map[string]interface{}{"type": "object", "description": "Waypoints per facility trait"},
+			"shipyards":         map[string]interface{}{"type": "array"},
+			"marketplaces":      map[string]interface{}{"type": "array"},
+			"mining_sites":      map[string]interface{}{"type": "array"},
+			"jump_gates":        map[string]interface{}{"type": "array"},
+			"fuel_stations":     map[string]interface{}{"type": "array"},
+			"strategic_summary": map[string]interface{}{"type": "object"},
+			"shipyard_details":  map[string]interface{}{"type": "array", "description": "Ships for sale at each shipyard, when fetched"},
+		}, "system_symbol", "total_waypoints", "waypoint_types", "key_facilities", "shipyards", "marketplaces", "mining_sites", "jump_gates", "fuel_stations", "strategic_summary"),
 	}
 }
 
@@ -55,38 +67,18 @@ func (t *SystemOverviewTool) Handler() func(ctx context.Context, request mcp.Cal
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "system-overview-tool")
 
-		// Extract parameters
-		var systemSymbol string
-		includeShipyards := true
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["system_symbol"]; exists {
-					if s, ok := val.(string); ok {
-						systemSymbol = strings.ToUpper(s)
-					}
-				}
-				if val, exists := argsMap["include_shipyards"]; exists {
-					if b, ok := val.(bool); ok {
-						includeShipyards = b
-					}
-				}
-			}
-		}
-
-		if systemSymbol == "" {
-			contextLogger.Error("Missing system_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: system_symbol parameter is required"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		systemSymbol := v.RequireSystem("system_symbol")
+		includeShipyards := v.OptionalBool("include_shipyards", true)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Generating overview for system %s", systemSymbol))
 
 		// Get waypoints from the system
-		waypoints, err := t.client.GetAllSystemWaypoints(systemSymbol)
+		waypoints, err := t.client.WithContext(ctx).GetAllSystemWaypoints(systemSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to get waypoints for system %s: %v", systemSymbol, err))
 			return &mcp.CallToolResult{
@@ -104,7 +96,7 @@ func (t *SystemOverviewTool) Handler() func(ctx context.Context, request mcp.Cal
 		var shipyardDetails []map[string]interface{}
 		if includeShipyards && len(analysis.Shipyards) > 0 {
 			for _, shipyardSymbol := range analysis.Shipyards {
-				shipyard, err := t.client.GetShipyard(systemSymbol, shipyardSymbol)
+				shipyard, err := t.client.WithContext(ctx).GetShipyard(systemSymbol, shipyardSymbol)
 				if err != nil {
 					contextLogger.Error(fmt.Sprintf("Failed to get shipyard details for %s: %v", shipyardSymbol, err))
 					continue
@@ -149,12 +141,7 @@ func (t *SystemOverviewTool) Handler() func(ctx context.Context, request mcp.Cal
 		// Create text summary
 		textSummary := t.generateTextSummary(analysis, shipyardDetails, includeShipyards)
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
 
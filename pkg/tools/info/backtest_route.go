@@ -0,0 +1,200 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultBacktestWindow is how far back a backtest looks when no window is given
+	defaultBacktestWindow = 24 * time.Hour
+
+	// minBacktestPoints is how many price changes a backtest needs before its verdict means more
+	// than a single snapshot would
+	minBacktestPoints = 3
+)
+
+// BacktestRouteTool replays recorded prices to show how a trade loop would have done
+type BacktestRouteTool struct {
+	prices *prices.DB
+	logger *logging.Logger
+}
+
+// NewBacktestRouteTool creates a new trade route backtesting tool
+func NewBacktestRouteTool(db *prices.DB, logger *logging.Logger) *BacktestRouteTool {
+	return &BacktestRouteTool{
+		prices: db,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *BacktestRouteTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "backtest_route",
+		Description: "Replay the recorded price history of a good at two markets to show what buying at one and selling at the other would have made over a window: the margin per unit and per trip, how much prices moved, and how much of the time the route paid. Use it to prefer routes that stay profitable over ones that only look good in the latest snapshot. Makes no API calls.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"buy_waypoint": map[string]interface{}{
+					"type":        "string",
+					"description": "Market the good is bought at (e.g., 'X1-FM66-A1')",
+				},
+				"sell_waypoint": map[string]interface{}{
+					"type":        "string",
+					"description": "Market the good is sold at (e.g., 'X1-FM66-B2')",
+				},
+				"good": map[string]interface{}{
+					"type":        "string",
+					"description": "Trade symbol of the good (e.g., 'IRON_ORE')",
+				},
+				"window_hours": map[string]interface{}{
+					"type":        "number",
+					"description": fmt.Sprintf("How far back to replay (default %g hours)", defaultBacktestWindow.Hours()),
+					"minimum":     0,
+				},
+				"units": map[string]interface{}{
+					"type":        "integer",
+					"description": "Units carried per trip (optional - defaults to the smaller trade volume of the two markets)",
+					"minimum":     1,
+				},
+			},
+			Required: []string{"buy_waypoint", "sell_waypoint", "good"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"buy_waypoint":     map[string]interface{}{"type": "string"},
+			"sell_waypoint":    map[string]interface{}{"type": "string"},
+			"good":             map[string]interface{}{"type": "string"},
+			"window":           map[string]interface{}{"type": "string"},
+			"observations":     map[string]interface{}{"type": "integer", "description": "Recorded prices of the good at either market in the window"},
+			"points":           map[string]interface{}{"type": "array", "description": "The route's margin after each price change, oldest first"},
+			"buy":              map[string]interface{}{"type": "object", "description": "Time-weighted min, max, mean, standard deviation and latest buy price"},
+			"sell":             map[string]interface{}{"type": "object", "description": "The same for the sell price"},
+			"margin":           map[string]interface{}{"type": "object", "description": "The same for the margin per unit"},
+			"profitable_share": map[string]interface{}{"type": "number", "description": "Share of the time the margin was positive, from 0 to 1"},
+			"units":            map[string]interface{}{"type": "integer"},
+			"profit_per_trip":  map[string]interface{}{"type": "object", "description": "Mean, worst and best profit of one trip of units"},
+			"verdict":          map[string]interface{}{"type": "string", "description": "robust, mostly_profitable, unreliable, unprofitable or insufficient_data"},
+		}, "buy_waypoint", "sell_waypoint", "good", "window", "observations", "points", "verdict"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *BacktestRouteTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "backtest-route-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		buyWaypoint := v.RequireWaypoint("buy_waypoint")
+		sellWaypoint := v.RequireWaypoint("sell_waypoint")
+		good := v.RequireSymbol("good", utils.TradeSymbols)
+		window := v.OptionalHours("window_hours", defaultBacktestWindow)
+		units := v.OptionalInt("units", 0, 1, math.MaxInt)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		now := time.Now()
+		windowDescription := fmt.Sprintf("last %s", formatWindow(window))
+		backtest := t.prices.Backtest(buyWaypoint, sellWaypoint, good, now.Add(-window), now)
+
+		result := map[string]interface{}{
+			"buy_waypoint":  buyWaypoint,
+			"sell_waypoint": sellWaypoint,
+			"good":          good,
+			"window":        windowDescription,
+			"observations":  backtest.Observations,
+			"points":        backtest.Points,
+		}
+
+		textSummary := fmt.Sprintf("## 🔁 Backtest: %s %s → %s\n\n", good, buyWaypoint, sellWaypoint)
+		textSummary += fmt.Sprintf("**Window:** %s\n", windowDescription)
+
+		if len(backtest.Points) == 0 {
+			result["verdict"] = "insufficient_data"
+			textSummary += "\nThere is no time in this window when both prices were known.\n"
+			for _, market := range []string{buyWaypoint, sellWaypoint} {
+				if !t.hasPrice(market, good) {
+					textSummary += fmt.Sprintf("- No price of %s has been recorded at %s.\n", good, market)
+				}
+			}
+			textSummary += "Prices are recorded whenever a market is viewed with a ship present; `deploy_probe` keeps recording them.\n"
+			ctxLogger.ToolCall("backtest_route", true)
+			return utils.NewResult(textSummary, result), nil
+		}
+
+		if units == 0 {
+			units = backtest.Points[len(backtest.Points)-1].Units
+		}
+		verdict := backtestVerdict(backtest)
+		result["buy"] = backtest.Buy
+		result["sell"] = backtest.Sell
+		result["margin"] = backtest.Margin
+		result["profitable_share"] = backtest.ProfitableShare
+		result["units"] = units
+		result["profit_per_trip"] = map[string]interface{}{
+			"mean":  math.Round(backtest.Margin.Mean * float64(units)),
+			"worst": backtest.Margin.Min * units,
+			"best":  backtest.Margin.Max * units,
+		}
+		result["verdict"] = verdict
+
+		textSummary += fmt.Sprintf("**History:** %d price changes from %s (%d recorded prices in the window)\n\n", len(backtest.Points), backtest.From.UTC().Format(time.RFC3339), backtest.Observations)
+		textSummary += fmt.Sprintf("**Buy price:** %d-%d, averaging %.0f (±%.0f), now %d\n", backtest.Buy.Min, backtest.Buy.Max, backtest.Buy.Mean, backtest.Buy.StdDev, backtest.Buy.Latest)
+		textSummary += fmt.Sprintf("**Sell price:** %d-%d, averaging %.0f (±%.0f), now %d\n", backtest.Sell.Min, backtest.Sell.Max, backtest.Sell.Mean, backtest.Sell.StdDev, backtest.Sell.Latest)
+		textSummary += fmt.Sprintf("**Margin per unit:** %d to %d, averaging %.0f (±%.0f), now %d\n", backtest.Margin.Min, backtest.Margin.Max, backtest.Margin.Mean, backtest.Margin.StdDev, backtest.Margin.Latest)
+		textSummary += fmt.Sprintf("**Profit per trip of %d units:** %.0f on average, %d at worst, %d at best\n", units, backtest.Margin.Mean*float64(units), backtest.Margin.Min*units, backtest.Margin.Max*units)
+		textSummary += fmt.Sprintf("**Profitable:** %.0f%% of the time\n\n", backtest.ProfitableShare*100)
+
+		switch verdict {
+		case "robust":
+			textSummary += "✅ **Robust** - the route paid throughout the window.\n"
+		case "mostly_profitable":
+			textSummary += "🟢 **Mostly profitable** - the route paid most of the time; check the current margin before each trip.\n"
+		case "unreliable":
+			textSummary += "⚠️ **Unreliable** - the route lost money for much of the window.\n"
+		case "unprofitable":
+			textSummary += "❌ **Unprofitable** - the route never paid in this window.\n"
+		default:
+			textSummary += fmt.Sprintf("⚠️ **Not enough history** - fewer than %d price changes were recorded, so this says little more than a single snapshot. Record more prices or widen the window.\n", minBacktestPoints)
+		}
+		textSummary += "Prices move as you trade, and the recorded ones don't show that, so large trips do worse than this.\n"
+
+		ctxLogger.ToolCall("backtest_route", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// hasPrice reports whether any price of the good was ever recorded at a market
+func (t *BacktestRouteTool) hasPrice(waypointSymbol, good string) bool {
+	for _, snapshot := range t.prices.History(waypointSymbol) {
+		if _, ok := snapshot.Price(good); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// backtestVerdict rates a route by how much of the time it paid
+func backtestVerdict(backtest prices.Backtest) string {
+	switch {
+	case len(backtest.Points) < minBacktestPoints:
+		return "insufficient_data"
+	case backtest.Margin.Min > 0:
+		return "robust"
+	case backtest.ProfitableShare >= 0.75:
+		return "mostly_profitable"
+	case backtest.ProfitableShare > 0:
+		return "unreliable"
+	default:
+		return "unprofitable"
+	}
+}
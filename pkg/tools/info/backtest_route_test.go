@@ -0,0 +1,93 @@
+package info
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBacktestRouteTool_Verdict(t *testing.T) {
+	db := prices.New()
+	now := time.Now()
+	// The sell price drops below the buy price for the last 20 minutes of the window
+	for ago, sellPrice := range map[time.Duration]int{4 * time.Hour: 60, 3 * time.Hour: 58, 2 * time.Hour: 62, 20 * time.Minute: 45} {
+		db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-B2", ObservedAt: now.Add(-ago), Prices: []prices.Price{
+			{TradeSymbol: "IRON_ORE", SellPrice: sellPrice, TradeVolume: 30},
+		}})
+	}
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: now.Add(-5 * time.Hour), Prices: []prices.Price{
+		{TradeSymbol: "IRON_ORE", PurchasePrice: 50, TradeVolume: 20},
+	}})
+
+	result := callTool(t, NewBacktestRouteTool(db, logging.NewLogger(nil)), map[string]interface{}{
+		"buy_waypoint": "x1-test-a1", "sell_waypoint": "X1-TEST-B2", "good": "iron ore", "window_hours": float64(4),
+	})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+
+	text, _ := mcp.AsTextContent(result.Content[0])
+	for _, want := range []string{"IRON_ORE X1-TEST-A1 → X1-TEST-B2", "Margin per unit:** -5 to 12", "Profit per trip of 20 units", "92% of the time", "Mostly profitable"} {
+		if !strings.Contains(text.Text, want) {
+			t.Errorf("Expected %q in: %s", want, text.Text)
+		}
+	}
+	data, _ := result.StructuredContent.(map[string]interface{})
+	if data["verdict"] != "mostly_profitable" || data["units"] != 20 {
+		t.Errorf("Expected a mostly profitable verdict for 20 units, got %v", data)
+	}
+}
+
+func TestBacktestRouteTool_NoHistory(t *testing.T) {
+	db := prices.New()
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: time.Now(), Prices: []prices.Price{
+		{TradeSymbol: "IRON_ORE", PurchasePrice: 50, TradeVolume: 20},
+	}})
+
+	result := callTool(t, NewBacktestRouteTool(db, logging.NewLogger(nil)), map[string]interface{}{
+		"buy_waypoint": "X1-TEST-A1", "sell_waypoint": "X1-TEST-B2", "good": "IRON_ORE",
+	})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+	text, _ := mcp.AsTextContent(result.Content[0])
+	if !strings.Contains(text.Text, "No price of IRON_ORE has been recorded at X1-TEST-B2") || strings.Contains(text.Text, "recorded at X1-TEST-A1") {
+		t.Errorf("Expected only the sell market to be missing prices, got: %s", text.Text)
+	}
+	if data, _ := result.StructuredContent.(map[string]interface{}); data["verdict"] != "insufficient_data" {
+		t.Errorf("Expected insufficient_data, got %v", data["verdict"])
+	}
+}
+
+func TestBacktestRouteTool_InvalidGood(t *testing.T) {
+	result := callTool(t, NewBacktestRouteTool(prices.New(), logging.NewLogger(nil)), map[string]interface{}{
+		"buy_waypoint": "X1-TEST-A1", "sell_waypoint": "X1-TEST-B2", "good": "UNOBTAINIUM",
+	})
+	if !result.IsError {
+		t.Error("Expected an error for an unknown good")
+	}
+}
+
+func TestBacktestVerdict(t *testing.T) {
+	points := make([]prices.BacktestPoint, minBacktestPoints)
+	tests := []struct {
+		backtest prices.Backtest
+		want     string
+	}{
+		{prices.Backtest{Points: points[:1], Margin: prices.PriceStats{Min: 10}, ProfitableShare: 1}, "insufficient_data"},
+		{prices.Backtest{Points: points, Margin: prices.PriceStats{Min: 10}, ProfitableShare: 1}, "robust"},
+		{prices.Backtest{Points: points, Margin: prices.PriceStats{Min: -5}, ProfitableShare: 0.8}, "mostly_profitable"},
+		{prices.Backtest{Points: points, Margin: prices.PriceStats{Min: -5}, ProfitableShare: 0.3}, "unreliable"},
+		{prices.Backtest{Points: points, Margin: prices.PriceStats{Min: -5}}, "unprofitable"},
+	}
+	for _, tt := range tests {
+		if got := backtestVerdict(tt.backtest); got != tt.want {
+			t.Errorf("backtestVerdict(%+v) = %s, want %s", tt.backtest.Margin, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -45,6 +46,9 @@ func (t *ContractInfoTool) Tool() mcp.Tool {
 				},
 			},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"contracts": map[string]interface{}{"type": "array", "description": "Matching contracts"},
+		}, "contracts"),
 	}
 }
 
@@ -55,28 +59,17 @@ func (t *ContractInfoTool) Handler() func(ctx context.Context, request mcp.CallT
 		ctxLogger := t.logger.WithContext(ctx, "contract-info-tool")
 		ctxLogger.Debug("Getting contract information")
 
-		// Parse arguments
-		var contractID string
-		includeFulfilled := false
-
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if id, exists := argsMap["contract_id"]; exists {
-					if idStr, ok := id.(string); ok {
-						contractID = strings.TrimSpace(idStr)
-					}
-				}
-				if include, exists := argsMap["include_fulfilled"]; exists {
-					if includeBool, ok := include.(bool); ok {
-						includeFulfilled = includeBool
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		contractID := v.OptionalString("contract_id", "")
+		includeFulfilled := v.OptionalBool("include_fulfilled", false)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		// Get contracts from API
 		start := time.Now()
-		contracts, err := t.client.GetAllContracts()
+		contracts, err := t.client.WithContext(ctx).GetAllContracts()
 		duration := time.Since(start)
 
 		if err != nil {
@@ -94,7 +87,7 @@ func (t *ContractInfoTool) Handler() func(ctx context.Context, request mcp.CallT
 		ctxLogger.Info("Successfully retrieved %d contracts", len(contracts))
 
 		// Filter contracts if needed
-		var filteredContracts []client.Contract
+		filteredContracts := make([]client.Contract, 0)
 		for _, contract := range contracts {
 			// Skip fulfilled contracts unless explicitly requested
 			if contract.Fulfilled && !includeFulfilled {
@@ -178,11 +171,10 @@ func (t *ContractInfoTool) Handler() func(ctx context.Context, request mcp.CallT
 		ctxLogger.ToolCall("get_contract_info", true)
 		ctxLogger.Debug("Contract info response size: %d bytes", len(response.String()))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(response.String()),
-			},
-		}, nil
+		result := map[string]interface{}{
+			"contracts": filteredContracts,
+		}
+		return utils.NewResult(response.String(), result), nil
 	}
 }
 
@@ -0,0 +1,157 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxTrendBuckets bounds how many periods one report lists
+const maxTrendBuckets = 168
+
+// CreditsTrendTool reports how the agent's credit balance has moved, per hour or per day
+type CreditsTrendTool struct {
+	history *credits.History
+	logger  *logging.Logger
+}
+
+// NewCreditsTrendTool creates a new credits trend tool
+func NewCreditsTrendTool(history *credits.History, logger *logging.Logger) *CreditsTrendTool {
+	return &CreditsTrendTool{
+		history: history,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *CreditsTrendTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "credits_trend",
+		Description: "Answer \"are we getting richer?\" from the credit balance sampled on every API response since the server started: the change and average rate over a window, and the change in each hour or day",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"period": map[string]interface{}{
+					"type":        "string",
+					"description": "Size of each period: 'hour' (default) or 'day'",
+					"enum":        []string{"hour", "day"},
+				},
+				"window_hours": map[string]interface{}{
+					"type":        "number",
+					"description": "How far back to look (optional - defaults to 24 hours for hourly periods and 7 days for daily ones)",
+					"minimum":     0,
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"period":          map[string]interface{}{"type": "string"},
+			"window":          map[string]interface{}{"type": "string"},
+			"current_credits": map[string]interface{}{"type": "integer"},
+			"start_credits":   map[string]interface{}{"type": "integer"},
+			"change":          map[string]interface{}{"type": "integer"},
+			"per_hour":        map[string]interface{}{"type": "number", "description": "Average change per hour over the window"},
+			"per_day":         map[string]interface{}{"type": "number", "description": "Average change per day at the same rate"},
+			"verdict":         map[string]interface{}{"type": "string", "description": "growing, shrinking or flat"},
+			"samples":         map[string]interface{}{"type": "integer"},
+			"buckets":         map[string]interface{}{"type": "array", "description": "Open, close, low, high and change of each period, oldest first"},
+		}, "period", "window", "samples", "buckets"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *CreditsTrendTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "credits-trend-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		period := v.OptionalChoice("period", "hour", "hour", "day")
+		size, window := time.Hour, 24*time.Hour
+		if period == "day" {
+			size, window = 24*time.Hour, 7*24*time.Hour
+		}
+		window = v.OptionalHours("window_hours", window)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+		window = min(window, maxTrendBuckets*size)
+
+		now := time.Now()
+		since := now.Add(-window)
+		windowDescription := fmt.Sprintf("last %s", formatWindow(window))
+		samples := t.history.Samples(since)
+		buckets := t.history.Buckets(size, since, now)
+
+		result := map[string]interface{}{
+			"period":  period,
+			"window":  windowDescription,
+			"samples": len(samples),
+			"buckets": buckets,
+		}
+
+		latest, ok := t.history.Latest()
+		if !ok {
+			ctxLogger.ToolCall("credits_trend", true)
+			return utils.NewResult("## 📈 Credits Trend\n\nNo credit balance has been seen yet. It is sampled from every API response that includes the agent, such as reading agent info or trading.", result), nil
+		}
+		result["current_credits"] = latest.Credits
+
+		textSummary := "## 📈 Credits Trend\n\n"
+		textSummary += fmt.Sprintf("**Window:** %s\n", windowDescription)
+		textSummary += fmt.Sprintf("**Current balance:** %d credits\n", latest.Credits)
+
+		trend, ok := t.history.Trend(since)
+		if !ok {
+			// A single sample, or none since the window began: the balance hasn't been seen to move
+			result["start_credits"] = latest.Credits
+			result["change"] = 0
+			result["verdict"] = "flat"
+			textSummary += "\nNo change in the balance has been seen in this window yet. Check again after some trading or contract work.\n"
+			ctxLogger.ToolCall("credits_trend", true)
+			return utils.NewResult(textSummary, result), nil
+		}
+
+		verdict := "flat"
+		if trend.Delta > 0 {
+			verdict = "growing"
+		} else if trend.Delta < 0 {
+			verdict = "shrinking"
+		}
+		result["start_credits"] = trend.From.Credits
+		result["change"] = trend.Delta
+		result["per_hour"] = trend.PerHour
+		result["per_day"] = trend.PerHour * 24
+		result["verdict"] = verdict
+
+		textSummary += fmt.Sprintf("**Change:** %+d credits since %s (%s)\n", trend.Delta, trend.From.At.UTC().Format(time.RFC3339), verdict)
+		textSummary += fmt.Sprintf("**Average rate:** %+.0f credits/hour (%+.0f/day)\n", trend.PerHour, trend.PerHour*24)
+
+		if len(buckets) > 0 {
+			layout := "Jan 02 15:04"
+			if period == "day" {
+				layout = "Jan 02"
+			}
+			textSummary += fmt.Sprintf("\n**Per %s:**\n", period)
+			for _, bucket := range buckets {
+				textSummary += fmt.Sprintf("- %s: %+d (%d → %d)\n", bucket.Start.Format(layout), bucket.Delta, bucket.Open, bucket.Close)
+			}
+		}
+
+		ctxLogger.ToolCall("credits_trend", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// formatWindow describes a window in whole days when it is one, otherwise in hours
+func formatWindow(window time.Duration) string {
+	if hours := window.Hours(); hours >= 48 && int(hours)%24 == 0 {
+		return fmt.Sprintf("%d days", int(hours)/24)
+	}
+	return fmt.Sprintf("%g hours", window.Round(time.Minute).Hours())
+}
@@ -0,0 +1,53 @@
+package info
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCreditsTrendTool_ReportsGrowth(t *testing.T) {
+	history := credits.New()
+	now := time.Now()
+	history.Record(now.Add(-3*time.Hour), 10000)
+	history.Record(now.Add(-2*time.Hour), 9000)
+	history.Record(now.Add(-time.Hour), 16000)
+
+	tool := NewCreditsTrendTool(history, logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"window_hours": float64(12)})
+	if result.IsError {
+		t.Fatalf("Expected success, got %+v", result)
+	}
+
+	structured, ok := result.StructuredContent.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured content, got %#v", result.StructuredContent)
+	}
+	for _, field := range tool.Tool().OutputSchema.Required {
+		if _, ok := structured[field]; !ok {
+			t.Errorf("Expected structured content to include %s", field)
+		}
+	}
+	if structured["change"] != int64(6000) || structured["verdict"] != "growing" || structured["current_credits"] != int64(16000) {
+		t.Errorf("Expected +6000 and growing, got change %v, verdict %v, current %v", structured["change"], structured["verdict"], structured["current_credits"])
+	}
+	if perHour := structured["per_hour"].(float64); perHour < 2999 || perHour > 3001 {
+		t.Errorf("Expected about 3000 credits/hour, got %v", perHour)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "+6000 credits") || !strings.Contains(text, "Per hour") {
+		t.Errorf("Expected the change and hourly breakdown in the summary, got:\n%s", text)
+	}
+
+	// Periods other than hour and day are rejected
+	result = callTool(t, tool, map[string]interface{}{"period": "week"})
+	if !result.IsError {
+		t.Error("Expected an unknown period to be rejected")
+	}
+}
@@ -0,0 +1,396 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fuelUnitsPerMarketUnit is how much ship fuel one unit of FUEL bought at a market provides
+const fuelUnitsPerMarketUnit = 100
+
+// EvaluateContractsTool ranks open contract offers by expected profit and feasibility
+type EvaluateContractsTool struct {
+	client *client.Client
+	ledger *ledger.Ledger
+	logger *logging.Logger
+}
+
+// NewEvaluateContractsTool creates a new contract evaluation tool.
+// When a ledger is given, prices paid earlier are used for goods no visible market quotes.
+func NewEvaluateContractsTool(client *client.Client, l *ledger.Ledger, logger *logging.Logger) *EvaluateContractsTool {
+	return &EvaluateContractsTool{
+		client: client,
+		ledger: l,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *EvaluateContractsTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "evaluate_contracts",
+		Description: "Rank unaccepted contracts by net expected profit (payment minus goods cost from known market prices minus fuel), with the number of trips your largest cargo hold needs and whether the deadline is achievable",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"contracts": map[string]interface{}{"type": "array", "description": "Evaluation of each open contract"},
+			"count":     map[string]interface{}{"type": "integer"},
+			"hauler":    map[string]interface{}{"type": "object", "description": "Ship the costs were estimated for, if any"},
+		}, "contracts", "count"),
+	}
+}
+
+// priceQuote is the best known purchase price for a good
+type priceQuote struct {
+	Waypoint string
+	Price    int
+	Source   string
+}
+
+// contractHauler is the ship assumed to carry a contract's goods
+type contractHauler struct {
+	Symbol   string `json:"symbol"`
+	Capacity int    `json:"capacity"`
+	Speed    int    `json:"speed"`
+}
+
+// deliveryEstimate is the cost of sourcing and delivering one good for a contract
+type deliveryEstimate struct {
+	TradeSymbol    string  `json:"tradeSymbol"`
+	Destination    string  `json:"destination"`
+	Units          int     `json:"units"`
+	SourceWaypoint string  `json:"sourceWaypoint,omitempty"`
+	PriceSource    string  `json:"priceSource,omitempty"`
+	UnitPrice      int     `json:"unitPrice,omitempty"`
+	GoodsCost      int     `json:"goodsCost"`
+	Distance       float64 `json:"distance"`
+	Trips          int     `json:"trips"`
+	Fuel           int     `json:"fuel"`
+	TravelSeconds  int     `json:"travelSeconds"`
+}
+
+// contractEvaluation is the outcome of evaluating one contract
+type contractEvaluation struct {
+	ContractID       string             `json:"contractId"`
+	Faction          string             `json:"faction"`
+	Type             string             `json:"type"`
+	Payment          int                `json:"payment"`
+	GoodsCost        int                `json:"goodsCost"`
+	FuelCost         int                `json:"fuelCost"`
+	NetProfit        int                `json:"netProfit"`
+	CostKnown        bool               `json:"costKnown"`
+	Trips            int                `json:"trips"`
+	Hauler           string             `json:"hauler,omitempty"`
+	EstimatedSeconds int                `json:"estimatedSeconds"`
+	Deadline         string             `json:"deadline"`
+	SecondsRemaining int                `json:"secondsRemaining"`
+	Feasible         bool               `json:"feasible"`
+	Deliveries       []deliveryEstimate `json:"deliveries"`
+	Warnings         []string           `json:"warnings,omitempty"`
+}
+
+// Handler returns the tool handler function
+func (t *EvaluateContractsTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "evaluate-contracts-tool")
+		ctxLogger.Debug("Evaluating contracts")
+
+		start := time.Now()
+		contracts, err := t.client.WithContext(ctx).GetAllContracts()
+		duration := time.Since(start)
+		if err != nil {
+			ctxLogger.Error("Failed to fetch contracts: %v", err)
+			ctxLogger.APICall("/my/contracts", 0, duration.String())
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching contracts: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		ctxLogger.APICall("/my/contracts", 200, duration.String())
+
+		start = time.Now()
+		ships, err := t.client.WithContext(ctx).GetAllShips()
+		duration = time.Since(start)
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			ctxLogger.APICall("/my/ships", 0, duration.String())
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching ships: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		ctxLogger.APICall("/my/ships", 200, duration.String())
+
+		hauler := largestHold(ships)
+		now := time.Now()
+
+		// Quotes and coordinates are looked up once per destination system
+		quotes := make(map[string]map[string]priceQuote)
+		coordinates := make(map[string]map[string]client.SystemWaypoint)
+
+		evaluations := make([]contractEvaluation, 0)
+		for _, contract := range contracts {
+			if contract.Accepted || contract.Fulfilled {
+				continue
+			}
+			if deadline, err := time.Parse(time.RFC3339, contract.DeadlineToAccept); err == nil && deadline.Before(now) {
+				continue
+			}
+
+			for _, delivery := range contract.Terms.Deliver {
+				system := travel.SystemSymbol(delivery.DestinationSymbol)
+				if _, ok := quotes[system]; !ok {
+					quotes[system], coordinates[system] = systemMarkets(ctx, t.client, t.ledger, ctxLogger, system)
+				}
+			}
+
+			evaluations = append(evaluations, evaluateContract(contract, quotes, coordinates, hauler, now))
+		}
+
+		rankEvaluations(evaluations)
+		ctxLogger.Info("Evaluated %d open contracts", len(evaluations))
+
+		result := map[string]interface{}{
+			"contracts": evaluations,
+			"count":     len(evaluations),
+		}
+		if hauler.Symbol != "" {
+			result["hauler"] = hauler
+		}
+
+		var response strings.Builder
+		response.WriteString("## 📊 Contract Evaluation\n\n")
+		if len(evaluations) == 0 {
+			response.WriteString("No open contract offers to evaluate. Check `get_contract_info` for contracts already in progress.\n")
+		} else {
+			if hauler.Symbol != "" {
+				fmt.Fprintf(&response, "Assuming %s (cargo %d, engine speed %d) hauls the goods in CRUISE mode.\n\n", hauler.Symbol, hauler.Capacity, hauler.Speed)
+			}
+			for i, evaluation := range evaluations {
+				verdict := "✅"
+				if !evaluation.Feasible {
+					verdict = "⏰"
+				} else if !evaluation.CostKnown {
+					verdict = "❔"
+				} else if evaluation.NetProfit <= 0 {
+					verdict = "📉"
+				}
+
+				fmt.Fprintf(&response, "### %d. %s %s (%s, %s)\n", i+1, verdict, evaluation.ContractID, evaluation.Type, evaluation.Faction)
+				fmt.Fprintf(&response, "- **Payment:** %d credits\n", evaluation.Payment)
+				if evaluation.CostKnown {
+					fmt.Fprintf(&response, "- **Net Profit:** %d credits (goods %d, fuel %d)\n", evaluation.NetProfit, evaluation.GoodsCost, evaluation.FuelCost)
+				} else {
+					fmt.Fprintf(&response, "- **Net Profit:** unknown - no price data for some goods (known costs: goods %d, fuel %d)\n", evaluation.GoodsCost, evaluation.FuelCost)
+				}
+				fmt.Fprintf(&response, "- **Trips:** %d, about %s of flying\n", evaluation.Trips, time.Duration(evaluation.EstimatedSeconds)*time.Second)
+				fmt.Fprintf(&response, "- **Deadline:** %s (%s remaining)\n", evaluation.Deadline, time.Duration(evaluation.SecondsRemaining)*time.Second)
+				for _, delivery := range evaluation.Deliveries {
+					fmt.Fprintf(&response, "- Deliver %d %s to %s", delivery.Units, delivery.TradeSymbol, delivery.Destination)
+					if delivery.SourceWaypoint != "" {
+						fmt.Fprintf(&response, " (buy at %s for %d/unit)", delivery.SourceWaypoint, delivery.UnitPrice)
+					}
+					response.WriteString("\n")
+				}
+				for _, warning := range evaluation.Warnings {
+					fmt.Fprintf(&response, "- ⚠️ %s\n", warning)
+				}
+				response.WriteString("\n")
+			}
+			response.WriteString("💡 Goods prices come from markets where you currently have a ship and from your own past purchases; visit more markets for better estimates.\n")
+		}
+
+		ctxLogger.ToolCall("evaluate_contracts", true)
+
+		return utils.NewResult(response.String(), result), nil
+	}
+}
+
+// systemMarkets returns the cheapest visible purchase price of each good in a system and the
+// system's waypoints by symbol. Prices the agent paid before fill in goods no market quotes.
+func systemMarkets(ctx context.Context, c *client.Client, l *ledger.Ledger, ctxLogger *logging.ContextLogger, systemSymbol string) (map[string]priceQuote, map[string]client.SystemWaypoint) {
+	quotes := make(map[string]priceQuote)
+	coordinates := make(map[string]client.SystemWaypoint)
+
+	waypoints, _, err := c.WithContext(ctx).GetCachedSystemWaypoints(systemSymbol)
+	if err != nil {
+		ctxLogger.Error("Failed to fetch waypoints for %s: %v", systemSymbol, err)
+		return quotes, coordinates
+	}
+
+	for _, waypoint := range waypoints {
+		coordinates[waypoint.Symbol] = waypoint
+
+		isMarket := false
+		for _, trait := range waypoint.Traits {
+			if trait.Symbol == "MARKETPLACE" {
+				isMarket = true
+				break
+			}
+		}
+		if !isMarket {
+			continue
+		}
+
+		market, err := c.WithContext(ctx).GetMarket(systemSymbol, waypoint.Symbol)
+		if err != nil {
+			ctxLogger.Debug("Skipping market %s: %v", waypoint.Symbol, err)
+			continue
+		}
+		for _, good := range market.TradeGoods {
+			if quote, ok := quotes[good.Symbol]; !ok || good.PurchasePrice < quote.Price {
+				quotes[good.Symbol] = priceQuote{Waypoint: waypoint.Symbol, Price: good.PurchasePrice, Source: "market"}
+			}
+		}
+	}
+
+	if l != nil {
+		for _, entry := range l.Query(ledger.Filter{}) {
+			if entry.Category != ledger.CategoryMarketPurchase && entry.Category != ledger.CategoryRefuel {
+				continue
+			}
+			if travel.SystemSymbol(entry.WaypointSymbol) != systemSymbol {
+				continue
+			}
+			if quote, ok := quotes[entry.TradeSymbol]; !ok || quote.Source == "ledger" {
+				// Entries are oldest first, so the most recent purchase wins
+				quotes[entry.TradeSymbol] = priceQuote{Waypoint: entry.WaypointSymbol, Price: entry.PricePerUnit, Source: "ledger"}
+			}
+		}
+	}
+
+	return quotes, coordinates
+}
+
+// largestHold picks the ship with the most cargo capacity to carry contract goods
+func largestHold(ships []client.Ship) contractHauler {
+	var hauler contractHauler
+	for _, ship := range ships {
+		if ship.Cargo.Capacity > hauler.Capacity {
+			hauler = contractHauler{Symbol: ship.Symbol, Capacity: ship.Cargo.Capacity, Speed: ship.Engine.Speed}
+		}
+	}
+	return hauler
+}
+
+// evaluateContract estimates the profit, trips and travel time of a contract.
+// Each trip is a round trip in CRUISE between the cheapest known source and the destination.
+func evaluateContract(contract client.Contract, quotes map[string]map[string]priceQuote, coordinates map[string]map[string]client.SystemWaypoint, hauler contractHauler, now time.Time) contractEvaluation {
+	evaluation := contractEvaluation{
+		ContractID: contract.ID,
+		Faction:    contract.FactionSymbol,
+		Type:       contract.Type,
+		Payment:    contract.Terms.Payment.OnAccepted + contract.Terms.Payment.OnFulfilled,
+		CostKnown:  true,
+		Hauler:     hauler.Symbol,
+		Deadline:   contract.Terms.Deadline,
+		Deliveries: make([]deliveryEstimate, 0, len(contract.Terms.Deliver)),
+	}
+
+	if hauler.Capacity == 0 {
+		evaluation.Warnings = append(evaluation.Warnings, "no ship with cargo space to haul the goods")
+	}
+
+	fuel := 0
+	for _, deliver := range contract.Terms.Deliver {
+		system := travel.SystemSymbol(deliver.DestinationSymbol)
+		delivery := deliveryEstimate{
+			TradeSymbol: deliver.TradeSymbol,
+			Destination: deliver.DestinationSymbol,
+			Units:       deliver.UnitsRequired - deliver.UnitsFulfilled,
+		}
+		if hauler.Capacity > 0 {
+			delivery.Trips = (delivery.Units + hauler.Capacity - 1) / hauler.Capacity
+		}
+
+		quote, ok := quotes[system][deliver.TradeSymbol]
+		if !ok {
+			evaluation.CostKnown = false
+			evaluation.Warnings = append(evaluation.Warnings, fmt.Sprintf("no known price for %s in %s - it may need to be mined or bought elsewhere", deliver.TradeSymbol, system))
+		} else {
+			delivery.SourceWaypoint = quote.Waypoint
+			delivery.PriceSource = quote.Source
+			delivery.UnitPrice = quote.Price
+			delivery.GoodsCost = quote.Price * delivery.Units
+
+			source, sourceOK := coordinates[system][quote.Waypoint]
+			destination, destinationOK := coordinates[system][deliver.DestinationSymbol]
+			if sourceOK && destinationOK {
+				delivery.Distance = travel.Distance(source.X, source.Y, destination.X, destination.Y)
+				legs := 2 * delivery.Trips
+				delivery.Fuel = legs * travel.FuelCost(delivery.Distance, "CRUISE")
+				delivery.TravelSeconds = legs * int(travel.TravelTime(delivery.Distance, "CRUISE", hauler.Speed).Seconds())
+			}
+		}
+
+		evaluation.GoodsCost += delivery.GoodsCost
+		evaluation.Trips += delivery.Trips
+		evaluation.EstimatedSeconds += delivery.TravelSeconds
+		fuel += delivery.Fuel
+		evaluation.Deliveries = append(evaluation.Deliveries, delivery)
+	}
+
+	if fuel > 0 {
+		if fuelQuote, ok := fuelPrice(quotes); ok {
+			evaluation.FuelCost = (fuel*fuelQuote + fuelUnitsPerMarketUnit - 1) / fuelUnitsPerMarketUnit
+		} else {
+			evaluation.Warnings = append(evaluation.Warnings, fmt.Sprintf("needs about %d fuel but no fuel price is known", fuel))
+		}
+	}
+	evaluation.NetProfit = evaluation.Payment - evaluation.GoodsCost - evaluation.FuelCost
+
+	evaluation.Feasible = hauler.Capacity > 0
+	if deadline, err := time.Parse(time.RFC3339, contract.Terms.Deadline); err == nil {
+		remaining := deadline.Sub(now)
+		evaluation.SecondsRemaining = int(remaining.Seconds())
+		if time.Duration(evaluation.EstimatedSeconds)*time.Second > remaining {
+			evaluation.Feasible = false
+			evaluation.Warnings = append(evaluation.Warnings, "estimated travel time exceeds the time left before the deadline")
+		}
+	}
+
+	return evaluation
+}
+
+// fuelPrice returns the cheapest known FUEL price in any evaluated system
+func fuelPrice(quotes map[string]map[string]priceQuote) (int, bool) {
+	price, found := 0, false
+	for _, systemQuotes := range quotes {
+		if quote, ok := systemQuotes["FUEL"]; ok && (!found || quote.Price < price) {
+			price, found = quote.Price, true
+		}
+	}
+	return price, found
+}
+
+// rankEvaluations orders contracts best first: feasible before infeasible, known costs
+// before unknown, then by net profit
+func rankEvaluations(evaluations []contractEvaluation) {
+	sort.SliceStable(evaluations, func(i, j int) bool {
+		a, b := evaluations[i], evaluations[j]
+		if a.Feasible != b.Feasible {
+			return a.Feasible
+		}
+		if a.CostKnown != b.CostKnown {
+			return a.CostKnown
+		}
+		return a.NetProfit > b.NetProfit
+	})
+}
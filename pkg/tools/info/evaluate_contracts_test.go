@@ -0,0 +1,79 @@
+package info
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestEvaluateContract(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	quotes := map[string]map[string]priceQuote{
+		"X1-TEST": {
+			"IRON_ORE": {Waypoint: "X1-TEST-MARKET", Price: 20, Source: "market"},
+			"FUEL":     {Waypoint: "X1-TEST-MARKET", Price: 70, Source: "market"},
+		},
+	}
+	coordinates := map[string]map[string]client.SystemWaypoint{
+		"X1-TEST": {
+			"X1-TEST-MARKET": {Symbol: "X1-TEST-MARKET", X: 0, Y: 0},
+			"X1-TEST-HQ":     {Symbol: "X1-TEST-HQ", X: 30, Y: 40},
+		},
+	}
+	hauler := contractHauler{Symbol: "HAULER-1", Capacity: 40, Speed: 30}
+
+	contract := client.Contract{
+		ID:   "profitable",
+		Type: "PROCUREMENT",
+		Terms: client.ContractTerms{
+			Deadline: now.Add(24 * time.Hour).Format(time.RFC3339),
+			Payment:  client.ContractPayment{OnAccepted: 1000, OnFulfilled: 5000},
+			Deliver: []client.ContractDeliverGood{
+				{TradeSymbol: "IRON_ORE", DestinationSymbol: "X1-TEST-HQ", UnitsRequired: 100, UnitsFulfilled: 10},
+			},
+		},
+	}
+
+	evaluation := evaluateContract(contract, quotes, coordinates, hauler, now)
+
+	if !evaluation.CostKnown || !evaluation.Feasible {
+		t.Fatalf("Expected a feasible contract with known costs, got %+v", evaluation)
+	}
+	if evaluation.Trips != 3 {
+		t.Errorf("Expected 90 units in a 40 unit hold to take 3 trips, got %d", evaluation.Trips)
+	}
+	if evaluation.GoodsCost != 1800 {
+		t.Errorf("Expected goods cost 1800, got %d", evaluation.GoodsCost)
+	}
+	// 6 legs of 50 units at 50 fuel each = 300 fuel, 3 market units at 70 credits
+	if evaluation.FuelCost != 210 {
+		t.Errorf("Expected fuel cost 210, got %d", evaluation.FuelCost)
+	}
+	if evaluation.NetProfit != 6000-1800-210 {
+		t.Errorf("Expected net profit %d, got %d", 6000-1800-210, evaluation.NetProfit)
+	}
+
+	contract.ID = "unknown"
+	contract.Terms.Deliver[0].TradeSymbol = "GOLD"
+	unknown := evaluateContract(contract, quotes, coordinates, hauler, now)
+	if unknown.CostKnown || len(unknown.Warnings) == 0 {
+		t.Errorf("Expected unknown cost with a warning for an unpriced good, got %+v", unknown)
+	}
+
+	contract.ID = "late"
+	contract.Terms.Deliver[0].TradeSymbol = "IRON_ORE"
+	contract.Terms.Deadline = now.Add(time.Minute).Format(time.RFC3339)
+	late := evaluateContract(contract, quotes, coordinates, hauler, now)
+	if late.Feasible {
+		t.Errorf("Expected contract with a one minute deadline to be infeasible, got %+v", late)
+	}
+
+	evaluations := []contractEvaluation{late, unknown, evaluation}
+	rankEvaluations(evaluations)
+	if evaluations[0].ContractID != "profitable" || evaluations[1].ContractID != "unknown" || evaluations[2].ContractID != "late" {
+		t.Errorf("Expected ranking profitable, unknown, late; got %s, %s, %s",
+			evaluations[0].ContractID, evaluations[1].ContractID, evaluations[2].ContractID)
+	}
+}
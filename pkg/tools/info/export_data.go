@@ -0,0 +1,357 @@
+package info
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// exportDatasets are the datasets export_data can write, in the order they are listed
+var exportDatasets = []string{"ledger", "prices", "mining", "fleet", "credits"}
+
+// exportTable is a dataset ready to write: rows for CSV, and the original records for JSON
+type exportTable struct {
+	header  []string
+	rows    [][]string
+	records interface{}
+}
+
+// ExportDataTool writes recorded data to local CSV or JSON files for offline analysis
+type ExportDataTool struct {
+	client  *client.Client
+	dir     string
+	ledger  *ledger.Ledger
+	prices  *prices.DB
+	mining  *mining.Recorder
+	credits *credits.History
+	logger  *logging.Logger
+}
+
+// NewExportDataTool creates a new export data tool that writes files under dir
+func NewExportDataTool(client *client.Client, dir string, logger *logging.Logger) *ExportDataTool {
+	return &ExportDataTool{
+		client: client,
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// WithLedger enables exporting the transactions ledger
+func (t *ExportDataTool) WithLedger(l *ledger.Ledger) *ExportDataTool {
+	t.ledger = l
+	return t
+}
+
+// WithPrices enables exporting the market price history
+func (t *ExportDataTool) WithPrices(db *prices.DB) *ExportDataTool {
+	t.prices = db
+	return t
+}
+
+// WithMining enables exporting recorded extractions
+func (t *ExportDataTool) WithMining(m *mining.Recorder) *ExportDataTool {
+	t.mining = m
+	return t
+}
+
+// WithCredits enables exporting the credit balance history
+func (t *ExportDataTool) WithCredits(h *credits.History) *ExportDataTool {
+	t.credits = h
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *ExportDataTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "export_data",
+		Description: fmt.Sprintf("Write the transactions ledger, market price history, mining extractions, a fleet snapshot or the credit balance history to a CSV or JSON file for analysis in a spreadsheet or notebook. Files are written under %s.", t.dir),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"dataset": map[string]interface{}{
+					"type":        "string",
+					"description": "What to export: 'ledger' (every credit movement), 'prices' (every price seen at every market), 'mining' (every extraction), 'fleet' (the ships as they are now) or 'credits' (the balance over time)",
+					"enum":        exportDatasets,
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "'csv' or 'json' (optional - taken from the path's extension, otherwise csv)",
+					"enum":        []string{"csv", "json"},
+				},
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "File to write, relative to the export directory (optional - defaults to the dataset name and the current time)",
+				},
+				"overwrite": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Replace the file if it already exists (default false)",
+				},
+			},
+			Required: []string{"dataset"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"dataset": map[string]interface{}{"type": "string"},
+			"format":  map[string]interface{}{"type": "string"},
+			"path":    map[string]interface{}{"type": "string", "description": "Absolute path of the file written"},
+			"rows":    map[string]interface{}{"type": "integer", "description": "Rows written, not counting the CSV header"},
+			"bytes":   map[string]interface{}{"type": "integer"},
+		}, "dataset", "format", "path", "rows"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *ExportDataTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "export-data-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		dataset := v.RequireChoice("dataset", exportDatasets...)
+		path := v.OptionalString("path", "")
+		defaultFormat := "csv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			defaultFormat = "json"
+		}
+		format := v.OptionalChoice("format", defaultFormat, "csv", "json")
+		overwrite := v.OptionalBool("overwrite", false)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		if path == "" {
+			path = fmt.Sprintf("%s-%s", dataset, time.Now().UTC().Format("20060102-150405"))
+		}
+		if filepath.Ext(path) == "" {
+			path += "." + format
+		}
+		target, err := resolveExportPath(t.dir, path)
+		if err != nil {
+			return exportError(err.Error()), nil
+		}
+		if !overwrite {
+			if _, err := os.Stat(target); err == nil {
+				return exportError(fmt.Sprintf("%s already exists. Choose another path or set overwrite to true.", target)), nil
+			}
+		}
+
+		table, err := t.collect(ctx, dataset)
+		if err != nil {
+			ctxLogger.Error("Failed to collect %s for export: %v", dataset, err)
+			ctxLogger.ToolCall("export_data", false)
+			return exportError(err.Error()), nil
+		}
+
+		data, err := table.encode(format)
+		if err != nil {
+			ctxLogger.Error("Failed to encode %s as %s: %v", dataset, format, err)
+			ctxLogger.ToolCall("export_data", false)
+			return exportError(fmt.Sprintf("Failed to encode %s as %s: %v", dataset, format, err)), nil
+		}
+		if err := writeExport(target, data); err != nil {
+			ctxLogger.Error("Failed to write export %s: %v", target, err)
+			ctxLogger.ToolCall("export_data", false)
+			return exportError(fmt.Sprintf("Failed to write %s: %v", target, err)), nil
+		}
+
+		result := map[string]interface{}{
+			"dataset": dataset,
+			"format":  format,
+			"path":    target,
+			"rows":    len(table.rows),
+			"bytes":   len(data),
+		}
+
+		textSummary := "## 💾 Data Exported\n\n"
+		textSummary += fmt.Sprintf("**Dataset:** %s\n", dataset)
+		textSummary += fmt.Sprintf("**File:** %s\n", target)
+		textSummary += fmt.Sprintf("**Rows:** %d (%s, %d bytes)\n", len(table.rows), format, len(data))
+		if len(table.rows) == 0 {
+			textSummary += "\nNothing has been recorded for this dataset yet, so the file is empty apart from its header.\n"
+		}
+
+		ctxLogger.ToolCall("export_data", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// collect gathers a dataset into a table
+func (t *ExportDataTool) collect(ctx context.Context, dataset string) (exportTable, error) {
+	switch dataset {
+	case "ledger":
+		if t.ledger == nil {
+			return exportTable{}, fmt.Errorf("the transactions ledger is not available")
+		}
+		entries := t.ledger.Query(ledger.Filter{})
+		table := exportTable{
+			header:  []string{"timestamp", "category", "ship", "waypoint", "trade_symbol", "contract_id", "units", "price_per_unit", "amount"},
+			records: entries,
+		}
+		for _, e := range entries {
+			table.rows = append(table.rows, []string{
+				formatExportTime(e.Timestamp), string(e.Category), e.ShipSymbol, e.WaypointSymbol, e.TradeSymbol, e.ContractID,
+				strconv.Itoa(e.Units), strconv.Itoa(e.PricePerUnit), strconv.Itoa(e.Amount),
+			})
+		}
+		return table, nil
+
+	case "prices":
+		if t.prices == nil {
+			return exportTable{}, fmt.Errorf("the price database is not available")
+		}
+		snapshots := []prices.Snapshot{}
+		table := exportTable{
+			header: []string{"observed_at", "waypoint", "trade_symbol", "type", "supply", "activity", "purchase_price", "sell_price", "trade_volume"},
+		}
+		for _, market := range t.prices.Markets() {
+			for _, snapshot := range t.prices.History(market) {
+				snapshots = append(snapshots, snapshot)
+				for _, p := range snapshot.Prices {
+					table.rows = append(table.rows, []string{
+						formatExportTime(snapshot.ObservedAt), snapshot.WaypointSymbol, p.TradeSymbol, p.Type, p.Supply, p.Activity,
+						strconv.Itoa(p.PurchasePrice), strconv.Itoa(p.SellPrice), strconv.Itoa(p.TradeVolume),
+					})
+				}
+			}
+		}
+		table.records = snapshots
+		return table, nil
+
+	case "mining":
+		if t.mining == nil {
+			return exportTable{}, fmt.Errorf("mining statistics are not available")
+		}
+		extractions := t.mining.Extractions(time.Time{})
+		table := exportTable{
+			header:  []string{"extracted_at", "ship", "waypoint", "trade_symbol", "units", "surveyed"},
+			records: extractions,
+		}
+		for _, e := range extractions {
+			table.rows = append(table.rows, []string{
+				formatExportTime(e.ExtractedAt), e.ShipSymbol, e.WaypointSymbol, e.TradeSymbol,
+				strconv.Itoa(e.Units), strconv.FormatBool(e.Surveyed),
+			})
+		}
+		return table, nil
+
+	case "fleet":
+		ships, err := t.client.WithContext(ctx).GetAllShips()
+		if err != nil {
+			return exportTable{}, fmt.Errorf("failed to get ships: %v", err)
+		}
+		table := exportTable{
+			header:  []string{"symbol", "role", "frame", "system", "waypoint", "status", "flight_mode", "fuel_current", "fuel_capacity", "cargo_units", "cargo_capacity", "condition", "cooldown_seconds"},
+			records: ships,
+		}
+		for _, s := range ships {
+			table.rows = append(table.rows, []string{
+				s.Symbol, s.Registration.Role, s.Frame.Symbol, s.Nav.SystemSymbol, s.Nav.WaypointSymbol, s.Nav.Status, s.Nav.FlightMode,
+				strconv.Itoa(s.Fuel.Current), strconv.Itoa(s.Fuel.Capacity), strconv.Itoa(s.Cargo.Units), strconv.Itoa(s.Cargo.Capacity),
+				strconv.FormatFloat(s.Frame.Condition, 'f', -1, 64), strconv.Itoa(s.Cooldown.RemainingSeconds),
+			})
+		}
+		return table, nil
+
+	case "credits":
+		if t.credits == nil {
+			return exportTable{}, fmt.Errorf("the credits history is not available")
+		}
+		samples := t.credits.Samples(time.Time{})
+		table := exportTable{
+			header:  []string{"at", "credits"},
+			records: samples,
+		}
+		for _, s := range samples {
+			table.rows = append(table.rows, []string{formatExportTime(s.At), strconv.FormatInt(s.Credits, 10)})
+		}
+		return table, nil
+
+	default:
+		return exportTable{}, fmt.Errorf("unknown dataset %q: use one of %s", dataset, strings.Join(exportDatasets, ", "))
+	}
+}
+
+// encode renders the table as CSV with a header row, or as an indented JSON array of records
+func (table exportTable) encode(format string) ([]byte, error) {
+	if format == "json" {
+		data, err := json.MarshalIndent(table.records, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(table.header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(table.rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resolveExportPath turns a path relative to the export directory into an absolute one, refusing
+// paths that would land outside it
+func resolveExportPath(dir, path string) (string, error) {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid export directory %s: %v", dir, err)
+	}
+	target := path
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(base, target)
+	}
+	target = filepath.Clean(target)
+
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path must be a file inside the export directory %s, got %q", base, path)
+	}
+	return target, nil
+}
+
+// writeExport writes data through a temporary file so a failed write never leaves half a file
+func writeExport(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// formatExportTime writes times in UTC RFC 3339, which spreadsheets and notebooks both parse
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// exportError builds an error result for export_data
+func exportError(message string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{mcp.NewTextContent("❌ " + message)},
+		IsError: true,
+	}
+}
@@ -0,0 +1,101 @@
+package info
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestExportDataTool_WritesCSVAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	history := credits.New()
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	history.Record(start, 1000)
+	history.Record(start.Add(time.Hour), 2500)
+
+	tool := NewExportDataTool(nil, dir, logging.NewLogger(nil)).WithCredits(history)
+
+	result := callTool(t, tool, map[string]interface{}{"dataset": "credits", "path": "reports/credits"})
+	if result.IsError {
+		t.Fatalf("Expected success, got %+v", result.Content)
+	}
+	structured := result.StructuredContent.(map[string]interface{})
+	for _, field := range tool.Tool().OutputSchema.Required {
+		if _, ok := structured[field]; !ok {
+			t.Errorf("Expected structured content to include %s", field)
+		}
+	}
+	csvPath := filepath.Join(dir, "reports", "credits.csv")
+	if structured["path"] != csvPath || structured["format"] != "csv" || structured["rows"] != 2 {
+		t.Fatalf("Expected 2 csv rows at %s, got %v", csvPath, structured)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("Expected the csv to be written: %v", err)
+	}
+	if want := "at,credits\n2026-01-02T03:00:00Z,1000\n2026-01-02T04:00:00Z,2500\n"; string(data) != want {
+		t.Errorf("Expected csv %q, got %q", want, data)
+	}
+
+	// The extension picks the format
+	result = callTool(t, tool, map[string]interface{}{"dataset": "credits", "path": "credits.json"})
+	if result.IsError {
+		t.Fatalf("Expected success, got %+v", result.Content)
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "credits.json"))
+	if err != nil {
+		t.Fatalf("Expected the json to be written: %v", err)
+	}
+	var samples []credits.Sample
+	if err := json.Unmarshal(data, &samples); err != nil || len(samples) != 2 || samples[1].Credits != 2500 {
+		t.Errorf("Expected two samples in the json, got %v (%v)", samples, err)
+	}
+
+	// An existing file is kept unless overwrite is set
+	result = callTool(t, tool, map[string]interface{}{"dataset": "credits", "path": "credits.json"})
+	if !result.IsError {
+		t.Error("Expected an error when the file already exists")
+	}
+	result = callTool(t, tool, map[string]interface{}{"dataset": "credits", "path": "credits.json", "overwrite": true})
+	if result.IsError {
+		t.Errorf("Expected overwrite to replace the file, got %+v", result.Content)
+	}
+}
+
+func TestExportDataTool_RejectsBadRequests(t *testing.T) {
+	dir := t.TempDir()
+	tool := NewExportDataTool(nil, dir, logging.NewLogger(nil))
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want string
+	}{
+		{"missing dataset", map[string]interface{}{}, "dataset: is required"},
+		{"unknown dataset", map[string]interface{}{"dataset": "contracts"}, "dataset: unknown value 'contracts'"},
+		{"unavailable dataset", map[string]interface{}{"dataset": "ledger"}, "not available"},
+		{"bad format", map[string]interface{}{"dataset": "credits", "format": "xlsx"}, "format: unknown value 'xlsx'"},
+		{"escaping path", map[string]interface{}{"dataset": "credits", "path": "../outside.csv"}, "inside the export directory"},
+		{"absolute path elsewhere", map[string]interface{}{"dataset": "credits", "path": filepath.Join(os.TempDir(), "outside.csv")}, "inside the export directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, tool, tt.args)
+			if !result.IsError {
+				t.Fatal("Expected an error result")
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, tt.want) {
+				t.Errorf("Expected error mentioning %q, got %q", tt.want, text)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -40,6 +41,11 @@ func (t *FleetAnalysisTool) Tool() mcp.Tool {
 				},
 			},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"total_ships": map[string]interface{}{"type": "integer"},
+			"fleet":       map[string]interface{}{"type": "object", "description": "Fleet capability totals"},
+			"contracts":   map[string]interface{}{"type": "object", "description": "What the active contracts require"},
+		}, "total_ships", "fleet", "contracts"),
 	}
 }
 
@@ -50,20 +56,15 @@ func (t *FleetAnalysisTool) Handler() func(ctx context.Context, request mcp.Call
 		ctxLogger := t.logger.WithContext(ctx, "fleet-analysis-tool")
 		ctxLogger.Debug("Analyzing fleet capabilities")
 
-		// Parse arguments
-		includeRecommendations := true
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if rec, exists := argsMap["include_recommendations"]; exists {
-					if recBool, ok := rec.(bool); ok {
-						includeRecommendations = recBool
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		includeRecommendations := v.OptionalBool("include_recommendations", true)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		// Get current fleet
-		ships, err := t.client.GetAllShips()
+		ships, err := t.client.WithContext(ctx).GetAllShips()
 		if err != nil {
 			ctxLogger.Error("Failed to fetch ships: %v", err)
 			return &mcp.CallToolResult{
@@ -75,7 +76,7 @@ func (t *FleetAnalysisTool) Handler() func(ctx context.Context, request mcp.Call
 		}
 
 		// Get current contracts
-		contracts, err := t.client.GetAllContracts()
+		contracts, err := t.client.WithContext(ctx).GetAllContracts()
 		if err != nil {
 			ctxLogger.Error("Failed to fetch contracts: %v", err)
 			return &mcp.CallToolResult{
@@ -142,44 +143,46 @@ func (t *FleetAnalysisTool) Handler() func(ctx context.Context, request mcp.Call
 			response.WriteString(t.generateRecommendations(fleetAnalysis, contractRequirements))
 		}
 
+		result := map[string]interface{}{
+			"total_ships": len(ships),
+			"fleet":       fleetAnalysis,
+			"contracts":   contractRequirements,
+		}
+
 		ctxLogger.ToolCall("analyze_fleet_capabilities", true)
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(response.String()),
-			},
-		}, nil
+		return utils.NewResult(response.String(), result), nil
 	}
 }
 
 // FleetAnalysis holds fleet capability data
 type FleetAnalysis struct {
-	TotalCargo   int
-	MiningShips  int
-	HaulingShips int
-	CombatShips  int
-	ShipsByType  map[string]int
+	TotalCargo   int            `json:"totalCargo"`
+	MiningShips  int            `json:"miningShips"`
+	HaulingShips int            `json:"haulingShips"`
+	CombatShips  int            `json:"combatShips"`
+	ShipsByType  map[string]int `json:"shipsByType"`
 }
 
 // ContractRequirements holds contract requirement data
 type ContractRequirements struct {
-	ActiveContracts  []ContractRequirement
-	TotalCargoNeeded int
-	RequiresMining   bool
+	ActiveContracts  []ContractRequirement `json:"activeContracts"`
+	TotalCargoNeeded int                   `json:"totalCargoNeeded"`
+	RequiresMining   bool                  `json:"requiresMining"`
 }
 
 // ContractRequirement holds individual contract requirements
 type ContractRequirement struct {
-	ContractID        string
-	Status            string
-	RequiredMaterials []MaterialRequirement
-	TotalCargoNeeded  int
+	ContractID        string                `json:"contractId"`
+	Status            string                `json:"status"`
+	RequiredMaterials []MaterialRequirement `json:"requiredMaterials"`
+	TotalCargoNeeded  int                   `json:"totalCargoNeeded"`
 }
 
 // MaterialRequirement holds material-specific requirements
 type MaterialRequirement struct {
-	Symbol         string
-	UnitsNeeded    int
-	RequiresMining bool
+	Symbol         string `json:"symbol"`
+	UnitsNeeded    int    `json:"unitsNeeded"`
+	RequiresMining bool   `json:"requiresMining"`
 }
 
 // analyzeFleet analyzes current fleet capabilities
@@ -0,0 +1,336 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// minHaulerCargo is the cargo capacity at which a ship without extraction mounts counts as a hauler
+const minHaulerCargo = 40
+
+// FleetAuditTool classifies ships by what they are fitted with rather than their registration role
+type FleetAuditTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewFleetAuditTool creates a new fleet loadout audit tool
+func NewFleetAuditTool(client *client.Client, logger *logging.Logger) *FleetAuditTool {
+	return &FleetAuditTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *FleetAuditTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "fleet_audit",
+		Description: "Classify every ship by its real capabilities (mining lasers, gas siphons, surveyors, cargo size, warp and jump drives, weapons) instead of its registration role, flag ships whose loadout does not match their role (e.g. a hauler carrying mining mounts), and suggest mount and module changes",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ships":       map[string]interface{}{"type": "array", "description": "Class, capabilities and issues of each ship"},
+			"by_class":    map[string]interface{}{"type": "object", "description": "Ship count per class"},
+			"mismatches":  map[string]interface{}{"type": "integer", "description": "Ships whose loadout does not suit their class"},
+			"total_ships": map[string]interface{}{"type": "integer"},
+		}, "ships", "by_class", "mismatches", "total_ships"),
+	}
+}
+
+// shipCapabilities is what a ship's mounts, modules and frame let it do
+type shipCapabilities struct {
+	MiningStrength     int  `json:"miningStrength"`
+	SiphonStrength     int  `json:"siphonStrength"`
+	Surveyors          int  `json:"surveyors"`
+	SensorArrays       int  `json:"sensorArrays"`
+	Weapons            int  `json:"weapons"`
+	CargoCapacity      int  `json:"cargoCapacity"`
+	FuelCapacity       int  `json:"fuelCapacity"`
+	WarpDrive          bool `json:"warpDrive"`
+	JumpDrive          bool `json:"jumpDrive"`
+	Refinery           bool `json:"refinery"`
+	FreeMountingPoints int  `json:"freeMountingPoints"`
+	FreeModuleSlots    int  `json:"freeModuleSlots"`
+}
+
+// auditedShip is one ship's classification and any loadout problems
+type auditedShip struct {
+	Symbol         string           `json:"symbol"`
+	RegisteredRole string           `json:"registeredRole"`
+	Class          string           `json:"class"`
+	Capabilities   shipCapabilities `json:"capabilities"`
+	Mismatch       bool             `json:"mismatch"`
+	Issues         []string         `json:"issues"`
+	Suggestions    []string         `json:"suggestions"`
+}
+
+// classRoles lists the registration roles that fit each class
+var classRoles = map[string][]string{
+	"MINER":    {"EXCAVATOR", "COMMAND"},
+	"SIPHONER": {"EXCAVATOR", "HARVESTER", "COMMAND"},
+	"SURVEYOR": {"SURVEYOR", "EXCAVATOR", "COMMAND"},
+	"HAULER":   {"HAULER", "TRANSPORT", "CARRIER", "COMMAND"},
+	"EXPLORER": {"EXPLORER", "SATELLITE", "COMMAND"},
+	"COMBAT":   {"INTERCEPTOR", "PATROL"},
+	"PROBE":    {"SATELLITE"},
+}
+
+// Handler returns the tool handler function
+func (t *FleetAuditTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "fleet-audit-tool")
+		ctxLogger.Debug("Auditing fleet loadouts")
+
+		start := time.Now()
+		ships, err := t.client.WithContext(ctx).GetAllShips()
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			ctxLogger.APICall("/my/ships", 0, duration.String())
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching ships: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.APICall("/my/ships", 200, duration.String())
+
+		audited := make([]auditedShip, 0, len(ships))
+		byClass := make(map[string]int)
+		mismatches := 0
+		for _, ship := range ships {
+			audit := auditShip(ship)
+			audited = append(audited, audit)
+			byClass[audit.Class]++
+			if audit.Mismatch {
+				mismatches++
+			}
+		}
+
+		result := map[string]interface{}{
+			"ships":       audited,
+			"by_class":    byClass,
+			"mismatches":  mismatches,
+			"total_ships": len(ships),
+		}
+
+		classes := make([]string, 0, len(byClass))
+		for class := range byClass {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		counts := make([]string, 0, len(classes))
+		for _, class := range classes {
+			counts = append(counts, fmt.Sprintf("%s %d", class, byClass[class]))
+		}
+
+		textSummary := "## 🔧 Fleet Audit\n\n"
+		textSummary += fmt.Sprintf("**Ships:** %d (%s)\n", len(ships), strings.Join(counts, ", "))
+		textSummary += fmt.Sprintf("**Role mismatches:** %d\n\n", mismatches)
+		for _, ship := range audited {
+			marker := "✅"
+			if ship.Mismatch {
+				marker = "⚠️"
+			}
+			textSummary += fmt.Sprintf("### %s %s: %s (registered %s)\n", marker, ship.Symbol, ship.Class, ship.RegisteredRole)
+			textSummary += "- " + describeCapabilities(ship.Capabilities) + "\n"
+			for _, issue := range ship.Issues {
+				textSummary += fmt.Sprintf("- ⚠️ %s\n", issue)
+			}
+			for _, suggestion := range ship.Suggestions {
+				textSummary += fmt.Sprintf("- 💡 %s\n", suggestion)
+			}
+			textSummary += "\n"
+		}
+
+		ctxLogger.ToolCall("fleet_audit", true)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// capabilitiesOf reads what a ship can do from its mounts, modules and frame
+func capabilitiesOf(ship client.Ship) shipCapabilities {
+	caps := shipCapabilities{
+		CargoCapacity: ship.Cargo.Capacity,
+		FuelCapacity:  ship.Fuel.Capacity,
+	}
+
+	for _, mount := range ship.Mounts {
+		switch {
+		case strings.HasPrefix(mount.Symbol, "MOUNT_MINING_LASER"):
+			caps.MiningStrength += mount.Strength
+		case strings.HasPrefix(mount.Symbol, "MOUNT_GAS_SIPHON"):
+			caps.SiphonStrength += mount.Strength
+		case strings.HasPrefix(mount.Symbol, "MOUNT_SURVEYOR"):
+			caps.Surveyors++
+		case strings.HasPrefix(mount.Symbol, "MOUNT_SENSOR_ARRAY"):
+			caps.SensorArrays++
+		case strings.HasPrefix(mount.Symbol, "MOUNT_MISSILE_LAUNCHER"),
+			strings.HasPrefix(mount.Symbol, "MOUNT_LASER_CANNON"),
+			strings.HasPrefix(mount.Symbol, "MOUNT_TURRET"):
+			caps.Weapons++
+		}
+	}
+	caps.FreeMountingPoints = max(ship.Frame.MountingPoints-len(ship.Mounts), 0)
+
+	usedSlots := 0
+	for _, module := range ship.Modules {
+		switch {
+		case strings.HasPrefix(module.Symbol, "MODULE_WARP_DRIVE"):
+			caps.WarpDrive = true
+		case strings.HasPrefix(module.Symbol, "MODULE_JUMP_DRIVE"):
+			caps.JumpDrive = true
+		case strings.HasPrefix(module.Symbol, "MODULE_ORE_REFINERY"):
+			caps.Refinery = true
+		}
+		// Every module takes at least one slot
+		usedSlots += max(module.Requirements.Slots, 1)
+	}
+	caps.FreeModuleSlots = max(ship.Frame.ModuleSlots-usedSlots, 0)
+
+	return caps
+}
+
+// classifyShip names the job a ship is best fitted for. Extraction mounts win over cargo space,
+// since a miner needs a hold anyway, and drives that leave the system win over a large hold.
+func classifyShip(caps shipCapabilities) string {
+	switch {
+	case caps.FuelCapacity == 0 && caps.CargoCapacity == 0:
+		return "PROBE"
+	case caps.MiningStrength > 0:
+		return "MINER"
+	case caps.SiphonStrength > 0:
+		return "SIPHONER"
+	case caps.Surveyors > 0:
+		return "SURVEYOR"
+	case caps.WarpDrive || caps.JumpDrive:
+		return "EXPLORER"
+	case caps.CargoCapacity >= minHaulerCargo:
+		return "HAULER"
+	case caps.Weapons > 0:
+		return "COMBAT"
+	case caps.SensorArrays > 0:
+		return "EXPLORER"
+	case caps.CargoCapacity > 0:
+		return "HAULER"
+	default:
+		return "PROBE"
+	}
+}
+
+// auditShip classifies a ship and flags loadouts that do not fit its registration role
+func auditShip(ship client.Ship) auditedShip {
+	caps := capabilitiesOf(ship)
+	audit := auditedShip{
+		Symbol:         ship.Symbol,
+		RegisteredRole: ship.Registration.Role,
+		Class:          classifyShip(caps),
+		Capabilities:   caps,
+		Issues:         make([]string, 0),
+		Suggestions:    make([]string, 0),
+	}
+
+	role := ship.Registration.Role
+	switch role {
+	case "HAULER", "TRANSPORT", "CARRIER":
+		if caps.MiningStrength > 0 || caps.SiphonStrength > 0 {
+			audit.Suggestions = append(audit.Suggestions, "Move the extraction mounts to a miner and keep this ship hauling, or run it as a miner with its large hold")
+		}
+	case "EXCAVATOR":
+		if caps.MiningStrength == 0 && caps.SiphonStrength == 0 {
+			audit.Mismatch = true
+			audit.Issues = append(audit.Issues, "Registered as EXCAVATOR without a mining laser or gas siphon")
+			audit.Suggestions = append(audit.Suggestions, "Install a MOUNT_MINING_LASER_I or MOUNT_GAS_SIPHON_I at a shipyard")
+		}
+	case "SURVEYOR":
+		if caps.Surveyors == 0 {
+			audit.Mismatch = true
+			audit.Issues = append(audit.Issues, "Registered as SURVEYOR without a surveyor mount")
+			audit.Suggestions = append(audit.Suggestions, "Install a MOUNT_SURVEYOR_I at a shipyard")
+		}
+	case "EXPLORER":
+		if !caps.WarpDrive && !caps.JumpDrive {
+			audit.Issues = append(audit.Issues, "Registered as EXPLORER without a warp or jump drive, so it can only leave a system through jump gates")
+			if caps.FreeModuleSlots > 0 {
+				audit.Suggestions = append(audit.Suggestions, "Install a MODULE_WARP_DRIVE_I to reach systems without a gate")
+			}
+		}
+	}
+
+	// Missing mounts are reported above; otherwise say what the ship is fitted for instead
+	if roles, known := classRoles[audit.Class]; known && role != "" && !audit.Mismatch && !listsSymbol(roles, role) {
+		audit.Mismatch = true
+		audit.Issues = append(audit.Issues, fmt.Sprintf("Registered as %s but fitted as a %s", role, strings.ToLower(audit.Class)))
+	}
+
+	// Spare capacity that would make the ship better at its job
+	switch audit.Class {
+	case "MINER":
+		if caps.FreeMountingPoints > 0 {
+			audit.Suggestions = append(audit.Suggestions, fmt.Sprintf("%d free mounting point(s): another MOUNT_MINING_LASER would raise yield per extraction", caps.FreeMountingPoints))
+		}
+		if caps.Surveyors == 0 && caps.FreeMountingPoints > 1 {
+			audit.Suggestions = append(audit.Suggestions, "A MOUNT_SURVEYOR_I would let it target richer deposits")
+		}
+		if caps.FreeModuleSlots > 0 {
+			audit.Suggestions = append(audit.Suggestions, "A free module slot could take a MODULE_CARGO_HOLD to mine longer between sales")
+		}
+	case "HAULER":
+		if caps.FreeModuleSlots > 0 {
+			audit.Suggestions = append(audit.Suggestions, fmt.Sprintf("%d free module slot(s): a MODULE_CARGO_HOLD would carry more per trip", caps.FreeModuleSlots))
+		}
+	case "SURVEYOR":
+		if caps.FreeMountingPoints > 0 {
+			audit.Suggestions = append(audit.Suggestions, "Another MOUNT_SURVEYOR would produce more surveys per scan")
+		}
+	}
+
+	return audit
+}
+
+// describeCapabilities renders a ship's capabilities on one line
+func describeCapabilities(caps shipCapabilities) string {
+	parts := []string{fmt.Sprintf("cargo %d", caps.CargoCapacity), fmt.Sprintf("fuel %d", caps.FuelCapacity)}
+	if caps.MiningStrength > 0 {
+		parts = append(parts, fmt.Sprintf("mining strength %d", caps.MiningStrength))
+	}
+	if caps.SiphonStrength > 0 {
+		parts = append(parts, fmt.Sprintf("siphon strength %d", caps.SiphonStrength))
+	}
+	if caps.Surveyors > 0 {
+		parts = append(parts, fmt.Sprintf("%d surveyor(s)", caps.Surveyors))
+	}
+	if caps.SensorArrays > 0 {
+		parts = append(parts, fmt.Sprintf("%d sensor array(s)", caps.SensorArrays))
+	}
+	if caps.Weapons > 0 {
+		parts = append(parts, fmt.Sprintf("%d weapon(s)", caps.Weapons))
+	}
+	if caps.WarpDrive {
+		parts = append(parts, "warp drive")
+	}
+	if caps.JumpDrive {
+		parts = append(parts, "jump drive")
+	}
+	if caps.Refinery {
+		parts = append(parts, "ore refinery")
+	}
+	parts = append(parts, fmt.Sprintf("%d free mount(s), %d free module slot(s)", caps.FreeMountingPoints, caps.FreeModuleSlots))
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,118 @@
+package info
+
+import (
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestAuditShip(t *testing.T) {
+	tests := []struct {
+		name         string
+		ship         client.Ship
+		wantClass    string
+		wantMismatch bool
+		wantIssue    string
+	}{
+		{
+			name: "hauler with mining mounts",
+			ship: client.Ship{
+				Symbol:       "HAULER-1",
+				Registration: client.Registration{Role: "HAULER"},
+				Frame:        client.Frame{MountingPoints: 2, ModuleSlots: 4},
+				Mounts:       []client.Mount{{Symbol: "MOUNT_MINING_LASER_I", Strength: 10}},
+				Cargo:        client.Cargo{Capacity: 80},
+				Fuel:         client.Fuel{Capacity: 600},
+			},
+			wantClass:    "MINER",
+			wantMismatch: true,
+			wantIssue:    "Registered as HAULER but fitted as a miner",
+		},
+		{
+			name: "excavator without extraction mounts",
+			ship: client.Ship{
+				Symbol:       "MINER-1",
+				Registration: client.Registration{Role: "EXCAVATOR"},
+				Cargo:        client.Cargo{Capacity: 15},
+				Fuel:         client.Fuel{Capacity: 100},
+			},
+			wantClass:    "HAULER",
+			wantMismatch: true,
+			wantIssue:    "without a mining laser or gas siphon",
+		},
+		{
+			name: "command frigate",
+			ship: client.Ship{
+				Symbol:       "COMMAND-1",
+				Registration: client.Registration{Role: "COMMAND"},
+				Mounts: []client.Mount{
+					{Symbol: "MOUNT_MINING_LASER_I", Strength: 10},
+					{Symbol: "MOUNT_SURVEYOR_I", Strength: 1},
+				},
+				Cargo: client.Cargo{Capacity: 40},
+				Fuel:  client.Fuel{Capacity: 400},
+			},
+			wantClass: "MINER",
+		},
+		{
+			name: "probe",
+			ship: client.Ship{
+				Symbol:       "PROBE-1",
+				Registration: client.Registration{Role: "SATELLITE"},
+			},
+			wantClass: "PROBE",
+		},
+		{
+			name: "explorer with warp drive and a large hold",
+			ship: client.Ship{
+				Symbol:       "EXPLORER-1",
+				Registration: client.Registration{Role: "EXPLORER"},
+				Modules:      []client.Module{{Symbol: "MODULE_WARP_DRIVE_I", Requirements: client.ShipRequirements{Slots: 1}}},
+				Cargo:        client.Cargo{Capacity: 60},
+				Fuel:         client.Fuel{Capacity: 800},
+			},
+			wantClass: "EXPLORER",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := auditShip(tt.ship)
+			if audit.Class != tt.wantClass {
+				t.Errorf("Expected class %s, got %s", tt.wantClass, audit.Class)
+			}
+			if audit.Mismatch != tt.wantMismatch {
+				t.Errorf("Expected mismatch %v, got %v (issues %v)", tt.wantMismatch, audit.Mismatch, audit.Issues)
+			}
+			if tt.wantIssue != "" && !strings.Contains(strings.Join(audit.Issues, "\n"), tt.wantIssue) {
+				t.Errorf("Expected an issue containing %q, got %v", tt.wantIssue, audit.Issues)
+			}
+			if !tt.wantMismatch && len(audit.Issues) > 0 {
+				t.Errorf("Expected no issues, got %v", audit.Issues)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesOf_FreeSlots(t *testing.T) {
+	ship := client.Ship{
+		Frame:  client.Frame{MountingPoints: 3, ModuleSlots: 4},
+		Mounts: []client.Mount{{Symbol: "MOUNT_MINING_LASER_II", Strength: 25}, {Symbol: "MOUNT_MINING_LASER_I", Strength: 10}},
+		Modules: []client.Module{
+			{Symbol: "MODULE_CARGO_HOLD_II", Requirements: client.ShipRequirements{Slots: 2}},
+			{Symbol: "MODULE_CREW_QUARTERS_I"},
+		},
+	}
+
+	caps := capabilitiesOf(ship)
+	if caps.MiningStrength != 35 {
+		t.Errorf("Expected mining strength 35, got %d", caps.MiningStrength)
+	}
+	if caps.FreeMountingPoints != 1 {
+		t.Errorf("Expected 1 free mounting point, got %d", caps.FreeMountingPoints)
+	}
+	if caps.FreeModuleSlots != 1 {
+		t.Errorf("Expected 1 free module slot, got %d", caps.FreeModuleSlots)
+	}
+}
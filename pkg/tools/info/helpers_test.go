@@ -0,0 +1,27 @@
+package info
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// testTool is what the tests need from a tool: its definition and its handler
+type testTool interface {
+	Tool() mcp.Tool
+	Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+}
+
+// callTool calls a tool's handler with args, failing the test if the handler returns an error
+// rather than an error result
+func callTool(t *testing.T, tool testTool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: tool.Tool().Name, Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// IdleShipsTool reports ships that are not doing anything so they can be put to work
+type IdleShipsTool struct {
+	client *client.Client
+	tasks  *tasks.Manager
+	logger *logging.Logger
+}
+
+// NewIdleShipsTool creates a new idle ship detection tool.
+// When a task manager is given, ships running a background task are never reported as idle.
+func NewIdleShipsTool(client *client.Client, taskManager *tasks.Manager, logger *logging.Logger) *IdleShipsTool {
+	return &IdleShipsTool{
+		client: client,
+		tasks:  taskManager,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *IdleShipsTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "find_idle_ships",
+		Description: "Find ships that are docked or in orbit with no cooldown, not in transit and no background task assigned, with suggested next actions based on each ship's role, cargo and fuel",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"idle_ships":  map[string]interface{}{"type": "array", "description": "Idle ships with a suggested next action"},
+			"idle_count":  map[string]interface{}{"type": "integer"},
+			"total_ships": map[string]interface{}{"type": "integer"},
+		}, "idle_ships", "idle_count", "total_ships"),
+	}
+}
+
+// idleShip describes an idle ship and what it could do next
+type idleShip struct {
+	Symbol       string   `json:"symbol"`
+	Role         string   `json:"role"`
+	Status       string   `json:"status"`
+	Waypoint     string   `json:"waypoint"`
+	FuelPercent  int      `json:"fuelPercent"`
+	CargoPercent int      `json:"cargoPercent"`
+	Suggestions  []string `json:"suggestions"`
+}
+
+// Handler returns the tool handler function
+func (t *IdleShipsTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "idle-ships-tool")
+		ctxLogger.Debug("Looking for idle ships")
+
+		start := time.Now()
+		ships, err := t.client.WithContext(ctx).GetAllShips()
+		duration := time.Since(start)
+
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			ctxLogger.APICall("/my/ships", 0, duration.String())
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching ships: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		ctxLogger.APICall("/my/ships", 200, duration.String())
+
+		idle := findIdleShips(ships, t.hasTask, time.Now())
+		ctxLogger.Info("Found %d idle ships out of %d", len(idle), len(ships))
+
+		result := map[string]interface{}{
+			"idle_ships":  idle,
+			"idle_count":  len(idle),
+			"total_ships": len(ships),
+		}
+
+		textSummary := "## 💤 Idle Ships\n\n"
+		if len(idle) == 0 {
+			textSummary += fmt.Sprintf("All %d ships are busy (in transit, on cooldown or running a task).\n", len(ships))
+		} else {
+			textSummary += fmt.Sprintf("**%d of %d ships are idle:**\n\n", len(idle), len(ships))
+			for _, ship := range idle {
+				textSummary += fmt.Sprintf("### %s (%s)\n", ship.Symbol, ship.Role)
+				textSummary += fmt.Sprintf("- %s at %s, fuel %d%%, cargo %d%%\n", ship.Status, ship.Waypoint, ship.FuelPercent, ship.CargoPercent)
+				for _, suggestion := range ship.Suggestions {
+					textSummary += fmt.Sprintf("- 💡 %s\n", suggestion)
+				}
+				textSummary += "\n"
+			}
+		}
+
+		ctxLogger.ToolCall("find_idle_ships", true)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// hasTask reports whether a ship is running a background task
+func (t *IdleShipsTool) hasTask(shipSymbol string) bool {
+	if t.tasks == nil {
+		return false
+	}
+	_, active := t.tasks.ActiveTask(shipSymbol)
+	return active
+}
+
+// findIdleShips returns the ships that are docked or in orbit with no active cooldown or task
+func findIdleShips(ships []client.Ship, hasTask func(shipSymbol string) bool, now time.Time) []idleShip {
+	idle := make([]idleShip, 0)
+	for _, ship := range ships {
+		if ship.Nav.Status != "DOCKED" && ship.Nav.Status != "IN_ORBIT" {
+			continue
+		}
+		if ship.CooldownRemaining(now) > 0 || hasTask(ship.Symbol) {
+			continue
+		}
+
+		idle = append(idle, idleShip{
+			Symbol:       ship.Symbol,
+			Role:         ship.Registration.Role,
+			Status:       ship.Nav.Status,
+			Waypoint:     ship.Nav.WaypointSymbol,
+			FuelPercent:  ship.FuelPercent(),
+			CargoPercent: ship.CargoPercent(),
+			Suggestions:  suggestNextActions(ship),
+		})
+	}
+	return idle
+}
+
+// suggestNextActions proposes what an idle ship should do next based on its role and state
+func suggestNextActions(ship client.Ship) []string {
+	suggestions := make([]string, 0)
+
+	if ship.Fuel.Capacity > 0 && ship.FuelPercent() < 50 {
+		suggestions = append(suggestions, "Refuel with refuel_ship before the next trip")
+	}
+
+	cargoFull := ship.Cargo.Capacity > 0 && ship.CargoPercent() >= 90
+	hasCargo := ship.Cargo.Units > 0
+
+	switch ship.Registration.Role {
+	case "EXCAVATOR":
+		if cargoFull {
+			suggestions = append(suggestions, "Cargo is full - sell ore with sell_cargo or deliver it to a contract")
+		} else {
+			suggestions = append(suggestions, "Orbit an asteroid field and run extract_resources, or automate it with assign_task (mine_loop)")
+		}
+	case "HAULER", "TRANSPORT", "FREIGHTER":
+		if hasCargo {
+			suggestions = append(suggestions, "Deliver or sell the cargo on board (deliver_contract / sell_cargo)")
+		} else {
+			suggestions = append(suggestions, "Pick up contract goods or buy cargo for a trade route, or automate it with assign_task (contract_haul / trade_loop)")
+		}
+	case "SATELLITE", "EXPLORER", "SURVEYOR":
+		suggestions = append(suggestions, "Navigate to an unvisited market or shipyard to refresh price data")
+	case "COMMAND":
+		if hasCargo {
+			suggestions = append(suggestions, "Sell or deliver the cargo on board")
+		} else {
+			suggestions = append(suggestions, "Check get_contract_info for work, or trade between nearby markets")
+		}
+	default:
+		if hasCargo {
+			suggestions = append(suggestions, "Sell or deliver the cargo on board")
+		} else {
+			suggestions = append(suggestions, "Assign work matching the ship's modules and mounts")
+		}
+	}
+
+	return suggestions
+}
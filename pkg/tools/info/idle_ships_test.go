@@ -0,0 +1,72 @@
+package info
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestFindIdleShips(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ships := []client.Ship{
+		{
+			Symbol:       "MINER-1",
+			Registration: client.Registration{Role: "EXCAVATOR"},
+			Nav:          client.Navigation{Status: "IN_ORBIT", WaypointSymbol: "X1-TEST-A1"},
+			Cargo:        client.Cargo{Capacity: 30, Units: 30},
+			Fuel:         client.Fuel{Current: 100, Capacity: 100},
+		},
+		{
+			Symbol:       "MINER-2",
+			Registration: client.Registration{Role: "EXCAVATOR"},
+			Nav:          client.Navigation{Status: "IN_ORBIT"},
+			Cooldown:     client.Cooldown{RemainingSeconds: 45},
+		},
+		{
+			Symbol:       "HAULER-1",
+			Registration: client.Registration{Role: "HAULER"},
+			Nav:          client.Navigation{Status: "IN_TRANSIT"},
+		},
+		{
+			Symbol:       "HAULER-2",
+			Registration: client.Registration{Role: "HAULER"},
+			Nav:          client.Navigation{Status: "DOCKED"},
+			Cargo:        client.Cargo{Capacity: 80},
+			Fuel:         client.Fuel{Current: 10, Capacity: 100},
+		},
+	}
+
+	ships = append(ships, client.Ship{
+		Symbol:       "HAULER-3",
+		Registration: client.Registration{Role: "HAULER"},
+		Nav:          client.Navigation{Status: "DOCKED"},
+	})
+	hasTask := func(shipSymbol string) bool {
+		return shipSymbol == "HAULER-3"
+	}
+
+	idle := findIdleShips(ships, hasTask, now)
+	if len(idle) != 2 {
+		t.Fatalf("Expected 2 idle ships, got %d: %+v", len(idle), idle)
+	}
+
+	if idle[0].Symbol != "MINER-1" || !mentionsTool(idle[0].Suggestions, "sell_cargo") {
+		t.Errorf("Expected full miner to be told to sell, got %+v", idle[0])
+	}
+	if idle[1].Symbol != "HAULER-2" || !mentionsTool(idle[1].Suggestions, "refuel_ship") {
+		t.Errorf("Expected low-fuel hauler to be told to refuel, got %+v", idle[1])
+	}
+}
+
+// mentionsTool reports whether any suggestion mentions the given tool
+func mentionsTool(suggestions []string, tool string) bool {
+	for _, suggestion := range suggestions {
+		if strings.Contains(suggestion, tool) {
+			return true
+		}
+	}
+	return false
+}
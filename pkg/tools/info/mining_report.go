@@ -0,0 +1,188 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MiningReportTool reports extraction yields per ship and per waypoint
+type MiningReportTool struct {
+	recorder *mining.Recorder
+	logger   *logging.Logger
+}
+
+// NewMiningReportTool creates a new mining report tool
+func NewMiningReportTool(recorder *mining.Recorder, logger *logging.Logger) *MiningReportTool {
+	return &MiningReportTool{
+		recorder: recorder,
+		logger:   logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *MiningReportTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "mining_report",
+		Description: "Report mining yields per ship and per asteroid: units per hour, units per extraction with and without surveys, and the goods extracted. Use it to move miners to richer rocks.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"window_hours": map[string]interface{}{
+					"type":        "number",
+					"description": "Only include extractions from the last N hours (optional - defaults to every extraction since the server started)",
+					"minimum":     0,
+				},
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Only include extractions by this ship (optional)",
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"window":      map[string]interface{}{"type": "string"},
+			"ship_symbol": map[string]interface{}{"type": "string"},
+			"total":       map[string]interface{}{"type": "object", "description": "Yield over every matching extraction"},
+			"by_ship":     map[string]interface{}{"type": "array", "description": "Yield per ship, best first"},
+			"by_waypoint": map[string]interface{}{"type": "array", "description": "Yield per waypoint, best first"},
+		}, "window", "ship_symbol", "total", "by_ship", "by_waypoint"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *MiningReportTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "mining-report-tool")
+		ctxLogger.Debug("Building mining report")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		var since time.Time
+		windowDescription := "since the server started"
+		if window := v.OptionalHours("window_hours", 0); window > 0 {
+			since = time.Now().Add(-window)
+			windowDescription = fmt.Sprintf("last %.1f hours", window.Hours())
+		}
+		shipSymbol := v.OptionalShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		extractions := t.recorder.Extractions(since)
+		if shipSymbol != "" {
+			filtered := extractions[:0]
+			for _, extraction := range extractions {
+				if extraction.ShipSymbol == shipSymbol {
+					filtered = append(filtered, extraction)
+				}
+			}
+			extractions = filtered
+		}
+		stats := mining.ComputeStats(extractions)
+
+		result := map[string]interface{}{
+			"window":      windowDescription,
+			"ship_symbol": shipSymbol,
+			"total":       stats.Total,
+			"by_ship":     stats.ByShip,
+			"by_waypoint": stats.ByWaypoint,
+		}
+
+		textSummary := "## ⛏️ Mining Report\n\n"
+		textSummary += fmt.Sprintf("**Window:** %s\n", windowDescription)
+		if shipSymbol != "" {
+			textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
+		}
+
+		if len(extractions) == 0 {
+			textSummary += "\nNo extractions have been observed in this window yet.\n"
+		} else {
+			textSummary += fmt.Sprintf("**Extracted:** %d units in %d extractions (%.1f per extraction)\n", stats.Total.Units, stats.Total.Extractions, stats.Total.UnitsPerExtraction)
+			textSummary += fmt.Sprintf("**Goods:** %s\n", formatGoods(stats.Total.PerGood))
+
+			textSummary += "\n**By Waypoint (best first):**\n"
+			for _, yield := range stats.ByWaypoint {
+				textSummary += "- " + formatYield(yield, "unknown location") + "\n"
+			}
+			textSummary += "\n**By Ship (best first):**\n"
+			for _, yield := range stats.ByShip {
+				textSummary += "- " + formatYield(yield, "") + "\n"
+			}
+
+			if advice := relocationAdvice(stats.ByWaypoint); advice != "" {
+				textSummary += "\n" + advice + "\n"
+			}
+		}
+
+		ctxLogger.ToolCall("mining_report", true)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// formatYield renders one group's yield on a line
+func formatYield(yield mining.Yield, emptyKey string) string {
+	key := yield.Key
+	if key == "" {
+		key = emptyKey
+	}
+	line := fmt.Sprintf("**%s:** %d units in %d extractions, %.1f per extraction", key, yield.Units, yield.Extractions, yield.UnitsPerExtraction)
+	if yield.UnitsPerHour > 0 {
+		line += fmt.Sprintf(", %.0f per hour", yield.UnitsPerHour)
+	}
+	if yield.SurveyedUnitsPerExtraction > 0 && yield.UnsurveyedUnitsPerExtraction > 0 {
+		line += fmt.Sprintf(" (%.1f with surveys, %.1f without)", yield.SurveyedUnitsPerExtraction, yield.UnsurveyedUnitsPerExtraction)
+	}
+	return line
+}
+
+// formatGoods lists units per good, largest first
+func formatGoods(perGood map[string]int) string {
+	goods := make([]string, 0, len(perGood))
+	for good := range perGood {
+		goods = append(goods, good)
+	}
+	sort.Slice(goods, func(i, j int) bool {
+		if perGood[goods[i]] != perGood[goods[j]] {
+			return perGood[goods[i]] > perGood[goods[j]]
+		}
+		return goods[i] < goods[j]
+	})
+
+	parts := make([]string, 0, len(goods))
+	for _, good := range goods {
+		parts = append(parts, fmt.Sprintf("%s %d", good, perGood[good]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// relocationAdvice suggests the best waypoint when another known waypoint yields clearly less per extraction
+func relocationAdvice(byWaypoint []mining.Yield) string {
+	var known []mining.Yield
+	for _, yield := range byWaypoint {
+		if yield.Key != "" {
+			known = append(known, yield)
+		}
+	}
+	if len(known) < 2 {
+		return ""
+	}
+
+	sort.SliceStable(known, func(i, j int) bool {
+		return known[i].UnitsPerExtraction > known[j].UnitsPerExtraction
+	})
+	best, worst := known[0], known[len(known)-1]
+	// Small differences are within the randomness of individual extractions
+	if best.UnitsPerExtraction < worst.UnitsPerExtraction*1.2 {
+		return ""
+	}
+	return fmt.Sprintf("💡 %s yields %.1f units per extraction against %.1f at %s. Consider moving miners there.", best.Key, best.UnitsPerExtraction, worst.UnitsPerExtraction, worst.Key)
+}
@@ -0,0 +1,50 @@
+package info
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMiningReportTool_FiltersShipAndSuggestsRicherRock(t *testing.T) {
+	recorder := mining.NewRecorder()
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 4; i++ {
+		at := start.Add(time.Duration(i) * 70 * time.Second)
+		recorder.Record(mining.Extraction{ExtractedAt: at, ShipSymbol: "MINER-1", WaypointSymbol: "X1-TEST-B2", TradeSymbol: "IRON_ORE", Units: 12})
+		recorder.Record(mining.Extraction{ExtractedAt: at, ShipSymbol: "MINER-1", WaypointSymbol: "X1-TEST-C3", TradeSymbol: "ICE_WATER", Units: 4})
+		recorder.Record(mining.Extraction{ExtractedAt: at, ShipSymbol: "MINER-2", WaypointSymbol: "X1-TEST-B2", TradeSymbol: "IRON_ORE", Units: 10})
+	}
+
+	tool := NewMiningReportTool(recorder, logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"ship_symbol": "miner-1", "window_hours": float64(2)})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+
+	structured, ok := result.StructuredContent.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured content, got %#v", result.StructuredContent)
+	}
+	for _, field := range tool.Tool().OutputSchema.Required {
+		if _, ok := structured[field]; !ok {
+			t.Errorf("Expected structured content to include %s", field)
+		}
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "64 units in 8 extractions") {
+		t.Errorf("Expected only MINER-1 extractions in the total, got:\n%s", text)
+	}
+	if strings.Contains(text, "MINER-2") {
+		t.Errorf("Expected MINER-2 to be filtered out, got:\n%s", text)
+	}
+	if !strings.Contains(text, "X1-TEST-B2 yields 12.0 units per extraction against 4.0 at X1-TEST-C3") {
+		t.Errorf("Expected a suggestion to move to X1-TEST-B2, got:\n%s", text)
+	}
+}
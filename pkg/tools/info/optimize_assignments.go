@@ -0,0 +1,412 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/planning"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultPlanningHorizonHours = 4
+	maxPlanningHorizonHours     = 48
+	defaultRoutesPerSystem      = 10
+	maxRoutesPerSystem          = 50
+)
+
+// OptimizeAssignmentsTool proposes which ships should work which contracts and trade routes
+type OptimizeAssignmentsTool struct {
+	client *client.Client
+	prices *prices.DB
+	tasks  *tasks.Manager
+	logger *logging.Logger
+}
+
+// NewOptimizeAssignmentsTool creates a new ship-to-job assignment optimizer.
+// When a task manager is given, ships running a background task are left out unless asked for.
+func NewOptimizeAssignmentsTool(client *client.Client, db *prices.DB, taskManager *tasks.Manager, logger *logging.Logger) *OptimizeAssignmentsTool {
+	return &OptimizeAssignmentsTool{
+		client: client,
+		prices: db,
+		tasks:  taskManager,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *OptimizeAssignmentsTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "optimize_assignments",
+		Description: "Propose which ships should service which accepted contracts and trade routes to earn the most credits per hour across the fleet. Each ship gets at most one job and each job at most one ship; jobs are rated from cargo capacity, the ship's position and engine speed, CRUISE trips, contract deadlines, and prices recorded in the price database. Nothing is started - each assignment comes with the assign_task call that would start it.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"horizon_hours": map[string]interface{}{
+					"type":        "number",
+					"description": fmt.Sprintf("How far ahead to plan trade routes, in hours (default %d, max %d)", defaultPlanningHorizonHours, maxPlanningHorizonHours),
+					"minimum":     0.25,
+					"maximum":     maxPlanningHorizonHours,
+				},
+				"routes_per_system": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("How many of the widest-margin trade routes in each system to consider (default %d, max %d)", defaultRoutesPerSystem, maxRoutesPerSystem),
+					"minimum":     0,
+					"maximum":     maxRoutesPerSystem,
+				},
+				"include_busy": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also plan for ships already running a background task (default false)",
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"assignments":      map[string]interface{}{"type": "array", "description": "Proposed ship-to-job assignments, best paying first"},
+			"idle_ships":       map[string]interface{}{"type": "array", "description": "Ships no job pays for"},
+			"open_jobs":        map[string]interface{}{"type": "array", "description": "Jobs left without a ship"},
+			"skipped":          map[string]interface{}{"type": "array", "description": "Contract deliveries and ships that could not be planned, and why"},
+			"credits_per_hour": map[string]interface{}{"type": "number", "description": "Combined credits per hour of the assignments"},
+			"horizon_hours":    map[string]interface{}{"type": "number"},
+		}, "assignments", "idle_ships", "open_jobs", "skipped", "credits_per_hour", "horizon_hours"),
+	}
+}
+
+// plannedJob is a job with what the assignment needs to start it
+type plannedJob struct {
+	planning.Job
+	ContractID string
+}
+
+// jobAssignment is a proposed assignment with the job it covers and how to start it
+type jobAssignment struct {
+	planning.Estimate
+	Good        string `json:"good"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Units       int    `json:"units,omitempty"`
+	UnitProfit  int    `json:"unit_profit"`
+	Start       string `json:"start"`
+}
+
+// Handler returns the tool handler function
+func (t *OptimizeAssignmentsTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "optimize-assignments-tool")
+		ctxLogger.Debug("Optimizing ship assignments")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		horizon := v.OptionalHours("horizon_hours", defaultPlanningHorizonHours*time.Hour)
+		if horizon > maxPlanningHorizonHours*time.Hour {
+			v.Fail("horizon_hours", fmt.Sprintf("is %g", horizon.Hours()), fmt.Sprintf("at most %d hours", maxPlanningHorizonHours))
+		}
+		routesPerSystem := v.OptionalInt("routes_per_system", defaultRoutesPerSystem, 0, maxRoutesPerSystem)
+		includeBusy := v.OptionalBool("include_busy", false)
+		horizonHours := horizon.Hours()
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		fleet, err := c.GetAllShips()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching ships: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		contracts, err := c.GetAllContracts()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch contracts: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching contracts: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		now := time.Now()
+		skipped := make([]string, 0)
+
+		// Coordinates are looked up once per system
+		coordinates := make(map[string]map[string]client.SystemWaypoint)
+		locate := func(waypointSymbol string) (planning.Location, bool) {
+			system := travel.SystemSymbol(waypointSymbol)
+			if _, ok := coordinates[system]; !ok {
+				coordinates[system] = make(map[string]client.SystemWaypoint)
+				waypoints, _, err := c.GetCachedSystemWaypoints(system)
+				if err != nil {
+					ctxLogger.Error("Failed to list waypoints in %s: %v", system, err)
+				}
+				for _, waypoint := range waypoints {
+					coordinates[system][waypoint.Symbol] = waypoint
+				}
+			}
+			waypoint, ok := coordinates[system][waypointSymbol]
+			return planning.Location{Waypoint: waypointSymbol, X: waypoint.X, Y: waypoint.Y}, ok
+		}
+
+		ships := make([]planning.Ship, 0, len(fleet))
+		systems := make(map[string]bool)
+		for _, ship := range fleet {
+			if ship.Cargo.Capacity == 0 {
+				continue
+			}
+			if !includeBusy && t.hasTask(ship.Symbol) {
+				continue
+			}
+			location, ok := locate(ship.Nav.WaypointSymbol)
+			if !ok {
+				skipped = append(skipped, fmt.Sprintf("%s: position of %s unknown", ship.Symbol, ship.Nav.WaypointSymbol))
+				continue
+			}
+			availableIn, _ := ship.ArrivalIn(now)
+			ships = append(ships, planning.Ship{
+				Symbol:      ship.Symbol,
+				Location:    location,
+				AvailableIn: availableIn,
+				Capacity:    ship.Cargo.Capacity,
+				Speed:       ship.Engine.Speed,
+			})
+			systems[ship.Nav.SystemSymbol] = true
+		}
+
+		jobs := make([]plannedJob, 0)
+		for _, contract := range contracts {
+			if !contract.Accepted || contract.Fulfilled {
+				continue
+			}
+			contractJobs, notes := t.contractJobs(contract, locate, now)
+			jobs = append(jobs, contractJobs...)
+			skipped = append(skipped, notes...)
+		}
+		for _, system := range slices.Sorted(maps.Keys(systems)) {
+			jobs = append(jobs, t.tradeJobs(system, routesPerSystem, locate)...)
+		}
+
+		planJobs := make([]planning.Job, 0, len(jobs))
+		byID := make(map[string]plannedJob, len(jobs))
+		for _, job := range jobs {
+			planJobs = append(planJobs, job.Job)
+			byID[job.ID] = job
+		}
+		plan := planning.Optimize(ships, planJobs, now, horizon)
+
+		assignments := make([]jobAssignment, 0, len(plan.Assignments))
+		for _, estimate := range plan.Assignments {
+			job := byID[estimate.Job]
+			assignment := jobAssignment{
+				Estimate:    estimate,
+				Good:        job.Good,
+				Source:      job.Source.Waypoint,
+				Destination: job.Destination.Waypoint,
+				Units:       job.Units,
+				UnitProfit:  job.UnitProfit,
+			}
+			switch job.Kind {
+			case planning.ContractJob:
+				assignment.Start = fmt.Sprintf("assign_task ship_symbol=%s behavior=contract_haul params={contract_id: %s, buy_at: %s, good: %s}", estimate.Ship, job.ContractID, job.Source.Waypoint, job.Good)
+			case planning.TradeJob:
+				assignment.Start = fmt.Sprintf("assign_task ship_symbol=%s behavior=trade_loop params={good: %s, buy_at: %s, sell_at: %s}", estimate.Ship, job.Good, job.Source.Waypoint, job.Destination.Waypoint)
+			}
+			assignments = append(assignments, assignment)
+		}
+
+		ctxLogger.Info("Planned %d assignments for %d ships and %d jobs", len(assignments), len(ships), len(jobs))
+
+		result := map[string]interface{}{
+			"assignments":      assignments,
+			"idle_ships":       plan.IdleShips,
+			"open_jobs":        plan.OpenJobs,
+			"skipped":          skipped,
+			"credits_per_hour": plan.CreditsPerHour,
+			"horizon_hours":    horizonHours,
+		}
+
+		var response strings.Builder
+		response.WriteString("## 🧮 Fleet Assignment Plan\n\n")
+		response.WriteString(fmt.Sprintf("**Ships considered:** %d | **Jobs considered:** %d | **Horizon:** %gh\n\n", len(ships), len(jobs), horizonHours))
+		if len(assignments) == 0 {
+			response.WriteString("No ship can work any known job at a profit. Accept a contract or record more market prices (view markets with a ship present) to find trade routes.\n")
+		} else {
+			response.WriteString(fmt.Sprintf("**Expected earnings:** %.0f credits/hour\n\n", plan.CreditsPerHour))
+			for i, assignment := range assignments {
+				response.WriteString(fmt.Sprintf("%d. **%s** → %s\n", i+1, assignment.Ship, assignment.Job))
+				response.WriteString(fmt.Sprintf("   - %.0f credits/hour: %d credits over %s in %d trip(s)\n", assignment.CreditsPerHour, assignment.Profit, assignment.DurationString, assignment.Trips))
+				response.WriteString(fmt.Sprintf("   - Start: `%s`\n", assignment.Start))
+			}
+		}
+		if len(plan.IdleShips) > 0 {
+			response.WriteString(fmt.Sprintf("\n**Left idle:** %s\n", strings.Join(plan.IdleShips, ", ")))
+		}
+		if len(plan.OpenJobs) > 0 {
+			response.WriteString(fmt.Sprintf("\n**Jobs without a ship:** %s\n", strings.Join(plan.OpenJobs, ", ")))
+		}
+		if len(skipped) > 0 {
+			response.WriteString("\n**Not planned:**\n")
+			for _, note := range skipped {
+				response.WriteString(fmt.Sprintf("- ⚠️ %s\n", note))
+			}
+		}
+		response.WriteString("\n💡 Estimates assume recorded prices hold and only cover ships and jobs within one system; fuel is not counted.\n")
+
+		ctxLogger.ToolCall("optimize_assignments", true)
+
+		return utils.NewResult(response.String(), result), nil
+	}
+}
+
+// contractJobs turns each unfinished delivery of an accepted contract into a job sourcing the good at the
+// cheapest recorded market in the destination's system. The fulfillment payment is split between deliveries
+// by units left.
+func (t *OptimizeAssignmentsTool) contractJobs(contract client.Contract, locate func(string) (planning.Location, bool), now time.Time) ([]plannedJob, []string) {
+	var deadline time.Time
+	if parsed, err := time.Parse(time.RFC3339, contract.Terms.Deadline); err == nil {
+		if parsed.Before(now) {
+			return nil, []string{fmt.Sprintf("contract %s: deadline has passed", contract.ID)}
+		}
+		deadline = parsed
+	}
+
+	remaining := 0
+	for _, deliver := range contract.Terms.Deliver {
+		remaining += max(0, deliver.UnitsRequired-deliver.UnitsFulfilled)
+	}
+
+	jobs := make([]plannedJob, 0, len(contract.Terms.Deliver))
+	notes := make([]string, 0)
+	for _, deliver := range contract.Terms.Deliver {
+		units := deliver.UnitsRequired - deliver.UnitsFulfilled
+		if units <= 0 {
+			continue
+		}
+		id := fmt.Sprintf("contract %s %s", contract.ID, deliver.TradeSymbol)
+		system := travel.SystemSymbol(deliver.DestinationSymbol)
+
+		var source prices.Quote
+		found := false
+		for _, quote := range t.prices.Quotes(deliver.TradeSymbol) {
+			if travel.SystemSymbol(quote.WaypointSymbol) != system || quote.PurchasePrice <= 0 {
+				continue
+			}
+			if !found || quote.PurchasePrice < source.PurchasePrice {
+				source, found = quote, true
+			}
+		}
+		if !found {
+			notes = append(notes, fmt.Sprintf("%s: no recorded market in %s sells %s", id, system, deliver.TradeSymbol))
+			continue
+		}
+
+		from, fromOK := locate(source.WaypointSymbol)
+		to, toOK := locate(deliver.DestinationSymbol)
+		if !fromOK || !toOK {
+			notes = append(notes, fmt.Sprintf("%s: waypoint coordinates unknown", id))
+			continue
+		}
+
+		jobs = append(jobs, plannedJob{
+			Job: planning.Job{
+				ID:          id,
+				Kind:        planning.ContractJob,
+				Good:        deliver.TradeSymbol,
+				Source:      from,
+				Destination: to,
+				Units:       units,
+				UnitProfit:  -source.PurchasePrice,
+				Payment:     contract.Terms.Payment.OnFulfilled * units / remaining,
+				Deadline:    deadline,
+			},
+			ContractID: contract.ID,
+		})
+	}
+	return jobs, notes
+}
+
+// tradeJobs returns the widest-margin trade routes between recorded markets in a system: for each good, buying
+// at the cheapest market and selling at the one paying most
+func (t *OptimizeAssignmentsTool) tradeJobs(system string, limit int, locate func(string) (planning.Location, bool)) []plannedJob {
+	type side struct {
+		waypoint string
+		price    int
+	}
+	buys := make(map[string]side)
+	sells := make(map[string]side)
+	for _, market := range t.prices.Markets() {
+		if travel.SystemSymbol(market) != system {
+			continue
+		}
+		snapshot, ok := t.prices.Latest(market)
+		if !ok {
+			continue
+		}
+		for _, price := range snapshot.Prices {
+			if price.PurchasePrice > 0 {
+				if best, ok := buys[price.TradeSymbol]; !ok || price.PurchasePrice < best.price {
+					buys[price.TradeSymbol] = side{market, price.PurchasePrice}
+				}
+			}
+			if price.SellPrice > 0 {
+				if best, ok := sells[price.TradeSymbol]; !ok || price.SellPrice > best.price {
+					sells[price.TradeSymbol] = side{market, price.SellPrice}
+				}
+			}
+		}
+	}
+
+	jobs := make([]plannedJob, 0)
+	for good, buy := range buys {
+		sell, ok := sells[good]
+		if !ok || sell.waypoint == buy.waypoint || sell.price <= buy.price {
+			continue
+		}
+		from, fromOK := locate(buy.waypoint)
+		to, toOK := locate(sell.waypoint)
+		if !fromOK || !toOK {
+			continue
+		}
+		jobs = append(jobs, plannedJob{Job: planning.Job{
+			ID:          fmt.Sprintf("trade %s %s→%s", good, buy.waypoint, sell.waypoint),
+			Kind:        planning.TradeJob,
+			Good:        good,
+			Source:      from,
+			Destination: to,
+			UnitProfit:  sell.price - buy.price,
+		}})
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].UnitProfit != jobs[j].UnitProfit {
+			return jobs[i].UnitProfit > jobs[j].UnitProfit
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+// hasTask reports whether a ship is running a background task
+func (t *OptimizeAssignmentsTool) hasTask(shipSymbol string) bool {
+	if t.tasks == nil {
+		return false
+	}
+	_, active := t.tasks.ActiveTask(shipSymbol)
+	return active
+}
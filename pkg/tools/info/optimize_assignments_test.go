@@ -0,0 +1,95 @@
+package info
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestOptimizeAssignmentsTool_ContractsAndTradeRoutes(t *testing.T) {
+	deadline := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 0, "y": 0, "orbitals": [], "isUnderConstruction": false, "traits": []},
+				{"symbol": "X1-TEST-B2", "systemSymbol": "X1-TEST", "type": "MOON", "x": 30, "y": 40, "orbitals": [], "isUnderConstruction": false, "traits": []},
+				{"symbol": "X1-TEST-HQ", "systemSymbol": "X1-TEST", "type": "PLANET", "x": -30, "y": 0, "orbitals": [], "isUnderConstruction": false, "traits": []}
+			], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "HAULER-1", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED", "flightMode": "CRUISE"},
+					"engine": {"symbol": "ENGINE_ION_DRIVE_I", "speed": 30}, "cargo": {"capacity": 40, "units": 0, "inventory": []}},
+				{"symbol": "HAULER-2", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-B2", "status": "IN_ORBIT", "flightMode": "CRUISE"},
+					"engine": {"symbol": "ENGINE_ION_DRIVE_I", "speed": 30}, "cargo": {"capacity": 40, "units": 0, "inventory": []}},
+				{"symbol": "PROBE-3", "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED", "flightMode": "CRUISE"},
+					"engine": {"symbol": "ENGINE_IMPULSE_DRIVE_I", "speed": 3}, "cargo": {"capacity": 0, "units": 0, "inventory": []}}
+			], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+		case "/my/contracts":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "c-iron", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "accepted": true, "fulfilled": false,
+					"terms": {"deadline": "` + deadline + `", "payment": {"onAccepted": 1000, "onFulfilled": 20000},
+						"deliver": [
+							{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-HQ", "unitsRequired": 60, "unitsFulfilled": 20},
+							{"tradeSymbol": "GOLD", "destinationSymbol": "X1-TEST-HQ", "unitsRequired": 40, "unitsFulfilled": 0}
+						]}},
+				{"id": "c-offered", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "accepted": false, "fulfilled": false,
+					"terms": {"deadline": "` + deadline + `", "payment": {"onAccepted": 0, "onFulfilled": 90000},
+						"deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-HQ", "unitsRequired": 10, "unitsFulfilled": 0}]}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	db := prices.New()
+	seen := time.Now()
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: seen, Prices: []prices.Price{
+		{TradeSymbol: "IRON_ORE", PurchasePrice: 20, SellPrice: 15},
+		{TradeSymbol: "COPPER_ORE", PurchasePrice: 10, SellPrice: 8},
+	}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-B2", ObservedAt: seen, Prices: []prices.Price{
+		{TradeSymbol: "COPPER_ORE", PurchasePrice: 60, SellPrice: 50},
+	}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-OTHER-C3", ObservedAt: seen, Prices: []prices.Price{
+		{TradeSymbol: "GOLD", PurchasePrice: 100, SellPrice: 90},
+	}})
+
+	tool := NewOptimizeAssignmentsTool(client.NewClientWithBaseURL("test-token", server.URL), db, nil, logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"horizon_hours": float64(2)})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+
+	text, _ := mcp.AsTextContent(result.Content[0])
+	// Two haulers and two profitable jobs: each hauler gets one of them
+	if !strings.Contains(text.Text, "behavior=contract_haul params={contract_id: c-iron, buy_at: X1-TEST-A1, good: IRON_ORE}") {
+		t.Errorf("Expected the iron delivery to be assigned with its contract_haul call, got: %s", text.Text)
+	}
+	if !strings.Contains(text.Text, "behavior=trade_loop params={good: COPPER_ORE, buy_at: X1-TEST-A1, sell_at: X1-TEST-B2}") {
+		t.Errorf("Expected the copper route to be assigned with its trade_loop call, got: %s", text.Text)
+	}
+	if !strings.Contains(text.Text, "HAULER-1") || !strings.Contains(text.Text, "HAULER-2") {
+		t.Errorf("Expected both haulers to get a job, got: %s", text.Text)
+	}
+	if strings.Contains(text.Text, "PROBE-3") {
+		t.Errorf("Expected the probe without a hold to be left out, got: %s", text.Text)
+	}
+	if strings.Contains(text.Text, "c-offered") {
+		t.Errorf("Expected contracts not yet accepted to be left out, got: %s", text.Text)
+	}
+	if !strings.Contains(text.Text, "contract c-iron GOLD: no recorded market in X1-TEST sells GOLD") {
+		t.Errorf("Expected the unsourced gold delivery to be noted, got: %s", text.Text)
+	}
+}
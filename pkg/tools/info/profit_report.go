@@ -0,0 +1,163 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ProfitReportTool summarizes credits earned versus spent from the transactions ledger
+type ProfitReportTool struct {
+	ledger    *ledger.Ledger
+	logger    *logging.Logger
+	startedAt time.Time
+}
+
+// NewProfitReportTool creates a new profit report tool
+func NewProfitReportTool(l *ledger.Ledger, logger *logging.Logger) *ProfitReportTool {
+	return &ProfitReportTool{
+		ledger:    l,
+		logger:    logger,
+		startedAt: time.Now(),
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *ProfitReportTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "profit_report",
+		Description: "Summarize credits earned vs spent over this session or a time window, broken down by activity (trading, contracts, mining sales, fuel, repairs, ship purchases)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"window_hours": map[string]interface{}{
+					"type":        "number",
+					"description": "Only include transactions from the last N hours (optional - defaults to the whole session)",
+					"minimum":     0,
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": "Only include transactions at or after this RFC3339 timestamp (optional, overrides window_hours)",
+				},
+				"until": map[string]interface{}{
+					"type":        "string",
+					"description": "Only include transactions at or before this RFC3339 timestamp (optional)",
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"window":       map[string]interface{}{"type": "string"},
+			"transactions": map[string]interface{}{"type": "integer"},
+			"earned":       map[string]interface{}{"type": "integer"},
+			"spent":        map[string]interface{}{"type": "integer"},
+			"net":          map[string]interface{}{"type": "integer"},
+			"verdict":      map[string]interface{}{"type": "string"},
+			"by_activity":  map[string]interface{}{"type": "object", "description": "Totals per activity"},
+			"by_ship":      map[string]interface{}{"type": "object", "description": "Net credits per ship"},
+			"by_good":      map[string]interface{}{"type": "object", "description": "Net credits per good"},
+		}, "window", "transactions", "earned", "spent", "net", "verdict", "by_activity", "by_ship", "by_good"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *ProfitReportTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "profit-report-tool")
+		ctxLogger.Debug("Building profit report")
+
+		now := time.Now()
+		filter := ledger.Filter{}
+		windowDescription := fmt.Sprintf("session (since %s)", t.startedAt.Format(time.RFC3339))
+
+		v := utils.NewValidator(request.Params.Arguments)
+		if window := v.OptionalHours("window_hours", 0); window > 0 {
+			filter.Since = now.Add(-window)
+			windowDescription = fmt.Sprintf("last %.1f hours", window.Hours())
+		}
+		if since := v.OptionalTime("since"); !since.IsZero() {
+			filter.Since = since
+			windowDescription = fmt.Sprintf("since %s", since.Format(time.RFC3339))
+		}
+		if until := v.OptionalTime("until"); !until.IsZero() {
+			filter.Until = until
+			windowDescription += fmt.Sprintf(" until %s", until.Format(time.RFC3339))
+		}
+		if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+			v.Fail("until", fmt.Sprintf("%s is before the start of the window", filter.Until.Format(time.RFC3339)), fmt.Sprintf("a time after %s", filter.Since.Format(time.RFC3339)))
+		}
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		entries := t.ledger.Query(filter)
+		totals := ledger.ComputeTotals(entries)
+		breakdown := ledger.ComputeBreakdown(entries)
+
+		verdict := "break_even"
+		if totals.Net > 0 {
+			verdict = "profitable"
+		} else if totals.Net < 0 {
+			verdict = "losing_money"
+		}
+
+		result := map[string]interface{}{
+			"window":       windowDescription,
+			"transactions": len(entries),
+			"earned":       totals.Income,
+			"spent":        totals.Expense,
+			"net":          totals.Net,
+			"verdict":      verdict,
+			"by_activity":  breakdown,
+			"by_ship":      totals.PerShip,
+			"by_good":      totals.PerGood,
+		}
+
+		// Order activities by absolute impact so the biggest drivers come first
+		activities := make([]string, 0, len(breakdown))
+		for activity := range breakdown {
+			activities = append(activities, activity)
+		}
+		sort.Slice(activities, func(i, j int) bool {
+			return abs(breakdown[activities[i]].Net) > abs(breakdown[activities[j]].Net)
+		})
+
+		textSummary := "## 💰 Profit & Loss Report\n\n"
+		textSummary += fmt.Sprintf("**Window:** %s\n", windowDescription)
+		textSummary += fmt.Sprintf("**Transactions:** %d\n", len(entries))
+		textSummary += fmt.Sprintf("**Earned:** %d credits\n", totals.Income)
+		textSummary += fmt.Sprintf("**Spent:** %d credits\n", totals.Expense)
+		textSummary += fmt.Sprintf("**Net:** %+d credits (%s)\n", totals.Net, strings.ReplaceAll(verdict, "_", " "))
+
+		if len(activities) > 0 {
+			textSummary += "\n**By Activity:**\n"
+			for _, activity := range activities {
+				totals := breakdown[activity]
+				textSummary += fmt.Sprintf("- **%s:** %+d (earned %d, spent %d, %d transactions)\n",
+					activity, totals.Net, totals.Earned, totals.Spent, totals.Transactions)
+			}
+		} else {
+			textSummary += "\nNo transactions have been observed in this window yet.\n"
+		}
+
+		ctxLogger.ToolCall("profit_report", true)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,141 @@
+package info
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newSeededLedger returns a ledger with an old trade and a recent round of trading, fuel and a contract
+func newSeededLedger(now time.Time) *ledger.Ledger {
+	l := ledger.New()
+	l.Add(ledger.Entry{Timestamp: now.Add(-30 * time.Hour), Category: ledger.CategoryMarketSale, ShipSymbol: "SHIP-1", TradeSymbol: "FUEL", Amount: 500})
+	l.Add(ledger.Entry{Timestamp: now.Add(-2 * time.Hour), Category: ledger.CategoryMarketPurchase, ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE", Amount: -1000})
+	l.Add(ledger.Entry{Timestamp: now.Add(-time.Hour), Category: ledger.CategoryMarketSale, ShipSymbol: "SHIP-1", TradeSymbol: "IRON_ORE", Amount: 1400})
+	l.Add(ledger.Entry{Timestamp: now.Add(-time.Hour), Category: ledger.CategoryRefuel, ShipSymbol: "SHIP-1", Amount: -150})
+	l.Add(ledger.Entry{Timestamp: now.Add(-30 * time.Minute), Category: ledger.CategoryContractPayment, ContractID: "contract-1", Amount: 2000})
+	return l
+}
+
+func TestProfitReportTool_BreaksDownActivities(t *testing.T) {
+	now := time.Now()
+	tool := NewProfitReportTool(newSeededLedger(now), logging.NewLogger(nil))
+
+	result := callTool(t, tool, map[string]interface{}{"window_hours": float64(3)})
+	if result.IsError {
+		t.Fatalf("Expected success, got %+v", result)
+	}
+
+	structured, ok := result.StructuredContent.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured content, got %#v", result.StructuredContent)
+	}
+	for _, field := range tool.Tool().OutputSchema.Required {
+		if _, ok := structured[field]; !ok {
+			t.Errorf("Expected structured content to include %s", field)
+		}
+	}
+	// The sale from 30 hours ago falls outside the window
+	if structured["transactions"] != 4 || structured["earned"] != 3400 || structured["spent"] != 1150 || structured["net"] != 2250 {
+		t.Errorf("Expected 4 transactions netting 2250, got %v transactions, earned %v, spent %v, net %v", structured["transactions"], structured["earned"], structured["spent"], structured["net"])
+	}
+	if structured["verdict"] != "profitable" || structured["window"] != "last 3.0 hours" {
+		t.Errorf("Expected a profitable 3 hour window, got %v over %v", structured["verdict"], structured["window"])
+	}
+
+	byActivity := structured["by_activity"].(map[string]ledger.ActivityTotals)
+	expected := map[string]ledger.ActivityTotals{
+		"trading":   {Earned: 1400, Spent: 1000, Net: 400, Transactions: 2},
+		"fuel":      {Spent: 150, Net: -150, Transactions: 1},
+		"contracts": {Earned: 2000, Net: 2000, Transactions: 1},
+	}
+	if len(byActivity) != len(expected) {
+		t.Errorf("Expected %d activities, got %v", len(expected), byActivity)
+	}
+	for activity, want := range expected {
+		if got := byActivity[activity]; got != want {
+			t.Errorf("Expected %s totals %+v, got %+v", activity, want, got)
+		}
+	}
+
+	// Activities are listed by the size of their impact
+	text := result.Content[0].(mcp.TextContent).Text
+	contracts, trading, fuel := strings.Index(text, "**contracts:**"), strings.Index(text, "**trading:**"), strings.Index(text, "**fuel:**")
+	if contracts < 0 || !(contracts < trading && trading < fuel) {
+		t.Errorf("Expected contracts, trading then fuel, got:\n%s", text)
+	}
+}
+
+func TestProfitReportTool_Windows(t *testing.T) {
+	now := time.Now().UTC()
+	tool := NewProfitReportTool(newSeededLedger(now), logging.NewLogger(nil))
+	since := now.Add(-3 * time.Hour).Format(time.RFC3339)
+	until := now.Add(-90 * time.Minute).Format(time.RFC3339)
+
+	tests := []struct {
+		name         string
+		args         map[string]interface{}
+		transactions int
+		window       string
+	}{
+		{name: "whole session", args: nil, transactions: 5, window: "session (since "},
+		{name: "window hours", args: map[string]interface{}{"window_hours": float64(1.5)}, transactions: 3, window: "last 1.5 hours"},
+		{name: "since overrides window hours", args: map[string]interface{}{"window_hours": float64(1), "since": since}, transactions: 4, window: "since " + since},
+		{name: "since and until", args: map[string]interface{}{"since": " " + since + " ", "until": until}, transactions: 1, window: "since " + since + " until " + until},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, tool, tt.args)
+			if result.IsError {
+				t.Fatalf("Expected success, got %+v", result)
+			}
+			structured := result.StructuredContent.(map[string]interface{})
+			if structured["transactions"] != tt.transactions {
+				t.Errorf("Expected %d transactions, got %v", tt.transactions, structured["transactions"])
+			}
+			if window, _ := structured["window"].(string); !strings.HasPrefix(window, tt.window) {
+				t.Errorf("Expected window %q, got %q", tt.window, window)
+			}
+		})
+	}
+}
+
+func TestProfitReportTool_RejectsBadWindows(t *testing.T) {
+	now := time.Now().UTC()
+	tool := NewProfitReportTool(newSeededLedger(now), logging.NewLogger(nil))
+
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{name: "unparseable since", args: map[string]interface{}{"since": "yesterday"}, expected: "since: 'yesterday' is not a time"},
+		{name: "unparseable until", args: map[string]interface{}{"until": "2025-01-01"}, expected: "until: '2025-01-01' is not a time"},
+		{
+			name:     "until before since",
+			args:     map[string]interface{}{"since": now.Format(time.RFC3339), "until": now.Add(-time.Hour).Format(time.RFC3339)},
+			expected: "is before the start of the window",
+		},
+		{
+			name:     "until before the window",
+			args:     map[string]interface{}{"window_hours": float64(1), "until": now.Add(-2 * time.Hour).Format(time.RFC3339)},
+			expected: "is before the start of the window",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, tool, tt.args)
+			if !result.IsError {
+				t.Fatalf("Expected an error, got %+v", result)
+			}
+			if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.expected) {
+				t.Errorf("Expected %q in %q", tt.expected, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,566 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Projection statuses, from best to worst
+const (
+	projectionOnTrack    = "ON_TRACK"
+	projectionAtRisk     = "AT_RISK"
+	projectionImpossible = "IMPOSSIBLE"
+)
+
+// onTrackShare is the largest share of the time left before the deadline a projection may use
+// and still be on track; anything tighter is at risk
+const onTrackShare = 0.8
+
+// miningWindow is how far back extractions are used to estimate mining yield
+const miningWindow = 24 * time.Hour
+
+// ProjectContractTool estimates when an accepted contract will be completed and whether that
+// beats the deadline
+type ProjectContractTool struct {
+	client *client.Client
+	ledger *ledger.Ledger
+	tasks  *tasks.Manager
+	mining *mining.Recorder
+	logger *logging.Logger
+}
+
+// NewProjectContractTool creates a new contract projection tool.
+// When a ledger is given, prices paid earlier count as sources for goods no visible market quotes.
+func NewProjectContractTool(client *client.Client, l *ledger.Ledger, logger *logging.Logger) *ProjectContractTool {
+	return &ProjectContractTool{
+		client: client,
+		ledger: l,
+		logger: logger,
+	}
+}
+
+// WithTasks uses contract_haul and mine_loop tasks to find the ships working on a contract and
+// where they get the goods
+func (t *ProjectContractTool) WithTasks(m *tasks.Manager) *ProjectContractTool {
+	t.tasks = m
+	return t
+}
+
+// WithMining uses observed extraction rates for goods that have to be mined
+func (t *ProjectContractTool) WithMining(r *mining.Recorder) *ProjectContractTool {
+	t.mining = r
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *ProjectContractTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "project_contract_completion",
+		Description: "Estimate when a contract will be completed from its delivery progress, the cargo capacity and engine speed of the ships working on it, CRUISE travel times and, for goods that are mined, the fleet's observed yield, then compare it with the deadline: ON_TRACK, AT_RISK or IMPOSSIBLE",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"contract_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the contract to project",
+				},
+				"ship_symbols": map[string]interface{}{
+					"type":        "array",
+					"description": "Ships working on the contract. Defaults to ships running contract_haul tasks for it, then ships holding its goods, then the ship with the largest hold",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"contract_id"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"contractId":          map[string]interface{}{"type": "string"},
+			"status":              map[string]interface{}{"type": "string", "enum": []string{projectionOnTrack, projectionAtRisk, projectionImpossible}},
+			"estimatedSeconds":    map[string]interface{}{"type": "integer"},
+			"estimatedCompletion": map[string]interface{}{"type": "string"},
+			"deadline":            map[string]interface{}{"type": "string"},
+			"secondsRemaining":    map[string]interface{}{"type": "integer"},
+			"ships":               map[string]interface{}{"type": "array", "description": "Ships the projection assumes do the work"},
+			"deliveries":          map[string]interface{}{"type": "array", "description": "Source, trips and time of each remaining delivery"},
+		}, "contractId", "status", "estimatedSeconds", "deliveries"),
+	}
+}
+
+// projectionSource is where the goods for one delivery come from
+type projectionSource struct {
+	Waypoint string
+	// Kind is task (a contract_haul task's buy_at), market, ledger or mining
+	Kind string
+	// UnitsPerHour is the observed mining yield of the good, for mined goods
+	UnitsPerHour float64
+}
+
+// projectedShip is a ship the projection assumes works on the contract
+type projectedShip struct {
+	Symbol   string `json:"symbol"`
+	Waypoint string `json:"waypoint"`
+	Status   string `json:"status"`
+	Capacity int    `json:"capacity"`
+	Speed    int    `json:"speed"`
+}
+
+// projectedDelivery is the time one remaining delivery is expected to take
+type projectedDelivery struct {
+	TradeSymbol    string `json:"tradeSymbol"`
+	Destination    string `json:"destination"`
+	UnitsRemaining int    `json:"unitsRemaining"`
+	UnitsHeld      int    `json:"unitsHeld"`
+	// Shortfall is how many units still have to be bought or mined
+	Shortfall          int     `json:"shortfall"`
+	Source             string  `json:"source,omitempty"`
+	SourceKind         string  `json:"sourceKind,omitempty"`
+	MiningUnitsPerHour float64 `json:"miningUnitsPerHour,omitempty"`
+	Trips              int     `json:"trips"`
+	AcquireSeconds     int     `json:"acquireSeconds"`
+	TravelSeconds      int     `json:"travelSeconds"`
+}
+
+// contractProjection is the expected completion of a contract
+type contractProjection struct {
+	ContractID          string              `json:"contractId"`
+	Status              string              `json:"status"`
+	Accepted            bool                `json:"accepted"`
+	ShipsFrom           string              `json:"shipsFrom"`
+	Ships               []projectedShip     `json:"ships"`
+	Deliveries          []projectedDelivery `json:"deliveries"`
+	EstimatedSeconds    int                 `json:"estimatedSeconds"`
+	EstimatedCompletion string              `json:"estimatedCompletion"`
+	Deadline            string              `json:"deadline"`
+	SecondsRemaining    int                 `json:"secondsRemaining"`
+	Warnings            []string            `json:"warnings,omitempty"`
+}
+
+// Handler returns the tool handler function
+func (t *ProjectContractTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "project-contract-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		contractID := v.RequireString("contract_id")
+		requested := v.OptionalShips("ship_symbols")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		start := time.Now()
+		contract, err := t.client.WithContext(ctx).GetContract(contractID)
+		duration := time.Since(start)
+		if err != nil {
+			ctxLogger.Error("Failed to fetch contract %s: %v", contractID, err)
+			ctxLogger.APICall(fmt.Sprintf("/my/contracts/%s", contractID), 0, duration.String())
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching contract %s: %s", contractID, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		ctxLogger.APICall(fmt.Sprintf("/my/contracts/%s", contractID), 200, duration.String())
+
+		if contract.Fulfilled {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Contract %s is already fulfilled; there is nothing left to project", contractID)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		start = time.Now()
+		ships, err := t.client.WithContext(ctx).GetAllShips()
+		duration = time.Since(start)
+		if err != nil {
+			ctxLogger.Error("Failed to fetch ships: %v", err)
+			ctxLogger.APICall("/my/ships", 0, duration.String())
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching ships: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		ctxLogger.APICall("/my/ships", 200, duration.String())
+
+		var taskList []tasks.Task
+		if t.tasks != nil {
+			taskList = t.tasks.List()
+		}
+
+		assigned, shipsFrom, err := contractShips(*contract, ships, taskList, requested)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent("❌ " + err.Error()),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		now := time.Now()
+		var extractions []mining.Extraction
+		if t.mining != nil {
+			extractions = t.mining.Extractions(now.Add(-miningWindow))
+		}
+
+		// Quotes and coordinates are looked up once per destination system
+		quotes := make(map[string]map[string]priceQuote)
+		coordinates := make(map[string]client.SystemWaypoint)
+		for _, deliver := range contract.Terms.Deliver {
+			system := travel.SystemSymbol(deliver.DestinationSymbol)
+			if _, ok := quotes[system]; ok {
+				continue
+			}
+			var systemCoordinates map[string]client.SystemWaypoint
+			quotes[system], systemCoordinates = systemMarkets(ctx, t.client, t.ledger, ctxLogger, system)
+			for symbol, waypoint := range systemCoordinates {
+				coordinates[symbol] = waypoint
+			}
+		}
+
+		sources := deliverySources(*contract, assigned, taskList, quotes, mining.ComputeStats(extractions))
+		projection := projectContract(*contract, assigned, sources, coordinates, now)
+		projection.ShipsFrom = shipsFrom
+
+		ctxLogger.Info("Projected contract %s: %s", contractID, projection.Status)
+		ctxLogger.ToolCall("project_contract_completion", true)
+
+		return utils.NewResult(formatProjection(projection), projection), nil
+	}
+}
+
+// contractShips picks the ships assumed to work on a contract: the requested ships, else ships
+// running contract_haul tasks for it, else ships holding its goods, else the largest hold.
+// It also says which of those it used.
+func contractShips(contract client.Contract, ships []client.Ship, taskList []tasks.Task, requested []string) ([]client.Ship, string, error) {
+	if len(requested) > 0 {
+		selected := make([]client.Ship, 0, len(requested))
+		for _, symbol := range requested {
+			index := slices.IndexFunc(ships, func(ship client.Ship) bool { return ship.Symbol == symbol })
+			if index < 0 {
+				return nil, "", fmt.Errorf("ship %s not found in your fleet", symbol)
+			}
+			selected = append(selected, ships[index])
+		}
+		return selected, "requested", nil
+	}
+
+	hauling := make(map[string]bool)
+	for _, task := range taskList {
+		if task.Active() && task.Behavior == "contract_haul" && task.Params["contract_id"] == contract.ID {
+			hauling[task.ShipSymbol] = true
+		}
+	}
+	var selected []client.Ship
+	for _, ship := range ships {
+		if hauling[ship.Symbol] {
+			selected = append(selected, ship)
+		}
+	}
+	if len(selected) > 0 {
+		return selected, "contract_haul tasks", nil
+	}
+
+	goods := make(map[string]bool)
+	for _, deliver := range contract.Terms.Deliver {
+		if deliver.UnitsFulfilled < deliver.UnitsRequired {
+			goods[deliver.TradeSymbol] = true
+		}
+	}
+	for _, ship := range ships {
+		if slices.ContainsFunc(ship.Cargo.Inventory, func(item client.CargoItem) bool { return goods[item.Symbol] && item.Units > 0 }) {
+			selected = append(selected, ship)
+		}
+	}
+	if len(selected) > 0 {
+		return selected, "ships holding contract goods", nil
+	}
+
+	hauler := largestHold(ships)
+	index := slices.IndexFunc(ships, func(ship client.Ship) bool { return ship.Symbol == hauler.Symbol })
+	if index < 0 {
+		return nil, "none", nil
+	}
+	return []client.Ship{ships[index]}, "largest cargo hold", nil
+}
+
+// deliverySources decides where each contract good comes from: a contract_haul task's buy_at,
+// then the asteroid of a mine_loop task run by one of the ships, then the cheapest known market,
+// then wherever the fleet has mined the good
+func deliverySources(contract client.Contract, ships []client.Ship, taskList []tasks.Task, quotes map[string]map[string]priceQuote, stats mining.Stats) map[string]projectionSource {
+	working := make(map[string]bool, len(ships))
+	for _, ship := range ships {
+		working[ship.Symbol] = true
+	}
+
+	sources := make(map[string]projectionSource)
+	for _, deliver := range contract.Terms.Deliver {
+		good := deliver.TradeSymbol
+		if _, ok := sources[good]; ok {
+			continue
+		}
+		rate := minedPerHour(stats.Total, good)
+
+		var minedAt string
+		for _, task := range taskList {
+			if !task.Active() {
+				continue
+			}
+			if task.Behavior == "contract_haul" && task.Params["contract_id"] == contract.ID && (task.Params["good"] == "" || task.Params["good"] == good) {
+				sources[good] = projectionSource{Waypoint: task.Params["buy_at"], Kind: "task"}
+				break
+			}
+			if task.Behavior == "mine_loop" && working[task.ShipSymbol] && minedAt == "" {
+				minedAt = task.Params["asteroid"]
+			}
+		}
+		if _, ok := sources[good]; ok {
+			continue
+		}
+		if minedAt != "" && rate > 0 {
+			sources[good] = projectionSource{Waypoint: minedAt, Kind: "mining", UnitsPerHour: rate}
+			continue
+		}
+		if quote, ok := quotes[travel.SystemSymbol(deliver.DestinationSymbol)][good]; ok {
+			sources[good] = projectionSource{Waypoint: quote.Waypoint, Kind: quote.Source}
+			continue
+		}
+		if rate > 0 {
+			best := 0
+			for _, yield := range stats.ByWaypoint {
+				if yield.Key != "" && yield.PerGood[good] > best {
+					best = yield.PerGood[good]
+					minedAt = yield.Key
+				}
+			}
+			sources[good] = projectionSource{Waypoint: minedAt, Kind: "mining", UnitsPerHour: rate}
+		}
+	}
+	return sources
+}
+
+// minedPerHour is the share of a yield's hourly rate that is the given good
+func minedPerHour(yield mining.Yield, good string) float64 {
+	if yield.Units == 0 {
+		return 0
+	}
+	return yield.UnitsPerHour * float64(yield.PerGood[good]) / float64(yield.Units)
+}
+
+// projectContract estimates how long the remaining deliveries take and compares that with the
+// deadline. The ships work together in CRUISE at the slowest ship's speed: they gather at the
+// source, then shuttle the shortfall to the destination using their combined holds. Mined goods
+// are mined in full before the shuttling starts, and deliveries are done one after another, so
+// the estimate leans pessimistic.
+func projectContract(contract client.Contract, ships []client.Ship, sources map[string]projectionSource, coordinates map[string]client.SystemWaypoint, now time.Time) contractProjection {
+	projection := contractProjection{
+		ContractID: contract.ID,
+		Accepted:   contract.Accepted,
+		Ships:      make([]projectedShip, 0, len(ships)),
+		Deliveries: make([]projectedDelivery, 0, len(contract.Terms.Deliver)),
+		Deadline:   contract.Terms.Deadline,
+	}
+	if !contract.Accepted {
+		projection.Warnings = append(projection.Warnings, "the contract has not been accepted yet")
+	}
+
+	capacity, speed := 0, 0
+	held := make(map[string]int)
+	// Where the ships start from and how long until they get there
+	var from []string
+	wait := 0
+	for _, ship := range ships {
+		projection.Ships = append(projection.Ships, projectedShip{
+			Symbol:   ship.Symbol,
+			Waypoint: ship.Nav.WaypointSymbol,
+			Status:   ship.Nav.Status,
+			Capacity: ship.Cargo.Capacity,
+			Speed:    ship.Engine.Speed,
+		})
+		capacity += ship.Cargo.Capacity
+		if speed == 0 || ship.Engine.Speed < speed {
+			speed = ship.Engine.Speed
+		}
+		for _, item := range ship.Cargo.Inventory {
+			held[item.Symbol] += item.Units
+		}
+		from = append(from, ship.Nav.WaypointSymbol)
+		if ship.Nav.Status == "IN_TRANSIT" {
+			if arrival, err := time.Parse(time.RFC3339, ship.Nav.Route.Arrival); err == nil && arrival.After(now) {
+				wait = max(wait, int(arrival.Sub(now).Seconds()))
+			}
+		}
+	}
+
+	uncertain := false
+	if capacity == 0 {
+		uncertain = true
+		projection.Warnings = append(projection.Warnings, "no ship with cargo space is working on the contract")
+	}
+
+	// leg is the CRUISE time between two waypoints at the slowest ship's speed
+	leg := func(origin, destination string) (int, bool) {
+		if origin == destination {
+			return 0, true
+		}
+		a, aOK := coordinates[origin]
+		b, bOK := coordinates[destination]
+		if !aOK || !bOK {
+			return 0, false
+		}
+		return int(travel.TravelTime(travel.Distance(a.X, a.Y, b.X, b.Y), "CRUISE", speed).Seconds()), true
+	}
+	// gather is how long until every ship reaches a waypoint
+	gather := func(destination string) (int, bool) {
+		longest, known := 0, true
+		for _, origin := range from {
+			seconds, ok := leg(origin, destination)
+			known = known && ok
+			longest = max(longest, seconds)
+		}
+		return wait + longest, known
+	}
+
+	for _, deliver := range contract.Terms.Deliver {
+		remaining := deliver.UnitsRequired - deliver.UnitsFulfilled
+		if remaining <= 0 {
+			continue
+		}
+		delivery := projectedDelivery{
+			TradeSymbol:    deliver.TradeSymbol,
+			Destination:    deliver.DestinationSymbol,
+			UnitsRemaining: remaining,
+			UnitsHeld:      min(held[deliver.TradeSymbol], remaining),
+		}
+		held[deliver.TradeSymbol] -= delivery.UnitsHeld
+		delivery.Shortfall = remaining - delivery.UnitsHeld
+
+		known := true
+		if delivery.Shortfall == 0 {
+			delivery.TravelSeconds, known = gather(deliver.DestinationSymbol)
+		} else if source, ok := sources[deliver.TradeSymbol]; !ok || source.Waypoint == "" {
+			known = false
+			projection.Warnings = append(projection.Warnings, fmt.Sprintf("no known source for %d %s - visit markets that sell it or mine it to improve the projection", delivery.Shortfall, deliver.TradeSymbol))
+		} else if capacity > 0 {
+			delivery.Source = source.Waypoint
+			delivery.SourceKind = source.Kind
+			delivery.Trips = (delivery.Shortfall + capacity - 1) / capacity
+
+			positioning, positionKnown := gather(source.Waypoint)
+			shuttle, shuttleKnown := leg(source.Waypoint, deliver.DestinationSymbol)
+			known = positionKnown && shuttleKnown
+			// Every trip ends at the destination, and all but the last return to the source
+			delivery.TravelSeconds = positioning + (2*delivery.Trips-1)*shuttle
+
+			if source.Kind == "mining" {
+				delivery.MiningUnitsPerHour = source.UnitsPerHour
+				delivery.AcquireSeconds = int(float64(delivery.Shortfall) / source.UnitsPerHour * 3600)
+			}
+		}
+		if !known {
+			uncertain = true
+			if delivery.Source != "" || delivery.Shortfall == 0 {
+				projection.Warnings = append(projection.Warnings, fmt.Sprintf("travel time for %s to %s is unknown - a waypoint is outside the destination system", deliver.TradeSymbol, deliver.DestinationSymbol))
+			}
+		}
+
+		projection.EstimatedSeconds += delivery.AcquireSeconds + delivery.TravelSeconds
+		projection.Deliveries = append(projection.Deliveries, delivery)
+		from = []string{deliver.DestinationSymbol}
+		wait = 0
+	}
+
+	completion := now.Add(time.Duration(projection.EstimatedSeconds) * time.Second)
+	projection.EstimatedCompletion = completion.Format(time.RFC3339)
+
+	deadline, err := time.Parse(time.RFC3339, contract.Terms.Deadline)
+	if err != nil {
+		projection.Status = projectionAtRisk
+		projection.Warnings = append(projection.Warnings, "the contract deadline could not be read")
+		return projection
+	}
+	remaining := deadline.Sub(now)
+	projection.SecondsRemaining = int(remaining.Seconds())
+	estimate := time.Duration(projection.EstimatedSeconds) * time.Second
+
+	switch {
+	case remaining <= 0:
+		projection.Status = projectionImpossible
+		projection.Warnings = append(projection.Warnings, "the deadline has passed")
+	case estimate > remaining:
+		projection.Status = projectionImpossible
+	case uncertain || estimate.Seconds() > remaining.Seconds()*onTrackShare:
+		projection.Status = projectionAtRisk
+	default:
+		projection.Status = projectionOnTrack
+	}
+	return projection
+}
+
+// formatProjection renders a projection as markdown
+func formatProjection(projection contractProjection) string {
+	icon := map[string]string{
+		projectionOnTrack:    "✅",
+		projectionAtRisk:     "⚠️",
+		projectionImpossible: "⛔",
+	}[projection.Status]
+
+	var response strings.Builder
+	fmt.Fprintf(&response, "## %s Contract %s: %s\n\n", icon, projection.ContractID, projection.Status)
+	fmt.Fprintf(&response, "- **Estimated completion:** %s (in %s)\n", projection.EstimatedCompletion, time.Duration(projection.EstimatedSeconds)*time.Second)
+	fmt.Fprintf(&response, "- **Deadline:** %s", projection.Deadline)
+	if projection.SecondsRemaining > 0 {
+		fmt.Fprintf(&response, " (%s remaining)", time.Duration(projection.SecondsRemaining)*time.Second)
+	}
+	response.WriteString("\n")
+
+	if len(projection.Ships) > 0 {
+		symbols := make([]string, 0, len(projection.Ships))
+		for _, ship := range projection.Ships {
+			symbols = append(symbols, ship.Symbol)
+		}
+		fmt.Fprintf(&response, "- **Ships** (%s): %s\n", projection.ShipsFrom, strings.Join(symbols, ", "))
+	}
+
+	response.WriteString("\n### Deliveries\n")
+	if len(projection.Deliveries) == 0 {
+		response.WriteString("Every delivery is complete; fulfill the contract to get paid.\n")
+	}
+	for _, delivery := range projection.Deliveries {
+		fmt.Fprintf(&response, "- **%s → %s:** %d remaining, %d aboard", delivery.TradeSymbol, delivery.Destination, delivery.UnitsRemaining, delivery.UnitsHeld)
+		if delivery.Source != "" {
+			fmt.Fprintf(&response, ", %d from %s (%s) in %d trips", delivery.Shortfall, delivery.Source, delivery.SourceKind, delivery.Trips)
+		}
+		if delivery.AcquireSeconds > 0 {
+			fmt.Fprintf(&response, ", %s mining at %.1f units/hour", time.Duration(delivery.AcquireSeconds)*time.Second, delivery.MiningUnitsPerHour)
+		}
+		fmt.Fprintf(&response, ", %s flying\n", time.Duration(delivery.TravelSeconds)*time.Second)
+	}
+
+	for _, warning := range projection.Warnings {
+		fmt.Fprintf(&response, "\n⚠️ %s", warning)
+	}
+	if len(projection.Warnings) > 0 {
+		response.WriteString("\n")
+	}
+	return response.String()
+}
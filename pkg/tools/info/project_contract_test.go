@@ -0,0 +1,134 @@
+package info
+
+import (
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/travel"
+)
+
+func TestProjectContract(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	coordinates := map[string]client.SystemWaypoint{
+		"X1-TEST-MARKET": {Symbol: "X1-TEST-MARKET", X: 0, Y: 0},
+		"X1-TEST-HQ":     {Symbol: "X1-TEST-HQ", X: 30, Y: 40},
+	}
+	hauler := client.Ship{Symbol: "HAULER-1"}
+	hauler.Nav.WaypointSymbol = "X1-TEST-MARKET"
+	hauler.Nav.Status = "DOCKED"
+	hauler.Cargo.Capacity = 40
+	hauler.Cargo.Inventory = []client.CargoItem{{Symbol: "IRON_ORE", Units: 10}}
+	hauler.Engine.Speed = 30
+
+	contract := client.Contract{
+		ID:       "contract-1",
+		Accepted: true,
+		Terms: client.ContractTerms{
+			Deadline: now.Add(24 * time.Hour).Format(time.RFC3339),
+			Deliver: []client.ContractDeliverGood{
+				{TradeSymbol: "IRON_ORE", DestinationSymbol: "X1-TEST-HQ", UnitsRequired: 100, UnitsFulfilled: 10},
+			},
+		},
+	}
+	sources := map[string]projectionSource{
+		"IRON_ORE": {Waypoint: "X1-TEST-MARKET", Kind: "market"},
+	}
+
+	projection := projectContract(contract, []client.Ship{hauler}, sources, coordinates, now)
+	if projection.Status != projectionOnTrack {
+		t.Fatalf("Expected ON_TRACK, got %+v", projection)
+	}
+	delivery := projection.Deliveries[0]
+	if delivery.UnitsHeld != 10 || delivery.Shortfall != 80 || delivery.Trips != 2 {
+		t.Errorf("Expected 10 held, 80 short in 2 trips, got %+v", delivery)
+	}
+	// 3 legs of 50 units: out, back and out again
+	leg := int(travel.TravelTime(50, "CRUISE", 30).Seconds())
+	if delivery.TravelSeconds != 3*leg {
+		t.Errorf("Expected %d seconds of travel, got %d", 3*leg, delivery.TravelSeconds)
+	}
+
+	contract.Terms.Deadline = now.Add(time.Duration(projection.EstimatedSeconds+10) * time.Second).Format(time.RFC3339)
+	tight := projectContract(contract, []client.Ship{hauler}, sources, coordinates, now)
+	if tight.Status != projectionAtRisk {
+		t.Errorf("Expected AT_RISK with barely enough time, got %s", tight.Status)
+	}
+
+	contract.Terms.Deadline = now.Add(time.Minute).Format(time.RFC3339)
+	late := projectContract(contract, []client.Ship{hauler}, sources, coordinates, now)
+	if late.Status != projectionImpossible {
+		t.Errorf("Expected IMPOSSIBLE with a one minute deadline, got %s", late.Status)
+	}
+
+	contract.Terms.Deadline = now.Add(24 * time.Hour).Format(time.RFC3339)
+	unknown := projectContract(contract, []client.Ship{hauler}, map[string]projectionSource{}, coordinates, now)
+	if unknown.Status != projectionAtRisk || len(unknown.Warnings) == 0 {
+		t.Errorf("Expected AT_RISK with a warning when no source is known, got %+v", unknown)
+	}
+
+	// 80 units mined at 10 per hour take 8 hours before hauling
+	mined := projectContract(contract, []client.Ship{hauler}, map[string]projectionSource{
+		"IRON_ORE": {Waypoint: "X1-TEST-MARKET", Kind: "mining", UnitsPerHour: 10},
+	}, coordinates, now)
+	if mined.Deliveries[0].AcquireSeconds != 8*3600 {
+		t.Errorf("Expected 8 hours of mining, got %d seconds", mined.Deliveries[0].AcquireSeconds)
+	}
+	if mined.EstimatedSeconds != 8*3600+3*leg {
+		t.Errorf("Expected mining plus travel, got %d seconds", mined.EstimatedSeconds)
+	}
+}
+
+func TestContractShipsAndSources(t *testing.T) {
+	contract := client.Contract{
+		ID: "contract-1",
+		Terms: client.ContractTerms{
+			Deliver: []client.ContractDeliverGood{
+				{TradeSymbol: "IRON_ORE", DestinationSymbol: "X1-TEST-HQ", UnitsRequired: 100},
+				{TradeSymbol: "COPPER_ORE", DestinationSymbol: "X1-TEST-HQ", UnitsRequired: 50},
+			},
+		},
+	}
+	ships := []client.Ship{{Symbol: "BIG"}, {Symbol: "HAULER"}, {Symbol: "HOLDER"}}
+	ships[0].Cargo.Capacity = 80
+	ships[1].Cargo.Capacity = 40
+	ships[2].Cargo.Capacity = 20
+	ships[2].Cargo.Inventory = []client.CargoItem{{Symbol: "COPPER_ORE", Units: 5}}
+
+	taskList := []tasks.Task{
+		{ShipSymbol: "HAULER", Behavior: "contract_haul", Status: tasks.StatusRunning, Params: map[string]string{"contract_id": "contract-1", "buy_at": "X1-TEST-MARKET", "good": "IRON_ORE"}},
+	}
+
+	selected, from, err := contractShips(contract, ships, taskList, nil)
+	if err != nil || from != "contract_haul tasks" || len(selected) != 1 || selected[0].Symbol != "HAULER" {
+		t.Errorf("Expected HAULER from its contract_haul task, got %v %q %v", selected, from, err)
+	}
+	selected, from, _ = contractShips(contract, ships, nil, nil)
+	if from != "ships holding contract goods" || len(selected) != 1 || selected[0].Symbol != "HOLDER" {
+		t.Errorf("Expected HOLDER for holding copper, got %v %q", selected, from)
+	}
+	ships[2].Cargo.Inventory = nil
+	selected, from, _ = contractShips(contract, ships, nil, nil)
+	if from != "largest cargo hold" || len(selected) != 1 || selected[0].Symbol != "BIG" {
+		t.Errorf("Expected BIG as the largest hold, got %v %q", selected, from)
+	}
+	if _, _, err := contractShips(contract, ships, nil, []string{"NOPE"}); err == nil {
+		t.Error("Expected an error for a ship not in the fleet")
+	}
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := mining.ComputeStats([]mining.Extraction{
+		{ExtractedAt: start, ShipSymbol: "MINER", WaypointSymbol: "X1-TEST-ROCK", TradeSymbol: "COPPER_ORE", Units: 10},
+		{ExtractedAt: start.Add(30 * time.Minute), ShipSymbol: "MINER", WaypointSymbol: "X1-TEST-ROCK", TradeSymbol: "COPPER_ORE", Units: 10},
+	})
+	sources := deliverySources(contract, ships[1:2], taskList, map[string]map[string]priceQuote{}, stats)
+	if source := sources["IRON_ORE"]; source.Kind != "task" || source.Waypoint != "X1-TEST-MARKET" {
+		t.Errorf("Expected iron from the task's buy_at, got %+v", source)
+	}
+	if source := sources["COPPER_ORE"]; source.Kind != "mining" || source.Waypoint != "X1-TEST-ROCK" || source.UnitsPerHour != 20 {
+		t.Errorf("Expected copper mined at X1-TEST-ROCK at 20 units/hour, got %+v", source)
+	}
+}
@@ -0,0 +1,319 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// purchaseGoals are the jobs a ship can be bought for
+var purchaseGoals = []string{"mining", "hauling", "exploration", "trading"}
+
+// RecommendShipTool recommends which ship to buy for a goal from known shipyard listings
+type RecommendShipTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewRecommendShipTool creates a new ship purchase advisor tool
+func NewRecommendShipTool(client *client.Client, logger *logging.Logger) *RecommendShipTool {
+	return &RecommendShipTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *RecommendShipTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "recommend_ship_purchase",
+		Description: "Recommend the best value ship to buy for a goal (mining, hauling, exploration or trading). Compares the specs and prices of every ship seen at a shipyard while one of your ships was there against your current credits. Shipyards only show prices with a ship present, so visit shipyards first to widen the choice.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"goal": map[string]interface{}{
+					"type":        "string",
+					"description": "What the ship is for",
+					"enum":        purchaseGoals,
+				},
+				"system": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Only consider shipyards in this system (e.g., 'X1-FM66'); its shipyards are fetched first to pick up current prices",
+				},
+			},
+			Required: []string{"goal"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"goal":          map[string]interface{}{"type": "string"},
+			"system":        map[string]interface{}{"type": "string"},
+			"credits":       map[string]interface{}{"type": "integer"},
+			"shipyards":     map[string]interface{}{"type": "integer", "description": "Shipyards compared"},
+			"offers":        map[string]interface{}{"type": "array", "description": "Suitable ships for sale, best value first"},
+			"recommended":   map[string]interface{}{"type": "object", "description": "Best value ship you can afford, if any"},
+			"refresh_error": map[string]interface{}{"type": "string"},
+		}, "goal", "system", "credits", "shipyards", "offers"),
+	}
+}
+
+// shipOffer is one ship for sale and how well it suits the goal
+type shipOffer struct {
+	ShipType     string           `json:"shipType"`
+	Name         string           `json:"name"`
+	Shipyard     string           `json:"shipyard"`
+	Price        int              `json:"price"`
+	Supply       string           `json:"supply,omitempty"`
+	Speed        int              `json:"speed"`
+	Capabilities shipCapabilities `json:"capabilities"`
+	// Score is the ship's capability for the goal; ValuePer1000 is the score per 1000 credits
+	Score        float64   `json:"score"`
+	ValuePer1000 float64   `json:"valuePer1000"`
+	Affordable   bool      `json:"affordable"`
+	ObservedAt   time.Time `json:"observedAt"`
+}
+
+// Handler returns the tool handler function
+func (t *RecommendShipTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "recommend-ship-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		goal := v.RequireChoice("goal", purchaseGoals...)
+		systemSymbol := v.OptionalSystem("system")
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		agent, err := c.GetAgent()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch agent: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Error fetching agent: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Fetching a system's shipyards refreshes the listings of any with a ship present
+		var refreshErr error
+		if systemSymbol != "" {
+			refreshErr = t.refreshShipyards(c, systemSymbol)
+			if refreshErr != nil {
+				ctxLogger.Error("Failed to refresh shipyards in %s: %v", systemSymbol, refreshErr)
+			}
+		}
+
+		offers := make([]shipOffer, 0)
+		shipyards := 0
+		for _, listing := range c.KnownShipyards() {
+			if systemSymbol != "" && travel.SystemSymbol(listing.Shipyard.Symbol) != systemSymbol {
+				continue
+			}
+			shipyards++
+			for _, ship := range listing.Shipyard.Ships {
+				offer := rateShipOffer(ship, goal, agent.Credits)
+				if offer.Score <= 0 {
+					continue
+				}
+				offer.Shipyard = listing.Shipyard.Symbol
+				offer.ObservedAt = listing.FetchedAt
+				offers = append(offers, offer)
+			}
+		}
+		rankShipOffers(offers)
+
+		var recommended *shipOffer
+		for i := range offers {
+			if offers[i].Affordable {
+				recommended = &offers[i]
+				break
+			}
+		}
+
+		result := map[string]interface{}{
+			"goal":      goal,
+			"system":    systemSymbol,
+			"credits":   agent.Credits,
+			"shipyards": shipyards,
+			"offers":    offers,
+		}
+		if recommended != nil {
+			result["recommended"] = recommended
+		}
+		if refreshErr != nil {
+			result["refresh_error"] = refreshErr.Error()
+		}
+
+		textSummary := fmt.Sprintf("## 🛒 Ship Purchase Advice: %s\n\n", goal)
+		textSummary += fmt.Sprintf("**Credits:** %d\n", agent.Credits)
+		textSummary += fmt.Sprintf("**Shipyards compared:** %d\n\n", shipyards)
+		switch {
+		case len(offers) == 0:
+			textSummary += fmt.Sprintf("No known ship suits %s. Shipyards only show ships and prices while one of your ships is there: navigate a ship or probe to a shipyard and view it, then ask again.\n", goal)
+		case recommended == nil:
+			cheapest := offers[0]
+			for _, offer := range offers {
+				if offer.Price < cheapest.Price {
+					cheapest = offer
+				}
+			}
+			textSummary += fmt.Sprintf("❌ **Nothing affordable.** The cheapest suitable ship is %s at %s for %d credits, %d more than you have.\n", cheapest.ShipType, cheapest.Shipyard, cheapest.Price, int64(cheapest.Price)-agent.Credits)
+		default:
+			textSummary += fmt.Sprintf("✅ **Recommended:** %s at %s for %d credits (%s)\n", recommended.ShipType, recommended.Shipyard, recommended.Price, goalReason(*recommended, goal))
+			textSummary += fmt.Sprintf("Buy it with `purchase_ship` (ship_type=%s, waypoint_symbol=%s) using a ship docked there.\n", recommended.ShipType, recommended.Shipyard)
+		}
+
+		if len(offers) > 0 {
+			textSummary += "\n**Best value first:**\n"
+			for i, offer := range offers {
+				if i == 10 {
+					textSummary += fmt.Sprintf("...and %d more\n", len(offers)-i)
+					break
+				}
+				affordable := ""
+				if !offer.Affordable {
+					affordable = " - can't afford"
+				}
+				textSummary += fmt.Sprintf("%d. **%s** at %s - %d credits, %s, %.2f per 1000 credits (seen %s ago)%s\n", i+1, offer.ShipType, offer.Shipyard, offer.Price, goalReason(offer, goal), offer.ValuePer1000, formatAge(time.Since(offer.ObservedAt)), affordable)
+			}
+		}
+		if refreshErr != nil {
+			textSummary += fmt.Sprintf("\n⚠️ Could not refresh every shipyard in %s: %v\n", systemSymbol, refreshErr)
+		}
+
+		ctxLogger.ToolCall("recommend_ship_purchase", true)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// refreshShipyards fetches every shipyard in a system so listings with a ship present are current
+func (t *RecommendShipTool) refreshShipyards(c *client.Client, systemSymbol string) error {
+	waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, waypoint := range waypoints {
+		for _, trait := range waypoint.Traits {
+			if trait.Symbol != "SHIPYARD" {
+				continue
+			}
+			if _, err := c.GetShipyard(systemSymbol, waypoint.Symbol); err != nil {
+				failed = append(failed, waypoint.Symbol)
+			}
+			break
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to get shipyards at %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// shipyardCapabilities reads what a ship for sale can do, the same way capabilitiesOf does for owned ships
+func shipyardCapabilities(ship client.ShipyardShip) shipCapabilities {
+	mounts := make([]client.Mount, 0, len(ship.Mounts))
+	for _, mount := range ship.Mounts {
+		mounts = append(mounts, client.Mount{Symbol: mount.Symbol, Strength: mount.Strength, Requirements: mount.Requirements})
+	}
+	// A ship for sale has an empty hold, so its capacity is the sum of its cargo modules
+	modules := make([]client.Module, 0, len(ship.Modules))
+	cargo := 0
+	for _, module := range ship.Modules {
+		modules = append(modules, client.Module{Symbol: module.Symbol, Capacity: module.Capacity, Range: module.Range, Requirements: module.Requirements})
+		if strings.HasPrefix(module.Symbol, "MODULE_CARGO_HOLD") {
+			cargo += module.Capacity
+		}
+	}
+
+	return capabilitiesOf(client.Ship{
+		Frame:   client.Frame{ModuleSlots: ship.Frame.ModuleSlots, MountingPoints: ship.Frame.MountingPoints},
+		Mounts:  mounts,
+		Modules: modules,
+		Cargo:   client.Cargo{Capacity: cargo},
+		Fuel:    client.Fuel{Capacity: ship.Frame.FuelCapacity},
+	})
+}
+
+// rateShipOffer scores a ship for a goal; a score of 0 means the ship cannot do the job.
+//   - mining: total mining laser strength, plus a little for cargo so a miner can mine longer between sales
+//   - hauling: cargo capacity
+//   - exploration: engine speed, doubled with a warp or jump drive; probes need no fuel so count as well
+//   - trading: cargo capacity times engine speed, the units moved per unit of time
+func rateShipOffer(ship client.ShipyardShip, goal string, credits int64) shipOffer {
+	caps := shipyardCapabilities(ship)
+	offer := shipOffer{
+		ShipType:     ship.Type,
+		Name:         ship.Name,
+		Price:        ship.PurchasePrice,
+		Supply:       ship.Supply,
+		Speed:        ship.Engine.Speed,
+		Capabilities: caps,
+		Affordable:   int64(ship.PurchasePrice) <= credits,
+	}
+
+	switch goal {
+	case "mining":
+		if caps.MiningStrength > 0 {
+			offer.Score = float64(caps.MiningStrength) + float64(caps.CargoCapacity)/10
+		}
+	case "hauling":
+		offer.Score = float64(caps.CargoCapacity)
+	case "exploration":
+		offer.Score = float64(ship.Engine.Speed)
+		if caps.WarpDrive || caps.JumpDrive {
+			offer.Score *= 2
+		}
+	case "trading":
+		offer.Score = float64(caps.CargoCapacity * ship.Engine.Speed)
+	}
+	if offer.Score > 0 && ship.PurchasePrice > 0 {
+		offer.ValuePer1000 = offer.Score / float64(ship.PurchasePrice) * 1000
+	}
+	return offer
+}
+
+// rankShipOffers sorts offers by value for money, best first, then by raw score
+func rankShipOffers(offers []shipOffer) {
+	sort.SliceStable(offers, func(i, j int) bool {
+		if offers[i].ValuePer1000 != offers[j].ValuePer1000 {
+			return offers[i].ValuePer1000 > offers[j].ValuePer1000
+		}
+		return offers[i].Score > offers[j].Score
+	})
+}
+
+// goalReason describes the specs that earned an offer its score
+func goalReason(offer shipOffer, goal string) string {
+	caps := offer.Capabilities
+	switch goal {
+	case "mining":
+		return fmt.Sprintf("mining strength %d, cargo %d", caps.MiningStrength, caps.CargoCapacity)
+	case "hauling":
+		return fmt.Sprintf("cargo %d, speed %d", caps.CargoCapacity, offer.Speed)
+	case "exploration":
+		reason := fmt.Sprintf("speed %d, fuel %d", offer.Speed, caps.FuelCapacity)
+		if caps.WarpDrive {
+			reason += ", warp drive"
+		}
+		if caps.JumpDrive {
+			reason += ", jump drive"
+		}
+		return reason
+	default:
+		return fmt.Sprintf("cargo %d at speed %d", caps.CargoCapacity, offer.Speed)
+	}
+}
@@ -0,0 +1,72 @@
+package info
+
+import (
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestRateShipOffer(t *testing.T) {
+	drone := client.ShipyardShip{
+		Type:          "SHIP_MINING_DRONE",
+		PurchasePrice: 20000,
+		Frame:         client.ShipyardShipFrame{ModuleSlots: 2, MountingPoints: 2, FuelCapacity: 80},
+		Engine:        client.ShipyardShipEngine{Speed: 2},
+		Modules:       []client.ShipyardShipModule{{Symbol: "MODULE_CARGO_HOLD_I", Capacity: 15, Requirements: client.ShipRequirements{Slots: 1}}},
+		Mounts:        []client.ShipyardShipMount{{Symbol: "MOUNT_MINING_LASER_I", Strength: 10}},
+	}
+	hauler := client.ShipyardShip{
+		Type:          "SHIP_LIGHT_HAULER",
+		PurchasePrice: 150000,
+		Frame:         client.ShipyardShipFrame{ModuleSlots: 6, MountingPoints: 1, FuelCapacity: 600},
+		Engine:        client.ShipyardShipEngine{Speed: 10},
+		Modules: []client.ShipyardShipModule{
+			{Symbol: "MODULE_CARGO_HOLD_II", Capacity: 40, Requirements: client.ShipRequirements{Slots: 2}},
+			{Symbol: "MODULE_CARGO_HOLD_II", Capacity: 40, Requirements: client.ShipRequirements{Slots: 2}},
+		},
+	}
+	probe := client.ShipyardShip{
+		Type:          "SHIP_PROBE",
+		PurchasePrice: 25000,
+		Engine:        client.ShipyardShipEngine{Speed: 3},
+	}
+
+	mining := rateShipOffer(drone, "mining", 50000)
+	if mining.Score != 11.5 || !mining.Affordable {
+		t.Errorf("Expected an affordable mining score of 11.5, got %v (affordable %v)", mining.Score, mining.Affordable)
+	}
+	if got := rateShipOffer(hauler, "mining", 50000); got.Score != 0 {
+		t.Errorf("Expected a hauler without lasers to score 0 for mining, got %v", got.Score)
+	}
+
+	hauling := rateShipOffer(hauler, "hauling", 50000)
+	if hauling.Capabilities.CargoCapacity != 80 || hauling.Affordable {
+		t.Errorf("Expected 80 cargo and not affordable, got %d (affordable %v)", hauling.Capabilities.CargoCapacity, hauling.Affordable)
+	}
+	if hauling.ValuePer1000 <= 0 {
+		t.Errorf("Expected a value per 1000 credits, got %v", hauling.ValuePer1000)
+	}
+
+	if got := rateShipOffer(probe, "trading", 50000); got.Score != 0 {
+		t.Errorf("Expected a probe without a hold to score 0 for trading, got %v", got.Score)
+	}
+	if got := rateShipOffer(probe, "exploration", 50000); got.Score != 3 {
+		t.Errorf("Expected a probe to score its speed for exploration, got %v", got.Score)
+	}
+}
+
+func TestRankShipOffers(t *testing.T) {
+	offers := []shipOffer{
+		{ShipType: "EXPENSIVE", Score: 80, ValuePer1000: 0.5},
+		{ShipType: "BARGAIN", Score: 15, ValuePer1000: 0.75},
+		{ShipType: "BIGGER_BARGAIN", Score: 30, ValuePer1000: 0.75},
+	}
+	rankShipOffers(offers)
+
+	want := []string{"BIGGER_BARGAIN", "BARGAIN", "EXPENSIVE"}
+	for i, offer := range offers {
+		if offer.ShipType != want[i] {
+			t.Fatalf("Expected order %v, got %v at %d", want, offer.ShipType, i)
+		}
+	}
+}
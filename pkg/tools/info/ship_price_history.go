@@ -0,0 +1,200 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipwatch"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultShipPriceWindow is how far back ship prices are summarized when no window is given
+const defaultShipPriceWindow = 24 * time.Hour
+
+// ShipPriceHistoryTool shows how shipyard prices of a ship type have moved, and the price watches
+type ShipPriceHistoryTool struct {
+	watcher *shipwatch.Watcher
+	logger  *logging.Logger
+}
+
+// NewShipPriceHistoryTool creates a new ship price history tool
+func NewShipPriceHistoryTool(watcher *shipwatch.Watcher, logger *logging.Logger) *ShipPriceHistoryTool {
+	return &ShipPriceHistoryTool{
+		watcher: watcher,
+		logger:  logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *ShipPriceHistoryTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "ship_price_history",
+		Description: "Show the shipyard prices recorded for a ship type: the latest price at each shipyard, cheapest first, and the range over a window, along with the ship price watches. Without a ship type, lists the cheapest latest price of every ship type seen and all watches. Prices are recorded whenever a shipyard is viewed with a ship present. Makes no API calls.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Ship type (e.g., 'SHIP_MINING_DRONE'); omit for an overview of every ship type",
+				},
+				"waypoint": map[string]interface{}{
+					"type":        "string",
+					"description": "Only show prices at this shipyard (optional)",
+				},
+				"window_hours": map[string]interface{}{
+					"type":        "number",
+					"description": fmt.Sprintf("How far back to summarize (default %g hours)", defaultShipPriceWindow.Hours()),
+					"minimum":     0,
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_type": map[string]interface{}{"type": "string"},
+			"window":    map[string]interface{}{"type": "string"},
+			"latest":    map[string]interface{}{"type": "array", "description": "The latest price at each shipyard, cheapest first, or of each ship type without one"},
+			"history":   map[string]interface{}{"type": "array", "description": "Every price seen in the window, oldest first"},
+			"range":     map[string]interface{}{"type": "object", "description": "Lowest, highest and mean price in the window"},
+			"watches":   map[string]interface{}{"type": "array"},
+		}, "latest", "watches"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *ShipPriceHistoryTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "ship-price-history-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipType := v.OptionalSymbol("ship_type", utils.ShipTypes, "")
+		waypoint := v.OptionalWaypoint("waypoint")
+		window := v.OptionalHours("window_hours", defaultShipPriceWindow)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		if shipType == "" {
+			ctxLogger.ToolCall("ship_price_history", true)
+			return t.overview(), nil
+		}
+
+		latest := make([]shipwatch.PricePoint, 0)
+		for _, point := range t.watcher.Latest(shipType) {
+			if waypoint == "" || point.WaypointSymbol == waypoint {
+				latest = append(latest, point)
+			}
+		}
+		history := t.watcher.History(shipType, waypoint, time.Now().Add(-window))
+		watches := make([]shipwatch.Watch, 0)
+		for _, watch := range t.watcher.Watches() {
+			if watch.ShipType == shipType {
+				watches = append(watches, watch)
+			}
+		}
+		windowDescription := fmt.Sprintf("last %s", formatWindow(window))
+
+		result := map[string]interface{}{
+			"ship_type": shipType,
+			"window":    windowDescription,
+			"latest":    latest,
+			"history":   history,
+			"watches":   watches,
+		}
+
+		textSummary := fmt.Sprintf("## 🚀 %s Prices\n\n", shipType)
+		if len(latest) == 0 {
+			textSummary += "No price has been recorded for it yet. Shipyards only show prices while one of your ships is there; view one with a ship present or station a probe with deploy_probe.\n"
+		} else {
+			textSummary += "**Latest price by shipyard:**\n"
+			for _, point := range latest {
+				textSummary += fmt.Sprintf("- **%s** - %d credits (supply %s, seen %s ago)\n", point.WaypointSymbol, point.PurchasePrice, point.Supply, formatAge(time.Since(point.ObservedAt)))
+			}
+		}
+
+		if len(history) > 0 {
+			lowest, highest, total := history[0], history[0], 0
+			for _, point := range history {
+				if point.PurchasePrice < lowest.PurchasePrice {
+					lowest = point
+				}
+				if point.PurchasePrice > highest.PurchasePrice {
+					highest = point
+				}
+				total += point.PurchasePrice
+			}
+			mean := float64(total) / float64(len(history))
+			result["range"] = map[string]interface{}{
+				"min":          lowest.PurchasePrice,
+				"max":          highest.PurchasePrice,
+				"mean":         mean,
+				"observations": len(history),
+			}
+			textSummary += fmt.Sprintf("\n**%s:** %d prices seen, from %d (%s) to %d (%s), averaging %.0f\n", windowDescription, len(history), lowest.PurchasePrice, lowest.WaypointSymbol, highest.PurchasePrice, highest.WaypointSymbol, mean)
+		}
+
+		textSummary += watchesSummary(watches)
+
+		ctxLogger.ToolCall("ship_price_history", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// overview lists the cheapest latest price of every ship type and every watch
+func (t *ShipPriceHistoryTool) overview() *mcp.CallToolResult {
+	latest := make([]shipwatch.PricePoint, 0)
+	textSummary := "## 🚀 Ship Prices\n\n"
+	types := t.watcher.ShipTypes()
+	if len(types) == 0 {
+		textSummary += "No shipyard prices have been recorded yet. Shipyards only show prices while one of your ships is there.\n"
+	}
+	for _, shipType := range types {
+		cheapest := t.watcher.Latest(shipType)[0]
+		latest = append(latest, cheapest)
+		textSummary += fmt.Sprintf("- **%s** - %d credits at %s (seen %s ago)\n", shipType, cheapest.PurchasePrice, cheapest.WaypointSymbol, formatAge(time.Since(cheapest.ObservedAt)))
+	}
+
+	watches := t.watcher.Watches()
+	textSummary += watchesSummary(watches)
+
+	shipTypes := make([]map[string]interface{}, 0, len(latest))
+	for i, point := range latest {
+		shipTypes = append(shipTypes, map[string]interface{}{"shipType": types[i], "cheapest": point})
+	}
+	return utils.NewResult(textSummary, map[string]interface{}{
+		"latest":  shipTypes,
+		"watches": watches,
+	})
+}
+
+// watchesSummary describes ship price watches, or nothing when there are none
+func watchesSummary(watches []shipwatch.Watch) string {
+	if len(watches) == 0 {
+		return ""
+	}
+	summary := "\n**Watches:**\n"
+	for _, watch := range watches {
+		where := "any shipyard"
+		if len(watch.Waypoints) > 0 {
+			where = strings.Join(watch.Waypoints, ", ")
+		}
+		summary += fmt.Sprintf("- **%s** - %s at %d credits or less at %s", watch.ID, watch.ShipType, watch.TargetPrice, where)
+		if watch.Best != nil {
+			summary += fmt.Sprintf("; best %d at %s", watch.Best.PurchasePrice, watch.Best.WaypointSymbol)
+		}
+		switch {
+		case watch.Alerts == 1:
+			summary += "; alerted once"
+		case watch.Alerts > 1:
+			summary += fmt.Sprintf("; alerted %d times", watch.Alerts)
+		}
+		summary += "\n"
+		if watch.LastError != "" {
+			summary += fmt.Sprintf("  ⚠️ %s\n", watch.LastError)
+		}
+	}
+	return summary
+}
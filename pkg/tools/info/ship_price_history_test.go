@@ -0,0 +1,47 @@
+package info
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipwatch"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestShipPriceHistoryTool(t *testing.T) {
+	watcher := shipwatch.New(context.Background(), nil, logging.NewLogger(nil))
+	now := time.Now()
+	for i, price := range []int{48000, 44000, 46000} {
+		watcher.Record("X1-TEST-A1", now.Add(time.Duration(i-3)*time.Hour), []client.ShipyardShip{{Type: "SHIP_MINING_DRONE", PurchasePrice: price, Supply: "MODERATE"}})
+	}
+	watcher.Record("X1-TEST-B2", now, []client.ShipyardShip{{Type: "SHIP_MINING_DRONE", PurchasePrice: 45500, Supply: "HIGH"}})
+	watcher.Watch("SHIP_MINING_DRONE", 45000, nil)
+
+	tool := NewShipPriceHistoryTool(watcher, logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"ship_type": "SHIP_MINING_DRONE"})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result)
+	}
+
+	text, _ := mcp.AsTextContent(result.Content[0])
+	b2 := strings.Index(text.Text, "**X1-TEST-B2** - 45500")
+	a1 := strings.Index(text.Text, "**X1-TEST-A1** - 46000")
+	if b2 == -1 || a1 == -1 || b2 > a1 {
+		t.Errorf("Expected B2 (45500) listed before A1 (46000), got: %s", text.Text)
+	}
+	for _, want := range []string{"4 prices seen, from 44000 (X1-TEST-A1) to 48000 (X1-TEST-A1)", "**watch-1** - SHIP_MINING_DRONE at 45000 credits or less at any shipyard; best 45500 at X1-TEST-B2"} {
+		if !strings.Contains(text.Text, want) {
+			t.Errorf("Expected %q in: %s", want, text.Text)
+		}
+	}
+
+	overview := callTool(t, tool, nil)
+	if text, _ := mcp.AsTextContent(overview.Content[0]); !strings.Contains(text.Text, "**SHIP_MINING_DRONE** - 45500 credits at X1-TEST-B2") {
+		t.Errorf("Expected the cheapest latest price in the overview, got: %s", text.Text)
+	}
+}
@@ -0,0 +1,271 @@
+package info
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/snapshot"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/version"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// snapshotPartNames describe archive parts to the user
+var snapshotPartNames = map[string]string{
+	snapshot.PartPrices:      "market price snapshots",
+	snapshot.PartExploration: "known systems and waypoints",
+	snapshot.PartShipMeta:    "labelled ships and squadrons",
+	snapshot.PartTasks:       "running tasks",
+}
+
+// SaveSnapshotTool saves the server's local state to a single archive
+type SaveSnapshotTool struct {
+	state  snapshot.State
+	dir    string
+	logger *logging.Logger
+}
+
+// NewSaveSnapshotTool creates a new save snapshot tool that writes archives under dir
+func NewSaveSnapshotTool(state snapshot.State, dir string, logger *logging.Logger) *SaveSnapshotTool {
+	return &SaveSnapshotTool{
+		state:  state,
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *SaveSnapshotTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "save_snapshot",
+		Description: fmt.Sprintf("Save what the server has learned locally - the market price database, exploration progress, ship labels, tags and squadrons, and the running background tasks - to a single zip archive, to move to another machine or recover after reinstalling with restore_snapshot. Archives are written under %s.", t.dir),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Archive to write, relative to the export directory (optional - defaults to snapshot- and the current time)",
+				},
+				"overwrite": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Replace the archive if it already exists (default false)",
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"path":     map[string]interface{}{"type": "string", "description": "Absolute path of the archive written"},
+			"bytes":    map[string]interface{}{"type": "integer"},
+			"manifest": map[string]interface{}{"type": "object", "description": "Format version, creation time and how much each part holds"},
+		}, "path", "manifest"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *SaveSnapshotTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "save-snapshot-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		path := v.OptionalString("path", "")
+		overwrite := v.OptionalBool("overwrite", false)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		now := time.Now()
+		if path == "" {
+			path = fmt.Sprintf("snapshot-%s", now.UTC().Format("20060102-150405"))
+		}
+		if filepath.Ext(path) == "" {
+			path += ".zip"
+		}
+		target, err := resolveExportPath(t.dir, path)
+		if err != nil {
+			return exportError(err.Error()), nil
+		}
+		if !overwrite {
+			if _, err := os.Stat(target); err == nil {
+				return exportError(fmt.Sprintf("%s already exists. Choose another path or set overwrite to true.", target)), nil
+			}
+		}
+
+		var buf bytes.Buffer
+		manifest, err := snapshot.Save(&buf, t.state, version.Get().Version, now)
+		if err != nil {
+			ctxLogger.Error("Failed to build snapshot: %v", err)
+			ctxLogger.ToolCall("save_snapshot", false)
+			return exportError(fmt.Sprintf("Failed to build snapshot: %v", err)), nil
+		}
+		if err := writeExport(target, buf.Bytes()); err != nil {
+			ctxLogger.Error("Failed to write snapshot %s: %v", target, err)
+			ctxLogger.ToolCall("save_snapshot", false)
+			return exportError(fmt.Sprintf("Failed to write %s: %v", target, err)), nil
+		}
+
+		result := map[string]interface{}{
+			"path":     target,
+			"bytes":    buf.Len(),
+			"manifest": manifest,
+		}
+
+		textSummary := "## 📦 Snapshot Saved\n\n"
+		textSummary += fmt.Sprintf("**File:** %s (%d bytes)\n\n", target, buf.Len())
+		textSummary += describeSnapshotCounts(manifest.Counts)
+		textSummary += "\nCopy the file to the other machine and call restore_snapshot with its path to pick up where you left off.\n"
+
+		ctxLogger.ToolCall("save_snapshot", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// RestoreSnapshotTool replaces the server's local state with a saved archive
+type RestoreSnapshotTool struct {
+	state  snapshot.State
+	dir    string
+	logger *logging.Logger
+}
+
+// NewRestoreSnapshotTool creates a new restore snapshot tool that finds relative paths under dir
+func NewRestoreSnapshotTool(state snapshot.State, dir string, logger *logging.Logger) *RestoreSnapshotTool {
+	return &RestoreSnapshotTool{
+		state:  state,
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *RestoreSnapshotTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "restore_snapshot",
+		Description: "Replace the server's market price database, exploration progress and ship labels, tags and squadrons with an archive written by save_snapshot. What the server recorded since is lost. Tasks that were running when it was saved are listed, and only started again when resume_tasks is set.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Archive to restore: an absolute path, or one relative to %s", t.dir),
+				},
+				"resume_tasks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Start the saved background tasks again on their ships (default false)",
+				},
+			},
+			Required: []string{"path"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"path":        map[string]interface{}{"type": "string"},
+			"manifest":    map[string]interface{}{"type": "object"},
+			"restored":    map[string]interface{}{"type": "object", "description": "How much of each restored part was loaded"},
+			"skipped":     map[string]interface{}{"type": "object", "description": "Why a part of the archive was not restored"},
+			"tasks":       map[string]interface{}{"type": "array", "description": "Tasks that were running when the archive was saved"},
+			"resumed":     map[string]interface{}{"type": "array"},
+			"task_errors": map[string]interface{}{"type": "array"},
+		}, "path", "manifest", "restored"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *RestoreSnapshotTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "restore-snapshot-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		path := v.RequireString("path")
+		resumeTasks := v.OptionalBool("resume_tasks", false)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+		// Archives may be read from anywhere; only writes are kept to the export directory
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(t.dir, path)
+		}
+
+		report, err := snapshot.RestoreFile(path, t.state, resumeTasks)
+		if err != nil {
+			ctxLogger.Error("Failed to restore snapshot %s: %v", path, err)
+			ctxLogger.ToolCall("restore_snapshot", false)
+			return exportError(fmt.Sprintf("Nothing was restored from %s: %v", path, err)), nil
+		}
+
+		result := map[string]interface{}{
+			"path":     path,
+			"manifest": report.Manifest,
+			"restored": report.Restored,
+		}
+		if len(report.Skipped) > 0 {
+			result["skipped"] = report.Skipped
+		}
+		if len(report.Tasks) > 0 {
+			result["tasks"] = report.Tasks
+		}
+		if len(report.Resumed) > 0 {
+			result["resumed"] = report.Resumed
+		}
+		if len(report.TaskErrors) > 0 {
+			result["task_errors"] = report.TaskErrors
+		}
+
+		textSummary := "## 📦 Snapshot Restored\n\n"
+		textSummary += fmt.Sprintf("**File:** %s, saved %s\n\n", path, report.Manifest.CreatedAt.Format(time.RFC3339))
+		textSummary += describeSnapshotCounts(report.Restored)
+
+		if len(report.Skipped) > 0 {
+			textSummary += "\n**Not restored:**\n"
+			for _, part := range sortedKeys(report.Skipped) {
+				textSummary += fmt.Sprintf("- %s: %s\n", snapshotPartNames[part], report.Skipped[part])
+			}
+		}
+		if len(report.Resumed) > 0 {
+			textSummary += "\n**Tasks started again:**\n"
+			for _, task := range report.Resumed {
+				textSummary += fmt.Sprintf("- %s: %s (%s)\n", task.ShipSymbol, task.Behavior, task.ID)
+			}
+		} else if len(report.Tasks) > 0 && !resumeTasks {
+			textSummary += "\n**Tasks saved but not started:**\n"
+			for _, spec := range report.Tasks {
+				textSummary += fmt.Sprintf("- %s: %s\n", spec.ShipSymbol, spec.Behavior)
+			}
+			textSummary += "Call restore_snapshot again with resume_tasks, or assign_task, to start them.\n"
+		}
+		for _, taskErr := range report.TaskErrors {
+			textSummary += fmt.Sprintf("- ⚠️ %s\n", taskErr)
+		}
+
+		ctxLogger.ToolCall("restore_snapshot", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// describeSnapshotCounts lists how much each archive part holds, in a fixed order
+func describeSnapshotCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "Nothing was included.\n"
+	}
+	text := ""
+	for _, part := range []string{snapshot.PartPrices, snapshot.PartExploration, snapshot.PartShipMeta, snapshot.PartTasks} {
+		if count, ok := counts[part]; ok {
+			text += fmt.Sprintf("- %d %s\n", count, snapshotPartNames[part])
+		}
+	}
+	return text
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
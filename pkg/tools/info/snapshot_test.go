@@ -0,0 +1,60 @@
+package info
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/snapshot"
+)
+
+func TestSnapshotTools_SaveThenRestore(t *testing.T) {
+	dir := t.TempDir()
+	logger := logging.NewLogger(nil)
+
+	source := snapshot.State{Prices: prices.New()}
+	source.ShipMeta, _ = shipmeta.Open("")
+	source.Prices.Record(prices.Snapshot{WaypointSymbol: "X1-A1", ObservedAt: time.Now()})
+	if _, err := source.ShipMeta.SetLabel("SHIP-1", "Scout", nil); err != nil {
+		t.Fatalf("Failed to label ship: %v", err)
+	}
+
+	save := NewSaveSnapshotTool(source, dir, logger)
+	result := callTool(t, save, map[string]interface{}{"path": "backup"})
+	if result.IsError {
+		t.Fatalf("Expected save to succeed, got %+v", result)
+	}
+	structured := result.StructuredContent.(map[string]interface{})
+	if structured["path"] != filepath.Join(dir, "backup.zip") {
+		t.Fatalf("Expected backup.zip in the export directory, got %v", structured["path"])
+	}
+
+	target := snapshot.State{Prices: prices.New()}
+	target.ShipMeta, _ = shipmeta.Open("")
+	restore := NewRestoreSnapshotTool(target, dir, logger)
+	result = callTool(t, restore, map[string]interface{}{"path": "backup.zip"})
+	if result.IsError {
+		t.Fatalf("Expected restore to succeed, got %+v", result)
+	}
+	structured = result.StructuredContent.(map[string]interface{})
+	for _, field := range restore.Tool().OutputSchema.Required {
+		if _, ok := structured[field]; !ok {
+			t.Errorf("Expected structured content to include %s", field)
+		}
+	}
+	if markets := target.Prices.Markets(); len(markets) != 1 {
+		t.Errorf("Expected one restored market, got %v", markets)
+	}
+	if meta, ok := target.ShipMeta.Get("SHIP-1"); !ok || meta.Label != "Scout" {
+		t.Errorf("Expected SHIP-1 to be labelled Scout, got %+v", meta)
+	}
+
+	// A missing archive changes nothing
+	result = callTool(t, restore, map[string]interface{}{"path": "missing.zip"})
+	if !result.IsError {
+		t.Error("Expected an error for a missing archive")
+	}
+}
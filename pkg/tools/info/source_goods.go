@@ -0,0 +1,328 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxSourceOptions bounds how many buy and mine options are listed each
+const maxSourceOptions = 10
+
+// defaultExtractionCooldown is assumed when no miner has a cooldown to go by
+const defaultExtractionCooldown = 70 * time.Second
+
+// depositGoods lists what extracting at an asteroid with each deposit trait usually yields. Every
+// extraction yields one of the goods, so the more goods a deposit has, the fewer extractions yield
+// the one wanted.
+var depositGoods = map[string][]string{
+	"COMMON_METAL_DEPOSITS":   {"IRON_ORE", "COPPER_ORE", "ALUMINUM_ORE", "ICE_WATER", "SILICON_CRYSTALS", "QUARTZ_SAND"},
+	"PRECIOUS_METAL_DEPOSITS": {"GOLD_ORE", "SILVER_ORE", "PLATINUM_ORE", "ICE_WATER", "SILICON_CRYSTALS", "QUARTZ_SAND"},
+	"RARE_METAL_DEPOSITS":     {"URANITE_ORE", "MERITIUM_ORE", "ICE_WATER", "SILICON_CRYSTALS", "QUARTZ_SAND"},
+	"MINERAL_DEPOSITS":        {"SILICON_CRYSTALS", "QUARTZ_SAND", "PRECIOUS_STONES", "DIAMONDS", "ICE_WATER", "AMMONIA_ICE"},
+	"ICE_CRYSTALS":            {"ICE_WATER", "AMMONIA_ICE", "LIQUID_HYDROGEN", "LIQUID_NITROGEN"},
+}
+
+// SourceGoodsTool works out the cheapest ways to obtain a number of units of a good
+type SourceGoodsTool struct {
+	client *client.Client
+	prices *prices.DB
+	logger *logging.Logger
+}
+
+// NewSourceGoodsTool creates a new goods sourcing tool
+func NewSourceGoodsTool(client *client.Client, db *prices.DB, logger *logging.Logger) *SourceGoodsTool {
+	return &SourceGoodsTool{
+		client: client,
+		prices: db,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *SourceGoodsTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "source_goods",
+		Description: "Work out the cheapest way to get N units of a good, for example to fill a procurement contract: which markets to buy from (using recorded prices) and which asteroids in a system could be mined for it, with cost and time estimates for each option.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"trade_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Trade symbol of the good needed (e.g., 'COPPER_ORE')",
+				},
+				"units": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of units needed",
+					"minimum":     1,
+				},
+				"near_system": map[string]interface{}{
+					"type":        "string",
+					"description": "System to source in (e.g., 'X1-FM66'); asteroids are searched here and its markets are listed first",
+				},
+			},
+			Required: []string{"trade_symbol", "units", "near_system"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"trade_symbol": map[string]interface{}{"type": "string"},
+			"units":        map[string]interface{}{"type": "integer"},
+			"near_system":  map[string]interface{}{"type": "string"},
+			"buy_options":  map[string]interface{}{"type": "array", "description": "Markets selling the good, cheapest delivered cost first"},
+			"mine_options": map[string]interface{}{"type": "array", "description": "Waypoints where the good can be extracted"},
+			"warnings":     map[string]interface{}{"type": "array"},
+		}, "trade_symbol", "units", "near_system", "buy_options", "mine_options"),
+	}
+}
+
+// buyOption is buying the units at one market
+type buyOption struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	SameSystem     bool      `json:"sameSystem"`
+	PricePerUnit   int       `json:"pricePerUnit"`
+	TotalCost      int       `json:"totalCost"`
+	TradeVolume    int       `json:"tradeVolume"`
+	Transactions   int       `json:"transactions"`
+	Supply         string    `json:"supply"`
+	ObservedAt     time.Time `json:"observedAt"`
+}
+
+// mineOption is extracting the units at one asteroid
+type mineOption struct {
+	WaypointSymbol string   `json:"waypointSymbol"`
+	WaypointType   string   `json:"waypointType"`
+	Deposit        string   `json:"deposit"`
+	DepositGoods   []string `json:"depositGoods"`
+	// The estimates below are only set when the fleet has a ship with a mining laser
+	Miner              string `json:"miner,omitempty"`
+	UnitsPerExtraction int    `json:"unitsPerExtraction,omitempty"`
+	Extractions        int    `json:"extractions,omitempty"`
+	EstimatedSeconds   int    `json:"estimatedSeconds,omitempty"`
+}
+
+// miner is the fleet's strongest extractor
+type miner struct {
+	symbol   string
+	strength int
+	cooldown time.Duration
+}
+
+// Handler returns the tool handler function
+func (t *SourceGoodsTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "source-goods-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		tradeSymbol := v.RequireSymbol("trade_symbol", utils.TradeSymbols)
+		units := v.RequireInt("units", 1)
+		systemSymbol := v.RequireSystem("near_system")
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Sourcing %d %s near %s", units, tradeSymbol, systemSymbol))
+
+		buys := t.buyOptions(tradeSymbol, units, systemSymbol)
+
+		c := t.client.WithContext(ctx)
+		var warnings []string
+		mines, err := mineOptions(c, tradeSymbol, systemSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to list asteroids in %s: %v", systemSymbol, err))
+			warnings = append(warnings, fmt.Sprintf("Could not list asteroids in %s: %v", systemSymbol, err))
+		}
+
+		var best *miner
+		if len(mines) > 0 {
+			ships, err := c.GetAllShips()
+			if err != nil {
+				contextLogger.Error(fmt.Sprintf("Failed to get ships: %v", err))
+				warnings = append(warnings, fmt.Sprintf("Could not check the fleet for miners: %v", err))
+			} else {
+				best = strongestMiner(ships)
+			}
+		}
+		if best != nil {
+			for i := range mines {
+				estimateMining(&mines[i], best, units)
+			}
+			sort.SliceStable(mines, func(i, j int) bool {
+				return mines[i].EstimatedSeconds < mines[j].EstimatedSeconds
+			})
+		}
+		if len(mines) > maxSourceOptions {
+			mines = mines[:maxSourceOptions]
+		}
+
+		contextLogger.ToolCall("source_goods", true)
+
+		result := map[string]interface{}{
+			"trade_symbol": tradeSymbol,
+			"units":        units,
+			"near_system":  systemSymbol,
+			"buy_options":  buys,
+			"mine_options": mines,
+		}
+		if len(warnings) > 0 {
+			result["warnings"] = warnings
+		}
+
+		textSummary := fmt.Sprintf("## Sourcing %d %s near %s\n\n", units, tradeSymbol, systemSymbol)
+
+		textSummary += "### 🛒 Buy\n"
+		if len(buys) == 0 {
+			textSummary += fmt.Sprintf("No recorded market sells %s. Prices are recorded whenever a market is viewed with a ship present; try `find_waypoints_by_capability` with sells=%s to find markets to check.\n", tradeSymbol, tradeSymbol)
+		}
+		for i, option := range buys {
+			textSummary += fmt.Sprintf("%d. **%s** (in %s) - %d credits total at %d/unit, %d purchase(s) of up to %d, supply %s\n",
+				i+1, option.WaypointSymbol, travel.SystemSymbol(option.WaypointSymbol), option.TotalCost, option.PricePerUnit, option.Transactions, option.TradeVolume, option.Supply)
+		}
+		if len(buys) > 0 {
+			textSummary += "Prices usually rise as you buy, so large orders cost more than shown.\n"
+		}
+
+		textSummary += "\n### ⛏️ Mine\n"
+		if len(mines) == 0 {
+			textSummary += fmt.Sprintf("No asteroid in %s has a deposit known to yield %s.\n", systemSymbol, tradeSymbol)
+		}
+		for i, option := range mines {
+			textSummary += fmt.Sprintf("%d. **%s** (%s, %s)", i+1, option.WaypointSymbol, option.WaypointType, option.Deposit)
+			if option.Miner != "" {
+				textSummary += fmt.Sprintf(" - about %d extractions, %s with %s", option.Extractions, time.Duration(option.EstimatedSeconds)*time.Second, option.Miner)
+			}
+			textSummary += "\n"
+		}
+		if len(mines) > 0 {
+			if best == nil {
+				textSummary += "No ship has a mining laser, so mining time cannot be estimated.\n"
+			} else {
+				textSummary += fmt.Sprintf("Estimates assume %d units per extraction split evenly across the deposit's goods, and %s cooldowns. Surveying first targets the good and cuts this down. Mining costs no credits apart from fuel.\n", best.strength, best.cooldown)
+			}
+		}
+
+		for _, warning := range warnings {
+			textSummary += fmt.Sprintf("\n⚠️ %s\n", warning)
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// buyOptions prices the units at every market with a recorded purchase price, cheapest first and
+// markets in the system before others at the same price
+func (t *SourceGoodsTool) buyOptions(tradeSymbol string, units int, systemSymbol string) []buyOption {
+	options := make([]buyOption, 0)
+	for _, quote := range t.prices.Quotes(tradeSymbol) {
+		if quote.PurchasePrice <= 0 {
+			continue
+		}
+		option := buyOption{
+			WaypointSymbol: quote.WaypointSymbol,
+			SameSystem:     travel.SystemSymbol(quote.WaypointSymbol) == systemSymbol,
+			PricePerUnit:   quote.PurchasePrice,
+			TotalCost:      quote.PurchasePrice * units,
+			TradeVolume:    quote.TradeVolume,
+			Transactions:   1,
+			Supply:         quote.Supply,
+			ObservedAt:     quote.ObservedAt,
+		}
+		if quote.TradeVolume > 0 {
+			option.Transactions = (units + quote.TradeVolume - 1) / quote.TradeVolume
+		}
+		options = append(options, option)
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].TotalCost != options[j].TotalCost {
+			return options[i].TotalCost < options[j].TotalCost
+		}
+		return options[i].SameSystem && !options[j].SameSystem
+	})
+	if len(options) > maxSourceOptions {
+		options = options[:maxSourceOptions]
+	}
+	return options
+}
+
+// mineOptions returns the asteroids in a system with a deposit known to yield the good
+func mineOptions(c *client.Client, tradeSymbol, systemSymbol string) ([]mineOption, error) {
+	waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]mineOption, 0)
+	for _, waypoint := range waypoints {
+		if !strings.Contains(waypoint.Type, "ASTEROID") {
+			continue
+		}
+		for _, trait := range waypoint.Traits {
+			goods, isDeposit := depositGoods[trait.Symbol]
+			if !isDeposit || !listsSymbol(goods, tradeSymbol) {
+				continue
+			}
+			options = append(options, mineOption{
+				WaypointSymbol: waypoint.Symbol,
+				WaypointType:   waypoint.Type,
+				Deposit:        trait.Symbol,
+				DepositGoods:   goods,
+			})
+			break
+		}
+	}
+	return options, nil
+}
+
+// strongestMiner returns the ship whose mining lasers have the most combined strength, or nil if none has any
+func strongestMiner(ships []client.Ship) *miner {
+	var best *miner
+	for _, ship := range ships {
+		strength := 0
+		for _, mount := range ship.Mounts {
+			if strings.HasPrefix(mount.Symbol, "MOUNT_MINING_LASER") {
+				strength += mount.Strength
+			}
+		}
+		if strength == 0 || (best != nil && strength <= best.strength) {
+			continue
+		}
+		cooldown := defaultExtractionCooldown
+		if ship.Cooldown.TotalSeconds > 0 {
+			cooldown = time.Duration(ship.Cooldown.TotalSeconds) * time.Second
+		}
+		best = &miner{symbol: ship.Symbol, strength: strength, cooldown: cooldown}
+	}
+	return best
+}
+
+// estimateMining fills in how many extractions and how long the miner needs for the units
+func estimateMining(option *mineOption, m *miner, units int) {
+	// Only a share of the extractions yield the good wanted
+	wantedPerExtraction := float64(m.strength) / float64(len(option.DepositGoods))
+	extractions := int(math.Ceil(float64(units) / wantedPerExtraction))
+
+	option.Miner = m.symbol
+	option.UnitsPerExtraction = m.strength
+	option.Extractions = max(extractions, 1)
+	option.EstimatedSeconds = option.Extractions * int(m.cooldown.Seconds())
+}
+
+// listsSymbol reports whether symbols contains symbol
+func listsSymbol(symbols []string, symbol string) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
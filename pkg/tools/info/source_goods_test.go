@@ -0,0 +1,66 @@
+package info
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSourceGoodsTool_BuyAndMineOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 0, "y": 0, "orbitals": [], "isUnderConstruction": false,
+					"traits": [{"symbol": "MARKETPLACE", "name": "", "description": ""}]},
+				{"symbol": "X1-TEST-AST", "systemSymbol": "X1-TEST", "type": "ASTEROID", "x": 10, "y": 0, "orbitals": [], "isUnderConstruction": false,
+					"traits": [{"symbol": "COMMON_METAL_DEPOSITS", "name": "", "description": ""}]},
+				{"symbol": "X1-TEST-ICE", "systemSymbol": "X1-TEST", "type": "ASTEROID", "x": 20, "y": 0, "orbitals": [], "isUnderConstruction": false,
+					"traits": [{"symbol": "ICE_CRYSTALS", "name": "", "description": ""}]}
+			], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+		case "/my/ships":
+			_, _ = w.Write([]byte(`{"data": [{"symbol": "MINER-1",
+				"mounts": [{"symbol": "MOUNT_MINING_LASER_I", "name": "", "strength": 12, "requirements": {}}],
+				"cooldown": {"shipSymbol": "MINER-1", "totalSeconds": 60, "remainingSeconds": 0}
+			}], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	db := prices.New()
+	seen := time.Now()
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-FAR-B2", ObservedAt: seen, Prices: []prices.Price{{TradeSymbol: "COPPER_ORE", PurchasePrice: 20, TradeVolume: 30}}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-TEST-A1", ObservedAt: seen, Prices: []prices.Price{{TradeSymbol: "COPPER_ORE", PurchasePrice: 25, TradeVolume: 20}}})
+
+	tool := NewSourceGoodsTool(client.NewClientWithBaseURL("test-token", server.URL), db, logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"trade_symbol": "COPPER_ORE", "units": float64(50), "near_system": "X1-TEST"})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+
+	text, _ := mcp.AsTextContent(result.Content[0])
+	far := strings.Index(text.Text, "X1-FAR-B2** (in X1-FAR) - 1000 credits total at 20/unit, 2 purchase(s)")
+	near := strings.Index(text.Text, "X1-TEST-A1** (in X1-TEST) - 1250 credits total at 25/unit, 3 purchase(s)")
+	if far == -1 || near == -1 || far > near {
+		t.Errorf("Expected the cheaper market first with totals and purchase counts, got: %s", text.Text)
+	}
+	// 12 units per extraction split over 6 common metal goods is 2 copper per extraction
+	if !strings.Contains(text.Text, "**X1-TEST-AST** (ASTEROID, COMMON_METAL_DEPOSITS) - about 25 extractions, 25m0s with MINER-1") {
+		t.Errorf("Expected a mining estimate for the common metal asteroid, got: %s", text.Text)
+	}
+	if strings.Contains(text.Text, "X1-TEST-ICE") {
+		t.Errorf("Expected the ice asteroid to be left out, got: %s", text.Text)
+	}
+}
@@ -0,0 +1,256 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultTradeLimit = 10
+	maxTradeLimit     = 50
+)
+
+// WhereToTradeTool ranks markets by the best known price for buying or selling one good
+type WhereToTradeTool struct {
+	client *client.Client
+	prices *prices.DB
+	logger *logging.Logger
+}
+
+// NewWhereToTradeTool creates a new market depth lookup tool
+func NewWhereToTradeTool(client *client.Client, db *prices.DB, logger *logging.Logger) *WhereToTradeTool {
+	return &WhereToTradeTool{
+		client: client,
+		prices: db,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *WhereToTradeTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "where_to_trade",
+		Description: "Find the best markets to buy or sell one good, from prices recorded whenever a market was viewed with a ship present. Each market shows its price, supply, activity, trade volume and when the price was observed. With a system, markets there that trade the good but have no recorded price are listed too.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"good": map[string]interface{}{
+					"type":        "string",
+					"description": "Trade symbol of the good (e.g., 'IRON_ORE')",
+				},
+				"mode": map[string]interface{}{
+					"type":        "string",
+					"description": "buy: cheapest markets to buy from. sell: markets paying the most",
+					"enum":        []string{"buy", "sell"},
+					"default":     "buy",
+				},
+				"system": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Only consider markets in this system (e.g., 'X1-FM66')",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of markets (default %d, max %d)", defaultTradeLimit, maxTradeLimit),
+					"minimum":     1,
+					"maximum":     maxTradeLimit,
+				},
+			},
+			Required: []string{"good"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"good":          map[string]interface{}{"type": "string"},
+			"mode":          map[string]interface{}{"type": "string"},
+			"system":        map[string]interface{}{"type": "string"},
+			"markets":       map[string]interface{}{"type": "array", "description": "Markets with a known price, best first"},
+			"count":         map[string]interface{}{"type": "integer"},
+			"unpriced":      map[string]interface{}{"type": "array", "description": "Markets trading the good without a known price"},
+			"listing_error": map[string]interface{}{"type": "string"},
+		}, "good", "mode", "system", "markets", "count", "unpriced"),
+	}
+}
+
+// tradeQuote is a market's latest price for the good, with how old it is
+type tradeQuote struct {
+	prices.Quote
+	AgeSeconds int `json:"ageSeconds"`
+}
+
+// Handler returns the tool handler function
+func (t *WhereToTradeTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "where-to-trade-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		good := v.RequireSymbol("good", utils.TradeSymbols)
+		mode := v.OptionalChoice("mode", "buy", "buy", "sell")
+		systemSymbol := v.OptionalSystem("system")
+		limit := min(v.OptionalInt("limit", defaultTradeLimit, 1, math.MaxInt), maxTradeLimit)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Looking up where to %s %s (system %q)", mode, good, systemSymbol))
+
+		// Markets are listed before prices are read, since fetching a market with a ship present records its prices
+		var trading []string
+		var listingErr error
+		if systemSymbol != "" {
+			trading, listingErr = t.tradingMarkets(ctx, systemSymbol, good, mode)
+			if listingErr != nil {
+				contextLogger.Error(fmt.Sprintf("Failed to list markets in %s: %v", systemSymbol, listingErr))
+			}
+		}
+
+		now := time.Now()
+		quotes := make([]tradeQuote, 0)
+		for _, quote := range t.prices.Quotes(good) {
+			if systemSymbol != "" && travel.SystemSymbol(quote.WaypointSymbol) != systemSymbol {
+				continue
+			}
+			quotes = append(quotes, tradeQuote{Quote: quote, AgeSeconds: int(now.Sub(quote.ObservedAt).Seconds())})
+		}
+
+		// Buying wants the lowest purchase price, selling the highest sell price
+		sort.SliceStable(quotes, func(i, j int) bool {
+			if mode == "buy" {
+				return quotes[i].PurchasePrice < quotes[j].PurchasePrice
+			}
+			return quotes[i].SellPrice > quotes[j].SellPrice
+		})
+		if len(quotes) > limit {
+			quotes = quotes[:limit]
+		}
+
+		priced := make(map[string]bool)
+		for _, quote := range t.prices.Quotes(good) {
+			priced[quote.WaypointSymbol] = true
+		}
+		withoutPrice := make([]string, 0)
+		for _, waypoint := range trading {
+			if !priced[waypoint] {
+				withoutPrice = append(withoutPrice, waypoint)
+			}
+		}
+
+		contextLogger.ToolCall("where_to_trade", true)
+
+		result := map[string]interface{}{
+			"good":     good,
+			"mode":     mode,
+			"system":   systemSymbol,
+			"markets":  quotes,
+			"count":    len(quotes),
+			"unpriced": withoutPrice,
+		}
+		if listingErr != nil {
+			result["listing_error"] = listingErr.Error()
+		}
+
+		scope := "any system"
+		if systemSymbol != "" {
+			scope = systemSymbol
+		}
+		verb := "Buy"
+		if mode == "sell" {
+			verb = "Sell"
+		}
+
+		textSummary := fmt.Sprintf("## Where to %s %s (%s)\n\n", verb, good, scope)
+		if len(quotes) == 0 {
+			textSummary += fmt.Sprintf("No recorded prices for %s. Prices are recorded whenever a market is viewed with a ship present, for example with `scan_markets_along_route` or `deploy_probe`.\n", good)
+		} else {
+			for i, quote := range quotes {
+				price := quote.PurchasePrice
+				if mode == "sell" {
+					price = quote.SellPrice
+				}
+				textSummary += fmt.Sprintf("%d. **%s** - %d credits/unit, supply %s", i+1, quote.WaypointSymbol, price, quote.Supply)
+				if quote.Activity != "" {
+					textSummary += fmt.Sprintf(", activity %s", quote.Activity)
+				}
+				textSummary += fmt.Sprintf(", volume %d (%s, seen %s ago)\n", quote.TradeVolume, quote.Type, formatAge(now.Sub(quote.ObservedAt)))
+			}
+		}
+		if len(withoutPrice) > 0 {
+			textSummary += fmt.Sprintf("\n**No price recorded yet** at %s, which also trade %s. Visit with a ship to see their prices.\n", strings.Join(withoutPrice, ", "), good)
+		}
+		if listingErr != nil {
+			textSummary += fmt.Sprintf("\n⚠️ Could not list every market in %s: %v\n", systemSymbol, listingErr)
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// tradingMarkets returns the marketplaces in a system that trade the good in the requested direction:
+// those exporting or exchanging it for buying, importing or exchanging it for selling
+func (t *WhereToTradeTool) tradingMarkets(ctx context.Context, systemSymbol, good, mode string) ([]string, error) {
+	c := t.client.WithContext(ctx)
+	waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var markets []string
+	var failed []string
+	for _, waypoint := range waypoints {
+		isMarket := false
+		for _, trait := range waypoint.Traits {
+			if trait.Symbol == "MARKETPLACE" {
+				isMarket = true
+				break
+			}
+		}
+		if !isMarket {
+			continue
+		}
+
+		market, _, err := c.GetCachedMarketListing(systemSymbol, waypoint.Symbol)
+		if err != nil {
+			failed = append(failed, waypoint.Symbol)
+			continue
+		}
+		list := market.Exports
+		if mode == "sell" {
+			list = market.Imports
+		}
+		if listsGood(list, good) || listsGood(market.Exchange, good) {
+			markets = append(markets, waypoint.Symbol)
+		}
+	}
+
+	if len(failed) > 0 {
+		return markets, fmt.Errorf("failed to get markets at %s", strings.Join(failed, ", "))
+	}
+	return markets, nil
+}
+
+// listsGood reports whether a market trade list includes the good
+func listsGood(list []client.TradeGood, good string) bool {
+	for _, tradeGood := range list {
+		if tradeGood.Symbol == good {
+			return true
+		}
+	}
+	return false
+}
+
+// formatAge renders how long ago a price was seen, to the minute
+func formatAge(age time.Duration) string {
+	if age < time.Minute {
+		return "<1m"
+	}
+	return strings.TrimSuffix(age.Truncate(time.Minute).String(), "0s")
+}
@@ -0,0 +1,75 @@
+package info
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestWhereToTradeTool_SellRanksHighestFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		marketplace := `[{"symbol": "MARKETPLACE", "name": "Marketplace", "description": ""}]`
+		switch r.URL.Path {
+		case "/systems/X1-TEST/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-TEST-A1", "systemSymbol": "X1-TEST", "type": "PLANET", "x": 0, "y": 0, "orbitals": [], "isUnderConstruction": false, "traits": ` + marketplace + `},
+				{"symbol": "X1-TEST-B2", "systemSymbol": "X1-TEST", "type": "MOON", "x": 10, "y": 0, "orbitals": [], "isUnderConstruction": false, "traits": ` + marketplace + `},
+				{"symbol": "X1-TEST-D4", "systemSymbol": "X1-TEST", "type": "MOON", "x": 20, "y": 0, "orbitals": [], "isUnderConstruction": false, "traits": ` + marketplace + `}
+			], "meta": {"total": 3, "page": 1, "limit": 20}}`))
+		case "/systems/X1-TEST/waypoints/X1-TEST-A1/market", "/systems/X1-TEST/waypoints/X1-TEST-B2/market", "/systems/X1-TEST/waypoints/X1-TEST-D4/market":
+			symbol := strings.Split(r.URL.Path, "/")[4]
+			_, _ = w.Write([]byte(`{"data": {"symbol": "` + symbol + `", "imports": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": ""}], "exports": [], "exchange": []}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	db := prices.New()
+	seen := time.Now().Add(-90 * time.Minute)
+	for waypoint, sellPrice := range map[string]int{"X1-TEST-A1": 40, "X1-TEST-B2": 55, "X1-OTHER-C1": 90} {
+		db.Record(prices.Snapshot{WaypointSymbol: waypoint, ObservedAt: seen, Prices: []prices.Price{
+			{TradeSymbol: "IRON_ORE", Type: "IMPORT", Supply: "SCARCE", Activity: "STRONG", SellPrice: sellPrice, TradeVolume: 20},
+		}})
+	}
+
+	tool := NewWhereToTradeTool(client.NewClientWithBaseURL("test-token", server.URL), db, logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"good": "iron ore", "mode": "sell", "system": "X1-TEST"})
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+
+	text, _ := mcp.AsTextContent(result.Content[0])
+	b2 := strings.Index(text.Text, "X1-TEST-B2** - 55")
+	a1 := strings.Index(text.Text, "X1-TEST-A1** - 40")
+	if b2 == -1 || a1 == -1 || b2 > a1 {
+		t.Errorf("Expected B2 (55) ranked above A1 (40), got: %s", text.Text)
+	}
+	if strings.Contains(text.Text, "X1-OTHER-C1") {
+		t.Errorf("Expected markets outside X1-TEST to be left out, got: %s", text.Text)
+	}
+	if !strings.Contains(text.Text, "No price recorded yet** at X1-TEST-D4") {
+		t.Errorf("Expected D4 listed as trading without a price, got: %s", text.Text)
+	}
+	if !strings.Contains(text.Text, "seen 1h30m ago") {
+		t.Errorf("Expected the age of each price, got: %s", text.Text)
+	}
+}
+
+func TestWhereToTradeTool_InvalidMode(t *testing.T) {
+	tool := NewWhereToTradeTool(client.NewClient("test-token"), prices.New(), logging.NewLogger(nil))
+	result := callTool(t, tool, map[string]interface{}{"good": "FUEL", "mode": "hold"})
+	if !result.IsError {
+		t.Error("Expected an error for an invalid mode")
+	}
+}
@@ -0,0 +1,112 @@
+package navigation
+
+import (
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/travel"
+)
+
+// refuelOutcome describes what the auto-refuel check did before departure
+type refuelOutcome struct {
+	FuelRequired int    `json:"fuel_required"`
+	FuelBefore   int    `json:"fuel_before"`
+	Refueled     bool   `json:"refueled"`
+	UnitsBought  int    `json:"units_bought,omitempty"`
+	TotalPrice   int    `json:"total_price,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+// autoRefuelProperty is the input schema entry shared by navigation tools
+func autoRefuelProperty(defaultValue bool) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Check fuel against the route cost first and, if it is too low, dock and refuel at the current waypoint before departing (requires a marketplace selling fuel)",
+		"default":     defaultValue,
+	}
+}
+
+// ensureFuel refuels the ship at its current waypoint when it does not have enough fuel to cover distance.
+// The ship is left in orbit, ready to depart. The fuel bill must pass the spending policy.
+func ensureFuel(c *client.Client, spending *policy.Policy, ship *client.Ship, distance float64) (*refuelOutcome, error) {
+	outcome := &refuelOutcome{
+		FuelRequired: travel.FuelCost(distance, ship.Nav.FlightMode),
+		FuelBefore:   ship.Fuel.Current,
+	}
+
+	if ship.Fuel.Capacity == 0 {
+		outcome.FuelRequired = 0
+		outcome.Note = "ship does not use fuel"
+		return outcome, nil
+	}
+	if ship.Fuel.Current >= outcome.FuelRequired {
+		return outcome, nil
+	}
+	if outcome.FuelRequired > ship.Fuel.Capacity {
+		outcome.Note = fmt.Sprintf("route needs %d fuel but the tank only holds %d - use DRIFT or plan a refueling stop", outcome.FuelRequired, ship.Fuel.Capacity)
+	}
+
+	market, err := c.GetMarket(ship.Nav.SystemSymbol, ship.Nav.WaypointSymbol)
+	if err != nil || !sellsFuel(market) {
+		outcome.Note = fmt.Sprintf("fuel is low (%d/%d needed) but %s does not sell fuel", ship.Fuel.Current, outcome.FuelRequired, ship.Nav.WaypointSymbol)
+		return outcome, nil
+	}
+
+	if spending.Enabled() {
+		if err := checkFuelBill(c, spending, ship, market); err != nil {
+			return outcome, err
+		}
+	}
+
+	if ship.Nav.Status != "DOCKED" {
+		if _, err := c.DockShip(ship.Symbol); err != nil {
+			return outcome, fmt.Errorf("failed to dock for refueling: %w", err)
+		}
+	}
+
+	resp, err := c.RefuelShip(ship.Symbol, nil, false)
+	if err != nil {
+		return outcome, fmt.Errorf("failed to refuel: %w", err)
+	}
+	outcome.Refueled = true
+	outcome.UnitsBought = resp.Data.Transaction.Units
+	outcome.TotalPrice = resp.Data.Transaction.TotalPrice
+
+	if _, err := c.OrbitShip(ship.Symbol); err != nil {
+		return outcome, fmt.Errorf("refueled but failed to return to orbit: %w", err)
+	}
+
+	return outcome, nil
+}
+
+// checkFuelBill asks the spending policy whether filling the ship's tank at market may go ahead
+func checkFuelBill(c *client.Client, spending *policy.Policy, ship *client.Ship, market *client.Market) error {
+	price := 0
+	for _, good := range market.TradeGoods {
+		if good.Symbol == "FUEL" {
+			price = good.PurchasePrice
+		}
+	}
+	if price == 0 {
+		return fmt.Errorf("the fuel price at %s is unknown, so the spending policy can't be checked", ship.Nav.WaypointSymbol)
+	}
+
+	agent, err := c.GetAgent()
+	if err != nil {
+		return fmt.Errorf("failed to check the fuel bill against the spending policy: %w", err)
+	}
+	return spending.Check(travel.RefuelCost(ship.Fuel.Capacity-ship.Fuel.Current, price), agent.Credits)
+}
+
+// sellsFuel reports whether a market trades fuel
+func sellsFuel(market *client.Market) bool {
+	for _, goods := range [][]client.TradeGood{market.Exports, market.Imports, market.Exchange} {
+		for _, good := range goods {
+			if good.Symbol == "FUEL" {
+				return true
+			}
+		}
+	}
+	return false
+}
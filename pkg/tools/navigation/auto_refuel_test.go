@@ -0,0 +1,63 @@
+package navigation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/policy"
+)
+
+func TestEnsureFuel_SpendingPolicy(t *testing.T) {
+	var refueled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "exchange": [{"symbol": "FUEL"}],
+				"tradeGoods": [{"symbol": "FUEL", "purchasePrice": 80, "tradeVolume": 100}]}}`))
+		case "GET /my/agent":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "AGENT", "credits": 1000}}`))
+		case "POST /my/ships/SHIP-1/dock":
+			_, _ = w.Write([]byte(`{"data": {"nav": {"status": "DOCKED"}}}`))
+		case "POST /my/ships/SHIP-1/refuel":
+			refueled = true
+			_, _ = w.Write([]byte(`{"data": {"fuel": {"current": 400, "capacity": 400}, "transaction": {"units": 390, "totalPrice": 320}}}`))
+		case "POST /my/ships/SHIP-1/orbit":
+			_, _ = w.Write([]byte(`{"data": {"nav": {"status": "IN_ORBIT"}}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+	ship := func() *client.Ship {
+		return &client.Ship{
+			Symbol: "SHIP-1",
+			Nav:    client.Navigation{SystemSymbol: "X1-TEST", WaypointSymbol: "X1-TEST-A1", Status: "IN_ORBIT", FlightMode: "CRUISE"},
+			Fuel:   client.Fuel{Current: 10, Capacity: 400},
+		}
+	}
+
+	// Filling 390 fuel takes 4 market units at 80 credits, which leaves 680 credits
+	_, err := ensureFuel(c, policy.New(policy.Limits{ReserveFloor: 900}), ship(), 100)
+	var violation *policy.Violation
+	if !errors.As(err, &violation) || violation.Limit != "reserve_floor" {
+		t.Fatalf("Expected the credit reserve to refuse the fuel bill, got %v", err)
+	}
+	if refueled {
+		t.Error("Expected no refuel once the spending policy refused it")
+	}
+
+	outcome, err := ensureFuel(c, policy.New(policy.Limits{ReserveFloor: 500}), ship(), 100)
+	if err != nil {
+		t.Fatalf("Expected the fuel bill to pass the spending policy, got %v", err)
+	}
+	if !refueled || !outcome.Refueled || outcome.TotalPrice != 320 {
+		t.Errorf("Expected the ship to refuel for 320 credits, got %+v", outcome)
+	}
+}
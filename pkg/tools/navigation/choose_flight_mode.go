@@ -0,0 +1,292 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Priorities choose_flight_mode can optimize for
+const (
+	priorityTime = "time"
+	priorityFuel = "fuel"
+)
+
+// ChooseFlightModeTool recommends the flight mode for a ship's next leg, and can switch to it
+type ChooseFlightModeTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewChooseFlightModeTool creates a new flight mode advisor tool
+func NewChooseFlightModeTool(client *client.Client, logger *logging.Logger) *ChooseFlightModeTool {
+	return &ChooseFlightModeTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *ChooseFlightModeTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "choose_flight_mode",
+		Description: "Recommend a flight mode for a ship's trip to a destination. Computes fuel and travel time in CRUISE, BURN, DRIFT and STEALTH for that leg from the ship's engine and fuel, then picks the fastest mode (priority=time) or the one burning least fuel (priority=fuel) that the fuel in the tank covers. Pass apply=true to switch the ship to the recommended mode.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship that will fly the leg",
+				},
+				"destination": map[string]interface{}{
+					"type":        "string",
+					"description": "Waypoint symbol the ship will fly to; a waypoint in another system is a warp",
+				},
+				"priority": map[string]interface{}{
+					"type":        "string",
+					"description": "What to optimize: time for the fastest trip, fuel for the least fuel burnt",
+					"enum":        []string{priorityTime, priorityFuel},
+					"default":     priorityTime,
+				},
+				"apply": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Switch the ship to the recommended flight mode",
+					"default":     false,
+				},
+			},
+			Required: []string{"ship_symbol", "destination"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":         map[string]interface{}{"type": "string"},
+			"origin":              map[string]interface{}{"type": "string"},
+			"destination":         map[string]interface{}{"type": "string"},
+			"distance":            map[string]interface{}{"type": "number"},
+			"warp":                map[string]interface{}{"type": "boolean"},
+			"engine_speed":        map[string]interface{}{"type": "integer"},
+			"priority":            map[string]interface{}{"type": "string"},
+			"fuel":                map[string]interface{}{"type": "object", "description": "Fuel in the tank and its capacity"},
+			"current_flight_mode": map[string]interface{}{"type": "string"},
+			"options":             map[string]interface{}{"type": "array", "description": "Fuel and travel time per flight mode, and whether the fuel in the tank or a full tank covers it"},
+			"recommended":         map[string]interface{}{"type": "object", "description": "The recommended flight mode's estimate"},
+			"refuel_first":        map[string]interface{}{"type": "boolean", "description": "Whether the ship must refuel before flying the recommended mode"},
+			"applied":             map[string]interface{}{"type": "boolean", "description": "Whether the ship was switched to the recommended mode"},
+		}, "ship_symbol", "origin", "destination", "distance", "priority", "options", "recommended", "refuel_first", "applied"),
+	}
+}
+
+// flightModeOption is one flight mode's cost for the leg and whether the ship can pay it
+type flightModeOption struct {
+	travel.Estimate
+	// CoveredByFuel is whether the fuel in the tank covers the leg
+	CoveredByFuel bool `json:"covered_by_fuel"`
+	// CoveredByTank is whether a full tank covers the leg
+	CoveredByTank bool `json:"covered_by_tank"`
+}
+
+// flightModeOptions prices the leg in every flight mode. Ships without a fuel tank fly on
+// solar power, so any mode is covered.
+func flightModeOptions(distance float64, engineSpeed int, fuel client.Fuel) []flightModeOption {
+	options := make([]flightModeOption, 0, len(travel.FlightModes))
+	for _, estimate := range travel.Estimates(distance, travel.FlightModes, engineSpeed) {
+		options = append(options, flightModeOption{
+			Estimate:      estimate,
+			CoveredByFuel: fuel.Capacity == 0 || estimate.FuelCost <= fuel.Current,
+			CoveredByTank: fuel.Capacity == 0 || estimate.FuelCost <= fuel.Capacity,
+		})
+	}
+	return options
+}
+
+// recommendFlightMode picks the fastest option for priority time, or the one burning least fuel
+// for priority fuel, breaking ties with the other. Options the fuel in the tank covers come
+// first; when none are, the pick needs a refuel first. ok is false when no mode fits even a full
+// tank.
+func recommendFlightMode(options []flightModeOption, priority string) (best flightModeOption, refuelFirst, ok bool) {
+	better := func(a, b flightModeOption) bool {
+		if priority == priorityFuel {
+			return a.FuelCost < b.FuelCost || a.FuelCost == b.FuelCost && a.TravelSeconds < b.TravelSeconds
+		}
+		return a.TravelSeconds < b.TravelSeconds || a.TravelSeconds == b.TravelSeconds && a.FuelCost < b.FuelCost
+	}
+	pick := func(covered func(flightModeOption) bool) (flightModeOption, bool) {
+		var best flightModeOption
+		found := false
+		for _, option := range options {
+			if covered(option) && (!found || better(option, best)) {
+				best, found = option, true
+			}
+		}
+		return best, found
+	}
+
+	if best, ok := pick(func(o flightModeOption) bool { return o.CoveredByFuel }); ok {
+		return best, false, true
+	}
+	if best, ok := pick(func(o flightModeOption) bool { return o.CoveredByTank }); ok {
+		return best, true, true
+	}
+	return flightModeOption{}, false, false
+}
+
+// Handler returns the tool handler function
+func (t *ChooseFlightModeTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "choose-flight-mode-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		destination := v.RequireWaypoint("destination")
+		priority := v.OptionalChoice("priority", priorityTime, priorityTime, priorityFuel)
+		apply := v.OptionalBool("apply", false)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			contextLogger.Error("Failed to get ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("❌ Failed to get ship %s: %v", shipSymbol, err))},
+				IsError: true,
+			}, nil
+		}
+
+		// A ship in transit flies the leg from where it arrives
+		origin := ship.Nav.WaypointSymbol
+		if ship.Nav.Status == "IN_TRANSIT" && ship.Nav.Route.Destination.Symbol != "" {
+			origin = ship.Nav.Route.Destination.Symbol
+		}
+		if origin == destination {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("❌ Ship %s is already at %s; choose another destination", shipSymbol, destination))},
+				IsError: true,
+			}, nil
+		}
+
+		distance, warp, err := travel.RouteDistance(c, origin, destination)
+		if err != nil {
+			contextLogger.Error("Failed to measure the leg from %s to %s: %v", origin, destination, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("❌ Failed to measure the leg from %s to %s: %v", origin, destination, err))},
+				IsError: true,
+			}, nil
+		}
+
+		engineSpeed := ship.Engine.Speed
+		if engineSpeed <= 0 {
+			engineSpeed = defaultEngineSpeed
+		}
+		options := flightModeOptions(distance, engineSpeed, ship.Fuel)
+		best, refuelFirst, ok := recommendFlightMode(options, priority)
+		if !ok {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("❌ No flight mode gets %s from %s to %s on a full tank of %d fuel; use plan_route to find refuel stops", shipSymbol, origin, destination, ship.Fuel.Capacity))},
+				IsError: true,
+			}, nil
+		}
+
+		applied := false
+		if apply && best.FlightMode != ship.Nav.FlightMode {
+			if _, err := c.PatchShipNav(shipSymbol, best.FlightMode); err != nil {
+				contextLogger.Error("Failed to set flight mode of %s to %s: %v", shipSymbol, best.FlightMode, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("❌ Recommended %s for %s, but failed to switch to it: %v", best.FlightMode, shipSymbol, err))},
+					IsError: true,
+				}, nil
+			}
+			applied = true
+		}
+
+		contextLogger.ToolCall("choose_flight_mode", true)
+
+		result := map[string]interface{}{
+			"ship_symbol":         shipSymbol,
+			"origin":              origin,
+			"destination":         destination,
+			"distance":            distance,
+			"warp":                warp,
+			"engine_speed":        engineSpeed,
+			"priority":            priority,
+			"fuel":                map[string]interface{}{"current": ship.Fuel.Current, "capacity": ship.Fuel.Capacity},
+			"current_flight_mode": ship.Nav.FlightMode,
+			"options":             options,
+			"recommended":         best,
+			"refuel_first":        refuelFirst,
+			"applied":             applied,
+		}
+
+		textSummary := "## Flight Mode Advice\n\n"
+		textSummary += fmt.Sprintf("**Ship:** %s (%s, fuel %d/%d)\n", shipSymbol, ship.Nav.FlightMode, ship.Fuel.Current, ship.Fuel.Capacity)
+		if warp {
+			textSummary += fmt.Sprintf("**Leg:** %s → %s, %.1f units between systems (warp)\n", origin, destination, distance)
+		} else {
+			textSummary += fmt.Sprintf("**Leg:** %s → %s, %.1f units\n", origin, destination, distance)
+		}
+		textSummary += fmt.Sprintf("**Priority:** %s\n\n", priority)
+
+		// Fastest first, so the trade-off reads top to bottom
+		sorted := append([]flightModeOption(nil), options...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].TravelSeconds < sorted[j].TravelSeconds
+		})
+		for _, option := range sorted {
+			line := fmt.Sprintf("- **%s:** %d fuel, %s", option.FlightMode, option.FuelCost, option.TravelTime)
+			switch {
+			case !option.CoveredByTank:
+				line += " ⚠️ exceeds fuel capacity"
+			case !option.CoveredByFuel:
+				line += " ⚠️ needs refuel"
+			}
+			if option.FlightMode == best.FlightMode {
+				line += " ✅"
+			}
+			textSummary += line + "\n"
+		}
+
+		reason := "fastest"
+		if priority == priorityFuel {
+			reason = "least fuel"
+		}
+		textSummary += fmt.Sprintf("\n**Recommended:** %s, the %s option", best.FlightMode, reason)
+		if refuelFirst {
+			textSummary += fmt.Sprintf(" once refuelled; no mode fits the %d fuel in the tank\n", ship.Fuel.Current)
+		} else {
+			textSummary += " the fuel in the tank covers\n"
+			if priority == priorityTime {
+				if faster, _, _ := recommendFlightMode(tankOptions(options), priority); faster.TravelSeconds < best.TravelSeconds {
+					textSummary += fmt.Sprintf("Refuelling first would allow %s (%s).\n", faster.FlightMode, faster.TravelTime)
+				}
+			}
+		}
+
+		switch {
+		case applied:
+			textSummary += fmt.Sprintf("\n✅ Switched %s to %s.\n", shipSymbol, best.FlightMode)
+		case best.FlightMode == ship.Nav.FlightMode:
+			textSummary += fmt.Sprintf("\n%s already flies in %s.\n", shipSymbol, best.FlightMode)
+		default:
+			textSummary += "\nPass apply=true, or call set_flight_mode, to switch to it.\n"
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// tankOptions returns the options as if the tank were full, to see what refuelling would allow
+func tankOptions(options []flightModeOption) []flightModeOption {
+	full := make([]flightModeOption, len(options))
+	for i, option := range options {
+		full[i] = option
+		full[i].CoveredByFuel = option.CoveredByTank
+	}
+	return full
+}
@@ -0,0 +1,147 @@
+package navigation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// flightModeServer serves a ship at X1-TEST-A1, 100 units from B2, with the given fuel, and
+// records the flight modes it is switched to
+func flightModeServer(t *testing.T, fuel int, patched *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/SHIP-1":
+			_, _ = fmt.Fprintf(w, `{"data": {"symbol": "SHIP-1",
+				"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT", "flightMode": "CRUISE"},
+				"engine": {"speed": 30}, "fuel": {"current": %d, "capacity": 400}}}`, fuel)
+		case "GET /systems/X1-TEST":
+			_, _ = w.Write([]byte(testSystemJSON))
+		case "PATCH /my/ships/SHIP-1/nav":
+			var req struct {
+				FlightMode string `json:"flightMode"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			*patched = append(*patched, req.FlightMode)
+			_, _ = fmt.Fprintf(w, `{"data": {"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT", "flightMode": %q,
+				"route": {"origin": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+					"destination": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+					"departureTime": "2024-01-01T00:00:00.000Z", "arrival": "2024-01-01T00:00:00.000Z"}},
+				"fuel": {"current": %d, "capacity": 400}, "events": []}}`, req.FlightMode, fuel)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func callChooseFlightMode(t *testing.T, server *httptest.Server, args map[string]interface{}) (*mcp.CallToolResult, string) {
+	t.Helper()
+	tool := NewChooseFlightModeTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "choose_flight_mode", Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result, result.Content[0].(mcp.TextContent).Text
+}
+
+func TestChooseFlightModeTool_FastestCoveredByFuel(t *testing.T) {
+	var patched []string
+	server := flightModeServer(t, 150, &patched)
+	defer server.Close()
+
+	result, text := callChooseFlightMode(t, server, map[string]interface{}{"ship_symbol": "SHIP-1", "destination": "X1-TEST-B2"})
+	if result.IsError {
+		t.Fatalf("Expected advice, got error: %s", text)
+	}
+	for _, want := range []string{
+		"**Leg:** X1-TEST-A1 → X1-TEST-B2, 100.0 units",
+		"**BURN:** 200 fuel, 57s ⚠️ needs refuel",
+		"**CRUISE:** 100 fuel, 1m38s ✅",
+		"**Recommended:** CRUISE, the fastest option the fuel in the tank covers",
+		"Refuelling first would allow BURN (57s)",
+		"SHIP-1 already flies in CRUISE",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+	data := result.StructuredContent.(map[string]interface{})
+	if data["refuel_first"] != false || data["applied"] != false || len(patched) != 0 {
+		t.Errorf("Expected no refuel and no change, got %v and patches %v", data, patched)
+	}
+}
+
+func TestChooseFlightModeTool_AppliesFuelPriority(t *testing.T) {
+	var patched []string
+	server := flightModeServer(t, 150, &patched)
+	defer server.Close()
+
+	result, text := callChooseFlightMode(t, server, map[string]interface{}{
+		"ship_symbol": "SHIP-1", "destination": "X1-TEST-B2", "priority": "fuel", "apply": true,
+	})
+	if result.IsError {
+		t.Fatalf("Expected advice, got error: %s", text)
+	}
+	if !strings.Contains(text, "**Recommended:** DRIFT, the least fuel option") || !strings.Contains(text, "Switched SHIP-1 to DRIFT") {
+		t.Errorf("Expected DRIFT recommended and applied, got:\n%s", text)
+	}
+	if len(patched) != 1 || patched[0] != "DRIFT" {
+		t.Errorf("Expected the ship switched to DRIFT, got %v", patched)
+	}
+}
+
+func TestChooseFlightModeTool_InvalidArguments(t *testing.T) {
+	var patched []string
+	server := flightModeServer(t, 150, &patched)
+	defer server.Close()
+
+	result, text := callChooseFlightMode(t, server, map[string]interface{}{
+		"ship_symbol": "SHIP-1", "destination": "X1-TEST", "priority": "comfort",
+	})
+	if !result.IsError {
+		t.Fatalf("Expected an error, got:\n%s", text)
+	}
+	for _, want := range []string{"2 invalid arguments", "destination: 'X1-TEST' is a system", "priority: unknown value 'comfort'. Allowed values: time, fuel"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestRecommendFlightMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		fuel     client.Fuel
+		priority string
+		want     string
+		refuel   bool
+	}{
+		{"full tank favours burn", client.Fuel{Current: 400, Capacity: 400}, priorityTime, "BURN", false},
+		{"fuel priority drifts", client.Fuel{Current: 400, Capacity: 400}, priorityFuel, "DRIFT", false},
+		{"empty tank refuels first", client.Fuel{Current: 0, Capacity: 400}, priorityTime, "BURN", true},
+		{"small tank refuels for cruise", client.Fuel{Current: 0, Capacity: 150}, priorityTime, "CRUISE", true},
+		{"no tank flies free", client.Fuel{}, priorityTime, "BURN", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			best, refuel, ok := recommendFlightMode(flightModeOptions(100, 30, tt.fuel), tt.priority)
+			if !ok || best.FlightMode != tt.want || refuel != tt.refuel {
+				t.Errorf("Expected %s (refuel %v), got %s (refuel %v, ok %v)", tt.want, tt.refuel, best.FlightMode, refuel, ok)
+			}
+		})
+	}
+}
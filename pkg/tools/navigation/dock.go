@@ -6,6 +6,7 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -39,6 +40,12 @@ func (t *DockShipTool) Tool() mcp.Tool {
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":     map[string]interface{}{"type": "boolean"},
+			"ship_symbol": map[string]interface{}{"type": "string"},
+			"navigation":  map[string]interface{}{"type": "object", "description": "Ship location, status and flight mode after the action"},
+			"route":       map[string]interface{}{"type": "object", "description": "Route of the ship, when it has one"},
+		}, "success", "ship_symbol", "navigation"),
 	}
 }
 
@@ -47,32 +54,17 @@ func (t *DockShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "dock-ship-tool")
 
-		// Extract ship symbol
-		var shipSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						shipSymbol = s
-					}
-				}
-			}
-		}
-
-		if shipSymbol == "" {
-			contextLogger.Error("Missing or invalid ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Attempting to dock ship: %s", shipSymbol))
 
 		// Dock the ship
-		nav, err := t.client.DockShip(shipSymbol)
+		nav, err := t.client.WithContext(ctx).DockShip(shipSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to dock ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -140,11 +132,6 @@ func (t *DockShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 		textSummary += "  - Refuel the ship\n"
 		textSummary += "  - Repair the ship\n"
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%+v\n```", result)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,176 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultEngineSpeed is the speed of the engine fitted to starting ships
+const defaultEngineSpeed = 30
+
+// EstimateTravelTool predicts fuel and time for a trip without moving any ship
+type EstimateTravelTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewEstimateTravelTool creates a new travel estimate tool
+func NewEstimateTravelTool(client *client.Client, logger *logging.Logger) *EstimateTravelTool {
+	return &EstimateTravelTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *EstimateTravelTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "estimate_travel",
+		Description: "Estimate distance, fuel cost and travel time between two waypoints for each flight mode, without moving any ship. Use it to compare CRUISE, BURN and DRIFT before navigating.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"origin": map[string]interface{}{
+					"type":        "string",
+					"description": "Starting waypoint symbol (e.g., 'X1-DF55-20250Z')",
+				},
+				"destination": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination waypoint symbol; a waypoint in another system gives a warp estimate",
+				},
+				"flight_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Only estimate this flight mode (CRUISE, BURN, DRIFT, STEALTH). All modes are compared when omitted.",
+				},
+				"engine_speed": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Optional: Engine speed of the ship (defaults to %d, or the ship's engine when ship_symbol is given)", defaultEngineSpeed),
+					"minimum":     1,
+				},
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Ship to read the engine speed and fuel level from",
+				},
+			},
+			Required: []string{"origin", "destination"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"origin":       map[string]interface{}{"type": "string"},
+			"destination":  map[string]interface{}{"type": "string"},
+			"distance":     map[string]interface{}{"type": "number"},
+			"warp":         map[string]interface{}{"type": "boolean", "description": "Whether the trip crosses systems"},
+			"engine_speed": map[string]interface{}{"type": "integer"},
+			"estimates":    map[string]interface{}{"type": "array", "description": "Travel time and fuel per flight mode"},
+			"ship":         map[string]interface{}{"type": "object", "description": "Ship the estimate was made for, if any"},
+		}, "origin", "destination", "distance", "warp", "engine_speed", "estimates"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *EstimateTravelTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "estimate-travel-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		origin := v.RequireWaypoint("origin")
+		destination := v.RequireWaypoint("destination")
+		flightMode := v.OptionalSymbol("flight_mode", utils.FlightModes, "")
+		engineSpeed := v.OptionalInt("engine_speed", 0, 1, math.MaxInt)
+		shipSymbol := v.OptionalShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		modes := travel.FlightModes
+		if flightMode != "" {
+			modes = []string{flightMode}
+		}
+
+		var ship *client.Ship
+		if shipSymbol != "" {
+			var err error
+			ship, err = t.client.WithContext(ctx).GetShip(shipSymbol)
+			if err != nil {
+				contextLogger.Error(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err))
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err)),
+					},
+					IsError: true,
+				}, nil
+			}
+			if engineSpeed <= 0 {
+				engineSpeed = ship.Engine.Speed
+			}
+		}
+		if engineSpeed <= 0 {
+			engineSpeed = defaultEngineSpeed
+		}
+
+		contextLogger.Info(fmt.Sprintf("Estimating travel from %s to %s", origin, destination))
+
+		distance, warp, err := travel.RouteDistance(t.client.WithContext(ctx), origin, destination)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to estimate route from %s to %s: %v", origin, destination, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to estimate route from %s to %s: %v", origin, destination, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		estimates := travel.Estimates(distance, modes, engineSpeed)
+
+		contextLogger.ToolCall("estimate_travel", true)
+
+		result := map[string]interface{}{
+			"origin":       origin,
+			"destination":  destination,
+			"distance":     distance,
+			"warp":         warp,
+			"engine_speed": engineSpeed,
+			"estimates":    estimates,
+		}
+		if ship != nil {
+			result["ship"] = map[string]interface{}{
+				"symbol":        ship.Symbol,
+				"fuel_current":  ship.Fuel.Current,
+				"fuel_capacity": ship.Fuel.Capacity,
+			}
+		}
+
+		textSummary := "## Travel Estimate\n\n"
+		textSummary += fmt.Sprintf("**Route:** %s → %s\n", origin, destination)
+		if warp {
+			textSummary += fmt.Sprintf("**Distance:** %.1f units between systems (warp)\n", distance)
+		} else {
+			textSummary += fmt.Sprintf("**Distance:** %.1f units\n", distance)
+		}
+		textSummary += fmt.Sprintf("**Engine Speed:** %d\n\n", engineSpeed)
+
+		for _, estimate := range estimates {
+			line := fmt.Sprintf("- **%s:** %d fuel, %s", estimate.FlightMode, estimate.FuelCost, estimate.TravelTime)
+			if ship != nil && ship.Fuel.Capacity > 0 {
+				if estimate.FuelCost > ship.Fuel.Capacity {
+					line += " ⚠️ exceeds fuel capacity"
+				} else if estimate.FuelCost > ship.Fuel.Current {
+					line += fmt.Sprintf(" ⚠️ needs refuel (%d/%d)", ship.Fuel.Current, ship.Fuel.Capacity)
+				}
+			}
+			textSummary += line + "\n"
+		}
+
+		textSummary += "\nEstimates use the published travel formulas; the server's arrival time may differ by a few seconds.\n"
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
@@ -0,0 +1,87 @@
+package navigation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const testSystemJSON = `{"data": {
+	"symbol": "X1-TEST", "sectorSymbol": "X1", "type": "RED_STAR", "x": 0, "y": 0, "factions": [],
+	"waypoints": [
+		{"symbol": "X1-TEST-A1", "type": "PLANET", "x": 0, "y": 0, "orbitals": []},
+		{"symbol": "X1-TEST-B2", "type": "MOON", "x": 60, "y": 80, "orbitals": []}
+	]
+}}`
+
+func TestEstimateTravelTool_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected only read-only requests, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testSystemJSON))
+	}))
+	defer server.Close()
+
+	tool := NewEstimateTravelTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "estimate_travel",
+			Arguments: map[string]interface{}{
+				"origin":       "X1-TEST-A1",
+				"destination":  "X1-TEST-B2",
+				"engine_speed": float64(30),
+			},
+		},
+	}
+
+	result, err := tool.Handler()(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got error: %v", result.Content)
+	}
+
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatalf("Expected TextContent, got %T", result.Content[0])
+	}
+	for _, expected := range []string{"100.0 units", "**CRUISE:** 100 fuel, 1m38s", "**BURN:** 200 fuel", "**DRIFT:** 1 fuel"} {
+		if !strings.Contains(text.Text, expected) {
+			t.Errorf("Expected summary to contain %q, got: %s", expected, text.Text)
+		}
+	}
+}
+
+func TestEstimateTravelTool_Handler_InvalidFlightMode(t *testing.T) {
+	tool := NewEstimateTravelTool(client.NewClient("test-token"), logging.NewLogger(nil))
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "estimate_travel",
+			Arguments: map[string]interface{}{
+				"origin":      "X1-TEST-A1",
+				"destination": "X1-TEST-B2",
+				"flight_mode": "WARP_SPEED",
+			},
+		},
+	}
+
+	result, err := tool.Handler()(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected an error result for an invalid flight mode")
+	}
+}
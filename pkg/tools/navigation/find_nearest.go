@@ -0,0 +1,306 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultNearestLimit = 5
+	maxNearestLimit     = 20
+)
+
+// facilities lists the facility kinds find_nearest can search for
+var facilities = []string{"MARKETPLACE", "SHIPYARD", "FUEL", "ASTEROID", "JUMP_GATE"}
+
+// FindNearestTool finds the closest waypoints offering a facility to a ship
+type FindNearestTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewFindNearestTool creates a new nearest facility finder tool
+func NewFindNearestTool(client *client.Client, logger *logging.Logger) *FindNearestTool {
+	return &FindNearestTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *FindNearestTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "find_nearest",
+		Description: "Find the waypoints closest to a ship that offer a facility (MARKETPLACE, SHIPYARD, FUEL, ASTEROID or JUMP_GATE), sorted by distance with fuel and ETA for each flight mode",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to measure distances from (e.g., 'SHIP_1234')",
+				},
+				"facility": map[string]interface{}{
+					"type":        "string",
+					"description": "Facility to look for",
+					"enum":        facilities,
+				},
+				"include_adjacent": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also search systems connected to this system's jump gate",
+					"default":     false,
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of results (default %d, max %d)", defaultNearestLimit, maxNearestLimit),
+					"minimum":     1,
+					"maximum":     maxNearestLimit,
+				},
+			},
+			Required: []string{"ship_symbol", "facility"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":  map[string]interface{}{"type": "string"},
+			"origin":       map[string]interface{}{"type": "string", "description": "Waypoint the search started from"},
+			"facility":     map[string]interface{}{"type": "string"},
+			"engine_speed": map[string]interface{}{"type": "integer"},
+			"results":      map[string]interface{}{"type": "array", "description": "Nearest facilities, closest first, with travel estimates per flight mode"},
+			"count":        map[string]interface{}{"type": "integer"},
+		}, "ship_symbol", "origin", "facility", "results", "count"),
+	}
+}
+
+// nearbyFacility is a waypoint offering the requested facility and how far away it is
+type nearbyFacility struct {
+	Symbol    string            `json:"symbol"`
+	Type      string            `json:"type"`
+	System    string            `json:"system"`
+	X         int               `json:"x"`
+	Y         int               `json:"y"`
+	Distance  float64           `json:"distance"`
+	ViaGate   string            `json:"via_gate,omitempty"`
+	Estimates []travel.Estimate `json:"estimates"`
+
+	// gateLeg is the distance flown to reach the local jump gate
+	gateLeg float64
+}
+
+// Handler returns the tool handler function
+func (t *FindNearestTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "find-nearest-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		facility := v.RequireChoice("facility", facilities...)
+		includeAdjacent := v.OptionalBool("include_adjacent", false)
+		limit := v.OptionalInt("limit", defaultNearestLimit, 1, maxNearestLimit)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		ship, err := t.client.WithContext(ctx).GetShip(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Searching for nearest %s to ship %s at %s", facility, shipSymbol, ship.Nav.WaypointSymbol))
+
+		results, err := t.search(ctx, ship, facility, includeAdjacent, limit)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to search for %s near %s: %v", facility, ship.Nav.WaypointSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to search for %s near %s: %v", facility, ship.Nav.WaypointSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("find_nearest", true)
+
+		result := map[string]interface{}{
+			"ship_symbol":  shipSymbol,
+			"origin":       ship.Nav.WaypointSymbol,
+			"facility":     facility,
+			"engine_speed": ship.Engine.Speed,
+			"results":      results,
+			"count":        len(results),
+		}
+
+		textSummary := fmt.Sprintf("## Nearest %s to %s\n\n", facility, shipSymbol)
+		textSummary += fmt.Sprintf("**From:** %s (engine speed %d, fuel %d/%d)\n\n", ship.Nav.WaypointSymbol, ship.Engine.Speed, ship.Fuel.Current, ship.Fuel.Capacity)
+
+		if len(results) == 0 {
+			textSummary += fmt.Sprintf("No %s found", facility)
+			if !includeAdjacent {
+				textSummary += " in this system. Try again with include_adjacent to search systems connected by jump gate"
+			}
+			textSummary += ".\n"
+		}
+
+		for i, found := range results {
+			textSummary += fmt.Sprintf("%d. **%s** (%s) at (%d, %d) - %.1f units", i+1, found.Symbol, found.Type, found.X, found.Y, found.Distance)
+			if found.ViaGate != "" {
+				textSummary += fmt.Sprintf(" via jump to %s", found.ViaGate)
+			}
+			textSummary += "\n"
+			for _, estimate := range found.Estimates {
+				textSummary += fmt.Sprintf("   - %s: %d fuel, %s\n", estimate.FlightMode, estimate.FuelCost, estimate.TravelTime)
+			}
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// search looks for the facility in the ship's system and, optionally, in systems one jump away
+func (t *FindNearestTool) search(ctx context.Context, ship *client.Ship, facility string, includeAdjacent bool, limit int) ([]nearbyFacility, error) {
+	waypoints, _, err := t.client.WithContext(ctx).GetCachedSystemWaypoints(ship.Nav.SystemSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := findWaypoint(waypoints, ship.Nav.WaypointSymbol)
+	if origin == nil {
+		return nil, fmt.Errorf("waypoint %s not found in system %s", ship.Nav.WaypointSymbol, ship.Nav.SystemSymbol)
+	}
+
+	candidates := rankFacilities(waypoints, facility, origin.X, origin.Y, 0, "")
+
+	if includeAdjacent {
+		for _, gate := range waypoints {
+			if gate.Type != "JUMP_GATE" {
+				continue
+			}
+			jumpGate, err := t.client.WithContext(ctx).GetCachedJumpGate(ship.Nav.SystemSymbol, gate.Symbol)
+			if err != nil {
+				return nil, err
+			}
+			toGate := travel.Distance(origin.X, origin.Y, gate.X, gate.Y)
+			for _, connection := range jumpGate.Connections {
+				remote, _, err := t.client.WithContext(ctx).GetCachedSystemWaypoints(travel.SystemSymbol(connection))
+				if err != nil {
+					continue
+				}
+				arrival := findWaypoint(remote, connection)
+				if arrival == nil {
+					continue
+				}
+				candidates = append(candidates, rankFacilities(remote, facility, arrival.X, arrival.Y, toGate, connection)...)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Distance < candidates[j].Distance
+		})
+	}
+
+	results := make([]nearbyFacility, 0, limit)
+	for _, candidate := range candidates {
+		if len(results) == limit {
+			break
+		}
+		if facility == "FUEL" {
+			market, err := t.client.WithContext(ctx).GetMarket(candidate.System, candidate.Symbol)
+			if err != nil || !sellsFuel(market) {
+				continue
+			}
+		}
+		candidate.Estimates = facilityEstimates(candidate, ship.Engine.Speed)
+		results = append(results, candidate)
+	}
+
+	return results, nil
+}
+
+// rankFacilities returns the waypoints matching facility sorted by distance from (x, y).
+// offset is added to every distance to account for the trip to a jump gate.
+func rankFacilities(waypoints []client.SystemWaypoint, facility string, x, y int, offset float64, viaGate string) []nearbyFacility {
+	ranked := make([]nearbyFacility, 0)
+	for _, waypoint := range waypoints {
+		if !offersFacility(waypoint, facility) {
+			continue
+		}
+		ranked = append(ranked, nearbyFacility{
+			Symbol:   waypoint.Symbol,
+			Type:     waypoint.Type,
+			System:   travel.SystemSymbol(waypoint.Symbol),
+			X:        waypoint.X,
+			Y:        waypoint.Y,
+			Distance: offset + travel.Distance(x, y, waypoint.X, waypoint.Y),
+			ViaGate:  viaGate,
+			gateLeg:  offset,
+		})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Distance < ranked[j].Distance
+	})
+	return ranked
+}
+
+// facilityEstimates returns fuel and ETA per flight mode. Trips through a jump gate are
+// estimated as two legs, since the jump itself costs no fuel or travel time.
+func facilityEstimates(candidate nearbyFacility, engineSpeed int) []travel.Estimate {
+	if candidate.ViaGate == "" {
+		return travel.Estimates(candidate.Distance, travel.FlightModes, engineSpeed)
+	}
+
+	estimates := travel.Estimates(candidate.gateLeg, travel.FlightModes, engineSpeed)
+	remote := travel.Estimates(candidate.Distance-candidate.gateLeg, travel.FlightModes, engineSpeed)
+	for i := range estimates {
+		estimates[i].FuelCost += remote[i].FuelCost
+		estimates[i].TravelSeconds += remote[i].TravelSeconds
+		estimates[i].TravelTime = (time.Duration(estimates[i].TravelSeconds) * time.Second).String()
+	}
+	return estimates
+}
+
+// offersFacility reports whether a waypoint provides the facility. FUEL matches every
+// marketplace; the caller confirms fuel is actually sold.
+func offersFacility(waypoint client.SystemWaypoint, facility string) bool {
+	switch facility {
+	case "ASTEROID":
+		return waypoint.Type == "ASTEROID" || waypoint.Type == "ENGINEERED_ASTEROID" || waypoint.Type == "ASTEROID_FIELD"
+	case "JUMP_GATE":
+		return waypoint.Type == "JUMP_GATE"
+	case "FUEL":
+		return hasTrait(waypoint, "MARKETPLACE")
+	default:
+		return hasTrait(waypoint, facility)
+	}
+}
+
+// hasTrait reports whether a waypoint has a trait
+func hasTrait(waypoint client.SystemWaypoint, trait string) bool {
+	for _, t := range waypoint.Traits {
+		if t.Symbol == trait {
+			return true
+		}
+	}
+	return false
+}
+
+// findWaypoint returns the waypoint with the given symbol, or nil
+func findWaypoint(waypoints []client.SystemWaypoint, symbol string) *client.SystemWaypoint {
+	for i := range waypoints {
+		if waypoints[i].Symbol == symbol {
+			return &waypoints[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package navigation
+
+import (
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestRankFacilities(t *testing.T) {
+	waypoints := []client.SystemWaypoint{
+		{Symbol: "X1-TEST-FAR", Type: "PLANET", X: 100, Y: 0, Traits: []client.WaypointTrait{{Symbol: "MARKETPLACE"}}},
+		{Symbol: "X1-TEST-NEAR", Type: "MOON", X: 3, Y: 4, Traits: []client.WaypointTrait{{Symbol: "MARKETPLACE"}, {Symbol: "SHIPYARD"}}},
+		{Symbol: "X1-TEST-ROCK", Type: "ENGINEERED_ASTEROID", X: 10, Y: 0},
+		{Symbol: "X1-TEST-GATE", Type: "JUMP_GATE", X: -50, Y: 0},
+	}
+
+	markets := rankFacilities(waypoints, "MARKETPLACE", 0, 0, 0, "")
+	if len(markets) != 2 || markets[0].Symbol != "X1-TEST-NEAR" || markets[1].Symbol != "X1-TEST-FAR" {
+		t.Fatalf("Expected markets sorted by distance, got %+v", markets)
+	}
+	if markets[0].Distance != 5 || markets[0].System != "X1-TEST" {
+		t.Errorf("Expected nearest market 5 units away in X1-TEST, got %+v", markets[0])
+	}
+
+	if asteroids := rankFacilities(waypoints, "ASTEROID", 0, 0, 0, ""); len(asteroids) != 1 || asteroids[0].Symbol != "X1-TEST-ROCK" {
+		t.Errorf("Expected engineered asteroid to match ASTEROID, got %+v", asteroids)
+	}
+	if gates := rankFacilities(waypoints, "JUMP_GATE", 0, 0, 0, ""); len(gates) != 1 || gates[0].Symbol != "X1-TEST-GATE" {
+		t.Errorf("Expected jump gate to match JUMP_GATE, got %+v", gates)
+	}
+
+	remote := rankFacilities(waypoints, "SHIPYARD", 0, 0, 50, "X1-TEST-GATE")
+	if len(remote) != 1 || remote[0].Distance != 55 || remote[0].ViaGate != "X1-TEST-GATE" {
+		t.Errorf("Expected gate leg to be added to the distance, got %+v", remote)
+	}
+}
+
+func TestFacilityEstimates_ViaGate(t *testing.T) {
+	direct := nearbyFacility{Distance: 100}
+	viaGate := nearbyFacility{Distance: 100, ViaGate: "X1-OTHER-GATE", gateLeg: 50}
+
+	directEstimates := facilityEstimates(direct, 30)
+	gateEstimates := facilityEstimates(viaGate, 30)
+
+	for i := range directEstimates {
+		if gateEstimates[i].FlightMode != directEstimates[i].FlightMode {
+			t.Fatalf("Expected matching flight modes, got %s and %s", gateEstimates[i].FlightMode, directEstimates[i].FlightMode)
+		}
+		// Two legs pay the fixed departure overhead twice
+		if gateEstimates[i].TravelSeconds <= directEstimates[i].TravelSeconds {
+			t.Errorf("Expected %s via gate to take longer than a direct flight, got %d <= %d",
+				gateEstimates[i].FlightMode, gateEstimates[i].TravelSeconds, directEstimates[i].TravelSeconds)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxGateCrawlLimit caps how many jump gates one gate_path call may fetch
+const maxGateCrawlLimit = 500
+
+// GatePathTool finds the shortest chain of jump gates between two systems
+type GatePathTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewGatePathTool creates a new gate path tool
+func NewGatePathTool(client *client.Client, logger *logging.Logger) *GatePathTool {
+	return &GatePathTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *GatePathTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "gate_path",
+		Description: "Find the shortest path through the jump gate network from one system to another, in number of jumps. Crawls gates outward from the origin system until the destination is reached.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"system_a": map[string]interface{}{
+					"type":        "string",
+					"description": "Origin system symbol (e.g., 'X1-FM66')",
+				},
+				"system_b": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination system symbol (e.g., 'X1-KS52')",
+				},
+				"max_gates": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of jump gates to fetch while searching (default %d, max %d)", travel.DefaultGateCrawlLimit, maxGateCrawlLimit),
+					"minimum":     1,
+					"maximum":     maxGateCrawlLimit,
+				},
+			},
+			Required: []string{"system_a", "system_b"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"system_a":        map[string]interface{}{"type": "string"},
+			"system_b":        map[string]interface{}{"type": "string"},
+			"found":           map[string]interface{}{"type": "boolean"},
+			"gates_explored":  map[string]interface{}{"type": "integer"},
+			"gates_remaining": map[string]interface{}{"type": "integer", "description": "Known gates whose connections are not charted yet"},
+			"jumps":           map[string]interface{}{"type": "integer"},
+			"gates":           map[string]interface{}{"type": "array", "description": "Jump gates on the path, in order"},
+			"systems":         map[string]interface{}{"type": "array", "description": "Systems on the path, in order"},
+		}, "system_a", "system_b", "found", "gates_explored", "gates_remaining"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *GatePathTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "gate-path-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		systemA := v.RequireSystem("system_a")
+		systemB := v.RequireSystem("system_b")
+		limit := v.OptionalInt("max_gates", travel.DefaultGateCrawlLimit, 1, maxGateCrawlLimit)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Finding gate path from %s to %s", systemA, systemB))
+
+		c := t.client.WithContext(ctx)
+		waypoints, _, err := c.GetCachedSystemWaypoints(systemA)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get waypoints for %s: %v", systemA, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get waypoints for system %s: %v", systemA, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		var seeds []string
+		for _, waypoint := range waypoints {
+			if waypoint.Type == "JUMP_GATE" {
+				seeds = append(seeds, waypoint.Symbol)
+			}
+		}
+		if len(seeds) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("System %s has no jump gate, so it cannot be left by jumping. Use warp_ship instead.", systemA)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		graph := travel.CrawlGates(seeds, limit, func(gate string) ([]string, error) {
+			jumpGate, err := c.GetCachedJumpGate(travel.SystemSymbol(gate), gate)
+			if err != nil {
+				return nil, err
+			}
+			return jumpGate.Connections, nil
+		}, func(g *travel.GateGraph) bool {
+			_, found := g.Path(systemA, systemB)
+			return found
+		})
+
+		contextLogger.ToolCall("gate_path", true)
+
+		path, found := graph.Path(systemA, systemB)
+		result := map[string]interface{}{
+			"system_a":        systemA,
+			"system_b":        systemB,
+			"found":           found,
+			"gates_explored":  len(graph.Connections),
+			"gates_remaining": len(graph.Unexplored),
+		}
+
+		var textSummary string
+		if found {
+			systems := make([]string, len(path))
+			for i, gate := range path {
+				systems[i] = travel.SystemSymbol(gate)
+			}
+			result["jumps"] = len(path) - 1
+			result["gates"] = path
+			result["systems"] = systems
+
+			textSummary = fmt.Sprintf("## 🌀 Gate Path: %s → %s\n\n", systemA, systemB)
+			textSummary += fmt.Sprintf("**Jumps:** %d\n\n", len(path)-1)
+			for i, gate := range path {
+				textSummary += fmt.Sprintf("%d. %s (%s)\n", i+1, systems[i], gate)
+			}
+			if len(path) > 1 {
+				textSummary += fmt.Sprintf("\nJump to each system in turn with `jump_ship`, starting from %s.\n", path[0])
+			}
+		} else {
+			textSummary = fmt.Sprintf("## 🌀 No Gate Path Found: %s → %s\n\n", systemA, systemB)
+			textSummary += fmt.Sprintf("Explored %d jump gates without reaching %s. ", len(graph.Connections), systemB)
+			if len(graph.Unexplored) > 0 {
+				textSummary += fmt.Sprintf("%d gates are still unexplored: they may be uncharted, or beyond the search limit of %d gates. Try a larger `max_gates`, or chart the gates first.\n", len(graph.Unexplored), limit)
+			} else {
+				textSummary += "The reachable gate network has been fully explored, so the systems are not connected by jump gates.\n"
+			}
+		}
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
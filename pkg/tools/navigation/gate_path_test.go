@@ -0,0 +1,57 @@
+package navigation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGatePathTool_FindsShortestPath(t *testing.T) {
+	gates := map[string]string{
+		"/systems/X1-A/waypoints/X1-A-GATE/jump-gate": `{"symbol": "X1-A-GATE", "connections": ["X1-B-GATE", "X1-C-GATE"]}`,
+		"/systems/X1-B/waypoints/X1-B-GATE/jump-gate": `{"symbol": "X1-B-GATE", "connections": ["X1-A-GATE", "X1-D-GATE"]}`,
+		"/systems/X1-C/waypoints/X1-C-GATE/jump-gate": `{"symbol": "X1-C-GATE", "connections": ["X1-A-GATE"]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/systems/X1-A/waypoints":
+			_, _ = w.Write([]byte(`{"data": [{"symbol": "X1-A-GATE", "type": "JUMP_GATE", "systemSymbol": "X1-A", "x": 0, "y": 0}], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case gates[r.URL.Path] != "":
+			_, _ = w.Write([]byte(`{"data": ` + gates[r.URL.Path] + `}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewGatePathTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "gate_path",
+			Arguments: map[string]interface{}{"system_a": "x1-a", "system_b": "X1-D"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"**Jumps:** 2", "1. X1-A (X1-A-GATE)", "2. X1-B (X1-B-GATE)", "3. X1-D (X1-D-GATE)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in summary, got %q", want, text)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -15,6 +16,8 @@ import (
 type JumpShipTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState bool
 }
 
 // NewJumpShipTool creates a new jump ship tool
@@ -25,6 +28,12 @@ func NewJumpShipTool(client *client.Client, logger *logging.Logger) *JumpShipToo
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *JumpShipTool) WithAutoCorrectState(enabled bool) *JumpShipTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *JumpShipTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -41,9 +50,19 @@ func (t *JumpShipTool) Tool() mcp.Tool {
 					"type":        "string",
 					"description": "Symbol of the destination system (e.g., 'X1-AB12')",
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol", "system_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"navigation":       map[string]interface{}{"type": "object", "description": "Ship location, status and flight mode after the action"},
+			"cooldown":         map[string]interface{}{"type": "object", "description": "Jump drive cooldown"},
+			"state_correction": map[string]interface{}{"type": "string", "description": "Ship state fixed up before jumping, if any"},
+			"route":            map[string]interface{}{"type": "object"},
+			"event":            map[string]interface{}{"type": "object", "description": "Event encountered on the jump, if any"},
+		}, "success", "ship_symbol", "navigation", "cooldown"),
 	}
 }
 
@@ -52,48 +71,35 @@ func (t *JumpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "jump-ship-tool")
 
-		// Extract ship symbol and system symbol
-		var shipSymbol string
-		var systemSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						shipSymbol = s
-					}
-				}
-				if val, exists := argsMap["system_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						systemSymbol = s
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		systemSymbol := v.RequireSystem("system_symbol")
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
-		if shipSymbol == "" {
-			contextLogger.Error("Missing or invalid ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
+		contextLogger.Info(fmt.Sprintf("Attempting to jump ship %s to system %s", shipSymbol, systemSymbol))
 
-		if systemSymbol == "" {
-			contextLogger.Error("Missing or invalid system_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: system_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusInOrbit)
+			if err != nil {
+				contextLogger.Error("Failed to orbit ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to orbit ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
 		}
 
-		contextLogger.Info(fmt.Sprintf("Attempting to jump ship %s to system %s", shipSymbol, systemSymbol))
-
 		// Jump the ship
-		resp, err := t.client.JumpShip(shipSymbol, systemSymbol)
+		resp, err := t.client.WithContext(ctx).JumpShip(shipSymbol, systemSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to jump ship %s to %s: %v", shipSymbol, systemSymbol, err))
 			return &mcp.CallToolResult{
@@ -128,6 +134,9 @@ func (t *JumpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 				"expiration":        cooldown.Expiration,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
 
 		// Add route information if available
 		if nav.Route.Destination.Symbol != "" {
@@ -161,6 +170,9 @@ func (t *JumpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 
 		// Create text summary
 		textSummary := "## Ship Jump Completed\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Status:** %s\n", nav.Status)
 		textSummary += fmt.Sprintf("**New Location:** %s (%s)\n", nav.WaypointSymbol, nav.SystemSymbol)
@@ -217,11 +229,6 @@ func (t *JumpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 			textSummary += "\n**Current Status:** The ship's jump drive is ready for immediate use.\n"
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%+v\n```", result)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,189 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NavigateSquadronTool sends every ship in a squadron to the same waypoint
+type NavigateSquadronTool struct {
+	client           *client.Client
+	store            *shipmeta.Store
+	logger           *logging.Logger
+	policy           *policy.Policy
+	autoRefuel       bool
+	autoCorrectState bool
+}
+
+// squadronDeparture is how one ship's navigation went
+type squadronDeparture struct {
+	ShipSymbol string `json:"ship_symbol"`
+	Success    bool   `json:"success"`
+	Arrival    string `json:"arrival,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewNavigateSquadronTool creates a new navigate squadron tool
+func NewNavigateSquadronTool(client *client.Client, store *shipmeta.Store, logger *logging.Logger) *NavigateSquadronTool {
+	return &NavigateSquadronTool{
+		client: client,
+		store:  store,
+		logger: logger,
+	}
+}
+
+// WithPolicy makes auto-refueling refuse fuel bills that break the spending policy
+func (t *NavigateSquadronTool) WithPolicy(p *policy.Policy) *NavigateSquadronTool {
+	t.policy = p
+	return t
+}
+
+// WithAutoRefuel sets whether ships refuel before departing when auto_refuel is not specified
+func (t *NavigateSquadronTool) WithAutoRefuel(enabled bool) *NavigateSquadronTool {
+	t.autoRefuel = enabled
+	return t
+}
+
+// WithAutoCorrectState sets whether ships are put into orbit first when auto_correct_state is not specified
+func (t *NavigateSquadronTool) WithAutoCorrectState(enabled bool) *NavigateSquadronTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *NavigateSquadronTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "navigate_squadron",
+		Description: "Navigate every ship in a squadron to the same waypoint within their system, one ship after another, exactly as navigate_ship would. A ship that can't depart doesn't stop the others; the result lists how each ship fared.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"squadron": utils.SquadronProperty,
+				"waypoint_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the destination waypoint (e.g., 'X1-DF55-20250Z')",
+				},
+				"auto_refuel":        autoRefuelProperty(t.autoRefuel),
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
+			},
+			Required: []string{"squadron", "waypoint_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"squadron":        map[string]interface{}{"type": "string"},
+			"waypoint_symbol": map[string]interface{}{"type": "string"},
+			"ships":           map[string]interface{}{"type": "array", "description": "Each ship's departure: success, arrival time or error"},
+			"departed":        map[string]interface{}{"type": "integer"},
+			"failed":          map[string]interface{}{"type": "integer"},
+		}, "squadron", "waypoint_symbol", "ships", "departed", "failed"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *NavigateSquadronTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "navigate-squadron-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		name := v.RequireString("squadron")
+		waypointSymbol := v.RequireWaypoint("waypoint_symbol")
+		autoRefuel := v.OptionalBool("auto_refuel", t.autoRefuel)
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		squadron, err := utils.FindSquadron(t.store, name)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent("❌ " + err.Error()),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Each ship goes through navigate_ship, so refuelling and state correction work the same way
+		navigate := NewNavigateShipTool(t.client, t.logger).
+			WithPolicy(t.policy).
+			WithAutoRefuel(autoRefuel).
+			WithAutoCorrectState(autoCorrectState).
+			Handler()
+
+		departures := make([]squadronDeparture, 0, len(squadron.Ships))
+		departed := 0
+		for _, shipSymbol := range squadron.Ships {
+			if ctx.Err() != nil {
+				departures = append(departures, squadronDeparture{ShipSymbol: shipSymbol, Error: "not attempted: " + ctx.Err().Error()})
+				continue
+			}
+
+			shipArgs := map[string]interface{}{
+				"ship_symbol":     shipSymbol,
+				"waypoint_symbol": waypointSymbol,
+			}
+			result, err := navigate(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "navigate_ship", Arguments: shipArgs}})
+			departures = append(departures, departureOf(shipSymbol, result, err))
+			if departures[len(departures)-1].Success {
+				departed++
+			}
+		}
+
+		failed := len(departures) - departed
+		contextLogger.ToolCall("navigate_squadron", departed > 0)
+
+		textSummary := fmt.Sprintf("## 🚀 Squadron %s → %s\n\n", squadron.Name, waypointSymbol)
+		textSummary += fmt.Sprintf("**Departed:** %d of %d ships\n\n", departed, len(departures))
+		for _, departure := range departures {
+			if departure.Success {
+				textSummary += fmt.Sprintf("- ✅ **%s** arrives %s\n", departure.ShipSymbol, departure.Arrival)
+			} else {
+				textSummary += fmt.Sprintf("- ❌ **%s**: %s\n", departure.ShipSymbol, departure.Error)
+			}
+		}
+
+		result := utils.NewResult(textSummary, map[string]interface{}{
+			"squadron":        squadron.Name,
+			"waypoint_symbol": waypointSymbol,
+			"ships":           departures,
+			"departed":        departed,
+			"failed":          failed,
+		})
+		result.IsError = departed == 0
+		return result, nil
+	}
+}
+
+// departureOf reads one ship's navigate_ship result
+func departureOf(shipSymbol string, result *mcp.CallToolResult, err error) squadronDeparture {
+	departure := squadronDeparture{ShipSymbol: shipSymbol}
+	switch {
+	case err != nil:
+		departure.Error = err.Error()
+	case result == nil:
+		departure.Error = "no result"
+	case result.IsError:
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				departure.Error = text.Text
+				break
+			}
+		}
+	default:
+		departure.Success = true
+		if data, ok := result.StructuredContent.(map[string]interface{}); ok {
+			if route, ok := data["route"].(map[string]interface{}); ok {
+				departure.Arrival, _ = route["arrival"].(string)
+			}
+		}
+	}
+	return departure
+}
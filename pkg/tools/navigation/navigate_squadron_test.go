@@ -0,0 +1,66 @@
+package navigation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestNavigateSquadronTool_NavigatesEveryShip(t *testing.T) {
+	var navigated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/DRONE-1/navigate", "/my/ships/DRONE-3/navigate":
+			navigated = append(navigated, r.URL.Path)
+			_, _ = w.Write([]byte(`{"data": {"fuel": {}, "nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_TRANSIT",
+				"route": {"destination": {"symbol": "X1-TEST-B2"}, "arrival": "2030-01-01T00:00:00.000Z"}}}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "Ship is docked", "code": 4236}}`))
+		}
+	}))
+	defer server.Close()
+
+	store, _ := shipmeta.Open("")
+	_, _ = store.SaveSquadron("miners", []string{"DRONE-1", "DRONE-2", "DRONE-3"})
+	tool := NewNavigateSquadronTool(client.NewClientWithBaseURL("test-token", server.URL), store, logging.NewLogger(nil))
+
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "navigate_squadron", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]interface{}{"squadron": "Miners", "waypoint_symbol": "x1-test-b2"})
+	if result.IsError {
+		t.Fatalf("Expected success while some ships departed, got %v", result.Content)
+	}
+	if len(navigated) != 2 {
+		t.Errorf("Expected DRONE-1 and DRONE-3 to navigate, got %v", navigated)
+	}
+	data := result.StructuredContent.(map[string]interface{})
+	if data["departed"] != 2 || data["failed"] != 1 {
+		t.Errorf("Expected 2 departed and 1 failed, got %v", data)
+	}
+	departures := data["ships"].([]squadronDeparture)
+	if departures[0].Arrival != "2030-01-01T00:00:00.000Z" || departures[1].Success || !strings.Contains(departures[1].Error, "DRONE-2") {
+		t.Errorf("Expected each ship's arrival or error, got %+v", departures)
+	}
+
+	if result := call(map[string]interface{}{"squadron": "haulers", "waypoint_symbol": "X1-TEST-B2"}); !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "miners") {
+		t.Errorf("Expected an unknown squadron to be rejected with the known names, got %v", result.Content)
+	}
+}
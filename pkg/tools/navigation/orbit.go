@@ -40,6 +40,12 @@ func (t *OrbitShipTool) Tool() mcp.Tool {
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":     map[string]interface{}{"type": "boolean"},
+			"ship_symbol": map[string]interface{}{"type": "string"},
+			"navigation":  map[string]interface{}{"type": "object", "description": "Ship location, status and flight mode after the action"},
+			"route":       map[string]interface{}{"type": "object", "description": "Route of the ship, when it has one"},
+		}, "success", "ship_symbol", "navigation"),
 	}
 }
 
@@ -48,32 +54,17 @@ func (t *OrbitShipTool) Handler() func(ctx context.Context, request mcp.CallTool
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "orbit-ship-tool")
 
-		// Extract ship symbol
-		var shipSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						shipSymbol = s
-					}
-				}
-			}
-		}
-
-		if shipSymbol == "" {
-			contextLogger.Error("Missing or invalid ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Attempting to orbit ship: %s", shipSymbol))
 
 		// Orbit the ship
-		nav, err := t.client.OrbitShip(shipSymbol)
+		nav, err := t.client.WithContext(ctx).OrbitShip(shipSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to orbit ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -134,11 +125,6 @@ func (t *OrbitShipTool) Handler() func(ctx context.Context, request mcp.CallTool
 			textSummary += fmt.Sprintf("- Arrival: %s\n", nav.Data.Nav.Route.Arrival)
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,430 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cheapFuelSecondsPerCredit is how much longer a route may take for each credit it saves on fuel
+// when cheap fuel is preferred
+const cheapFuelSecondsPerCredit = 1
+
+// PlanRouteTool plans a ship's route to a waypoint, adding refuel stops where the tank cannot cover a leg
+type PlanRouteTool struct {
+	client  *client.Client
+	tracker *explorer.Tracker
+	prices  *prices.DB
+	logger  *logging.Logger
+}
+
+// NewPlanRouteTool creates a new route planning tool
+func NewPlanRouteTool(client *client.Client, logger *logging.Logger) *PlanRouteTool {
+	return &PlanRouteTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithExplorer lets warp routes refuel in systems the exploration tracker has seen marketplaces in
+func (t *PlanRouteTool) WithExplorer(tracker *explorer.Tracker) *PlanRouteTool {
+	t.tracker = tracker
+	return t
+}
+
+// WithPrices prices refuel stops from the fuel index, and lets routes prefer cheap fuel
+func (t *PlanRouteTool) WithPrices(db *prices.DB) *PlanRouteTool {
+	t.prices = db
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *PlanRouteTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "plan_route",
+		Description: "Plan a ship's route to a waypoint without moving it. Within a system the route uses navigate legs; to another system it uses warp legs limited by the ship's warp drive range. Refuel stops at marketplaces selling fuel are added wherever the tank cannot cover a leg, preferring stations with cheap recorded fuel prices when that costs little extra time.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to plan for (e.g., 'SHIP_1234')",
+				},
+				"destination": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination waypoint symbol; a waypoint in another system plans a warp route",
+				},
+				"flight_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Flight mode to plan with (CRUISE, BURN, DRIFT, STEALTH). Defaults to the ship's current flight mode.",
+				},
+				"prefer_cheap_fuel": map[string]interface{}{
+					"type":        "boolean",
+					"description": fmt.Sprintf("Optional: Accept up to %d second(s) of extra travel per credit saved by refuelling at cheaper stations (default true)", cheapFuelSecondsPerCredit),
+				},
+			},
+			Required: []string{"ship_symbol", "destination"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":    map[string]interface{}{"type": "string"},
+			"origin":         map[string]interface{}{"type": "string"},
+			"destination":    map[string]interface{}{"type": "string"},
+			"warp":           map[string]interface{}{"type": "boolean"},
+			"flight_mode":    map[string]interface{}{"type": "string"},
+			"fuel_current":   map[string]interface{}{"type": "integer"},
+			"fuel_capacity":  map[string]interface{}{"type": "integer"},
+			"legs":           map[string]interface{}{"type": "array", "description": "Route legs in order, with distance, fuel and time"},
+			"refuel_stops":   map[string]interface{}{"type": "array", "description": "Waypoints to refuel at"},
+			"total_distance": map[string]interface{}{"type": "number"},
+			"total_fuel":     map[string]interface{}{"type": "integer"},
+			"total_seconds":  map[string]interface{}{"type": "integer"},
+			"fuel_credits":   map[string]interface{}{"type": "integer", "description": "Estimated cost of the refuels, from recorded fuel prices"},
+			"warp_drive":     map[string]interface{}{"type": "object", "description": "Warp drive used for a trip to another system"},
+		}, "ship_symbol", "origin", "destination", "warp", "flight_mode", "legs", "refuel_stops", "total_distance", "total_fuel", "total_seconds"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *PlanRouteTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "plan-route-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		destination := v.RequireWaypoint("destination")
+		flightMode := v.OptionalSymbol("flight_mode", utils.FlightModes, "")
+		preferCheapFuel := v.OptionalBool("prefer_cheap_fuel", true)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if flightMode == "" {
+			flightMode = ship.Nav.FlightMode
+		}
+		if flightMode == "" {
+			flightMode = "CRUISE"
+		}
+
+		origin := ship.Nav.WaypointSymbol
+		warp := travel.SystemSymbol(destination) != ship.Nav.SystemSymbol
+		limits := travel.RouteLimits{
+			Fuel:         ship.Fuel.Current,
+			FuelCapacity: ship.Fuel.Capacity,
+			FlightMode:   flightMode,
+			EngineSpeed:  ship.Engine.Speed,
+		}
+
+		var drive *client.Module
+		if warp {
+			drive = warpDrive(ship)
+			if drive == nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("Ship %s has no warp drive, so it cannot warp to %s. Install a warp drive module, or travel through the jump gate network instead (see gate_path and jump_ship).", shipSymbol, travel.SystemSymbol(destination))),
+					},
+					IsError: true,
+				}, nil
+			}
+			limits.MaxLeg = float64(drive.Range)
+		}
+
+		contextLogger.Info(fmt.Sprintf("Planning route for %s from %s to %s", shipSymbol, origin, destination))
+
+		var from, to travel.Stop
+		var stops []travel.Stop
+		if warp {
+			from, to, stops, err = t.warpStops(c, origin, destination)
+		} else {
+			from, to, stops, err = navigationStops(c, origin, destination)
+		}
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to plan route from %s to %s: %v", origin, destination, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to plan route from %s to %s: %v", origin, destination, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+		from.Fuel = marketSellsFuel(c, origin)
+		if t.prices != nil {
+			priceFuelStops(t.prices, &from, stops, preferCheapFuel)
+			if preferCheapFuel {
+				limits.SecondsPerCredit = cheapFuelSecondsPerCredit
+			}
+		}
+
+		legs, err := planWithFuelStops(c, from, to, stops, limits)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(noRouteText(from, to, limits, warp)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("plan_route", true)
+
+		var totalDistance float64
+		var totalFuel, totalSeconds, fuelCredits int
+		refuelStops := make([]string, 0)
+		for _, leg := range legs {
+			totalDistance += leg.Distance
+			totalFuel += leg.FuelCost
+			totalSeconds += leg.TravelSeconds
+			fuelCredits += leg.FuelCredits
+			if leg.Refuel {
+				refuelStops = append(refuelStops, leg.From)
+			}
+		}
+
+		result := map[string]interface{}{
+			"ship_symbol":    shipSymbol,
+			"origin":         origin,
+			"destination":    destination,
+			"warp":           warp,
+			"flight_mode":    flightMode,
+			"fuel_current":   ship.Fuel.Current,
+			"fuel_capacity":  ship.Fuel.Capacity,
+			"legs":           legs,
+			"refuel_stops":   refuelStops,
+			"total_distance": totalDistance,
+			"total_fuel":     totalFuel,
+			"total_seconds":  totalSeconds,
+		}
+		if fuelCredits > 0 {
+			result["fuel_credits"] = fuelCredits
+		}
+		if drive != nil {
+			result["warp_drive"] = map[string]interface{}{
+				"symbol": drive.Symbol,
+				"range":  drive.Range,
+			}
+		}
+
+		action := "navigate_ship"
+		if warp {
+			action = "warp_ship"
+		}
+
+		textSummary := fmt.Sprintf("## 🧭 Route Plan: %s → %s\n\n", origin, destination)
+		textSummary += fmt.Sprintf("**Ship:** %s (%s, fuel %d/%d)\n", shipSymbol, flightMode, ship.Fuel.Current, ship.Fuel.Capacity)
+		if drive != nil {
+			textSummary += fmt.Sprintf("**Warp Drive:** %s (range %d)\n", drive.Symbol, drive.Range)
+		}
+		textSummary += fmt.Sprintf("**Total:** %d legs, %.1f units, %d fuel, %s\n", len(legs), totalDistance, totalFuel, time.Duration(totalSeconds)*time.Second)
+		if fuelCredits > 0 {
+			textSummary += fmt.Sprintf("**Refuelling:** about %d credits\n", fuelCredits)
+		}
+		textSummary += "\n"
+		for i, leg := range legs {
+			line := fmt.Sprintf("%d. %s → %s: %.1f units, %d fuel, %s", i+1, leg.From, leg.To, leg.Distance, leg.FuelCost, time.Duration(leg.TravelSeconds)*time.Second)
+			if leg.Refuel {
+				line += " ⛽ refuel first"
+				if leg.FuelCredits > 0 {
+					line += fmt.Sprintf(" (~%d credits)", leg.FuelCredits)
+				}
+			}
+			textSummary += line + "\n"
+		}
+		textSummary += fmt.Sprintf("\nFly each leg with `%s`, docking to refuel where marked.", action)
+		if warp {
+			textSummary += " Warping burns fuel; only jumping through gates uses antimatter."
+		}
+		textSummary += "\n"
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// warpDrive returns the ship's warp drive module, or nil if it has none
+func warpDrive(ship *client.Ship) *client.Module {
+	for i := range ship.Modules {
+		if strings.HasPrefix(ship.Modules[i].Symbol, "MODULE_WARP_DRIVE") {
+			return &ship.Modules[i]
+		}
+	}
+	return nil
+}
+
+// navigationStops returns the origin, destination and marketplaces of one system, positioned by waypoint coordinates
+func navigationStops(c *client.Client, origin, destination string) (from, to travel.Stop, stops []travel.Stop, err error) {
+	systemSymbol := travel.SystemSymbol(origin)
+	waypoints, _, err := c.GetCachedSystemWaypoints(systemSymbol)
+	if err != nil {
+		return from, to, nil, fmt.Errorf("failed to get waypoints for system %s: %w", systemSymbol, err)
+	}
+
+	var foundFrom, foundTo bool
+	for _, waypoint := range waypoints {
+		stop := travel.Stop{Symbol: waypoint.Symbol, X: waypoint.X, Y: waypoint.Y}
+		switch waypoint.Symbol {
+		case origin:
+			from, foundFrom = stop, true
+		case destination:
+			to, foundTo = stop, true
+		}
+		for _, trait := range waypoint.Traits {
+			if trait.Symbol == "MARKETPLACE" {
+				// Assumed to sell fuel until the plan uses it and the market is checked
+				stop.Fuel = true
+				stops = append(stops, stop)
+				break
+			}
+		}
+	}
+	if !foundFrom {
+		return from, to, nil, fmt.Errorf("waypoint %s not found in system %s", origin, systemSymbol)
+	}
+	if !foundTo {
+		return from, to, nil, fmt.Errorf("waypoint %s not found in system %s", destination, systemSymbol)
+	}
+	return from, to, stops, nil
+}
+
+// warpStops returns the origin, destination and candidate refuel stops for a warp route, positioned by
+// system coordinates. Refuel stops are marketplaces the exploration tracker has seen in other systems.
+func (t *PlanRouteTool) warpStops(c *client.Client, origin, destination string) (from, to travel.Stop, stops []travel.Stop, err error) {
+	originSystem, err := c.GetSystem(travel.SystemSymbol(origin))
+	if err != nil {
+		return from, to, nil, fmt.Errorf("failed to look up system %s: %w", travel.SystemSymbol(origin), err)
+	}
+	destinationSystem, err := c.GetSystem(travel.SystemSymbol(destination))
+	if err != nil {
+		return from, to, nil, fmt.Errorf("failed to look up system %s: %w", travel.SystemSymbol(destination), err)
+	}
+	from = travel.Stop{Symbol: origin, X: originSystem.X, Y: originSystem.Y}
+	to = travel.Stop{Symbol: destination, X: destinationSystem.X, Y: destinationSystem.Y}
+
+	if t.tracker == nil {
+		return from, to, nil, nil
+	}
+	for _, system := range t.tracker.Systems() {
+		if system.Symbol == originSystem.Symbol || system.Symbol == destinationSystem.Symbol {
+			continue
+		}
+		var market string
+		for _, waypoint := range t.tracker.Waypoints(system.Symbol) {
+			if waypoint.HasTrait("MARKETPLACE") {
+				market = waypoint.Symbol
+				break
+			}
+		}
+		if market == "" {
+			continue
+		}
+		x, y := system.X, system.Y
+		if system.Type == "" {
+			// Only scanned systems have a recorded position
+			found, err := c.GetSystem(system.Symbol)
+			if err != nil {
+				continue
+			}
+			x, y = found.X, found.Y
+		}
+		stops = append(stops, travel.Stop{Symbol: market, X: x, Y: y, Fuel: true})
+	}
+	return from, to, stops, nil
+}
+
+// marketSellsFuel reports whether fuel can be bought at a waypoint
+func marketSellsFuel(c *client.Client, waypointSymbol string) bool {
+	market, err := c.GetMarket(travel.SystemSymbol(waypointSymbol), waypointSymbol)
+	return err == nil && sellsFuel(market)
+}
+
+// priceFuelStops sets the recorded fuel price of the origin and every stop. When cheap fuel is
+// preferred, stops with no recorded price are given the dearest price known among them, so that
+// an unpriced station is never favoured over one known to be cheap.
+func priceFuelStops(db *prices.DB, origin *travel.Stop, stops []travel.Stop, preferCheapFuel bool) {
+	if price, ok := db.FuelPrice(origin.Symbol); ok {
+		origin.FuelPrice = price
+	}
+	dearest := 0
+	for i := range stops {
+		if price, ok := db.FuelPrice(stops[i].Symbol); ok {
+			stops[i].FuelPrice = price
+			dearest = max(dearest, price)
+		}
+	}
+	if !preferCheapFuel {
+		return
+	}
+	for i := range stops {
+		if stops[i].Fuel && stops[i].FuelPrice == 0 {
+			stops[i].FuelPrice = dearest
+		}
+	}
+}
+
+// planWithFuelStops plans a route and checks the market at every refuel stop it uses, replanning
+// without any stop that turns out not to sell fuel
+func planWithFuelStops(c *client.Client, from, to travel.Stop, stops []travel.Stop, limits travel.RouteLimits) ([]travel.Leg, error) {
+	confirmed := make(map[string]bool)
+	for {
+		legs, err := travel.PlanRoute(from, to, stops, limits)
+		if err != nil {
+			return nil, err
+		}
+
+		rejected := ""
+		for _, leg := range legs[1:] {
+			if confirmed[leg.From] {
+				continue
+			}
+			if !marketSellsFuel(c, leg.From) {
+				rejected = leg.From
+				break
+			}
+			confirmed[leg.From] = true
+		}
+		if rejected == "" {
+			return legs, nil
+		}
+
+		remaining := stops[:0:0]
+		for _, stop := range stops {
+			if stop.Symbol != rejected {
+				remaining = append(remaining, stop)
+			}
+		}
+		stops = remaining
+	}
+}
+
+// noRouteText explains why no route could be planned
+func noRouteText(from, to travel.Stop, limits travel.RouteLimits, warp bool) string {
+	distance := travel.Distance(from.X, from.Y, to.X, to.Y)
+	text := fmt.Sprintf("No route found from %s to %s (%.1f units direct). ", from.Symbol, to.Symbol, distance)
+	if warp && limits.MaxLeg > 0 && distance > limits.MaxLeg {
+		text += fmt.Sprintf("The warp drive's range is %.0f units and no known system with a fuel market lies in between. ", limits.MaxLeg)
+		return text + "Scan for systems and chart their marketplaces to find stops within range, or travel through jump gates instead (see gate_path)."
+	}
+	text += fmt.Sprintf("The tank holds %d fuel (%d now) and no reachable stop sells fuel to bridge the gap. ", limits.FuelCapacity, limits.Fuel)
+	if limits.FlightMode != "DRIFT" {
+		return text + "Try flight_mode DRIFT, which needs almost no fuel, or find more marketplaces to refuel at."
+	}
+	return text + "Find more marketplaces to refuel at."
+}
@@ -0,0 +1,133 @@
+package navigation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const planRouteShip = `{"data": {"symbol": "SHIP_1", "nav": {"systemSymbol": "X1-A", "waypointSymbol": "X1-A-HOME", "status": "IN_ORBIT", "flightMode": "CRUISE"}, "engine": {"speed": 30}, "modules": [], "fuel": {"current": 50, "capacity": 100}}}`
+
+func callPlanRoute(t *testing.T, handler http.HandlerFunc, arguments map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	return callPlanRouteWithPrices(t, nil, handler, arguments)
+}
+
+func callPlanRouteWithPrices(t *testing.T, db *prices.DB, handler http.HandlerFunc, arguments map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tool := NewPlanRouteTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).WithPrices(db)
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "plan_route", Arguments: arguments},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
+
+func TestPlanRouteTool_NoWarpDrive(t *testing.T) {
+	result := callPlanRoute(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/my/ships/SHIP_1" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(planRouteShip))
+	}, map[string]interface{}{"ship_symbol": "ship_1", "destination": "X1-B-FAR"})
+
+	if !result.IsError {
+		t.Fatalf("Expected an error for a ship without a warp drive")
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "has no warp drive") {
+		t.Errorf("Expected warp drive explanation, got %q", text)
+	}
+}
+
+func TestPlanRouteTool_InSystemRefuelStop(t *testing.T) {
+	// X1-A-DRY is on the faster route but sells no fuel, so the plan falls back to X1-A-FUEL
+	result := callPlanRoute(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/SHIP_1":
+			_, _ = w.Write([]byte(planRouteShip))
+		case "/systems/X1-A/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-A-HOME", "type": "PLANET", "systemSymbol": "X1-A", "x": 0, "y": 0},
+				{"symbol": "X1-A-DRY", "type": "MOON", "systemSymbol": "X1-A", "x": 50, "y": 0, "traits": [{"symbol": "MARKETPLACE"}]},
+				{"symbol": "X1-A-FUEL", "type": "MOON", "systemSymbol": "X1-A", "x": 48, "y": 10, "traits": [{"symbol": "MARKETPLACE"}]},
+				{"symbol": "X1-A-FAR", "type": "ASTEROID", "systemSymbol": "X1-A", "x": 120, "y": 0}
+			], "meta": {"total": 4, "page": 1, "limit": 20}}`))
+		case "/systems/X1-A/waypoints/X1-A-FUEL/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-A-FUEL", "exports": [], "imports": [], "exchange": [{"symbol": "FUEL"}]}}`))
+		case "/systems/X1-A/waypoints/X1-A-DRY/market":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-A-DRY", "exports": [], "imports": [], "exchange": []}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"message": "not found", "code": 404}}`))
+		}
+	}, map[string]interface{}{"ship_symbol": "SHIP_1", "destination": "X1-A-FAR"})
+
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"**Total:** 2 legs", "1. X1-A-HOME → X1-A-FUEL", "2. X1-A-FUEL → X1-A-FAR: 72.7 units, 73 fuel, 1m16s ⛽ refuel first"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in summary, got %q", want, text)
+		}
+	}
+}
+
+func TestPlanRouteTool_PrefersCheapFuel(t *testing.T) {
+	// X1-A-DEAR is on the slightly faster route, but its fuel costs five times as much
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/SHIP_1":
+			_, _ = w.Write([]byte(planRouteShip))
+		case "/systems/X1-A/waypoints":
+			_, _ = w.Write([]byte(`{"data": [
+				{"symbol": "X1-A-HOME", "type": "PLANET", "systemSymbol": "X1-A", "x": 0, "y": 0},
+				{"symbol": "X1-A-DEAR", "type": "MOON", "systemSymbol": "X1-A", "x": 50, "y": 0, "traits": [{"symbol": "MARKETPLACE"}]},
+				{"symbol": "X1-A-CHEAP", "type": "MOON", "systemSymbol": "X1-A", "x": 48, "y": 10, "traits": [{"symbol": "MARKETPLACE"}]},
+				{"symbol": "X1-A-FAR", "type": "ASTEROID", "systemSymbol": "X1-A", "x": 120, "y": 0}
+			], "meta": {"total": 4, "page": 1, "limit": 20}}`))
+		case "/systems/X1-A/waypoints/X1-A-DEAR/market", "/systems/X1-A/waypoints/X1-A-CHEAP/market":
+			symbol := strings.Split(r.URL.Path, "/")[4]
+			_, _ = w.Write([]byte(`{"data": {"symbol": "` + symbol + `", "exports": [], "imports": [], "exchange": [{"symbol": "FUEL"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"message": "not found", "code": 404}}`))
+		}
+	}
+
+	db := prices.New()
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-A-DEAR", Prices: []prices.Price{{TradeSymbol: "FUEL", PurchasePrice: 300}}})
+	db.Record(prices.Snapshot{WaypointSymbol: "X1-A-CHEAP", Prices: []prices.Price{{TradeSymbol: "FUEL", PurchasePrice: 60}}})
+
+	result := callPlanRouteWithPrices(t, db, handler, map[string]interface{}{"ship_symbol": "SHIP_1", "destination": "X1-A-FAR"})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"1. X1-A-HOME → X1-A-CHEAP", "⛽ refuel first (~44 credits)", "**Refuelling:** about 44 credits"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in summary, got %q", want, text)
+		}
+	}
+
+	result = callPlanRouteWithPrices(t, db, handler, map[string]interface{}{"ship_symbol": "SHIP_1", "destination": "X1-A-FAR", "prefer_cheap_fuel": false})
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "1. X1-A-HOME → X1-A-DEAR") {
+		t.Errorf("Expected the fastest route through X1-A-DEAR without the preference, got %q", text)
+	}
+}
@@ -0,0 +1,330 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultMinCondition is the lowest frame, reactor and engine condition, in percent, a ship passes with
+const defaultMinCondition = 50
+
+// PreflightCheck is one item on the pre-flight checklist
+type PreflightCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// PreflightCheckTool checks a ship is fit for a trip before it leaves
+type PreflightCheckTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewPreflightCheckTool creates a new pre-flight check tool
+func NewPreflightCheckTool(client *client.Client, logger *logging.Logger) *PreflightCheckTool {
+	return &PreflightCheckTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *PreflightCheckTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "preflight_check",
+		Description: "Check a ship is fit for a trip before navigating: fuel for the flight mode, free cargo space for the mission, frame/reactor/engine condition, cooldowns that would still block it on arrival, and deadlines of accepted contracts that depend on the trip. Returns a pass/fail checklist with what to fix. Moves no ship.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to check",
+				},
+				"destination": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination waypoint symbol; a waypoint in another system checks a warp",
+				},
+				"flight_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Flight mode to check with (CRUISE, BURN, DRIFT, STEALTH). Defaults to the ship's current mode.",
+				},
+				"cargo_units": map[string]interface{}{
+					"type":        "integer",
+					"description": "Optional: Free cargo space the mission needs, e.g. the units to buy or mine at the destination",
+					"minimum":     0,
+				},
+				"min_condition": map[string]interface{}{
+					"type":        "number",
+					"description": fmt.Sprintf("Optional: Lowest frame, reactor and engine condition to accept, in percent (default %d)", defaultMinCondition),
+					"minimum":     0,
+					"maximum":     100,
+				},
+			},
+			Required: []string{"ship_symbol", "destination"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":    map[string]interface{}{"type": "string"},
+			"origin":         map[string]interface{}{"type": "string"},
+			"destination":    map[string]interface{}{"type": "string"},
+			"flight_mode":    map[string]interface{}{"type": "string"},
+			"distance":       map[string]interface{}{"type": "number"},
+			"warp":           map[string]interface{}{"type": "boolean", "description": "Whether the trip crosses systems"},
+			"fuel_cost":      map[string]interface{}{"type": "integer"},
+			"travel_seconds": map[string]interface{}{"type": "integer"},
+			"arrival":        map[string]interface{}{"type": "string", "description": "Estimated arrival if the ship left now"},
+			"passed":         map[string]interface{}{"type": "boolean", "description": "Whether every check passed"},
+			"checks":         map[string]interface{}{"type": "array", "description": "Each check with whether it passed and why"},
+		}, "ship_symbol", "destination", "flight_mode", "passed", "checks"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *PreflightCheckTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "preflight-check-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		destination := v.RequireWaypoint("destination")
+		flightMode := v.OptionalSymbol("flight_mode", utils.FlightModes, "")
+		cargoUnits := v.OptionalInt("cargo_units", 0, 0, math.MaxInt)
+		minCondition := v.OptionalNumber("min_condition", defaultMinCondition, 0, 100)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if flightMode == "" {
+			flightMode = ship.Nav.FlightMode
+		}
+		if flightMode == "" {
+			flightMode = "CRUISE"
+		}
+
+		origin := ship.Nav.WaypointSymbol
+		distance, warp, err := travel.RouteDistance(c, origin, destination)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to estimate route from %s to %s: %v", origin, destination, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to estimate route from %s to %s: %v", origin, destination, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Pre-flight check for %s to %s in %s", shipSymbol, destination, flightMode))
+
+		now := time.Now()
+		fuelCost := travel.FuelCost(distance, flightMode)
+		duration := travel.TravelTime(distance, flightMode, ship.Engine.Speed)
+		departure := now
+		if arrival, err := time.Parse(time.RFC3339, ship.Nav.Route.Arrival); err == nil && ship.Nav.Status == "IN_TRANSIT" && arrival.After(now) {
+			departure = arrival
+		}
+		arrival := departure.Add(duration)
+
+		checks := []PreflightCheck{
+			checkInTransit(ship, now),
+			checkFuel(ship, flightMode, fuelCost),
+			checkCargo(ship, cargoUnits),
+			checkCondition(ship, minCondition),
+			checkCooldown(ship, now, arrival),
+		}
+
+		contracts, err := c.GetAllContracts()
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get contracts: %v", err))
+			checks = append(checks, PreflightCheck{Name: "contracts", Detail: fmt.Sprintf("Could not check contract deadlines: %v", err)})
+		} else {
+			checks = append(checks, checkContracts(ship, contracts, destination, arrival))
+		}
+
+		passed := true
+		for _, check := range checks {
+			passed = passed && check.Passed
+		}
+
+		contextLogger.ToolCall("preflight_check", true)
+
+		result := map[string]interface{}{
+			"ship_symbol":    ship.Symbol,
+			"origin":         origin,
+			"destination":    destination,
+			"flight_mode":    flightMode,
+			"distance":       distance,
+			"warp":           warp,
+			"fuel_cost":      fuelCost,
+			"travel_seconds": int(duration.Seconds()),
+			"arrival":        arrival.UTC().Format(time.RFC3339),
+			"passed":         passed,
+			"checks":         checks,
+		}
+
+		verdict := "✅ Cleared for departure"
+		if !passed {
+			verdict = "❌ Not cleared for departure"
+		}
+		textSummary := fmt.Sprintf("## Pre-flight Check: %s\n\n", ship.Symbol)
+		textSummary += fmt.Sprintf("**Route:** %s → %s in %s\n", origin, destination, flightMode)
+		if warp {
+			textSummary += fmt.Sprintf("**Distance:** %.1f units between systems (warp)\n", distance)
+		} else {
+			textSummary += fmt.Sprintf("**Distance:** %.1f units\n", distance)
+		}
+		textSummary += fmt.Sprintf("**Trip:** %d fuel, %s, arriving about %s\n\n", fuelCost, duration, arrival.UTC().Format(time.RFC3339))
+		for _, check := range checks {
+			mark := "✅"
+			if !check.Passed {
+				mark = "❌"
+			}
+			textSummary += fmt.Sprintf("- %s **%s:** %s\n", mark, check.Name, check.Detail)
+		}
+		textSummary += fmt.Sprintf("\n**%s**\n", verdict)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// checkInTransit fails while the ship is still flying, since it can't be sent anywhere until it arrives
+func checkInTransit(ship *client.Ship, now time.Time) PreflightCheck {
+	if ship.Nav.Status != "IN_TRANSIT" {
+		return PreflightCheck{Name: "status", Passed: true, Detail: fmt.Sprintf("%s at %s", ship.Nav.Status, ship.Nav.WaypointSymbol)}
+	}
+	detail := fmt.Sprintf("In transit to %s; wait for it to arrive", ship.Nav.WaypointSymbol)
+	if arrival, err := time.Parse(time.RFC3339, ship.Nav.Route.Arrival); err == nil && arrival.After(now) {
+		detail = fmt.Sprintf("In transit to %s for another %s; wait for it to arrive", ship.Nav.WaypointSymbol, arrival.Sub(now).Round(time.Second))
+	}
+	return PreflightCheck{Name: "status", Detail: detail}
+}
+
+// checkFuel compares the fuel the trip burns with what the ship has and can hold
+func checkFuel(ship *client.Ship, flightMode string, fuelCost int) PreflightCheck {
+	switch {
+	case ship.Fuel.Capacity == 0:
+		return PreflightCheck{Name: "fuel", Passed: true, Detail: "Ship does not use fuel"}
+	case fuelCost > ship.Fuel.Capacity:
+		detail := fmt.Sprintf("Needs %d fuel in %s but the tank holds %d; use DRIFT or plan_route for refuel stops", fuelCost, flightMode, ship.Fuel.Capacity)
+		return PreflightCheck{Name: "fuel", Detail: detail}
+	case fuelCost > ship.Fuel.Current:
+		detail := fmt.Sprintf("Needs %d fuel in %s but has %d/%d; refuel first", fuelCost, flightMode, ship.Fuel.Current, ship.Fuel.Capacity)
+		return PreflightCheck{Name: "fuel", Detail: detail}
+	}
+	detail := fmt.Sprintf("Needs %d fuel in %s, has %d/%d", fuelCost, flightMode, ship.Fuel.Current, ship.Fuel.Capacity)
+	return PreflightCheck{Name: "fuel", Passed: true, Detail: detail}
+}
+
+// checkCargo compares the ship's free cargo space with what the mission needs
+func checkCargo(ship *client.Ship, needed int) PreflightCheck {
+	free := ship.Cargo.Capacity - ship.Cargo.Units
+	if needed == 0 {
+		return PreflightCheck{Name: "cargo", Passed: true, Detail: fmt.Sprintf("%d/%d units free; no space requested", free, ship.Cargo.Capacity)}
+	}
+	if free < needed {
+		detail := fmt.Sprintf("Needs %d units free but has %d/%d; sell, deliver or jettison cargo first", needed, free, ship.Cargo.Capacity)
+		return PreflightCheck{Name: "cargo", Detail: detail}
+	}
+	return PreflightCheck{Name: "cargo", Passed: true, Detail: fmt.Sprintf("Needs %d units free, has %d/%d", needed, free, ship.Cargo.Capacity)}
+}
+
+// checkCondition fails when the frame, reactor or engine is worn below the threshold
+func checkCondition(ship *client.Ship, minCondition float64) PreflightCheck {
+	parts := []struct {
+		name      string
+		condition float64
+	}{
+		{"frame", ship.Frame.Condition},
+		{"reactor", ship.Reactor.Condition},
+		{"engine", ship.Engine.Condition},
+	}
+	var worn []string
+	for _, part := range parts {
+		if part.condition*100 < minCondition {
+			worn = append(worn, fmt.Sprintf("%s %.0f%%", part.name, part.condition*100))
+		}
+	}
+	if len(worn) > 0 {
+		detail := fmt.Sprintf("Below %.0f%%: %s; repair at a shipyard first", minCondition, strings.Join(worn, ", "))
+		return PreflightCheck{Name: "condition", Detail: detail}
+	}
+	detail := fmt.Sprintf("Frame %.0f%%, reactor %.0f%%, engine %.0f%%", ship.Frame.Condition*100, ship.Reactor.Condition*100, ship.Engine.Condition*100)
+	return PreflightCheck{Name: "condition", Passed: true, Detail: detail}
+}
+
+// checkCooldown fails when the ship's cooldown would still be running on arrival, blocking
+// extraction, surveys, scans and jumps at the destination
+func checkCooldown(ship *client.Ship, now, arrival time.Time) PreflightCheck {
+	expiration := now.Add(ship.CooldownRemaining(now))
+	switch {
+	case !expiration.After(now):
+		return PreflightCheck{Name: "cooldown", Passed: true, Detail: "No active cooldown"}
+	case expiration.After(arrival):
+		detail := fmt.Sprintf("Cooldown runs another %s, %s past arrival", expiration.Sub(now).Round(time.Second), expiration.Sub(arrival).Round(time.Second))
+		return PreflightCheck{Name: "cooldown", Detail: detail}
+	}
+	detail := fmt.Sprintf("Cooldown of %s ends before arrival", expiration.Sub(now).Round(time.Second))
+	return PreflightCheck{Name: "cooldown", Passed: true, Detail: detail}
+}
+
+// checkContracts fails when an accepted contract the trip serves - one delivering to the
+// destination or needing goods in the hold - is due before the ship would arrive
+func checkContracts(ship *client.Ship, contracts []client.Contract, destination string, arrival time.Time) PreflightCheck {
+	aboard := make(map[string]bool)
+	for _, item := range ship.Cargo.Inventory {
+		aboard[item.Symbol] = true
+	}
+
+	var late, onTime []string
+	for _, contract := range contracts {
+		if !contract.Accepted || contract.Fulfilled {
+			continue
+		}
+		relevant := false
+		for _, good := range contract.Terms.Deliver {
+			if good.UnitsFulfilled < good.UnitsRequired && (good.DestinationSymbol == destination || aboard[good.TradeSymbol]) {
+				relevant = true
+			}
+		}
+		if !relevant {
+			continue
+		}
+		deadline, err := time.Parse(time.RFC3339, contract.Terms.Deadline)
+		if err != nil {
+			continue
+		}
+		if arrival.After(deadline) {
+			late = append(late, fmt.Sprintf("%s is due %s before arrival", contract.ID, arrival.Sub(deadline).Round(time.Minute)))
+		} else {
+			onTime = append(onTime, fmt.Sprintf("%s with %s to spare", contract.ID, deadline.Sub(arrival).Round(time.Minute)))
+		}
+	}
+
+	switch {
+	case len(late) > 0:
+		return PreflightCheck{Name: "contracts", Detail: strings.Join(late, "; ") + "; pick a faster flight mode or let it lapse"}
+	case len(onTime) > 0:
+		return PreflightCheck{Name: "contracts", Passed: true, Detail: "Arrives in time for " + strings.Join(onTime, ", ")}
+	}
+	return PreflightCheck{Name: "contracts", Passed: true, Detail: "No accepted contract depends on this trip"}
+}
@@ -0,0 +1,122 @@
+package navigation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestPreflightCheckTool_Handler(t *testing.T) {
+	cooldown := time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339)
+	deadline := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected only read-only requests, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/SHIP_1":
+			_, _ = fmt.Fprintf(w, `{"data": {"symbol": "SHIP_1",
+				"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_ORBIT", "flightMode": "CRUISE"},
+				"frame": {"condition": 0.9}, "reactor": {"condition": 0.3}, "engine": {"speed": 30, "condition": 1},
+				"cooldown": {"shipSymbol": "SHIP_1", "totalSeconds": 600, "remainingSeconds": 600, "expiration": %q},
+				"cargo": {"capacity": 40, "units": 30, "inventory": [{"symbol": "IRON_ORE", "units": 30}]},
+				"fuel": {"current": 80, "capacity": 400}}}`, cooldown)
+		case "/systems/X1-TEST":
+			_, _ = w.Write([]byte(testSystemJSON))
+		case "/my/contracts":
+			_, _ = fmt.Fprintf(w, `{"data": [{"id": "CONTRACT-1", "factionSymbol": "COSMIC", "type": "PROCUREMENT", "accepted": true, "fulfilled": false,
+				"expiration": %q, "terms": {"deadline": %q, "payment": {"onAccepted": 1000, "onFulfilled": 9000},
+				"deliver": [{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-C3", "unitsRequired": 50, "unitsFulfilled": 10}]}}],
+				"meta": {"total": 1, "page": 1, "limit": 20}}`, deadline, deadline)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewPreflightCheckTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "preflight_check",
+			Arguments: map[string]interface{}{
+				"ship_symbol": "ship_1",
+				"destination": "X1-TEST-B2",
+				"cargo_units": float64(20),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected a checklist, got error: %v", result.Content)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, expected := range []string{
+		"**Trip:** 100 fuel, 1m38s",
+		"❌ **fuel:** Needs 100 fuel in CRUISE but has 80/400; refuel first",
+		"❌ **cargo:** Needs 20 units free but has 10/40",
+		"❌ **condition:** Below 50%: reactor 30%",
+		"❌ **cooldown:** Cooldown runs another",
+		"❌ **contracts:** CONTRACT-1 is due",
+		"✅ **status:** IN_ORBIT at X1-TEST-A1",
+		"Not cleared for departure",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected summary to contain %q, got: %s", expected, text)
+		}
+	}
+}
+
+func TestPreflightCheck_Passes(t *testing.T) {
+	now := time.Now()
+	ship := &client.Ship{
+		Nav:     client.Navigation{WaypointSymbol: "X1-TEST-A1", Status: "DOCKED"},
+		Frame:   client.Frame{Condition: 1},
+		Reactor: client.Reactor{Condition: 1},
+		Engine:  client.Engine{Condition: 0.8},
+		Cargo:   client.Cargo{Capacity: 40},
+		Fuel:    client.Fuel{Current: 400, Capacity: 400},
+		Cooldown: client.Cooldown{
+			RemainingSeconds: 30,
+		},
+	}
+	arrival := now.Add(2 * time.Minute)
+	contracts := []client.Contract{{
+		ID:       "CONTRACT-2",
+		Accepted: true,
+		Terms: client.ContractTerms{
+			Deadline: now.Add(time.Hour).UTC().Format(time.RFC3339),
+			Deliver:  []client.ContractDeliverGood{{TradeSymbol: "COPPER", DestinationSymbol: "X1-TEST-B2", UnitsRequired: 10}},
+		},
+	}}
+
+	checks := []PreflightCheck{
+		checkInTransit(ship, now),
+		checkFuel(ship, "BURN", 200),
+		checkCargo(ship, 40),
+		checkCondition(ship, 80),
+		checkCooldown(ship, now, arrival),
+		checkContracts(ship, contracts, "X1-TEST-B2", arrival),
+	}
+	for _, check := range checks {
+		if !check.Passed {
+			t.Errorf("Expected %s to pass, got %q", check.Name, check.Detail)
+		}
+	}
+	if detail := checks[5].Detail; !strings.Contains(detail, "Arrives in time for CONTRACT-2") {
+		t.Errorf("Expected the contract to be on time, got %q", detail)
+	}
+}
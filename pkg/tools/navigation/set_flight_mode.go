@@ -6,29 +6,31 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// PatchNavTool handles changing ship navigation settings
-type PatchNavTool struct {
+// SetFlightModeTool changes the flight mode a ship uses for its next trips
+type SetFlightModeTool struct {
 	client *client.Client
 	logger *logging.Logger
 }
 
-// NewPatchNavTool creates a new patch nav tool
-func NewPatchNavTool(client *client.Client, logger *logging.Logger) *PatchNavTool {
-	return &PatchNavTool{
+// NewSetFlightModeTool creates a new flight mode tool
+func NewSetFlightModeTool(client *client.Client, logger *logging.Logger) *SetFlightModeTool {
+	return &SetFlightModeTool{
 		client: client,
 		logger: logger,
 	}
 }
 
 // Tool returns the MCP tool definition
-func (t *PatchNavTool) Tool() mcp.Tool {
+func (t *SetFlightModeTool) Tool() mcp.Tool {
 	return mcp.Tool{
-		Name:        "patch_ship_nav",
-		Description: "Change a ship's navigation settings, particularly the flight mode. Available flight modes: DRIFT, STEALTH, CRUISE, BURN.",
+		Name:        "set_flight_mode",
+		Description: "Change the flight mode a ship uses for navigate_ship and warp_ship. Available flight modes: DRIFT, STEALTH, CRUISE, BURN. Reports the ship's fuel and what its current or last route costs in the new mode.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -39,78 +41,39 @@ func (t *PatchNavTool) Tool() mcp.Tool {
 				"flight_mode": map[string]interface{}{
 					"type":        "string",
 					"description": "Flight mode to set. Options: DRIFT (slowest, most fuel efficient), STEALTH (slow, hard to detect), CRUISE (balanced), BURN (fastest, most fuel consumption)",
-					"enum":        []string{"DRIFT", "STEALTH", "CRUISE", "BURN"},
+					"enum":        travel.FlightModes,
 				},
 			},
 			Required: []string{"ship_symbol", "flight_mode"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":     map[string]interface{}{"type": "boolean"},
+			"ship_symbol": map[string]interface{}{"type": "string"},
+			"navigation":  map[string]interface{}{"type": "object", "description": "Ship location, status and flight mode after the action"},
+			"route":       map[string]interface{}{"type": "object", "description": "Route of the ship, when it has one"},
+			"fuel":        map[string]interface{}{"type": "object", "description": "Fuel in the tank, when the ship could be fetched"},
+			"route_cost":  map[string]interface{}{"type": "object", "description": "Fuel and travel time of the route in the new flight mode"},
+		}, "success", "ship_symbol", "navigation"),
 	}
 }
 
 // Handler returns the tool handler function
-func (t *PatchNavTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (t *SetFlightModeTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		contextLogger := t.logger.WithContext(ctx, "patch-nav-tool")
-
-		// Extract ship symbol and flight mode
-		var shipSymbol string
-		var flightMode string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						shipSymbol = s
-					}
-				}
-				if val, exists := argsMap["flight_mode"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						flightMode = s
-					}
-				}
-			}
-		}
-
-		if shipSymbol == "" {
-			contextLogger.Error("Missing or invalid ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if flightMode == "" {
-			contextLogger.Error("Missing or invalid flight_mode parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: flight_mode parameter is required and must be one of: DRIFT, STEALTH, CRUISE, BURN"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		// Validate flight mode
-		validModes := map[string]bool{
-			"DRIFT":   true,
-			"STEALTH": true,
-			"CRUISE":  true,
-			"BURN":    true,
-		}
-		if !validModes[flightMode] {
-			contextLogger.Error(fmt.Sprintf("Invalid flight mode: %s", flightMode))
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent(fmt.Sprintf("Error: Invalid flight mode '%s'. Must be one of: DRIFT, STEALTH, CRUISE, BURN", flightMode)),
-				},
-				IsError: true,
-			}, nil
+		contextLogger := t.logger.WithContext(ctx, "set-flight-mode-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		flightMode := v.RequireSymbol("flight_mode", utils.FlightModes)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		contextLogger.Info(fmt.Sprintf("Attempting to change flight mode for ship %s to %s", shipSymbol, flightMode))
 
 		// Patch the ship's navigation
-		nav, err := t.client.PatchShipNav(shipSymbol, flightMode)
+		nav, err := t.client.WithContext(ctx).PatchShipNav(shipSymbol, flightMode)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to patch nav for ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -121,7 +84,7 @@ func (t *PatchNavTool) Handler() func(ctx context.Context, request mcp.CallToolR
 			}, nil
 		}
 
-		contextLogger.ToolCall("patch_ship_nav", true)
+		contextLogger.ToolCall("set_flight_mode", true)
 		contextLogger.Info(fmt.Sprintf("Successfully changed flight mode for ship %s to %s", shipSymbol, flightMode))
 
 		// Create structured response
@@ -156,12 +119,35 @@ func (t *PatchNavTool) Handler() func(ctx context.Context, request mcp.CallToolR
 			}
 		}
 
+		// Fuel and engine speed aren't part of the nav response, so they come from the ship itself
+		var ship *client.Ship
+		if fetched, err := t.client.WithContext(ctx).GetShip(shipSymbol); err != nil {
+			contextLogger.Warn("Failed to fetch ship %s for fuel reporting: %v", shipSymbol, err)
+		} else {
+			ship = fetched
+			result["fuel"] = map[string]interface{}{
+				"current":  ship.Fuel.Current,
+				"capacity": ship.Fuel.Capacity,
+			}
+		}
+
+		var routeCost *travel.Estimate
+		if ship != nil && nav.Data.Route.Destination.Symbol != "" && nav.Data.Route.Origin.Symbol != nav.Data.Route.Destination.Symbol {
+			origin, destination := nav.Data.Route.Origin, nav.Data.Route.Destination
+			distance := travel.Distance(origin.X, origin.Y, destination.X, destination.Y)
+			routeCost = &travel.Estimates(distance, []string{flightMode}, ship.Engine.Speed)[0]
+			result["route_cost"] = routeCost
+		}
+
 		// Create text summary with flight mode descriptions
 		textSummary := "## Flight Mode Updated\n\n"
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Flight Mode:** %s\n", nav.Data.FlightMode)
 		textSummary += fmt.Sprintf("**Status:** %s\n", nav.Data.Status)
 		textSummary += fmt.Sprintf("**Location:** %s (%s)\n", nav.Data.WaypointSymbol, nav.Data.SystemSymbol)
+		if ship != nil {
+			textSummary += fmt.Sprintf("**Fuel:** %d/%d units\n", ship.Fuel.Current, ship.Fuel.Capacity)
+		}
 
 		// Add flight mode description
 		modeDescriptions := map[string]string{
@@ -180,6 +166,12 @@ func (t *PatchNavTool) Handler() func(ctx context.Context, request mcp.CallToolR
 			textSummary += fmt.Sprintf("- To: %s (%s)\n", nav.Data.Route.Destination.Symbol, nav.Data.Route.Destination.Type)
 			textSummary += fmt.Sprintf("- Departure: %s\n", nav.Data.Route.DepartureTime)
 			textSummary += fmt.Sprintf("- Arrival: %s\n", nav.Data.Route.Arrival)
+			if routeCost != nil {
+				textSummary += fmt.Sprintf("- In %s this trip costs %d fuel and takes %s\n", flightMode, routeCost.FuelCost, routeCost.TravelTime)
+				if ship.Fuel.Capacity > 0 && routeCost.FuelCost > ship.Fuel.Current {
+					textSummary += fmt.Sprintf("- ⚠️ That is more than the %d fuel in the tank\n", ship.Fuel.Current)
+				}
+			}
 			textSummary += "\n**Note:** The arrival time may have changed due to the flight mode change.\n"
 		}
 
@@ -189,11 +181,6 @@ func (t *PatchNavTool) Handler() func(ctx context.Context, request mcp.CallToolR
 		textSummary += "- **CRUISE:** 100% speed, 1x fuel consumption (default)\n"
 		textSummary += "- **BURN:** 200% speed, 2x fuel consumption\n"
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%+v\n```", result)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
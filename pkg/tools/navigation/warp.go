@@ -7,14 +7,20 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // WarpShipTool handles warping ships to waypoints
 type WarpShipTool struct {
-	client *client.Client
-	logger *logging.Logger
+	client           *client.Client
+	logger           *logging.Logger
+	policy           *policy.Policy
+	autoRefuel       bool
+	autoCorrectState bool
 }
 
 // NewWarpShipTool creates a new warp ship tool
@@ -25,6 +31,24 @@ func NewWarpShipTool(client *client.Client, logger *logging.Logger) *WarpShipToo
 	}
 }
 
+// WithAutoRefuel sets whether the tool refuels before departing when auto_refuel is not specified
+func (t *WarpShipTool) WithAutoRefuel(enabled bool) *WarpShipTool {
+	t.autoRefuel = enabled
+	return t
+}
+
+// WithPolicy makes auto-refueling refuse fuel bills that break the spending policy
+func (t *WarpShipTool) WithPolicy(p *policy.Policy) *WarpShipTool {
+	t.policy = p
+	return t
+}
+
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *WarpShipTool) WithAutoCorrectState(enabled bool) *WarpShipTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *WarpShipTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -41,9 +65,22 @@ func (t *WarpShipTool) Tool() mcp.Tool {
 					"type":        "string",
 					"description": "Symbol of the destination waypoint in another system (e.g., 'X1-AB12-34567Z')",
 				},
+				"auto_refuel":        autoRefuelProperty(t.autoRefuel),
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol", "waypoint_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"navigation":       map[string]interface{}{"type": "object", "description": "Ship location, status and flight mode after the action"},
+			"fuel":             map[string]interface{}{"type": "object", "description": "Fuel after departure"},
+			"state_correction": map[string]interface{}{"type": "string", "description": "Ship state fixed up before warping, if any"},
+			"route":            map[string]interface{}{"type": "object", "description": "Departure, destination and arrival time"},
+			"fuel_consumed":    map[string]interface{}{"type": "object"},
+			"event":            map[string]interface{}{"type": "object", "description": "Event encountered on departure, if any"},
+			"auto_refuel":      map[string]interface{}{"type": "object", "description": "Refuel made before departure, if any"},
+		}, "success", "ship_symbol", "navigation", "fuel"),
 	}
 }
 
@@ -52,48 +89,60 @@ func (t *WarpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "warp-ship-tool")
 
-		// Extract ship symbol and waypoint symbol
-		var shipSymbol string
-		var waypointSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						shipSymbol = s
-					}
-				}
-				if val, exists := argsMap["waypoint_symbol"]; exists {
-					if s, ok := val.(string); ok && s != "" {
-						waypointSymbol = s
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		waypointSymbol := v.RequireWaypoint("waypoint_symbol")
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		autoRefuel := v.OptionalBool("auto_refuel", t.autoRefuel)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
-		if shipSymbol == "" {
-			contextLogger.Error("Missing or invalid ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
+		contextLogger.Info(fmt.Sprintf("Attempting to warp ship %s to %s", shipSymbol, waypointSymbol))
 
-		if waypointSymbol == "" {
-			contextLogger.Error("Missing or invalid waypoint_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: waypoint_symbol parameter is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusInOrbit)
+			if err != nil {
+				contextLogger.Error("Failed to orbit ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to orbit ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
 		}
 
-		contextLogger.Info(fmt.Sprintf("Attempting to warp ship %s to %s", shipSymbol, waypointSymbol))
+		// Top up fuel first if requested and the trip needs more than the ship has
+		var refuel *refuelOutcome
+		if autoRefuel {
+			ship, err := t.client.WithContext(ctx).GetShip(shipSymbol)
+			if err == nil {
+				var distance float64
+				if distance, _, err = travel.RouteDistance(t.client.WithContext(ctx), ship.Nav.WaypointSymbol, waypointSymbol); err == nil {
+					refuel, err = ensureFuel(t.client.WithContext(ctx), t.policy, ship, distance)
+				}
+			}
+			if err != nil {
+				contextLogger.Error(fmt.Sprintf("Auto-refuel check failed for ship %s: %v", shipSymbol, err))
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("Auto-refuel failed for ship %s: %v", shipSymbol, err)),
+					},
+					IsError: true,
+				}, nil
+			}
+			if refuel.Refueled {
+				contextLogger.Info(fmt.Sprintf("Auto-refueled ship %s with %d units for %d credits", shipSymbol, refuel.UnitsBought, refuel.TotalPrice))
+			}
+		}
 
 		// Warp the ship
-		resp, err := t.client.WarpShip(shipSymbol, waypointSymbol)
+		resp, err := t.client.WithContext(ctx).WarpShip(shipSymbol, waypointSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to warp ship %s to %s: %v", shipSymbol, waypointSymbol, err))
 			return &mcp.CallToolResult{
@@ -122,6 +171,9 @@ func (t *WarpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 				"capacity": resp.Data.Fuel.Capacity,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
 
 		// Add route information
 		if resp.Data.Nav.Route.Destination.Symbol != "" {
@@ -144,7 +196,7 @@ func (t *WarpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 		}
 
 		// Add fuel consumption information if available
-		if resp.Data.Fuel.Consumed.Amount > 0 {
+		if resp.Data.Fuel.Consumed != nil && resp.Data.Fuel.Consumed.Amount > 0 {
 			result["fuel_consumed"] = map[string]interface{}{
 				"amount":    resp.Data.Fuel.Consumed.Amount,
 				"timestamp": resp.Data.Fuel.Consumed.Timestamp,
@@ -163,6 +215,9 @@ func (t *WarpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 
 		// Create text summary
 		textSummary := "## Ship Warp Initiated\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Status:** %s\n", resp.Data.Nav.Status)
 		textSummary += fmt.Sprintf("**Current Location:** %s (%s)\n", resp.Data.Nav.WaypointSymbol, resp.Data.Nav.SystemSymbol)
@@ -189,13 +244,24 @@ func (t *WarpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 			}
 		}
 
-		if resp.Data.Fuel.Consumed.Amount > 0 {
+		if resp.Data.Fuel.Consumed != nil && resp.Data.Fuel.Consumed.Amount > 0 {
 			textSummary += "\n**Fuel Consumption:**\n"
 			textSummary += fmt.Sprintf("- **Amount Used:** %d units\n", resp.Data.Fuel.Consumed.Amount)
 			textSummary += fmt.Sprintf("- **Remaining:** %d units\n", resp.Data.Fuel.Current)
 			textSummary += "- **Efficiency:** Warp drives consume significant fuel for inter-system travel\n"
 		}
 
+		if refuel != nil {
+			result["auto_refuel"] = refuel
+			if refuel.Refueled {
+				textSummary += "\n**Auto-Refuel:**\n"
+				textSummary += fmt.Sprintf("- **Bought:** %d units of fuel for %d credits before departing\n", refuel.UnitsBought, refuel.TotalPrice)
+				textSummary += fmt.Sprintf("- **Route Needed:** %d fuel (had %d)\n", refuel.FuelRequired, refuel.FuelBefore)
+			} else if refuel.Note != "" {
+				textSummary += fmt.Sprintf("\n**Auto-Refuel:** %s\n", refuel.Note)
+			}
+		}
+
 		if resp.Data.Event.Symbol != "" {
 			textSummary += "\n**Warp Event:**\n"
 			textSummary += fmt.Sprintf("- **Event:** %s\n", resp.Data.Event.Name)
@@ -216,11 +282,6 @@ func (t *WarpShipTool) Handler() func(ctx context.Context, request mcp.CallToolR
 		textSummary += "- Ships must have a functional warp drive installed\n"
 		textSummary += "- Ships must be in orbit before initiating warp\n"
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%+v\n```", result)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withRateLimitNote adds the API's rate-limit state to a tool's error result when the API
+// refused one of its requests with 429, so the caller knows how long to back off
+func withRateLimitNote(c *client.Client, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+		if err != nil || result == nil || !result.IsError {
+			return result, err
+		}
+
+		status, throttled := c.ThrottledSince(start)
+		if !throttled {
+			return result, nil
+		}
+		result.Content = append(result.Content, mcp.NewTextContent("⏳ "+status.Describe()))
+		return result, nil
+	}
+}
@@ -3,7 +3,21 @@ package tools
 import (
 	"context"
 	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/credits"
+	"spacetraders-mcp/pkg/explorer"
+	"spacetraders-mcp/pkg/ledger"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/shiplock"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/shipwatch"
+	"spacetraders-mcp/pkg/snapshot"
+	"spacetraders-mcp/pkg/stations"
+	"spacetraders-mcp/pkg/tasks"
+	"spacetraders-mcp/pkg/tools/automation"
 	"spacetraders-mcp/pkg/tools/contract"
 	"spacetraders-mcp/pkg/tools/exploration"
 	"spacetraders-mcp/pkg/tools/info"
@@ -21,21 +35,160 @@ type ToolHandler interface {
 	Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
 
+// Option configures optional subsystems used by tools
+type Option func(*Registry)
+
+// WithLedger enables tools backed by the transactions ledger
+func WithLedger(l *ledger.Ledger) Option {
+	return func(r *Registry) {
+		r.ledger = l
+	}
+}
+
+// WithTasks enables tools that assign and cancel background tasks
+func WithTasks(m *tasks.Manager) Option {
+	return func(r *Registry) {
+		r.tasks = m
+	}
+}
+
+// WithExplorer enables tools backed by the exploration tracker
+func WithExplorer(t *explorer.Tracker) Option {
+	return func(r *Registry) {
+		r.explorer = t
+	}
+}
+
+// WithStations enables tools that station probes at markets and shipyards
+func WithStations(p *stations.Poller) Option {
+	return func(r *Registry) {
+		r.stations = p
+	}
+}
+
+// WithPrices enables tools backed by the market price database
+func WithPrices(db *prices.DB) Option {
+	return func(r *Registry) {
+		r.prices = db
+	}
+}
+
+// WithShipWatch enables the tools that watch shipyard prices
+func WithShipWatch(w *shipwatch.Watcher) Option {
+	return func(r *Registry) {
+		r.shipWatch = w
+	}
+}
+
+// WithMining enables tools backed by the extraction recorder
+func WithMining(m *mining.Recorder) Option {
+	return func(r *Registry) {
+		r.mining = m
+	}
+}
+
+// WithCooldowns makes scan tools refuse to scan while a ship is known to be on cooldown
+func WithCooldowns(t *cooldowns.Tracker) Option {
+	return func(r *Registry) {
+		r.cooldowns = t
+	}
+}
+
+// WithShipMeta enables tools that label, tag and group ships into squadrons
+func WithShipMeta(s *shipmeta.Store) Option {
+	return func(r *Registry) {
+		r.shipMeta = s
+	}
+}
+
+// WithAutoRefuel makes navigation tools refuel before departing by default
+func WithAutoRefuel(enabled bool) Option {
+	return func(r *Registry) {
+		r.autoRefuel = enabled
+	}
+}
+
+// WithAutoCorrectState makes action tools dock or orbit the ship first by default when the
+// action needs a different nav status
+func WithAutoCorrectState(enabled bool) Option {
+	return func(r *Registry) {
+		r.autoCorrectState = enabled
+	}
+}
+
+// WithConfirmSpendAbove makes purchase tools ask the user to confirm, through MCP elicitation,
+// any purchase costing more than credits; 0 never asks
+func WithConfirmSpendAbove(credits int) Option {
+	return func(r *Registry) {
+		r.confirmSpendAbove = credits
+	}
+}
+
+// WithPolicy makes purchase tools refuse purchases that break the spending policy
+func WithPolicy(p *policy.Policy) Option {
+	return func(r *Registry) {
+		r.policy = p
+	}
+}
+
+// WithShipLocks makes tools that change a ship's state wait for any other action on the same
+// ship, including background task steps, to finish first
+func WithShipLocks(l *shiplock.Locks) Option {
+	return func(r *Registry) {
+		r.shipLocks = l
+	}
+}
+
+// WithCredits enables the credits trend tool, backed by the history of the agent's balance
+func WithCredits(h *credits.History) Option {
+	return func(r *Registry) {
+		r.credits = h
+	}
+}
+
+// WithExportDir enables the export_data tool, writing files under dir
+func WithExportDir(dir string) Option {
+	return func(r *Registry) {
+		r.exportDir = dir
+	}
+}
+
 // Registry manages all MCP tools
 type Registry struct {
-	client   *client.Client
-	logger   *logging.Logger
-	handlers []ToolHandler
+	client    *client.Client
+	logger    *logging.Logger
+	ledger    *ledger.Ledger
+	tasks     *tasks.Manager
+	explorer  *explorer.Tracker
+	stations  *stations.Poller
+	prices    *prices.DB
+	shipWatch *shipwatch.Watcher
+	mining    *mining.Recorder
+	policy    *policy.Policy
+	shipMeta  *shipmeta.Store
+	cooldowns *cooldowns.Tracker
+	shipLocks *shiplock.Locks
+	credits   *credits.History
+	handlers  []ToolHandler
+
+	autoRefuel        bool
+	autoCorrectState  bool
+	confirmSpendAbove int
+	exportDir         string
 }
 
 // NewRegistry creates a new tool registry
-func NewRegistry(client *client.Client, logger *logging.Logger) *Registry {
+func NewRegistry(client *client.Client, logger *logging.Logger, opts ...Option) *Registry {
 	registry := &Registry{
 		client:   client,
 		logger:   logger,
 		handlers: make([]ToolHandler, 0),
 	}
 
+	for _, opt := range opts {
+		opt(registry)
+	}
+
 	// Register all available tools
 	registry.registerTools()
 
@@ -45,85 +198,211 @@ func NewRegistry(client *client.Client, logger *logging.Logger) *Registry {
 // registerTools registers all available tool handlers
 func (r *Registry) registerTools() {
 	// Register AcceptContract tool
-	r.handlers = append(r.handlers, contract.NewAcceptContractTool(r.client))
+	r.register(idempotent, contract.NewAcceptContractTool(r.client))
 
 	// Register Status Summary tool
-	r.handlers = append(r.handlers, status.NewStatusTool(r.client, r.logger))
+	r.register(readOnly, status.NewStatusTool(r.client, r.logger))
+
+	// Register Ping tool
+	r.register(readOnly, status.NewPingTool(r.client, r.logger))
+
+	// Register Server Info tool
+	r.register(readOnly, status.NewServerInfoTool(r.client, r.logger))
+
+	// Register Wait tool
+	r.register(readOnly, status.NewWaitTool(r.client, r.logger).WithCooldowns(r.cooldowns))
 
 	// Register Contract Info tool
-	r.handlers = append(r.handlers, info.NewContractInfoTool(r.client, r.logger))
+	r.register(readOnly, info.NewContractInfoTool(r.client, r.logger))
 
 	// Register Fleet Analysis tool
-	r.handlers = append(r.handlers, info.NewFleetAnalysisTool(r.client, r.logger))
+	r.register(readOnly, info.NewFleetAnalysisTool(r.client, r.logger))
+
+	// Register Fleet Audit tool
+	r.register(readOnly, info.NewFleetAuditTool(r.client, r.logger))
+
+	// Register Ship Purchase Advisor tool
+	r.register(readOnly, info.NewRecommendShipTool(r.client, r.logger))
+
+	// Register Evaluate Contracts tool
+	r.register(readOnly, info.NewEvaluateContractsTool(r.client, r.ledger, r.logger))
+
+	// Register Contract Projection tool
+	r.register(readOnly, info.NewProjectContractTool(r.client, r.ledger, r.logger).WithTasks(r.tasks).WithMining(r.mining))
+
+	// Register Idle Ships tool
+	r.register(readOnly, info.NewIdleShipsTool(r.client, r.tasks, r.logger))
+
+	// Register Refresh Ship tool
+	r.register(readOnly, ships.NewRefreshShipTool(r.client, r.logger))
 
 	// Register Ship Purchase tool
-	r.handlers = append(r.handlers, ships.NewPurchaseShipTool(r.client, r.logger))
+	r.register(action, ships.NewPurchaseShipTool(r.client, r.logger).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
+
+	// Register Ship Provisioning tool
+	r.register(action, ships.NewProvisionShipTool(r.client, r.logger).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
 
 	// Register Refuel Ship tool
-	r.handlers = append(r.handlers, ships.NewRefuelShipTool(r.client, r.logger))
+	r.register(action, ships.NewRefuelShipTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
 
 	// Register Extract Resources tool
-	r.handlers = append(r.handlers, ships.NewExtractResourcesTool(r.client, r.logger))
+	r.register(action, ships.NewExtractResourcesTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState))
 
 	// Register Jettison Cargo tool
-	r.handlers = append(r.handlers, ships.NewJettisonCargoTool(r.client, r.logger))
+	r.register(destructive, ships.NewJettisonCargoTool(r.client, r.logger))
+	r.register(destructive, ships.NewCleanCargoTool(r.client, r.logger))
 
 	// Register Navigation tools
-	r.handlers = append(r.handlers, navigation.NewOrbitShipTool(r.client, r.logger))
-	r.handlers = append(r.handlers, navigation.NewDockShipTool(r.client, r.logger))
-	r.handlers = append(r.handlers, navigation.NewNavigateShipTool(r.client, r.logger))
-	r.handlers = append(r.handlers, navigation.NewPatchNavTool(r.client, r.logger))
-	r.handlers = append(r.handlers, navigation.NewWarpShipTool(r.client, r.logger))
-	r.handlers = append(r.handlers, navigation.NewJumpShipTool(r.client, r.logger))
+	r.register(idempotent, navigation.NewOrbitShipTool(r.client, r.logger))
+	r.register(idempotent, navigation.NewDockShipTool(r.client, r.logger))
+	r.register(action, navigation.NewNavigateShipTool(r.client, r.logger).WithPolicy(r.policy).WithAutoRefuel(r.autoRefuel).WithAutoCorrectState(r.autoCorrectState))
+	r.register(idempotent, navigation.NewSetFlightModeTool(r.client, r.logger))
+	r.register(idempotent, navigation.NewChooseFlightModeTool(r.client, r.logger))
+	r.register(action, navigation.NewWarpShipTool(r.client, r.logger).WithPolicy(r.policy).WithAutoRefuel(r.autoRefuel).WithAutoCorrectState(r.autoCorrectState))
+	r.register(action, navigation.NewJumpShipTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState))
+	r.register(readOnly, navigation.NewEstimateTravelTool(r.client, r.logger))
+	r.register(readOnly, navigation.NewPreflightCheckTool(r.client, r.logger))
+	r.register(readOnly, navigation.NewFindNearestTool(r.client, r.logger))
+	r.register(readOnly, navigation.NewGatePathTool(r.client, r.logger))
+	r.register(readOnly, navigation.NewPlanRouteTool(r.client, r.logger).WithExplorer(r.explorer).WithPrices(r.prices))
 
 	// Register Exploration tools
-	r.handlers = append(r.handlers, exploration.NewFindWaypointsTool(r.client, r.logger))
-	r.handlers = append(r.handlers, exploration.NewSystemOverviewTool(r.client, r.logger))
-	r.handlers = append(r.handlers, exploration.NewCurrentLocationTool(r.client, r.logger))
+	r.register(readOnly, exploration.NewFindWaypointsTool(r.client, r.logger))
+	r.register(readOnly, exploration.NewFindByCapabilityTool(r.client, r.logger))
+	r.register(readOnly, exploration.NewSystemOverviewTool(r.client, r.logger))
+	r.register(readOnly, exploration.NewHomeSystemTool(r.client, r.logger))
+	r.register(readOnly, exploration.NewCurrentLocationTool(r.client, r.logger))
 
 	// Register Sell Cargo tool
-	r.handlers = append(r.handlers, ships.NewSellCargoTool(r.client, r.logger))
+	r.register(action, ships.NewSellCargoTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState))
+
+	// Register Sell All Cargo tool
+	r.register(action, ships.NewSellAllCargoTool(r.client, r.logger))
 
 	// Register Buy Cargo tool
-	r.handlers = append(r.handlers, ships.NewBuyCargoTool(r.client, r.logger))
+	r.register(action, ships.NewBuyCargoTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
+
+	// Register Buy Cargo Max tool
+	r.register(action, ships.NewBuyCargoMaxTool(r.client, r.logger).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
+
+	// Register Simulate Trade tool
+	r.register(readOnly, ships.NewSimulateTradeTool(r.client, r.logger).WithPrices(r.prices).WithLedger(r.ledger))
 
 	// Register Deliver Contract tool
-	r.handlers = append(r.handlers, contract.NewDeliverContractTool(r.client, r.logger))
+	r.register(action, contract.NewDeliverContractTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState))
 
 	// Register Fulfill Contract tool
-	r.handlers = append(r.handlers, contract.NewFulfillContractTool(r.client, r.logger))
+	r.register(idempotent, contract.NewFulfillContractTool(r.client, r.logger))
 
 	// Register Scan tools
-	r.handlers = append(r.handlers, exploration.NewScanSystemsTool(r.client, r.logger))
-	r.handlers = append(r.handlers, exploration.NewScanWaypointsTool(r.client, r.logger))
-	r.handlers = append(r.handlers, exploration.NewScanShipsTool(r.client, r.logger))
+	r.register(action, exploration.NewScanSystemsTool(r.client, r.logger).WithCooldowns(r.cooldowns))
+	r.register(action, exploration.NewScanWaypointsTool(r.client, r.logger).WithCooldowns(r.cooldowns))
+	r.register(action, exploration.NewScanShipsTool(r.client, r.logger).WithCooldowns(r.cooldowns))
 
 	// Register Repair Ship tool
-	r.handlers = append(r.handlers, ships.NewRepairShipTool(r.client, r.logger))
-
-	// TODO: Add more tool handlers here as we implement them:
-	// etc.
-	//
-	// IMPLEMENTED:
-	// - RefuelShip tool ✅
-	// - ExtractResources tool ✅
-	// - JettisonCargo tool ✅
-	// - SellCargo tool ✅
-	// - BuyCargo tool ✅
-	// - FulfillContract tool ✅
-	// - ScanSystems tool ✅
-	// - ScanWaypoints tool ✅
-	// - ScanShips tool ✅
-	// - RepairShip tool ✅
-}
-
-// RegisterWithServer registers all tools with the MCP server
+	r.register(readOnly, ships.NewGetRepairCostTool(r.client, r.logger))
+	r.register(action, ships.NewRepairShipTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
+
+	// Register Scrap tools
+	r.register(readOnly, ships.NewGetScrapValueTool(r.client, r.logger))
+	r.register(destructive, ships.NewScrapShipTool(r.client, r.logger).WithAutoCorrectState(r.autoCorrectState))
+
+	// Register Profit Report tool
+	if r.ledger != nil {
+		r.register(localReadOnly, info.NewProfitReportTool(r.ledger, r.logger))
+	}
+
+	// Register credits trend tool
+	if r.credits != nil {
+		r.register(localReadOnly, info.NewCreditsTrendTool(r.credits, r.logger))
+	}
+
+	// Register mining statistics tools
+	if r.mining != nil {
+		r.register(localReadOnly, info.NewMiningReportTool(r.mining, r.logger))
+	}
+
+	// Register data export and snapshot tools
+	if r.exportDir != "" {
+		r.register(localIdempotent, info.NewExportDataTool(r.client, r.exportDir, r.logger).
+			WithLedger(r.ledger).WithPrices(r.prices).WithMining(r.mining).WithCredits(r.credits))
+
+		state := snapshot.State{Prices: r.prices, Explorer: r.explorer, ShipMeta: r.shipMeta, Tasks: r.tasks}
+		r.register(localIdempotent, info.NewSaveSnapshotTool(state, r.exportDir, r.logger))
+		r.register(localDestructive, info.NewRestoreSnapshotTool(state, r.exportDir, r.logger))
+	}
+
+	// Register background task tools
+	if r.tasks != nil {
+		r.register(action, automation.NewAssignTaskTool(r.tasks, r.logger))
+		r.register(destructive, automation.NewCancelTaskTool(r.tasks, r.logger))
+		r.register(action, automation.NewStartTradeLoopTool(r.tasks, r.logger))
+		r.register(action, automation.NewScanMarketsTool(r.tasks, r.logger))
+		r.register(action, automation.NewRescueShipTool(r.client, r.tasks, r.logger))
+		r.register(action, automation.NewBootstrapAgentTool(r.client, r.tasks, r.logger).WithPolicy(r.policy).WithConfirmSpendAbove(r.confirmSpendAbove))
+	}
+
+	// Register probe station tools
+	if r.stations != nil {
+		r.register(action, automation.NewDeployProbeTool(r.client, r.stations, r.logger))
+	}
+
+	// Register price database tools
+	if r.prices != nil {
+		r.register(readOnly, info.NewWhereToTradeTool(r.client, r.prices, r.logger))
+		r.register(readOnly, info.NewSourceGoodsTool(r.client, r.prices, r.logger))
+		r.register(localReadOnly, info.NewBacktestRouteTool(r.prices, r.logger))
+		r.register(readOnly, info.NewOptimizeAssignmentsTool(r.client, r.prices, r.tasks, r.logger))
+	}
+
+	// Register shipyard price watch tools
+	if r.shipWatch != nil {
+		r.register(localIdempotent, automation.NewWatchShipPriceTool(r.shipWatch, r.logger))
+		r.register(localIdempotent, automation.NewUnwatchShipPriceTool(r.shipWatch, r.logger))
+		r.register(localReadOnly, info.NewShipPriceHistoryTool(r.shipWatch, r.logger))
+	}
+
+	// Register exploration tracker tools
+	if r.explorer != nil {
+		r.register(readOnly, exploration.NewSuggestTargetsTool(r.client, r.explorer, r.logger))
+		r.register(readOnly, exploration.NewSearchUniverseTool(r.client, r.explorer, r.logger))
+	}
+
+	// Register ship label, tag and squadron tools
+	if r.shipMeta != nil {
+		r.register(localIdempotent, ships.NewSetShipLabelTool(r.shipMeta, r.logger))
+		r.register(localIdempotent, ships.NewTagShipTool(r.shipMeta, r.logger))
+		r.register(localIdempotent, ships.NewCreateSquadronTool(r.shipMeta, r.logger))
+		r.register(localIdempotent, ships.NewDisbandSquadronTool(r.shipMeta, r.logger))
+		r.register(action, navigation.NewNavigateSquadronTool(r.client, r.shipMeta, r.logger).WithPolicy(r.policy).WithAutoRefuel(r.autoRefuel).WithAutoCorrectState(r.autoCorrectState))
+		if r.tasks != nil {
+			r.register(action, automation.NewAssignSquadronTaskTool(r.tasks, r.shipMeta, r.logger))
+		}
+	}
+}
+
+// RegisterWithServer registers all tools with the MCP server. Errors caused by the API's rate
+// limit get the current quota added, so the caller can slow down. Tools that change game state
+// take the locks of the ships they act on when ship locks are enabled.
 func (r *Registry) RegisterWithServer(s *server.MCPServer) {
 	for _, handler := range r.handlers {
-		s.AddTool(handler.Tool(), handler.Handler())
+		tool := handler.Tool()
+		next := server.ToolHandlerFunc(handler.Handler())
+		if r.shipLocks != nil && changesGameState(tool) {
+			next = withShipLock(r.shipLocks, r.shipMeta, tool.Name, next)
+		}
+		s.AddTool(tool, withRateLimitNote(r.client, next))
 	}
 }
 
+// changesGameState reports whether a tool's annotations say it acts on the game rather than
+// only reading it or changing what the server records
+func changesGameState(tool mcp.Tool) bool {
+	annotations := tool.Annotations
+	return annotations.ReadOnlyHint != nil && !*annotations.ReadOnlyHint &&
+		annotations.OpenWorldHint != nil && *annotations.OpenWorldHint
+}
+
 // GetTools returns all registered tools (useful for testing/debugging)
 func (r *Registry) GetTools() []mcp.Tool {
 	tools := make([]mcp.Tool, len(r.handlers))
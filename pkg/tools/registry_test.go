@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/mining"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/shiplock"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tasks"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newTestRegistry builds a registry with every optional tool group enabled
+func newTestRegistry() *Registry {
+	c := client.NewClient("test-token")
+	logger := logging.NewLogger(nil)
+	shipMeta, _ := shipmeta.Open("")
+	return NewRegistry(c, logger,
+		WithLedger(ledger.New()),
+		WithTasks(tasks.NewManager(context.Background(), c, logger)),
+		WithPrices(prices.New()),
+		WithMining(mining.NewRecorder()),
+		WithShipMeta(shipMeta),
+		WithExportDir(os.TempDir()),
+	)
+}
+
+func TestRegistry_EveryToolIsAnnotated(t *testing.T) {
+	registry := newTestRegistry()
+
+	byName := make(map[string]mcp.ToolAnnotation)
+	for _, tool := range registry.GetTools() {
+		hints := tool.Annotations
+		if hints.ReadOnlyHint == nil || hints.DestructiveHint == nil || hints.IdempotentHint == nil || hints.OpenWorldHint == nil {
+			t.Errorf("Expected every hint to be set for %s, got %+v", tool.Name, hints)
+			continue
+		}
+		if *hints.ReadOnlyHint && *hints.DestructiveHint {
+			t.Errorf("Expected %s not to be both read-only and destructive", tool.Name)
+		}
+		byName[tool.Name] = hints
+	}
+
+	for name, want := range map[string]struct{ destructive, idempotent, openWorld bool }{
+		"get_status_summary": {false, true, true},
+		"scrap_ship":         {true, false, true},
+		"jettison_cargo":     {true, false, true},
+		"navigate_ship":      {false, false, true},
+		"tag_ship":           {false, true, false},
+		"refuel_ship":        {false, false, true},
+		"repair_ship":        {false, false, true},
+		"restore_snapshot":   {true, false, false},
+	} {
+		hints, ok := byName[name]
+		if !ok {
+			t.Errorf("Expected %s to be registered", name)
+			continue
+		}
+		if *hints.DestructiveHint != want.destructive || *hints.IdempotentHint != want.idempotent || *hints.OpenWorldHint != want.openWorld {
+			t.Errorf("Expected %s destructive=%v idempotent=%v openWorld=%v, got %v %v %v", name,
+				want.destructive, want.idempotent, want.openWorld, *hints.DestructiveHint, *hints.IdempotentHint, *hints.OpenWorldHint)
+		}
+	}
+}
+
+func TestRegistry_EveryToolDeclaresOutputSchema(t *testing.T) {
+	for _, tool := range newTestRegistry().GetTools() {
+		schema := tool.OutputSchema
+		if schema.Type != "object" || len(schema.Properties) == 0 {
+			t.Errorf("Expected %s to declare an object output schema, got %+v", tool.Name, schema)
+			continue
+		}
+		for _, field := range schema.Required {
+			if _, ok := schema.Properties[field]; !ok {
+				t.Errorf("Expected required field %s of %s to be a declared property", field, tool.Name)
+			}
+		}
+	}
+}
+
+func TestWithRateLimitNote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		w.Header().Set("X-Ratelimit-Reset", "2025-01-01T00:00:01.000Z")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": {"message": "You have reached your API limit.", "code": 429}}`))
+	}))
+	defer server.Close()
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+
+	// A tool that fails the way every tool does, with the client's error in its text
+	failing := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, err := c.WithContext(ctx).GetAgent(); err != nil {
+			return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent(err.Error())}, IsError: true}, nil
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("ok")}}, nil
+	}
+
+	result, err := withRateLimitNote(c, failing)(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected the rate limit note after the error, got %+v", result.Content)
+	}
+	note := result.Content[1].(mcp.TextContent).Text
+	if !strings.Contains(note, "0 requests remaining") || !strings.Contains(note, "2025-01-01T00:00:01.000Z") {
+		t.Errorf("Expected the quota and reset time in the note, got %q", note)
+	}
+
+	// Errors that have nothing to do with the rate limit are left alone
+	unrelated := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("bad input")}, IsError: true}, nil
+	}
+	result, _ = withRateLimitNote(c, unrelated)(context.Background(), mcp.CallToolRequest{})
+	if len(result.Content) != 1 {
+		t.Errorf("Expected no note on an unrelated error, got %+v", result.Content)
+	}
+}
+
+func TestWithShipLock(t *testing.T) {
+	locks := shiplock.New()
+	ran := 0
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ran++
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("ok")}}, nil
+	}
+	call := func(ctx context.Context, args map[string]interface{}) *mcp.CallToolResult {
+		result, err := withShipLock(locks, nil, "orbit_ship", handler)(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "orbit_ship", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result
+	}
+
+	// A background task holds the ship, so the call waits and gives up when its context ends
+	unlock, _ := locks.Lock(context.Background(), "SHIP-1", "task-1")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if result := call(ctx, map[string]interface{}{"ship_symbol": "ship-1"}); !result.IsError || ran != 0 {
+		t.Fatalf("Expected the call to wait for the task and give up, got %+v", result.Content)
+	}
+	if text := call(ctx, map[string]interface{}{"ship_symbol": "ship-1"}).Content[0].(mcp.TextContent).Text; !strings.Contains(text, "busy with task-1") {
+		t.Errorf("Expected the error to name the task holding the ship, got %q", text)
+	}
+
+	// Other ships and calls naming no ship go ahead
+	if result := call(context.Background(), map[string]interface{}{"ship_symbol": "SHIP-2"}); result.IsError {
+		t.Errorf("Expected another ship to proceed, got %+v", result.Content)
+	}
+	if result := call(context.Background(), nil); result.IsError {
+		t.Errorf("Expected a call naming no ship to proceed, got %+v", result.Content)
+	}
+
+	unlock()
+	if result := call(context.Background(), map[string]interface{}{"ship_symbol": "SHIP-1"}); result.IsError {
+		t.Errorf("Expected the call to run once the ship is free, got %+v", result.Content)
+	}
+	if holder, _ := locks.Holder("SHIP-1"); holder != "" {
+		t.Errorf("Expected the call to release the ship, still held by %q", holder)
+	}
+}
+
+func TestWithShipLock_Squadron(t *testing.T) {
+	locks := shiplock.New()
+	store, _ := shipmeta.Open("")
+	if _, err := store.SaveSquadron("miners", []string{"SHIP-2", "SHIP-1"}); err != nil {
+		t.Fatalf("SaveSquadron returned error: %v", err)
+	}
+	var held []string
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		for _, shipSymbol := range []string{"SHIP-1", "SHIP-2"} {
+			if holder, _ := locks.Holder(shipSymbol); holder == "navigate_squadron" {
+				held = append(held, shipSymbol)
+			}
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("ok")}}, nil
+	}
+	call := func(ctx context.Context) *mcp.CallToolResult {
+		result, err := withShipLock(locks, store, "navigate_squadron", handler)(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "navigate_squadron", Arguments: map[string]interface{}{"squadron": "Miners"}},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result
+	}
+
+	// A task holding one member keeps the whole squadron waiting
+	unlock, _ := locks.Lock(context.Background(), "SHIP-2", "task-1")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if result := call(ctx); !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "busy with task-1") {
+		t.Fatalf("Expected the squadron to wait for the task and give up, got %+v", result.Content)
+	}
+	if holder, _ := locks.Holder("SHIP-1"); holder != "" {
+		t.Errorf("Expected the members already taken to be released, SHIP-1 held by %q", holder)
+	}
+
+	unlock()
+	if result := call(context.Background()); result.IsError {
+		t.Fatalf("Expected the call to run once the squadron is free, got %+v", result.Content)
+	}
+	if strings.Join(held, ",") != "SHIP-1,SHIP-2" {
+		t.Errorf("Expected every member held while the tool runs, got %v", held)
+	}
+	for _, shipSymbol := range []string{"SHIP-1", "SHIP-2"} {
+		if holder, _ := locks.Holder(shipSymbol); holder != "" {
+			t.Errorf("Expected %s released after the call, still held by %q", shipSymbol, holder)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"spacetraders-mcp/pkg/shiplock"
+	"spacetraders-mcp/pkg/shipmeta"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// shipArguments are the tool arguments naming the one ship a tool acts on
+var shipArguments = []string{"ship_symbol", "probe_ship"}
+
+// withShipLock makes a tool that acts on ships wait until no other tool call or background
+// task is acting on the same ships, so their API calls don't interleave. A tool naming a
+// squadron holds every member's lock. Calls naming no ship run straight away.
+func withShipLock(locks *shiplock.Locks, store *shipmeta.Store, name string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		shipSymbols := lockedShips(store, request.Params.Arguments)
+		if len(shipSymbols) == 0 {
+			return next(ctx, request)
+		}
+
+		// Ships are always taken in the same order, so two squadron calls sharing ships
+		// can't each hold one the other waits for
+		unlocks := make([]func(), 0, len(shipSymbols))
+		defer func() {
+			for _, unlock := range unlocks {
+				unlock()
+			}
+		}()
+		for _, shipSymbol := range shipSymbols {
+			unlock, err := locks.Lock(ctx, shipSymbol, name)
+			if err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Gave up waiting to act on the ship: %s", err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			unlocks = append(unlocks, unlock)
+		}
+		return next(ctx, request)
+	}
+}
+
+// lockedShips returns the ships a tool call acts on, sorted: the one named by a ship argument,
+// or every member of the squadron it names
+func lockedShips(store *shipmeta.Store, arguments interface{}) []string {
+	argsMap, ok := arguments.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, key := range shipArguments {
+		if value, ok := argsMap[key].(string); ok && strings.TrimSpace(value) != "" {
+			return []string{strings.ToUpper(strings.TrimSpace(value))}
+		}
+	}
+
+	name, ok := argsMap["squadron"].(string)
+	if !ok || store == nil {
+		return nil
+	}
+	squadron, ok := store.Squadron(name)
+	if !ok {
+		return nil
+	}
+	sort.Strings(squadron.Ships)
+	return squadron.Ships
+}
@@ -3,11 +3,11 @@ package ships
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
 	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -17,6 +17,10 @@ import (
 type BuyCargoTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState  bool
+	policy            *policy.Policy
+	confirmSpendAbove int
 }
 
 // NewBuyCargoTool creates a new buy cargo tool
@@ -27,11 +31,29 @@ func NewBuyCargoTool(client *client.Client, logger *logging.Logger) *BuyCargoToo
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *BuyCargoTool) WithAutoCorrectState(enabled bool) *BuyCargoTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
+// WithPolicy makes the tool refuse purchases that break the spending policy
+func (t *BuyCargoTool) WithPolicy(p *policy.Policy) *BuyCargoTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the order size, in credits, above which the user is asked to confirm the purchase
+func (t *BuyCargoTool) WithConfirmSpendAbove(credits int) *BuyCargoTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *BuyCargoTool) Tool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "buy_cargo",
-		Description: "Purchase cargo for a ship at a marketplace. Ship must be docked at a waypoint with a marketplace that sells the cargo type and you must have sufficient credits and cargo space.",
+		Description: "Purchase cargo for a ship at a marketplace. Ship must be docked at a waypoint with a marketplace that sells the cargo type and you must have sufficient credits and cargo space. Purchases above the market's trade volume are split into several transactions automatically.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -48,9 +70,23 @@ func (t *BuyCargoTool) Tool() mcp.Tool {
 					"description": "Number of units to buy",
 					"minimum":     1,
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol", "cargo_symbol", "units"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"message":          map[string]interface{}{"type": "string"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"cargo_symbol":     map[string]interface{}{"type": "string"},
+			"units_bought":     map[string]interface{}{"type": "integer"},
+			"transaction":      map[string]interface{}{"type": "object", "description": "Totals over every transaction"},
+			"transactions":     map[string]interface{}{"type": "array", "description": "Individual market transactions"},
+			"price_slippage":   map[string]interface{}{"type": "integer"},
+			"cargo":            map[string]interface{}{"type": "object"},
+			"agent":            map[string]interface{}{"type": "object"},
+			"state_correction": map[string]interface{}{"type": "string"},
+		}, "success", "message", "ship_symbol", "cargo_symbol", "units_bought", "transaction", "transactions", "cargo", "agent"),
 	}
 }
 
@@ -61,75 +97,65 @@ func (t *BuyCargoTool) Handler() func(ctx context.Context, request mcp.CallToolR
 		ctxLogger := t.logger.WithContext(ctx, "buy-cargo-tool")
 		ctxLogger.Debug("Processing cargo purchase request")
 
-		// Parse arguments
-		shipSymbol := ""
-		cargoSymbol := ""
-		units := 0
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required arguments: ship_symbol, cargo_symbol, and units"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		cargoSymbol := v.RequireSymbol("cargo_symbol", utils.TradeSymbols)
+		units := v.RequireInt("units", 1)
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			return result, nil
 		}
 
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if ss, exists := argsMap["ship_symbol"]; exists {
-				if ssStr, ok := ss.(string); ok {
-					shipSymbol = strings.TrimSpace(ssStr)
-				}
+		// Check the order at the local market's price against the spending policy, and have the
+		// user approve large orders
+		if t.policy.Enabled() || (t.confirmSpendAbove > 0 && utils.CanConfirm(ctx)) {
+			c := t.client.WithContext(ctx)
+			what := fmt.Sprintf("%d units of %s for %s", units, cargoSymbol, shipSymbol)
+			entry := localTradeGood(c, shipSymbol, cargoSymbol)
+			if entry == nil && t.policy.Enabled() {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to check the price of %s against the spending policy: the market where %s is doesn't list %s", what, shipSymbol, cargoSymbol)),
+					},
+					IsError: true,
+				}, nil
 			}
-			if cs, exists := argsMap["cargo_symbol"]; exists {
-				if csStr, ok := cs.(string); ok {
-					cargoSymbol = strings.TrimSpace(strings.ToUpper(csStr))
+			if entry != nil {
+				if result := checkSpend(c, t.policy, units*entry.PurchasePrice, what); result != nil {
+					return result, nil
 				}
-			}
-			if u, exists := argsMap["units"]; exists {
-				if uFloat, ok := u.(float64); ok {
-					units = int(uFloat)
-				} else if uInt, ok := u.(int); ok {
-					units = uInt
+				if result := confirmSpend(ctx, t.confirmSpendAbove, units*entry.PurchasePrice, what); result != nil {
+					return result, nil
 				}
 			}
 		}
 
-		if shipSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if cargoSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ cargo_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
+		ctxLogger.Info("Attempting to buy %d units of %s for ship %s", units, cargoSymbol, shipSymbol)
 
-		if units <= 0 {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ units must be a positive integer"),
-				},
-				IsError: true,
-			}, nil
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusDocked)
+			if err != nil {
+				ctxLogger.Error("Failed to dock ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
 		}
 
-		ctxLogger.Info("Attempting to buy %d units of %s for ship %s", units, cargoSymbol, shipSymbol)
-
-		// Buy the cargo
+		// Buy the cargo, split into chunks the market accepts
+		c := t.client.WithContext(ctx)
+		tradeVolume := marketTradeVolume(c, shipSymbol, cargoSymbol)
 		start := time.Now()
-		resp, err := t.client.BuyCargo(shipSymbol, cargoSymbol, units)
+		order, err := buyInChunks(c, shipSymbol, cargoSymbol, units, tradeVolume, 0, nil)
 		duration := time.Since(start)
 
-		if err != nil {
+		if order.Units == 0 {
 			ctxLogger.Error("Failed to buy cargo: %v", err)
 			ctxLogger.APICall(fmt.Sprintf("/my/ships/%s/purchase", shipSymbol), 0, duration.String())
 			return &mcp.CallToolResult{
@@ -140,9 +166,13 @@ func (t *BuyCargoTool) Handler() func(ctx context.Context, request mcp.CallToolR
 			}, nil
 		}
 
-		transaction := resp.Data.Transaction
-		cargo := resp.Data.Cargo
-		agent := resp.Data.Agent
+		if err != nil {
+			ctxLogger.Error("Purchase stopped partway: %v", err)
+		}
+
+		transaction := order.Summary()
+		cargo := order.Cargo
+		units = order.Units
 
 		ctxLogger.APICall(fmt.Sprintf("/my/ships/%s/purchase", shipSymbol), 201, duration.String())
 		ctxLogger.Info("Successfully bought %d units of %s for ship %s, spent %d credits", units, cargoSymbol, shipSymbol, transaction.TotalPrice)
@@ -164,6 +194,8 @@ func (t *BuyCargoTool) Handler() func(ctx context.Context, request mcp.CallToolR
 				"total_price":     transaction.TotalPrice,
 				"timestamp":       transaction.Timestamp,
 			},
+			"transactions":   order.Transactions,
+			"price_slippage": order.PriceSlippage,
 			"cargo": map[string]interface{}{
 				"capacity": cargo.Capacity,
 				"units":    cargo.Units,
@@ -181,9 +213,12 @@ func (t *BuyCargoTool) Handler() func(ctx context.Context, request mcp.CallToolR
 				}(),
 			},
 			"agent": map[string]interface{}{
-				"credits": agent.Credits,
+				"credits": order.Credits,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
 
 		jsonData := utils.FormatJSON(result)
 
@@ -203,12 +238,25 @@ func (t *BuyCargoTool) Handler() func(ctx context.Context, request mcp.CallToolR
 
 		// Create formatted text summary
 		textSummary := "🛒 **Cargo Purchase Successful!**\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Purchased:** %d units of %s\n", units, boughtItemName)
-		textSummary += fmt.Sprintf("**Price per Unit:** %d credits\n", costPerUnit)
+		if len(order.Transactions) > 1 {
+			textSummary += fmt.Sprintf("**Average Price per Unit:** %.1f credits\n", order.AveragePrice)
+		} else {
+			textSummary += fmt.Sprintf("**Price per Unit:** %d credits\n", costPerUnit)
+		}
 		textSummary += fmt.Sprintf("**Total Cost:** %d credits\n", transaction.TotalPrice)
-		textSummary += fmt.Sprintf("**Remaining Credits:** %d\n", agent.Credits)
+		textSummary += fmt.Sprintf("**Remaining Credits:** %d\n", order.Credits)
 		textSummary += fmt.Sprintf("**Location:** %s\n\n", transaction.WaypointSymbol)
+		if lines := chunkLines(order); lines != "" {
+			textSummary += lines + "\n"
+		}
+		if err != nil {
+			textSummary += fmt.Sprintf("⚠️ **Partial Purchase:** %s\n\n", err.Error())
+		}
 
 		// Cargo status
 		textSummary += fmt.Sprintf("**Cargo Status:** %d/%d units (%.1f%% full)\n", cargo.Units, cargo.Capacity, cargoPercent)
@@ -260,11 +308,6 @@ func (t *BuyCargoTool) Handler() func(ctx context.Context, request mcp.CallToolR
 		ctxLogger.ToolCall("buy_cargo", true)
 		ctxLogger.Debug("Buy cargo response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,287 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// BuyCargoMaxTool fills a ship's free cargo space with one good, within a budget
+type BuyCargoMaxTool struct {
+	client *client.Client
+	logger *logging.Logger
+
+	policy            *policy.Policy
+	confirmSpendAbove int
+}
+
+// NewBuyCargoMaxTool creates a new buy-to-capacity tool
+func NewBuyCargoMaxTool(client *client.Client, logger *logging.Logger) *BuyCargoMaxTool {
+	return &BuyCargoMaxTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithPolicy keeps the budget within what the spending policy allows
+func (t *BuyCargoMaxTool) WithPolicy(p *policy.Policy) *BuyCargoMaxTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the order size, in credits, above which the user is asked to confirm the purchase
+func (t *BuyCargoMaxTool) WithConfirmSpendAbove(credits int) *BuyCargoMaxTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *BuyCargoMaxTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "buy_cargo_max",
+		Description: "Fill a ship's free cargo space with a good from the local marketplace, docking first if needed. Buys in chunks of the market's trade volume and stops at capacity, at max_total_price, or when credits run out. No need to work out how many units fit.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to buy cargo for (e.g., 'SHIP_1234')",
+				},
+				"good": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the good to buy (e.g., 'IRON_ORE', 'FOOD', 'MACHINERY')",
+				},
+				"max_total_price": map[string]interface{}{
+					"type":        "integer",
+					"description": "Most credits to spend in total. Defaults to all available credits.",
+					"minimum":     1,
+				},
+			},
+			Required: []string{"ship_symbol", "good"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":     map[string]interface{}{"type": "string"},
+			"waypoint_symbol": map[string]interface{}{"type": "string"},
+			"good":            map[string]interface{}{"type": "string"},
+			"units_bought":    map[string]interface{}{"type": "integer"},
+			"total_price":     map[string]interface{}{"type": "integer"},
+			"average_price":   map[string]interface{}{"type": "number"},
+			"budget":          map[string]interface{}{"type": "integer"},
+			"stop_reason":     map[string]interface{}{"type": "string"},
+			"transactions":    map[string]interface{}{"type": "array"},
+			"agent_credits":   map[string]interface{}{"type": "integer"},
+			"cargo":           map[string]interface{}{"type": "object"},
+		}, "ship_symbol", "waypoint_symbol", "good", "units_bought", "total_price", "stop_reason", "transactions", "cargo"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *BuyCargoMaxTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "buy-cargo-max-tool")
+		ctxLogger.Debug("Processing buy to capacity request")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		good := v.RequireSymbol("good", utils.TradeSymbols)
+		maxTotalPrice := v.OptionalInt("max_total_price", 0, 0, math.MaxInt)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+
+		cargo, err := c.GetShipCargo(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get cargo for ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get cargo for ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		free := cargo.Capacity - cargo.Units
+		if free <= 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Ship %s has no free cargo space (%d/%d units)", shipSymbol, cargo.Units, cargo.Capacity)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		nav, err := c.GetShipNav(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get nav for ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get location of ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		if nav.Status == "IN_TRANSIT" {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Ship %s is in transit to %s and cannot trade until it arrives", shipSymbol, nav.Route.Destination.Symbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		market, err := c.GetMarket(nav.SystemSymbol, nav.WaypointSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get market at %s: %v", nav.WaypointSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ No marketplace found at %s: %s", nav.WaypointSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		entry := marketTradeGood(market, good)
+		if entry == nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s is not sold at %s", good, nav.WaypointSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		agent, err := c.GetAgent()
+		if err != nil {
+			ctxLogger.Error("Failed to get agent: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get credits: %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		budget := int(t.policy.Allowance(agent.Credits))
+		if maxTotalPrice > 0 && maxTotalPrice < budget {
+			budget = maxTotalPrice
+		}
+		if budget < entry.PurchasePrice {
+			limit := ""
+			if t.policy.Enabled() {
+				limit = " allowed by the spending policy"
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ A single unit of %s costs %d credits, more than the budget of %d%s", good, entry.PurchasePrice, budget, limit)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Filling the hold can cost more than the user expects, so large orders need their approval
+		estimate := min(budget, free*entry.PurchasePrice)
+		if result := confirmSpend(ctx, t.confirmSpendAbove, estimate, fmt.Sprintf("filling %s with %s", shipSymbol, good)); result != nil {
+			return result, nil
+		}
+
+		if nav.Status != "DOCKED" {
+			if _, err := c.DockShip(shipSymbol); err != nil {
+				ctxLogger.Error("Failed to dock ship %s: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+		}
+
+		// The first chunk uses the price already fetched; prices move as we buy, so later
+		// chunks check the market again to stay within budget
+		quoted := false
+		quote := func() (int, error) {
+			if !quoted {
+				quoted = true
+				return entry.PurchasePrice, nil
+			}
+			market, err := c.GetMarket(nav.SystemSymbol, nav.WaypointSymbol)
+			if err != nil {
+				return 0, err
+			}
+			if current := marketTradeGood(market, good); current != nil {
+				return current.PurchasePrice, nil
+			}
+			return 0, fmt.Errorf("%s is no longer sold at %s", good, nav.WaypointSymbol)
+		}
+
+		ctxLogger.Info("Buying up to %d units of %s for ship %s with a budget of %d credits", free, good, shipSymbol, budget)
+		order, buyErr := buyInChunks(c, shipSymbol, good, free, entry.TradeVolume, budget, quote)
+		if buyErr != nil {
+			ctxLogger.Error("Failed to buy %s for ship %s: %v", good, shipSymbol, buyErr)
+		}
+		if order.Units == 0 {
+			if buyErr != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to buy %s: %s", good, buyErr.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Could not afford any %s within the budget of %d credits", good, budget)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		stopReason := "cargo hold full"
+		switch {
+		case buyErr != nil:
+			stopReason = buyErr.Error()
+		case order.Units < free:
+			stopReason = fmt.Sprintf("budget of %d credits reached", budget)
+		}
+
+		result := map[string]interface{}{
+			"ship_symbol":     shipSymbol,
+			"waypoint_symbol": nav.WaypointSymbol,
+			"good":            good,
+			"units_bought":    order.Units,
+			"total_price":     order.TotalPrice,
+			"average_price":   order.AveragePrice,
+			"budget":          budget,
+			"stop_reason":     stopReason,
+			"transactions":    order.Transactions,
+			"agent_credits":   order.Credits,
+			"cargo": map[string]interface{}{
+				"capacity":  order.Cargo.Capacity,
+				"units":     order.Cargo.Units,
+				"inventory": order.Cargo.Inventory,
+			},
+		}
+
+		textSummary := "🛒 **Cargo Purchased**\n\n"
+		textSummary += fmt.Sprintf("**Ship:** %s at %s\n", shipSymbol, nav.WaypointSymbol)
+		textSummary += fmt.Sprintf("**Bought:** %d units of %s", order.Units, good)
+		if len(order.Transactions) > 1 {
+			textSummary += fmt.Sprintf(" in %d transactions", len(order.Transactions))
+		}
+		textSummary += "\n"
+		textSummary += fmt.Sprintf("**Total Cost:** %d credits (avg %.1f/unit)\n", order.TotalPrice, order.AveragePrice)
+		textSummary += fmt.Sprintf("**Stopped Because:** %s\n", stopReason)
+		textSummary += fmt.Sprintf("**Current Credits:** %d\n", order.Credits)
+		textSummary += fmt.Sprintf("**Cargo Status:** %d/%d units\n", order.Cargo.Units, order.Cargo.Capacity)
+
+		ctxLogger.ToolCall("buy_cargo_max", buyErr == nil)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
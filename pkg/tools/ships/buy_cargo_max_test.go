@@ -0,0 +1,177 @@
+package ships
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newBuyMarketServer serves a docked ship with 40 free units at a market selling IRON_ORE in
+// chunks of 10, where the price rises by 10 credits after every purchase
+func newBuyMarketServer(t *testing.T, credits int, purchases *[]int) *httptest.Server {
+	t.Helper()
+	held := 0
+	price := 100
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		cargo := fmt.Sprintf(`{"capacity": 40, "units": %d, "inventory": []}`, held)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/HAULER-1/cargo":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, cargo)
+		case "GET /my/ships/HAULER-1/nav":
+			_, _ = fmt.Fprintf(w, `{"data": `+testNav+`}`, "DOCKED")
+		case "GET /my/agent":
+			_, _ = fmt.Fprintf(w, `{"data": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": %d, "startingFaction": "COSMIC", "shipCount": 1}}`, credits)
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = fmt.Fprintf(w, `{"data": {
+				"symbol": "X1-TEST-A1", "imports": [], "exchange": [],
+				"exports": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": ""}],
+				"tradeGoods": [{"symbol": "IRON_ORE", "type": "EXPORT", "tradeVolume": 10, "supply": "ABUNDANT", "purchasePrice": %d, "sellPrice": 50}]
+			}}`, price)
+		case "POST /my/ships/HAULER-1/purchase":
+			var req struct {
+				Units int `json:"units"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			*purchases = append(*purchases, req.Units)
+			total := req.Units * price
+			credits -= total
+			held += req.Units
+			_, _ = fmt.Fprintf(w, `{"data": {
+				"agent": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": %d, "startingFaction": "COSMIC", "shipCount": 1},
+				"cargo": {"capacity": 40, "units": %d, "inventory": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "", "units": %d}]},
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "HAULER-1", "tradeSymbol": "IRON_ORE", "type": "PURCHASE", "units": %d, "pricePerUnit": %d, "totalPrice": %d, "timestamp": "2024-01-01T00:00:00.000Z"}
+			}}`, credits, held, held, req.Units, price, total)
+			price += 10
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func callBuyCargoMax(t *testing.T, serverURL string, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	tool := NewBuyCargoMaxTool(client.NewClientWithBaseURL("test-token", serverURL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "buy_cargo_max", Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
+
+func TestBuyCargoMaxTool_FillsCapacityInChunks(t *testing.T) {
+	var purchases []int
+	server := newBuyMarketServer(t, 100000, &purchases)
+	defer server.Close()
+
+	result := callBuyCargoMax(t, server.URL, map[string]interface{}{"ship_symbol": "HAULER-1", "good": "iron_ore"})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	if got := fmt.Sprint(purchases); got != "[10 10 10 10]" {
+		t.Errorf("Expected four purchases of 10 units, got %s", got)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"40 units of IRON_ORE in 4 transactions", "4600 credits (avg 115.0/unit)", "cargo hold full"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestBuyCargoMaxTool_StopsAtBudget(t *testing.T) {
+	var purchases []int
+	server := newBuyMarketServer(t, 100000, &purchases)
+	defer server.Close()
+
+	// 10 units at 100, then 9 units at 110 leaves 10 credits, less than the next unit at 120
+	result := callBuyCargoMax(t, server.URL, map[string]interface{}{"ship_symbol": "HAULER-1", "good": "IRON_ORE", "max_total_price": float64(2000)})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	if got := fmt.Sprint(purchases); got != "[10 9]" {
+		t.Errorf("Expected purchases of 10 and 9 units, got %s", got)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "budget of 2000 credits reached") {
+		t.Errorf("Expected budget stop reason, got:\n%s", text)
+	}
+}
+
+func TestBuyCargoMaxTool_LimitedByCredits(t *testing.T) {
+	var purchases []int
+	server := newBuyMarketServer(t, 50, &purchases)
+	defer server.Close()
+
+	result := callBuyCargoMax(t, server.URL, map[string]interface{}{"ship_symbol": "HAULER-1", "good": "IRON_ORE"})
+	if !result.IsError {
+		t.Fatalf("Expected an error when a single unit is unaffordable, got %v", result.Content)
+	}
+	if len(purchases) != 0 {
+		t.Errorf("Expected no purchases, got %v", purchases)
+	}
+}
+
+func TestBuyCargoTool_RefusedBySpendingPolicy(t *testing.T) {
+	var purchases []int
+	server := newBuyMarketServer(t, 100000, &purchases)
+	defer server.Close()
+
+	tool := NewBuyCargoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).
+		WithPolicy(policy.New(policy.Limits{ReserveFloor: 99500}))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "buy_cargo", Arguments: map[string]interface{}{"ship_symbol": "HAULER-1", "cargo_symbol": "IRON_ORE", "units": float64(10)}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected the purchase to be refused")
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "99500 credit reserve") {
+		t.Errorf("Expected the refusal to explain the reserve, got %s", text)
+	}
+	if len(purchases) != 0 {
+		t.Errorf("Expected nothing to be bought, got %v", purchases)
+	}
+}
+
+func TestBuyCargoMaxTool_BudgetWithinSpendingPolicy(t *testing.T) {
+	var purchases []int
+	server := newBuyMarketServer(t, 100000, &purchases)
+	defer server.Close()
+
+	tool := NewBuyCargoMaxTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).
+		WithPolicy(policy.New(policy.Limits{MaxPurchase: 1500}))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "buy_cargo_max", Arguments: map[string]interface{}{"ship_symbol": "HAULER-1", "good": "IRON_ORE"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	total := 0
+	for _, units := range purchases {
+		total += units
+	}
+	if total == 0 || total > 14 {
+		t.Errorf("Expected at most 1500 credits of IRON_ORE to be bought, got %d units in %v", total, purchases)
+	}
+}
@@ -0,0 +1,211 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CleanCargoTool jettisons every good in a ship's hold that is not worth keeping
+type CleanCargoTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewCleanCargoTool creates a new clean cargo tool
+func NewCleanCargoTool(client *client.Client, logger *logging.Logger) *CleanCargoTool {
+	return &CleanCargoTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *CleanCargoTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "clean_cargo",
+		Description: "Jettison everything in a ship's hold except the goods on a keep list and goods an accepted contract still needs, to free space before a mining or hauling run. The cargo is lost permanently; sell it with sell_all_cargo instead when a market buys it. Ship must be in orbit.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to clean out (e.g., 'SHIP_1234')",
+				},
+				"keep": map[string]interface{}{
+					"type":        "array",
+					"description": "Cargo symbols to keep on board (e.g., ['IRON_ORE', 'ANTIMATTER'])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"jettisoned":       map[string]interface{}{"type": "array", "description": "Units jettisoned per good"},
+			"kept":             map[string]interface{}{"type": "array", "description": "Goods left on board and why"},
+			"units_jettisoned": map[string]interface{}{"type": "integer"},
+			"free_space":       map[string]interface{}{"type": "integer", "description": "Free cargo units afterwards"},
+			"capacity":         map[string]interface{}{"type": "integer"},
+			"failures":         map[string]interface{}{"type": "array"},
+		}, "ship_symbol", "jettisoned", "kept", "units_jettisoned", "free_space", "capacity"),
+	}
+}
+
+// cargoEntry is a good in the hold and how many units of it
+type cargoEntry struct {
+	Symbol string `json:"symbol"`
+	Units  int    `json:"units"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Handler returns the tool handler function
+func (t *CleanCargoTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "clean-cargo-tool")
+		ctxLogger.Debug("Processing clean cargo request")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		keep := make(map[string]bool)
+		for _, symbol := range v.OptionalSymbols("keep", utils.TradeSymbols) {
+			keep[symbol] = true
+		}
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+
+		cargo, err := c.GetShipCargo(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get cargo for ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get cargo for ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Goods an accepted contract still needs are worth far more delivered than dumped
+		contracts, err := c.GetAllContracts()
+		if err != nil {
+			ctxLogger.Error("Failed to fetch contracts before cleaning cargo: %v", err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Could not check which goods contracts need: %s. Nothing was jettisoned.", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		toJettison := make([]cargoEntry, 0)
+		kept := make([]cargoEntry, 0)
+		total := 0
+		for _, item := range cargo.Inventory {
+			if keep[item.Symbol] {
+				kept = append(kept, cargoEntry{item.Symbol, item.Units, "on the keep list"})
+				continue
+			}
+			if needs := contractNeeds(contracts, item.Symbol); len(needs) > 0 {
+				kept = append(kept, cargoEntry{item.Symbol, item.Units, "needed for " + strings.Join(needs, "; ")})
+				continue
+			}
+			toJettison = append(toJettison, cargoEntry{Symbol: item.Symbol, Units: item.Units})
+			total += item.Units
+		}
+
+		if len(toJettison) == 0 {
+			result := map[string]interface{}{
+				"ship_symbol":      shipSymbol,
+				"jettisoned":       toJettison,
+				"kept":             kept,
+				"units_jettisoned": 0,
+				"free_space":       cargo.Capacity - cargo.Units,
+				"capacity":         cargo.Capacity,
+			}
+			return utils.NewResult(fmt.Sprintf("📦 Nothing to jettison from %s; %d/%d units free.", shipSymbol, cargo.Capacity-cargo.Units, cargo.Capacity), result), nil
+		}
+
+		// Show the user what is about to be thrown away and let them approve it
+		if utils.CanConfirm(ctx) {
+			goods := make([]string, 0, len(toJettison))
+			for _, entry := range toJettison {
+				goods = append(goods, fmt.Sprintf("%d %s", entry.Units, entry.Symbol))
+			}
+			approved, err := utils.Confirm(ctx, fmt.Sprintf("Jettison %s from %s? Jettisoned cargo is lost for good.", strings.Join(goods, ", "), shipSymbol))
+			if err != nil {
+				ctxLogger.Error("Failed to confirm cleaning cargo: %v", err)
+			}
+			if !approved {
+				return utils.NotConfirmedResult(fmt.Sprintf("jettisoning %d units of cargo", total)), nil
+			}
+		}
+
+		jettisoned := make([]cargoEntry, 0, len(toJettison))
+		failures := make([]string, 0)
+		unitsJettisoned := 0
+		remaining := *cargo
+		for _, entry := range toJettison {
+			resp, err := c.JettisonCargo(shipSymbol, entry.Symbol, entry.Units)
+			if err != nil {
+				ctxLogger.Error("Failed to jettison %s from ship %s: %v", entry.Symbol, shipSymbol, err)
+				failures = append(failures, fmt.Sprintf("%s: %s", entry.Symbol, err.Error()))
+				continue
+			}
+			jettisoned = append(jettisoned, entry)
+			unitsJettisoned += entry.Units
+			remaining = resp.Data.Cargo
+		}
+
+		ctxLogger.Info("Jettisoned %d units of %d goods from ship %s", unitsJettisoned, len(jettisoned), shipSymbol)
+
+		result := map[string]interface{}{
+			"ship_symbol":      shipSymbol,
+			"jettisoned":       jettisoned,
+			"kept":             kept,
+			"units_jettisoned": unitsJettisoned,
+			"free_space":       remaining.Capacity - remaining.Units,
+			"capacity":         remaining.Capacity,
+		}
+		if len(failures) > 0 {
+			result["failures"] = failures
+		}
+
+		textSummary := "🗑️ **Cargo Cleaned**\n\n"
+		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
+		textSummary += fmt.Sprintf("**Jettisoned:** %d units\n", unitsJettisoned)
+		for _, entry := range jettisoned {
+			textSummary += fmt.Sprintf("- %s: %d units\n", entry.Symbol, entry.Units)
+		}
+		textSummary += fmt.Sprintf("**Free Space:** %d/%d units\n", remaining.Capacity-remaining.Units, remaining.Capacity)
+
+		if len(kept) > 0 {
+			textSummary += "\n**Kept:**\n"
+			for _, entry := range kept {
+				textSummary += fmt.Sprintf("- %s: %d units (%s)\n", entry.Symbol, entry.Units, entry.Reason)
+			}
+		}
+		if len(failures) > 0 {
+			textSummary += "\n**Failed:**\n"
+			for _, failure := range failures {
+				textSummary += fmt.Sprintf("- ❌ %s\n", failure)
+			}
+		}
+
+		ctxLogger.ToolCall("clean_cargo", len(failures) == 0)
+
+		callResult := utils.NewResult(textSummary, result)
+		callResult.IsError = len(jettisoned) == 0
+		return callResult, nil
+	}
+}
@@ -0,0 +1,82 @@
+package ships
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCleanCargoTool_KeepsListAndContractGoods(t *testing.T) {
+	var jettisoned []string
+	units := 40
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/MINER-1/cargo":
+			_, _ = w.Write([]byte(`{"data": {"capacity": 40, "units": 40, "inventory": [
+				{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "", "units": 15},
+				{"symbol": "QUARTZ_SAND", "name": "Quartz Sand", "description": "", "units": 12},
+				{"symbol": "ICE_WATER", "name": "Ice Water", "description": "", "units": 8},
+				{"symbol": "COPPER_ORE", "name": "Copper Ore", "description": "", "units": 5}
+			]}}`))
+		case "GET /my/contracts":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "c-1", "accepted": true, "fulfilled": false, "terms": {"deliver": [
+					{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-HQ", "unitsRequired": 60, "unitsFulfilled": 10}
+				]}}
+			], "meta": {"total": 1, "page": 1, "limit": 20}}`))
+		case "POST /my/ships/MINER-1/jettison":
+			var body struct {
+				Symbol string `json:"symbol"`
+				Units  int    `json:"units"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			jettisoned = append(jettisoned, body.Symbol)
+			units -= body.Units
+			_, _ = fmt.Fprintf(w, `{"data": {"cargo": {"capacity": 40, "units": %d, "inventory": []}}}`, units)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewCleanCargoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "clean_cargo", Arguments: map[string]interface{}{
+			"ship_symbol": "miner-1",
+			"keep":        []interface{}{"copper ore"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	if strings.Join(jettisoned, ",") != "QUARTZ_SAND,ICE_WATER" {
+		t.Errorf("Expected only the junk to be jettisoned, got %v", jettisoned)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, expected := range []string{
+		"**Jettisoned:** 20 units",
+		"- QUARTZ_SAND: 12 units",
+		"**Free Space:** 20/40 units",
+		"IRON_ORE: 15 units (needed for contract c-1",
+		"COPPER_ORE: 5 units (on the keep list)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in summary, got: %s", expected, text)
+		}
+	}
+}
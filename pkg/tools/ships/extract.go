@@ -3,7 +3,6 @@ package ships
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
@@ -17,6 +16,8 @@ import (
 type ExtractResourcesTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState bool
 }
 
 // NewExtractResourcesTool creates a new extract resources tool
@@ -27,6 +28,12 @@ func NewExtractResourcesTool(client *client.Client, logger *logging.Logger) *Ext
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *ExtractResourcesTool) WithAutoCorrectState(enabled bool) *ExtractResourcesTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *ExtractResourcesTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -76,9 +83,20 @@ func (t *ExtractResourcesTool) Tool() mcp.Tool {
 					},
 					"required": []string{"signature", "symbol", "deposits", "expiration", "size"},
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"message":          map[string]interface{}{"type": "string"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"extraction":       map[string]interface{}{"type": "object", "description": "Extracted good and units"},
+			"cargo":            map[string]interface{}{"type": "object"},
+			"cooldown":         map[string]interface{}{"type": "object"},
+			"events":           map[string]interface{}{"type": "array"},
+			"state_correction": map[string]interface{}{"type": "string"},
+		}, "success", "message", "ship_symbol", "extraction", "cargo", "cooldown"),
 	}
 }
 
@@ -89,82 +107,16 @@ func (t *ExtractResourcesTool) Handler() func(ctx context.Context, request mcp.C
 		ctxLogger := t.logger.WithContext(ctx, "extract-resources-tool")
 		ctxLogger.Debug("Processing resource extraction request")
 
-		// Parse arguments
-		shipSymbol := ""
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
 		var survey *client.Survey
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required argument: ship_symbol"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if ss, exists := argsMap["ship_symbol"]; exists {
-				if ssStr, ok := ss.(string); ok {
-					shipSymbol = strings.TrimSpace(ssStr)
-				}
-			}
-
-			// Parse survey if provided
-			if surveyData, exists := argsMap["survey"]; exists {
-				if surveyMap, ok := surveyData.(map[string]interface{}); ok {
-					survey = &client.Survey{}
-
-					if sig, exists := surveyMap["signature"]; exists {
-						if sigStr, ok := sig.(string); ok {
-							survey.Signature = sigStr
-						}
-					}
-
-					if sym, exists := surveyMap["symbol"]; exists {
-						if symStr, ok := sym.(string); ok {
-							survey.Symbol = symStr
-						}
-					}
-
-					if exp, exists := surveyMap["expiration"]; exists {
-						if expStr, ok := exp.(string); ok {
-							survey.Expiration = expStr
-						}
-					}
-
-					if size, exists := surveyMap["size"]; exists {
-						if sizeStr, ok := size.(string); ok {
-							survey.Size = sizeStr
-						}
-					}
-
-					if deposits, exists := surveyMap["deposits"]; exists {
-						if depositsArray, ok := deposits.([]interface{}); ok {
-							survey.Deposits = make([]client.SurveyDeposit, 0, len(depositsArray))
-							for _, dep := range depositsArray {
-								if depMap, ok := dep.(map[string]interface{}); ok {
-									if symbol, exists := depMap["symbol"]; exists {
-										if symbolStr, ok := symbol.(string); ok {
-											survey.Deposits = append(survey.Deposits, client.SurveyDeposit{
-												Symbol: symbolStr,
-											})
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+		if given := new(client.Survey); v.OptionalObject("survey", given) {
+			survey = given
 		}
-
-		if shipSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		ctxLogger.Info("Attempting to extract resources with ship %s", shipSymbol)
@@ -174,7 +126,23 @@ func (t *ExtractResourcesTool) Handler() func(ctx context.Context, request mcp.C
 
 		// Extract resources
 		start := time.Now()
-		resp, err := t.client.ExtractResources(shipSymbol, survey)
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusInOrbit)
+			if err != nil {
+				ctxLogger.Error("Failed to orbit ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to orbit ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
+		}
+
+		resp, err := t.client.WithContext(ctx).ExtractResources(shipSymbol, survey)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -228,6 +196,9 @@ func (t *ExtractResourcesTool) Handler() func(ctx context.Context, request mcp.C
 				"expiration":        cooldown.Expiration,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
 
 		// Add events if any occurred
 		if len(events) > 0 {
@@ -250,6 +221,9 @@ func (t *ExtractResourcesTool) Handler() func(ctx context.Context, request mcp.C
 
 		// Create formatted text summary
 		textSummary := "⛏️ **Resource Extraction Successful!**\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Extracted:** %d units of %s\n", extraction.Yield.Units, extraction.Yield.Symbol)
 		textSummary += fmt.Sprintf("**Cargo Status:** %d/%d units (%.1f%% full)", cargo.Units, cargo.Capacity, cargoPercent)
@@ -310,11 +284,6 @@ func (t *ExtractResourcesTool) Handler() func(ctx context.Context, request mcp.C
 		ctxLogger.ToolCall("extract_resources", true)
 		ctxLogger.Debug("Extract resources response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
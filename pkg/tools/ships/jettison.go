@@ -31,7 +31,7 @@ func NewJettisonCargoTool(client *client.Client, logger *logging.Logger) *Jettis
 func (t *JettisonCargoTool) Tool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "jettison_cargo",
-		Description: "Jettison (dump) cargo from a ship to free up space. The cargo will be lost permanently. Ship must be in orbit to jettison cargo.",
+		Description: "Jettison (dump) cargo from a ship to free up space. The cargo will be lost permanently. Ship must be in orbit to jettison cargo. Goods still needed by an accepted contract are kept unless force is true.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -48,9 +48,24 @@ func (t *JettisonCargoTool) Tool() mcp.Tool {
 					"description": "Number of units to jettison",
 					"minimum":     1,
 				},
+				"force": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Jettison even if an accepted, unfulfilled contract still needs this good",
+					"default":     false,
+				},
 			},
 			Required: []string{"ship_symbol", "cargo_symbol", "units"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"message":          map[string]interface{}{"type": "string"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"cargo_symbol":     map[string]interface{}{"type": "string"},
+			"units_jettisoned": map[string]interface{}{"type": "integer"},
+			"space_reclaimed":  map[string]interface{}{"type": "integer", "description": "Cargo units freed by this jettison"},
+			"free_space":       map[string]interface{}{"type": "integer", "description": "Free cargo units after the jettison"},
+			"cargo":            map[string]interface{}{"type": "object"},
+		}, "success", "message", "ship_symbol", "cargo_symbol", "units_jettisoned", "space_reclaimed", "free_space", "cargo"),
 	}
 }
 
@@ -61,72 +76,64 @@ func (t *JettisonCargoTool) Handler() func(ctx context.Context, request mcp.Call
 		ctxLogger := t.logger.WithContext(ctx, "jettison-cargo-tool")
 		ctxLogger.Debug("Processing cargo jettison request")
 
-		// Parse arguments
-		shipSymbol := ""
-		cargoSymbol := ""
-		units := 0
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required arguments: ship_symbol, cargo_symbol, and units"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		cargoSymbol := v.RequireSymbol("cargo_symbol", utils.TradeSymbols)
+		units := v.RequireInt("units", 1)
+		force := v.OptionalBool("force", false)
+		if result := v.Result(); result != nil {
+			return result, nil
 		}
 
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if ss, exists := argsMap["ship_symbol"]; exists {
-				if ssStr, ok := ss.(string); ok {
-					shipSymbol = strings.TrimSpace(ssStr)
-				}
-			}
-			if cs, exists := argsMap["cargo_symbol"]; exists {
-				if csStr, ok := cs.(string); ok {
-					cargoSymbol = strings.TrimSpace(strings.ToUpper(csStr))
-				}
+		// Goods an accepted contract still needs are worth far more delivered than dumped
+		if !force {
+			start := time.Now()
+			contracts, err := t.client.WithContext(ctx).GetAllContracts()
+			duration := time.Since(start)
+			if err != nil {
+				ctxLogger.Error("Failed to fetch contracts before jettisoning: %v", err)
+				ctxLogger.APICall("/my/contracts", 0, duration.String())
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Could not check whether a contract needs %s: %s. Call jettison_cargo again with force set to true to jettison anyway.", cargoSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
 			}
-			if u, exists := argsMap["units"]; exists {
-				if uFloat, ok := u.(float64); ok {
-					units = int(uFloat)
-				} else if uInt, ok := u.(int); ok {
-					units = uInt
-				}
-			}
-		}
+			ctxLogger.APICall("/my/contracts", 200, duration.String())
 
-		if shipSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if cargoSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ cargo_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+			if needs := contractNeeds(contracts, cargoSymbol); len(needs) > 0 {
+				ctxLogger.Info("Refusing to jettison %s from %s: needed by %s", cargoSymbol, shipSymbol, strings.Join(needs, ", "))
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ %s is still needed for %s. Deliver it with deliver_contract instead, or call jettison_cargo again with force set to true to jettison anyway.", cargoSymbol, strings.Join(needs, "; "))),
+					},
+					IsError: true,
+				}, nil
+			}
 		}
 
-		if units <= 0 {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ units must be a positive integer"),
-				},
-				IsError: true,
-			}, nil
+		// Show the user what is about to be thrown away and let them approve it
+		if utils.CanConfirm(ctx) {
+			message := fmt.Sprintf("Jettison %d units of %s from %s? Jettisoned cargo is lost for good.", units, cargoSymbol, shipSymbol)
+			if entry := localTradeGood(t.client.WithContext(ctx), shipSymbol, cargoSymbol); entry != nil {
+				message = fmt.Sprintf("Jettison %d units of %s from %s? The local market would pay %d credits for them; jettisoned cargo is lost for good.",
+					units, cargoSymbol, shipSymbol, units*entry.SellPrice)
+			}
+			approved, err := utils.Confirm(ctx, message)
+			if err != nil {
+				ctxLogger.Error("Failed to confirm jettisoning cargo: %v", err)
+			}
+			if !approved {
+				return utils.NotConfirmedResult(fmt.Sprintf("jettisoning %d units of %s", units, cargoSymbol)), nil
+			}
 		}
 
 		ctxLogger.Info("Attempting to jettison %d units of %s from ship %s", units, cargoSymbol, shipSymbol)
 
 		// Jettison the cargo
 		start := time.Now()
-		resp, err := t.client.JettisonCargo(shipSymbol, cargoSymbol, units)
+		resp, err := t.client.WithContext(ctx).JettisonCargo(shipSymbol, cargoSymbol, units)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -152,6 +159,8 @@ func (t *JettisonCargoTool) Handler() func(ctx context.Context, request mcp.Call
 			"ship_symbol":      shipSymbol,
 			"cargo_symbol":     cargoSymbol,
 			"units_jettisoned": units,
+			"space_reclaimed":  units,
+			"free_space":       cargo.Capacity - cargo.Units,
 			"cargo": map[string]interface{}{
 				"capacity": cargo.Capacity,
 				"units":    cargo.Units,
@@ -190,6 +199,7 @@ func (t *JettisonCargoTool) Handler() func(ctx context.Context, request mcp.Call
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Jettisoned:** %d units of %s\n", units, jettisonedItemName)
 		textSummary += fmt.Sprintf("**Cargo Status:** %d/%d units (%.1f%% full)\n", cargo.Units, cargo.Capacity, cargoPercent)
+		textSummary += fmt.Sprintf("**Space Reclaimed:** %d units\n", units)
 		textSummary += fmt.Sprintf("**Free Space:** %d units available\n\n", freedSpace)
 
 		// Show warning about permanent loss
@@ -227,11 +237,22 @@ func (t *JettisonCargoTool) Handler() func(ctx context.Context, request mcp.Call
 		ctxLogger.ToolCall("jettison_cargo", true)
 		ctxLogger.Debug("Jettison cargo response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// contractNeeds describes the accepted, unfulfilled contracts that still need deliveries of a good
+func contractNeeds(contracts []client.Contract, tradeSymbol string) []string {
+	var needs []string
+	for _, contract := range contracts {
+		if !contract.Accepted || contract.Fulfilled {
+			continue
+		}
+		for _, deliver := range contract.Terms.Deliver {
+			if remaining := deliver.UnitsRequired - deliver.UnitsFulfilled; deliver.TradeSymbol == tradeSymbol && remaining > 0 {
+				needs = append(needs, fmt.Sprintf("contract %s (%d units to %s)", contract.ID, remaining, deliver.DestinationSymbol))
+			}
+		}
 	}
+	return needs
 }
@@ -0,0 +1,75 @@
+package ships
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestJettisonCargoTool_KeepsContractGoods(t *testing.T) {
+	jettisoned := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/contracts":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "c-1", "accepted": true, "fulfilled": false, "terms": {"deliver": [
+					{"tradeSymbol": "IRON_ORE", "destinationSymbol": "X1-TEST-HQ", "unitsRequired": 60, "unitsFulfilled": 10}
+				]}},
+				{"id": "c-2", "accepted": false, "fulfilled": false, "terms": {"deliver": [
+					{"tradeSymbol": "QUARTZ_SAND", "destinationSymbol": "X1-TEST-HQ", "unitsRequired": 5, "unitsFulfilled": 0}
+				]}}
+			], "meta": {"total": 2, "page": 1, "limit": 20}}`))
+		case "POST /my/ships/MINER-1/jettison":
+			jettisoned++
+			_, _ = w.Write([]byte(`{"data": {"cargo": {"capacity": 40, "units": 25, "inventory": [
+				{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "", "units": 25}
+			]}}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewJettisonCargoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "jettison_cargo", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return result
+	}
+
+	refused := call(map[string]interface{}{"ship_symbol": "MINER-1", "cargo_symbol": "IRON_ORE", "units": float64(15)})
+	if !refused.IsError || jettisoned != 0 {
+		t.Fatalf("Expected contract goods to be kept, got %v after %d jettisons", refused.Content, jettisoned)
+	}
+	if text := refused.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "c-1") || !strings.Contains(text, "force") {
+		t.Errorf("Expected the refusal to name the contract and mention force, got %q", text)
+	}
+
+	// Only accepted contracts hold goods back
+	if result := call(map[string]interface{}{"ship_symbol": "MINER-1", "cargo_symbol": "QUARTZ_SAND", "units": float64(15)}); result.IsError {
+		t.Errorf("Expected goods of an unaccepted contract to be jettisoned, got %v", result.Content)
+	}
+
+	forced := call(map[string]interface{}{"ship_symbol": "MINER-1", "cargo_symbol": "IRON_ORE", "units": float64(15), "force": true})
+	if forced.IsError || jettisoned != 2 {
+		t.Fatalf("Expected force to jettison contract goods, got %v", forced.Content)
+	}
+	data := forced.StructuredContent.(map[string]interface{})
+	if data["space_reclaimed"] != 15 || data["free_space"] != 15 {
+		t.Errorf("Expected 15 units reclaimed and 15 free, got %v and %v", data["space_reclaimed"], data["free_space"])
+	}
+}
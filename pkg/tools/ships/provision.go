@@ -0,0 +1,321 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ProvisionShipTool buys a ship, fits it out and sends it to work in one call
+type ProvisionShipTool struct {
+	client *client.Client
+	logger *logging.Logger
+
+	policy            *policy.Policy
+	confirmSpendAbove int
+}
+
+// NewProvisionShipTool creates a new ship provisioning tool
+func NewProvisionShipTool(client *client.Client, logger *logging.Logger) *ProvisionShipTool {
+	return &ProvisionShipTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithPolicy makes the tool refuse ships whose price breaks the spending policy
+func (t *ProvisionShipTool) WithPolicy(p *policy.Policy) *ProvisionShipTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the ship price, in credits, above which the user is asked to confirm the purchase
+func (t *ProvisionShipTool) WithConfirmSpendAbove(credits int) *ProvisionShipTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *ProvisionShipTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "provision_ship",
+		Description: "Buy a ship at a shipyard, install mounts and modules, set its flight mode and send it to a staging waypoint in one call. Parts not already in the new ship's cargo are bought at the shipyard's market first. Stops at the first failed step and reports what was already done, since purchases and installations cannot be undone.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Type of ship to purchase (e.g., SHIP_MINING_DRONE, SHIP_LIGHT_HAULER)",
+				},
+				"waypoint_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Shipyard waypoint to buy the ship at (e.g., X1-FM66-B2). One of your ships must be there.",
+				},
+				"mounts": map[string]interface{}{
+					"type":        "array",
+					"description": "Optional: Mounts to install (e.g., ['MOUNT_MINING_LASER_II'])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"modules": map[string]interface{}{
+					"type":        "array",
+					"description": "Optional: Modules to install (e.g., ['MODULE_CARGO_HOLD_II'])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"flight_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Flight mode to set (CRUISE, BURN, DRIFT, STEALTH)",
+				},
+				"destination": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional: Staging waypoint in the same system to navigate the new ship to (e.g., X1-FM66-C3)",
+				},
+			},
+			Required: []string{"ship_type", "waypoint_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":     map[string]interface{}{"type": "boolean"},
+			"ship_symbol": map[string]interface{}{"type": "string", "description": "The new ship, empty if the purchase failed"},
+			"steps":       map[string]interface{}{"type": "array", "description": "Each step with status done, failed or skipped"},
+		}, "success", "ship_symbol", "steps"),
+	}
+}
+
+// provisionStep is one step of provisioning and how it went
+type provisionStep struct {
+	Step   string `json:"step"`
+	Status string `json:"status"` // done, failed or skipped
+	Detail string `json:"detail"`
+}
+
+// provisionPlan is a validated provisioning request
+type provisionPlan struct {
+	shipType    string
+	waypoint    string
+	mounts      []string
+	modules     []string
+	flightMode  string
+	destination string
+}
+
+// Handler returns the tool handler function
+func (t *ProvisionShipTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "provision-ship-tool")
+		ctxLogger.Debug("Processing ship provisioning request")
+
+		// Everything is validated before the purchase, so a typo never leaves a half-built ship
+		v := utils.NewValidator(request.Params.Arguments)
+		plan := parseProvisionPlan(v)
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+
+		ctxLogger.Info("Provisioning %s at %s", plan.shipType, plan.waypoint)
+		// Only the ship's price is checked against the spending policy and shown for approval;
+		// parts are cheap by comparison
+		if t.policy.Enabled() || (t.confirmSpendAbove > 0 && utils.CanConfirm(ctx)) {
+			c := t.client.WithContext(ctx)
+			what := fmt.Sprintf("a %s at %s", plan.shipType, plan.waypoint)
+			price, err := shipPrice(c, plan.waypoint, plan.shipType)
+			switch {
+			case err != nil && t.policy.Enabled():
+				ctxLogger.Error("Failed to look up the price of %s: %v", what, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to check the price of %s against the spending policy: %s", what, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			case err != nil:
+				ctxLogger.Error("Failed to look up the price of %s: %v", what, err)
+			default:
+				if result := checkSpend(c, t.policy, price, what); result != nil {
+					return result, nil
+				}
+				if result := confirmSpend(ctx, t.confirmSpendAbove, price, what); result != nil {
+					return result, nil
+				}
+			}
+		}
+
+		shipSymbol, steps, failed := t.provision(t.client.WithContext(ctx), plan)
+
+		result := map[string]interface{}{
+			"success":     !failed,
+			"ship_symbol": shipSymbol,
+			"steps":       steps,
+		}
+
+		textSummary := "## 🚀 Ship Provisioning\n\n"
+		if failed {
+			textSummary = "## ⚠️ Ship Provisioning Stopped\n\n"
+		}
+		for _, step := range steps {
+			icon := "✅"
+			switch step.Status {
+			case "failed":
+				icon = "❌"
+			case "skipped":
+				icon = "⏭️"
+			}
+			textSummary += fmt.Sprintf("%s **%s:** %s\n", icon, step.Step, step.Detail)
+		}
+		switch {
+		case failed && shipSymbol == "":
+			textSummary += "\nNothing was bought.\n"
+		case failed:
+			textSummary += fmt.Sprintf("\nThe steps marked ✅ cannot be undone: %s is yours and keeps any parts installed. Fix the problem and finish the remaining steps with the individual tools (buy_cargo, set_flight_mode, navigate_ship) instead of calling provision_ship again, which would buy another ship.\n", shipSymbol)
+		default:
+			textSummary += fmt.Sprintf("\n%s is ready.\n", shipSymbol)
+		}
+
+		ctxLogger.ToolCall("provision_ship", !failed)
+
+		callResult := utils.NewResult(textSummary, result)
+		callResult.IsError = failed
+		return callResult, nil
+	}
+}
+
+// parseProvisionPlan reads the tool arguments, recording any problems with them in v
+func parseProvisionPlan(v *utils.Validator) provisionPlan {
+	plan := provisionPlan{
+		shipType:    v.RequireSymbol("ship_type", utils.ShipTypes),
+		waypoint:    v.RequireWaypoint("waypoint_symbol"),
+		flightMode:  v.OptionalSymbol("flight_mode", utils.FlightModes, ""),
+		destination: v.OptionalWaypoint("destination"),
+	}
+
+	// Mounts and modules are trade symbols, of the kind their prefix names
+	parts := []struct {
+		key    string
+		prefix string
+		into   *[]string
+	}{
+		{"mounts", "MOUNT_", &plan.mounts},
+		{"modules", "MODULE_", &plan.modules},
+	}
+	for _, p := range parts {
+		for _, symbol := range v.OptionalSymbols(p.key, utils.TradeSymbols) {
+			if !strings.HasPrefix(symbol, p.prefix) {
+				v.Fail(p.key, fmt.Sprintf("%s is not a %s", symbol, strings.TrimSuffix(p.key, "s")), fmt.Sprintf("symbols starting %s", p.prefix))
+				continue
+			}
+			*p.into = append(*p.into, symbol)
+		}
+	}
+	return plan
+}
+
+// steps names every step of the plan, in the order they run
+func (plan provisionPlan) steps() []string {
+	names := []string{"Purchase " + plan.shipType}
+	for _, part := range plan.parts() {
+		names = append(names, "Install "+part)
+	}
+	if plan.flightMode != "" {
+		names = append(names, "Set flight mode "+plan.flightMode)
+	}
+	if plan.destination != "" {
+		names = append(names, "Navigate to "+plan.destination)
+	}
+	return names
+}
+
+// parts lists the mounts, then the modules, to install
+func (plan provisionPlan) parts() []string {
+	return append(append([]string{}, plan.mounts...), plan.modules...)
+}
+
+// provision runs the plan step by step, stopping at the first failure. It returns the new ship's
+// symbol (empty if the purchase failed), every step and whether one failed.
+func (t *ProvisionShipTool) provision(c *client.Client, plan provisionPlan) (string, []provisionStep, bool) {
+	names := plan.steps()
+	steps := make([]provisionStep, 0, len(names))
+	done := func(detail string) {
+		steps = append(steps, provisionStep{Step: names[len(steps)], Status: "done", Detail: detail})
+	}
+	// fail records the current step as failed and every later one as skipped
+	fail := func(err error) []provisionStep {
+		steps = append(steps, provisionStep{Step: names[len(steps)], Status: "failed", Detail: err.Error()})
+		for _, name := range names[len(steps):] {
+			steps = append(steps, provisionStep{Step: name, Status: "skipped", Detail: "not attempted after the failure"})
+		}
+		return steps
+	}
+
+	purchase, err := c.PurchaseShip(client.PurchaseShipRequest{ShipType: plan.shipType, WaypointSymbol: plan.waypoint})
+	if err != nil {
+		return "", fail(err), true
+	}
+	ship := purchase.Data.Ship
+	done(fmt.Sprintf("bought %s for %d credits, %d credits left", ship.Symbol, purchase.Data.Transaction.Price, purchase.Data.Agent.Credits))
+
+	cargo := ship.Cargo
+	for _, part := range plan.parts() {
+		if cargoUnits(cargo, part) == 0 {
+			bought, err := c.BuyCargo(ship.Symbol, part, 1)
+			if err != nil {
+				return ship.Symbol, fail(fmt.Errorf("buying it at %s: %w", plan.waypoint, err)), true
+			}
+			cargo = bought.Data.Cargo
+		}
+
+		var installed *client.ShipModificationResponse
+		if strings.HasPrefix(part, "MOUNT_") {
+			installed, err = c.InstallMount(ship.Symbol, part)
+		} else {
+			installed, err = c.InstallShipModule(ship.Symbol, part)
+		}
+		if err != nil {
+			return ship.Symbol, fail(err), true
+		}
+		cargo = installed.Data.Cargo
+		done(fmt.Sprintf("installed for %d credits", installed.Data.Transaction.TotalPrice))
+	}
+
+	if plan.flightMode != "" {
+		if _, err := c.PatchShipNav(ship.Symbol, plan.flightMode); err != nil {
+			return ship.Symbol, fail(err), true
+		}
+		done("flight mode set")
+	}
+
+	if plan.destination != "" {
+		if plan.destination == ship.Nav.WaypointSymbol {
+			done("already there")
+			return ship.Symbol, steps, false
+		}
+		if _, err := c.OrbitShip(ship.Symbol); err != nil {
+			return ship.Symbol, fail(fmt.Errorf("orbiting first: %w", err)), true
+		}
+		navigation, err := c.NavigateShip(ship.Symbol, plan.destination)
+		if err != nil {
+			return ship.Symbol, fail(err), true
+		}
+		done(fmt.Sprintf("in transit, arriving %s", navigation.Data.Nav.Route.Arrival))
+	}
+
+	return ship.Symbol, steps, false
+}
+
+// cargoUnits returns how many units of a symbol are in the cargo hold
+func cargoUnits(cargo client.Cargo, symbol string) int {
+	for _, item := range cargo.Inventory {
+		if item.Symbol == symbol {
+			return item.Units
+		}
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package ships
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+func TestParseProvisionPlan(t *testing.T) {
+	v := utils.NewValidator(map[string]interface{}{
+		"ship_type":       "ship mining drone",
+		"waypoint_symbol": "x1-test-a1",
+		"mounts":          []interface{}{"mount mining laser ii"},
+		"modules":         []interface{}{"MODULE_CARGO_HOLD_I"},
+		"flight_mode":     "burn",
+		"destination":     "X1-TEST-B2",
+	})
+	plan := parseProvisionPlan(v)
+	if errors := v.Errors(); len(errors) > 0 {
+		t.Fatalf("Expected no errors, got %v", errors)
+	}
+	want := []string{"Purchase SHIP_MINING_DRONE", "Install MOUNT_MINING_LASER_II", "Install MODULE_CARGO_HOLD_I", "Set flight mode BURN", "Navigate to X1-TEST-B2"}
+	if got := plan.steps(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected steps %v, got %v", want, got)
+	}
+
+	for field, args := range map[string]map[string]interface{}{
+		"ship_type":       {"waypoint_symbol": "X1-TEST-A1"},
+		"waypoint_symbol": {"ship_type": "SHIP_PROBE"},
+		"mounts":          {"ship_type": "SHIP_PROBE", "waypoint_symbol": "X1-TEST-A1", "mounts": []interface{}{"MODULE_CARGO_HOLD_I"}},
+		"modules[1]":      {"ship_type": "SHIP_PROBE", "waypoint_symbol": "X1-TEST-A1", "modules": []interface{}{"MODULE_CARGO_HOLD_I", "CARGO HOLD"}},
+		"flight_mode":     {"ship_type": "SHIP_PROBE", "waypoint_symbol": "X1-TEST-A1", "flight_mode": "WARP"},
+	} {
+		v := utils.NewValidator(args)
+		parseProvisionPlan(v)
+		if errors := v.Errors(); len(errors) != 1 || errors[0].Field != field {
+			t.Errorf("Expected one error for %s with arguments %v, got %v", field, args, errors)
+		}
+	}
+}
+
+func TestProvisionShipTool_StopsWhenPurchaseFails(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": {"message": "Insufficient credits", "code": 4216}}`))
+	}))
+	defer server.Close()
+
+	tool := NewProvisionShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "provision_ship",
+			Arguments: map[string]interface{}{
+				"ship_type":       "SHIP_MINING_DRONE",
+				"waypoint_symbol": "X1-TEST-A1",
+				"mounts":          []interface{}{"MOUNT_MINING_LASER_II"},
+				"destination":     "X1-TEST-B2",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected an error result")
+	}
+	if requests != 1 {
+		t.Errorf("Expected only the purchase to be attempted, got %d requests", requests)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"❌ **Purchase SHIP_MINING_DRONE:**", "⏭️ **Install MOUNT_MINING_LASER_II:**", "⏭️ **Navigate to X1-TEST-B2:**", "Nothing was bought"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the summary, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestProvisionShipTool_AsksBeforeExpensivePurchase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected no ship bought, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST-A1", "shipTypes": [{"type": "SHIP_MINING_DRONE"}],
+			"ships": [{"type": "SHIP_MINING_DRONE", "name": "Drone", "purchasePrice": 45000}]}}`))
+	}))
+	defer server.Close()
+
+	tool := NewProvisionShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).WithConfirmSpendAbove(10000)
+	session := &decliningSession{}
+	ctx := mcpserver.NewMCPServer("Test Server", "1.0.0").WithContext(context.Background(), session)
+
+	result, err := tool.Handler()(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "provision_ship",
+			Arguments: map[string]interface{}{"ship_type": "SHIP_MINING_DRONE", "waypoint_symbol": "X1-TEST-A1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an error result when the user declines")
+	}
+	if len(session.messages) != 1 || !strings.Contains(session.messages[0], "45000 credits") {
+		t.Errorf("Expected the user to be shown the price, got %v", session.messages)
+	}
+}
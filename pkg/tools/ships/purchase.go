@@ -3,11 +3,11 @@ package ships
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
 	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -17,6 +17,9 @@ import (
 type PurchaseShipTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	policy            *policy.Policy
+	confirmSpendAbove int
 }
 
 // NewPurchaseShipTool creates a new ship purchase tool
@@ -27,6 +30,18 @@ func NewPurchaseShipTool(client *client.Client, logger *logging.Logger) *Purchas
 	}
 }
 
+// WithPolicy makes the tool refuse purchases that break the spending policy
+func (t *PurchaseShipTool) WithPolicy(p *policy.Policy) *PurchaseShipTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the price, in credits, above which the user is asked to confirm the purchase
+func (t *PurchaseShipTool) WithConfirmSpendAbove(credits int) *PurchaseShipTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *PurchaseShipTool) Tool() mcp.Tool {
 	return mcp.Tool{
@@ -46,6 +61,12 @@ func (t *PurchaseShipTool) Tool() mcp.Tool {
 			},
 			Required: []string{"ship_type", "waypoint_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":     map[string]interface{}{"type": "boolean"},
+			"ship":        map[string]interface{}{"type": "object", "description": "The new ship"},
+			"transaction": map[string]interface{}{"type": "object"},
+			"agent":       map[string]interface{}{"type": "object"},
+		}, "success", "ship", "transaction", "agent"),
 	}
 }
 
@@ -56,50 +77,40 @@ func (t *PurchaseShipTool) Handler() func(ctx context.Context, request mcp.CallT
 		ctxLogger := t.logger.WithContext(ctx, "purchase-ship-tool")
 		ctxLogger.Debug("Processing ship purchase request")
 
-		// Parse arguments
-		shipType := ""
-		waypointSymbol := ""
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required arguments: ship_type and waypoint_symbol"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipType := v.RequireSymbol("ship_type", utils.ShipTypes)
+		waypointSymbol := v.RequireWaypoint("waypoint_symbol")
+		if result := v.Result(); result != nil {
+			return result, nil
 		}
 
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if st, exists := argsMap["ship_type"]; exists {
-				if stStr, ok := st.(string); ok {
-					shipType = strings.TrimSpace(stStr)
+		// Check the shipyard's asking price against the spending policy, and have the user
+		// approve expensive ships
+		if t.policy.Enabled() || (t.confirmSpendAbove > 0 && utils.CanConfirm(ctx)) {
+			c := t.client.WithContext(ctx)
+			what := fmt.Sprintf("a %s at %s", shipType, waypointSymbol)
+			price, err := shipPrice(c, waypointSymbol, shipType)
+			switch {
+			case err != nil && t.policy.Enabled():
+				ctxLogger.Error("Failed to look up the price of %s: %v", what, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to check the price of %s against the spending policy: %s", what, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			case err != nil:
+				ctxLogger.Error("Failed to look up the price of %s: %v", what, err)
+			default:
+				if result := checkSpend(c, t.policy, price, what); result != nil {
+					return result, nil
 				}
-			}
-			if ws, exists := argsMap["waypoint_symbol"]; exists {
-				if wsStr, ok := ws.(string); ok {
-					waypointSymbol = strings.TrimSpace(wsStr)
+				if result := confirmSpend(ctx, t.confirmSpendAbove, price, what); result != nil {
+					return result, nil
 				}
 			}
 		}
 
-		if shipType == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_type is required"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if waypointSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ waypoint_symbol is required"),
-				},
-				IsError: true,
-			}, nil
-		}
-
 		ctxLogger.Info("Attempting to purchase %s at %s", shipType, waypointSymbol)
 
 		// Purchase the ship
@@ -108,7 +119,7 @@ func (t *PurchaseShipTool) Handler() func(ctx context.Context, request mcp.CallT
 			ShipType:       shipType,
 			WaypointSymbol: waypointSymbol,
 		}
-		resp, err := t.client.PurchaseShip(req)
+		resp, err := t.client.WithContext(ctx).PurchaseShip(req)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -181,11 +192,6 @@ func (t *PurchaseShipTool) Handler() func(ctx context.Context, request mcp.CallT
 		ctxLogger.ToolCall("purchase_ship", true)
 		ctxLogger.Debug("Purchase ship response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,132 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RefreshShipTool fetches the current state of a single ship
+type RefreshShipTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewRefreshShipTool creates a new refresh ship tool
+func NewRefreshShipTool(client *client.Client, logger *logging.Logger) *RefreshShipTool {
+	return &RefreshShipTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *RefreshShipTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "refresh_ship",
+		Description: "Fetch the current state of one ship (nav status, location, fuel, cargo, cooldown and condition) with a single API call. Cheaper than reading the whole fleet when you only need one ship.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to refresh (e.g., 'MYSHIP-1')",
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":       map[string]interface{}{"type": "string"},
+			"role":              map[string]interface{}{"type": "string"},
+			"status":            map[string]interface{}{"type": "string"},
+			"flight_mode":       map[string]interface{}{"type": "string"},
+			"system_symbol":     map[string]interface{}{"type": "string"},
+			"waypoint_symbol":   map[string]interface{}{"type": "string"},
+			"fuel":              map[string]interface{}{"type": "object"},
+			"cargo":             map[string]interface{}{"type": "object"},
+			"cooldown_seconds":  map[string]interface{}{"type": "integer"},
+			"frame_condition":   map[string]interface{}{"type": "number"},
+			"reactor_condition": map[string]interface{}{"type": "number"},
+			"engine_condition":  map[string]interface{}{"type": "number"},
+			"destination":       map[string]interface{}{"type": "string"},
+			"arrival_seconds":   map[string]interface{}{"type": "integer"},
+		}, "ship_symbol", "status", "system_symbol", "waypoint_symbol", "fuel", "cargo", "cooldown_seconds"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *RefreshShipTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "refresh-ship-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Refreshing ship %s", shipSymbol))
+
+		ship, err := t.client.WithContext(ctx).GetShip(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("refresh_ship", true)
+
+		now := time.Now()
+		cooldownSeconds := int(ship.CooldownRemaining(now).Round(time.Second) / time.Second)
+
+		result := map[string]interface{}{
+			"ship_symbol":       ship.Symbol,
+			"role":              ship.Registration.Role,
+			"status":            ship.Nav.Status,
+			"flight_mode":       ship.Nav.FlightMode,
+			"system_symbol":     ship.Nav.SystemSymbol,
+			"waypoint_symbol":   ship.Nav.WaypointSymbol,
+			"fuel":              ship.Fuel,
+			"cargo":             ship.Cargo,
+			"cooldown_seconds":  cooldownSeconds,
+			"frame_condition":   ship.Frame.Condition,
+			"reactor_condition": ship.Reactor.Condition,
+			"engine_condition":  ship.Engine.Condition,
+		}
+
+		textSummary := fmt.Sprintf("## 🚀 %s (%s)\n\n", ship.Symbol, ship.Registration.Role)
+		textSummary += fmt.Sprintf("**Status:** %s at %s (%s)\n", ship.Nav.Status, ship.Nav.WaypointSymbol, ship.Nav.FlightMode)
+		if ship.Nav.Status == "IN_TRANSIT" {
+			result["destination"] = ship.Nav.Route.Destination.Symbol
+			if arrivalIn, known := ship.ArrivalIn(now); known {
+				arrivalSeconds := int(arrivalIn.Round(time.Second) / time.Second)
+				result["arrival_seconds"] = arrivalSeconds
+				textSummary += fmt.Sprintf("**Destination:** %s, arriving in %ds\n", ship.Nav.Route.Destination.Symbol, arrivalSeconds)
+			} else {
+				textSummary += fmt.Sprintf("**Destination:** %s\n", ship.Nav.Route.Destination.Symbol)
+			}
+		}
+		textSummary += fmt.Sprintf("**Fuel:** %d/%d (%d%%)\n", ship.Fuel.Current, ship.Fuel.Capacity, ship.FuelPercent())
+		textSummary += fmt.Sprintf("**Cargo:** %d/%d units\n", ship.Cargo.Units, ship.Cargo.Capacity)
+		if cooldownSeconds > 0 {
+			textSummary += fmt.Sprintf("**Cooldown:** %ds remaining\n", cooldownSeconds)
+		} else {
+			textSummary += "**Cooldown:** ready\n"
+		}
+		textSummary += fmt.Sprintf("**Condition:** frame %.0f%%, reactor %.0f%%, engine %.0f%%\n",
+			ship.Frame.Condition*100, ship.Reactor.Condition*100, ship.Engine.Condition*100)
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
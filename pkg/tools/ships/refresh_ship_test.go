@@ -0,0 +1,58 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestRefreshShipTool_FetchesSingleShip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/my/ships/HAULER-1" {
+			t.Errorf("Expected GET /my/ships/HAULER-1, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data": {
+			"symbol": "HAULER-1",
+			"registration": {"name": "HAULER-1", "factionSymbol": "COSMIC", "role": "HAULER"},
+			"nav": `+testNav+`,
+			"frame": {"symbol": "FRAME_LIGHT_FREIGHTER", "condition": 0.9, "integrity": 1},
+			"reactor": {"symbol": "REACTOR_CHEMICAL_I", "condition": 1, "integrity": 1},
+			"engine": {"symbol": "ENGINE_ION_DRIVE_I", "condition": 0.75, "integrity": 1},
+			"cooldown": {"shipSymbol": "HAULER-1", "totalSeconds": 0, "remainingSeconds": 0},
+			"cargo": `+cargoJSON(map[string]int{"IRON_ORE": 12})+`,
+			"fuel": {"current": 300, "capacity": 400}
+		}}`, "DOCKED")
+	}))
+	defer server.Close()
+
+	tool := NewRefreshShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "refresh_ship",
+			Arguments: map[string]interface{}{"ship_symbol": "hauler-1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"HAULER-1 (HAULER)", "**Fuel:** 300/400 (75%)", "**Cargo:** 12/40", "**Cooldown:** ready", "engine 75%"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in summary, got %q", want, text)
+		}
+	}
+}
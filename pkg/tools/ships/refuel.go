@@ -3,20 +3,28 @@ package ships
 import (
 	"context"
 	"fmt"
-	"strings"
+	"math"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
 	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// fuelUnitsPerMarketUnit is how much ship fuel one unit of FUEL bought at a market provides
+const fuelUnitsPerMarketUnit = 100
+
 // RefuelShipTool handles refueling ships at fuel stations
 type RefuelShipTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState  bool
+	policy            *policy.Policy
+	confirmSpendAbove int
 }
 
 // NewRefuelShipTool creates a new refuel ship tool
@@ -27,11 +35,29 @@ func NewRefuelShipTool(client *client.Client, logger *logging.Logger) *RefuelShi
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *RefuelShipTool) WithAutoCorrectState(enabled bool) *RefuelShipTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
+// WithPolicy makes the tool refuse fuel purchases that break the spending policy
+func (t *RefuelShipTool) WithPolicy(p *policy.Policy) *RefuelShipTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the fuel bill, in credits, above which the user is asked to confirm the purchase
+func (t *RefuelShipTool) WithConfirmSpendAbove(credits int) *RefuelShipTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *RefuelShipTool) Tool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "refuel_ship",
-		Description: "Refuel a ship at the current waypoint. The ship must be docked at a waypoint with a fuel station or market that sells fuel.",
+		Description: "Refuel a ship at the current waypoint. The ship must be docked at a waypoint with a market that sells fuel, or carry FUEL in its cargo when refueling from cargo. Purchases are checked against the spending policy first. Returns the fuel added, the cost and the updated fuel level.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -41,17 +67,30 @@ func (t *RefuelShipTool) Tool() mcp.Tool {
 				},
 				"units": map[string]interface{}{
 					"type":        "integer",
-					"description": "Optional: Specific amount of fuel units to purchase. If not specified, refuels to full capacity.",
+					"description": "Optional: Specific amount of fuel units to add. If not specified, refuels to full capacity. Markets sell fuel in blocks of 100 units, so the bill is rounded up to whole blocks.",
 					"minimum":     1,
 				},
 				"from_cargo": map[string]interface{}{
 					"type":        "boolean",
-					"description": "Optional: Whether to refuel from cargo instead of purchasing from marketplace. Defaults to false.",
+					"description": "Optional: Whether to refuel from FUEL carried in the ship's cargo instead of purchasing from the marketplace. Defaults to false.",
 					"default":     false,
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"message":          map[string]interface{}{"type": "string"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"fuel":             map[string]interface{}{"type": "object"},
+			"fuel_added":       map[string]interface{}{"type": "integer"},
+			"transaction":      map[string]interface{}{"type": "object"},
+			"agent":            map[string]interface{}{"type": "object"},
+			"state_correction": map[string]interface{}{"type": "string"},
+			"policy_note":      map[string]interface{}{"type": "string"},
+			"fuel_consumed":    map[string]interface{}{"type": "object"},
+		}, "success", "message", "ship_symbol", "fuel", "transaction", "agent"),
 	}
 }
 
@@ -62,66 +101,41 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 		ctxLogger := t.logger.WithContext(ctx, "refuel-ship-tool")
 		ctxLogger.Debug("Processing ship refuel request")
 
-		// Parse arguments
-		shipSymbol := ""
-		units := 0
-		fromCargo := false
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required argument: ship_symbol"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		units := v.OptionalInt("units", 0, 0, math.MaxInt)
+		fromCargo := v.OptionalBool("from_cargo", false)
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if ss, exists := argsMap["ship_symbol"]; exists {
-				if ssStr, ok := ss.(string); ok {
-					shipSymbol = strings.TrimSpace(ssStr)
-				}
-			}
-			if u, exists := argsMap["units"]; exists {
-				if uFloat, ok := u.(float64); ok {
-					units = int(uFloat)
-				} else if uInt, ok := u.(int); ok {
-					units = uInt
-				}
-			}
-			if fc, exists := argsMap["from_cargo"]; exists {
-				if fcBool, ok := fc.(bool); ok {
-					fromCargo = fcBool
-				}
-			}
+		ctxLogger.Info("Attempting to refuel ship %s", shipSymbol)
+		if units > 0 {
+			ctxLogger.Info("Refueling %d units", units)
+		} else {
+			ctxLogger.Info("Refueling to full capacity")
 		}
-
-		if shipSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		if fromCargo {
+			ctxLogger.Info("Refueling from cargo")
 		}
 
-		if units < 0 {
+		// Make sure there is fuel to be had before docking or spending anything
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get ship %s before refueling: %v", shipSymbol, err)
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
-					mcp.NewTextContent("❌ units must be a positive integer if specified"),
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get ship %s before refueling: %s", shipSymbol, err.Error())),
 				},
 				IsError: true,
 			}, nil
 		}
-
-		ctxLogger.Info("Attempting to refuel ship %s", shipSymbol)
-		if units > 0 {
-			ctxLogger.Info("Refueling %d units", units)
-		} else {
-			ctxLogger.Info("Refueling to full capacity")
-		}
-		if fromCargo {
-			ctxLogger.Info("Refueling from cargo")
+		units, policyNote, refusal := t.checkFuelSource(ctx, c, ship, units, fromCargo)
+		if refusal != nil {
+			return refusal, nil
 		}
 
 		// Refuel the ship
@@ -130,7 +144,23 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 		if units > 0 {
 			unitsPtr = &units
 		}
-		resp, err := t.client.RefuelShip(shipSymbol, unitsPtr, fromCargo)
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(c, shipSymbol, utils.StatusDocked)
+			if err != nil {
+				ctxLogger.Error("Failed to dock ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
+		}
+
+		resp, err := c.RefuelShip(shipSymbol, unitsPtr, fromCargo)
 		duration := time.Since(start)
 
 		if err != nil {
@@ -148,6 +178,7 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 		ctxLogger.Info("Successfully refueled ship %s", shipSymbol)
 
 		// Format the response
+		fuelAdded := max(resp.Data.Fuel.Current-ship.Fuel.Current, 0)
 		result := map[string]interface{}{
 			"success":     true,
 			"message":     fmt.Sprintf("Successfully refueled ship %s", shipSymbol),
@@ -156,8 +187,11 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 				"current":  resp.Data.Fuel.Current,
 				"capacity": resp.Data.Fuel.Capacity,
 			},
+			"fuel_added": fuelAdded,
 			"transaction": map[string]interface{}{
 				"waypoint_symbol": resp.Data.Transaction.WaypointSymbol,
+				"units":           resp.Data.Transaction.Units,
+				"price_per_unit":  resp.Data.Transaction.PricePerUnit,
 				"price":           resp.Data.Transaction.TotalPrice,
 				"timestamp":       resp.Data.Transaction.Timestamp,
 			},
@@ -165,9 +199,15 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 				"credits": resp.Data.Agent.Credits,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
+		if policyNote != "" {
+			result["policy_note"] = policyNote
+		}
 
 		// Add fuel consumption details if available
-		if resp.Data.Fuel.Consumed.Amount > 0 {
+		if resp.Data.Fuel.Consumed != nil && resp.Data.Fuel.Consumed.Amount > 0 {
 			result["fuel_consumed"] = map[string]interface{}{
 				"amount":    resp.Data.Fuel.Consumed.Amount,
 				"timestamp": resp.Data.Fuel.Consumed.Timestamp,
@@ -176,21 +216,17 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 
 		jsonData := utils.FormatJSON(result)
 
-		// Calculate fuel purchased and cost per unit
-		fuelPurchased := resp.Data.Fuel.Current - (resp.Data.Fuel.Capacity - resp.Data.Transaction.TotalPrice) // This is an approximation
-		if units > 0 {
-			fuelPurchased = units
-		}
-
-		costPerUnit := 0
-		if fuelPurchased > 0 {
-			costPerUnit = resp.Data.Transaction.TotalPrice / fuelPurchased
-		}
-
 		// Create formatted text summary
 		textSummary := "⛽ **Ship Refuel Successful!**\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
+		if policyNote != "" {
+			textSummary += "⚠️ " + policyNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Location:** %s\n", resp.Data.Transaction.WaypointSymbol)
+		textSummary += fmt.Sprintf("**Fuel Added:** %d units\n", fuelAdded)
 		textSummary += fmt.Sprintf("**Fuel Status:** %d/%d units", resp.Data.Fuel.Current, resp.Data.Fuel.Capacity)
 
 		if resp.Data.Fuel.Current == resp.Data.Fuel.Capacity {
@@ -201,8 +237,8 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 		}
 
 		textSummary += fmt.Sprintf("**Cost:** %d credits", resp.Data.Transaction.TotalPrice)
-		if fuelPurchased > 0 && costPerUnit > 0 {
-			textSummary += fmt.Sprintf(" (%d credits per unit)", costPerUnit)
+		if resp.Data.Transaction.PricePerUnit > 0 {
+			textSummary += fmt.Sprintf(" (%d credits per %d fuel)", resp.Data.Transaction.PricePerUnit, fuelUnitsPerMarketUnit)
 		}
 		textSummary += "\n"
 
@@ -224,11 +260,84 @@ func (t *RefuelShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 		ctxLogger.ToolCall("refuel_ship", true)
 		ctxLogger.Debug("Refuel ship response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// checkFuelSource makes sure the ship can be refueled where it is: from FUEL in its cargo, or
+// from a market at its waypoint that sells fuel, at a price the spending policy allows and
+// the user approves. It returns the units to ask for, a note when the policy cut the order
+// down, and the result to send back when the ship can't be refueled, or nil to go ahead.
+//
+// Filling the tank is never refused outright, so a limit can't strand a ship: when a full
+// tank breaks a limit, the order is cut down to the whole blocks of fuel the policy allows.
+// An explicit number of units is checked as asked.
+func (t *RefuelShipTool) checkFuelSource(ctx context.Context, c *client.Client, ship *client.Ship, units int, fromCargo bool) (int, string, *mcp.CallToolResult) {
+	refusal := func(text string) (int, string, *mcp.CallToolResult) {
+		return 0, "", &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.NewTextContent(text)},
+			IsError: true,
+		}
+	}
+
+	if ship.Fuel.Capacity == 0 {
+		return refusal(fmt.Sprintf("❌ %s has no fuel tank to refuel", ship.Symbol))
+	}
+	wanted := units
+	if wanted == 0 {
+		wanted = ship.Fuel.Capacity - ship.Fuel.Current
+	}
+	if wanted <= 0 {
+		return refusal(fmt.Sprintf("❌ %s's tank is already full (%d/%d); nothing was bought", ship.Symbol, ship.Fuel.Current, ship.Fuel.Capacity))
+	}
+	if ship.Nav.Status == "IN_TRANSIT" {
+		return refusal(fmt.Sprintf("❌ %s is in transit to %s; refuel it once it arrives", ship.Symbol, ship.Nav.WaypointSymbol))
+	}
+
+	if fromCargo {
+		for _, item := range ship.Cargo.Inventory {
+			if item.Symbol == "FUEL" && item.Units > 0 {
+				return units, "", nil
+			}
+		}
+		return refusal(fmt.Sprintf("❌ %s has no FUEL in its cargo to refuel from", ship.Symbol))
+	}
+
+	var fuel *client.MarketTradeGood
+	if market, err := c.GetMarket(ship.Nav.SystemSymbol, ship.Nav.WaypointSymbol); err == nil {
+		fuel = marketTradeGood(market, "FUEL")
+	}
+	if fuel == nil {
+		return refusal(fmt.Sprintf("❌ %s doesn't sell fuel. Use find_nearest to find a market that does, or refuel with from_cargo if %s carries FUEL.", ship.Nav.WaypointSymbol, ship.Symbol))
+	}
+
+	blocks := (wanted + fuelUnitsPerMarketUnit - 1) / fuelUnitsPerMarketUnit
+	note := ""
+	if units == 0 && t.policy.Enabled() {
+		agent, err := c.GetAgent()
+		if err != nil {
+			return refusal(fmt.Sprintf("❌ Failed to check the fuel bill against the spending policy: %s", err.Error()))
+		}
+		allowed := int(t.policy.Allowance(agent.Credits)) / max(fuel.PurchasePrice, 1)
+		if allowed < 1 {
+			return refusal(fmt.Sprintf("🚫 Refused to buy fuel for %s: a block of %d fuel costs %d credits, more than the spending policy allows right now. Nothing was bought.",
+				ship.Symbol, fuelUnitsPerMarketUnit, fuel.PurchasePrice))
+		}
+		if allowed < blocks {
+			blocks = allowed
+			wanted = blocks * fuelUnitsPerMarketUnit
+			units = wanted
+			note = fmt.Sprintf("The spending policy only allows %d fuel right now, so the tank was not filled", wanted)
+		}
+	}
+
+	credits := blocks * fuel.PurchasePrice
+	what := fmt.Sprintf("%d fuel for %s", wanted, ship.Symbol)
+	if result := checkSpend(c, t.policy, credits, what); result != nil {
+		return 0, "", result
+	}
+	if result := confirmSpend(ctx, t.confirmSpendAbove, credits, what); result != nil {
+		return 0, "", result
 	}
+	return units, note, nil
 }
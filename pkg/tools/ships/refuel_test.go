@@ -0,0 +1,138 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// refuelServer serves a docked ship with 100 of 400 fuel at a waypoint whose market lists
+// fuelGood, holding cargo, and counts refuel requests
+func refuelServer(t *testing.T, fuelGood, cargo string, refuels *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/HAULER-1":
+			_, _ = fmt.Fprintf(w, `{"data": {"symbol": "HAULER-1",
+				"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED", "flightMode": "CRUISE"},
+				"fuel": {"current": 100, "capacity": 400},
+				"cargo": {"capacity": 40, "units": 0, "inventory": [%s]}
+			}}`, cargo)
+		case "GET /my/agent":
+			_, _ = w.Write([]byte(`{"data": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 1000, "startingFaction": "COSMIC", "shipCount": 1}}`))
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = fmt.Fprintf(w, `{"data": {"symbol": "X1-TEST-A1", "imports": [], "exports": [], "exchange": [],
+				"tradeGoods": [{"symbol": "%s", "type": "EXCHANGE", "tradeVolume": 100, "supply": "ABUNDANT", "purchasePrice": 80, "sellPrice": 70}]
+			}}`, fuelGood)
+		case "POST /my/ships/HAULER-1/refuel":
+			*refuels++
+			_, _ = w.Write([]byte(`{"data": {
+				"agent": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 760, "startingFaction": "COSMIC", "shipCount": 1},
+				"fuel": {"current": 400, "capacity": 400},
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "HAULER-1", "tradeSymbol": "FUEL", "type": "PURCHASE", "units": 3, "pricePerUnit": 80, "totalPrice": 240, "timestamp": "2025-01-01T12:00:00.000Z"}
+			}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func callRefuel(t *testing.T, tool *RefuelShipTool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "refuel_ship", Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
+
+func TestRefuelShipTool_Handler(t *testing.T) {
+	refuels := 0
+	server := refuelServer(t, "FUEL", "", &refuels)
+	defer server.Close()
+
+	tool := NewRefuelShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result := callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1"})
+	if result.IsError || refuels != 1 {
+		t.Fatalf("Expected the ship to be refueled, got %v", result.Content)
+	}
+	data := result.StructuredContent.(map[string]interface{})
+	if data["fuel_added"] != 300 {
+		t.Errorf("Expected 300 fuel added, got %v", data["fuel_added"])
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "**Cost:** 240 credits (80 credits per 100 fuel)") {
+		t.Errorf("Expected the cost per block of fuel, got %q", text)
+	}
+}
+
+func TestRefuelShipTool_Handler_NeedsFuel(t *testing.T) {
+	refuels := 0
+	server := refuelServer(t, "IRON_ORE", "", &refuels)
+	defer server.Close()
+
+	tool := NewRefuelShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result := callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1"})
+	if !result.IsError || refuels != 0 {
+		t.Fatalf("Expected a market without fuel to be refused, got %v", result.Content)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "doesn't sell fuel") {
+		t.Errorf("Expected the refusal to say the market doesn't sell fuel, got %q", text)
+	}
+
+	result = callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1", "from_cargo": true})
+	if !result.IsError || refuels != 0 {
+		t.Fatalf("Expected refueling from an empty hold to be refused, got %v", result.Content)
+	}
+}
+
+func TestRefuelShipTool_Handler_FromCargo(t *testing.T) {
+	refuels := 0
+	server := refuelServer(t, "IRON_ORE", `{"symbol": "FUEL", "name": "Fuel", "description": "", "units": 3}`, &refuels)
+	defer server.Close()
+
+	tool := NewRefuelShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	if result := callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1", "from_cargo": true}); result.IsError || refuels != 1 {
+		t.Fatalf("Expected the ship to refuel from its cargo, got %v", result.Content)
+	}
+}
+
+func TestRefuelShipTool_Handler_RespectsPolicy(t *testing.T) {
+	refuels := 0
+	server := refuelServer(t, "FUEL", "", &refuels)
+	defer server.Close()
+
+	// 300 fuel is 3 blocks at 80 credits, over the 200 credit limit
+	tool := NewRefuelShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).
+		WithPolicy(policy.New(policy.Limits{MaxPurchase: 200}))
+	result := callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1", "units": float64(300)})
+	if !result.IsError || refuels != 0 {
+		t.Fatalf("Expected 300 fuel over the limit to be refused, got %v", result.Content)
+	}
+
+	// Filling the tank buys the 2 blocks the limit allows instead of stranding the ship
+	result = callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1"})
+	if result.IsError || refuels != 1 {
+		t.Fatalf("Expected the tank to be partly filled, got %v", result.Content)
+	}
+	data := result.StructuredContent.(map[string]interface{})
+	if note, _ := data["policy_note"].(string); !strings.Contains(note, "200 fuel") {
+		t.Errorf("Expected a note that only 200 fuel was allowed, got %q", note)
+	}
+
+	tool.WithPolicy(policy.New(policy.Limits{MaxPurchase: 50}))
+	if result := callRefuel(t, tool, map[string]interface{}{"ship_symbol": "HAULER-1"}); !result.IsError || refuels != 1 {
+		t.Fatalf("Expected a refusal when not even a block of fuel is allowed, got %v", result.Content)
+	}
+}
@@ -3,10 +3,10 @@ package ships
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/policy"
 	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -16,6 +16,10 @@ import (
 type RepairShipTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState  bool
+	policy            *policy.Policy
+	confirmSpendAbove int
 }
 
 // NewRepairShipTool creates a new repair ship tool
@@ -26,11 +30,29 @@ func NewRepairShipTool(client *client.Client, logger *logging.Logger) *RepairShi
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *RepairShipTool) WithAutoCorrectState(enabled bool) *RepairShipTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
+// WithPolicy makes the tool refuse repairs that break the spending policy
+func (t *RepairShipTool) WithPolicy(p *policy.Policy) *RepairShipTool {
+	t.policy = p
+	return t
+}
+
+// WithConfirmSpendAbove sets the repair bill, in credits, above which the user is asked to confirm the repair
+func (t *RepairShipTool) WithConfirmSpendAbove(credits int) *RepairShipTool {
+	t.confirmSpendAbove = credits
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *RepairShipTool) Tool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "repair_ship",
-		Description: "Repair a ship at a shipyard. Ship must be docked at a waypoint with a shipyard.",
+		Description: "Repair a ship at a shipyard. Ship must be docked at a waypoint with a shipyard. Use get_repair_cost first to check the price.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -38,9 +60,21 @@ func (t *RepairShipTool) Tool() mcp.Tool {
 					"type":        "string",
 					"description": "Symbol of the ship to repair (e.g., 'MYSHIP-1')",
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"repair_cost":      map[string]interface{}{"type": "integer"},
+			"agent":            map[string]interface{}{"type": "object"},
+			"ship_condition":   map[string]interface{}{"type": "object"},
+			"transaction":      map[string]interface{}{"type": "object"},
+			"state_correction": map[string]interface{}{"type": "string"},
+			"quoted_cost":      map[string]interface{}{"type": "integer"},
+			"modules":          map[string]interface{}{"type": "array"},
+			"mounts":           map[string]interface{}{"type": "array"},
+		}, "ship_symbol", "repair_cost", "agent", "ship_condition", "transaction"),
 	}
 }
 
@@ -49,32 +83,61 @@ func (t *RepairShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		contextLogger := t.logger.WithContext(ctx, "repair-ship-tool")
 
-		// Extract parameters
-		var shipSymbol string
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["ship_symbol"]; exists {
-					if s, ok := val.(string); ok {
-						shipSymbol = strings.ToUpper(s)
-					}
-				}
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Repairing ship %s", shipSymbol))
+
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusDocked)
+			if err != nil {
+				contextLogger.Error("Failed to dock ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
 			}
+			stateNote = note
 		}
 
-		if shipSymbol == "" {
-			contextLogger.Error("Missing ship_symbol parameter")
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("Error: ship_symbol parameter is required"),
-				},
-				IsError: true,
-			}, nil
+		// Quote first so the response shows what the repair was expected to cost
+		quote, quoteErr := t.client.WithContext(ctx).GetRepairQuote(shipSymbol)
+		if quoteErr != nil {
+			contextLogger.Debug(fmt.Sprintf("Could not get repair quote for %s: %v", shipSymbol, quoteErr))
 		}
 
-		contextLogger.Info(fmt.Sprintf("Repairing ship %s", shipSymbol))
+		// The quote is what the spending policy is checked against
+		if t.policy.Enabled() {
+			if quoteErr != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to check the repair of %s against the spending policy: %v", shipSymbol, quoteErr)),
+					},
+					IsError: true,
+				}, nil
+			}
+			if result := checkSpend(t.client.WithContext(ctx), t.policy, quote.TotalPrice, fmt.Sprintf("a repair of %s", shipSymbol)); result != nil {
+				return result, nil
+			}
+		}
+		// Expensive repairs are shown to the user for approval
+		if quoteErr == nil {
+			if result := confirmSpend(ctx, t.confirmSpendAbove, quote.TotalPrice, fmt.Sprintf("a repair of %s", shipSymbol)); result != nil {
+				return result, nil
+			}
+		}
 
 		// Perform the repair
-		resp, err := t.client.RepairShip(shipSymbol)
+		resp, err := t.client.WithContext(ctx).RepairShip(shipSymbol)
 		if err != nil {
 			contextLogger.Error(fmt.Sprintf("Failed to repair ship %s: %v", shipSymbol, err))
 			return &mcp.CallToolResult{
@@ -108,6 +171,13 @@ func (t *RepairShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 				"timestamp":       resp.Data.Transaction.Timestamp,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
+
+		if quote != nil {
+			result["quoted_cost"] = quote.TotalPrice
+		}
 
 		// Add module and mount integrity information
 		if len(resp.Data.Ship.Modules) > 0 {
@@ -134,10 +204,16 @@ func (t *RepairShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 
 		// Create text summary
 		textSummary := fmt.Sprintf("## 🔧 Ship Repair Complete for %s\n\n", shipSymbol)
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("✅ **Ship successfully repaired** at %s\n\n", resp.Data.Transaction.WaypointSymbol)
 
 		// Financial summary
 		textSummary += "## 💰 Financial Summary\n\n"
+		if quote != nil {
+			textSummary += fmt.Sprintf("**Quoted Cost:** %d credits\n", quote.TotalPrice)
+		}
 		textSummary += fmt.Sprintf("**Repair Cost:** %d credits\n", resp.Data.Transaction.TotalPrice)
 		textSummary += fmt.Sprintf("**Remaining Credits:** %d credits\n", resp.Data.Agent.Credits)
 		textSummary += fmt.Sprintf("**Agent:** %s\n\n", resp.Data.Agent.Symbol)
@@ -210,11 +286,6 @@ func (t *RepairShipTool) Handler() func(ctx context.Context, request mcp.CallToo
 		textSummary += "- Repair before integrity drops below 50%\n"
 		textSummary += "- Consider upgrading components for better durability\n"
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", utils.FormatJSON(result))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
@@ -0,0 +1,90 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetRepairCostTool quotes what repairing a ship would cost without repairing it
+type GetRepairCostTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewGetRepairCostTool creates a new repair cost quote tool
+func NewGetRepairCostTool(client *client.Client, logger *logging.Logger) *GetRepairCostTool {
+	return &GetRepairCostTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *GetRepairCostTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "get_repair_cost",
+		Description: "Get how many credits repairing a ship would cost at its current shipyard, without repairing it. Ship must be docked at a waypoint with a shipyard.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to quote (e.g., 'MYSHIP-1')",
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":     map[string]interface{}{"type": "string"},
+			"repair_cost":     map[string]interface{}{"type": "integer"},
+			"waypoint_symbol": map[string]interface{}{"type": "string"},
+		}, "ship_symbol", "repair_cost", "waypoint_symbol"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *GetRepairCostTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "get-repair-cost-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Getting repair cost for ship %s", shipSymbol))
+
+		quote, err := t.client.WithContext(ctx).GetRepairQuote(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get repair cost for ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get repair cost for ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("get_repair_cost", true)
+
+		result := map[string]interface{}{
+			"ship_symbol":     shipSymbol,
+			"repair_cost":     quote.TotalPrice,
+			"waypoint_symbol": quote.WaypointSymbol,
+		}
+
+		textSummary := fmt.Sprintf("## 🔧 Repair Cost for %s\n\n", shipSymbol)
+		textSummary += fmt.Sprintf("**Cost:** %d credits at %s\n\n", quote.TotalPrice, quote.WaypointSymbol)
+		textSummary += "Nothing has been repaired. To repair the ship, call `repair_ship`.\n"
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
@@ -0,0 +1,84 @@
+package ships
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+func TestGetRepairCostTool_QuotesWithoutRepairing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/my/ships/HAULER-1/repair" {
+			t.Errorf("Expected GET /my/ships/HAULER-1/repair, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"transaction": {
+			"waypointSymbol": "X1-TEST-A1",
+			"shipSymbol": "HAULER-1",
+			"totalPrice": 1250,
+			"timestamp": "2024-01-01T00:00:00.000Z"
+		}}}`))
+	}))
+	defer server.Close()
+
+	tool := NewGetRepairCostTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "get_repair_cost",
+			Arguments: map[string]interface{}{"ship_symbol": "hauler-1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "1250 credits at X1-TEST-A1") {
+		t.Errorf("Expected quoted cost in summary, got %q", text)
+	}
+}
+
+func TestRepairShipTool_AsksBeforeExpensiveRepair(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected the ship not to be repaired, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"transaction": {
+			"waypointSymbol": "X1-TEST-A1",
+			"shipSymbol": "HAULER-1",
+			"totalPrice": 12500,
+			"timestamp": "2024-01-01T00:00:00.000Z"
+		}}}`))
+	}))
+	defer server.Close()
+
+	tool := NewRepairShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).WithConfirmSpendAbove(5000)
+	session := &decliningSession{}
+	ctx := mcpserver.NewMCPServer("Test Server", "1.0.0").WithContext(context.Background(), session)
+
+	result, err := tool.Handler()(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "repair_ship", Arguments: map[string]interface{}{"ship_symbol": "HAULER-1"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an error result when the user declines")
+	}
+	if len(session.messages) != 1 || !strings.Contains(session.messages[0], "12500 credits") {
+		t.Errorf("Expected the user to be shown the repair bill, got %v", session.messages)
+	}
+}
@@ -0,0 +1,167 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ScrapShipTool permanently scraps a ship for credits
+type ScrapShipTool struct {
+	client *client.Client
+	logger *logging.Logger
+
+	autoCorrectState bool
+}
+
+// NewScrapShipTool creates a new scrap ship tool
+func NewScrapShipTool(client *client.Client, logger *logging.Logger) *ScrapShipTool {
+	return &ScrapShipTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *ScrapShipTool) WithAutoCorrectState(enabled bool) *ScrapShipTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *ScrapShipTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "scrap_ship",
+		Description: "Permanently scrap a ship for credits. This cannot be undone, so confirm must be true. Ship must be docked at a waypoint with a shipyard; use get_scrap_value first to see what it pays.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to scrap (e.g., 'MYSHIP-1')",
+				},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true to scrap the ship. Scrapping is irreversible.",
+				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
+			},
+			Required: []string{"ship_symbol", "confirm"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"credits_earned":   map[string]interface{}{"type": "integer"},
+			"agent":            map[string]interface{}{"type": "object"},
+			"transaction":      map[string]interface{}{"type": "object"},
+			"state_correction": map[string]interface{}{"type": "string"},
+		}, "ship_symbol", "credits_earned", "agent", "transaction"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *ScrapShipTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "scrap-ship-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		confirmed := v.OptionalBool("confirm", false)
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		if !confirmed {
+			contextLogger.Info(fmt.Sprintf("Refusing to scrap ship %s without confirmation", shipSymbol))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Error: scrapping %s is irreversible. Check the payout with get_scrap_value, then call scrap_ship again with confirm set to true.", shipSymbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Show the user the exact payout and let them approve it, rather than trusting confirm alone
+		if utils.CanConfirm(ctx) {
+			message := fmt.Sprintf("Scrap ship %s? It is removed from the fleet for good.", shipSymbol)
+			if quote, err := t.client.WithContext(ctx).GetScrapValue(shipSymbol); err == nil {
+				message = fmt.Sprintf("Scrap ship %s at %s for %d credits? It is removed from the fleet for good.",
+					shipSymbol, quote.WaypointSymbol, quote.TotalPrice)
+			}
+			approved, err := utils.Confirm(ctx, message)
+			if err != nil {
+				contextLogger.Error("Failed to confirm scrapping ship %s: %v", shipSymbol, err)
+			}
+			if !approved {
+				return utils.NotConfirmedResult(fmt.Sprintf("scrapping %s", shipSymbol)), nil
+			}
+		}
+
+		contextLogger.Info(fmt.Sprintf("Scrapping ship %s", shipSymbol))
+
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusDocked)
+			if err != nil {
+				contextLogger.Error("Failed to dock ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
+		}
+
+		resp, err := t.client.WithContext(ctx).ScrapShip(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to scrap ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to scrap ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("scrap_ship", true)
+		contextLogger.Info(fmt.Sprintf("Scrapped ship %s for %d credits", shipSymbol, resp.Data.Transaction.TotalPrice))
+
+		result := map[string]interface{}{
+			"ship_symbol":    shipSymbol,
+			"credits_earned": resp.Data.Transaction.TotalPrice,
+			"agent": map[string]interface{}{
+				"symbol":  resp.Data.Agent.Symbol,
+				"credits": resp.Data.Agent.Credits,
+			},
+			"transaction": map[string]interface{}{
+				"waypoint_symbol": resp.Data.Transaction.WaypointSymbol,
+				"ship_symbol":     resp.Data.Transaction.ShipSymbol,
+				"price":           resp.Data.Transaction.TotalPrice,
+				"timestamp":       resp.Data.Transaction.Timestamp,
+			},
+		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
+
+		textSummary := fmt.Sprintf("## ♻️ Ship %s Scrapped\n\n", shipSymbol)
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
+		textSummary += fmt.Sprintf("**Credits Earned:** %d\n", resp.Data.Transaction.TotalPrice)
+		textSummary += fmt.Sprintf("**Remaining Credits:** %d\n", resp.Data.Agent.Credits)
+		textSummary += fmt.Sprintf("**Location:** %s\n\n", resp.Data.Transaction.WaypointSymbol)
+		textSummary += "The ship has been removed from your fleet.\n"
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
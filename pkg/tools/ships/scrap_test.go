@@ -0,0 +1,90 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+func TestScrapShipTool_RequiresConfirm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no API call without confirmation, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	tool := NewScrapShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	for _, args := range []map[string]interface{}{
+		{"ship_symbol": "PROBE-1"},
+		{"ship_symbol": "PROBE-1", "confirm": false},
+		{"ship_symbol": "PROBE-1", "confirm": "true"},
+	} {
+		result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "scrap_ship", Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("Expected an error result for arguments %v", args)
+		}
+	}
+}
+
+// decliningSession is a client session with elicitation that turns down every confirmation
+type decliningSession struct {
+	messages []string
+}
+
+func (s *decliningSession) Initialize()                                         {}
+func (s *decliningSession) Initialized() bool                                   { return true }
+func (s *decliningSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *decliningSession) SessionID() string                                   { return "test-session" }
+func (s *decliningSession) GetClientInfo() mcp.Implementation                   { return mcp.Implementation{} }
+func (s *decliningSession) SetClientInfo(mcp.Implementation)                    {}
+func (s *decliningSession) SetClientCapabilities(mcp.ClientCapabilities)        {}
+func (s *decliningSession) GetClientCapabilities() mcp.ClientCapabilities {
+	return mcp.ClientCapabilities{Elicitation: &mcp.ElicitationCapability{}}
+}
+
+func (s *decliningSession) RequestElicitation(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	s.messages = append(s.messages, request.Params.Message)
+	return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}}, nil
+}
+
+func TestScrapShipTool_UserDeclines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected the ship not to be scrapped, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": {"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "PROBE-1", "totalPrice": 1200, "timestamp": "2025-01-01T00:00:00Z"}}}`)
+	}))
+	defer server.Close()
+
+	tool := NewScrapShipTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	session := &decliningSession{}
+	ctx := mcpserver.NewMCPServer("Test Server", "1.0.0").WithContext(context.Background(), session)
+
+	result, err := tool.Handler()(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "scrap_ship", Arguments: map[string]interface{}{"ship_symbol": "PROBE-1", "confirm": true}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an error result when the user declines")
+	}
+	if len(session.messages) != 1 || !strings.Contains(session.messages[0], "1200 credits") {
+		t.Errorf("Expected the user to be shown the payout, got %v", session.messages)
+	}
+}
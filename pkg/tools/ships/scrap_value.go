@@ -0,0 +1,90 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetScrapValueTool quotes what scrapping a ship would pay without scrapping it
+type GetScrapValueTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewGetScrapValueTool creates a new scrap value quote tool
+func NewGetScrapValueTool(client *client.Client, logger *logging.Logger) *GetScrapValueTool {
+	return &GetScrapValueTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *GetScrapValueTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "get_scrap_value",
+		Description: "Get how many credits scrapping a ship would pay at its current shipyard, without scrapping it. Ship must be docked at a waypoint with a shipyard.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to quote (e.g., 'MYSHIP-1')",
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":     map[string]interface{}{"type": "string"},
+			"scrap_value":     map[string]interface{}{"type": "integer"},
+			"waypoint_symbol": map[string]interface{}{"type": "string"},
+		}, "ship_symbol", "scrap_value", "waypoint_symbol"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *GetScrapValueTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "get-scrap-value-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		contextLogger.Info(fmt.Sprintf("Getting scrap value for ship %s", shipSymbol))
+
+		quote, err := t.client.WithContext(ctx).GetScrapValue(shipSymbol)
+		if err != nil {
+			contextLogger.Error(fmt.Sprintf("Failed to get scrap value for ship %s: %v", shipSymbol, err))
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get scrap value for ship %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		contextLogger.ToolCall("get_scrap_value", true)
+
+		result := map[string]interface{}{
+			"ship_symbol":     shipSymbol,
+			"scrap_value":     quote.TotalPrice,
+			"waypoint_symbol": quote.WaypointSymbol,
+		}
+
+		textSummary := fmt.Sprintf("## ♻️ Scrap Value for %s\n\n", shipSymbol)
+		textSummary += fmt.Sprintf("**Value:** %d credits at %s\n\n", quote.TotalPrice, quote.WaypointSymbol)
+		textSummary += "Nothing has been scrapped. To retire the ship, call `scrap_ship` with `confirm: true` - this cannot be undone.\n"
+
+		return utils.NewResult(textSummary, result), nil
+	}
+}
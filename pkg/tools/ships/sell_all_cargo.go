@@ -0,0 +1,238 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/trade"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SellAllCargoTool sells everything in a ship's hold that the local market buys
+type SellAllCargoTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewSellAllCargoTool creates a new sell all cargo tool
+func NewSellAllCargoTool(client *client.Client, logger *logging.Logger) *SellAllCargoTool {
+	return &SellAllCargoTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *SellAllCargoTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "sell_all_cargo",
+		Description: "Sell all cargo the local marketplace buys, docking first if needed. Large stacks are sold in chunks of the market's trade volume. Goods the market does not trade are kept.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to sell cargo from (e.g., 'SHIP_1234')",
+				},
+				"except": map[string]interface{}{
+					"type":        "array",
+					"description": "Cargo symbols to keep on board (e.g., ['FUEL', 'ANTIMATTER'])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":     map[string]interface{}{"type": "string"},
+			"waypoint_symbol": map[string]interface{}{"type": "string"},
+			"total_credits":   map[string]interface{}{"type": "integer"},
+			"sales":           map[string]interface{}{"type": "array", "description": "One entry per good sold"},
+			"skipped":         map[string]interface{}{"type": "array", "description": "Goods not sold and why"},
+			"failures":        map[string]interface{}{"type": "array"},
+			"cargo":           map[string]interface{}{"type": "object", "description": "Ship cargo after selling"},
+		}, "ship_symbol", "total_credits", "sales"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *SellAllCargoTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "sell-all-cargo-tool")
+		ctxLogger.Debug("Processing sell all cargo request")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		except := make(map[string]bool)
+		for _, symbol := range v.OptionalSymbols("except", utils.TradeSymbols) {
+			except[symbol] = true
+		}
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		c := t.client.WithContext(ctx)
+
+		cargo, err := c.GetShipCargo(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get cargo for ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get cargo for ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		if len(cargo.Inventory) == 0 {
+			result := map[string]interface{}{
+				"ship_symbol":   shipSymbol,
+				"total_credits": 0,
+				"sales":         []*trade.Order{},
+			}
+			return utils.NewResult(fmt.Sprintf("📦 Ship %s has no cargo to sell.", shipSymbol), result), nil
+		}
+
+		nav, err := c.GetShipNav(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get nav for ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get location of ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		if nav.Status == "IN_TRANSIT" {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Ship %s is in transit to %s and cannot trade until it arrives", shipSymbol, nav.Route.Destination.Symbol)),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		market, err := c.GetMarket(nav.SystemSymbol, nav.WaypointSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get market at %s: %v", nav.WaypointSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ No marketplace found at %s: %s", nav.WaypointSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		// Decide what to sell before docking, so a hold of unsellable goods costs no API calls
+		type skippedGood struct {
+			Symbol string `json:"symbol"`
+			Units  int    `json:"units"`
+			Reason string `json:"reason"`
+		}
+		var toSell []client.CargoItem
+		skipped := make([]skippedGood, 0)
+		for _, item := range cargo.Inventory {
+			switch {
+			case except[item.Symbol]:
+				skipped = append(skipped, skippedGood{item.Symbol, item.Units, "excluded"})
+			case marketTradeGood(market, item.Symbol) == nil:
+				skipped = append(skipped, skippedGood{item.Symbol, item.Units, "not traded at " + nav.WaypointSymbol})
+			default:
+				toSell = append(toSell, item)
+			}
+		}
+
+		if len(toSell) > 0 && nav.Status != "DOCKED" {
+			if _, err := c.DockShip(shipSymbol); err != nil {
+				ctxLogger.Error("Failed to dock ship %s: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+		}
+
+		sales := make([]*trade.Order, 0)
+		failures := make([]string, 0)
+		totalCredits := 0
+		var credits int64
+		remaining := *cargo
+		for _, item := range toSell {
+			tradeVolume := marketTradeGood(market, item.Symbol).TradeVolume
+			order, err := sellInChunks(c, shipSymbol, item.Symbol, item.Units, tradeVolume)
+			if order.Units > 0 {
+				sales = append(sales, order)
+				totalCredits += order.TotalPrice
+				credits = order.Credits
+				remaining = order.Cargo
+			}
+			if err != nil {
+				ctxLogger.Error("Failed to sell %s from ship %s: %v", item.Symbol, shipSymbol, err)
+				failures = append(failures, err.Error())
+			}
+		}
+
+		ctxLogger.Info("Sold %d goods from ship %s for %d credits", len(sales), shipSymbol, totalCredits)
+
+		result := map[string]interface{}{
+			"ship_symbol":     shipSymbol,
+			"waypoint_symbol": nav.WaypointSymbol,
+			"total_credits":   totalCredits,
+			"sales":           sales,
+			"skipped":         skipped,
+			"failures":        failures,
+			"cargo": map[string]interface{}{
+				"capacity":  remaining.Capacity,
+				"units":     remaining.Units,
+				"inventory": remaining.Inventory,
+			},
+		}
+		if len(sales) > 0 {
+			result["agent_credits"] = credits
+		}
+
+		textSummary := fmt.Sprintf("💰 **Cargo Sold at %s**\n\n", nav.WaypointSymbol)
+		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
+		if len(sales) > 0 {
+			textSummary += "\n**Sales:**\n"
+			for _, sale := range sales {
+				textSummary += fmt.Sprintf("- %s: %d units for %d credits (avg %.1f/unit", sale.Good, sale.Units, sale.TotalPrice, sale.AveragePrice)
+				if len(sale.Transactions) > 1 {
+					textSummary += fmt.Sprintf(", %d transactions", len(sale.Transactions))
+				}
+				textSummary += ")\n"
+			}
+			textSummary += fmt.Sprintf("\n**Total Revenue:** %d credits\n", totalCredits)
+			textSummary += fmt.Sprintf("**Current Credits:** %d\n", credits)
+		} else {
+			textSummary += "\nNothing was sold.\n"
+		}
+
+		if len(skipped) > 0 {
+			textSummary += "\n**Kept on Board:**\n"
+			for _, good := range skipped {
+				textSummary += fmt.Sprintf("- %s: %d units (%s)\n", good.Symbol, good.Units, good.Reason)
+			}
+		}
+		if len(failures) > 0 {
+			textSummary += "\n⚠️ **Failed Sales:**\n"
+			for _, failure := range failures {
+				textSummary += fmt.Sprintf("- %s\n", failure)
+			}
+		}
+		textSummary += fmt.Sprintf("\n**Cargo Status:** %d/%d units\n", remaining.Units, remaining.Capacity)
+
+		ctxLogger.ToolCall("sell_all_cargo", len(failures) == 0)
+
+		callResult := utils.NewResult(textSummary, result)
+		callResult.IsError = len(sales) == 0 && len(failures) > 0
+		return callResult, nil
+	}
+}
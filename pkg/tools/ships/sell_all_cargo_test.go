@@ -0,0 +1,112 @@
+package ships
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const testNav = `{
+	"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "%s", "flightMode": "CRUISE",
+	"route": {
+		"origin": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+		"destination": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+		"departureTime": "2024-01-01T00:00:00.000Z", "arrival": "2024-01-01T00:00:00.000Z"
+	}
+}`
+
+func TestSellAllCargoTool_SellsInTradeVolumeChunks(t *testing.T) {
+	inventory := map[string]int{"IRON_ORE": 25, "QUARTZ_SAND": 5, "FUEL": 3}
+	var docked bool
+	var sales []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/HAULER-1/cargo":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, cargoJSON(inventory))
+		case "GET /my/ships/HAULER-1/nav":
+			_, _ = fmt.Fprintf(w, `{"data": `+testNav+`}`, "IN_ORBIT")
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = w.Write([]byte(`{"data": {
+				"symbol": "X1-TEST-A1", "exports": [], "exchange": [],
+				"imports": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": ""}, {"symbol": "FUEL", "name": "Fuel", "description": ""}],
+				"tradeGoods": [
+					{"symbol": "IRON_ORE", "type": "IMPORT", "tradeVolume": 10, "supply": "SCARCE", "purchasePrice": 60, "sellPrice": 50},
+					{"symbol": "FUEL", "type": "IMPORT", "tradeVolume": 100, "supply": "SCARCE", "purchasePrice": 80, "sellPrice": 70}
+				]
+			}}`))
+		case "POST /my/ships/HAULER-1/dock":
+			docked = true
+			_, _ = fmt.Fprintf(w, `{"data": {"nav": `+testNav+`}}`, "DOCKED")
+		case "POST /my/ships/HAULER-1/sell":
+			if !docked {
+				t.Error("Expected the ship to dock before selling")
+			}
+			var req struct {
+				Symbol string `json:"symbol"`
+				Units  int    `json:"units"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			sales = append(sales, fmt.Sprintf("%s:%d", req.Symbol, req.Units))
+			inventory[req.Symbol] -= req.Units
+			_, _ = fmt.Fprintf(w, `{"data": {
+				"agent": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 1000, "startingFaction": "COSMIC", "shipCount": 1},
+				"cargo": %s,
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "HAULER-1", "tradeSymbol": %q, "type": "SELL", "units": %d, "pricePerUnit": 50, "totalPrice": %d, "timestamp": "2024-01-01T00:00:00.000Z"}
+			}}`, cargoJSON(inventory), req.Symbol, req.Units, req.Units*50)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewSellAllCargoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "sell_all_cargo",
+			Arguments: map[string]interface{}{"ship_symbol": "hauler-1", "except": []interface{}{"fuel"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	if got := strings.Join(sales, ","); got != "IRON_ORE:10,IRON_ORE:10,IRON_ORE:5" {
+		t.Errorf("Expected iron ore sold in chunks of 10 and nothing else, got %s", got)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"IRON_ORE: 25 units for 1250 credits", "3 transactions", "QUARTZ_SAND: 5 units (not traded at X1-TEST-A1)", "FUEL: 3 units (excluded)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+// cargoJSON renders a cargo hold holding the given units of each good
+func cargoJSON(inventory map[string]int) string {
+	var items []string
+	total := 0
+	for _, symbol := range []string{"IRON_ORE", "QUARTZ_SAND", "FUEL"} {
+		if units := inventory[symbol]; units > 0 {
+			items = append(items, fmt.Sprintf(`{"symbol": %q, "name": %q, "description": "", "units": %d}`, symbol, symbol, units))
+			total += units
+		}
+	}
+	return fmt.Sprintf(`{"capacity": 40, "units": %d, "inventory": [%s]}`, total, strings.Join(items, ","))
+}
@@ -3,7 +3,6 @@ package ships
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"spacetraders-mcp/pkg/client"
@@ -17,6 +16,8 @@ import (
 type SellCargoTool struct {
 	client *client.Client
 	logger *logging.Logger
+
+	autoCorrectState bool
 }
 
 // NewSellCargoTool creates a new sell cargo tool
@@ -27,11 +28,17 @@ func NewSellCargoTool(client *client.Client, logger *logging.Logger) *SellCargoT
 	}
 }
 
+// WithAutoCorrectState sets whether the tool docks or orbits the ship first when auto_correct_state is not specified
+func (t *SellCargoTool) WithAutoCorrectState(enabled bool) *SellCargoTool {
+	t.autoCorrectState = enabled
+	return t
+}
+
 // Tool returns the MCP tool definition
 func (t *SellCargoTool) Tool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "sell_cargo",
-		Description: "Sell cargo from a ship at a marketplace. Ship must be docked at a waypoint with a marketplace that accepts the cargo type.",
+		Description: "Sell cargo from a ship at a marketplace. Ship must be docked at a waypoint with a marketplace that accepts the cargo type. Sales above the market's trade volume are split into several transactions automatically.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -48,9 +55,23 @@ func (t *SellCargoTool) Tool() mcp.Tool {
 					"description": "Number of units to sell",
 					"minimum":     1,
 				},
+				"auto_correct_state": utils.AutoCorrectStateProperty(t.autoCorrectState),
 			},
 			Required: []string{"ship_symbol", "cargo_symbol", "units"},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"success":          map[string]interface{}{"type": "boolean"},
+			"message":          map[string]interface{}{"type": "string"},
+			"ship_symbol":      map[string]interface{}{"type": "string"},
+			"cargo_symbol":     map[string]interface{}{"type": "string"},
+			"units_sold":       map[string]interface{}{"type": "integer"},
+			"transaction":      map[string]interface{}{"type": "object", "description": "Totals over every transaction"},
+			"transactions":     map[string]interface{}{"type": "array", "description": "Individual market transactions"},
+			"price_slippage":   map[string]interface{}{"type": "integer"},
+			"cargo":            map[string]interface{}{"type": "object"},
+			"agent":            map[string]interface{}{"type": "object"},
+			"state_correction": map[string]interface{}{"type": "string"},
+		}, "success", "message", "ship_symbol", "cargo_symbol", "units_sold", "transaction", "transactions", "cargo", "agent"),
 	}
 }
 
@@ -61,75 +82,41 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 		ctxLogger := t.logger.WithContext(ctx, "sell-cargo-tool")
 		ctxLogger.Debug("Processing cargo sell request")
 
-		// Parse arguments
-		shipSymbol := ""
-		cargoSymbol := ""
-		units := 0
-
-		if request.Params.Arguments == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ Missing required arguments: ship_symbol, cargo_symbol, and units"),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-			if ss, exists := argsMap["ship_symbol"]; exists {
-				if ssStr, ok := ss.(string); ok {
-					shipSymbol = strings.TrimSpace(ssStr)
-				}
-			}
-			if cs, exists := argsMap["cargo_symbol"]; exists {
-				if csStr, ok := cs.(string); ok {
-					cargoSymbol = strings.TrimSpace(strings.ToUpper(csStr))
-				}
-			}
-			if u, exists := argsMap["units"]; exists {
-				if uFloat, ok := u.(float64); ok {
-					units = int(uFloat)
-				} else if uInt, ok := u.(int); ok {
-					units = uInt
-				}
-			}
-		}
-
-		if shipSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ ship_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		cargoSymbol := v.RequireSymbol("cargo_symbol", utils.TradeSymbols)
+		units := v.RequireInt("units", 1)
+		autoCorrectState := v.OptionalBool("auto_correct_state", t.autoCorrectState)
+		if result := v.Result(); result != nil {
+			return result, nil
 		}
 
-		if cargoSymbol == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ cargo_symbol is required and must be a non-empty string"),
-				},
-				IsError: true,
-			}, nil
-		}
+		ctxLogger.Info("Attempting to sell %d units of %s from ship %s", units, cargoSymbol, shipSymbol)
 
-		if units <= 0 {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent("❌ units must be a positive integer"),
-				},
-				IsError: true,
-			}, nil
+		// Get the ship into the right state first if requested
+		stateNote := ""
+		if autoCorrectState {
+			note, err := utils.EnsureShipState(t.client.WithContext(ctx), shipSymbol, utils.StatusDocked)
+			if err != nil {
+				ctxLogger.Error("Failed to dock ship %s before the action: %v", shipSymbol, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to dock ship %s first: %s", shipSymbol, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			stateNote = note
 		}
 
-		ctxLogger.Info("Attempting to sell %d units of %s from ship %s", units, cargoSymbol, shipSymbol)
-
-		// Sell the cargo
+		// Sell the cargo, split into chunks the market accepts
+		c := t.client.WithContext(ctx)
+		tradeVolume := marketTradeVolume(c, shipSymbol, cargoSymbol)
 		start := time.Now()
-		resp, err := t.client.SellCargo(shipSymbol, cargoSymbol, units)
+		order, err := sellInChunks(c, shipSymbol, cargoSymbol, units, tradeVolume)
 		duration := time.Since(start)
 
-		if err != nil {
+		if order.Units == 0 {
 			ctxLogger.Error("Failed to sell cargo: %v", err)
 			ctxLogger.APICall(fmt.Sprintf("/my/ships/%s/sell", shipSymbol), 0, duration.String())
 			return &mcp.CallToolResult{
@@ -139,9 +126,16 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 				IsError: true,
 			}, nil
 		}
+		if err != nil {
+			ctxLogger.Error("Sale stopped partway: %v", err)
+		}
+
+		transaction := order.Summary()
+		cargo := order.Cargo
+		units = order.Units
 
 		ctxLogger.APICall(fmt.Sprintf("/my/ships/%s/sell", shipSymbol), 201, duration.String())
-		ctxLogger.Info("Successfully sold %d units of %s from ship %s for %d credits", units, cargoSymbol, shipSymbol, resp.Data.Transaction.TotalPrice)
+		ctxLogger.Info("Successfully sold %d units of %s from ship %s for %d credits", units, cargoSymbol, shipSymbol, transaction.TotalPrice)
 
 		// Format the response
 		result := map[string]interface{}{
@@ -151,21 +145,23 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 			"cargo_symbol": cargoSymbol,
 			"units_sold":   units,
 			"transaction": map[string]interface{}{
-				"waypoint_symbol": resp.Data.Transaction.WaypointSymbol,
-				"ship_symbol":     resp.Data.Transaction.ShipSymbol,
-				"trade_symbol":    resp.Data.Transaction.TradeSymbol,
-				"type":            resp.Data.Transaction.Type,
-				"units":           resp.Data.Transaction.Units,
-				"price_per_unit":  resp.Data.Transaction.PricePerUnit,
-				"total_price":     resp.Data.Transaction.TotalPrice,
-				"timestamp":       resp.Data.Transaction.Timestamp,
+				"waypoint_symbol": transaction.WaypointSymbol,
+				"ship_symbol":     transaction.ShipSymbol,
+				"trade_symbol":    transaction.TradeSymbol,
+				"type":            transaction.Type,
+				"units":           transaction.Units,
+				"price_per_unit":  transaction.PricePerUnit,
+				"total_price":     transaction.TotalPrice,
+				"timestamp":       transaction.Timestamp,
 			},
+			"transactions":   order.Transactions,
+			"price_slippage": order.PriceSlippage,
 			"cargo": map[string]interface{}{
-				"capacity": resp.Data.Cargo.Capacity,
-				"units":    resp.Data.Cargo.Units,
+				"capacity": cargo.Capacity,
+				"units":    cargo.Units,
 				"inventory": func() []map[string]interface{} {
-					inventory := make([]map[string]interface{}, len(resp.Data.Cargo.Inventory))
-					for i, item := range resp.Data.Cargo.Inventory {
+					inventory := make([]map[string]interface{}, len(cargo.Inventory))
+					for i, item := range cargo.Inventory {
 						inventory[i] = map[string]interface{}{
 							"symbol":      item.Symbol,
 							"name":        item.Name,
@@ -177,20 +173,23 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 				}(),
 			},
 			"agent": map[string]interface{}{
-				"credits": resp.Data.Agent.Credits,
+				"credits": order.Credits,
 			},
 		}
+		if stateNote != "" {
+			result["state_correction"] = stateNote
+		}
 
 		jsonData := utils.FormatJSON(result)
 
 		// Calculate cargo utilization and profit
-		cargoPercent := float64(resp.Data.Cargo.Units) / float64(resp.Data.Cargo.Capacity) * 100
-		freedSpace := resp.Data.Cargo.Capacity - resp.Data.Cargo.Units
-		profitPerUnit := resp.Data.Transaction.PricePerUnit
+		cargoPercent := float64(cargo.Units) / float64(cargo.Capacity) * 100
+		freedSpace := cargo.Capacity - cargo.Units
+		profitPerUnit := transaction.PricePerUnit
 
 		// Find the sold item name
 		soldItemName := cargoSymbol
-		for _, item := range resp.Data.Cargo.Inventory {
+		for _, item := range cargo.Inventory {
 			if item.Symbol == cargoSymbol {
 				soldItemName = item.Name
 				break
@@ -199,21 +198,34 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 
 		// Create formatted text summary
 		textSummary := "💰 **Cargo Sale Successful!**\n\n"
+		if stateNote != "" {
+			textSummary += "🔧 " + stateNote + "\n\n"
+		}
 		textSummary += fmt.Sprintf("**Ship:** %s\n", shipSymbol)
 		textSummary += fmt.Sprintf("**Sold:** %d units of %s\n", units, soldItemName)
-		textSummary += fmt.Sprintf("**Price per Unit:** %d credits\n", profitPerUnit)
-		textSummary += fmt.Sprintf("**Total Revenue:** %d credits\n", resp.Data.Transaction.TotalPrice)
-		textSummary += fmt.Sprintf("**Current Credits:** %d\n", resp.Data.Agent.Credits)
-		textSummary += fmt.Sprintf("**Location:** %s\n\n", resp.Data.Transaction.WaypointSymbol)
+		if len(order.Transactions) > 1 {
+			textSummary += fmt.Sprintf("**Average Price per Unit:** %.1f credits\n", order.AveragePrice)
+		} else {
+			textSummary += fmt.Sprintf("**Price per Unit:** %d credits\n", profitPerUnit)
+		}
+		textSummary += fmt.Sprintf("**Total Revenue:** %d credits\n", transaction.TotalPrice)
+		textSummary += fmt.Sprintf("**Current Credits:** %d\n", order.Credits)
+		textSummary += fmt.Sprintf("**Location:** %s\n\n", transaction.WaypointSymbol)
+		if lines := chunkLines(order); lines != "" {
+			textSummary += lines + "\n"
+		}
+		if err != nil {
+			textSummary += fmt.Sprintf("⚠️ **Partial Sale:** %s\n\n", err.Error())
+		}
 
 		// Cargo status
-		textSummary += fmt.Sprintf("**Cargo Status:** %d/%d units (%.1f%% full)\n", resp.Data.Cargo.Units, resp.Data.Cargo.Capacity, cargoPercent)
+		textSummary += fmt.Sprintf("**Cargo Status:** %d/%d units (%.1f%% full)\n", cargo.Units, cargo.Capacity, cargoPercent)
 		textSummary += fmt.Sprintf("**Available Space:** %d units\n\n", freedSpace)
 
 		// Show current cargo inventory
-		if len(resp.Data.Cargo.Inventory) > 0 {
+		if len(cargo.Inventory) > 0 {
 			textSummary += "**Remaining Inventory:**\n"
-			for _, item := range resp.Data.Cargo.Inventory {
+			for _, item := range cargo.Inventory {
 				textSummary += fmt.Sprintf("- %s: %d units\n", item.Symbol, item.Units)
 			}
 		} else {
@@ -230,11 +242,11 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 			textSummary += "• 💭 **Consider** higher-value trade routes for better margins\n"
 		}
 
-		if freedSpace >= resp.Data.Cargo.Capacity/2 {
+		if freedSpace >= cargo.Capacity/2 {
 			textSummary += "• 📦 **Plenty of space** - ready for more cargo\n"
 			textSummary += "• ⛏️ Use `extract_resources` to mine valuable materials\n"
 			textSummary += "• 🛒 Use `buy_cargo` to purchase goods for resale\n"
-		} else if resp.Data.Cargo.Units > 0 {
+		} else if cargo.Units > 0 {
 			textSummary += "• 💼 Consider selling more cargo to free up space\n"
 		}
 
@@ -242,18 +254,13 @@ func (t *SellCargoTool) Handler() func(ctx context.Context, request mcp.CallTool
 		textSummary += "• 🗺️ Use `find_waypoints` to find more markets\n"
 
 		// Add trading tips
-		if resp.Data.Transaction.TotalPrice >= 1000 {
+		if transaction.TotalPrice >= 1000 {
 			textSummary += "\n🚀 **Pro Trading Tip:** High-value sales like this indicate profitable trade routes!\n"
 		}
 
 		ctxLogger.ToolCall("sell_cargo", true)
 		ctxLogger.Debug("Sell cargo response size: %d bytes", len(jsonData))
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", jsonData)),
-			},
-		}, nil
+		return utils.NewResult(textSummary, result), nil
 	}
 }
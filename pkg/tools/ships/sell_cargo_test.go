@@ -0,0 +1,114 @@
+package ships
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSellCargoTool_SplitsAboveTradeVolume(t *testing.T) {
+	held := 25
+	price := 50
+	var sales []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/HAULER-1/nav":
+			_, _ = fmt.Fprintf(w, `{"data": `+testNav+`}`, "DOCKED")
+		case "GET /systems/X1-TEST/waypoints/X1-TEST-A1/market":
+			_, _ = w.Write([]byte(`{"data": {
+				"symbol": "X1-TEST-A1", "exports": [], "exchange": [],
+				"imports": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": ""}],
+				"tradeGoods": [{"symbol": "IRON_ORE", "type": "IMPORT", "tradeVolume": 10, "supply": "SCARCE", "purchasePrice": 60, "sellPrice": 50}]
+			}}`))
+		case "POST /my/ships/HAULER-1/sell":
+			var req struct {
+				Units int `json:"units"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Units > 10 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": {"message": "Trade volume exceeded", "code": 4604}}`))
+				return
+			}
+			sales = append(sales, req.Units)
+			held -= req.Units
+			_, _ = fmt.Fprintf(w, `{"data": {
+				"agent": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 1000, "startingFaction": "COSMIC", "shipCount": 1},
+				"cargo": {"capacity": 40, "units": %d, "inventory": [{"symbol": "IRON_ORE", "name": "Iron Ore", "description": "", "units": %d}]},
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "HAULER-1", "tradeSymbol": "IRON_ORE", "type": "SELL", "units": %d, "pricePerUnit": %d, "totalPrice": %d, "timestamp": "2024-01-01T00:00:00.000Z"}
+			}}`, held, held, req.Units, price, req.Units*price)
+			price -= 5
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewSellCargoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "sell_cargo",
+			Arguments: map[string]interface{}{"ship_symbol": "HAULER-1", "cargo_symbol": "IRON_ORE", "units": float64(25)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+
+	if got := fmt.Sprint(sales); got != "[10 10 5]" {
+		t.Errorf("Expected sales of 10, 10 and 5 units, got %s", got)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"25 units of Iron Ore", "**Total Revenue:** 1150 credits", "#2: 10 units @ 45 credits (-5)", "#3: 5 units @ 40 credits (-10)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, text)
+		}
+	}
+	if jsonText := result.Content[1].(mcp.TextContent).Text; !strings.Contains(jsonText, `"price_slippage": -10`) {
+		t.Errorf("Expected price slippage in JSON, got:\n%s", jsonText)
+	}
+}
+
+func TestSellCargoTool_ReportsEveryInvalidArgument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no API call for invalid arguments, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	tool := NewSellCargoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "sell_cargo",
+			Arguments: map[string]interface{}{"cargo_symbol": "iron bar", "units": float64(0)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected an error result")
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"3 invalid arguments", "ship_symbol: is required", "cargo_symbol: unknown trade symbol 'iron bar'", "units: is 0"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+}
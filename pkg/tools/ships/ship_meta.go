@@ -0,0 +1,214 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// shipMetaOutputSchema is the structured result of the ship metadata tools
+var shipMetaOutputSchema = utils.OutputSchema(map[string]interface{}{
+	"ship_symbol": map[string]interface{}{"type": "string"},
+	"label":       map[string]interface{}{"type": "string"},
+	"notes":       map[string]interface{}{"type": "string"},
+	"tags":        map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+}, "ship_symbol", "tags")
+
+// SetShipLabelTool gives a ship a friendly label and notes, which the API can't store
+type SetShipLabelTool struct {
+	store  *shipmeta.Store
+	logger *logging.Logger
+}
+
+// NewSetShipLabelTool creates a new ship label tool
+func NewSetShipLabelTool(store *shipmeta.Store, logger *logging.Logger) *SetShipLabelTool {
+	return &SetShipLabelTool{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *SetShipLabelTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "set_ship_label",
+		Description: "Give a ship a friendly label (e.g., 'Ore Hauler #2') and optional notes. Ships can't be renamed in the game, so labels are kept by this server, survive restarts, and appear in the ships list, fleet summary and ship details. An empty label removes it.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to label (e.g., 'MYSHIP-1')",
+				},
+				"label": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Friendly name for the ship, at most %d characters; empty removes the label", shipmeta.MaxLabelLength),
+				},
+				"notes": map[string]interface{}{
+					"type":        "string",
+					"description": "Free-form notes about the ship; empty clears them, and omitting them keeps the current notes",
+				},
+			},
+			Required: []string{"ship_symbol", "label"},
+		},
+		OutputSchema: shipMetaOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *SetShipLabelTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "set-ship-label-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		labelErrors := len(v.Errors())
+		label, hasLabel := v.ClearableString("label")
+		if !hasLabel && len(v.Errors()) == labelErrors {
+			v.Fail("label", "is required", "a label, or an empty string to remove it")
+		}
+		var notes *string
+		if value, given := v.ClearableString("notes"); given {
+			notes = &value
+		}
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		meta, err := t.store.SetLabel(shipSymbol, label, notes)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to label %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if err := t.store.SaveError(); err != nil {
+			contextLogger.Warn("Failed to save ship metadata: %v", err)
+		}
+		contextLogger.ToolCall("set_ship_label", true)
+
+		summary := fmt.Sprintf("🏷️ Removed the label from %s", shipSymbol)
+		if meta.Label != "" {
+			summary = fmt.Sprintf("🏷️ %s is now labelled **%s**", shipSymbol, meta.Label)
+		}
+		return utils.NewResult(summary+saveWarning(t.store), shipMetaResult(shipSymbol, meta)), nil
+	}
+}
+
+// TagShipTool adds and removes tags that group ships for automation and filtering
+type TagShipTool struct {
+	store  *shipmeta.Store
+	logger *logging.Logger
+}
+
+// NewTagShipTool creates a new ship tagging tool
+func NewTagShipTool(store *shipmeta.Store, logger *logging.Logger) *TagShipTool {
+	return &TagShipTool{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *TagShipTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "tag_ship",
+		Description: "Add or remove tags on a ship (e.g., 'mining', 'trade-route-1') to group ships for automation. Tags are kept by this server and survive restarts; read spacetraders://fleet/summary?tag=<tag> to list the ships with a tag. Tags are lowercased, with spaces turned into dashes.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship to tag (e.g., 'MYSHIP-1')",
+				},
+				"add": map[string]interface{}{
+					"type":        "array",
+					"description": "Tags to add (e.g., ['mining', 'x1-fm66'])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"remove": map[string]interface{}{
+					"type":        "array",
+					"description": "Tags to remove; tags the ship doesn't have are ignored",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"ship_symbol"},
+		},
+		OutputSchema: shipMetaOutputSchema,
+	}
+}
+
+// Handler returns the tool handler function
+func (t *TagShipTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "tag-ship-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		tagErrors := len(v.Errors())
+		add := v.OptionalStrings("add")
+		remove := v.OptionalStrings("remove")
+		if len(add)+len(remove) == 0 && len(v.Errors()) == tagErrors {
+			v.Fail("add", "no tags given", "at least one tag to add or remove")
+		}
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		meta, err := t.store.Tag(shipSymbol, add, remove)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to tag %s: %v", shipSymbol, err)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if err := t.store.SaveError(); err != nil {
+			contextLogger.Warn("Failed to save ship metadata: %v", err)
+		}
+		contextLogger.ToolCall("tag_ship", true)
+
+		summary := fmt.Sprintf("🏷️ %s has no tags", shipSymbol)
+		if len(meta.Tags) > 0 {
+			summary = fmt.Sprintf("🏷️ %s is tagged %s", shipSymbol, strings.Join(meta.Tags, ", "))
+		}
+		return utils.NewResult(summary+saveWarning(t.store), shipMetaResult(shipSymbol, meta)), nil
+	}
+}
+
+// shipMetaResult is the structured result of the ship metadata tools
+func shipMetaResult(shipSymbol string, meta shipmeta.Meta) map[string]interface{} {
+	tags := meta.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return map[string]interface{}{
+		"ship_symbol": shipSymbol,
+		"label":       meta.Label,
+		"notes":       meta.Notes,
+		"tags":        tags,
+	}
+}
+
+// saveWarning tells the caller a change will be lost on restart because it couldn't be saved
+func saveWarning(store *shipmeta.Store) string {
+	if err := store.SaveError(); err != nil {
+		return fmt.Sprintf("\n\n⚠️ Could not save ship metadata to %s, so this change will be lost on restart: %v", store.Path(), err)
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package ships
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func callShipMetaTool(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	result, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("Handler returned error: %v", err)
+	}
+	return result
+}
+
+func TestSetShipLabelTool_LabelsShip(t *testing.T) {
+	store, _ := shipmeta.Open("")
+	tool := NewSetShipLabelTool(store, logging.NewLogger(nil))
+
+	result := callShipMetaTool(t, tool.Handler(), map[string]interface{}{
+		"ship_symbol": "hauler-2",
+		"label":       "Ore Hauler #2",
+		"notes":       "Sells at X1-A-B2",
+	})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	meta, ok := store.Get("HAULER-2")
+	if !ok || meta.Label != "Ore Hauler #2" || meta.Notes != "Sells at X1-A-B2" {
+		t.Errorf("Expected the label and notes to be stored, got %+v", meta)
+	}
+	data := result.StructuredContent.(map[string]interface{})
+	if data["ship_symbol"] != "HAULER-2" || data["label"] != "Ore Hauler #2" {
+		t.Errorf("Expected the stored metadata in the result, got %v", data)
+	}
+
+	if result := callShipMetaTool(t, tool.Handler(), map[string]interface{}{"ship_symbol": "HAULER-2"}); !result.IsError {
+		t.Error("Expected an error without a label")
+	}
+}
+
+func TestTagShipTool_AddsAndRemovesTags(t *testing.T) {
+	store, _ := shipmeta.Open("")
+	tool := NewTagShipTool(store, logging.NewLogger(nil))
+
+	result := callShipMetaTool(t, tool.Handler(), map[string]interface{}{
+		"ship_symbol": "MINER-1",
+		"add":         []interface{}{"Mining", "trade route 1"},
+	})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "mining, trade-route-1") {
+		t.Errorf("Expected the normalized tags in the summary, got %q", text)
+	}
+
+	result = callShipMetaTool(t, tool.Handler(), map[string]interface{}{
+		"ship_symbol": "MINER-1",
+		"remove":      []interface{}{"trade-route-1"},
+	})
+	if result.IsError {
+		t.Fatalf("Expected success, got %v", result.Content)
+	}
+	if got := store.ShipsTagged("mining"); len(got) != 1 || got[0] != "MINER-1" {
+		t.Errorf("Expected MINER-1 to keep the mining tag, got %v", got)
+	}
+	if got := store.ShipsTagged("trade-route-1"); len(got) != 0 {
+		t.Errorf("Expected the removed tag to be gone, got %v", got)
+	}
+
+	if result := callShipMetaTool(t, tool.Handler(), map[string]interface{}{"ship_symbol": "MINER-1"}); !result.IsError {
+		t.Error("Expected an error without tags to add or remove")
+	}
+	if result := callShipMetaTool(t, tool.Handler(), map[string]interface{}{"ship_symbol": "MINER-1", "add": []interface{}{"??"}}); !result.IsError {
+		t.Error("Expected an error for a tag without letters or digits")
+	}
+}
@@ -0,0 +1,385 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/trade"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// supplyPriceSteps is how much, as a share of the price, a good's price is assumed to move
+// after each trade volume's worth of units when no trades at the market have been seen.
+// Thin markets move the most.
+var supplyPriceSteps = map[string]float64{
+	"SCARCE":   0.10,
+	"LIMITED":  0.06,
+	"MODERATE": 0.04,
+	"HIGH":     0.02,
+	"ABUNDANT": 0.01,
+}
+
+// defaultPriceStep is the assumed price move per chunk when the market's supply is unknown
+const defaultPriceStep = 0.04
+
+// chunkGap is how far apart two transactions may be and still count as chunks of one order
+// when measuring how far a market's price moved between them
+const chunkGap = 2 * time.Minute
+
+// SimulateTradeTool predicts what an order at a ship's current market would cost or earn,
+// without trading
+type SimulateTradeTool struct {
+	client *client.Client
+	logger *logging.Logger
+
+	prices *prices.DB
+	ledger *ledger.Ledger
+}
+
+// NewSimulateTradeTool creates a new trade simulation tool
+func NewSimulateTradeTool(client *client.Client, logger *logging.Logger) *SimulateTradeTool {
+	return &SimulateTradeTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithPrices makes the tool use the price database's latest snapshot of the market instead of
+// fetching it
+func (t *SimulateTradeTool) WithPrices(db *prices.DB) *SimulateTradeTool {
+	t.prices = db
+	return t
+}
+
+// WithLedger makes the tool estimate price movement from past orders at the market
+func (t *SimulateTradeTool) WithLedger(l *ledger.Ledger) *SimulateTradeTool {
+	t.ledger = l
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *SimulateTradeTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "simulate_trade",
+		Description: "Predict what buying or selling a good at a ship's current market would cost or earn, without trading. Splits the order into trade volume sized transactions like buy_cargo and sell_cargo do, and gives best, expected and worst case totals for how far the price moves after each one. Use it right before committing to a large order.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ship_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the ship at the market (e.g., 'SHIP_1234')",
+				},
+				"good": map[string]interface{}{
+					"type":        "string",
+					"description": "Symbol of the good to trade (e.g., 'IRON_ORE')",
+				},
+				"units": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of units to trade",
+					"minimum":     1,
+				},
+				"side": map[string]interface{}{
+					"type":        "string",
+					"description": "Whether to simulate buying or selling",
+					"enum":        []string{"buy", "sell"},
+				},
+			},
+			Required: []string{"ship_symbol", "good", "units", "side"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"ship_symbol":     map[string]interface{}{"type": "string"},
+			"waypoint_symbol": map[string]interface{}{"type": "string"},
+			"good":            map[string]interface{}{"type": "string"},
+			"side":            map[string]interface{}{"type": "string"},
+			"units":           map[string]interface{}{"type": "integer"},
+			"price_source":    map[string]interface{}{"type": "string"},
+			"price_age":       map[string]interface{}{"type": "string"},
+			"simulation":      map[string]interface{}{"type": "object"},
+			"warnings":        map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		}, "ship_symbol", "waypoint_symbol", "good", "side", "units", "price_source", "simulation"),
+	}
+}
+
+// tradeChunk is one simulated transaction of an order
+type tradeChunk struct {
+	Units         int `json:"units"`
+	ExpectedPrice int `json:"expected_price"`
+	WorstPrice    int `json:"worst_price"`
+}
+
+// tradeSimulation is the predicted outcome of an order. Totals are credits paid when buying
+// and credits earned when selling; the best case assumes the price doesn't move at all.
+type tradeSimulation struct {
+	StartPrice    int          `json:"start_price"`
+	TradeVolume   int          `json:"trade_volume"`
+	Supply        string       `json:"supply,omitempty"`
+	PriceStep     float64      `json:"price_step"`
+	WorstStep     float64      `json:"worst_step"`
+	StepSource    string       `json:"step_source"`
+	Chunks        []tradeChunk `json:"chunks"`
+	BestTotal     int          `json:"best_total"`
+	ExpectedTotal int          `json:"expected_total"`
+	WorstTotal    int          `json:"worst_total"`
+	ExpectedAvg   float64      `json:"expected_average_price"`
+}
+
+// simulateOrder splits units into trade volume chunks starting at price, moving the price by
+// step per chunk in the expected case and by worstStep in the worst case. Buying pushes the
+// price up and selling pushes it down.
+func simulateOrder(units, price, tradeVolume int, step, worstStep float64, buying bool) tradeSimulation {
+	simulation := tradeSimulation{
+		StartPrice:  price,
+		TradeVolume: tradeVolume,
+		PriceStep:   step,
+		WorstStep:   worstStep,
+	}
+
+	direction := -1.0
+	if buying {
+		direction = 1.0
+	}
+	movedPrice := func(step float64, chunk int) int {
+		return max(int(math.Round(float64(price)*math.Pow(1+direction*step, float64(chunk)))), 0)
+	}
+
+	for i, size := range trade.ChunkSizes(units, tradeVolume) {
+		chunk := tradeChunk{
+			Units:         size,
+			ExpectedPrice: movedPrice(step, i),
+			WorstPrice:    movedPrice(worstStep, i),
+		}
+		simulation.Chunks = append(simulation.Chunks, chunk)
+		simulation.BestTotal += size * price
+		simulation.ExpectedTotal += size * chunk.ExpectedPrice
+		simulation.WorstTotal += size * chunk.WorstPrice
+	}
+	simulation.ExpectedAvg = float64(simulation.ExpectedTotal) / float64(units)
+	return simulation
+}
+
+// observedPriceSteps measures how far the price of good moved, as a share of the price,
+// between consecutive chunks of past orders on the same side at a market. Entries must be
+// oldest first.
+func observedPriceSteps(entries []ledger.Entry, good string, buying bool) []float64 {
+	onSide := func(category ledger.Category) bool {
+		if buying {
+			return category == ledger.CategoryMarketPurchase
+		}
+		return category == ledger.CategoryMarketSale || category == ledger.CategoryMiningSale
+	}
+
+	var steps []float64
+	var previous *ledger.Entry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.TradeSymbol != good || !onSide(entry.Category) || entry.PricePerUnit <= 0 {
+			continue
+		}
+		// A sale split between mining and trading shares one timestamp, and isn't two chunks
+		if previous != nil && previous.ShipSymbol == entry.ShipSymbol &&
+			entry.Timestamp.After(previous.Timestamp) && entry.Timestamp.Sub(previous.Timestamp) <= chunkGap {
+			moved := float64(entry.PricePerUnit-previous.PricePerUnit) / float64(previous.PricePerUnit)
+			if !buying {
+				moved = -moved
+			}
+			steps = append(steps, max(moved, 0))
+		}
+		previous = entry
+	}
+	return steps
+}
+
+// priceSteps picks the expected and worst case price move per chunk: from past orders at the
+// market when there are any, otherwise from the good's supply, with twice that as the worst case
+func priceSteps(observed []float64, supply string) (step, worst float64, source string) {
+	if len(observed) > 0 {
+		total := 0.0
+		for _, moved := range observed {
+			total += moved
+			worst = max(worst, moved)
+		}
+		return total / float64(len(observed)), worst, fmt.Sprintf("%d past chunks at this market", len(observed))
+	}
+
+	step, ok := supplyPriceSteps[supply]
+	if !ok {
+		return defaultPriceStep, 2 * defaultPriceStep, "assumed, supply unknown"
+	}
+	return step, 2 * step, fmt.Sprintf("assumed from %s supply", supply)
+}
+
+// Handler returns the tool handler function
+func (t *SimulateTradeTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctxLogger := t.logger.WithContext(ctx, "simulate-trade-tool")
+		ctxLogger.Debug("Processing trade simulation request")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		shipSymbol := v.RequireShip("ship_symbol")
+		good := v.RequireSymbol("good", utils.TradeSymbols)
+		units := v.RequireInt("units", 1)
+		side := v.RequireChoice("side", "buy", "sell")
+		if result := v.Result(); result != nil {
+			return result, nil
+		}
+		buying := side == "buy"
+
+		c := t.client.WithContext(ctx)
+		ship, err := c.GetShip(shipSymbol)
+		if err != nil {
+			ctxLogger.Error("Failed to get ship %s: %v", shipSymbol, err)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to get ship %s: %s", shipSymbol, err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+		waypoint := ship.Nav.WaypointSymbol
+
+		// Prefer the cached snapshot; fetching the market also records a fresh one
+		now := time.Now()
+		priceSource := "live"
+		priceAge := ""
+		var price prices.Price
+		found := false
+		if t.prices != nil {
+			if snapshot, ok := t.prices.Latest(waypoint); ok {
+				if price, found = snapshot.Price(good); found {
+					priceSource = "cached"
+					priceAge = now.Sub(snapshot.ObservedAt).Round(time.Second).String()
+				}
+			}
+		}
+		if !found {
+			market, err := c.GetMarket(ship.Nav.SystemSymbol, waypoint)
+			if err != nil {
+				ctxLogger.Error("Failed to get market at %s: %v", waypoint, err)
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ Failed to get the market at %s: %s", waypoint, err.Error())),
+					},
+					IsError: true,
+				}, nil
+			}
+			entry := marketTradeGood(market, good)
+			if entry == nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						mcp.NewTextContent(fmt.Sprintf("❌ The market at %s has no price for %s; it doesn't trade it, or %s isn't there to see prices", waypoint, good, shipSymbol)),
+					},
+					IsError: true,
+				}, nil
+			}
+			price = prices.Price{
+				TradeSymbol:   entry.Symbol,
+				Supply:        entry.Supply,
+				PurchasePrice: entry.PurchasePrice,
+				SellPrice:     entry.SellPrice,
+				TradeVolume:   entry.TradeVolume,
+			}
+		}
+
+		var observed []float64
+		if t.ledger != nil {
+			observed = observedPriceSteps(t.ledger.Query(ledger.Filter{WaypointSymbol: waypoint}), good, buying)
+		}
+		step, worstStep, stepSource := priceSteps(observed, price.Supply)
+
+		startPrice := price.SellPrice
+		if buying {
+			startPrice = price.PurchasePrice
+		}
+		simulation := simulateOrder(units, startPrice, price.TradeVolume, step, worstStep, buying)
+		simulation.Supply = price.Supply
+		simulation.StepSource = stepSource
+
+		var warnings []string
+		if ship.Nav.Status == "IN_TRANSIT" {
+			warnings = append(warnings, fmt.Sprintf("%s is still in transit to %s; prices may change before it arrives", shipSymbol, waypoint))
+		}
+		if buying {
+			if free := ship.Cargo.Capacity - ship.Cargo.Units; units > free {
+				warnings = append(warnings, fmt.Sprintf("%s only has %d units of free cargo space", shipSymbol, free))
+			}
+			if agent, err := c.GetAgent(); err == nil && int64(simulation.WorstTotal) > agent.Credits {
+				warnings = append(warnings, fmt.Sprintf("The worst case costs %d credits, more than the %d you have", simulation.WorstTotal, agent.Credits))
+			}
+		} else {
+			held := 0
+			for _, item := range ship.Cargo.Inventory {
+				if item.Symbol == good {
+					held = item.Units
+				}
+			}
+			if units > held {
+				warnings = append(warnings, fmt.Sprintf("%s only holds %d units of %s", shipSymbol, held, good))
+			}
+		}
+
+		result := map[string]interface{}{
+			"ship_symbol":     shipSymbol,
+			"waypoint_symbol": waypoint,
+			"good":            good,
+			"side":            side,
+			"units":           units,
+			"price_source":    priceSource,
+			"simulation":      simulation,
+		}
+		if priceAge != "" {
+			result["price_age"] = priceAge
+		}
+		if len(warnings) > 0 {
+			result["warnings"] = warnings
+		}
+
+		verb, total := "Selling", "Revenue"
+		if buying {
+			verb, total = "Buying", "Cost"
+		}
+		var text strings.Builder
+		text.WriteString(fmt.Sprintf("🧮 **Trade Simulation: %s %d %s at %s**\n\n", verb, units, good, waypoint))
+		text.WriteString("_Nothing was traded._\n\n")
+		text.WriteString(fmt.Sprintf("**Price:** %d credits per unit (%s", startPrice, priceSource))
+		if priceAge != "" {
+			text.WriteString(fmt.Sprintf(", %s old", priceAge))
+		}
+		text.WriteString(")\n")
+		if price.Supply != "" {
+			text.WriteString(fmt.Sprintf("**Supply:** %s\n", price.Supply))
+		}
+		text.WriteString(fmt.Sprintf("**Price Move per Chunk:** %.1f%% expected, %.1f%% worst case (%s)\n\n", step*100, worstStep*100, stepSource))
+
+		if len(simulation.Chunks) > 1 {
+			text.WriteString(fmt.Sprintf("**%d transactions** of up to %d units (market trade volume limit):\n", len(simulation.Chunks), price.TradeVolume))
+			for i, chunk := range simulation.Chunks {
+				text.WriteString(fmt.Sprintf("- #%d: %d units @ %d credits (worst %d)\n", i+1, chunk.Units, chunk.ExpectedPrice, chunk.WorstPrice))
+			}
+			text.WriteString("\n")
+		}
+
+		text.WriteString(fmt.Sprintf("**%s:**\n", total))
+		text.WriteString(fmt.Sprintf("- Best case: %d credits\n", simulation.BestTotal))
+		text.WriteString(fmt.Sprintf("- Expected: %d credits (%.1f per unit)\n", simulation.ExpectedTotal, simulation.ExpectedAvg))
+		text.WriteString(fmt.Sprintf("- Worst case: %d credits\n", simulation.WorstTotal))
+
+		if len(warnings) > 0 {
+			text.WriteString("\n**Warnings:**\n")
+			for _, warning := range warnings {
+				text.WriteString(fmt.Sprintf("- ⚠️ %s\n", warning))
+			}
+		}
+
+		ctxLogger.ToolCall("simulate_trade", true)
+		return utils.NewResult(text.String(), result), nil
+	}
+}
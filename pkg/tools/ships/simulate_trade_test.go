@@ -0,0 +1,117 @@
+package ships
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/ledger"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/prices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSimulateOrder(t *testing.T) {
+	// 25 units in chunks of 10, 10 and 5, with the price up 10% per chunk expected and 20% worst
+	buy := simulateOrder(25, 100, 10, 0.1, 0.2, true)
+	if len(buy.Chunks) != 3 || buy.Chunks[2].Units != 5 {
+		t.Fatalf("Expected chunks of 10, 10 and 5, got %+v", buy.Chunks)
+	}
+	if buy.Chunks[1].ExpectedPrice != 110 || buy.Chunks[2].ExpectedPrice != 121 || buy.Chunks[2].WorstPrice != 144 {
+		t.Errorf("Expected prices to compound upwards, got %+v", buy.Chunks)
+	}
+	if buy.BestTotal != 2500 || buy.ExpectedTotal != 1000+1100+605 || buy.WorstTotal != 1000+1200+720 {
+		t.Errorf("Unexpected totals %d, %d and %d", buy.BestTotal, buy.ExpectedTotal, buy.WorstTotal)
+	}
+
+	sell := simulateOrder(20, 100, 10, 0.1, 0.2, false)
+	if sell.Chunks[1].ExpectedPrice != 90 || sell.WorstTotal != 1000+800 {
+		t.Errorf("Expected selling to push the price down, got %+v", sell)
+	}
+
+	// An unknown trade volume sends the whole order at once
+	if single := simulateOrder(25, 100, 0, 0.1, 0.2, true); len(single.Chunks) != 1 || single.ExpectedTotal != 2500 {
+		t.Errorf("Expected a single chunk at the start price, got %+v", single)
+	}
+}
+
+func TestObservedPriceSteps(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []ledger.Entry{
+		{Timestamp: start, Category: ledger.CategoryMarketPurchase, ShipSymbol: "HAULER-1", TradeSymbol: "IRON_ORE", PricePerUnit: 100},
+		{Timestamp: start.Add(time.Second), Category: ledger.CategoryMarketPurchase, ShipSymbol: "HAULER-1", TradeSymbol: "IRON_ORE", PricePerUnit: 105},
+		{Timestamp: start.Add(2 * time.Second), Category: ledger.CategoryMarketSale, ShipSymbol: "HAULER-1", TradeSymbol: "IRON_ORE", PricePerUnit: 90},
+		{Timestamp: start.Add(3 * time.Second), Category: ledger.CategoryMarketPurchase, ShipSymbol: "HAULER-1", TradeSymbol: "COPPER_ORE", PricePerUnit: 50},
+		{Timestamp: start.Add(4 * time.Second), Category: ledger.CategoryMarketPurchase, ShipSymbol: "HAULER-1", TradeSymbol: "IRON_ORE", PricePerUnit: 126},
+		// An hour later is a new order, not another chunk
+		{Timestamp: start.Add(time.Hour), Category: ledger.CategoryMarketPurchase, ShipSymbol: "HAULER-1", TradeSymbol: "IRON_ORE", PricePerUnit: 80},
+	}
+
+	steps := observedPriceSteps(entries, "IRON_ORE", true)
+	if len(steps) != 2 || steps[0] != 0.05 || steps[1] != 0.2 {
+		t.Errorf("Expected steps of 5%% and 20%%, got %v", steps)
+	}
+
+	step, worst, source := priceSteps(steps, "ABUNDANT")
+	if step != 0.125 || worst != 0.2 || !strings.Contains(source, "2 past chunks") {
+		t.Errorf("Expected the observed average and maximum, got %v, %v and %q", step, worst, source)
+	}
+	if step, worst, _ := priceSteps(nil, "SCARCE"); step != 0.10 || worst != 0.20 {
+		t.Errorf("Expected the SCARCE default, got %v and %v", step, worst)
+	}
+}
+
+func TestSimulateTradeTool_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/HAULER-1":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "HAULER-1",
+				"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED", "flightMode": "CRUISE"},
+				"cargo": {"capacity": 40, "units": 30, "inventory": [{"symbol": "FUEL", "name": "Fuel", "description": "", "units": 30}]}
+			}}`))
+		case "GET /my/agent":
+			_, _ = w.Write([]byte(`{"data": {"accountId": "A", "symbol": "AGENT", "headquarters": "X1-TEST-A1", "credits": 100000, "startingFaction": "COSMIC", "shipCount": 1}}`))
+		default:
+			// The cached price is used, so the market is never fetched
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	db := prices.New()
+	db.Record(prices.Snapshot{
+		WaypointSymbol: "X1-TEST-A1",
+		ObservedAt:     time.Now().Add(-5 * time.Minute),
+		Prices:         []prices.Price{{TradeSymbol: "IRON_ORE", Supply: "LIMITED", PurchasePrice: 100, SellPrice: 90, TradeVolume: 10}},
+	})
+	tool := NewSimulateTradeTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).WithPrices(db)
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "simulate_trade", Arguments: map[string]interface{}{
+			"ship_symbol": "HAULER-1", "good": "IRON_ORE", "units": float64(20), "side": "buy",
+		}},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("Expected a simulation, got %v (%v)", result, err)
+	}
+
+	data := result.StructuredContent.(map[string]interface{})
+	if data["price_source"] != "cached" {
+		t.Errorf("Expected the cached price to be used, got %v", data["price_source"])
+	}
+	simulation := data["simulation"].(tradeSimulation)
+	if simulation.ExpectedTotal != 1000+1060 || simulation.StepSource != "assumed from LIMITED supply" {
+		t.Errorf("Expected a 6%% step for LIMITED supply, got %+v", simulation)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "Nothing was traded") || !strings.Contains(text, "only has 10 units of free cargo space") {
+		t.Errorf("Expected the simulation to warn about cargo space, got %q", text)
+	}
+}
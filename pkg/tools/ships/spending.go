@@ -0,0 +1,70 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/policy"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/travel"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// checkSpend asks the spending policy whether a purchase costing credits may go ahead. It
+// returns the refusal to send back when it may not, or nil to go ahead. A nil policy allows
+// everything.
+func checkSpend(c *client.Client, spending *policy.Policy, credits int, what string) *mcp.CallToolResult {
+	if !spending.Enabled() {
+		return nil
+	}
+
+	agent, err := c.GetAgent()
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("❌ Failed to check %s against the spending policy: %s", what, err.Error())),
+			},
+			IsError: true,
+		}
+	}
+	if err := spending.Check(credits, agent.Credits); err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("🚫 Refused to buy %s: %s. Nothing was bought.", what, err.Error())),
+			},
+			IsError: true,
+		}
+	}
+	return nil
+}
+
+// confirmSpend asks the user to approve spending credits on a purchase described by what, when
+// that is more than threshold. It returns the result to send back when they don't, or nil to go
+// ahead. A threshold of 0 never asks, and neither do clients that can't be asked.
+func confirmSpend(ctx context.Context, threshold, credits int, what string) *mcp.CallToolResult {
+	if threshold <= 0 || credits <= threshold || !utils.CanConfirm(ctx) {
+		return nil
+	}
+
+	approved, err := utils.Confirm(ctx, fmt.Sprintf("Spend %d credits on %s? This is above the %d credit confirmation limit.", credits, what, threshold))
+	if err != nil || !approved {
+		return utils.NotConfirmedResult(fmt.Sprintf("spending %d credits on %s", credits, what))
+	}
+	return nil
+}
+
+// shipPrice looks up what a shipyard asks for a ship type
+func shipPrice(c *client.Client, waypointSymbol, shipType string) (int, error) {
+	shipyard, err := c.GetShipyard(travel.SystemSymbol(waypointSymbol), waypointSymbol)
+	if err != nil {
+		return 0, err
+	}
+	for _, ship := range shipyard.Ships {
+		if ship.Type == shipType {
+			return ship.PurchasePrice, nil
+		}
+	}
+	return 0, fmt.Errorf("%s has no price listed at %s; a ship must be present to see prices", shipType, waypointSymbol)
+}
@@ -0,0 +1,155 @@
+package ships
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/shipmeta"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateSquadronTool groups ships under a name so tools can act on all of them at once
+type CreateSquadronTool struct {
+	store  *shipmeta.Store
+	logger *logging.Logger
+}
+
+// NewCreateSquadronTool creates a new squadron creation tool
+func NewCreateSquadronTool(store *shipmeta.Store, logger *logging.Logger) *CreateSquadronTool {
+	return &CreateSquadronTool{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *CreateSquadronTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "create_squadron",
+		Description: "Group ships into a named squadron, so navigate_squadron and assign_squadron_task can act on all of them without listing ship symbols each time. Creating a squadron that already exists replaces its ships. Squadrons are kept by this server, survive restarts, and are listed in spacetraders://squadrons/list.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Squadron name (e.g., 'mining-fleet'); lowercased, with spaces turned into dashes",
+				},
+				"ships": map[string]interface{}{
+					"type":        "array",
+					"description": "Symbols of the ships in the squadron (e.g., ['MYSHIP-3', 'MYSHIP-4'])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"name", "ships"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"name":      map[string]interface{}{"type": "string"},
+			"ships":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"createdAt": map[string]interface{}{"type": "string"},
+			"updatedAt": map[string]interface{}{"type": "string"},
+		}, "name", "ships"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *CreateSquadronTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "create-squadron-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		name := v.RequireString("name")
+		members := v.RequireShips("ships")
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		squadron, err := t.store.SaveSquadron(name, members)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ Failed to create squadron: %v", err)),
+				},
+				IsError: true,
+			}, nil
+		}
+		if err := t.store.SaveError(); err != nil {
+			contextLogger.Warn("Failed to save ship metadata: %v", err)
+		}
+		contextLogger.ToolCall("create_squadron", true)
+
+		summary := fmt.Sprintf("🚀 Squadron **%s** has %d ships: %s", squadron.Name, len(squadron.Ships), strings.Join(squadron.Ships, ", "))
+		return utils.NewResult(summary+saveWarning(t.store), squadron), nil
+	}
+}
+
+// DisbandSquadronTool forgets a squadron, leaving its ships as they are
+type DisbandSquadronTool struct {
+	store  *shipmeta.Store
+	logger *logging.Logger
+}
+
+// NewDisbandSquadronTool creates a new squadron disband tool
+func NewDisbandSquadronTool(store *shipmeta.Store, logger *logging.Logger) *DisbandSquadronTool {
+	return &DisbandSquadronTool{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *DisbandSquadronTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "disband_squadron",
+		Description: "Forget a squadron created with create_squadron. The ships themselves and any tasks they are running are not affected.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the squadron to disband",
+				},
+			},
+			Required: []string{"name"},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"name":      map[string]interface{}{"type": "string"},
+			"disbanded": map[string]interface{}{"type": "boolean", "description": "False when there was no such squadron"},
+		}, "name", "disbanded"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *DisbandSquadronTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "disband-squadron-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		name := shipmeta.NormalizeTag(v.RequireString("name"))
+		if result := v.Result(); result != nil {
+			contextLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
+		}
+
+		disbanded := t.store.DisbandSquadron(name)
+		if err := t.store.SaveError(); err != nil {
+			contextLogger.Warn("Failed to save ship metadata: %v", err)
+		}
+		contextLogger.ToolCall("disband_squadron", true)
+
+		summary := fmt.Sprintf("Squadron **%s** disbanded", name)
+		if !disbanded {
+			summary = fmt.Sprintf("There is no squadron named **%s**; nothing to disband", name)
+		}
+		return utils.NewResult(summary+saveWarning(t.store), map[string]interface{}{
+			"name":      name,
+			"disbanded": disbanded,
+		}), nil
+	}
+}
@@ -0,0 +1,102 @@
+package ships
+
+import (
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/trade"
+)
+
+// chunkLines lists each transaction of a multi-transaction order with its price change
+// from the first, so slippage from moving the market is visible. Single orders list nothing.
+func chunkLines(o *trade.Order) string {
+	if len(o.Transactions) < 2 {
+		return ""
+	}
+
+	lines := fmt.Sprintf("**Split into %d transactions** (market trade volume limit):\n", len(o.Transactions))
+	first := o.Transactions[0].PricePerUnit
+	for i, transaction := range o.Transactions {
+		lines += fmt.Sprintf("- #%d: %d units @ %d credits", i+1, transaction.Units, transaction.PricePerUnit)
+		if i > 0 {
+			lines += fmt.Sprintf(" (%+d)", transaction.PricePerUnit-first)
+		}
+		lines += "\n"
+	}
+	return lines
+}
+
+// marketTradeVolume looks up a good's trade volume at the ship's current market. It returns 0,
+// meaning the order is not split, when the volume cannot be determined.
+func marketTradeVolume(c *client.Client, shipSymbol, good string) int {
+	if entry := localTradeGood(c, shipSymbol, good); entry != nil {
+		return entry.TradeVolume
+	}
+	return 0
+}
+
+// localTradeGood looks up a good at the market where the ship is, or returns nil when there
+// is no market there or it doesn't trade the good
+func localTradeGood(c *client.Client, shipSymbol, good string) *client.MarketTradeGood {
+	nav, err := c.GetShipNav(shipSymbol)
+	if err != nil {
+		return nil
+	}
+	market, err := c.GetMarket(nav.SystemSymbol, nav.WaypointSymbol)
+	if err != nil {
+		return nil
+	}
+	return marketTradeGood(market, good)
+}
+
+// sellInChunks sells units of a good in tradeVolume-sized transactions. It stops at the first
+// failed transaction, returning what was sold so far together with the error.
+func sellInChunks(c *client.Client, shipSymbol, good string, units, tradeVolume int) (*trade.Order, error) {
+	order := trade.NewOrder(good)
+	for _, size := range trade.ChunkSizes(units, tradeVolume) {
+		resp, err := c.SellCargo(shipSymbol, good, size)
+		if err != nil {
+			return order, fmt.Errorf("sold %d of %d units of %s before failing: %w", order.Units, units, good, err)
+		}
+		order.Add(resp.Data.Transaction, resp.Data.Agent, resp.Data.Cargo)
+	}
+	return order, nil
+}
+
+// buyInChunks buys up to units of a good in tradeVolume-sized transactions. With a budget
+// above 0, quote is asked for the current price per unit before each chunk, the chunk is
+// shrunk to what the remaining budget covers, and buying stops once no further unit is
+// affordable. It stops at the first failed transaction, returning what was bought so far
+// together with the error.
+func buyInChunks(c *client.Client, shipSymbol, good string, units, tradeVolume, budget int, quote func() (int, error)) (*trade.Order, error) {
+	order := trade.NewOrder(good)
+	for _, size := range trade.ChunkSizes(units, tradeVolume) {
+		if budget > 0 {
+			price, err := quote()
+			if err != nil {
+				return order, fmt.Errorf("bought %d of %d units of %s before the price check failed: %w", order.Units, units, good, err)
+			}
+			size = min(size, (budget-order.TotalPrice)/max(price, 1))
+			if size <= 0 {
+				break
+			}
+		}
+
+		resp, err := c.BuyCargo(shipSymbol, good, size)
+		if err != nil {
+			return order, fmt.Errorf("bought %d of %d units of %s before failing: %w", order.Units, units, good, err)
+		}
+		order.Add(resp.Data.Transaction, resp.Data.Agent, resp.Data.Cargo)
+	}
+	return order, nil
+}
+
+// marketTradeGood returns a good's entry in the market's trade goods, or nil if the market does not trade it
+func marketTradeGood(market *client.Market, good string) *client.MarketTradeGood {
+	for i := range market.TradeGoods {
+		if market.TradeGoods[i].Symbol == good {
+			return &market.TradeGoods[i]
+		}
+	}
+	return nil
+}
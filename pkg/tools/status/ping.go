@@ -0,0 +1,105 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/health"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PingTool checks that the SpaceTraders API is reachable and the token is valid
+type PingTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewPingTool creates a new ping tool
+func NewPingTool(client *client.Client, logger *logging.Logger) *PingTool {
+	return &PingTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *PingTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "ping",
+		Description: "Check that the SpaceTraders API is reachable and the API token is valid. Reports latency, rate-limit status and cache state.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"status":       map[string]interface{}{"type": "string"},
+			"apiReachable": map[string]interface{}{"type": "boolean"},
+			"tokenValid":   map[string]interface{}{"type": "boolean"},
+			"agent":        map[string]interface{}{"type": "string"},
+			"credits":      map[string]interface{}{"type": "integer"},
+			"latencyMs":    map[string]interface{}{"type": "integer"},
+			"rateLimit":    map[string]interface{}{"type": "object"},
+			"cache":        map[string]interface{}{"type": "object", "description": "Fresh cache entries"},
+			"circuits":     map[string]interface{}{"type": "array", "description": "Parts of the API that have been failing, and whether calls to them are paused"},
+			"error":        map[string]interface{}{"type": "string"},
+			"checkedAt":    map[string]interface{}{"type": "string"},
+		}, "status", "apiReachable", "tokenValid", "latencyMs", "cache", "checkedAt"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *PingTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "ping-tool")
+		contextLogger.Debug("Checking API health")
+
+		report := health.Check(ctx, t.client, health.DefaultTimeout)
+
+		textSummary := "## 🩺 API Health\n\n"
+		switch report.Status {
+		case health.StatusOK:
+			textSummary += fmt.Sprintf("✅ **API reachable**, token valid for agent **%s** (%d credits)\n", report.Agent, report.Credits)
+		case health.StatusUnauthorized:
+			textSummary += "❌ **Token rejected** - " + report.Error + "\n"
+		case health.StatusDegraded:
+			textSummary += "⚠️ **API degraded** - " + report.Error + "\n"
+		default:
+			textSummary += "❌ **API unreachable** - " + report.Error + "\n"
+		}
+		textSummary += fmt.Sprintf("**Latency:** %dms\n", report.LatencyMs)
+		if report.RateLimit != nil {
+			textSummary += fmt.Sprintf("**Rate Limit:** %d requests remaining", report.RateLimit.Remaining)
+			if report.RateLimit.ResetAt != "" {
+				textSummary += fmt.Sprintf(" (resets %s)", report.RateLimit.ResetAt)
+			}
+			textSummary += "\n"
+		}
+		textSummary += fmt.Sprintf("**Cache:** waypoints for %d system(s)", report.Cache.WaypointSystems)
+		if report.Cache.SupplyChainCached {
+			textSummary += ", supply chain"
+		}
+		textSummary += "\n"
+		for _, circuit := range report.Circuits {
+			textSummary += fmt.Sprintf("**%s calls:** %s after %d failures in a row (%s)", circuit.Class, circuit.State, circuit.ConsecutiveFailures, circuit.LastError)
+			if circuit.RetryAt != nil && circuit.State == client.CircuitOpen {
+				textSummary += fmt.Sprintf(", paused until %s", circuit.RetryAt.UTC().Format(time.RFC3339))
+			}
+			textSummary += "\n"
+		}
+
+		if !report.Ready() {
+			contextLogger.ToolCall("ping", false)
+			result := utils.NewResult(textSummary, report)
+			result.IsError = true
+			return result, nil
+		}
+
+		contextLogger.ToolCall("ping", true)
+		return utils.NewResult(textSummary, report), nil
+	}
+}
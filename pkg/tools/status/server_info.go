@@ -0,0 +1,81 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+	"spacetraders-mcp/pkg/version"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerInfoTool reports which build of the server is running and which SpaceTraders API
+// version it talks to, for bug reports
+type ServerInfoTool struct {
+	client *client.Client
+	logger *logging.Logger
+}
+
+// NewServerInfoTool creates a new server info tool
+func NewServerInfoTool(client *client.Client, logger *logging.Logger) *ServerInfoTool {
+	return &ServerInfoTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Tool returns the MCP tool definition
+func (t *ServerInfoTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "server_info",
+		Description: "Show the version, commit and build of this MCP server, the MCP protocol version, and the SpaceTraders API version and last reset. Include this in bug reports.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"server":          map[string]interface{}{"type": "object", "description": "Version, commit, build date, Go version and platform"},
+			"protocolVersion": map[string]interface{}{"type": "string"},
+			"api":             map[string]interface{}{"type": "object", "description": "SpaceTraders server status, when it could be fetched"},
+			"apiError":        map[string]interface{}{"type": "string"},
+		}, "server", "protocolVersion"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *ServerInfoTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "server-info-tool")
+
+		build := version.Get()
+		result := map[string]interface{}{
+			"server":          build,
+			"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+		}
+
+		textSummary := "## ℹ️ Server Info\n\n"
+		textSummary += fmt.Sprintf("**Server:** spacetraders-mcp %s\n", build)
+		if build.Date != "" {
+			textSummary += fmt.Sprintf("**Built:** %s\n", build.Date)
+		}
+		textSummary += fmt.Sprintf("**Go:** %s on %s\n", build.GoVersion, build.Platform)
+		textSummary += fmt.Sprintf("**MCP Protocol:** %s\n", mcp.LATEST_PROTOCOL_VERSION)
+
+		// The build info is useful on its own, so an unreachable API doesn't fail the tool
+		status, err := t.client.WithContext(ctx).GetServerStatus()
+		if err != nil {
+			contextLogger.Warn("Failed to get server status: %v", err)
+			result["apiError"] = err.Error()
+			textSummary += fmt.Sprintf("**SpaceTraders API:** unavailable (%s)\n", err.Error())
+		} else {
+			result["api"] = status
+			textSummary += fmt.Sprintf("**SpaceTraders API:** %s, last reset %s\n", status.Version, status.ResetDate)
+		}
+
+		contextLogger.ToolCall("server_info", true)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
@@ -0,0 +1,55 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/version"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestServerInfoTool_Handler(t *testing.T) {
+	defer func(v string) { version.Version = v }(version.Version)
+	version.Version = "v1.2.3"
+
+	apiUp := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !apiUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": {"message": "Maintenance", "code": 503}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "SpaceTraders is currently online", "version": "v2.3.0", "resetDate": "2025-01-05",
+			"description": "", "stats": {"agents": 1, "ships": 2, "systems": 3, "waypoints": 4},
+			"leaderboards": {"mostCredits": [], "mostSubmittedCharts": []},
+			"serverResets": {"next": "2025-01-19T16:00:00.000Z", "frequency": "fortnightly"}, "announcements": [], "links": []}`))
+	}))
+	defer server.Close()
+	tool := NewServerInfoTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil))
+
+	result, err := tool.Handler()(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected failure: %v %+v", err, result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"spacetraders-mcp v1.2.3", mcp.LATEST_PROTOCOL_VERSION, "v2.3.0, last reset 2025-01-05"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in %q", want, text)
+		}
+	}
+
+	// The build info is still reported when the API is down
+	apiUp = false
+	result, _ = tool.Handler()(context.Background(), mcp.CallToolRequest{})
+	data := result.StructuredContent.(map[string]interface{})
+	if result.IsError || data["apiError"] == nil || data["server"].(version.Info).Version != "v1.2.3" {
+		t.Errorf("Expected the build info with an API error, got %+v", data)
+	}
+}
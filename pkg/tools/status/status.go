@@ -8,6 +8,7 @@ import (
 
 	"spacetraders-mcp/pkg/client"
 	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -30,7 +31,7 @@ func NewStatusTool(client *client.Client, logger *logging.Logger) *StatusTool {
 func (t *StatusTool) Tool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "get_status_summary",
-		Description: "Get a comprehensive status summary including agent info, ships, and contracts",
+		Description: "Get a comprehensive status summary including agent info, ships, and contracts. The spacetraders://dashboard resource has the same overview as compact JSON, plus running tasks and recent transactions.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -46,6 +47,11 @@ func (t *StatusTool) Tool() mcp.Tool {
 				},
 			},
 		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"agent":     map[string]interface{}{"type": "object", "description": "Agent symbol, credits and headquarters"},
+			"ships":     map[string]interface{}{"type": "object", "description": "Fleet totals, or an error"},
+			"contracts": map[string]interface{}{"type": "object", "description": "Contract totals and details, or an error"},
+		}, "agent"),
 	}
 }
 
@@ -56,23 +62,12 @@ func (t *StatusTool) Handler() func(ctx context.Context, request mcp.CallToolReq
 		ctxLogger := t.logger.WithContext(ctx, "status-tool")
 		ctxLogger.Debug("Getting comprehensive status summary")
 
-		// Parse arguments
-		includeShips := true
-		includeContracts := true
-
-		if request.Params.Arguments != nil {
-			if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
-				if val, exists := argsMap["include_ships"]; exists {
-					if b, ok := val.(bool); ok {
-						includeShips = b
-					}
-				}
-				if val, exists := argsMap["include_contracts"]; exists {
-					if b, ok := val.(bool); ok {
-						includeContracts = b
-					}
-				}
-			}
+		v := utils.NewValidator(request.Params.Arguments)
+		includeShips := v.OptionalBool("include_ships", true)
+		includeContracts := v.OptionalBool("include_contracts", true)
+		if result := v.Result(); result != nil {
+			ctxLogger.Error("Invalid arguments: %v", v.Errors())
+			return result, nil
 		}
 
 		// Build status summary
@@ -83,7 +78,7 @@ func (t *StatusTool) Handler() func(ctx context.Context, request mcp.CallToolReq
 
 		// Get agent information
 		ctxLogger.Debug("Fetching agent information")
-		agent, err := t.client.GetAgent()
+		agent, err := t.client.WithContext(ctx).GetAgent()
 		if err != nil {
 			ctxLogger.Error("Failed to fetch agent info: %v", err)
 			return &mcp.CallToolResult{
@@ -107,7 +102,7 @@ func (t *StatusTool) Handler() func(ctx context.Context, request mcp.CallToolReq
 		// Get ships if requested
 		if includeShips {
 			ctxLogger.Debug("Fetching ships information")
-			ships, err := t.client.GetAllShips()
+			ships, err := t.client.WithContext(ctx).GetAllShips()
 			if err != nil {
 				ctxLogger.Error("Failed to fetch ships: %v", err)
 				summary["ships"] = map[string]interface{}{
@@ -142,7 +137,7 @@ func (t *StatusTool) Handler() func(ctx context.Context, request mcp.CallToolReq
 		// Get contracts if requested
 		if includeContracts {
 			ctxLogger.Debug("Fetching contracts information")
-			contracts, err := t.client.GetAllContracts()
+			contracts, err := t.client.WithContext(ctx).GetAllContracts()
 			if err != nil {
 				ctxLogger.Error("Failed to fetch contracts: %v", err)
 				summary["contracts"] = map[string]interface{}{
@@ -230,12 +225,7 @@ func (t *StatusTool) Handler() func(ctx context.Context, request mcp.CallToolReq
 		// Create formatted text summary
 		textSummary := t.formatTextSummary(summary)
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(textSummary),
-				mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", string(jsonData))),
-			},
-		}, nil
+		return utils.NewResult(textSummary, summary), nil
 	}
 }
 
@@ -0,0 +1,262 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tools/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// maxWait caps a single wait so the call returns before clients give up on it; longer
+	// waits report what is left so the caller can wait again
+	maxWait = 10 * time.Minute
+	// progressInterval is how often a wait reports progress to clients that asked for it
+	progressInterval = 5 * time.Second
+)
+
+// WaitTool blocks until a time, a ship's cooldown expiry or a ship's arrival, so agents don't
+// have to poll to know when to act
+type WaitTool struct {
+	client    *client.Client
+	cooldowns *cooldowns.Tracker
+	logger    *logging.Logger
+}
+
+// NewWaitTool creates a new wait tool
+func NewWaitTool(client *client.Client, logger *logging.Logger) *WaitTool {
+	return &WaitTool{
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithCooldowns answers cooldown waits from the tracker instead of asking the API
+func (t *WaitTool) WithCooldowns(tracker *cooldowns.Tracker) *WaitTool {
+	t.cooldowns = tracker
+	return t
+}
+
+// Tool returns the MCP tool definition
+func (t *WaitTool) Tool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "wait",
+		Description: fmt.Sprintf("Wait on the server until a number of seconds has passed, until a timestamp, or until a ship's cooldown expires or it arrives, then return. Use it instead of polling resources when nothing useful can be done until then. Give exactly one of seconds, until_timestamp or until. Sends progress notifications while waiting and stops early if the call is cancelled. A single wait lasts at most %d minutes; longer waits return what is left so you can wait again.", int(maxWait.Minutes())),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"seconds": map[string]interface{}{
+					"type":        "number",
+					"description": "Number of seconds to wait",
+					"minimum":     0,
+				},
+				"until_timestamp": map[string]interface{}{
+					"type":        "string",
+					"description": "RFC 3339 time to wait until (e.g., '2025-01-01T12:00:00Z')",
+				},
+				"until": map[string]interface{}{
+					"type":        "string",
+					"description": "Event to wait for: 'cooldown:SHIP' until the ship's cooldown expires, or 'arrival:SHIP' until it arrives (e.g., 'arrival:GHOST-1')",
+				},
+			},
+		},
+		OutputSchema: utils.OutputSchema(map[string]interface{}{
+			"waited_for":        map[string]interface{}{"type": "string", "description": "What the call waited for"},
+			"target":            map[string]interface{}{"type": "string", "description": "When the wait was due to end"},
+			"waited_seconds":    map[string]interface{}{"type": "number"},
+			"remaining_seconds": map[string]interface{}{"type": "number", "description": "Time still left when the wait was capped or cancelled"},
+			"completed":         map[string]interface{}{"type": "boolean", "description": "Whether the target time was reached"},
+			"cancelled":         map[string]interface{}{"type": "boolean"},
+		}, "waited_for", "target", "waited_seconds", "completed"),
+	}
+}
+
+// Handler returns the tool handler function
+func (t *WaitTool) Handler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contextLogger := t.logger.WithContext(ctx, "wait-tool")
+
+		v := utils.NewValidator(request.Params.Arguments)
+		wait := readWait(v)
+		if result := v.Result(); result != nil {
+			contextLogger.ToolCall("wait", false)
+			return result, nil
+		}
+
+		target, description, err := t.resolveTarget(ctx, wait)
+		if err != nil {
+			contextLogger.ToolCall("wait", false)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("❌ %s", err.Error())),
+				},
+				IsError: true,
+			}, nil
+		}
+
+		start := time.Now()
+		deadline := target
+		if deadline.Sub(start) > maxWait {
+			deadline = start.Add(maxWait)
+		}
+		contextLogger.Debug("Waiting for %s until %s", description, deadline.Format(time.RFC3339))
+
+		cancelled := t.sleep(ctx, request, start, deadline, description)
+
+		now := time.Now()
+		waited := now.Sub(start).Round(time.Second)
+		remaining := max(target.Sub(now), 0).Round(time.Second)
+		completed := remaining == 0
+
+		result := map[string]interface{}{
+			"waited_for":     description,
+			"target":         target.UTC().Format(time.RFC3339),
+			"waited_seconds": waited.Seconds(),
+			"completed":      completed,
+		}
+		if remaining > 0 {
+			result["remaining_seconds"] = remaining.Seconds()
+		}
+
+		var textSummary string
+		switch {
+		case cancelled:
+			result["cancelled"] = true
+			textSummary = fmt.Sprintf("⏹️ Stopped waiting for %s after %s; %s still left.", description, waited, remaining)
+		case completed:
+			textSummary = fmt.Sprintf("⏰ Done waiting for %s (waited %s).", description, waited)
+		default:
+			textSummary = fmt.Sprintf("⏳ Waited the maximum %s for %s; %s still left until %s. Call wait again to keep waiting.", waited, description, remaining, target.UTC().Format(time.RFC3339))
+		}
+
+		contextLogger.ToolCall("wait", !cancelled)
+		return utils.NewResult(textSummary, result), nil
+	}
+}
+
+// sleep waits until deadline, reporting progress as it goes. It returns true if the call was
+// cancelled first.
+func (t *WaitTool) sleep(ctx context.Context, request mcp.CallToolRequest, start, deadline time.Time, description string) bool {
+	total := deadline.Sub(start)
+	timer := time.NewTimer(total)
+	defer timer.Stop()
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-timer.C:
+			return false
+		case now := <-ticker.C:
+			elapsed := now.Sub(start)
+			utils.ReportProgress(ctx, request, elapsed.Seconds(), total.Seconds(),
+				fmt.Sprintf("Waiting for %s, %s left", description, max(deadline.Sub(now), 0).Round(time.Second)))
+		}
+	}
+}
+
+// waitArgs is what a wait call waits for: a number of seconds, a time, or a ship's cooldown or arrival
+type waitArgs struct {
+	seconds   float64
+	timestamp string
+	at        time.Time
+	event     string
+	ship      string
+}
+
+// readWait reads the one wait target the arguments give
+func readWait(v *utils.Validator) waitArgs {
+	wait := waitArgs{
+		seconds:   v.OptionalNumber("seconds", -1, 0, math.MaxFloat64),
+		timestamp: v.OptionalString("until_timestamp", ""),
+	}
+	until := v.OptionalString("until", "")
+
+	given := 0
+	for _, set := range []bool{wait.seconds >= 0, wait.timestamp != "", until != ""} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		v.Fail("seconds", fmt.Sprintf("%d wait targets given", given), "exactly one of seconds, until_timestamp or until")
+		return wait
+	}
+
+	if wait.timestamp != "" {
+		target, err := time.Parse(time.RFC3339, wait.timestamp)
+		if err != nil {
+			v.Fail("until_timestamp", fmt.Sprintf("'%s' is not a time", wait.timestamp), "an RFC 3339 time such as 2025-01-01T12:00:00Z")
+		}
+		wait.at = target
+	}
+	if until != "" {
+		event, ship, _ := strings.Cut(until, ":")
+		wait.event = strings.ToLower(strings.TrimSpace(event))
+		wait.ship = strings.ToUpper(strings.TrimSpace(ship))
+		if (wait.event != "cooldown" && wait.event != "arrival") || wait.ship == "" {
+			v.Fail("until", fmt.Sprintf("'%s' is not a ship event", until), "'cooldown:SHIP' or 'arrival:SHIP'")
+		}
+	}
+	return wait
+}
+
+// resolveTarget works out when the wait ends, and describes what it waits for
+func (t *WaitTool) resolveTarget(ctx context.Context, wait waitArgs) (time.Time, string, error) {
+	now := time.Now()
+	switch {
+	case wait.seconds >= 0:
+		return now.Add(time.Duration(wait.seconds * float64(time.Second))), fmt.Sprintf("%gs", wait.seconds), nil
+	case wait.timestamp != "":
+		return wait.at, wait.timestamp, nil
+	}
+
+	shipSymbol := wait.ship
+	c := t.client.WithContext(ctx)
+
+	switch wait.event {
+	case "cooldown":
+		description := fmt.Sprintf("%s's cooldown", shipSymbol)
+		if t.cooldowns != nil {
+			if cooldown, ok := t.cooldowns.Get(shipSymbol, now); ok {
+				return cooldown.Expiration, description, nil
+			}
+		}
+		cooldown, err := c.GetShipCooldown(shipSymbol)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		if cooldown == nil || cooldown.RemainingSeconds <= 0 {
+			return now, description, nil
+		}
+		if expiration, err := time.Parse(time.RFC3339, cooldown.Expiration); err == nil {
+			return expiration, description, nil
+		}
+		return now.Add(time.Duration(cooldown.RemainingSeconds) * time.Second), description, nil
+	case "arrival":
+		nav, err := c.GetShipNav(shipSymbol)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		description := fmt.Sprintf("%s to arrive at %s", shipSymbol, nav.WaypointSymbol)
+		if nav.Status != "IN_TRANSIT" {
+			return now, description, nil
+		}
+		arrival, err := time.Parse(time.RFC3339, nav.Route.Arrival)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("%s is in transit but its arrival time %q can't be read", shipSymbol, nav.Route.Arrival)
+		}
+		return arrival, description, nil
+	}
+	return time.Time{}, "", fmt.Errorf("unknown wait event %q", wait.event)
+}
@@ -0,0 +1,95 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/cooldowns"
+	"spacetraders-mcp/pkg/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func callWait(t *testing.T, tool *WaitTool, ctx context.Context, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	result, err := tool.Handler()(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "wait", Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
+
+func TestWaitTool_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/ships/SHIP-1/nav":
+			_, _ = w.Write([]byte(`{"data": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "IN_TRANSIT", "route": {"arrival": "` +
+				time.Now().Add(50*time.Millisecond).UTC().Format(time.RFC3339Nano) + `"}}}`))
+		case "/my/ships/SHIP-2/nav":
+			_, _ = w.Write([]byte(`{"data": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-B2", "status": "DOCKED", "route": {}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tracker := cooldowns.NewTracker()
+	tracker.Observe(client.Observation{Kind: client.ObservedCooldown, ShipSymbol: "SHIP-1", ObservedAt: time.Now(),
+		Cooldown: &client.Cooldown{TotalSeconds: 1, Expiration: time.Now().Add(300 * time.Millisecond).UTC().Format(time.RFC3339Nano)}})
+	tool := NewWaitTool(client.NewClientWithBaseURL("test-token", server.URL), logging.NewLogger(nil)).WithCooldowns(tracker)
+
+	for _, args := range []map[string]interface{}{
+		{"seconds": 0.05},
+		{"until": "cooldown:ship-1"},
+		{"until": "arrival:SHIP-1"},
+		{"until": "arrival:SHIP-2"},
+	} {
+		start := time.Now()
+		result := callWait(t, tool, context.Background(), args)
+		if result.IsError {
+			t.Errorf("Expected %v to complete, got %v", args, result.Content)
+			continue
+		}
+		if data := result.StructuredContent.(map[string]interface{}); data["completed"] != true {
+			t.Errorf("Expected %v to complete, got %v", args, data)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Expected %v to return promptly, took %s", args, elapsed)
+		}
+	}
+
+	for _, args := range []map[string]interface{}{
+		{},
+		{"seconds": float64(1), "until": "arrival:SHIP-1"},
+		{"until": "docking:SHIP-1"},
+		{"until_timestamp": "tomorrow"},
+	} {
+		if result := callWait(t, tool, context.Background(), args); !result.IsError {
+			t.Errorf("Expected %v to be rejected, got %v", args, result.Content)
+		}
+	}
+}
+
+func TestWaitTool_Handler_Cancelled(t *testing.T) {
+	tool := NewWaitTool(client.NewClientWithBaseURL("test-token", "http://127.0.0.1:1"), logging.NewLogger(nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result := callWait(t, tool, ctx, map[string]interface{}{"until_timestamp": time.Now().Add(time.Hour).UTC().Format(time.RFC3339)})
+
+	data := result.StructuredContent.(map[string]interface{})
+	if data["cancelled"] != true || data["completed"] != false {
+		t.Fatalf("Expected the wait to stop when cancelled, got %v", data)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Stopped waiting") {
+		t.Errorf("Expected the summary to say the wait stopped, got %q", text)
+	}
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// confirmSchema asks the user for a single yes/no answer
+var confirmSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"confirm": map[string]interface{}{
+			"type":        "boolean",
+			"title":       "Confirm",
+			"description": "Go ahead with this action",
+		},
+	},
+	"required": []string{"confirm"},
+}
+
+// CanConfirm reports whether the client calling a tool can ask its user to confirm an action
+// through MCP elicitation. Tools use it to skip looking up costs nobody will be shown.
+func CanConfirm(ctx context.Context) bool {
+	session := server.ClientSessionFromContext(ctx)
+	if _, ok := session.(server.SessionWithElicitation); !ok {
+		return false
+	}
+	if info, ok := session.(server.SessionWithClientInfo); ok {
+		return info.GetClientCapabilities().Elicitation != nil
+	}
+	return false
+}
+
+// Confirm asks the user to approve an action described by message, which should state exactly
+// what will be lost or spent. It returns true only when the user accepts and ticks confirm;
+// declining, cancelling or an elicitation failure all count as no. Callers check CanConfirm
+// first, since clients without elicitation can't be asked.
+func Confirm(ctx context.Context, message string) (bool, error) {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithElicitation)
+	if !ok {
+		return false, server.ErrElicitationNotSupported
+	}
+
+	result, err := session.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message:         message,
+			RequestedSchema: confirmSchema,
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to ask for confirmation: %w", err)
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+
+	content, ok := result.Content.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	confirmed, _ := content["confirm"].(bool)
+	return confirmed, nil
+}
+
+// NotConfirmedResult is the tool result returned when the user did not approve an action
+func NotConfirmedResult(action string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(fmt.Sprintf("🛑 Cancelled: the user did not confirm %s. Nothing was changed.", action)),
+		},
+		IsError: true,
+	}
+}
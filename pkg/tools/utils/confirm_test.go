@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// elicitingSession is a client session that answers every elicitation with a fixed response
+type elicitingSession struct {
+	capabilities mcp.ClientCapabilities
+	response     mcp.ElicitationResponse
+	messages     []string
+}
+
+func (s *elicitingSession) Initialize()                                         {}
+func (s *elicitingSession) Initialized() bool                                   { return true }
+func (s *elicitingSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *elicitingSession) SessionID() string                                   { return "test-session" }
+func (s *elicitingSession) GetClientInfo() mcp.Implementation                   { return mcp.Implementation{} }
+func (s *elicitingSession) SetClientInfo(mcp.Implementation)                    {}
+func (s *elicitingSession) GetClientCapabilities() mcp.ClientCapabilities       { return s.capabilities }
+func (s *elicitingSession) SetClientCapabilities(c mcp.ClientCapabilities)      { s.capabilities = c }
+
+func (s *elicitingSession) RequestElicitation(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	s.messages = append(s.messages, request.Params.Message)
+	return &mcp.ElicitationResult{ElicitationResponse: s.response}, nil
+}
+
+// sessionContext returns a tool call context for a client that answers elicitations with response
+func sessionContext(session *elicitingSession) context.Context {
+	return server.NewMCPServer("Test Server", "1.0.0").WithContext(context.Background(), session)
+}
+
+func TestCanConfirm(t *testing.T) {
+	if CanConfirm(context.Background()) {
+		t.Error("Expected no confirmation outside a client session")
+	}
+
+	session := &elicitingSession{}
+	if CanConfirm(sessionContext(session)) {
+		t.Error("Expected no confirmation for a client without the elicitation capability")
+	}
+
+	session.capabilities.Elicitation = &mcp.ElicitationCapability{}
+	if !CanConfirm(sessionContext(session)) {
+		t.Error("Expected confirmation for a client with the elicitation capability")
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		response mcp.ElicitationResponse
+		expected bool
+	}{
+		{"accepted", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]interface{}{"confirm": true}}, true},
+		{"accepted unticked", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]interface{}{"confirm": false}}, false},
+		{"declined", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}, false},
+		{"cancelled", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionCancel}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &elicitingSession{response: tt.response}
+			approved, err := Confirm(sessionContext(session), "Scrap ship PROBE-1 for 1200 credits?")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if approved != tt.expected {
+				t.Errorf("Expected approved=%v, got %v", tt.expected, approved)
+			}
+			if len(session.messages) != 1 || !strings.Contains(session.messages[0], "1200 credits") {
+				t.Errorf("Expected the user to be shown the cost, got %v", session.messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ReportProgress sends a progress notification for a long-running tool call. It does nothing
+// unless the client asked for progress by sending a progress token with the request, or when
+// the call is not being served by an MCP server, as in tests.
+func ReportProgress(ctx context.Context, request mcp.CallToolRequest, progress, total float64, message string) {
+	meta := request.Params.Meta
+	if meta == nil || meta.ProgressToken == nil {
+		return
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": meta.ProgressToken,
+		"progress":      progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	// Progress is best effort: a client that went away is noticed through the context instead
+	_ = mcpServer.SendNotificationToClient(ctx, "notifications/progress", params)
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NewResult builds a successful tool result from a short human-readable summary and the result data.
+// The data is returned as structured content for clients that read it, and repeated as a JSON text
+// block for clients that only show text content.
+func NewResult(summary string, data interface{}) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(summary),
+			mcp.NewTextContent(fmt.Sprintf("```json\n%s\n```", FormatJSON(data))),
+		},
+		StructuredContent: data,
+	}
+}
+
+// OutputSchema declares the object a tool returns as structured content. Properties describe its
+// top-level fields the same way an input schema does; required lists the fields always present.
+func OutputSchema(properties map[string]interface{}, required ...string) mcp.ToolOutputSchema {
+	return mcp.ToolOutputSchema{
+		Type:       "object",
+		Properties: properties,
+		Required:   required,
+	}
+}
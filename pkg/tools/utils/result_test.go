@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestNewResult(t *testing.T) {
+	data := map[string]interface{}{"ship_symbol": "SHIP-1", "units": 5}
+	result := NewResult("Sold 5 units", data)
+
+	if result.IsError {
+		t.Error("Expected a successful result")
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected a summary and a JSON block, got %d content items", len(result.Content))
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; text != "Sold 5 units" {
+		t.Errorf("Expected the summary first, got %q", text)
+	}
+	if text := result.Content[1].(mcp.TextContent).Text; !contains(text, `"ship_symbol": "SHIP-1"`) {
+		t.Errorf("Expected the data as a JSON block, got %q", text)
+	}
+
+	structured, ok := result.StructuredContent.(map[string]interface{})
+	if !ok || structured["units"] != 5 {
+		t.Errorf("Expected the data as structured content, got %#v", result.StructuredContent)
+	}
+}
+
+func TestOutputSchema(t *testing.T) {
+	schema := OutputSchema(map[string]interface{}{
+		"ship_symbol": map[string]interface{}{"type": "string"},
+	}, "ship_symbol")
+
+	if schema.Type != "object" {
+		t.Errorf("Expected an object schema, got %q", schema.Type)
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "ship_symbol" {
+		t.Errorf("Expected ship_symbol to be required, got %v", schema.Required)
+	}
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// Ship nav statuses that actions require
+const (
+	StatusDocked  = "DOCKED"
+	StatusInOrbit = "IN_ORBIT"
+)
+
+// AutoCorrectStateProperty is the input schema entry shared by tools that need the ship docked or in orbit
+func AutoCorrectStateProperty(defaultValue bool) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Check the ship's nav status first and dock or orbit it if the action needs a different state",
+		"default":     defaultValue,
+	}
+}
+
+// EnsureShipState docks or orbits the ship if it is not already in the required status
+// (StatusDocked or StatusInOrbit). It returns a note describing the extra step taken, or ""
+// when the ship was already in place. A ship in transit cannot be corrected.
+func EnsureShipState(c *client.Client, shipSymbol, required string) (string, error) {
+	nav, err := c.GetShipNav(shipSymbol)
+	if err != nil {
+		return "", fmt.Errorf("failed to check ship status: %w", err)
+	}
+
+	switch {
+	case nav.Status == required:
+		return "", nil
+	case nav.Status == "IN_TRANSIT":
+		return "", fmt.Errorf("ship %s is in transit to %s and must arrive first", shipSymbol, nav.Route.Destination.Symbol)
+	case required == StatusDocked:
+		if _, err := c.DockShip(shipSymbol); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Docked %s at %s first (it was in orbit)", shipSymbol, nav.WaypointSymbol), nil
+	default:
+		if _, err := c.OrbitShip(shipSymbol); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Moved %s into orbit at %s first (it was docked)", shipSymbol, nav.WaypointSymbol), nil
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+const testNav = `{
+	"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": %q, "flightMode": "CRUISE",
+	"route": {
+		"origin": {"symbol": "X1-TEST-A1", "type": "PLANET", "systemSymbol": "X1-TEST", "x": 0, "y": 0},
+		"destination": {"symbol": "X1-TEST-B2", "type": "MOON", "systemSymbol": "X1-TEST", "x": 5, "y": 5},
+		"departureTime": "2024-01-01T00:00:00.000Z", "arrival": "2024-01-01T00:01:00.000Z"
+	}
+}`
+
+// newNavServer serves a ship in the given nav status and records dock and orbit calls
+func newNavServer(t *testing.T, status string, calls *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my/ships/SHIP-1/nav":
+			_, _ = fmt.Fprintf(w, `{"data": `+testNav+`}`, status)
+		case "POST /my/ships/SHIP-1/dock":
+			*calls = append(*calls, "dock")
+			_, _ = fmt.Fprintf(w, `{"data": {"nav": `+testNav+`}}`, StatusDocked)
+		case "POST /my/ships/SHIP-1/orbit":
+			*calls = append(*calls, "orbit")
+			_, _ = fmt.Fprintf(w, `{"data": {"nav": `+testNav+`}}`, StatusInOrbit)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestEnsureShipState(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		required  string
+		wantCalls string
+		wantNote  string
+		wantErr   string
+	}{
+		{"already docked", StatusDocked, StatusDocked, "", "", ""},
+		{"docks from orbit", StatusInOrbit, StatusDocked, "dock", "Docked SHIP-1 at X1-TEST-A1", ""},
+		{"orbits from dock", StatusDocked, StatusInOrbit, "orbit", "Moved SHIP-1 into orbit", ""},
+		{"in transit", "IN_TRANSIT", StatusDocked, "", "", "in transit to X1-TEST-B2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := newNavServer(t, tt.status, &calls)
+			defer server.Close()
+
+			note, err := EnsureShipState(client.NewClientWithBaseURL("test-token", server.URL), "SHIP-1", tt.required)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := strings.Join(calls, ","); got != tt.wantCalls {
+				t.Errorf("Expected calls %q, got %q", tt.wantCalls, got)
+			}
+			if (tt.wantNote == "") != (note == "") || !strings.Contains(note, tt.wantNote) {
+				t.Errorf("Expected note containing %q, got %q", tt.wantNote, note)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"spacetraders-mcp/pkg/shipmeta"
+)
+
+// SquadronProperty is the input schema entry shared by tools that act on every ship in a squadron
+var SquadronProperty = map[string]interface{}{
+	"type":        "string",
+	"description": "Name of a squadron created with create_squadron (e.g., 'mining-fleet')",
+}
+
+// FindSquadron looks up a squadron by name. The error lists the squadrons that do exist so the
+// caller can correct itself in one step.
+func FindSquadron(store *shipmeta.Store, name string) (shipmeta.Squadron, error) {
+	if squadron, ok := store.Squadron(name); ok {
+		return squadron, nil
+	}
+
+	squadrons := store.Squadrons()
+	if len(squadrons) == 0 {
+		return shipmeta.Squadron{}, fmt.Errorf("no squadron named '%s'; create one with create_squadron", name)
+	}
+	names := make([]string, len(squadrons))
+	for i, squadron := range squadrons {
+		names[i] = squadron.Name
+	}
+	return shipmeta.Squadron{}, fmt.Errorf("no squadron named '%s'. Squadrons: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	spacetraders "github.com/grantmd/spacetraders-mcp/spacetraders"
+)
+
+// SymbolKind identifies a game enumeration that tool arguments are validated against
+type SymbolKind string
+
+const (
+	TradeSymbols   SymbolKind = "trade symbol"
+	ShipTypes      SymbolKind = "ship type"
+	WaypointTraits SymbolKind = "waypoint trait"
+	WaypointTypes  SymbolKind = "waypoint type"
+	FlightModes    SymbolKind = "flight mode"
+	SystemTypes    SymbolKind = "system type"
+	FactionSymbols SymbolKind = "faction symbol"
+)
+
+var (
+	enumerationsOnce sync.Once
+	enumerations     map[SymbolKind][]string
+	enumerationSets  map[SymbolKind]map[string]bool
+)
+
+// loadEnumerations caches the enumerations from the OpenAPI-generated client,
+// which is the authoritative list of values the game API accepts
+func loadEnumerations() {
+	enumerationsOnce.Do(func() {
+		enumerations = map[SymbolKind][]string{
+			TradeSymbols:   toStrings(spacetraders.AllowedTradeSymbolEnumValues),
+			ShipTypes:      toStrings(spacetraders.AllowedShipTypeEnumValues),
+			WaypointTraits: toStrings(spacetraders.AllowedWaypointTraitSymbolEnumValues),
+			WaypointTypes:  toStrings(spacetraders.AllowedWaypointTypeEnumValues),
+			FlightModes:    toStrings(spacetraders.AllowedShipNavFlightModeEnumValues),
+			SystemTypes:    toStrings(spacetraders.AllowedSystemTypeEnumValues),
+			FactionSymbols: toStrings(spacetraders.AllowedFactionSymbolEnumValues),
+		}
+
+		enumerationSets = make(map[SymbolKind]map[string]bool, len(enumerations))
+		for kind, values := range enumerations {
+			sort.Strings(values)
+			set := make(map[string]bool, len(values))
+			for _, value := range values {
+				set[value] = true
+			}
+			enumerationSets[kind] = set
+		}
+	})
+}
+
+// toStrings converts a slice of generated enum values into plain strings
+func toStrings[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+// AllowedSymbols returns the sorted list of valid values for an enumeration
+func AllowedSymbols(kind SymbolKind) []string {
+	loadEnumerations()
+	return append([]string(nil), enumerations[kind]...)
+}
+
+// NormalizeSymbol converts free-form input like "iron ore" or "ship-probe" into enum form (IRON_ORE, SHIP_PROBE)
+func NormalizeSymbol(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return '_'
+		}
+		return r
+	}, value)
+}
+
+// ValidateSymbol normalizes a value and checks it against the given enumeration, exactly as
+// Validator.RequireSymbol checks a tool argument. The returned error lists the closest matches and
+// every allowed value so the caller can correct itself in one step.
+func ValidateSymbol(kind SymbolKind, value string) (string, error) {
+	v := &Validator{}
+	symbol := v.symbol("", kind, value)
+	if len(v.errors) > 0 {
+		return "", fmt.Errorf("%s", v.errors[0].detail())
+	}
+	return symbol, nil
+}
+
+// maxSuggestedSymbols is how many close matches an unknown symbol's error suggests
+const maxSuggestedSymbols = 5
+
+// suggestSymbols returns allowed values sharing a word with the input, closest first
+func suggestSymbols(allowed []string, value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	words := strings.Split(value, "_")
+	type candidate struct {
+		symbol string
+		score  int
+	}
+	candidates := make([]candidate, 0)
+
+	for _, symbol := range allowed {
+		score := 0
+		if strings.Contains(symbol, value) {
+			score += len(words) + 1
+		}
+		for _, word := range words {
+			if len(word) >= 3 && strings.Contains(symbol, word) {
+				score++
+			}
+		}
+		if score > 0 {
+			candidates = append(candidates, candidate{symbol: symbol, score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	suggestions := make([]string, 0, maxSuggestedSymbols)
+	for i := 0; i < len(candidates) && i < maxSuggestedSymbols; i++ {
+		suggestions = append(suggestions, candidates[i].symbol)
+	}
+	return suggestions
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestValidateSymbol(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     SymbolKind
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"exact trade symbol", TradeSymbols, "IRON_ORE", "IRON_ORE", false},
+		{"free-form trade symbol", TradeSymbols, " iron ore ", "IRON_ORE", false},
+		{"hyphenated ship type", ShipTypes, "ship-probe", "SHIP_PROBE", false},
+		{"waypoint trait", WaypointTraits, "shipyard", "SHIPYARD", false},
+		{"flight mode", FlightModes, "cruise", "CRUISE", false},
+		{"unknown trade symbol", TradeSymbols, "IRON_BAR", "", true},
+		{"unknown flight mode", FlightModes, "WARP", "", true},
+		{"empty value", ShipTypes, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateSymbol(tt.kind, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSymbol(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ValidateSymbol(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateSymbol_ErrorListsAllowedValues(t *testing.T) {
+	_, err := ValidateSymbol(TradeSymbols, "IRON_BAR")
+	if err == nil {
+		t.Fatal("Expected an error for an unknown trade symbol")
+	}
+
+	message := err.Error()
+	if !contains(message, "Did you mean: IRON") {
+		t.Errorf("Expected suggestions starting with IRON goods, got: %s", message)
+	}
+	if !contains(message, "Allowed values:") || !contains(message, "PRECIOUS_STONES") {
+		t.Errorf("Expected the full list of allowed values, got: %s", message)
+	}
+}
+
+func TestAllowedSymbols(t *testing.T) {
+	modes := AllowedSymbols(FlightModes)
+	if len(modes) != 4 {
+		t.Fatalf("Expected 4 flight modes, got %d: %v", len(modes), modes)
+	}
+
+	// Callers must not be able to modify the cached enumeration
+	modes[0] = "MODIFIED"
+	if AllowedSymbols(FlightModes)[0] == "MODIFIED" {
+		t.Error("Expected AllowedSymbols to return a copy")
+	}
+}
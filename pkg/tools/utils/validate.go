@@ -0,0 +1,547 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	systemSymbolPattern   = regexp.MustCompile(`^[A-Z0-9]+-[A-Z0-9]+$`)
+	waypointSymbolPattern = regexp.MustCompile(`^[A-Z0-9]+-[A-Z0-9]+-[A-Z0-9]+$`)
+	shipSymbolPattern     = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]*$`)
+)
+
+// catalogs are the resources listing an enumeration's values with their names
+var catalogs = map[SymbolKind]string{
+	TradeSymbols: "spacetraders://catalog/trade-goods",
+	ShipTypes:    "spacetraders://catalog/ship-types",
+}
+
+// FieldError is what is wrong with one tool argument, in enough detail to correct it
+type FieldError struct {
+	Field   string `json:"field"`
+	Problem string `json:"problem"`
+	// Expected describes a valid value, such as "a waypoint symbol like X1-DF55-A1"
+	Expected string `json:"expected,omitempty"`
+	// Suggestions are the allowed values closest to what was given
+	Suggestions []string `json:"suggestions,omitempty"`
+	// Allowed lists every valid value of an enumerated argument
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// String explains the error in one line
+func (e FieldError) String() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.detail())
+}
+
+// detail explains the error without naming the field
+func (e FieldError) detail() string {
+	message := e.Problem
+	if e.Expected != "" {
+		message += fmt.Sprintf("; expected %s", e.Expected)
+	}
+	if len(e.Suggestions) > 0 {
+		message += fmt.Sprintf(". Did you mean: %s?", strings.Join(e.Suggestions, ", "))
+	}
+	if len(e.Allowed) > 0 {
+		if len(e.Suggestions) == 0 {
+			message += "."
+		}
+		message += fmt.Sprintf(" Allowed values: %s", strings.Join(e.Allowed, ", "))
+	}
+	return message
+}
+
+// Validator reads tool arguments, normalizing symbols as it goes, and collects an error for every
+// argument that is missing or malformed so one reply can report them all. Read each argument,
+// then return Result when it is not nil:
+//
+//	v := utils.NewValidator(request.Params.Arguments)
+//	ship := v.RequireShip("ship_symbol")
+//	mode := v.OptionalSymbol("flight_mode", utils.FlightModes, "CRUISE")
+//	if result := v.Result(); result != nil {
+//		return result, nil
+//	}
+type Validator struct {
+	args   map[string]interface{}
+	errors []FieldError
+}
+
+// NewValidator validates the arguments of a tool call
+func NewValidator(arguments interface{}) *Validator {
+	args, _ := arguments.(map[string]interface{})
+	return &Validator{args: args}
+}
+
+// Errors returns the problems found so far, in the order the arguments were read
+func (v *Validator) Errors() []FieldError {
+	return v.errors
+}
+
+// Result returns an error result listing every problem found, or nil when the arguments are valid.
+// The errors are also returned as structured content under "errors".
+func (v *Validator) Result() *mcp.CallToolResult {
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	if len(v.errors) == 1 {
+		b.WriteString("❌ Invalid argument:")
+	} else {
+		fmt.Fprintf(&b, "❌ %d invalid arguments:", len(v.errors))
+	}
+	for _, e := range v.errors {
+		b.WriteString("\n- ")
+		b.WriteString(e.String())
+	}
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{mcp.NewTextContent(b.String())},
+		StructuredContent: map[string]interface{}{"errors": v.errors},
+		IsError:           true,
+	}
+}
+
+// fail records a problem with an argument
+func (v *Validator) fail(e FieldError) {
+	v.errors = append(v.errors, e)
+}
+
+// Fail records a problem the tool found with an argument it read, such as two arguments that
+// must differ, so it is reported alongside the rest
+func (v *Validator) Fail(field, problem, expected string) {
+	v.fail(FieldError{Field: field, Problem: problem, Expected: expected})
+}
+
+// str reads a string argument, trimmed. present is false when the argument is missing or empty;
+// an argument of another type is recorded as an error.
+func (v *Validator) str(field string) (value string, present bool) {
+	raw, exists := v.args[field]
+	if !exists || raw == nil {
+		return "", false
+	}
+	s, ok := raw.(string)
+	if !ok {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("must be a string, got %T", raw)})
+		return "", false
+	}
+	s = strings.TrimSpace(s)
+	return s, s != ""
+}
+
+// missing records a required argument that wasn't given
+func (v *Validator) missing(field, expected string) {
+	v.fail(FieldError{Field: field, Problem: "is required", Expected: expected})
+}
+
+// RequireString reads a required, non-empty string argument
+func (v *Validator) RequireString(field string) string {
+	value, ok := v.str(field)
+	if !ok {
+		if !v.lastFailed(field) {
+			v.missing(field, "a non-empty string")
+		}
+		return ""
+	}
+	return value
+}
+
+// OptionalString reads a string argument, returning def when it is missing or empty
+func (v *Validator) OptionalString(field, def string) string {
+	if value, ok := v.str(field); ok {
+		return value
+	}
+	return def
+}
+
+// ClearableString reads a string argument that may be empty, as when an empty value clears a
+// setting. given is false only when the argument is missing.
+func (v *Validator) ClearableString(field string) (value string, given bool) {
+	raw, exists := v.args[field]
+	if !exists || raw == nil {
+		return "", false
+	}
+	s, ok := raw.(string)
+	if !ok {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("must be a string, got %T", raw)})
+		return "", false
+	}
+	return strings.TrimSpace(s), true
+}
+
+// OptionalStrings reads a list of free-form strings, such as tags, skipping empty entries
+func (v *Validator) OptionalStrings(field string) []string {
+	return v.list(field, "a list of strings", func(entry, value string) string {
+		return value
+	})
+}
+
+// RequireSymbol reads a required argument that must be one of an enumeration, such as a trade
+// symbol or flight mode. Free-form input like "iron ore" is normalized to IRON_ORE.
+func (v *Validator) RequireSymbol(field string, kind SymbolKind) string {
+	value, ok := v.str(field)
+	if !ok {
+		if !v.lastFailed(field) {
+			v.missing(field, fmt.Sprintf("a %s", kind))
+		}
+		return ""
+	}
+	return v.symbol(field, kind, value)
+}
+
+// OptionalSymbol reads an enumerated argument, returning def when it is missing or empty
+func (v *Validator) OptionalSymbol(field string, kind SymbolKind, def string) string {
+	value, ok := v.str(field)
+	if !ok {
+		return def
+	}
+	return v.symbol(field, kind, value)
+}
+
+// symbol checks a value against an enumeration
+func (v *Validator) symbol(field string, kind SymbolKind, value string) string {
+	loadEnumerations()
+	normalized := NormalizeSymbol(value)
+	if enumerationSets[kind][normalized] {
+		return normalized
+	}
+	v.fail(FieldError{
+		Field:       field,
+		Problem:     fmt.Sprintf("unknown %s '%s'", kind, value),
+		Expected:    catalogHint(kind),
+		Suggestions: suggestSymbols(enumerations[kind], normalized),
+		Allowed:     AllowedSymbols(kind),
+	})
+	return ""
+}
+
+// catalogHint points to the resource listing an enumeration, when there is one
+func catalogHint(kind SymbolKind) string {
+	if uri, ok := catalogs[kind]; ok {
+		return fmt.Sprintf("a %s listed in %s", kind, uri)
+	}
+	return ""
+}
+
+// OptionalSymbols reads a list of enumerated values, such as trade symbols, skipping empty
+// entries. A bad entry is reported under its position, such as keep[1].
+func (v *Validator) OptionalSymbols(field string, kind SymbolKind) []string {
+	return v.list(field, fmt.Sprintf("a list of %ss", kind), func(entry, value string) string {
+		return v.symbol(entry, kind, value)
+	})
+}
+
+// OptionalWaypoints reads a list of waypoint symbols, skipping empty entries
+func (v *Validator) OptionalWaypoints(field string) []string {
+	return v.list(field, "a list of waypoint symbols like X1-DF55-A1", func(entry, value string) string {
+		return v.match(entry, value, waypointSymbolPattern, "a waypoint symbol like X1-DF55-A1")
+	})
+}
+
+// RequireWaypoints reads a list of at least one waypoint symbol
+func (v *Validator) RequireWaypoints(field string) []string {
+	before := len(v.errors)
+	waypoints := v.OptionalWaypoints(field)
+	if len(waypoints) == 0 && len(v.errors) == before {
+		v.missing(field, "a list of waypoint symbols like X1-DF55-A1")
+	}
+	return waypoints
+}
+
+// OptionalShips reads a list of ship symbols, skipping empty entries
+func (v *Validator) OptionalShips(field string) []string {
+	return v.list(field, "a list of ship symbols like AGENT-1", func(entry, value string) string {
+		return v.match(entry, value, shipSymbolPattern, "a ship symbol like AGENT-1")
+	})
+}
+
+// RequireShips reads a list of at least one ship symbol
+func (v *Validator) RequireShips(field string) []string {
+	before := len(v.errors)
+	ships := v.OptionalShips(field)
+	if len(ships) == 0 && len(v.errors) == before {
+		v.missing(field, "a list of ship symbols like AGENT-1")
+	}
+	return ships
+}
+
+// list reads a list of strings, passing each non-empty entry and its position, such as keep[1],
+// to read, and keeping the entries it returns a value for
+func (v *Validator) list(field, expected string, read func(entry, value string) string) []string {
+	raw, exists := v.args[field]
+	if !exists || raw == nil {
+		return nil
+	}
+	var items []interface{}
+	switch list := raw.(type) {
+	case []interface{}:
+		items = list
+	case []string:
+		for _, item := range list {
+			items = append(items, item)
+		}
+	default:
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("must be a list, got %T", raw), Expected: expected})
+		return nil
+	}
+
+	values := make([]string, 0, len(items))
+	for i, item := range items {
+		entry := fmt.Sprintf("%s[%d]", field, i)
+		value, ok := item.(string)
+		if !ok {
+			v.fail(FieldError{Field: entry, Problem: fmt.Sprintf("must be a string, got %T", item)})
+			continue
+		}
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		if symbol := read(entry, value); symbol != "" {
+			values = append(values, symbol)
+		}
+	}
+	return values
+}
+
+// RequireChoice reads a required string argument that must be one of choices, ignoring case
+func (v *Validator) RequireChoice(field string, choices ...string) string {
+	if _, ok := v.str(field); !ok {
+		if !v.lastFailed(field) {
+			v.fail(FieldError{Field: field, Problem: "is required", Allowed: choices})
+		}
+		return ""
+	}
+	return v.OptionalChoice(field, "", choices...)
+}
+
+// OptionalChoice reads a string argument that must be one of choices, ignoring case, returning
+// def when it is missing or empty
+func (v *Validator) OptionalChoice(field, def string, choices ...string) string {
+	value, ok := v.str(field)
+	if !ok {
+		return def
+	}
+	for _, choice := range choices {
+		if strings.EqualFold(value, choice) {
+			return choice
+		}
+	}
+	v.fail(FieldError{Field: field, Problem: fmt.Sprintf("unknown value '%s'", value), Allowed: choices})
+	return def
+}
+
+// OptionalBool reads a boolean argument, returning def when it is missing
+func (v *Validator) OptionalBool(field string, def bool) bool {
+	raw, exists := v.args[field]
+	if !exists || raw == nil {
+		return def
+	}
+	value, ok := raw.(bool)
+	if !ok {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("must be true or false, got %T", raw)})
+		return def
+	}
+	return value
+}
+
+// OptionalObject decodes an object argument, such as a survey, into target and reports whether it
+// was given and valid
+func (v *Validator) OptionalObject(field string, target interface{}) bool {
+	raw, exists := v.args[field]
+	if !exists || raw == nil {
+		return false
+	}
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("must be an object, got %T", raw)})
+		return false
+	}
+	data, err := json.Marshal(object)
+	if err == nil {
+		err = json.Unmarshal(data, target)
+	}
+	if err != nil {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("is not well formed: %v", err)})
+		return false
+	}
+	return true
+}
+
+// RequireSystem reads a required system symbol such as X1-DF55
+func (v *Validator) RequireSystem(field string) string {
+	return v.format(field, true, systemSymbolPattern, "a system symbol like X1-DF55")
+}
+
+// OptionalSystem reads a system symbol, returning "" when it is missing or empty
+func (v *Validator) OptionalSystem(field string) string {
+	return v.format(field, false, systemSymbolPattern, "a system symbol like X1-DF55")
+}
+
+// RequireWaypoint reads a required waypoint symbol such as X1-DF55-A1
+func (v *Validator) RequireWaypoint(field string) string {
+	return v.format(field, true, waypointSymbolPattern, "a waypoint symbol like X1-DF55-A1")
+}
+
+// OptionalWaypoint reads a waypoint symbol, returning "" when it is missing or empty
+func (v *Validator) OptionalWaypoint(field string) string {
+	return v.format(field, false, waypointSymbolPattern, "a waypoint symbol like X1-DF55-A1")
+}
+
+// RequireShip reads a required ship symbol, usually the agent symbol and a number such as AGENT-1
+func (v *Validator) RequireShip(field string) string {
+	return v.format(field, true, shipSymbolPattern, "a ship symbol like AGENT-1")
+}
+
+// OptionalShip reads a ship symbol, returning "" when it is missing or empty
+func (v *Validator) OptionalShip(field string) string {
+	return v.format(field, false, shipSymbolPattern, "a ship symbol like AGENT-1")
+}
+
+// format reads a symbol argument, uppercased, that must match pattern. Giving a waypoint where a
+// system is wanted, or the reverse, gets a hint naming the other one.
+func (v *Validator) format(field string, required bool, pattern *regexp.Regexp, expected string) string {
+	value, ok := v.str(field)
+	if !ok {
+		if required && !v.lastFailed(field) {
+			v.missing(field, expected)
+		}
+		return ""
+	}
+	return v.match(field, value, pattern, expected)
+}
+
+// match uppercases value and checks it against pattern
+func (v *Validator) match(field, value string, pattern *regexp.Regexp, expected string) string {
+	symbol := strings.ToUpper(value)
+	if pattern.MatchString(symbol) {
+		return symbol
+	}
+
+	problem := fmt.Sprintf("'%s' is not well formed", value)
+	switch {
+	case pattern == systemSymbolPattern && waypointSymbolPattern.MatchString(symbol):
+		problem = fmt.Sprintf("'%s' is a waypoint; its system is %s", value, symbol[:strings.LastIndex(symbol, "-")])
+	case pattern == waypointSymbolPattern && systemSymbolPattern.MatchString(symbol):
+		problem = fmt.Sprintf("'%s' is a system, not a waypoint in it", value)
+	}
+	v.fail(FieldError{Field: field, Problem: problem, Expected: expected})
+	return ""
+}
+
+// RequireInt reads a required whole number argument of at least min
+func (v *Validator) RequireInt(field string, min int) int {
+	value, ok := v.number(field)
+	if !ok {
+		if !v.lastFailed(field) {
+			v.missing(field, fmt.Sprintf("a whole number of at least %d", min))
+		}
+		return 0
+	}
+	if value < min {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("is %d", value), Expected: fmt.Sprintf("a whole number of at least %d", min)})
+		return 0
+	}
+	return value
+}
+
+// OptionalInt reads a whole number argument between min and max, returning def when it is missing
+func (v *Validator) OptionalInt(field string, def, min, max int) int {
+	value, ok := v.number(field)
+	if !ok {
+		return def
+	}
+	if value < min || value > max {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("is %d", value), Expected: fmt.Sprintf("a whole number from %d to %d", min, max)})
+		return def
+	}
+	return value
+}
+
+// OptionalNumber reads a number argument between min and max, returning def when it is missing
+func (v *Validator) OptionalNumber(field string, def, min, max float64) float64 {
+	value, ok := v.float(field)
+	if !ok {
+		return def
+	}
+	if value < min || value > max {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("is %g", value), Expected: fmt.Sprintf("a number from %g to %g", min, max)})
+		return def
+	}
+	return value
+}
+
+// OptionalHours reads a positive number of hours, which may be fractional, returning def when it
+// is missing
+func (v *Validator) OptionalHours(field string, def time.Duration) time.Duration {
+	hours, ok := v.float(field)
+	if !ok {
+		return def
+	}
+	if hours <= 0 {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("is %g", hours), Expected: "a positive number of hours"})
+		return def
+	}
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// OptionalTime reads an RFC 3339 time such as 2025-01-01T12:00:00Z, returning the zero time when
+// it is missing or empty
+func (v *Validator) OptionalTime(field string) time.Time {
+	value, ok := v.str(field)
+	if !ok {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("'%s' is not a time", value), Expected: "an RFC 3339 time such as 2025-01-01T12:00:00Z"})
+		return time.Time{}
+	}
+	return parsed
+}
+
+// number reads a whole number argument
+func (v *Validator) number(field string) (int, bool) {
+	n, ok := v.float(field)
+	if !ok {
+		return 0, false
+	}
+	if n != math.Trunc(n) {
+		v.fail(FieldError{Field: field, Problem: fmt.Sprintf("is %g", n), Expected: "a whole number"})
+		return 0, false
+	}
+	return int(n), true
+}
+
+// float reads a number argument. JSON numbers arrive as float64, and some clients send numbers
+// as strings, which are accepted when they parse.
+func (v *Validator) float(field string) (float64, bool) {
+	raw, exists := v.args[field]
+	if !exists || raw == nil {
+		return 0, false
+	}
+	switch n := raw.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case string:
+		if parsed, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil {
+			return parsed, true
+		}
+	}
+	v.fail(FieldError{Field: field, Problem: fmt.Sprintf("must be a number, got %T", raw)})
+	return 0, false
+}
+
+// lastFailed reports whether the latest error is about field, so a wrongly typed argument isn't
+// also reported as missing
+func (v *Validator) lastFailed(field string) bool {
+	return len(v.errors) > 0 && v.errors[len(v.errors)-1].Field == field
+}
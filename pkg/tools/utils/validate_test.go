@@ -0,0 +1,271 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestValidator_Valid(t *testing.T) {
+	v := NewValidator(map[string]interface{}{
+		"ship_symbol":     " agent-1 ",
+		"waypoint_symbol": "x1-df55-a1",
+		"system_symbol":   "X1-DF55",
+		"flight_mode":     "burn",
+		"good":            "iron ore",
+		"units":           float64(10),
+		"priority":        "Fuel",
+		"apply":           true,
+	})
+
+	if got := v.RequireShip("ship_symbol"); got != "AGENT-1" {
+		t.Errorf("Expected AGENT-1, got %q", got)
+	}
+	if got := v.RequireWaypoint("waypoint_symbol"); got != "X1-DF55-A1" {
+		t.Errorf("Expected X1-DF55-A1, got %q", got)
+	}
+	if got := v.RequireSystem("system_symbol"); got != "X1-DF55" {
+		t.Errorf("Expected X1-DF55, got %q", got)
+	}
+	if got := v.OptionalSymbol("flight_mode", FlightModes, "CRUISE"); got != "BURN" {
+		t.Errorf("Expected BURN, got %q", got)
+	}
+	if got := v.RequireSymbol("good", TradeSymbols); got != "IRON_ORE" {
+		t.Errorf("Expected IRON_ORE, got %q", got)
+	}
+	if got := v.RequireInt("units", 1); got != 10 {
+		t.Errorf("Expected 10, got %d", got)
+	}
+	if got := v.OptionalInt("limit", 5, 1, 20); got != 5 {
+		t.Errorf("Expected the default 5, got %d", got)
+	}
+	if got := v.OptionalChoice("priority", "time", "time", "fuel"); got != "fuel" {
+		t.Errorf("Expected fuel, got %q", got)
+	}
+	if !v.OptionalBool("apply", false) || v.OptionalBool("dry_run", false) {
+		t.Error("Expected apply set and dry_run defaulted")
+	}
+	if got := v.OptionalWaypoint("destination"); got != "" {
+		t.Errorf("Expected no destination, got %q", got)
+	}
+	if result := v.Result(); result != nil {
+		t.Errorf("Expected no errors, got %v", v.Errors())
+	}
+}
+
+func TestValidator_CollectsFieldErrors(t *testing.T) {
+	v := NewValidator(map[string]interface{}{
+		"waypoint_symbol": "X1-DF55",
+		"system_symbol":   "X1-DF55-A1",
+		"flight_mode":     "WARP",
+		"good":            "IRON_BAR",
+		"units":           float64(2.5),
+		"limit":           float64(50),
+		"note":            float64(3),
+	})
+
+	v.RequireShip("ship_symbol")
+	v.RequireWaypoint("waypoint_symbol")
+	v.RequireSystem("system_symbol")
+	v.OptionalSymbol("flight_mode", FlightModes, "CRUISE")
+	v.RequireSymbol("good", TradeSymbols)
+	v.RequireInt("units", 1)
+	v.OptionalInt("limit", 5, 1, 20)
+	v.RequireString("note")
+
+	errors := v.Errors()
+	fields := make([]string, len(errors))
+	for i, e := range errors {
+		fields[i] = e.Field
+	}
+	if got := strings.Join(fields, ","); got != "ship_symbol,waypoint_symbol,system_symbol,flight_mode,good,units,limit,note" {
+		t.Fatalf("Expected one error per bad field, got %s: %v", got, errors)
+	}
+
+	if errors[0].Problem != "is required" || !strings.Contains(errors[0].Expected, "AGENT-1") {
+		t.Errorf("Expected the missing ship to be required, got %+v", errors[0])
+	}
+	if !strings.Contains(errors[1].Problem, "is a system") {
+		t.Errorf("Expected a hint that X1-DF55 is a system, got %+v", errors[1])
+	}
+	if !strings.Contains(errors[2].Problem, "its system is X1-DF55") {
+		t.Errorf("Expected a hint naming the waypoint's system, got %+v", errors[2])
+	}
+	if len(errors[3].Allowed) != 4 {
+		t.Errorf("Expected the four flight modes, got %+v", errors[3])
+	}
+	if !strings.Contains(errors[4].Expected, "spacetraders://catalog/trade-goods") {
+		t.Errorf("Expected the trade goods catalog named, got %+v", errors[4])
+	}
+	if len(errors[4].Suggestions) == 0 || !strings.HasPrefix(errors[4].Suggestions[0], "IRON") {
+		t.Errorf("Expected IRON goods suggested, got %+v", errors[4])
+	}
+	if !strings.Contains(errors[7].Problem, "must be a string") {
+		t.Errorf("Expected a type error for note, not a missing one, got %+v", errors[7])
+	}
+
+	result := v.Result()
+	if result == nil || !result.IsError {
+		t.Fatal("Expected an error result")
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"8 invalid arguments", "- ship_symbol: is required", "- flight_mode: unknown flight mode 'WARP'", "Did you mean: IRON"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in %s", want, text)
+		}
+	}
+	if structured, ok := result.StructuredContent.(map[string]interface{}); !ok || len(structured["errors"].([]FieldError)) != 8 {
+		t.Errorf("Expected the errors as structured content, got %v", result.StructuredContent)
+	}
+}
+
+func TestValidator_ListsNumbersAndChoices(t *testing.T) {
+	v := NewValidator(map[string]interface{}{
+		"keep":         []interface{}{"fuel", "", "iron ore"},
+		"waypoints":    []interface{}{" x1-df55-a1 ", "X1-DF55-B2"},
+		"mode":         "SELL",
+		"min":          "72.5",
+		"window_hours": float64(1.5),
+		"units":        "12",
+		"system":       "x1-df55",
+		"ship":         "agent-3",
+	})
+
+	if got := strings.Join(v.OptionalSymbols("keep", TradeSymbols), ","); got != "FUEL,IRON_ORE" {
+		t.Errorf("Expected FUEL,IRON_ORE, got %q", got)
+	}
+	if got := strings.Join(v.OptionalWaypoints("waypoints"), ","); got != "X1-DF55-A1,X1-DF55-B2" {
+		t.Errorf("Expected both waypoints, got %q", got)
+	}
+	if got := v.RequireChoice("mode", "buy", "sell"); got != "sell" {
+		t.Errorf("Expected sell, got %q", got)
+	}
+	if got := v.OptionalNumber("min", 50, 0, 100); got != 72.5 {
+		t.Errorf("Expected 72.5, got %v", got)
+	}
+	if got := v.OptionalHours("window_hours", time.Hour); got != 90*time.Minute {
+		t.Errorf("Expected 90m, got %v", got)
+	}
+	if got := v.OptionalHours("missing_hours", time.Hour); got != time.Hour {
+		t.Errorf("Expected the default hour, got %v", got)
+	}
+	if got := v.RequireInt("units", 1); got != 12 {
+		t.Errorf("Expected 12 from a numeric string, got %d", got)
+	}
+	if got := v.OptionalSystem("system"); got != "X1-DF55" {
+		t.Errorf("Expected X1-DF55, got %q", got)
+	}
+	if got := v.OptionalShip("ship"); got != "AGENT-3" {
+		t.Errorf("Expected AGENT-3, got %q", got)
+	}
+	if got := v.OptionalShip("other_ship"); got != "" {
+		t.Errorf("Expected no ship, got %q", got)
+	}
+	if errors := v.Errors(); len(errors) != 0 {
+		t.Fatalf("Expected no errors, got %v", errors)
+	}
+}
+
+func TestValidator_ListAndRangeErrors(t *testing.T) {
+	v := NewValidator(map[string]interface{}{
+		"keep":         []interface{}{"FUEL", "IRON_BAR", float64(3)},
+		"waypoints":    "X1-DF55-A1",
+		"min":          float64(120),
+		"window_hours": float64(-2),
+		"units":        "lots",
+		"system":       "X1-DF55-A1",
+		"since":        "yesterday",
+		"ships":        []interface{}{"AGENT-1", "agent 2"},
+	})
+
+	v.OptionalSymbols("keep", TradeSymbols)
+	v.OptionalWaypoints("waypoints")
+	v.RequireWaypoints("route")
+	v.OptionalShips("ships")
+	v.RequireChoice("mode", "buy", "sell")
+	v.OptionalNumber("min", 50, 0, 100)
+	v.OptionalHours("window_hours", time.Hour)
+	v.OptionalTime("since")
+	v.RequireInt("units", 1)
+	v.OptionalSystem("system")
+	v.Fail("sell_waypoint", "is the same market as buy_waypoint", "a different market")
+
+	errors := v.Errors()
+	fields := make([]string, len(errors))
+	for i, e := range errors {
+		fields[i] = e.Field
+	}
+	if got := strings.Join(fields, ","); got != "keep[1],keep[2],waypoints,route,ships[1],mode,min,window_hours,since,units,system,sell_waypoint" {
+		t.Fatalf("Expected one error per bad field or entry, got %s: %v", got, errors)
+	}
+	if len(errors[5].Allowed) != 2 {
+		t.Errorf("Expected the choices listed for a missing mode, got %+v", errors[5])
+	}
+	if !strings.Contains(errors[10].Problem, "its system is X1-DF55") {
+		t.Errorf("Expected a hint naming the waypoint's system, got %+v", errors[10])
+	}
+	if got := errors[11].String(); got != "sell_waypoint: is the same market as buy_waypoint; expected a different market" {
+		t.Errorf("Unexpected message for a custom failure: %q", got)
+	}
+}
+
+func TestValidator_OptionalObject(t *testing.T) {
+	type survey struct {
+		Signature string `json:"signature"`
+		Deposits  []struct {
+			Symbol string `json:"symbol"`
+		} `json:"deposits"`
+	}
+	v := NewValidator(map[string]interface{}{
+		"survey":  map[string]interface{}{"signature": "X1-DF55-A1-ABC", "deposits": []interface{}{map[string]interface{}{"symbol": "IRON_ORE"}}},
+		"bad":     "X1-DF55-A1-ABC",
+		"wrong":   map[string]interface{}{"deposits": "IRON_ORE"},
+		"ignored": nil,
+	})
+
+	var got survey
+	if !v.OptionalObject("survey", &got) || got.Signature != "X1-DF55-A1-ABC" || len(got.Deposits) != 1 || got.Deposits[0].Symbol != "IRON_ORE" {
+		t.Errorf("Expected the survey decoded, got %+v", got)
+	}
+	if v.OptionalObject("ignored", &survey{}) || v.OptionalObject("missing", &survey{}) {
+		t.Error("Expected missing objects to be reported as not given")
+	}
+	v.OptionalObject("bad", &survey{})
+	v.OptionalObject("wrong", &survey{})
+
+	errors := v.Errors()
+	if len(errors) != 2 || errors[0].Field != "bad" || errors[1].Field != "wrong" {
+		t.Fatalf("Expected errors for bad and wrong, got %v", errors)
+	}
+	if !strings.Contains(errors[0].Problem, "must be an object") {
+		t.Errorf("Expected a type error, got %+v", errors[0])
+	}
+}
+
+func TestValidator_ClearableAndListArguments(t *testing.T) {
+	v := NewValidator(map[string]interface{}{
+		"label": "",
+		"notes": float64(3),
+		"tags":  []interface{}{" mining ", "", "x1-fm66"},
+		"ships": []interface{}{""},
+	})
+
+	if label, given := v.ClearableString("label"); !given || label != "" {
+		t.Errorf("Expected an empty label to count as given, got %q, %v", label, given)
+	}
+	if _, given := v.ClearableString("missing"); given {
+		t.Error("Expected a missing argument not to count as given")
+	}
+	v.ClearableString("notes")
+	if got := strings.Join(v.OptionalStrings("tags"), ","); got != "mining,x1-fm66" {
+		t.Errorf("Expected the non-empty tags, got %q", got)
+	}
+	v.RequireShips("ships")
+
+	errors := v.Errors()
+	if len(errors) != 2 || errors[0].Field != "notes" || errors[1].Field != "ships" || errors[1].Problem != "is required" {
+		t.Fatalf("Expected errors for notes and the empty ship list, got %v", errors)
+	}
+}
@@ -0,0 +1,71 @@
+// Package trade splits market orders into transactions the API accepts
+package trade
+
+import "spacetraders-mcp/pkg/client"
+
+// Order is the combined result of an order split into tradeVolume-sized transactions,
+// since the API rejects a single purchase or sale larger than a good's trade volume
+type Order struct {
+	Good         string  `json:"good"`
+	Units        int     `json:"units"`
+	TotalPrice   int     `json:"total_price"`
+	AveragePrice float64 `json:"average_price"`
+	// PriceSlippage is how far the price per unit moved between the first and last transaction
+	PriceSlippage int                        `json:"price_slippage"`
+	Transactions  []client.MarketTransaction `json:"transactions"`
+
+	// Credits and Cargo are the agent's balance and the ship's hold after the last transaction
+	Credits int64        `json:"-"`
+	Cargo   client.Cargo `json:"-"`
+}
+
+// NewOrder starts an order for a good with no transactions yet
+func NewOrder(good string) *Order {
+	return &Order{Good: good, Transactions: make([]client.MarketTransaction, 0)}
+}
+
+// Add records one transaction of the order
+func (o *Order) Add(transaction client.MarketTransaction, agent client.Agent, cargo client.Cargo) {
+	o.Transactions = append(o.Transactions, transaction)
+	o.Units += transaction.Units
+	o.TotalPrice += transaction.TotalPrice
+	o.AveragePrice = float64(o.TotalPrice) / float64(o.Units)
+	o.PriceSlippage = transaction.PricePerUnit - o.Transactions[0].PricePerUnit
+	o.Credits = agent.Credits
+	o.Cargo = cargo
+}
+
+// Summary combines the order's transactions into one, priced at the rounded average
+func (o *Order) Summary() client.MarketTransaction {
+	if len(o.Transactions) == 0 {
+		return client.MarketTransaction{TradeSymbol: o.Good}
+	}
+
+	last := o.Transactions[len(o.Transactions)-1]
+	return client.MarketTransaction{
+		WaypointSymbol: last.WaypointSymbol,
+		ShipSymbol:     last.ShipSymbol,
+		TradeSymbol:    last.TradeSymbol,
+		Type:           last.Type,
+		Units:          o.Units,
+		PricePerUnit:   int(o.AveragePrice + 0.5),
+		TotalPrice:     o.TotalPrice,
+		Timestamp:      last.Timestamp,
+	}
+}
+
+// ChunkSizes splits units into tradeVolume-sized chunks. A trade volume of 0 means the
+// limit is unknown, and the whole order is sent at once.
+func ChunkSizes(units, tradeVolume int) []int {
+	if tradeVolume <= 0 || units <= tradeVolume {
+		return []int{units}
+	}
+
+	var sizes []int
+	for units > 0 {
+		size := min(units, tradeVolume)
+		sizes = append(sizes, size)
+		units -= size
+	}
+	return sizes
+}
@@ -0,0 +1,39 @@
+package trade
+
+import (
+	"fmt"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestChunkSizes(t *testing.T) {
+	tests := []struct {
+		units, tradeVolume int
+		want               string
+	}{
+		{25, 10, "[10 10 5]"},
+		{10, 10, "[10]"},
+		{7, 0, "[7]"},
+		{30, 10, "[10 10 10]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(ChunkSizes(tt.units, tt.tradeVolume)); got != tt.want {
+			t.Errorf("ChunkSizes(%d, %d) = %s, want %s", tt.units, tt.tradeVolume, got, tt.want)
+		}
+	}
+}
+
+func TestOrder_Summary(t *testing.T) {
+	order := NewOrder("IRON_ORE")
+	order.Add(client.MarketTransaction{TradeSymbol: "IRON_ORE", Type: "SELL", Units: 10, PricePerUnit: 50, TotalPrice: 500}, client.Agent{Credits: 1500}, client.Cargo{Units: 5})
+	order.Add(client.MarketTransaction{TradeSymbol: "IRON_ORE", Type: "SELL", Units: 5, PricePerUnit: 47, TotalPrice: 235}, client.Agent{Credits: 1735}, client.Cargo{Units: 0})
+
+	summary := order.Summary()
+	if summary.Units != 15 || summary.TotalPrice != 735 || summary.PricePerUnit != 49 {
+		t.Errorf("Expected 15 units for 735 credits at 49 each, got %+v", summary)
+	}
+	if order.PriceSlippage != -3 || order.Credits != 1735 || order.Cargo.Units != 0 {
+		t.Errorf("Expected the slippage and the state after the last transaction, got %+v", order)
+	}
+}
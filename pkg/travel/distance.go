@@ -0,0 +1,49 @@
+package travel
+
+import (
+	"fmt"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+// RouteDistance returns the distance between two waypoints. When they are in different
+// systems the distance between the systems is returned and warp is true.
+func RouteDistance(c *client.Client, origin, destination string) (distance float64, warp bool, err error) {
+	originSystem := SystemSymbol(origin)
+	destinationSystem := SystemSymbol(destination)
+
+	if originSystem != destinationSystem {
+		from, err := c.GetSystem(originSystem)
+		if err != nil {
+			return 0, true, fmt.Errorf("failed to look up system %s: %w", originSystem, err)
+		}
+		to, err := c.GetSystem(destinationSystem)
+		if err != nil {
+			return 0, true, fmt.Errorf("failed to look up system %s: %w", destinationSystem, err)
+		}
+		return Distance(from.X, from.Y, to.X, to.Y), true, nil
+	}
+
+	system, err := c.GetSystem(originSystem)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to look up system %s: %w", originSystem, err)
+	}
+
+	var from, to *client.SystemWaypoint
+	for i := range system.Waypoints {
+		if system.Waypoints[i].Symbol == origin {
+			from = &system.Waypoints[i]
+		}
+		if system.Waypoints[i].Symbol == destination {
+			to = &system.Waypoints[i]
+		}
+	}
+	if from == nil {
+		return 0, false, fmt.Errorf("waypoint %s not found in system %s", origin, originSystem)
+	}
+	if to == nil {
+		return 0, false, fmt.Errorf("waypoint %s not found in system %s", destination, originSystem)
+	}
+
+	return Distance(from.X, from.Y, to.X, to.Y), false, nil
+}
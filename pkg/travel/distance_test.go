@@ -0,0 +1,46 @@
+package travel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spacetraders-mcp/pkg/client"
+)
+
+func TestRouteDistance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systems/X1-TEST":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-TEST", "sectorSymbol": "X1", "type": "RED_STAR", "x": 0, "y": 0, "waypoints": [
+				{"symbol": "X1-TEST-A1", "type": "PLANET", "x": 0, "y": 0},
+				{"symbol": "X1-TEST-B2", "type": "MOON", "x": 30, "y": 40}
+			], "factions": []}}`))
+		case "/systems/X1-FAR":
+			_, _ = w.Write([]byte(`{"data": {"symbol": "X1-FAR", "sectorSymbol": "X1", "type": "RED_STAR", "x": 600, "y": 800, "waypoints": [], "factions": []}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := client.NewClientWithBaseURL("test-token", server.URL)
+
+	distance, warp, err := RouteDistance(c, "X1-TEST-A1", "X1-TEST-B2")
+	if err != nil || warp || distance != 50 {
+		t.Errorf("Expected 50 units within the system, got %v (warp %v, err %v)", distance, warp, err)
+	}
+
+	distance, warp, err = RouteDistance(c, "X1-TEST-A1", "X1-FAR-C3")
+	if err != nil || !warp || distance != 1000 {
+		t.Errorf("Expected a 1000 unit warp between systems, got %v (warp %v, err %v)", distance, warp, err)
+	}
+
+	if _, _, err = RouteDistance(c, "X1-TEST-A1", "X1-TEST-Z9"); err == nil || !strings.Contains(err.Error(), "X1-TEST-Z9 not found") {
+		t.Errorf("Expected a missing waypoint to be reported, got %v", err)
+	}
+	if _, _, err = RouteDistance(c, "X1-NONE-A1", "X1-NONE-B2"); err == nil {
+		t.Error("Expected an unknown system to be reported")
+	}
+}
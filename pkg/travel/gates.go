@@ -0,0 +1,150 @@
+package travel
+
+import "sort"
+
+// DefaultGateCrawlLimit is how many jump gates a crawl fetches when the caller does not choose a limit
+const DefaultGateCrawlLimit = 50
+
+// GateGraph is the jump gate network: the gates whose connections are known, and the gates
+// that have been seen but not explored
+type GateGraph struct {
+	Connections map[string][]string `json:"connections"`
+	Unexplored  []string            `json:"unexplored"`
+}
+
+// NewGateGraph returns an empty gate graph
+func NewGateGraph() *GateGraph {
+	return &GateGraph{Connections: make(map[string][]string), Unexplored: []string{}}
+}
+
+// AddGate records the gates a gate connects to
+func (g *GateGraph) AddGate(gate string, connections []string) {
+	sorted := append([]string(nil), connections...)
+	sort.Strings(sorted)
+	g.Connections[gate] = sorted
+}
+
+// Systems returns every system with a known gate, sorted
+func (g *GateGraph) Systems() []string {
+	seen := make(map[string]bool)
+	for gate, connections := range g.Connections {
+		seen[SystemSymbol(gate)] = true
+		for _, connection := range connections {
+			seen[SystemSymbol(connection)] = true
+		}
+	}
+
+	systems := make([]string, 0, len(seen))
+	for system := range seen {
+		systems = append(systems, system)
+	}
+	sort.Strings(systems)
+	return systems
+}
+
+// adjacency returns each gate's neighbours. Gate connections are two-way, so a gate is linked
+// to every explored gate that lists it even when its own connections are unknown.
+func (g *GateGraph) adjacency() map[string][]string {
+	links := make(map[string]map[string]bool)
+	link := func(a, b string) {
+		if links[a] == nil {
+			links[a] = make(map[string]bool)
+		}
+		links[a][b] = true
+	}
+	for gate, connections := range g.Connections {
+		for _, connection := range connections {
+			link(gate, connection)
+			link(connection, gate)
+		}
+	}
+
+	adjacency := make(map[string][]string, len(links))
+	for gate, neighbours := range links {
+		for neighbour := range neighbours {
+			adjacency[gate] = append(adjacency[gate], neighbour)
+		}
+		sort.Strings(adjacency[gate])
+	}
+	return adjacency
+}
+
+// Path returns the shortest sequence of gates from a gate in fromSystem to a gate in toSystem,
+// counted in jumps. The second return value is false when no path is known.
+func (g *GateGraph) Path(fromSystem, toSystem string) ([]string, bool) {
+	adjacency := g.adjacency()
+
+	var queue []string
+	previous := make(map[string]string)
+	for gate := range adjacency {
+		if SystemSymbol(gate) == fromSystem {
+			queue = append(queue, gate)
+			previous[gate] = ""
+		}
+	}
+	sort.Strings(queue)
+
+	for len(queue) > 0 {
+		gate := queue[0]
+		queue = queue[1:]
+
+		if SystemSymbol(gate) == toSystem {
+			var path []string
+			for at := gate; at != ""; at = previous[at] {
+				path = append([]string{at}, path...)
+			}
+			return path, true
+		}
+
+		for _, neighbour := range adjacency[gate] {
+			if _, seen := previous[neighbour]; !seen {
+				previous[neighbour] = gate
+				queue = append(queue, neighbour)
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// CrawlGates explores the gate network breadth-first from the seed gates, fetching at most limit
+// gates. fetch returns a gate's connections; gates it fails on, such as uncharted gates, and gates
+// left over when the crawl stops are listed as unexplored. When done is set the crawl also stops
+// as soon as done reports true for the graph so far.
+func CrawlGates(seeds []string, limit int, fetch func(gate string) ([]string, error), done func(*GateGraph) bool) *GateGraph {
+	graph := NewGateGraph()
+	queued := make(map[string]bool)
+	var queue []string
+	enqueue := func(gate string) {
+		if !queued[gate] {
+			queued[gate] = true
+			queue = append(queue, gate)
+		}
+	}
+	for _, seed := range seeds {
+		enqueue(seed)
+	}
+
+	for fetched := 0; len(queue) > 0 && fetched < limit; fetched++ {
+		gate := queue[0]
+		queue = queue[1:]
+
+		connections, err := fetch(gate)
+		if err != nil {
+			graph.Unexplored = append(graph.Unexplored, gate)
+			continue
+		}
+		graph.AddGate(gate, connections)
+		for _, connection := range connections {
+			enqueue(connection)
+		}
+
+		if done != nil && done(graph) {
+			break
+		}
+	}
+
+	graph.Unexplored = append(graph.Unexplored, queue...)
+	sort.Strings(graph.Unexplored)
+	return graph
+}
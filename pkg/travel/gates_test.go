@@ -0,0 +1,77 @@
+package travel
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testGates is a small gate network: A - B - C - D, with a shortcut A - C and an uncharted gate E off D
+var testGates = map[string][]string{
+	"X1-A-GATE": {"X1-B-GATE", "X1-C-GATE"},
+	"X1-B-GATE": {"X1-A-GATE", "X1-C-GATE"},
+	"X1-C-GATE": {"X1-A-GATE", "X1-B-GATE", "X1-D-GATE"},
+	"X1-D-GATE": {"X1-C-GATE", "X1-E-GATE"},
+}
+
+func fetchTestGate(fetched *[]string) func(string) ([]string, error) {
+	return func(gate string) ([]string, error) {
+		*fetched = append(*fetched, gate)
+		connections, ok := testGates[gate]
+		if !ok {
+			return nil, errors.New("gate not charted")
+		}
+		return connections, nil
+	}
+}
+
+func TestCrawlGates(t *testing.T) {
+	var fetched []string
+	graph := CrawlGates([]string{"X1-A-GATE"}, DefaultGateCrawlLimit, fetchTestGate(&fetched), nil)
+
+	if len(graph.Connections) != 4 {
+		t.Errorf("Expected 4 explored gates, got %d", len(graph.Connections))
+	}
+	if !reflect.DeepEqual(graph.Unexplored, []string{"X1-E-GATE"}) {
+		t.Errorf("Expected the uncharted gate to be unexplored, got %v", graph.Unexplored)
+	}
+	if want := []string{"X1-A", "X1-B", "X1-C", "X1-D", "X1-E"}; !reflect.DeepEqual(graph.Systems(), want) {
+		t.Errorf("Expected systems %v, got %v", want, graph.Systems())
+	}
+
+	path, ok := graph.Path("X1-A", "X1-D")
+	if !ok {
+		t.Fatal("Expected a path from X1-A to X1-D")
+	}
+	if want := []string{"X1-A-GATE", "X1-C-GATE", "X1-D-GATE"}; !reflect.DeepEqual(path, want) {
+		t.Errorf("Expected shortest path %v, got %v", want, path)
+	}
+
+	// The uncharted gate is still reachable through the gate that lists it
+	if path, ok := graph.Path("X1-E", "X1-B"); !ok || len(path) != 4 {
+		t.Errorf("Expected a 3-jump path from X1-E to X1-B, got %v", path)
+	}
+	if _, ok := graph.Path("X1-A", "X1-Z"); ok {
+		t.Error("Expected no path to an unknown system")
+	}
+}
+
+func TestCrawlGates_StopsAtLimitAndDone(t *testing.T) {
+	var fetched []string
+	graph := CrawlGates([]string{"X1-A-GATE"}, 1, fetchTestGate(&fetched), nil)
+	if len(fetched) != 1 {
+		t.Errorf("Expected one fetch with limit 1, got %v", fetched)
+	}
+	if want := []string{"X1-B-GATE", "X1-C-GATE"}; !reflect.DeepEqual(graph.Unexplored, want) {
+		t.Errorf("Expected queued gates to be unexplored, got %v", graph.Unexplored)
+	}
+
+	fetched = nil
+	CrawlGates([]string{"X1-A-GATE"}, DefaultGateCrawlLimit, fetchTestGate(&fetched), func(g *GateGraph) bool {
+		_, ok := g.Path("X1-A", "X1-C")
+		return ok
+	})
+	if len(fetched) != 1 {
+		t.Errorf("Expected the crawl to stop once X1-C was reachable, fetched %v", fetched)
+	}
+}
@@ -0,0 +1,143 @@
+package travel
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNoRoute is returned when no sequence of legs reaches the destination within the ship's fuel and range
+var ErrNoRoute = errors.New("no route within fuel and range limits")
+
+// FuelPerMarketUnit is how much fuel one unit bought at a market puts in the tank
+const FuelPerMarketUnit = 100
+
+// Stop is a place a route can start, end or pass through. X and Y are in whatever frame the
+// route is planned in: waypoint coordinates within a system, or system coordinates for warps.
+type Stop struct {
+	Symbol string `json:"symbol"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	// Fuel is true when fuel can be bought at the stop
+	Fuel bool `json:"fuel"`
+	// FuelPrice is what a unit of fuel costs at the stop; 0 when unknown
+	FuelPrice int `json:"fuel_price,omitempty"`
+}
+
+// Leg is one hop of a planned route
+type Leg struct {
+	From          string  `json:"from"`
+	To            string  `json:"to"`
+	Distance      float64 `json:"distance"`
+	FuelCost      int     `json:"fuel_cost"`
+	TravelSeconds int     `json:"travel_seconds"`
+	// Refuel is true when the ship should refuel at From before departing
+	Refuel bool `json:"refuel"`
+	// FuelCredits is what refuelling for the leg costs at From's fuel price, when known
+	FuelCredits int `json:"fuel_credits,omitempty"`
+}
+
+// RouteLimits describes what a ship can do on one leg
+type RouteLimits struct {
+	Fuel         int     // fuel in the tank at the origin
+	FuelCapacity int     // tank size; 0 for ships that do not use fuel
+	MaxLeg       float64 // longest single leg, e.g. a warp drive's range; 0 for no limit
+	FlightMode   string
+	EngineSpeed  int
+	// SecondsPerCredit is how much longer a route may take to save one credit on fuel; 0 plans the
+	// fastest route whatever the fuel costs
+	SecondsPerCredit float64
+}
+
+// PlanRoute returns the fastest route from origin to destination, refuelling at stops that sell
+// fuel whenever the next leg costs more than the tank holds. Stops that do not sell fuel are never
+// used, since passing through them gains nothing. With SecondsPerCredit set, refuelling is counted
+// as extra time by its price, so a slightly slower route through cheaper fuel can win.
+func PlanRoute(origin, destination Stop, stops []Stop, limits RouteLimits) ([]Leg, error) {
+	nodes := []Stop{origin, destination}
+	for _, stop := range stops {
+		if stop.Fuel && stop.Symbol != origin.Symbol && stop.Symbol != destination.Symbol {
+			nodes = append(nodes, stop)
+		}
+	}
+
+	// fuelAt is the fuel available when departing a node: a full tank wherever fuel is sold
+	fuelAt := func(i int) int {
+		if nodes[i].Fuel {
+			return limits.FuelCapacity
+		}
+		return limits.Fuel
+	}
+	leg := func(from, to int) (Leg, bool) {
+		distance := Distance(nodes[from].X, nodes[from].Y, nodes[to].X, nodes[to].Y)
+		if limits.MaxLeg > 0 && distance > limits.MaxLeg {
+			return Leg{}, false
+		}
+		fuelCost := 0
+		if limits.FuelCapacity > 0 {
+			fuelCost = FuelCost(distance, limits.FlightMode)
+			if fuelCost > fuelAt(from) {
+				return Leg{}, false
+			}
+		}
+		l := Leg{
+			From:          nodes[from].Symbol,
+			To:            nodes[to].Symbol,
+			Distance:      distance,
+			FuelCost:      fuelCost,
+			TravelSeconds: int(TravelTime(distance, limits.FlightMode, limits.EngineSpeed).Seconds()),
+			Refuel:        nodes[from].Fuel && fuelCost > 0 && (from != 0 || fuelCost > limits.Fuel),
+		}
+		if l.Refuel && nodes[from].FuelPrice > 0 {
+			l.FuelCredits = (fuelCost*nodes[from].FuelPrice + FuelPerMarketUnit - 1) / FuelPerMarketUnit
+		}
+		return l, true
+	}
+	weight := func(l Leg) float64 {
+		return float64(l.TravelSeconds) + limits.SecondsPerCredit*float64(l.FuelCredits)
+	}
+
+	// Dijkstra over the nodes by total weight; there are few enough that a linear scan for the
+	// next node is fine
+	costs := make([]float64, len(nodes))
+	previous := make([]int, len(nodes))
+	done := make([]bool, len(nodes))
+	for i := range costs {
+		costs[i] = math.Inf(1)
+		previous[i] = -1
+	}
+	costs[0] = 0
+
+	for {
+		current := -1
+		for i := range nodes {
+			if !done[i] && !math.IsInf(costs[i], 1) && (current == -1 || costs[i] < costs[current]) {
+				current = i
+			}
+		}
+		if current == -1 || current == 1 {
+			break
+		}
+		done[current] = true
+
+		for next := range nodes {
+			if done[next] || next == current {
+				continue
+			}
+			if l, ok := leg(current, next); ok && costs[current]+weight(l) < costs[next] {
+				costs[next] = costs[current] + weight(l)
+				previous[next] = current
+			}
+		}
+	}
+
+	if previous[1] == -1 {
+		return nil, ErrNoRoute
+	}
+
+	var legs []Leg
+	for at := 1; previous[at] != -1; at = previous[at] {
+		l, _ := leg(previous[at], at)
+		legs = append([]Leg{l}, legs...)
+	}
+	return legs, nil
+}
@@ -0,0 +1,95 @@
+package travel
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlanRoute_Direct(t *testing.T) {
+	legs, err := PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 30, Y: 40}, nil, RouteLimits{Fuel: 100, FuelCapacity: 100, FlightMode: "CRUISE", EngineSpeed: 30})
+	if err != nil {
+		t.Fatalf("Expected a route, got %v", err)
+	}
+	if len(legs) != 1 || legs[0].Distance != 50 || legs[0].FuelCost != 50 || legs[0].Refuel {
+		t.Errorf("Expected one 50 unit leg without refuelling, got %+v", legs)
+	}
+}
+
+func TestPlanRoute_RefuelStop(t *testing.T) {
+	stops := []Stop{
+		{Symbol: "MID", X: 60, Y: 0, Fuel: true},
+		{Symbol: "DRY", X: 40, Y: 0},
+	}
+	limits := RouteLimits{Fuel: 70, FuelCapacity: 80, FlightMode: "CRUISE", EngineSpeed: 30}
+
+	legs, err := PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 120, Y: 0}, stops, limits)
+	if err != nil {
+		t.Fatalf("Expected a route, got %v", err)
+	}
+	if len(legs) != 2 || legs[0].To != "MID" || legs[1].From != "MID" {
+		t.Fatalf("Expected a route through MID, got %+v", legs)
+	}
+	if legs[0].Refuel || !legs[1].Refuel {
+		t.Errorf("Expected to refuel at MID only, got %+v", legs)
+	}
+
+	// Without the fuel stop the tank cannot cover the trip
+	if _, err := PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 120, Y: 0}, stops[1:], limits); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Expected ErrNoRoute, got %v", err)
+	}
+}
+
+func TestPlanRoute_OriginRefuel(t *testing.T) {
+	limits := RouteLimits{Fuel: 10, FuelCapacity: 100, FlightMode: "CRUISE", EngineSpeed: 30}
+
+	legs, err := PlanRoute(Stop{Symbol: "A", Fuel: true}, Stop{Symbol: "B", X: 50, Y: 0}, nil, limits)
+	if err != nil {
+		t.Fatalf("Expected a route, got %v", err)
+	}
+	if len(legs) != 1 || !legs[0].Refuel {
+		t.Errorf("Expected to refuel at the origin, got %+v", legs)
+	}
+}
+
+func TestPlanRoute_MaxLeg(t *testing.T) {
+	stops := []Stop{{Symbol: "MID", X: 500, Y: 0, Fuel: true}}
+	limits := RouteLimits{MaxLeg: 600, FlightMode: "CRUISE", EngineSpeed: 30}
+
+	legs, err := PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 1000, Y: 0}, stops, limits)
+	if err != nil {
+		t.Fatalf("Expected a route, got %v", err)
+	}
+	if len(legs) != 2 || legs[0].FuelCost != 0 {
+		t.Errorf("Expected two fuel-free legs within range, got %+v", legs)
+	}
+
+	if _, err := PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 1000, Y: 0}, nil, limits); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Expected ErrNoRoute beyond range, got %v", err)
+	}
+}
+
+func TestPlanRoute_PrefersCheapFuel(t *testing.T) {
+	stops := []Stop{
+		{Symbol: "DEAR", X: 60, Y: 0, Fuel: true, FuelPrice: 200},
+		{Symbol: "CHEAP", X: 60, Y: 10, Fuel: true, FuelPrice: 50},
+	}
+	limits := RouteLimits{Fuel: 70, FuelCapacity: 80, FlightMode: "CRUISE", EngineSpeed: 30}
+
+	// The straight line through DEAR is a little faster
+	legs, err := PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 120, Y: 0}, stops, limits)
+	if err != nil {
+		t.Fatalf("Expected a route, got %v", err)
+	}
+	if legs[0].To != "DEAR" || legs[1].FuelCredits != 120 {
+		t.Errorf("Expected the fastest route through DEAR costing 120 credits of fuel, got %+v", legs)
+	}
+
+	limits.SecondsPerCredit = 1
+	legs, err = PlanRoute(Stop{Symbol: "A"}, Stop{Symbol: "B", X: 120, Y: 0}, stops, limits)
+	if err != nil {
+		t.Fatalf("Expected a route, got %v", err)
+	}
+	if legs[0].To != "CHEAP" || !legs[1].Refuel || legs[1].FuelCredits != 31 {
+		t.Errorf("Expected to refuel at CHEAP for 31 credits, got %+v", legs)
+	}
+}
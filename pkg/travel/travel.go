@@ -0,0 +1,91 @@
+package travel
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// FlightModes lists the flight modes in order from slowest to fastest
+var FlightModes = []string{"DRIFT", "STEALTH", "CRUISE", "BURN"}
+
+// Distance returns the euclidean distance between two points
+func Distance(x1, y1, x2, y2 int) float64 {
+	dx := float64(x2 - x1)
+	dy := float64(y2 - y1)
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// FuelCost returns the fuel a trip of the given distance consumes in the given flight mode
+func FuelCost(distance float64, flightMode string) int {
+	rounded := int(math.Round(distance))
+	switch flightMode {
+	case "DRIFT":
+		return 1
+	case "BURN":
+		return max(2, 2*rounded)
+	default: // CRUISE and STEALTH
+		return max(1, rounded)
+	}
+}
+
+// speedMultiplier returns the flight mode factor applied to travel time
+func speedMultiplier(flightMode string) float64 {
+	switch flightMode {
+	case "DRIFT":
+		return 250
+	case "STEALTH":
+		return 30
+	case "BURN":
+		return 12.5
+	default: // CRUISE
+		return 25
+	}
+}
+
+// TravelTime returns how long a trip of the given distance takes for an engine speed and flight mode
+func TravelTime(distance float64, flightMode string, engineSpeed int) time.Duration {
+	if engineSpeed <= 0 {
+		engineSpeed = 1
+	}
+	rounded := math.Max(1, math.Round(distance))
+	seconds := math.Round(rounded*(speedMultiplier(flightMode)/float64(engineSpeed)) + 15)
+	return time.Duration(seconds) * time.Second
+}
+
+// SystemSymbol extracts the system symbol from a waypoint symbol (X1-FM66-A1 -> X1-FM66)
+func SystemSymbol(waypointSymbol string) string {
+	if i := strings.LastIndex(waypointSymbol, "-"); i > 0 {
+		return waypointSymbol[:i]
+	}
+	return waypointSymbol
+}
+
+// Estimate is the predicted fuel and time cost of a trip in one flight mode
+type Estimate struct {
+	FlightMode    string `json:"flight_mode"`
+	FuelCost      int    `json:"fuel_cost"`
+	TravelSeconds int    `json:"travel_seconds"`
+	TravelTime    string `json:"travel_time"`
+}
+
+// Estimates returns the fuel and time cost of a trip for each flight mode
+func Estimates(distance float64, flightModes []string, engineSpeed int) []Estimate {
+	estimates := make([]Estimate, 0, len(flightModes))
+	for _, mode := range flightModes {
+		duration := TravelTime(distance, mode, engineSpeed)
+		estimates = append(estimates, Estimate{
+			FlightMode:    mode,
+			FuelCost:      FuelCost(distance, mode),
+			TravelSeconds: int(duration.Seconds()),
+			TravelTime:    duration.String(),
+		})
+	}
+	return estimates
+}
+
+// RefuelCost returns what buying fuel units of ship fuel costs at a market charging
+// pricePerUnit for each unit of FUEL, which markets only sell whole
+func RefuelCost(fuel, pricePerUnit int) int {
+	return (fuel + FuelPerMarketUnit - 1) / FuelPerMarketUnit * pricePerUnit
+}
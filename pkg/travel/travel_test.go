@@ -0,0 +1,93 @@
+package travel
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	if got := Distance(0, 0, 3, 4); got != 5 {
+		t.Errorf("Expected distance 5, got %f", got)
+	}
+	if got := Distance(-10, 5, -10, 5); got != 0 {
+		t.Errorf("Expected distance 0, got %f", got)
+	}
+}
+
+func TestFuelCost(t *testing.T) {
+	tests := []struct {
+		distance float64
+		mode     string
+		expected int
+	}{
+		{42.4, "CRUISE", 42},
+		{42.6, "STEALTH", 43},
+		{42.4, "BURN", 84},
+		{42.4, "DRIFT", 1},
+		{0, "CRUISE", 1},
+		{0, "BURN", 2},
+	}
+
+	for _, tt := range tests {
+		if got := FuelCost(tt.distance, tt.mode); got != tt.expected {
+			t.Errorf("FuelCost(%v, %s) = %d, expected %d", tt.distance, tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestTravelTime(t *testing.T) {
+	tests := []struct {
+		distance float64
+		mode     string
+		speed    int
+		expected time.Duration
+	}{
+		{100, "CRUISE", 30, 98 * time.Second},   // 100 * 25/30 + 15 = 98.3
+		{100, "BURN", 30, 57 * time.Second},     // 100 * 12.5/30 + 15 = 56.7
+		{100, "DRIFT", 30, 848 * time.Second},   // 100 * 250/30 + 15 = 848.3
+		{100, "STEALTH", 30, 115 * time.Second}, // 100 * 30/30 + 15
+		{0, "CRUISE", 10, 18 * time.Second},     // minimum distance of 1
+	}
+
+	for _, tt := range tests {
+		if got := TravelTime(tt.distance, tt.mode, tt.speed); got != tt.expected {
+			t.Errorf("TravelTime(%v, %s, %d) = %v, expected %v", tt.distance, tt.mode, tt.speed, got, tt.expected)
+		}
+	}
+}
+
+func TestSystemSymbol(t *testing.T) {
+	if got := SystemSymbol("X1-FM66-A1"); got != "X1-FM66" {
+		t.Errorf("Expected X1-FM66, got %s", got)
+	}
+}
+
+func TestEstimates(t *testing.T) {
+	estimates := Estimates(100, FlightModes, 30)
+	if len(estimates) != len(FlightModes) {
+		t.Fatalf("Expected %d estimates, got %d", len(FlightModes), len(estimates))
+	}
+
+	for i, estimate := range estimates {
+		if estimate.FlightMode != FlightModes[i] {
+			t.Errorf("Expected flight mode %s at index %d, got %s", FlightModes[i], i, estimate.FlightMode)
+		}
+		if i > 0 && estimate.TravelSeconds >= estimates[i-1].TravelSeconds {
+			t.Errorf("Expected %s to be faster than %s", estimate.FlightMode, estimates[i-1].FlightMode)
+		}
+	}
+
+	cruise := estimates[2]
+	if cruise.FuelCost != 100 || cruise.TravelSeconds != 98 || cruise.TravelTime != "1m38s" {
+		t.Errorf("Unexpected CRUISE estimate: %+v", cruise)
+	}
+}
+
+func TestRefuelCost(t *testing.T) {
+	if got := RefuelCost(250, 72); got != 216 {
+		t.Errorf("Expected 3 units of FUEL for 216 credits, got %d", got)
+	}
+	if got := RefuelCost(0, 72); got != 0 {
+		t.Errorf("Expected nothing to buy for a full tank, got %d", got)
+	}
+}
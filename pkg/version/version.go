@@ -0,0 +1,82 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time with -ldflags, for example:
+//
+//	go build -ldflags "-X spacetraders-mcp/pkg/version.Version=v1.2.0 -X spacetraders-mcp/pkg/version.Commit=abc1234"
+//
+// Builds without them fall back to the module version and VCS details Go embeds in the binary.
+var (
+	// Version is the release the binary was built from
+	Version = ""
+	// Commit is the git revision the binary was built from
+	Commit = ""
+	// Date is when the binary was built, or when its commit was made
+	Date = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the build information, preferring values set at build time over what Go embedded
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+			info.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if len(info.Commit) > 12 {
+		info.Commit = info.Commit[:12]
+	}
+	return info
+}
+
+// String returns the version with its commit, such as "v1.2.0 (abc1234, modified)"
+func (i Info) String() string {
+	s := i.Version
+	switch {
+	case i.Commit != "" && i.Modified:
+		s += fmt.Sprintf(" (%s, modified)", i.Commit)
+	case i.Commit != "":
+		s += fmt.Sprintf(" (%s)", i.Commit)
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGet_BuildTimeValues(t *testing.T) {
+	defer func(version, commit, date string) {
+		Version, Commit, Date = version, commit, date
+	}(Version, Commit, Date)
+
+	Version, Commit, Date = "v1.2.0", "abc1234def5678", "2025-01-01T00:00:00Z"
+	info := Get()
+	if info.Version != "v1.2.0" || info.Commit != "abc1234def56" || info.Date != "2025-01-01T00:00:00Z" {
+		t.Errorf("Expected the build-time values with a short commit, got %+v", info)
+	}
+	if info.GoVersion != runtime.Version() || info.Platform != runtime.GOOS+"/"+runtime.GOARCH {
+		t.Errorf("Expected the Go version and platform, got %+v", info)
+	}
+}
+
+func TestGet_DefaultsToDev(t *testing.T) {
+	defer func(version string) { Version = version }(Version)
+
+	// Test binaries have no module version, so nothing overrides the fallback
+	Version = ""
+	if info := Get(); info.Version != "dev" {
+		t.Errorf("Expected dev, got %q", info.Version)
+	}
+}
+
+func TestInfo_String(t *testing.T) {
+	for _, tc := range []struct {
+		info Info
+		want string
+	}{
+		{Info{Version: "v1.2.0"}, "v1.2.0"},
+		{Info{Version: "v1.2.0", Commit: "abc1234"}, "v1.2.0 (abc1234)"},
+		{Info{Version: "dev", Commit: "abc1234", Modified: true}, "dev (abc1234, modified)"},
+	} {
+		if got := tc.info.String(); got != tc.want {
+			t.Errorf("Expected %q, got %q", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+)
+
+// queueSize bounds how many alerts may wait to be posted; more are dropped while the webhook is slow
+const queueSize = 100
+
+// postTimeout is how long a single webhook POST may take
+const postTimeout = 10 * time.Second
+
+// payload is accepted by both Slack and Discord incoming webhooks; each ignores the other's field
+type payload struct {
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// Notifier posts significant events to a webhook, so the user hears about them even when no
+// MCP client is attached: contracts fulfilled, ships arriving, tasks finishing and credits
+// running low
+type Notifier struct {
+	url        string
+	httpClient *http.Client
+	logger     *logging.Logger
+	ctx        context.Context
+	queue      chan string
+
+	mu         sync.Mutex
+	lowCredits int64
+	creditsLow bool
+	arrivals   map[string]*time.Timer
+}
+
+// New creates a notifier posting to url. Alerts are sent in the background until ctx is cancelled.
+func New(ctx context.Context, url string, logger *logging.Logger) *Notifier {
+	n := &Notifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: postTimeout},
+		logger:     logger,
+		ctx:        ctx,
+		queue:      make(chan string, queueSize),
+		arrivals:   make(map[string]*time.Timer),
+	}
+	go n.run()
+	return n
+}
+
+// WithLowCredits sets the balance below which a low credits alert is sent; 0 turns the alert off.
+// It is sent once each time the balance drops below the threshold.
+func (n *Notifier) WithLowCredits(threshold int64) *Notifier {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.lowCredits = threshold
+	return n
+}
+
+// Observe alerts on fulfilled contracts, ship arrivals and low credits; it is meant to be passed
+// to client.AddObserver
+func (n *Notifier) Observe(observation client.Observation) {
+	switch observation.Kind {
+	case client.ObservedContractFulfilled:
+		if contract := observation.Contract; contract != nil {
+			n.Send(fmt.Sprintf("✅ Contract %s for %s fulfilled, paying %d credits",
+				contract.ID, contract.FactionSymbol, contract.Terms.Payment.OnFulfilled))
+		}
+	case client.ObservedNavigation:
+		if nav := observation.Nav; nav != nil {
+			n.scheduleArrival(observation.ShipSymbol, *nav)
+		}
+	case client.ObservedAgent:
+		if agent := observation.Agent; agent != nil {
+			n.checkCredits(agent.Credits)
+		}
+	}
+}
+
+// TaskFinished alerts on a completed or failed background task; it is meant to be passed to
+// tasks.Manager.OnFinish
+func (n *Notifier) TaskFinished(task tasks.Task) {
+	switch task.Status {
+	case tasks.StatusCompleted:
+		n.Send(fmt.Sprintf("🏁 Task %s (%s) on %s completed: %s", task.ID, task.Behavior, task.ShipSymbol, task.LastMessage))
+	case tasks.StatusFailed:
+		n.Send(fmt.Sprintf("❌ Task %s (%s) on %s failed: %s", task.ID, task.Behavior, task.ShipSymbol, task.LastError))
+	}
+}
+
+// Send queues a message to be posted to the webhook. It never blocks; the message is dropped
+// when the queue is full.
+func (n *Notifier) Send(message string) {
+	select {
+	case n.queue <- message:
+	default:
+		n.logger.Warn("Webhook queue is full, dropping alert: %s", message)
+	}
+}
+
+// scheduleArrival alerts when a ship in transit reaches its destination. Any later navigation
+// of the ship replaces the pending alert, since it either rerouted or has already arrived.
+func (n *Notifier) scheduleArrival(shipSymbol string, nav client.Navigation) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if timer, pending := n.arrivals[shipSymbol]; pending {
+		timer.Stop()
+		delete(n.arrivals, shipSymbol)
+	}
+	if nav.Status != "IN_TRANSIT" {
+		return
+	}
+	arrival, err := time.Parse(time.RFC3339, nav.Route.Arrival)
+	if err != nil {
+		return
+	}
+
+	destination := nav.Route.Destination.Symbol
+	if destination == "" {
+		destination = nav.WaypointSymbol
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(arrival), func() {
+		n.mu.Lock()
+		current := n.arrivals[shipSymbol] == timer
+		if current {
+			delete(n.arrivals, shipSymbol)
+		}
+		n.mu.Unlock()
+
+		if current && n.ctx.Err() == nil {
+			n.Send(fmt.Sprintf("🛬 %s arrived at %s", shipSymbol, destination))
+		}
+	})
+	n.arrivals[shipSymbol] = timer
+}
+
+// checkCredits alerts the first time the balance is seen below the threshold, and again only
+// after it has recovered
+func (n *Notifier) checkCredits(credits int64) {
+	n.mu.Lock()
+	threshold := n.lowCredits
+	alert := threshold > 0 && credits < threshold && !n.creditsLow
+	n.creditsLow = threshold > 0 && credits < threshold
+	n.mu.Unlock()
+
+	if alert {
+		n.Send(fmt.Sprintf("⚠️ Credits are low: %d, below the %d credit alert threshold", credits, threshold))
+	}
+}
+
+// run posts queued messages until the context is cancelled, then stops pending arrival alerts
+func (n *Notifier) run() {
+	for {
+		select {
+		case <-n.ctx.Done():
+			n.mu.Lock()
+			for shipSymbol, timer := range n.arrivals {
+				timer.Stop()
+				delete(n.arrivals, shipSymbol)
+			}
+			n.mu.Unlock()
+			return
+		case message := <-n.queue:
+			if err := n.post(message); err != nil {
+				n.logger.Error("Failed to post webhook alert: %v", err)
+			}
+		}
+	}
+}
+
+// post sends one message to the webhook
+func (n *Notifier) post(message string) error {
+	body, err := json.Marshal(payload{Text: message, Content: message})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, postTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", response.Status)
+	}
+	return nil
+}
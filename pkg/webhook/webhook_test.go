@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spacetraders-mcp/pkg/client"
+	"spacetraders-mcp/pkg/logging"
+	"spacetraders-mcp/pkg/tasks"
+)
+
+// newTestNotifier returns a notifier whose webhook hands every posted payload to the returned channel
+func newTestNotifier(t *testing.T) (*Notifier, <-chan payload) {
+	t.Helper()
+
+	posted := make(chan payload, queueSize)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body payload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode webhook body: %v", err)
+		}
+		posted <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return New(ctx, server.URL, logging.NewLogger(nil)), posted
+}
+
+// next waits for the next posted alert
+func next(t *testing.T, posted <-chan payload) string {
+	t.Helper()
+	select {
+	case body := <-posted:
+		if body.Text != body.Content {
+			t.Errorf("Expected the Slack and Discord fields to match, got %+v", body)
+		}
+		return body.Text
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected an alert to be posted")
+		return ""
+	}
+}
+
+func TestNotifier_Alerts(t *testing.T) {
+	n, posted := newTestNotifier(t)
+	n.WithLowCredits(10000)
+
+	contract := client.Contract{ID: "contract-1", FactionSymbol: "COSMIC"}
+	contract.Terms.Payment.OnFulfilled = 50000
+	n.Observe(client.Observation{Kind: client.ObservedContractFulfilled, Contract: &contract})
+	if got := next(t, posted); !strings.Contains(got, "contract-1") || !strings.Contains(got, "50000") {
+		t.Errorf("Expected the fulfilled contract and its payment, got %q", got)
+	}
+
+	// Low credits alert once, and again only after the balance recovers
+	for _, credits := range []int64{20000, 9000, 8000, 12000, 5000} {
+		n.Observe(client.Observation{Kind: client.ObservedAgent, Agent: &client.Agent{Credits: credits}})
+	}
+	if got := next(t, posted); !strings.Contains(got, "9000") {
+		t.Errorf("Expected a low credits alert at 9000, got %q", got)
+	}
+	if got := next(t, posted); !strings.Contains(got, "5000") {
+		t.Errorf("Expected a second low credits alert at 5000, got %q", got)
+	}
+
+	n.TaskFinished(tasks.Task{ID: "task-1", Behavior: "mine_loop", ShipSymbol: "MINER-1", Status: tasks.StatusFailed, LastError: "ship not found"})
+	n.TaskFinished(tasks.Task{ID: "task-2", Behavior: "mine_loop", ShipSymbol: "MINER-2", Status: tasks.StatusCancelled})
+	if got := next(t, posted); !strings.Contains(got, "task-1") || !strings.Contains(got, "ship not found") {
+		t.Errorf("Expected the failed task, got %q", got)
+	}
+	select {
+	case body := <-posted:
+		t.Errorf("Expected no alert for a cancelled task, got %q", body.Text)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNotifier_ShipArrival(t *testing.T) {
+	n, posted := newTestNotifier(t)
+	inTransit := func(ship, destination string, arrival time.Time) client.Observation {
+		return client.Observation{Kind: client.ObservedNavigation, ShipSymbol: ship, Nav: &client.Navigation{
+			Status: "IN_TRANSIT",
+			Route:  client.Route{Destination: client.Waypoint{Symbol: destination}, Arrival: arrival.UTC().Format(time.RFC3339Nano)},
+		}}
+	}
+
+	// A rerouted ship only alerts for its new destination
+	n.Observe(inTransit("HAULER-1", "X1-TEST-A1", time.Now().Add(100*time.Millisecond)))
+	n.Observe(inTransit("HAULER-1", "X1-TEST-B2", time.Now().Add(150*time.Millisecond)))
+	if got := next(t, posted); !strings.Contains(got, "HAULER-1") || !strings.Contains(got, "X1-TEST-B2") {
+		t.Errorf("Expected HAULER-1 to arrive at X1-TEST-B2, got %q", got)
+	}
+
+	// Docking before the arrival time cancels the alert
+	n.Observe(inTransit("HAULER-2", "X1-TEST-C3", time.Now().Add(100*time.Millisecond)))
+	n.Observe(client.Observation{Kind: client.ObservedNavigation, ShipSymbol: "HAULER-2", Nav: &client.Navigation{Status: "DOCKED"}})
+	select {
+	case body := <-posted:
+		t.Errorf("Expected no further alerts, got %q", body.Text)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
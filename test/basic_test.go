@@ -2,9 +2,13 @@ package test
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -56,7 +60,7 @@ func TestBasic_ServerHelp(t *testing.T) {
 
 	// Test that the binary can be executed (even if it exits quickly)
 	cmd = exec.Command(binaryPath)
-	cmd.Env = []string{"SPACETRADERS_API_TOKEN=dummy-token-for-basic-test"}
+	cmd.Env = append([]string{"SPACETRADERS_API_TOKEN=dummy-token-for-basic-test", "SPACETRADERS_SKIP_TOKEN_CHECK=true"}, isolatedEnv...)
 
 	// Run with a timeout to avoid hanging
 	if err := cmd.Start(); err != nil {
@@ -133,6 +137,47 @@ func TestBasic_ResourcesListStructure(t *testing.T) {
 	t.Log("Resources list has correct structure")
 }
 
+// TestBasic_ResourceTemplatesList tests that parameterized resources are served as templates
+func TestBasic_ResourceTemplatesList(t *testing.T) {
+	response := callMCPServer(t, `{"jsonrpc": "2.0", "id": 1, "method": "resources/templates/list"}`)
+
+	var mcpResponse MCPResponse
+	if err := json.Unmarshal(response, &mcpResponse); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if mcpResponse.Error != nil {
+		t.Fatalf("Unexpected error in resources/templates/list: %v", mcpResponse.Error)
+	}
+
+	resultBytes, err := json.Marshal(mcpResponse.Result)
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+
+	var listResult struct {
+		ResourceTemplates []struct {
+			URITemplate string `json:"uriTemplate"`
+		} `json:"resourceTemplates"`
+	}
+	if err := json.Unmarshal(resultBytes, &listResult); err != nil {
+		t.Fatalf("Failed to parse resource templates list result: %v", err)
+	}
+
+	found := make(map[string]bool)
+	for _, template := range listResult.ResourceTemplates {
+		found[template.URITemplate] = true
+	}
+	for _, expected := range []string{
+		"spacetraders://ships/{shipSymbol}{?detail}",
+		"spacetraders://systems/{systemSymbol}/waypoints{?page,limit,cursor,detail,refresh}",
+	} {
+		if !found[expected] {
+			t.Errorf("Expected resource template %s not found", expected)
+		}
+	}
+}
+
 // TestBasic_MultipleRequests tests multiple sequential requests
 func TestBasic_MultipleRequests(t *testing.T) {
 	// Test that the server can handle multiple sequential requests
@@ -205,3 +250,53 @@ func TestBasic_ToolsListStructure(t *testing.T) {
 
 	t.Log("Tools list has correct structure")
 }
+
+// TestBasic_CommandLine tests calling a tool and reading a resource without an MCP host
+func TestBasic_CommandLine(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/my/agent" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"message": "Not found", "code": 404}}`))
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"data": {"symbol": "CLI_AGENT", "headquarters": "X1-TEST-A1", "credits": 4242, "startingFaction": "COSMIC", "shipCount": 1}}`)
+	}))
+	defer api.Close()
+
+	binaryPath := buildTestServer(t)
+	defer cleanupTestServer(t, binaryPath)
+
+	run := func(args ...string) (string, int) {
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Env = append(append(os.Environ(), isolatedEnv...),
+			"SPACETRADERS_API_TOKEN=dummy-token-for-basic-tests",
+			"SPACETRADERS_API_URL="+api.URL,
+			"SPACETRADERS_SKIP_TOKEN_CHECK=true",
+		)
+		output, err := cmd.Output()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return string(output), exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("Failed to run %v: %v", args, err)
+		}
+		return string(output), 0
+	}
+
+	output, code := run("read", "spacetraders://agent/info")
+	if code != 0 || !strings.Contains(output, "CLI_AGENT") {
+		t.Errorf("Expected the agent from read, got exit %d and %q", code, output)
+	}
+
+	output, code = run("call", "ping", "--json")
+	if code != 0 || !strings.Contains(output, "CLI_AGENT") || !strings.Contains(output, `"content"`) {
+		t.Errorf("Expected the agent from ping as JSON, got exit %d and %q", code, output)
+	}
+
+	if _, code = run("call", "refresh_ship", "--args", `{"ship_symbol": "NOPE-1"}`); code != 1 {
+		t.Errorf("Expected exit 1 for a tool error, got %d", code)
+	}
+	if _, code = run("call", "ping", "--args", "not json"); code != 2 {
+		t.Errorf("Expected exit 2 for invalid arguments, got %d", code)
+	}
+}
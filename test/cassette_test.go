@@ -0,0 +1,83 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"spacetraders-mcp/pkg/config"
+	"spacetraders-mcp/test/vcr"
+)
+
+// Helper function to call the MCP server with a request, serving the API from a cassette in
+// testdata/cassettes. SPACETRADERS_VCR selects the mode: "replay" (the default) needs no token,
+// "record" re-records the cassette against the live API, and "off" calls the live API directly.
+func callMCPServerWithCassette(t *testing.T, name, request string) []byte {
+	mode := vcr.Mode(os.Getenv("SPACETRADERS_VCR"))
+	if mode == "" {
+		mode = vcr.ModeReplay
+	}
+	if mode == "off" {
+		checkAPITokenAvailable(t)
+		return callMCPServer(t, request)
+	}
+
+	cassettePath := filepath.Join(getProjectRoot(t), "test", "testdata", "cassettes", name+".json")
+	token := "vcr-replay-token"
+	if mode == vcr.ModeRecord {
+		checkAPITokenAvailable(t)
+		cfg := loadTestConfig(t)
+		token = cfg.SpaceTradersAPIToken
+	} else if _, err := os.Stat(cassettePath); os.IsNotExist(err) {
+		t.Skipf("Cassette %s not recorded yet; run with SPACETRADERS_VCR=record", name)
+	}
+
+	recorder, err := vcr.New(cassettePath, mode, "https://api.spacetraders.io/v2")
+	if err != nil {
+		t.Fatalf("Failed to load cassette: %v", err)
+	}
+	apiServer := httptest.NewServer(recorder)
+	defer apiServer.Close()
+
+	response := callMCPServerWithEnv(t, []string{
+		"SPACETRADERS_API_TOKEN=" + token,
+		"SPACETRADERS_API_URL=" + apiServer.URL,
+	}, request)
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Failed to save cassette: %v", err)
+	}
+	if misses := recorder.Misses(); len(misses) > 0 {
+		t.Errorf("Requests missing from cassette %s (re-record with SPACETRADERS_VCR=record): %v", name, misses)
+	}
+
+	return response
+}
+
+// Helper function to load the configuration from the project root
+func loadTestConfig(t *testing.T) *config.Config {
+	projectRoot := getProjectRoot(t)
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalWd); err != nil {
+			t.Errorf("Failed to restore working directory: %v", err)
+		}
+	}()
+
+	if err := os.Chdir(projectRoot); err != nil {
+		t.Fatalf("Failed to change to project root: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	return cfg
+}
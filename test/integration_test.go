@@ -81,7 +81,7 @@ func TestIntegration_ServerStartup(t *testing.T) {
 	serverCmd := exec.Command(binaryPath)
 
 	// Use the real API token
-	serverCmd.Env = os.Environ()
+	serverCmd.Env = append(os.Environ(), isolatedEnv...)
 	stdin, err := serverCmd.StdinPipe()
 	if err != nil {
 		t.Fatalf("Failed to create stdin pipe: %v", err)
@@ -201,9 +201,7 @@ func TestIntegration_ServerStartup(t *testing.T) {
 }
 
 func TestIntegration_AgentResource(t *testing.T) {
-	checkAPITokenAvailable(t)
-
-	response := callMCPServer(t, `{"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": {"uri": "spacetraders://agent/info"}}`)
+	response := callMCPServerWithCassette(t, "agent_resource", `{"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": {"uri": "spacetraders://agent/info"}}`)
 
 	var mcpResponse MCPResponse
 	if err := json.Unmarshal(response, &mcpResponse); err != nil {
@@ -254,7 +252,7 @@ func TestIntegration_AgentResource(t *testing.T) {
 		t.Fatalf("Failed to parse agent JSON: %v", err)
 	}
 
-	agent, ok := agentData["agent"].(map[string]interface{})
+	agent, ok := agentData["data"].(map[string]interface{})
 	if !ok {
 		t.Fatal("Expected agent object in response")
 	}
@@ -317,7 +315,7 @@ func TestIntegration_ShipsResource(t *testing.T) {
 		t.Fatalf("Failed to parse ships JSON: %v", err)
 	}
 
-	ships, ok := shipsData["ships"].([]interface{})
+	ships, ok := shipsData["data"].([]interface{})
 	if !ok {
 		t.Fatal("Expected ships array in response")
 	}
@@ -381,7 +379,7 @@ func TestIntegration_IndividualShipResource(t *testing.T) {
 		t.Fatalf("Failed to parse ships JSON: %v", err)
 	}
 
-	ships, ok := shipsData["ships"].([]interface{})
+	ships, ok := shipsData["data"].([]interface{})
 	if !ok {
 		t.Fatal("Expected ships array in response")
 	}
@@ -441,10 +439,14 @@ func TestIntegration_IndividualShipResource(t *testing.T) {
 	}
 
 	// Parse the JSON content to verify structure
-	var shipData map[string]interface{}
-	if err := json.Unmarshal([]byte(shipContent.Text), &shipData); err != nil {
+	var shipEnvelope struct {
+		Data map[string]interface{} `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(shipContent.Text), &shipEnvelope); err != nil {
 		t.Fatalf("Failed to parse ship JSON: %v", err)
 	}
+	shipData := shipEnvelope.Data
 
 	// Verify expected structure
 	if _, ok := shipData["ship"]; !ok {
@@ -463,7 +465,7 @@ func TestIntegration_IndividualShipResource(t *testing.T) {
 		t.Error("Expected 'recommendations' field in response")
 	}
 
-	if _, ok := shipData["meta"]; !ok {
+	if shipEnvelope.Meta == nil {
 		t.Error("Expected 'meta' field in response")
 	}
 
@@ -525,7 +527,7 @@ func TestIntegration_ShipCooldownResource(t *testing.T) {
 		t.Fatalf("Failed to parse ships JSON: %v", err)
 	}
 
-	ships, ok := shipsData["ships"].([]interface{})
+	ships, ok := shipsData["data"].([]interface{})
 	if !ok {
 		t.Fatal("Expected ships array in response")
 	}
@@ -585,10 +587,14 @@ func TestIntegration_ShipCooldownResource(t *testing.T) {
 	}
 
 	// Parse the JSON content to verify structure
-	var cooldownData map[string]interface{}
-	if err := json.Unmarshal([]byte(cooldownContent.Text), &cooldownData); err != nil {
+	var cooldownEnvelope struct {
+		Data map[string]interface{} `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(cooldownContent.Text), &cooldownEnvelope); err != nil {
 		t.Fatalf("Failed to parse cooldown JSON: %v", err)
 	}
+	cooldownData := cooldownEnvelope.Data
 
 	// Verify expected structure
 	if _, ok := cooldownData["ship_symbol"]; !ok {
@@ -611,7 +617,7 @@ func TestIntegration_ShipCooldownResource(t *testing.T) {
 		t.Error("Expected 'recommendations' field in response")
 	}
 
-	if _, ok := cooldownData["meta"]; !ok {
+	if cooldownEnvelope.Meta == nil {
 		t.Error("Expected 'meta' field in response")
 	}
 
@@ -724,9 +730,7 @@ func TestIntegration_ShipResourceInvalidURI(t *testing.T) {
 }
 
 func TestIntegration_ContractsResource(t *testing.T) {
-	checkAPITokenAvailable(t)
-
-	response := callMCPServer(t, `{"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": {"uri": "spacetraders://contracts/list"}}`)
+	response := callMCPServerWithCassette(t, "contracts_resource", `{"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": {"uri": "spacetraders://contracts/list"}}`)
 
 	var mcpResponse MCPResponse
 	if err := json.Unmarshal(response, &mcpResponse); err != nil {
@@ -773,7 +777,7 @@ func TestIntegration_ContractsResource(t *testing.T) {
 		t.Fatalf("Failed to parse contracts JSON: %v", err)
 	}
 
-	contracts, ok := contractsData["contracts"].([]interface{})
+	contracts, ok := contractsData["data"].([]interface{})
 	if !ok {
 		t.Fatal("Expected contracts array in response")
 	}
@@ -824,7 +828,7 @@ func TestIntegration_ServerShutdownGraceful(t *testing.T) {
 	serverCmd := exec.Command(binaryPath)
 
 	// Use the real API token
-	serverCmd.Env = os.Environ()
+	serverCmd.Env = append(os.Environ(), isolatedEnv...)
 	stdin, err := serverCmd.StdinPipe()
 	if err != nil {
 		t.Fatalf("Failed to create stdin pipe: %v", err)
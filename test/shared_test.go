@@ -89,13 +89,6 @@ func cleanupTestServer(t *testing.T, binaryPath string) {
 
 // Helper function to call the MCP server with a request
 func callMCPServer(t *testing.T, request string) []byte {
-	// Build the server first
-	binaryPath := buildTestServer(t)
-	defer cleanupTestServer(t, binaryPath)
-
-	// Start the server
-	serverCmd := exec.Command(binaryPath)
-
 	// Use the real API token if available, otherwise dummy token for basic tests
 	// Try to load config from project root to check for .env file
 	projectRoot := getProjectRoot(t)
@@ -116,12 +109,32 @@ func callMCPServer(t *testing.T, request string) []byte {
 	// Check if we can load a valid config (which means token is available)
 	if cfg, err := config.Load(); err == nil && cfg.SpaceTradersAPIToken != "" {
 		// Token is available via config system, set it as environment variable for subprocess
-		serverCmd.Env = append(os.Environ(), "SPACETRADERS_API_TOKEN="+cfg.SpaceTradersAPIToken)
-	} else {
-		// No valid token, use dummy token for basic tests
-		serverCmd.Env = append(os.Environ(), "SPACETRADERS_API_TOKEN=dummy-token-for-basic-tests")
+		return callMCPServerWithEnv(t, []string{"SPACETRADERS_API_TOKEN=" + cfg.SpaceTradersAPIToken}, request)
 	}
 
+	// No valid token, use dummy token for basic tests and skip the startup token check
+	return callMCPServerWithEnv(t, []string{"SPACETRADERS_API_TOKEN=dummy-token-for-basic-tests", "SPACETRADERS_SKIP_TOKEN_CHECK=true"}, request)
+}
+
+// isolatedEnv turns off the files the server keeps state in between sessions, so test runs
+// neither read nor overwrite a developer's checkpoint, exploration progress, ship labels or exports
+var isolatedEnv = []string{
+	"SPACETRADERS_CHECKPOINT_FILE=off",
+	"SPACETRADERS_EXPLORATION_FILE=off",
+	"SPACETRADERS_SHIP_METADATA_FILE=off",
+	"SPACETRADERS_EXPORT_DIR=off",
+}
+
+// Helper function to call the MCP server with a request, adding env to the server's environment
+func callMCPServerWithEnv(t *testing.T, env []string, request string) []byte {
+	// Build the server first
+	binaryPath := buildTestServer(t)
+	defer cleanupTestServer(t, binaryPath)
+
+	// Start the server
+	serverCmd := exec.Command(binaryPath)
+	serverCmd.Env = append(append(os.Environ(), isolatedEnv...), env...)
+
 	stdin, err := serverCmd.StdinPipe()
 	if err != nil {
 		t.Fatalf("Failed to create stdin pipe: %v", err)
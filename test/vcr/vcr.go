@@ -0,0 +1,284 @@
+// Package vcr records SpaceTraders API traffic to cassette files and replays it, so the
+// MCP server can be exercised end to end without a live token.
+//
+// A Recorder is an http.Handler that stands in for the API: point the server at it with
+// SPACETRADERS_API_URL. In record mode it forwards requests to the real API and keeps the
+// responses; in replay mode it answers from the cassette. Interactions are matched by
+// method, path and body. Tokens and the agent's symbol are removed before a cassette is saved.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the real API
+type Mode string
+
+const (
+	// ModeReplay answers every request from the cassette
+	ModeReplay Mode = "replay"
+	// ModeRecord forwards requests to the real API and saves the responses to the cassette
+	ModeRecord Mode = "record"
+)
+
+// Placeholders written to cassettes in place of sensitive values
+const (
+	TokenPlaceholder     = "REDACTED-TOKEN"
+	AgentPlaceholder     = "VCR-AGENT"
+	AccountIDPlaceholder = "vcr-account"
+)
+
+// Cassette is the recorded traffic of one test
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response the API gave to it
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request identifies a recorded request
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body"`
+}
+
+// key matches a request to its recorded interactions
+func (r Request) key() string {
+	return r.Method + " " + r.Path + " " + r.Body
+}
+
+// Recorder serves API requests from a cassette, or records them from upstream
+type Recorder struct {
+	path     string
+	mode     Mode
+	upstream string
+	client   *http.Client
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed map[string]int
+	secrets  map[string]string
+	misses   []string
+}
+
+// New creates a recorder for the cassette at path. In replay mode the cassette must exist;
+// in record mode requests are forwarded to upstream, the real API base URL.
+func New(path string, mode Mode, upstream string) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		upstream: strings.TrimSuffix(upstream, "/"),
+		client:   &http.Client{},
+		replayed: make(map[string]int),
+		secrets:  make(map[string]string),
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+	}
+
+	return r, nil
+}
+
+// ServeHTTP answers an API request
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recorded := Request{Method: req.Method, Path: req.URL.RequestURI(), Body: normalizeBody(body)}
+
+	var resp Response
+	if r.mode == ModeRecord {
+		resp, err = r.forward(req, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		r.mu.Lock()
+		r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: recorded, Response: resp})
+		r.mu.Unlock()
+	} else {
+		var ok bool
+		if resp, ok = r.replay(recorded); !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotImplemented)
+			_, _ = fmt.Fprintf(w, `{"error": {"message": %q, "code": 0}}`, "no recorded interaction for "+recorded.Method+" "+recorded.Path)
+			return
+		}
+	}
+
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	w.WriteHeader(resp.Status)
+	_, _ = io.WriteString(w, resp.Body)
+}
+
+// replay finds the recorded response to a request. Repeated requests get the recorded
+// responses in order, and the last one again once they run out.
+func (r *Recorder) replay(req Request) (Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []Response
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Request.key() == req.key() {
+			matches = append(matches, interaction.Response)
+		}
+	}
+	if len(matches) == 0 {
+		r.misses = append(r.misses, req.Method+" "+req.Path)
+		return Response{}, false
+	}
+
+	i := r.replayed[req.key()]
+	r.replayed[req.key()] = i + 1
+	if i >= len(matches) {
+		i = len(matches) - 1
+	}
+	return matches[i], true
+}
+
+// forward sends a request to the real API and learns the secrets in it to sanitize later
+func (r *Recorder) forward(req *http.Request, body []byte) (Response, error) {
+	upstreamReq, err := http.NewRequestWithContext(req.Context(), req.Method, r.upstream+req.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		return Response{}, err
+	}
+	upstreamReq.Header = req.Header.Clone()
+
+	if token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "); token != "" {
+		r.addSecret(token, TokenPlaceholder)
+	}
+
+	upstreamResp, err := r.client.Do(upstreamReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to reach upstream API: %w", err)
+	}
+	defer upstreamResp.Body.Close()
+
+	respBody, err := io.ReadAll(upstreamResp.Body)
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to read upstream response: %w", err)
+	}
+
+	var agent struct {
+		Data struct {
+			AccountID string `json:"accountId"`
+			Symbol    string `json:"symbol"`
+		} `json:"data"`
+	}
+	if req.URL.Path == "/my/agent" && json.Unmarshal(respBody, &agent) == nil {
+		r.addSecret(agent.Data.AccountID, AccountIDPlaceholder)
+		r.addSecret(agent.Data.Symbol, AgentPlaceholder)
+	}
+
+	return Response{
+		Status:      upstreamResp.StatusCode,
+		ContentType: upstreamResp.Header.Get("Content-Type"),
+		Body:        string(respBody),
+	}, nil
+}
+
+// addSecret remembers a value to replace when the cassette is saved
+func (r *Recorder) addSecret(value, placeholder string) {
+	if value == "" {
+		return
+	}
+	r.mu.Lock()
+	r.secrets[value] = placeholder
+	r.mu.Unlock()
+}
+
+// Misses returns the requests that had no recorded interaction during replay
+func (r *Recorder) Misses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.misses...)
+}
+
+// Save sanitizes the recorded interactions and writes them to the cassette. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	cassette := Cassette{Interactions: make([]Interaction, len(r.cassette.Interactions))}
+	for i, interaction := range r.cassette.Interactions {
+		interaction.Request.Path = sanitize(interaction.Request.Path, r.secrets)
+		interaction.Request.Body = sanitize(interaction.Request.Body, r.secrets)
+		interaction.Response.Body = sanitize(interaction.Response.Body, r.secrets)
+		cassette.Interactions[i] = interaction
+	}
+	r.mu.Unlock()
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// tokenField matches token values in JSON bodies, such as the one returned on registration
+var tokenField = regexp.MustCompile(`"token"\s*:\s*"[^"]*"`)
+
+// sanitize replaces tokens and the agent's identity with placeholders. Longer secrets are
+// replaced first, so a secret containing another is replaced whole.
+func sanitize(text string, secrets map[string]string) string {
+	text = tokenField.ReplaceAllString(text, `"token": "`+TokenPlaceholder+`"`)
+
+	values := make([]string, 0, len(secrets))
+	for value := range secrets {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, secrets[value])
+	}
+	return text
+}
+
+// normalizeBody compacts JSON bodies so formatting differences don't prevent a match
+func normalizeBody(body []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, body); err == nil {
+		return buf.String()
+	}
+	return string(body)
+}
@@ -0,0 +1,130 @@
+package vcr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordSanitizeReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			t.Errorf("Expected the token to be forwarded, got %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my/agent":
+			_, _ = io.WriteString(w, `{"data": {"accountId": "acct-123", "symbol": "REAL_AGENT", "credits": 100}}`)
+		case "/my/ships/REAL_AGENT-1/orbit":
+			_, _ = io.WriteString(w, `{"data": {"nav": {"status": "IN_ORBIT"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := New(path, ModeRecord, upstream.URL)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	server := httptest.NewServer(recorder)
+	get(t, server.URL+"/my/agent", "secret-token")
+	post(t, server.URL+"/my/ships/REAL_AGENT-1/orbit", "secret-token", `{ "ok": true }`)
+	server.Close()
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read cassette: %v", err)
+	}
+	for _, secret := range []string{"secret-token", "REAL_AGENT", "acct-123"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %q to be sanitized from the cassette:\n%s", secret, data)
+		}
+	}
+
+	replayer, err := New(path, ModeReplay, "")
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	server = httptest.NewServer(replayer)
+	defer server.Close()
+
+	if body := get(t, server.URL+"/my/agent", "any-token"); !strings.Contains(body, AgentPlaceholder) {
+		t.Errorf("Expected replayed agent to use the placeholder symbol, got %s", body)
+	}
+	if body := post(t, server.URL+"/my/ships/"+AgentPlaceholder+"-1/orbit", "any-token", `{"ok":true}`); !strings.Contains(body, "IN_ORBIT") {
+		t.Errorf("Expected replayed orbit response, got %s", body)
+	}
+	if len(replayer.Misses()) != 0 {
+		t.Errorf("Expected no misses, got %v", replayer.Misses())
+	}
+
+	get(t, server.URL+"/my/contracts", "any-token")
+	if misses := replayer.Misses(); len(misses) != 1 || misses[0] != "GET /my/contracts" {
+		t.Errorf("Expected the unrecorded request to be a miss, got %v", misses)
+	}
+}
+
+func TestRecorder_ReplayRepeatsInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := `{"interactions": [
+		{"request": {"method": "GET", "path": "/my/agent"}, "response": {"status": 200, "body": "first"}},
+		{"request": {"method": "GET", "path": "/my/agent"}, "response": {"status": 200, "body": "second"}}
+	]}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatalf("Failed to write cassette: %v", err)
+	}
+
+	replayer, err := New(path, ModeReplay, "")
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	server := httptest.NewServer(replayer)
+	defer server.Close()
+
+	for _, want := range []string{"first", "second", "second"} {
+		if body := get(t, server.URL+"/my/agent", ""); body != want {
+			t.Errorf("Expected %q, got %q", want, body)
+		}
+	}
+}
+
+func get(t *testing.T, url, token string) string {
+	t.Helper()
+	return do(t, http.MethodGet, url, token, "")
+}
+
+func post(t *testing.T, url, token, body string) string {
+	t.Helper()
+	return do(t, http.MethodPost, url, token, body)
+}
+
+func do(t *testing.T, method, url, token, body string) string {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	return string(data)
+}